@@ -504,6 +504,80 @@ func TestImportInMultiServerTenant(t *testing.T) {
 	t2.CheckQueryResults(t, "SELECT * FROM foo", [][]string{{"10", "2"}, {"11", "22"}, {"33", "44"}, {"55", "66"}})
 }
 
+// TestImportPgDumpPartitioned tests that the partitions of PostgreSQL
+// partitioned tables are imported as partitions of the primary index of their
+// parent table.
+func TestImportPgDumpPartitioned(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := base.TestServerArgs{ExternalIODir: sharedTestdata(t)}
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{ServerArgs: args})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+	sqlDB.Exec(t, `IMPORT PGDUMP 'nodelocal://0/pgdump/partitioned.sql'`)
+
+	sqlDB.CheckQueryResults(t, `SELECT table_name FROM [SHOW TABLES] ORDER BY table_name`,
+		[][]string{{"cities"}, {"measurement"}})
+	sqlDB.CheckQueryResults(t, `SELECT create_statement FROM [SHOW CREATE TABLE measurement]`,
+		[][]string{{`CREATE TABLE public.measurement (
+	city_id INT8 NOT NULL,
+	logdate DATE NOT NULL,
+	peaktemp INT8 NULL,
+	CONSTRAINT measurement_pkey PRIMARY KEY (logdate ASC, city_id ASC),
+	INDEX measurement_city_idx (city_id ASC)
+) PARTITION BY RANGE (logdate) (
+	PARTITION measurement_y2006m03 VALUES FROM ('2006-03-01') TO ('2006-04-01'),
+	PARTITION measurement_y2006m02 VALUES FROM ('2006-02-01') TO ('2006-03-01')
+)
+-- Warning: Partitioned table with no zone configurations.`}})
+	sqlDB.CheckQueryResults(t, `SELECT create_statement FROM [SHOW CREATE TABLE cities]`,
+		[][]string{{`CREATE TABLE public.cities (
+	region STRING NOT NULL,
+	name STRING NOT NULL,
+	CONSTRAINT cities_pkey PRIMARY KEY (region ASC, name ASC)
+) PARTITION BY LIST (region) (
+	PARTITION cities_west VALUES IN (('ca'), ('or')),
+	PARTITION cities_other VALUES IN ((DEFAULT))
+)
+-- Warning: Partitioned table with no zone configurations.`}})
+
+	sqlDB.CheckQueryResults(t, `SELECT city_id, logdate::STRING, peaktemp FROM measurement ORDER BY logdate, city_id`,
+		[][]string{
+			{"1", "2006-02-01", "10"},
+			{"2", "2006-02-01", "12"},
+			{"1", "2006-03-15", "15"},
+		})
+	sqlDB.CheckQueryResults(t, `SELECT * FROM cities ORDER BY region, name`,
+		[][]string{
+			{"ca", "los angeles"},
+			{"ca", "san francisco"},
+			{"ny", "new york"},
+			{"or", "portland"},
+			{"tx", "austin"},
+		})
+
+	// The parent table of a partition must be defined in the dump.
+	sqlDB.ExpectErr(t, `parent table public.measurement of partition public.measurement_y2006m02 does not exist`,
+		`IMPORT PGDUMP 'nodelocal://0/pgdump/partition_missing_parent.sql'`)
+
+	// DEFAULT partitions of range partitioned tables are unsupported. If they
+	// are ignored, their rows are imported into the unpartitioned remainder of
+	// the parent table.
+	sqlDB.ExpectErr(t, `unsupported DEFAULT partition public.events_default of range partitioned table public.events`,
+		`IMPORT PGDUMP 'nodelocal://0/pgdump/partition_range_default.sql'`)
+	sqlDB.Exec(t, `IMPORT PGDUMP 'nodelocal://0/pgdump/partition_range_default.sql' WITH ignore_unsupported_statements`)
+	sqlDB.CheckQueryResults(t, `SELECT partition_name FROM [SHOW PARTITIONS FROM TABLE events]`,
+		[][]string{{"events_2006"}})
+	sqlDB.CheckQueryResults(t, `SELECT id, ts::STRING FROM events ORDER BY ts`,
+		[][]string{
+			{"1", "2006-05-01"},
+			{"2", "2007-01-15"},
+		})
+}
+
 func putUserfile(
 	ctx context.Context, conn *gosql.DB, user username.SQLUsername, uri string, content []byte,
 ) error {
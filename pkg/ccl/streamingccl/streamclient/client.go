@@ -49,6 +49,11 @@ type Client interface {
 	// can be used to interact with this stream in the future.
 	Create(ctx context.Context, tenant roachpb.TenantName) (streampb.ReplicationProducerSpec, error)
 
+	// CreateForPublication is like Create, but initializes a stream of the
+	// tables of a publication of the database named by the address of the
+	// source.
+	CreateForPublication(ctx context.Context, publication string) (streampb.ReplicationProducerSpec, error)
+
 	// Dial checks if the source is able to be connected to for queries
 	Dial(ctx context.Context) error
 
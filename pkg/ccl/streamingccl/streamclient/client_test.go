@@ -47,6 +47,16 @@ func (sc testStreamClient) Create(
 	}, nil
 }
 
+// CreateForPublication implements the Client interface.
+func (sc testStreamClient) CreateForPublication(
+	_ context.Context, _ string,
+) (streampb.ReplicationProducerSpec, error) {
+	return streampb.ReplicationProducerSpec{
+		StreamID:             streampb.StreamID(1),
+		ReplicationStartTime: hlc.Timestamp{WallTime: timeutil.Now().UnixNano()},
+	}, nil
+}
+
 // Plan implements the Client interface.
 func (sc testStreamClient) Plan(_ context.Context, _ streampb.StreamID) (Topology, error) {
 	return Topology{
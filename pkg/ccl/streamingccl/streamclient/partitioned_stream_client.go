@@ -94,6 +94,29 @@ func (p *partitionedStreamClient) Create(
 	return replicationProducerSpec, err
 }
 
+// CreateForPublication implements Client interface.
+func (p *partitionedStreamClient) CreateForPublication(
+	ctx context.Context, publication string,
+) (streampb.ReplicationProducerSpec, error) {
+	ctx, sp := tracing.ChildSpan(ctx, "streamclient.Client.CreateForPublication")
+	defer sp.Finish()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var rawReplicationProducerSpec []byte
+	row := p.mu.srcConn.QueryRow(ctx, `SELECT crdb_internal.start_replication_stream_for_publication($1)`, publication)
+	err := row.Scan(&rawReplicationProducerSpec)
+	if err != nil {
+		return streampb.ReplicationProducerSpec{}, errors.Wrapf(err, "error creating replication stream for publication %s", publication)
+	}
+	var replicationProducerSpec streampb.ReplicationProducerSpec
+	if err := protoutil.Unmarshal(rawReplicationProducerSpec, &replicationProducerSpec); err != nil {
+		return streampb.ReplicationProducerSpec{}, err
+	}
+
+	return replicationProducerSpec, err
+}
+
 // Dial implements Client interface.
 func (p *partitionedStreamClient) Dial(ctx context.Context) error {
 	p.mu.Lock()
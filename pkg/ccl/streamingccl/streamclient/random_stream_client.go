@@ -391,6 +391,13 @@ func (m *RandomStreamClient) Create(
 	}, nil
 }
 
+// CreateForPublication implements the Client interface.
+func (m *RandomStreamClient) CreateForPublication(
+	ctx context.Context, publication string,
+) (streampb.ReplicationProducerSpec, error) {
+	return streampb.ReplicationProducerSpec{}, errors.New("random stream client does not support publications")
+}
+
 // Heartbeat implements the Client interface.
 func (m *RandomStreamClient) Heartbeat(
 	ctx context.Context, _ streampb.StreamID, ts hlc.Timestamp,
@@ -0,0 +1,280 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package streamingest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/streamingccl"
+	"github.com/cockroachdb/cockroach/pkg/ccl/streamingccl/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/errors"
+)
+
+// logicalReplicationResumer is the resumer of the job of a subscription. It
+// subscribes to all the partitions of the replication stream of the
+// publication on the source cluster, and applies the row changes to the
+// destination tables with a logicalReplicationWriter.
+//
+// The source table descriptors are captured when the subscription is created,
+// so schema changes to the published tables are not supported.
+type logicalReplicationResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &logicalReplicationResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *logicalReplicationResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	jobExecCtx := execCtx.(sql.JobExecContext)
+	ro := retry.Options{
+		InitialBackoff: 3 * time.Second,
+		Multiplier:     2,
+		MaxBackoff:     1 * time.Minute,
+		MaxRetries:     60,
+	}
+
+	var err error
+	for retrier := retry.Start(ro); retrier.Next(); {
+		err = r.replicate(ctx, jobExecCtx)
+		if err == nil {
+			break
+		}
+		// All errors are retryable unless they are marked as permanent job
+		// errors, e.g. a conflict with the ERROR conflict resolution, or if
+		// the job is being paused or canceled.
+		if jobs.IsPermanentJobError(err) || errors.Is(err, context.Canceled) {
+			break
+		}
+		const msgFmt = "logical replication waits for retrying after error %s"
+		log.Warningf(ctx, msgFmt, err)
+		updateRunningStatus(ctx, r.job, fmt.Sprintf(msgFmt, err))
+	}
+	return err
+}
+
+// replicate applies the changes of the replication stream until an error
+// occurs, starting from the last checkpoint of the job.
+func (r *logicalReplicationResumer) replicate(
+	ctx context.Context, execCtx sql.JobExecContext,
+) error {
+	details := r.job.Details().(jobspb.LogicalReplicationDetails)
+	jobProgress := r.job.Progress()
+	progress := jobProgress.GetLogicalReplication()
+	streamID := streampb.StreamID(details.StreamID)
+	replicatedTime := progress.ReplicatedTime
+
+	client, err := streamclient.NewStreamClient(ctx, streamingccl.StreamAddress(details.StreamAddress))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.Close(ctx); err != nil {
+			log.Warningf(ctx, "encountered error when closing the stream client: %v", err)
+		}
+	}()
+	if err := waitUntilProducerActive(ctx, client, streamID, replicatedTime, r.job.ID()); err != nil {
+		return err
+	}
+	topology, err := client.Plan(ctx, streamID)
+	if err != nil {
+		return err
+	}
+
+	var spans []roachpb.Span
+	for _, partition := range topology.Partitions {
+		spans = append(spans, partition.Spans...)
+	}
+	frontier, err := span.MakeFrontier(spans...)
+	if err != nil {
+		return err
+	}
+	for _, resolvedSpan := range progress.Checkpoint.ResolvedSpans {
+		if _, err := frontier.Forward(resolvedSpan.Span, resolvedSpan.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	user := r.job.Payload().UsernameProto.Decode()
+	g := ctxgroup.WithContext(ctx)
+	for _, partition := range topology.Partitions {
+		writer, err := newLogicalReplicationWriter(
+			ctx, execCtx.ExecCfg(), user, details, topology.SourceTenantID,
+		)
+		if err != nil {
+			return err
+		}
+		sub, err := client.Subscribe(
+			ctx, streamID, partition.SubscriptionToken, details.ReplicationStartTime, replicatedTime,
+		)
+		if err != nil {
+			return err
+		}
+		g.GoCtx(sub.Subscribe)
+		g.GoCtx(func(ctx context.Context) error {
+			return consumePartition(ctx, writer, sub, frontier)
+		})
+	}
+	g.GoCtx(func(ctx context.Context) error {
+		return r.checkpointLoop(ctx, execCtx, client, streamID, frontier)
+	})
+	return g.Wait()
+}
+
+// consumePartition applies the events of the subscription to a partition in
+// order, and forwards the frontier once the events preceding a checkpoint
+// have been applied.
+func consumePartition(
+	ctx context.Context,
+	writer *logicalReplicationWriter,
+	sub streamclient.Subscription,
+	frontier *span.Frontier,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			switch event.Type() {
+			case streamingccl.KVEvent:
+				if err := writer.applyKV(ctx, *event.GetKV()); err != nil {
+					return err
+				}
+			case streamingccl.SSTableEvent:
+				if err := writer.applySST(ctx, event.GetSSTable()); err != nil {
+					return err
+				}
+			case streamingccl.DeleteRangeEvent:
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"range deletions are not supported by logical replication")
+			case streamingccl.CheckpointEvent:
+				// The buffered changes must be applied before the frontier is
+				// forwarded past them.
+				if err := writer.flush(ctx); err != nil {
+					return err
+				}
+				for _, resolvedSpan := range event.GetResolvedSpans() {
+					if _, err := frontier.Forward(resolvedSpan.Span, resolvedSpan.Timestamp); err != nil {
+						return err
+					}
+				}
+			default:
+				return errors.AssertionFailedf("unexpected event type %v", event.Type())
+			}
+		}
+	}
+}
+
+// checkpointLoop periodically records the frontier in the progress of the job,
+// and heartbeats the producer job with it, which allows the source cluster to
+// release the history of the published tables older than the frontier.
+func (r *logicalReplicationResumer) checkpointLoop(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	client streamclient.Client,
+	streamID streampb.StreamID,
+	frontier *span.Frontier,
+) error {
+	sv := &execCtx.ExecCfg().Settings.SV
+	// The progress of the job is not updated if the checkpoint frequency is 0,
+	// but the producer job is still heartbeated.
+	interval := func() time.Duration {
+		if freq := JobCheckpointFrequency.Get(sv); freq > 0 {
+			return freq
+		}
+		return streamingccl.StreamReplicationConsumerHeartbeatFrequency.Get(sv)
+	}
+	timer := time.NewTimer(interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			timer.Reset(interval())
+		}
+
+		replicatedTime := frontier.Frontier()
+		if JobCheckpointFrequency.Get(sv) > 0 {
+			var resolvedSpans []jobspb.ResolvedSpan
+			frontier.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+				resolvedSpans = append(resolvedSpans, jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
+				return span.ContinueMatch
+			})
+			if err := r.job.Update(ctx, nil /* txn */, func(
+				txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+			) error {
+				if err := md.CheckRunningOrReverting(); err != nil {
+					return err
+				}
+				progress := md.Progress.GetLogicalReplication()
+				progress.Checkpoint.ResolvedSpans = resolvedSpans
+				progress.ReplicatedTime = replicatedTime
+				if !replicatedTime.IsEmpty() {
+					md.Progress.Progress = &jobspb.Progress_HighWater{HighWater: &replicatedTime}
+				}
+				ju.UpdateProgress(md.Progress)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		status, err := client.Heartbeat(ctx, streamID, replicatedTime)
+		if err != nil {
+			return err
+		}
+		switch status.StreamStatus {
+		case streampb.StreamReplicationStatus_STREAM_ACTIVE,
+			streampb.StreamReplicationStatus_UNKNOWN_STREAM_STATUS_RETRY:
+		default:
+			return jobs.MarkAsPermanentJobError(streamingccl.NewStreamStatusErr(streamID, status.StreamStatus))
+		}
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. It cancels the
+// producer job on the source cluster on a best effort basis.
+func (r *logicalReplicationResumer) OnFailOrCancel(
+	ctx context.Context, _ interface{}, _ error,
+) error {
+	details := r.job.Details().(jobspb.LogicalReplicationDetails)
+	streamID := streampb.StreamID(details.StreamID)
+	client, err := streamclient.NewStreamClient(ctx, streamingccl.StreamAddress(details.StreamAddress))
+	if err != nil {
+		log.Warningf(ctx, "encountered error when creating the stream client: %v", err)
+		return nil
+	}
+	log.Infof(ctx, "canceling the producer job %d as logical replication job %d is being canceled",
+		streamID, r.job.ID())
+	if err := client.Complete(ctx, streamID, false /* successfulIngestion */); err != nil {
+		log.Warningf(ctx, "encountered error when canceling the producer job: %v", err)
+	}
+	if err := client.Close(ctx); err != nil {
+		log.Warningf(ctx, "encountered error when closing the stream client: %v", err)
+	}
+	return nil
+}
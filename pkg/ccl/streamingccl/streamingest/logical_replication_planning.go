@@ -0,0 +1,413 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package streamingest
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/streamingccl"
+	"github.com/cockroachdb/cockroach/pkg/ccl/streamingccl/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// originTimestampColumnName is the name of the column which the tables of a
+// subscription must have, in which the MVCC timestamp on the source cluster of
+// each replicated row is stored. Local writes reset it to NULL through its
+// ON UPDATE NULL expression, which is how conflicting local writes are told
+// apart from replicated ones.
+const originTimestampColumnName = "crdb_replication_origin_timestamp"
+
+const (
+	subscriptionOptConflictResolution = "conflict_resolution"
+
+	conflictResolutionLastWriteWins = "last_write_wins"
+	conflictResolutionError         = "error"
+)
+
+var createSubscriptionOptionValidations = exprutil.KVOptionValidationMap{
+	subscriptionOptConflictResolution: exprutil.KVStringOptRequireValue,
+}
+
+var createSubscriptionHeader = colinfo.ResultColumns{
+	{Name: "job_id", Typ: types.Int},
+}
+
+// redactConnectionURI removes the password from the URI of the source cluster
+// of a subscription, so that it can be stored in the job description and in
+// the subscription record.
+func redactConnectionURI(uri string) (string, error) {
+	u, err := streamingccl.StreamAddress(uri).URL()
+	if err != nil {
+		return "", err
+	}
+	return u.Redacted(), nil
+}
+
+func createSubscriptionJobDescription(
+	p sql.PlanHookState, subStmt *tree.CreateSubscription, redactedURI string,
+) string {
+	c := *subStmt
+	c.ConnectionURI = tree.NewDString(redactedURI)
+	return tree.AsStringWithFQNames(&c, p.ExtendedEvalContext().Annotations)
+}
+
+func evalConflictResolution(
+	opts map[string]string,
+) (jobspb.LogicalReplicationDetails_ConflictResolution, error) {
+	v, ok := opts[subscriptionOptConflictResolution]
+	if !ok {
+		return jobspb.LogicalReplicationDetails_LAST_WRITE_WINS, nil
+	}
+	switch v {
+	case conflictResolutionLastWriteWins:
+		return jobspb.LogicalReplicationDetails_LAST_WRITE_WINS, nil
+	case conflictResolutionError:
+		return jobspb.LogicalReplicationDetails_ERROR, nil
+	default:
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid value for %s: %q, expected %q or %q", subscriptionOptConflictResolution, v,
+			conflictResolutionLastWriteWins, conflictResolutionError)
+	}
+}
+
+// replicatedColumns returns the columns of the source table whose values are
+// replicated, i.e. its public columns which are not computed.
+func replicatedColumns(src catalog.TableDescriptor) []catalog.Column {
+	var cols []catalog.Column
+	for _, col := range src.PublicColumns() {
+		if !col.IsComputed() {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// resolveDestinationTable returns the table of the current database with the
+// same name as the given source table, after checking that the replicated rows
+// of the source table can be written into it.
+func resolveDestinationTable(
+	ctx context.Context,
+	p sql.PlanHookState,
+	dbDesc catalog.DatabaseDescriptor,
+	src catalog.TableDescriptor,
+) (catalog.TableDescriptor, error) {
+	tn := tree.MakeUnqualifiedTableName(tree.Name(src.GetName()))
+	_, dst, err := resolver.ResolveExistingTableObject(ctx, p, &tn, tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireTableDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dst.GetParentID() != dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"table %q is not in the current database %q", dst.GetName(), dbDesc.GetName())
+	}
+	for _, kind := range []privilege.Kind{privilege.SELECT, privilege.INSERT, privilege.UPDATE, privilege.DELETE} {
+		if err := p.CheckPrivilege(ctx, dst, kind); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each KV of the stream is decoded as a whole row of the source table,
+	// which only holds for tables with a single column family.
+	if src.NumFamilies() > 1 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the published table %q has multiple column families, "+
+				"which are not supported by logical replication", src.GetName())
+	}
+
+	for _, srcCol := range replicatedColumns(src) {
+		// The values of user-defined types are encoded using the descriptors
+		// of the types on the source cluster, which are not replicated.
+		if srcCol.GetType().UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q of the published table %q has a user-defined type, "+
+					"which is not supported by logical replication",
+				srcCol.GetName(), src.GetName())
+		}
+		dstCol, err := dst.FindColumnWithName(srcCol.ColName())
+		if err != nil || !dstCol.Public() || dstCol.IsComputed() {
+			return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+				"table %q has no writable column %q", dst.GetName(), srcCol.GetName())
+		}
+		if !dstCol.GetType().Equivalent(srcCol.GetType()) {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q of table %q has type %s, but the published column has type %s",
+				dstCol.GetName(), dst.GetName(), dstCol.GetType().SQLString(), srcCol.GetType().SQLString())
+		}
+	}
+	if err := checkPrimaryKeysMatch(src, dst); err != nil {
+		return nil, err
+	}
+
+	originCol, err := dst.FindColumnWithName(originTimestampColumnName)
+	if err != nil || !originCol.Public() || originCol.GetType().Family() != types.DecimalFamily ||
+		!originCol.IsNullable() || !originCol.HasOnUpdate() || originCol.GetOnUpdateExpr() != "NULL" {
+		return nil, errors.WithHintf(
+			pgerror.Newf(pgcode.InvalidTableDefinition,
+				"table %q must have a nullable DECIMAL column %s with ON UPDATE NULL",
+				dst.GetName(), originTimestampColumnName),
+			"Add it with ALTER TABLE %s ADD COLUMN %s DECIMAL ON UPDATE NULL.",
+			tree.NameString(dst.GetName()), originTimestampColumnName)
+	}
+	return dst, nil
+}
+
+// checkPrimaryKeysMatch checks that the primary key of the destination table
+// consists of the same columns as the one of the source table, since the
+// replicated rows are matched with the local ones by primary key.
+func checkPrimaryKeysMatch(src, dst catalog.TableDescriptor) error {
+	srcPK, dstPK := src.GetPrimaryIndex(), dst.GetPrimaryIndex()
+	for i := 0; i < srcPK.NumKeyColumns(); i++ {
+		col, err := src.FindColumnWithID(srcPK.GetKeyColumnID(i))
+		if err != nil {
+			return err
+		}
+		if col.IsComputed() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"the primary key of the published table %q has computed column %q",
+				src.GetName(), col.GetName())
+		}
+	}
+	if srcPK.NumKeyColumns() == dstPK.NumKeyColumns() {
+		match := true
+		for i := 0; i < srcPK.NumKeyColumns(); i++ {
+			if srcPK.GetKeyColumnName(i) != dstPK.GetKeyColumnName(i) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return nil
+		}
+	}
+	return pgerror.Newf(pgcode.InvalidTableDefinition,
+		"the primary key of table %q does not match the one of the published table", dst.GetName())
+}
+
+func createSubscriptionTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	subStmt, ok := stmt.(*tree.CreateSubscription)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(ctx, "CREATE SUBSCRIPTION", p.SemaCtx(),
+		exprutil.Strings{subStmt.ConnectionURI},
+		&exprutil.KVOptions{
+			KVOptions:  subStmt.Options,
+			Validation: createSubscriptionOptionValidations,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, createSubscriptionHeader, nil
+}
+
+func createSubscriptionPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	subStmt, ok := stmt.(*tree.CreateSubscription)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	exprEval := p.ExprEvaluator("CREATE SUBSCRIPTION")
+	from, err := exprEval.String(ctx, subStmt.ConnectionURI)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, subStmt.Options, createSubscriptionOptionValidations)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	conflictResolution, err := evalConflictResolution(opts)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if err := utilccl.CheckEnterpriseEnabled(
+			p.ExecCfg().Settings, p.ExecCfg().NodeInfo.LogicalClusterID(),
+			"CREATE SUBSCRIPTION",
+		); err != nil {
+			return err
+		}
+
+		dbDesc, err := p.MustGetCurrentSessionDatabase(ctx)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+			return err
+		}
+		for _, sub := range dbDesc.GetSubscriptions() {
+			if sub.Name == string(subStmt.Name) {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"subscription %q already exists", subStmt.Name)
+			}
+		}
+
+		redactedURI, err := redactConnectionURI(from)
+		if err != nil {
+			return err
+		}
+		streamAddress := streamingccl.StreamAddress(from)
+		client, err := streamclient.NewStreamClient(ctx, streamAddress)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := client.Close(ctx); err != nil {
+				log.Warningf(ctx, "encountered error when closing the stream client: %v", err)
+			}
+		}()
+		spec, err := client.CreateForPublication(ctx, string(subStmt.Publication))
+		if err != nil {
+			return err
+		}
+
+		details := jobspb.LogicalReplicationDetails{
+			StreamAddress:          string(streamAddress),
+			StreamID:               uint64(spec.StreamID),
+			SourceTableDescriptors: spec.TableDescriptors,
+			ConflictResolution:     conflictResolution,
+			ReplicationStartTime:   spec.ReplicationStartTime,
+		}
+		for i := range spec.TableDescriptors {
+			src := tabledesc.NewBuilder(&spec.TableDescriptors[i]).BuildImmutableTable()
+			dst, err := resolveDestinationTable(ctx, p, dbDesc, src)
+			if err != nil {
+				// The producer job is not needed anymore.
+				if cErr := client.Complete(ctx, spec.StreamID, false /* successfulIngestion */); cErr != nil {
+					log.Warningf(ctx, "encountered error when canceling the producer job: %v", cErr)
+				}
+				return err
+			}
+			details.DestinationTableIDs = append(details.DestinationTableIDs, dst.GetID())
+		}
+
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+		jr := jobs.Record{
+			Description: createSubscriptionJobDescription(p, subStmt, redactedURI),
+			Username:    p.User(),
+			Details:     details,
+			Progress:    jobspb.LogicalReplicationProgress{},
+		}
+		if err := p.AddSubscription(ctx, descpb.DatabaseDescriptor_Subscription{
+			Name:          string(subStmt.Name),
+			JobID:         jobID,
+			Publication:   string(subStmt.Publication),
+			ConnectionURI: redactedURI,
+			OwnerProto:    p.User().EncodeProto(),
+		}); err != nil {
+			return err
+		}
+		if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(ctx, jr, jobID, p.Txn()); err != nil {
+			return err
+		}
+		resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+		return nil
+	}
+	return fn, createSubscriptionHeader, nil, false, nil
+}
+
+func dropSubscriptionTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	if _, ok := stmt.(*tree.DropSubscription); !ok {
+		return false, nil, nil
+	}
+	return true, nil, nil
+}
+
+func dropSubscriptionPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	subStmt, ok := stmt.(*tree.DropSubscription)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, _ chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		dbDesc, err := p.MustGetCurrentSessionDatabase(ctx)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, sub := range dbDesc.GetSubscriptions() {
+			if sub.Name == string(subStmt.Name) {
+				found = true
+				break
+			}
+		}
+		if !found && subStmt.IfExists {
+			return nil
+		}
+
+		sub, err := p.RemoveSubscription(ctx, string(subStmt.Name))
+		if err != nil {
+			return err
+		}
+
+		// Cancel the job of the subscription, which in turn cancels the
+		// producer job on the source cluster.
+		registry := p.ExecCfg().JobRegistry
+		job, err := registry.LoadJobWithTxn(ctx, sub.JobID, p.Txn())
+		if err != nil {
+			if jobs.HasJobNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		if job.Status().Terminal() {
+			return nil
+		}
+		return registry.CancelRequested(ctx, p.Txn(), sub.JobID)
+	}
+	return fn, nil, nil, false, nil
+}
+
+func init() {
+	sql.AddPlanHook("create subscription", createSubscriptionPlanHook, createSubscriptionTypeCheck)
+	sql.AddPlanHook("drop subscription", dropSubscriptionPlanHook, dropSubscriptionTypeCheck)
+	jobs.RegisterConstructor(
+		jobspb.TypeLogicalReplication,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &logicalReplicationResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package streamingest_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	_ "github.com/cockroachdb/cockroach/pkg/ccl/streamingccl/streamproducer"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+type logicalReplicationTestCase struct {
+	srcSQL, dstSQL *sqlutils.SQLRunner
+	srcURL         url.URL
+}
+
+func startLogicalReplicationTestCase(
+	t *testing.T, ctx context.Context,
+) (logicalReplicationTestCase, func()) {
+	srcServer, srcDB, _ := serverutils.StartServer(t, base.TestServerArgs{DisableDefaultTestTenant: true})
+	dstServer, dstDB, _ := serverutils.StartServer(t, base.TestServerArgs{DisableDefaultTestTenant: true})
+
+	sqlutils.MakeSQLRunner(srcDB).ExecMultiple(t,
+		`SET CLUSTER SETTING kv.rangefeed.enabled = true`,
+		`SET CLUSTER SETTING stream_replication.min_checkpoint_frequency = '100ms'`,
+		`CREATE DATABASE a`,
+	)
+	srcSQL := sqlutils.MakeSQLRunner(serverutils.OpenDBConn(
+		t, srcServer.ServingSQLAddr(), "a", false /* insecure */, srcServer.Stopper()))
+	srcSQL.ExecMultiple(t,
+		`CREATE TABLE t (k INT PRIMARY KEY, v STRING)`,
+		`INSERT INTO t VALUES (1, 'one'), (2, 'two')`,
+		`CREATE PUBLICATION p FOR TABLE t`,
+	)
+	sqlutils.MakeSQLRunner(dstDB).ExecMultiple(t,
+		`SET CLUSTER SETTING stream_replication.job_checkpoint_frequency = '100ms'`,
+		`CREATE DATABASE b`,
+	)
+	dstSQL := sqlutils.MakeSQLRunner(serverutils.OpenDBConn(
+		t, dstServer.ServingSQLAddr(), "b", false /* insecure */, dstServer.Stopper()))
+
+	// The path of the URI names the database of the publication.
+	srcURL, cleanupURL := sqlutils.PGUrl(t, srcServer.ServingSQLAddr(), t.Name(), url.User(username.RootUser))
+	srcURL.Path = "a"
+	return logicalReplicationTestCase{srcSQL: srcSQL, dstSQL: dstSQL, srcURL: srcURL}, func() {
+		cleanupURL()
+		dstServer.Stopper().Stop(ctx)
+		srcServer.Stopper().Stop(ctx)
+	}
+}
+
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	skip.UnderRace(t, "slow under race")
+
+	ctx := context.Background()
+	tc, cleanup := startLogicalReplicationTestCase(t, ctx)
+	defer cleanup()
+
+	tc.dstSQL.Exec(t, `CREATE TABLE t (
+		k INT PRIMARY KEY,
+		v STRING,
+		crdb_replication_origin_timestamp DECIMAL ON UPDATE NULL
+	)`)
+	var jobID int64
+	tc.dstSQL.QueryRow(t, `CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p`, tc.srcURL.String()).Scan(&jobID)
+
+	const query = `SELECT k, v FROM t ORDER BY k`
+	waitForRows := func(expected [][]string) {
+		testutils.SucceedsSoon(t, func() error {
+			if actual := tc.dstSQL.QueryStr(t, query); !reflect.DeepEqual(expected, actual) {
+				return errors.Newf("expected %v, got %v", expected, actual)
+			}
+			return nil
+		})
+	}
+
+	// The initial scan replicates the existing rows.
+	waitForRows([][]string{{"1", "one"}, {"2", "two"}})
+
+	// Changes are replicated as they happen.
+	tc.srcSQL.Exec(t, `INSERT INTO t VALUES (3, 'three')`)
+	tc.srcSQL.Exec(t, `UPDATE t SET v = 'uno' WHERE k = 1`)
+	tc.srcSQL.Exec(t, `DELETE FROM t WHERE k = 2`)
+	waitForRows([][]string{{"1", "uno"}, {"3", "three"}})
+
+	// With last-write-wins, a replicated change overwrites an older local
+	// write, and is skipped if the local write is more recent.
+	tc.srcSQL.Exec(t, `UPDATE t SET v = 'tres' WHERE k = 3`)
+	waitForRows([][]string{{"1", "uno"}, {"3", "tres"}})
+	tc.dstSQL.Exec(t, `UPDATE t SET v = 'local' WHERE k = 1`)
+	tc.dstSQL.CheckQueryResults(t,
+		`SELECT crdb_replication_origin_timestamp IS NULL FROM t WHERE k = 1`, [][]string{{"true"}})
+	tc.srcSQL.Exec(t, `INSERT INTO t VALUES (4, 'four')`)
+	waitForRows([][]string{{"1", "local"}, {"3", "tres"}, {"4", "four"}})
+
+	// Changes are applied in batches, each in a single transaction.
+	tc.srcSQL.Exec(t, `INSERT INTO t SELECT i, 'many' FROM generate_series(10, 209) AS g(i)`)
+	tc.dstSQL.CheckQueryResultsRetry(t,
+		`SELECT count(*) FROM t WHERE v = 'many'`, [][]string{{"200"}})
+
+	tc.dstSQL.CheckQueryResults(t,
+		`SELECT subname, subpublications FROM pg_catalog.pg_subscription`,
+		[][]string{{"s", "{p}"}})
+
+	tc.dstSQL.Exec(t, `DROP SUBSCRIPTION s`)
+	tc.dstSQL.CheckQueryResultsRetry(t,
+		fmt.Sprintf(`SELECT status FROM [SHOW JOBS] WHERE job_id = %d`, jobID), [][]string{{"canceled"}})
+	tc.dstSQL.CheckQueryResults(t, `SELECT count(*) FROM pg_catalog.pg_subscription`, [][]string{{"0"}})
+}
+
+func TestLogicalReplicationConflictError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	skip.UnderRace(t, "slow under race")
+
+	ctx := context.Background()
+	tc, cleanup := startLogicalReplicationTestCase(t, ctx)
+	defer cleanup()
+
+	tc.dstSQL.Exec(t, `CREATE TABLE t (
+		k INT PRIMARY KEY,
+		v STRING,
+		crdb_replication_origin_timestamp DECIMAL ON UPDATE NULL
+	)`)
+	// The local row is more recent than the published one, which is
+	// replicated with its original MVCC timestamp by the initial scan.
+	tc.dstSQL.Exec(t, `INSERT INTO t VALUES (2, 'local')`)
+	var jobID int64
+	tc.dstSQL.QueryRow(t,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p WITH conflict_resolution = 'error'`,
+		tc.srcURL.String(),
+	).Scan(&jobID)
+	tc.dstSQL.CheckQueryResultsRetry(t,
+		fmt.Sprintf(`SELECT status FROM [SHOW JOBS] WHERE job_id = %d`, jobID), [][]string{{"failed"}})
+	tc.dstSQL.CheckQueryResults(t, `SELECT v FROM t WHERE k = 2`, [][]string{{"local"}})
+}
+
+func TestLogicalReplicationCreationErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc, cleanup := startLogicalReplicationTestCase(t, ctx)
+	defer cleanup()
+
+	tc.dstSQL.ExpectErr(t, `invalid value for conflict_resolution: "first_write_wins"`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p WITH conflict_resolution = 'first_write_wins'`,
+		tc.srcURL.String())
+	tc.dstSQL.ExpectErr(t, `publication "missing" does not exist`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION missing`, tc.srcURL.String())
+	tc.dstSQL.ExpectErr(t, `relation "t" does not exist`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p`, tc.srcURL.String())
+
+	tc.dstSQL.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	tc.dstSQL.ExpectErr(t, `column "v" of table "t" has type INT8, but the published column has type STRING`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p`, tc.srcURL.String())
+
+	tc.dstSQL.Exec(t, `DROP TABLE t`)
+	tc.dstSQL.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	tc.dstSQL.ExpectErr(t, `table "t" must have a nullable DECIMAL column crdb_replication_origin_timestamp with ON UPDATE NULL`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION p`, tc.srcURL.String())
+
+	// Every KV of the stream is decoded as a whole row, so tables with multiple
+	// column families cannot be replicated.
+	tc.srcSQL.ExecMultiple(t,
+		`CREATE TABLE f (k INT PRIMARY KEY, a INT, b INT, FAMILY (k, a), FAMILY (b))`,
+		`CREATE PUBLICATION pf FOR TABLE f`,
+	)
+	tc.dstSQL.Exec(t, `CREATE TABLE f (
+		k INT PRIMARY KEY,
+		a INT,
+		b INT,
+		crdb_replication_origin_timestamp DECIMAL ON UPDATE NULL,
+		FAMILY (k, a, crdb_replication_origin_timestamp),
+		FAMILY (b)
+	)`)
+	tc.dstSQL.ExpectErr(t, `the published table "f" has multiple column families, which are not supported by logical replication`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION pf`, tc.srcURL.String())
+
+	// Neither can columns of user-defined types.
+	tc.srcSQL.ExecMultiple(t,
+		`CREATE TYPE e AS ENUM ('x', 'y')`,
+		`CREATE TABLE u (k INT PRIMARY KEY, v e)`,
+		`CREATE PUBLICATION pu FOR TABLE u`,
+	)
+	tc.dstSQL.ExecMultiple(t,
+		`CREATE TYPE e AS ENUM ('x', 'y')`,
+		`CREATE TABLE u (k INT PRIMARY KEY, v e, crdb_replication_origin_timestamp DECIMAL ON UPDATE NULL)`,
+	)
+	tc.dstSQL.ExpectErr(t, `column "v" of the published table "u" has a user-defined type, which is not supported by logical replication`,
+		`CREATE SUBSCRIPTION s CONNECTION $1 PUBLICATION pu`, tc.srcURL.String())
+
+	tc.dstSQL.Exec(t, `DROP SUBSCRIPTION IF EXISTS s`)
+	tc.dstSQL.ExpectErr(t, `subscription "s" does not exist`, `DROP SUBSCRIPTION s`)
+}
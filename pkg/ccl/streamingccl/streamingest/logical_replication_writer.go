@@ -0,0 +1,318 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package streamingest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/streamingccl/replicationutils"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// logicalReplicationBatchSize is the maximum number of row changes applied by
+// a writer in a single transaction.
+const logicalReplicationBatchSize = 64
+
+// logicalReplicationWriter applies the row changes of the replication stream
+// of a subscription to the destination tables. Each change is decoded into a
+// row of the source table and written through SQL, so that the constraints,
+// indexes and triggers of the destination tables are maintained as for any
+// other write. The changes are buffered and applied in batches, each in a
+// single transaction.
+//
+// Since every KV of the stream is decoded as a whole row, the source tables
+// must have a single column family (see resolveDestinationTable).
+//
+// A writer is not safe for concurrent use; each partition of the stream uses
+// its own.
+type logicalReplicationWriter struct {
+	execCfg            *sql.ExecutorConfig
+	override           sessiondata.InternalExecutorOverride
+	codec              keys.SQLCodec
+	conflictResolution jobspb.LogicalReplicationDetails_ConflictResolution
+	// tables maps the IDs of the source tables to their writers.
+	tables     map[descpb.ID]*tableWriter
+	kvProvider row.KVProvider
+	// batch contains the decoded changes which have not been applied yet, in
+	// the order of the stream.
+	batch []rowChange
+}
+
+// rowChange is a change to a row of a source table.
+type rowChange struct {
+	tw      *tableWriter
+	datums  tree.Datums
+	deleted bool
+	ts      hlc.Timestamp
+}
+
+// tableWriter decodes the rows of a source table and writes them into the
+// corresponding destination table.
+type tableWriter struct {
+	srcName string
+	fetcher row.Fetcher
+	// pkOrdinals are the ordinals of the primary key columns in the decoded
+	// rows.
+	pkOrdinals []int
+	selectStmt string
+	upsertStmt string
+	deleteStmt string
+}
+
+func newLogicalReplicationWriter(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	details jobspb.LogicalReplicationDetails,
+	sourceTenantID roachpb.TenantID,
+) (*logicalReplicationWriter, error) {
+	w := &logicalReplicationWriter{
+		execCfg:            execCfg,
+		override:           sessiondata.InternalExecutorOverride{User: user},
+		codec:              keys.MakeSQLCodec(sourceTenantID),
+		conflictResolution: details.ConflictResolution,
+		tables:             make(map[descpb.ID]*tableWriter, len(details.SourceTableDescriptors)),
+	}
+	for i := range details.SourceTableDescriptors {
+		src := tabledesc.NewBuilder(&details.SourceTableDescriptors[i]).BuildImmutableTable()
+		tw, err := w.newTableWriter(ctx, src, details.DestinationTableIDs[i])
+		if err != nil {
+			return nil, err
+		}
+		w.tables[src.GetID()] = tw
+	}
+	return w, nil
+}
+
+func (w *logicalReplicationWriter) newTableWriter(
+	ctx context.Context, src catalog.TableDescriptor, dstID descpb.ID,
+) (*tableWriter, error) {
+	cols := replicatedColumns(src)
+	colIDs := make([]descpb.ColumnID, len(cols))
+	colNames := make([]string, len(cols))
+	for i, col := range cols {
+		colIDs[i] = col.GetID()
+		colNames[i] = tree.NameString(col.GetName())
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, w.codec, src, src.GetPrimaryIndex(), colIDs); err != nil {
+		return nil, err
+	}
+	tw := &tableWriter{srcName: src.GetName()}
+	if err := tw.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+
+	pk := src.GetPrimaryIndex()
+	pkPreds := make([]string, pk.NumKeyColumns())
+	for i := 0; i < pk.NumKeyColumns(); i++ {
+		ord := -1
+		for j := range colIDs {
+			if colIDs[j] == pk.GetKeyColumnID(i) {
+				ord = j
+				break
+			}
+		}
+		if ord == -1 {
+			return nil, errors.AssertionFailedf(
+				"primary key column %q of table %q is not replicated", pk.GetKeyColumnName(i), src.GetName())
+		}
+		tw.pkOrdinals = append(tw.pkOrdinals, ord)
+		pkPreds[i] = fmt.Sprintf("%s = $%d", colNames[ord], i+1)
+	}
+	where := strings.Join(pkPreds, " AND ")
+
+	placeholders := make([]string, len(cols)+1)
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	tw.selectStmt = fmt.Sprintf(
+		`SELECT crdb_internal_mvcc_timestamp, %s FROM [%d AS t] WHERE %s`,
+		originTimestampColumnName, dstID, where,
+	)
+	tw.upsertStmt = fmt.Sprintf(
+		`UPSERT INTO [%d AS t] (%s, %s) VALUES (%s)`,
+		dstID, strings.Join(colNames, ", "), originTimestampColumnName, strings.Join(placeholders, ", "),
+	)
+	tw.deleteStmt = fmt.Sprintf(`DELETE FROM [%d AS t] WHERE %s`, dstID, where)
+	return tw, nil
+}
+
+// applyKV decodes the row written by the given KV of the source cluster and
+// adds it to the batch of changes to apply to the corresponding destination
+// table, applying the batch if it is full.
+func (w *logicalReplicationWriter) applyKV(ctx context.Context, kv roachpb.KeyValue) error {
+	_, tableID, err := w.codec.DecodeTablePrefix(kv.Key)
+	if err != nil {
+		return err
+	}
+	tw, ok := w.tables[descpb.ID(tableID)]
+	if !ok {
+		return errors.AssertionFailedf("unexpected key %s outside of the published tables", kv.Key)
+	}
+
+	w.kvProvider.KVs = append(w.kvProvider.KVs[:0], kv)
+	if err := tw.fetcher.ConsumeKVProvider(ctx, &w.kvProvider); err != nil {
+		return err
+	}
+	datums, err := tw.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return err
+	}
+	if datums == nil {
+		return errors.AssertionFailedf("unexpected empty row for key %s", kv.Key)
+	}
+	w.batch = append(w.batch, rowChange{
+		tw: tw,
+		// The datums are owned by the fetcher, which reuses them for the next
+		// row.
+		datums:  append(tree.Datums(nil), datums...),
+		deleted: tw.fetcher.RowIsDeleted(),
+		ts:      kv.Value.Timestamp,
+	})
+	if len(w.batch) >= logicalReplicationBatchSize {
+		return w.flush(ctx)
+	}
+	return nil
+}
+
+// flush applies the buffered changes in a single transaction. It must be
+// called before the frontier of the stream is forwarded past them.
+func (w *logicalReplicationWriter) flush(ctx context.Context) error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	if err := w.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		for i := range w.batch {
+			c := &w.batch[i]
+			if err := w.applyRow(ctx, txn, c.tw, c.datums, c.deleted, c.ts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	w.batch = w.batch[:0]
+	return nil
+}
+
+// applySST applies the KVs of an SST emitted by the source cluster, e.g. by
+// an IMPORT or a RESTORE into a published table.
+func (w *logicalReplicationWriter) applySST(
+	ctx context.Context, sst *roachpb.RangeFeedSSTable,
+) error {
+	return replicationutils.ScanSST(sst, sst.Span,
+		func(keyVal storage.MVCCKeyValue) error {
+			v, err := storage.DecodeMVCCValue(keyVal.Value)
+			if err != nil {
+				return err
+			}
+			v.Value.Timestamp = keyVal.Key.Timestamp
+			return w.applyKV(ctx, roachpb.KeyValue{Key: keyVal.Key.Key, Value: v.Value})
+		}, func(rangeKeyVal storage.MVCCRangeKeyValue) error {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"range deletions are not supported by logical replication")
+		})
+}
+
+// applyRow writes a row of the source table, or deletes it, in the destination
+// table using the given transaction, unless the destination table already has
+// a more recent version of the row. The version of a local row is the MVCC timestamp on the source
+// cluster stored in its origin timestamp column if it was replicated, and its
+// own MVCC timestamp if it was written locally.
+//
+// Note that the deletion of a local row leaves no trace which can be compared
+// with a replicated change, so a replicated change to a row deleted locally
+// always recreates it.
+func (w *logicalReplicationWriter) applyRow(
+	ctx context.Context,
+	txn *kv.Txn,
+	tw *tableWriter,
+	datums tree.Datums,
+	deleted bool,
+	ts hlc.Timestamp,
+) error {
+	eventTS := eval.TimestampToDecimalDatum(ts)
+	pkArgs := make([]interface{}, len(tw.pkOrdinals))
+	for i, ord := range tw.pkOrdinals {
+		pkArgs[i] = datums[ord]
+	}
+	ie := w.execCfg.InternalExecutor
+	existing, err := ie.QueryRowEx(
+		ctx, "logical-replication-read", txn, w.override, tw.selectStmt, pkArgs...,
+	)
+	if err != nil {
+		return err
+	}
+	if existing == nil && deleted {
+		return nil
+	}
+	if existing != nil {
+		if existing[1] == tree.DNull {
+			localTS := tree.MustBeDDecimal(existing[0])
+			if localTS.Cmp(&eventTS.Decimal) > 0 {
+				if w.conflictResolution == jobspb.LogicalReplicationDetails_ERROR {
+					return jobs.MarkAsPermanentJobError(errors.Newf(
+						"replicated change to table %q at %s conflicts with a more recent local write",
+						tw.srcName, ts))
+				}
+				log.VEventf(ctx, 2, "skipping replicated change to table %q at %s "+
+					"older than a local write", tw.srcName, ts)
+				return nil
+			}
+		} else {
+			originTS := tree.MustBeDDecimal(existing[1])
+			if originTS.Cmp(&eventTS.Decimal) >= 0 {
+				// The change was already applied, e.g. before the job was
+				// resumed from its last checkpoint.
+				return nil
+			}
+		}
+	}
+
+	if deleted {
+		_, err := ie.ExecEx(ctx, "logical-replication-delete", txn, w.override, tw.deleteStmt, pkArgs...)
+		return err
+	}
+	args := make([]interface{}, len(datums)+1)
+	for i := range datums {
+		args[i] = datums[i]
+	}
+	args[len(datums)] = eventTS
+	_, err = ie.ExecEx(ctx, "logical-replication-upsert", txn, w.override, tw.upsertStmt, args...)
+	return err
+}
@@ -66,6 +66,13 @@ func (m *mockStreamClient) Create(
 	panic("unimplemented")
 }
 
+// CreateForPublication implements the Client interface.
+func (m *mockStreamClient) CreateForPublication(
+	_ context.Context, _ string,
+) (streampb.ReplicationProducerSpec, error) {
+	panic("unimplemented")
+}
+
 // Dial implements the Client interface.
 func (m *mockStreamClient) Dial(_ context.Context) error {
 	panic("unimplemented")
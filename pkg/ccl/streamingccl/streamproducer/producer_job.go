@@ -55,6 +55,32 @@ func makeProducerJobRecord(
 	}
 }
 
+// makeProducerJobRecordForTables is like makeProducerJobRecord, but for a
+// stream of the given spans of the tables of a publication.
+func makeProducerJobRecordForTables(
+	registry *jobs.Registry,
+	publication string,
+	tenantID roachpb.TenantID,
+	spans []*roachpb.Span,
+	timeout time.Duration,
+	user username.SQLUsername,
+	ptsID uuid.UUID,
+) jobs.Record {
+	return jobs.Record{
+		JobID:       registry.MakeJobID(),
+		Description: fmt.Sprintf("stream replication for publication %s", publication),
+		Username:    user,
+		Details: jobspb.StreamReplicationDetails{
+			ProtectedTimestampRecordID: ptsID,
+			Spans:                      spans,
+			TenantID:                   tenantID,
+		},
+		Progress: jobspb.StreamReplicationProgress{
+			Expiration: timeutil.Now().Add(timeout),
+		},
+	}
+}
+
 type producerJobResumer struct {
 	job *jobs.Job
 
@@ -35,6 +35,14 @@ func (r *replicationStreamManagerImpl) StartReplicationStream(
 	return startReplicationProducerJob(ctx, r.evalCtx, r.txn, tenantName)
 }
 
+// StartReplicationStreamForPublication implements
+// streaming.ReplicationStreamManager interface.
+func (r *replicationStreamManagerImpl) StartReplicationStreamForPublication(
+	ctx context.Context, publication string,
+) (streampb.ReplicationProducerSpec, error) {
+	return startReplicationProducerJobForPublication(ctx, r.evalCtx, r.txn, publication)
+}
+
 // HeartbeatReplicationStream implements streaming.ReplicationStreamManager interface.
 func (r *replicationStreamManagerImpl) HeartbeatReplicationStream(
 	ctx context.Context, streamID streampb.StreamID, frontier hlc.Timestamp,
@@ -16,12 +16,17 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -82,6 +87,93 @@ func startReplicationProducerJob(
 	}, nil
 }
 
+// startReplicationProducerJobForPublication initializes a replication stream
+// producer job like startReplicationProducerJob, but for the primary indexes
+// of the tables of the given publication of the current database instead of
+// the keyspace of a tenant.
+func startReplicationProducerJobForPublication(
+	ctx context.Context, evalCtx *eval.Context, txn *kv.Txn, publication string,
+) (streampb.ReplicationProducerSpec, error) {
+	execConfig := evalCtx.Planner.ExecutorConfig().(*sql.ExecutorConfig)
+	descsCol := evalCtx.JobExecContext.(sql.JobExecContext).ExtendedEvalContext().Descs
+
+	dbDesc, err := descsCol.ByNameWithLeased(txn).Get().Database(ctx, evalCtx.SessionData().Database)
+	if err != nil {
+		return streampb.ReplicationProducerSpec{}, err
+	}
+	var pub *descpb.DatabaseDescriptor_Publication
+	pubs := dbDesc.GetPublications()
+	for i := range pubs {
+		if pubs[i].Name == publication {
+			pub = &pubs[i]
+			break
+		}
+	}
+	if pub == nil {
+		return streampb.ReplicationProducerSpec{}, pgerror.Newf(pgcode.UndefinedObject,
+			"publication %q does not exist", publication)
+	}
+
+	var tableIDs descpb.IDs
+	var spans []*roachpb.Span
+	var tableDescs []descpb.TableDescriptor
+	for _, id := range pub.TableIDs {
+		desc, err := descsCol.ByID(txn).Get().Desc(ctx, id)
+		if errors.Is(err, catalog.ErrDescriptorNotFound) {
+			continue
+		} else if err != nil {
+			return streampb.ReplicationProducerSpec{}, err
+		}
+		tableDesc, ok := desc.(catalog.TableDescriptor)
+		if !ok || tableDesc.Dropped() {
+			continue
+		}
+		span := tableDesc.PrimaryIndexSpan(execConfig.Codec)
+		tableIDs = append(tableIDs, id)
+		spans = append(spans, &span)
+		tableDescs = append(tableDescs, *tableDesc.TableDesc())
+	}
+	if len(tableIDs) == 0 {
+		return streampb.ReplicationProducerSpec{}, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"publication %q has no tables", publication)
+	}
+	_, tenantID, err := keys.DecodeTenantPrefix(execConfig.Codec.TenantPrefix())
+	if err != nil {
+		return streampb.ReplicationProducerSpec{}, err
+	}
+
+	registry := execConfig.JobRegistry
+	timeout := streamingccl.StreamReplicationJobLivenessTimeout.Get(&evalCtx.Settings.SV)
+	ptsID := uuid.MakeV4()
+
+	jr := makeProducerJobRecordForTables(
+		registry, publication, tenantID, spans, timeout, evalCtx.SessionData().User(), ptsID,
+	)
+	if _, err := registry.CreateAdoptableJobWithTxn(ctx, jr, jr.JobID, txn); err != nil {
+		return streampb.ReplicationProducerSpec{}, err
+	}
+
+	statementTime := hlc.Timestamp{
+		WallTime: evalCtx.GetStmtTimestamp().UnixNano(),
+	}
+	deprecatedSpansToProtect := make(roachpb.Spans, 0, len(spans))
+	for _, sp := range spans {
+		deprecatedSpansToProtect = append(deprecatedSpansToProtect, *sp)
+	}
+	targetToProtect := ptpb.MakeSchemaObjectsTarget(tableIDs)
+	pts := jobsprotectedts.MakeRecord(ptsID, int64(jr.JobID), statementTime,
+		deprecatedSpansToProtect, jobsprotectedts.Jobs, targetToProtect)
+
+	if err := execConfig.ProtectedTimestampProvider.Protect(ctx, txn, pts); err != nil {
+		return streampb.ReplicationProducerSpec{}, err
+	}
+	return streampb.ReplicationProducerSpec{
+		StreamID:             streampb.StreamID(jr.JobID),
+		ReplicationStartTime: statementTime,
+		TableDescriptors:     tableDescs,
+	}, nil
+}
+
 // Convert the producer job's status into corresponding replication
 // stream status.
 func convertProducerJobStatusToStreamStatus(
@@ -401,6 +401,14 @@ const (
 	// chagnefeeds created prior to this version.
 	V23_1_ChangefeedExpressionProductionReady
 
+	// V23_1_ReadCommittedIsolation is the version where transactions may run
+	// at the READ COMMITTED isolation level.
+	V23_1_ReadCommittedIsolation
+
+	// V23_1_CreateSystemNotificationsTable creates the system.notifications
+	// table, through which NOTIFY delivers notifications to listening sessions.
+	V23_1_CreateSystemNotificationsTable
+
 	// *************************************************
 	// Step (1): Add new versions here.
 	// Do not add new versions to a patch release.
@@ -687,6 +695,14 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_1_ChangefeedExpressionProductionReady,
 		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 30},
 	},
+	{
+		Key:     V23_1_ReadCommittedIsolation,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 32},
+	},
+	{
+		Key:     V23_1_CreateSystemNotificationsTable,
+		Version: roachpb.Version{Major: 22, Minor: 2, Internal: 34},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
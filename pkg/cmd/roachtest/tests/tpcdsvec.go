@@ -37,15 +37,6 @@ func registerTPCDSVec(r registry.Registry) {
 		// These queries don't complete within 5 minutes.
 		1:  true,
 		64: true,
-
-		// These queries contain unsupported function 'rollup' (#46280).
-		5:  true,
-		14: true,
-		18: true,
-		22: true,
-		67: true,
-		77: true,
-		80: true,
 	}
 
 	tpcdsTables := []string{
@@ -27,6 +27,7 @@ PROTOBUF_SRCS = [
     "//pkg/kv/bulk/bulkpb:bulkpb_go_proto",
     "//pkg/kv/kvnemesis:kvnemesis_go_proto",
     "//pkg/kv/kvserver/closedts/ctpb:ctpb_go_proto",
+    "//pkg/kv/kvserver/concurrency/isolation:isolation_go_proto",
     "//pkg/kv/kvserver/concurrency/lock:lock_go_proto",
     "//pkg/kv/kvserver/concurrency/poison:poison_go_proto",
     "//pkg/kv/kvserver/kvserverpb:kvserverpb_go_proto",
@@ -59,6 +60,7 @@ PROTOBUF_SRCS = [
     "//pkg/sql/inverted:inverted_go_proto",
     "//pkg/sql/lex:lex_go_proto",
     "//pkg/sql/pgwire/pgerror:pgerror_go_proto",
+    "//pkg/sql/pgwire/pgnotify:pgnotify_go_proto",
     "//pkg/sql/protoreflect/test:protoreflecttest_go_proto",
     "//pkg/sql/rowenc/rowencpb:rowencpb_go_proto",
     "//pkg/sql/schemachanger/scpb:scpb_go_proto",
@@ -15,6 +15,7 @@ import (
 	"runtime/debug"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -778,6 +779,12 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	// reflect the reason for the restart. More details about the
 	// different error types are documented above on the metaRestart
 	// variables.
+	//
+	// While doing so, determine whether the error could be recovered from by
+	// retrying only the statement that encountered it. This is not the case for
+	// async write failures, which indicate that a write performed by an earlier
+	// statement has been lost.
+	partialRetryPossible := true
 	switch tErr := pErr.GetDetail().(type) {
 	case *roachpb.TransactionRetryError:
 		switch tErr.Reason {
@@ -787,6 +794,7 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 			tc.metrics.RestartsSerializable.Inc()
 		case roachpb.RETRY_ASYNC_WRITE_FAILURE:
 			tc.metrics.RestartsAsyncWriteFailure.Inc()
+			partialRetryPossible = false
 		case roachpb.RETRY_COMMIT_DEADLINE_EXCEEDED:
 			tc.metrics.RestartsCommitDeadlineExceeded.Inc()
 		default:
@@ -836,8 +844,27 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		return retErr
 	}
 
+	// Transactions that establish a new read snapshot for each statement may be
+	// able to recover from the error by retrying only the statement that
+	// encountered it, without restarting. To allow for this, defer the epoch
+	// bump until the client either decides to retry the entire transaction
+	// (see ClearTxnRetryableErr) or to retry the statement (see
+	// PrepareForPartialRetry).
+	if tc.mu.txn.IsoLevel.PerStatementReadSnapshot() && partialRetryPossible {
+		log.VEventf(ctx, 2, "deferring epoch bump on retry")
+		return retErr
+	}
+
+	tc.bumpEpochLocked(ctx, &newTxn)
+	return retErr
+}
+
+// bumpEpochLocked updates the transaction with the provided transaction proto,
+// which must be from a later epoch, and resets the epoch-scoped state in all
+// interceptors.
+func (tc *TxnCoordSender) bumpEpochLocked(ctx context.Context, newTxn *roachpb.Transaction) {
 	// This is where we get a new epoch.
-	tc.mu.txn.Update(&newTxn)
+	tc.mu.txn.Update(newTxn)
 
 	// Reset state as this is a retryable txn error that is incrementing
 	// the transaction's epoch.
@@ -845,7 +872,16 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	for _, reqInt := range tc.interceptorStack {
 		reqInt.epochBumpedLocked()
 	}
-	return retErr
+}
+
+// epochBumpDeferredLocked returns whether the TxnCoordSender is in a retryable
+// error state whose epoch bump was deferred by handleRetryableErrLocked.
+func (tc *TxnCoordSender) epochBumpDeferredLocked() bool {
+	if tc.mu.txnState != txnRetryableError {
+		return false
+	}
+	retErr := tc.mu.storedRetryableErr
+	return !retErr.PrevTxnAborted() && tc.mu.txn.Epoch < retErr.Transaction.Epoch
 }
 
 // updateStateLocked updates the transaction state in both the success and error
@@ -997,6 +1033,24 @@ func (tc *TxnCoordSender) SetUserPriority(pri roachpb.UserPriority) error {
 	return nil
 }
 
+// SetIsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) SetIsoLevel(isoLevel isolation.Level) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.active && isoLevel != tc.mu.txn.IsoLevel {
+		return errors.New("cannot change the isolation level of a running transaction")
+	}
+	tc.mu.txn.IsoLevel = isoLevel
+	return nil
+}
+
+// IsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) IsoLevel() isolation.Level {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mu.txn.IsoLevel
+}
+
 // SetDebugName is part of the client.TxnSender interface.
 func (tc *TxnCoordSender) SetDebugName(name string) {
 	tc.mu.Lock()
@@ -1111,6 +1165,11 @@ func (tc *TxnCoordSender) IsSerializablePushAndRefreshNotPossible() bool {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
 
+	// Transactions that tolerate write skew can commit at a write timestamp
+	// above their read timestamp without refreshing.
+	if tc.mu.txn.IsoLevel.ToleratesWriteSkew() {
+		return false
+	}
 	isTxnPushed := tc.mu.txn.WriteTimestamp != tc.mu.txn.ReadTimestamp
 	refreshAttemptNotPossible := tc.interceptorAlloc.txnSpanRefresher.refreshInvalid ||
 		tc.mu.txn.CommitTimestampFixed
@@ -1286,12 +1345,37 @@ func (tc *TxnCoordSender) PrepareRetryableError(
 }
 
 // Step is part of the TxnSender interface.
-func (tc *TxnCoordSender) Step(ctx context.Context) error {
+func (tc *TxnCoordSender) Step(ctx context.Context, allowReadTimestampStep bool) error {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
+	if allowReadTimestampStep {
+		tc.maybeStepReadTimestampLocked(ctx)
+	}
 	return tc.interceptorAlloc.txnSeqNumAllocator.stepLocked(ctx)
 }
 
+// maybeStepReadTimestampLocked advances the transaction's read timestamp to the
+// current time if the transaction's isolation level calls for a new read
+// snapshot on each statement. Transactions with a fixed commit timestamp never
+// move their read timestamp.
+func (tc *TxnCoordSender) maybeStepReadTimestampLocked(ctx context.Context) {
+	if !tc.mu.txn.IsoLevel.PerStatementReadSnapshot() ||
+		tc.mu.txn.CommitTimestampFixed || tc.mu.txnState != txnPending {
+		return
+	}
+	now := tc.clock.Now()
+	tc.mu.txn.BumpReadTimestamp(now)
+	// The new read snapshot comes with a new uncertainty interval. Observed
+	// timestamps were captured for the previous read snapshot and may not be
+	// used to shrink the new uncertainty interval, so they are discarded.
+	tc.mu.txn.GlobalUncertaintyLimit.Forward(now.Add(tc.clock.MaxOffset().Nanoseconds(), 0))
+	tc.mu.txn.ResetObservedTimestamps()
+	// Reads performed under the previous read snapshot no longer need to be
+	// refreshed.
+	tc.interceptorAlloc.txnSpanRefresher.resetRefreshSpansLocked(tc.mu.txn.ReadTimestamp)
+	log.VEventf(ctx, 2, "stepped read timestamp to %s", tc.mu.txn.ReadTimestamp)
+}
+
 // SetReadSeqNum is part of the TxnSender interface.
 func (tc *TxnCoordSender) SetReadSeqNum(seq enginepb.TxnSeq) error {
 	tc.mu.Lock()
@@ -1380,11 +1464,40 @@ func (tc *TxnCoordSender) ClearTxnRetryableErr(ctx context.Context) {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
 	if tc.mu.txnState == txnRetryableError {
+		// If the epoch bump was deferred, the client has now decided to retry
+		// the entire transaction, so perform it.
+		if tc.epochBumpDeferredLocked() {
+			tc.bumpEpochLocked(ctx, &tc.mu.storedRetryableErr.Transaction)
+		}
 		tc.mu.storedRetryableErr = nil
 		tc.mu.txnState = txnPending
 	}
 }
 
+// PrepareForPartialRetry is part of the TxnSender interface.
+func (tc *TxnCoordSender) PrepareForPartialRetry(ctx context.Context) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.txnState != txnRetryableError {
+		return errors.AssertionFailedf(
+			"cannot prepare for partial retry in state %s", tc.mu.txnState)
+	}
+	retErr := tc.mu.storedRetryableErr
+	if !tc.epochBumpDeferredLocked() {
+		return retErr
+	}
+	log.VEventf(ctx, 2, "partially retrying transaction: %s because of a retryable error: %s",
+		tc.mu.txn, retErr)
+
+	// Carry over the timestamp and priority that were prepared for the next
+	// attempt, but remain in the current epoch.
+	tc.mu.txn.BumpReadTimestamp(retErr.Transaction.ReadTimestamp)
+	tc.mu.txn.UpgradePriority(retErr.Transaction.Priority)
+	tc.mu.storedRetryableErr = nil
+	tc.mu.txnState = txnPending
+	return nil
+}
+
 // HasPerformedReads is part of the TxnSender interface.
 func (tc *TxnCoordSender) HasPerformedReads() bool {
 	tc.mu.Lock()
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
@@ -3016,3 +3017,189 @@ func TestTxnCoordSenderSetFixedTimestamp(t *testing.T) {
 		})
 	}
 }
+
+// TestTxnCoordSenderStepReadTimestamp verifies that stepping a transaction
+// with allowReadTimestampStep establishes a new read snapshot if and only if
+// the transaction's isolation level calls for per-statement read snapshots.
+func TestTxnCoordSenderStepReadTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name     string
+		isoLevel isolation.Level
+		fixedTS  bool
+		expStep  bool
+	}{
+		{
+			name:     "serializable",
+			isoLevel: isolation.Serializable,
+		},
+		{
+			name:     "read committed",
+			isoLevel: isolation.ReadCommitted,
+			expStep:  true,
+		},
+		{
+			name:     "read committed, fixed timestamp",
+			isoLevel: isolation.ReadCommitted,
+			fixedTS:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := createTestDB(t)
+			defer s.Stop()
+
+			key := roachpb.Key("a")
+			txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+			require.NoError(t, txn.SetIsoLevel(test.isoLevel))
+			if test.fixedTS {
+				require.NoError(t, txn.SetFixedTimestamp(ctx, s.Clock.Now()))
+			}
+			txn.ConfigureStepping(ctx, kv.SteppingEnabled)
+
+			res, err := txn.Get(ctx, key)
+			require.NoError(t, err)
+			require.False(t, res.Exists())
+			readTS := txn.ReadTimestamp()
+
+			// Commit a value from another transaction.
+			require.NoError(t, s.DB.Put(ctx, key, "v"))
+
+			// Stepping without allowReadTimestampStep never moves the read
+			// timestamp.
+			require.NoError(t, txn.Step(ctx, false /* allowReadTimestampStep */))
+			require.Equal(t, readTS, txn.ReadTimestamp())
+
+			require.NoError(t, txn.Step(ctx, true /* allowReadTimestampStep */))
+			if !test.expStep {
+				require.Equal(t, readTS, txn.ReadTimestamp())
+				return
+			}
+			require.True(t, readTS.Less(txn.ReadTimestamp()))
+
+			// The new read snapshot observes the value committed in between.
+			res, err = txn.Get(ctx, key)
+			require.NoError(t, err)
+			require.True(t, res.Exists())
+			require.NoError(t, txn.Commit(ctx))
+		})
+	}
+}
+
+// TestTxnCoordSenderPrepareForPartialRetry verifies that the TxnCoordSender
+// defers the epoch bump on retryable errors hit by transactions that use
+// per-statement read snapshots, so that they can be recovered from either by
+// retrying only the failed statement (PrepareForPartialRetry) or by restarting
+// the entire transaction (PrepareForRetry).
+func TestTxnCoordSenderPrepareForPartialRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	errKey := roachpb.Key("a")
+	// injectReason is the reason of the retry error to inject, plus one. It is
+	// reset after the error is injected.
+	var injectReason int32
+	var retryTS atomic.Value
+	knobs := &kvserver.StoreTestingKnobs{
+		TestingRequestFilter: func(_ context.Context, ba *roachpb.BatchRequest) *roachpb.Error {
+			if ba.Txn == nil {
+				return nil
+			}
+			if g, ok := ba.GetArg(roachpb.Get); ok && g.(*roachpb.GetRequest).Key.Equal(errKey) {
+				reason := roachpb.TransactionRetryReason(atomic.SwapInt32(&injectReason, 0) - 1)
+				if reason < 0 {
+					return nil
+				}
+				txn := ba.Txn.Clone()
+				txn.WriteTimestamp = txn.WriteTimestamp.Next()
+				retryTS.Store(txn.WriteTimestamp)
+				return roachpb.NewErrorWithTxn(roachpb.NewTransactionRetryError(reason, "injected"), txn)
+			}
+			return nil
+		},
+	}
+
+	for _, test := range []struct {
+		name     string
+		isoLevel isolation.Level
+		reason   roachpb.TransactionRetryReason
+		// partialRetry is whether the test attempts a partial retry, instead
+		// of a full restart.
+		partialRetry bool
+		// expDeferred is whether the epoch bump is expected to be deferred.
+		expDeferred bool
+	}{
+		{
+			name:         "read committed, partial retry",
+			isoLevel:     isolation.ReadCommitted,
+			reason:       roachpb.RETRY_REASON_UNKNOWN,
+			partialRetry: true,
+			expDeferred:  true,
+		},
+		{
+			name:        "read committed, full retry",
+			isoLevel:    isolation.ReadCommitted,
+			reason:      roachpb.RETRY_REASON_UNKNOWN,
+			expDeferred: true,
+		},
+		{
+			name:         "read committed, async write failure",
+			isoLevel:     isolation.ReadCommitted,
+			reason:       roachpb.RETRY_ASYNC_WRITE_FAILURE,
+			partialRetry: true,
+		},
+		{
+			name:         "serializable",
+			isoLevel:     isolation.Serializable,
+			reason:       roachpb.RETRY_REASON_UNKNOWN,
+			partialRetry: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := createTestDBWithKnobs(t, knobs)
+			defer s.Stop()
+
+			txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+			require.NoError(t, txn.SetIsoLevel(test.isoLevel))
+			require.NoError(t, txn.Put(ctx, "b", "v"))
+
+			atomic.StoreInt32(&injectReason, int32(test.reason)+1)
+			_, err := txn.Get(ctx, errKey)
+			require.True(t, errors.HasType(err, (*roachpb.TransactionRetryWithProtoRefreshError)(nil)),
+				"expected TransactionRetryWithProtoRefreshError, got: %v", err)
+
+			if !test.expDeferred {
+				require.Equal(t, enginepb.TxnEpoch(1), txn.Epoch())
+				if test.partialRetry {
+					require.Error(t, txn.PrepareForPartialRetry(ctx))
+				}
+				return
+			}
+			require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+
+			if !test.partialRetry {
+				txn.PrepareForRetry(ctx)
+				require.Equal(t, enginepb.TxnEpoch(1), txn.Epoch())
+				return
+			}
+
+			require.NoError(t, txn.PrepareForPartialRetry(ctx))
+			// The transaction remains in its epoch, but moves its read timestamp
+			// beyond the cause of the error.
+			require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+			require.True(t, retryTS.Load().(hlc.Timestamp).LessEq(txn.ReadTimestamp()))
+
+			// The retried operation succeeds and the write performed before it is
+			// preserved.
+			_, err = txn.Get(ctx, errKey)
+			require.NoError(t, err)
+			res, err := txn.Get(ctx, "b")
+			require.NoError(t, err)
+			require.True(t, res.Exists())
+			require.NoError(t, txn.Commit(ctx))
+		})
+	}
+}
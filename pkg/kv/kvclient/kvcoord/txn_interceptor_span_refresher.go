@@ -434,9 +434,13 @@ func (sr *txnSpanRefresher) maybeRefreshPreemptivelyLocked(
 	// If true, tryRefreshTxnSpans will trivially succeed.
 	refreshFree := ba.CanForwardReadTimestamp
 
-	// If true, this batch is guaranteed to fail without a refresh.
+	// If true, this batch is guaranteed to fail without a refresh. Transactions
+	// that tolerate write skew are permitted to commit with a read timestamp
+	// below their write timestamp, so they never need to refresh before
+	// committing.
 	args, hasET := ba.GetArg(roachpb.EndTxn)
-	refreshInevitable := hasET && args.(*roachpb.EndTxnRequest).Commit
+	refreshInevitable := hasET && args.(*roachpb.EndTxnRequest).Commit &&
+		!ba.Txn.IsoLevel.ToleratesWriteSkew()
 
 	// If neither condition is true, defer the refresh.
 	if !refreshFree && !refreshInevitable && !force {
@@ -706,6 +710,16 @@ func (sr *txnSpanRefresher) epochBumpedLocked() {
 	sr.refreshedTimestamp.Reset()
 }
 
+// resetRefreshSpansLocked clears the refresher's tracked read spans. It is
+// called when the transaction's read timestamp is advanced to establish a new
+// read snapshot, at which point the reads performed under the previous snapshot
+// no longer need to be refreshed.
+func (sr *txnSpanRefresher) resetRefreshSpansLocked(readTimestamp hlc.Timestamp) {
+	sr.refreshFootprint.clear()
+	sr.refreshInvalid = false
+	sr.refreshedTimestamp.Forward(readTimestamp)
+}
+
 // createSavepointLocked is part of the txnInterceptor interface.
 func (sr *txnSpanRefresher) createSavepointLocked(ctx context.Context, s *savepoint) {
 	s.refreshSpans = make([]roachpb.Span, len(sr.refreshFootprint.asSlice()))
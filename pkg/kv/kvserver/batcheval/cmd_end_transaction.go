@@ -483,8 +483,11 @@ func IsEndTxnTriggeringRetryError(
 		isTxnPushed := txn.WriteTimestamp != readTimestamp
 
 		// Return a transaction retry error if the commit timestamp isn't equal to
-		// the txn timestamp.
-		if isTxnPushed {
+		// the txn timestamp, unless the transaction's isolation level tolerates
+		// write skew. Such transactions are permitted to commit at a timestamp
+		// above their read timestamp; the WriteTooOld check above still protects
+		// them against lost updates.
+		if isTxnPushed && !txn.IsoLevel.ToleratesWriteSkew() {
 			retry, reason = true, roachpb.RETRY_SERIALIZABLE
 		}
 	}
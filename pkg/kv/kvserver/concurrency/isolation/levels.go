@@ -0,0 +1,31 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package isolation provides type definitions for isolation level-related
+// concepts used by concurrency control in the key-value layer.
+package isolation
+
+// ToleratesWriteSkew returns whether the isolation level permits write skew.
+// Transactions running at such isolation levels may commit even if their read
+// timestamp and their write timestamp have diverged, without first refreshing
+// their reads to the write timestamp.
+func (l Level) ToleratesWriteSkew() bool {
+	return l == ReadCommitted
+}
+
+// PerStatementReadSnapshot returns whether the isolation level establishes a
+// new read snapshot for each statement in a transaction, as opposed to using a
+// single read snapshot for the entire transaction.
+func (l Level) PerStatementReadSnapshot() bool {
+	return l == ReadCommitted
+}
+
+// SafeValue implements redact.SafeValue.
+func (Level) SafeValue() {}
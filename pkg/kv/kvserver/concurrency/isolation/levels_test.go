@@ -0,0 +1,39 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package isolation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToleratesWriteSkew(t *testing.T) {
+	exp := map[Level]bool{
+		Serializable:  false,
+		ReadCommitted: true,
+	}
+	for l, want := range exp {
+		require.Equal(t, want, l.ToleratesWriteSkew(), l.String())
+	}
+	require.Len(t, exp, len(Level_name))
+}
+
+func TestPerStatementReadSnapshot(t *testing.T) {
+	exp := map[Level]bool{
+		Serializable:  false,
+		ReadCommitted: true,
+	}
+	for l, want := range exp {
+		require.Equal(t, want, l.PerStatementReadSnapshot(), l.String())
+	}
+	require.Len(t, exp, len(Level_name))
+}
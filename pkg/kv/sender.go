@@ -13,6 +13,7 @@ package kv
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -116,6 +117,13 @@ type TxnSender interface {
 	// SetUserPriority sets the txn's priority.
 	SetUserPriority(roachpb.UserPriority) error
 
+	// SetIsoLevel sets the txn's isolation level. It is an error to change the
+	// isolation level of a transaction once it has performed any operations.
+	SetIsoLevel(isolation.Level) error
+
+	// IsoLevel returns the txn's isolation level.
+	IsoLevel() isolation.Level
+
 	// SetDebugName sets the txn's debug name.
 	SetDebugName(name string)
 
@@ -272,8 +280,14 @@ type TxnSender interface {
 	// Step() can only be called after stepping mode has been enabled
 	// using ConfigureStepping(SteppingEnabled).
 	//
+	// If allowReadTimestampStep is set and the transaction's isolation level
+	// establishes a new read snapshot for each statement (see
+	// isolation.Level.PerStatementReadSnapshot), the sequencing point also
+	// advances the transaction's read timestamp to the current time, so that
+	// subsequent operations observe all values committed before the step.
+	//
 	// The method is idempotent.
-	Step(context.Context) error
+	Step(ctx context.Context, allowReadTimestampStep bool) error
 
 	// SetReadSeqNum sets the read sequence point for the current transaction.
 	SetReadSeqNum(seq enginepb.TxnSeq) error
@@ -327,6 +341,19 @@ type TxnSender interface {
 	// ClearTxnRetryableErr clears the retryable error, if any.
 	ClearTxnRetryableErr(ctx context.Context)
 
+	// PrepareForPartialRetry is used by transactions that establish a new read
+	// snapshot for each statement to recover from a retryable error without
+	// restarting the entire transaction. It is only legal to call when the
+	// TxnSender is in a retryable error state that did not abort the
+	// transaction; in all other cases, the stored retryable error is returned.
+	//
+	// On success, the retryable error is cleared without bumping the
+	// transaction's epoch and the transaction's timestamp is forwarded beyond
+	// the cause of the error. The caller is then expected to roll back to a
+	// savepoint taken before the failed operation and to call Step() before
+	// retrying it.
+	PrepareForPartialRetry(ctx context.Context) error
+
 	// HasPerformedReads returns true if a read has been performed.
 	HasPerformedReads() bool
 
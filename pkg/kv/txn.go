@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -300,6 +301,26 @@ func (txn *Txn) SetUserPriority(userPriority roachpb.UserPriority) error {
 	return txn.mu.sender.SetUserPriority(userPriority)
 }
 
+// SetIsoLevel sets the transaction's isolation level. Transactions default to
+// Serializable isolation. The isolation level must be set before any operations
+// are performed on the transaction.
+func (txn *Txn) SetIsoLevel(isoLevel isolation.Level) error {
+	if txn.typ != RootTxn {
+		panic(errors.AssertionFailedf("SetIsoLevel() called on leaf txn"))
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.SetIsoLevel(isoLevel)
+}
+
+// IsoLevel returns the transaction's isolation level.
+func (txn *Txn) IsoLevel() isolation.Level {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.IsoLevel()
+}
+
 // TestingSetPriority sets the transaction priority. It is intended for
 // internal (testing) use only.
 func (txn *Txn) TestingSetPriority(priority enginepb.TxnPriority) {
@@ -973,6 +994,26 @@ func (txn *Txn) exec(ctx context.Context, fn func(context.Context, *Txn) error)
 	return err
 }
 
+// PrepareForPartialRetry is like PrepareForRetry, except that it does not
+// restart the transaction. Instead, it clears the retryable error and keeps
+// all of the transaction's state (including its epoch) intact, so that the
+// caller can roll back to a savepoint and retry only the operation that
+// encountered the error. It is only supported for transactions whose isolation
+// level establishes a new read snapshot for each statement, and only for
+// retryable errors that did not abort the transaction; in all other cases the
+// retryable error is returned and the caller should fall back to retrying the
+// entire transaction.
+func (txn *Txn) PrepareForPartialRetry(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		panic(errors.WithContextTags(
+			errors.AssertionFailedf("PrepareForPartialRetry() called on leaf txn"), ctx))
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.PrepareForPartialRetry(ctx)
+}
+
 // PrepareForRetry needs to be called before a retry to perform some
 // book-keeping and clear errors when possible.
 func (txn *Txn) PrepareForRetry(ctx context.Context) {
@@ -1338,7 +1379,14 @@ func (txn *Txn) UpdateStateOnRemoteRetryableErr(ctx context.Context, pErr *roach
 	}
 
 	pErr = txn.mu.sender.UpdateStateOnRemoteRetryableErr(ctx, pErr)
-	txn.replaceRootSenderIfTxnAbortedLocked(ctx, pErr.GetDetail().(*roachpb.TransactionRetryWithProtoRefreshError), origTxnID)
+	retryErr := pErr.GetDetail().(*roachpb.TransactionRetryWithProtoRefreshError)
+	if txn.mu.sender.IsoLevel().PerStatementReadSnapshot() && !retryErr.PrevTxnAborted() {
+		// Leave the retryable error in place so that the caller can decide
+		// between retrying only the current statement (PrepareForPartialRetry)
+		// and retrying the entire transaction (PrepareForRetry).
+		return pErr.GoError()
+	}
+	txn.replaceRootSenderIfTxnAbortedLocked(ctx, retryErr, origTxnID)
 
 	return pErr.GoError()
 }
@@ -1485,10 +1533,15 @@ func (txn *Txn) Active() bool {
 //
 // In step-wise execution, reads operate at a snapshot established at
 // the last step, instead of the latest write if not yet enabled.
-func (txn *Txn) Step(ctx context.Context) error {
+//
+// If allowReadTimestampStep is set and the transaction uses per-statement read
+// snapshots (e.g. READ COMMITTED isolation), the step also advances the
+// transaction's read timestamp. Callers should only set it at statement
+// boundaries.
+func (txn *Txn) Step(ctx context.Context, allowReadTimestampStep bool) error {
 	txn.mu.Lock()
 	defer txn.mu.Unlock()
-	return txn.mu.sender.Step(ctx)
+	return txn.mu.sender.Step(ctx, allowReadTimestampStep)
 }
 
 // SetReadSeqNum sets the read sequence number for this transaction.
@@ -1141,6 +1141,18 @@ func (t *Transaction) BumpEpoch() {
 	t.Epoch++
 }
 
+// BumpReadTimestamp forwards the transaction's read timestamp to the specified
+// timestamp, forwarding its write timestamp along with it if necessary. Unlike
+// Refresh, the method does not assume that the transaction's prior reads have
+// been validated at the new timestamp. It is used by transactions whose
+// isolation level establishes a new read snapshot for each statement, which
+// tolerate the invalidation of reads performed by earlier statements.
+func (t *Transaction) BumpReadTimestamp(timestamp hlc.Timestamp) {
+	t.ReadTimestamp.Forward(timestamp)
+	t.WriteTimestamp.Forward(t.ReadTimestamp)
+	t.WriteTooOld = false
+}
+
 // Refresh reconfigures a transaction to account for a read refresh up to the
 // specified timestamp. For details about transaction read refreshes, see the
 // comment on txnSpanRefresher.
@@ -1474,6 +1486,8 @@ func PrepareTransactionForRetry(
 		)
 		// Use the priority communicated back by the server.
 		txn.Priority = errTxnPri
+		// Preserve the original transaction's isolation level.
+		txn.IsoLevel = pErr.GetTxn().IsoLevel
 	case *ReadWithinUncertaintyIntervalError:
 		txn.WriteTimestamp.Forward(tErr.RetryTimestamp())
 	case *TransactionPushError:
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils/zerofields"
@@ -512,6 +513,7 @@ var nonZeroTxn = Transaction{
 		Priority:          957356782,
 		Sequence:          123,
 		CoordinatorNodeID: 3,
+		IsoLevel:          isolation.ReadCommitted,
 	},
 	Name:                   "name",
 	Status:                 COMMITTED,
@@ -554,6 +556,7 @@ func TestTransactionUpdate(t *testing.T) {
 	txn3.Name = "carl"
 	txn3.Priority = 123
 	txn3.CoordinatorNodeID = 3
+	txn3.IsoLevel = isolation.ReadCommitted
 	txn3.Update(&txn)
 
 	expTxn3 := txn
@@ -569,6 +572,7 @@ func TestTransactionUpdate(t *testing.T) {
 	txn4.Name = "carl"
 	txn4.Priority = 123
 	txn4.CoordinatorNodeID = 3
+	txn4.IsoLevel = isolation.ReadCommitted
 	txn4.Update(&txn)
 
 	expTxn4 := txn
@@ -609,6 +613,7 @@ func TestTransactionUpdate(t *testing.T) {
 	txn5.Name = "carl"
 	txn5.Priority = 123
 	txn5.CoordinatorNodeID = 3
+	txn5.IsoLevel = isolation.ReadCommitted
 	txn5.Update(&txn)
 
 	expTxn5 := txn
@@ -758,6 +763,28 @@ func TestTransactionRestart(t *testing.T) {
 	require.Equal(t, expTxn, txn)
 }
 
+func TestTransactionBumpReadTimestamp(t *testing.T) {
+	txn := nonZeroTxn
+	txn.BumpReadTimestamp(makeTS(25, 1))
+
+	expTxn := nonZeroTxn
+	expTxn.WriteTimestamp = makeTS(25, 1)
+	expTxn.ReadTimestamp = makeTS(25, 1)
+	expTxn.WriteTooOld = false
+	require.Equal(t, expTxn, txn)
+
+	// Bumping to a timestamp below the write timestamp leaves the write
+	// timestamp untouched.
+	txn = nonZeroTxn
+	txn.ReadTimestamp = makeTS(10, 1)
+	txn.BumpReadTimestamp(makeTS(15, 1))
+
+	expTxn = nonZeroTxn
+	expTxn.ReadTimestamp = makeTS(15, 1)
+	expTxn.WriteTooOld = false
+	require.Equal(t, expTxn, txn)
+}
+
 func TestTransactionRefresh(t *testing.T) {
 	txn := nonZeroTxn
 	txn.Refresh(makeTS(25, 1))
@@ -81,6 +81,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
 	"github.com/cockroachdb/cockroach/pkg/sql/scheduledlogging"
@@ -881,6 +882,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		SessionRegistry:           cfg.sessionRegistry,
 		ClosedSessionCache:        cfg.closedSessionCache,
 		ContentionRegistry:        contentionRegistry,
+		NotificationRegistry:      pgnotify.NewRegistry(),
 		SQLLiveness:               cfg.sqlLivenessProvider,
 		JobRegistry:               jobRegistry,
 		VirtualSchemas:            virtualSchemas,
@@ -1398,6 +1400,13 @@ func (s *SQLServer) preStart(
 	if err := s.execCfg.TableStatsCache.Start(ctx, s.execCfg.Codec, s.execCfg.RangeFeedFactory); err != nil {
 		return err
 	}
+	notificationsWatcher := pgnotify.NewWatcher(
+		s.execCfg.Codec, s.execCfg.Clock, s.execCfg.RangeFeedFactory, s.execCfg.DB,
+		stopper, s.execCfg.Settings, s.execCfg.NotificationRegistry,
+	)
+	if err := notificationsWatcher.Start(ctx, s.execCfg.SystemTableIDResolver); err != nil {
+		return err
+	}
 
 	s.leaseMgr.RefreshLeases(ctx, stopper, s.execCfg.DB)
 	s.leaseMgr.PeriodicallyRefreshSomeLeases(ctx)
@@ -0,0 +1,253 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+// AlterDomain alters a domain type.
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN || desc.Domain == nil {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	domain := n.desc.Domain
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = nil
+			break
+		}
+		typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+			params.ctx, t.Default, domain.BaseType, "DEFAULT", params.p.SemaCtx(),
+			volatility.Volatile, true, /* allowAssignmentCast */
+		)
+		if err != nil {
+			return err
+		}
+		s := tree.Serialize(typedExpr)
+		domain.DefaultExpr = &s
+
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull && !domain.NotNull {
+			// The existing values are validated by the type schema change job.
+			domain.NotNullValidating = true
+		}
+		if !t.NotNull {
+			domain.NotNullValidating = false
+		}
+		domain.NotNull = t.NotNull
+
+	case *tree.AlterDomainAddConstraint:
+		c := t.Constraint
+		switch c.Kind {
+		case tree.DomainConstraintNull:
+			return pgerror.New(pgcode.Syntax,
+				"NULL constraints are not supported for ALTER DOMAIN ADD CONSTRAINT")
+		case tree.DomainConstraintNotNull:
+			if !t.NotValid && !domain.NotNull {
+				domain.NotNullValidating = true
+			}
+		}
+		numChecks := len(domain.Checks)
+		if err := addDomainConstraints(
+			params.ctx, params.p.SemaCtx(), n.desc.Name, domain, tree.DomainConstraints{c},
+		); err != nil {
+			return err
+		}
+		// The existing values are validated against the new CHECK constraint by
+		// the type schema change job, once the new version of the domain is
+		// leased on all nodes, so that it's already enforced on new values.
+		if !t.NotValid && len(domain.Checks) > numChecks {
+			domain.Checks[len(domain.Checks)-1].Validating = true
+		}
+
+	case *tree.AlterDomainDropConstraint:
+		idx := findDomainCheck(domain, string(t.Constraint))
+		if idx == -1 {
+			if t.IfExists {
+				params.p.BufferClientNotice(
+					params.ctx,
+					pgnotice.Newf("constraint %q of domain %q does not exist, skipping",
+						t.Constraint, n.desc.Name),
+				)
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.Checks = append(domain.Checks[:idx], domain.Checks[idx+1:]...)
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+		})
+}
+
+// forEachDomainColumn calls fn for every public column of a table whose type
+// is the given domain.
+func forEachDomainColumn(
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	typeDesc catalog.TypeDescriptor,
+	fn func(tbl catalog.TableDescriptor, col catalog.Column) error,
+) error {
+	domainOID := catid.TypeIDToOID(typeDesc.GetID())
+	for _, id := range typeDesc.TypeDesc().ReferencingDescriptorIDs {
+		d, err := descsCol.ByID(txn).Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok || tbl.IsView() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.GetType().Oid() != domainOID {
+				continue
+			}
+			if err := fn(tbl, col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateDomainNotNull verifies that no column of the given domain type
+// contains NULL values.
+func validateDomainNotNull(
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	ie sqlutil.InternalExecutor,
+	typeDesc catalog.TypeDescriptor,
+) error {
+	return forEachDomainColumn(ctx, txn, descsCol, typeDesc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		colName := tree.Name(col.GetName())
+		stmt := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s IS NULL LIMIT 1`,
+			tbl.GetID(), colName.String())
+		row, err := ie.QueryRowEx(ctx, "validate-domain-not-null", txn, sessiondata.RootUserSessionDataOverride, stmt)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.NotNullViolation,
+				"column %q of table %q contains null values", col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+// validateDomainCheck verifies that all values in columns of the given domain
+// type satisfy the given CHECK constraint expression.
+func validateDomainCheck(
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	ie sqlutil.InternalExecutor,
+	typeDesc catalog.TypeDescriptor,
+	expr tree.Expr,
+) error {
+	return forEachDomainColumn(ctx, txn, descsCol, typeDesc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		colName := tree.Name(col.GetName())
+		value := &tree.CastExpr{
+			Expr:       &colName,
+			Type:       typeDesc.TypeDesc().Domain.BaseType,
+			SyntaxMode: tree.CastShort,
+		}
+		replaced, err := replaceDomainValue(expr, value)
+		if err != nil {
+			return err
+		}
+		stmt := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE NOT (%s) LIMIT 1`,
+			tbl.GetID(), tree.Serialize(replaced))
+		row, err := ie.QueryRowEx(ctx, "validate-domain-check", txn, sessiondata.RootUserSessionDataOverride, stmt)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
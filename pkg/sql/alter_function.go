@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
@@ -76,8 +77,9 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	}
 	for _, option := range n.n.Options {
 		// Note that language and function body cannot be altered, and it's blocked
-		// from parser level with "common_func_opt_item" syntax.
-		err := setFuncOption(params, fnDesc, option)
+		// from parser level with "common_func_opt_item" syntax. The language is
+		// only used to process the function body, so it is not passed here.
+		err := setFuncOption(params, fnDesc, option, 0 /* lang */)
 		if err != nil {
 			return err
 		}
@@ -382,13 +384,22 @@ func (p *planner) mustGetMutableFunctionForAlter(
 
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionOverload {
 	ret := descpb.SchemaDescriptor_FunctionOverload{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
-	}
+		ID:          fnDesc.GetID(),
+		ArgTypes:    make([]*types.T, 0, len(fnDesc.GetParams())),
+		ReturnType:  fnDesc.ReturnType.Type,
+		ReturnSet:   fnDesc.ReturnType.ReturnSet,
+		IsAggregate: fnDesc.Aggregate != nil,
+		IsProcedure: fnDesc.IsProcedure,
+	}
+	// OUT parameters are not part of the signature.
 	for i := range fnDesc.Params {
-		ret.ArgTypes[i] = fnDesc.Params[i].Type
+		switch fnDesc.Params[i].Class {
+		case catpb.Function_Param_OUT:
+			continue
+		case catpb.Function_Param_VARIADIC:
+			ret.IsVariadic = true
+		}
+		ret.ArgTypes = append(ret.ArgTypes, fnDesc.Params[i].Type)
 	}
 	return ret
 }
@@ -137,6 +137,14 @@ func (n *alterIndexNode) startExec(params runParams) error {
 					return err
 				}
 			}
+		case *tree.AlterIndexAttachPartition:
+			// Partitions created by CREATE TABLE ... PARTITION OF are partitions of
+			// the primary index of the parent table, so they have no indexes of
+			// their own which could be attached.
+			return unimplemented.New(
+				"ALTER INDEX ... ATTACH PARTITION",
+				"ALTER INDEX ... ATTACH PARTITION is not supported",
+			)
 		default:
 			return errors.AssertionFailedf(
 				"unsupported alter command: %T", cmd)
@@ -135,6 +135,18 @@ func (p *planner) checkPasswordOptionConstraints(
 	return nil
 }
 
+// checkBypassRLSOptionConstraints checks that only admins may grant or revoke
+// the BYPASSRLS role option, since it exempts a role from the row-level
+// security policies of every table.
+func (p *planner) checkBypassRLSOptionConstraints(
+	ctx context.Context, roleOptions roleoption.List,
+) error {
+	if roleOptions.Contains(roleoption.BYPASSRLS) || roleOptions.Contains(roleoption.NOBYPASSRLS) {
+		return p.RequireAdminRole(ctx, "grant or revoke BYPASSRLS")
+	}
+	return nil
+}
+
 func (n *alterRoleNode) startExec(params runParams) error {
 	var opName string
 	if n.isRole {
@@ -182,6 +194,9 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		if err := params.p.checkPasswordOptionConstraints(params.ctx, n.roleOptions, false /* newUser */); err != nil {
 			return err
 		}
+		if err := params.p.checkBypassRLSOptionConstraints(params.ctx, n.roleOptions); err != nil {
+			return err
+		}
 	}
 
 	// Check if role exists.
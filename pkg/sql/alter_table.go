@@ -180,6 +180,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 			return errors.Newf("table %q does not have a primary key, cannot perform%s", n.tableDesc.Name, tree.AsString(cmd))
 		}
 
+		// ATTACH PARTITION is translated into a PARTITION BY of the primary index
+		// which includes the new partition.
+		if t, ok := cmd.(*tree.AlterTableAttachPartition); ok {
+			partitionBy, err := partitionByWithAttachedPartition(params.ExecCfg().Codec, n.tableDesc, t)
+			if err != nil {
+				return err
+			}
+			cmd = &tree.AlterTablePartitionByTable{
+				PartitionByTable: &tree.PartitionByTable{PartitionBy: partitionBy},
+			}
+		}
+
 		switch t := cmd.(type) {
 		case *tree.AlterTableAddColumn:
 			if t.ColumnDef.Unique.WithoutIndex {
@@ -290,6 +302,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 					StoreColumnNames: d.Storing.ToStrings(),
 					CreatedAtNanos:   params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano(),
 				}
+				if d.Deferrable.IsDeferrable() {
+					// A deferrable unique constraint is backed by a non-unique index
+					// with an auto-generated name, and is enforced as a UNIQUE WITHOUT
+					// INDEX constraint. See NewTableDesc.
+					idx.Name = ""
+					idx.Unique = false
+				}
 				if err := idx.FillColumns(d.Columns); err != nil {
 					return err
 				}
@@ -349,6 +368,77 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+
+				if d.Deferrable.IsDeferrable() {
+					if err := addUniqueWithoutIndexTableDef(
+						params.ctx,
+						params.EvalContext(),
+						params.SessionData(),
+						d,
+						n.tableDesc,
+						*tn,
+						NonEmptyTable,
+						t.ValidationBehavior,
+						params.p.SemaCtx(),
+					); err != nil {
+						return err
+					}
+				}
+			case *tree.ExcludeConstraintTableDef:
+				// Add the index that backs the constraint. The existing rows are
+				// validated against the constraint once the index is backfilled.
+				idx, err := makeExclusionConstraintIndex(
+					params.ctx, params.ExecCfg().Settings, d, n.tableDesc, tn, params.p.SemaCtx(),
+				)
+				if err != nil {
+					return err
+				}
+				idx.CreatedAtNanos = params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano()
+				idx, err = params.p.configureIndexDescForNewIndexPartitioning(
+					params.ctx,
+					n.tableDesc,
+					idx,
+					nil, /* partitionByIndex */
+				)
+				if err != nil {
+					return err
+				}
+				if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
+					&idx, descpb.DescriptorMutation_ADD,
+				); err != nil {
+					return err
+				}
+				version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
+					return err
+				}
+				if err := params.p.configureZoneConfigForNewIndexPartitioning(
+					params.ctx,
+					n.tableDesc,
+					idx,
+				); err != nil {
+					return err
+				}
+				if n.tableDesc.IsLocalityRegionalByRow() {
+					if err := params.p.checkNoRegionChangeUnderway(
+						params.ctx,
+						n.tableDesc.GetParentID(),
+						"create an EXCLUDE CONSTRAINT on a REGIONAL BY ROW table",
+					); err != nil {
+						return err
+					}
+				}
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -673,6 +763,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableRowLevelSecurity:
+			changed := setRowLevelSecurity(n.tableDesc, t.Mode)
+			descriptorChanged = descriptorChanged || changed
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -1390,6 +1484,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1701,6 +1798,11 @@ func dropColumnImpl(
 		}
 	}
 
+	// Drop policies which reference the column.
+	if err := dropPoliciesUsingColumn(tableDesc, colToDrop, t.DropBehavior); err != nil {
+		return nil, err
+	}
+
 	// Drop check constraints which reference the column.
 	for _, check := range tableDesc.CheckConstraints() {
 		if check.Dropped() {
@@ -253,7 +253,7 @@ func (a *applyJoinNode) runNextRightSideIteration(params runParams, leftRow tree
 	}
 	plan := p.(*planComponents)
 	rowResultWriter := NewRowResultWriter(&a.run.rightRows)
-	if err := runPlanInsidePlan(ctx, params, plan, rowResultWriter); err != nil {
+	if err := runPlanInsidePlan(ctx, params, plan, rowResultWriter, tree.Rows); err != nil {
 		return err
 	}
 	a.run.rightRowsIterator = newRowContainerIterator(ctx, a.run.rightRows, a.rightTypes)
@@ -261,13 +261,19 @@ func (a *applyJoinNode) runNextRightSideIteration(params runParams, leftRow tree
 }
 
 // runPlanInsidePlan is used to run a plan and gather the results in the
-// resultWriter, as part of the execution of an "outer" plan.
+// resultWriter, as part of the execution of an "outer" plan. If stmtType is not
+// tree.Rows, only the number of rows affected by the plan is reported to the
+// resultWriter.
 func runPlanInsidePlan(
-	ctx context.Context, params runParams, plan *planComponents, resultWriter rowResultWriter,
+	ctx context.Context,
+	params runParams,
+	plan *planComponents,
+	resultWriter rowResultWriter,
+	stmtType tree.StatementReturnType,
 ) error {
 	defer plan.close(ctx)
 	recv := MakeDistSQLReceiver(
-		ctx, resultWriter, tree.Rows,
+		ctx, resultWriter, stmtType,
 		params.ExecCfg().RangeDescriptorCache,
 		params.p.Txn(),
 		params.ExecCfg().Clock,
@@ -1561,8 +1561,8 @@ func ValidateConstraint(
 			return ie.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(ctx, tableDesc, uwi.GetName(), uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(), indexIDForValidation, ie, txn, sessionData.User(), false)
+					return validateUniqueWithoutIndexConstraint(ctx, tableDesc, uwi.UniqueWithoutIndexDesc(),
+						indexIDForValidation, ie, txn, sessionData.User(), false)
 				},
 			)
 		default:
@@ -2739,12 +2739,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return ie.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				uc,
 				0, /* indexIDForValidation */
 				ie,
 				txn,
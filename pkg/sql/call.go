@@ -0,0 +1,140 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// procedureCall holds the state of the execution of a procedure invoked by a
+// CALL statement. The statements in the body of the procedure are executed by
+// the connExecutor one at a time. If the body contains a COMMIT or ROLLBACK
+// statement, the current transaction is finished and the CALL statement is
+// re-executed in a new transaction, resuming at the statement following the
+// COMMIT or ROLLBACK.
+type procedureCall struct {
+	// stmt is the CALL statement. It identifies the invocation that is resumed
+	// when the CALL statement is re-executed.
+	stmt *tree.Call
+	// params are the parameters of the procedure.
+	params tree.ParamTypes
+	// args are the values of the arguments passed to the procedure, one for
+	// each parameter. They are evaluated once, when the procedure is first
+	// invoked.
+	args tree.Datums
+	// body contains the statements in the body of the procedure.
+	body parser.Statements
+	// sessionOverrides is the session data that the body of the procedure is
+	// executed with, if it differs from that of the caller.
+	sessionOverrides *tree.RoutineSessionOverrides
+	// next is the index of the next statement in body to execute.
+	next int
+	// txnStart is the index of the first statement in body executed in the
+	// current txn. The procedure is resumed from this statement if the txn is
+	// retried.
+	txnStart int
+	// portalName is the name of the portal executing the CALL statement, if it
+	// was executed through the extended protocol. If keepPortal is set, the
+	// body finished the txn and the portal is kept open when the txn finishes,
+	// so that it can be executed again.
+	portalName string
+	keepPortal bool
+}
+
+// resolveProcedureCall resolves the procedure invoked by the given CALL
+// statement, evaluates its arguments and parses its body.
+func (p *planner) resolveProcedureCall(
+	ctx context.Context, call *tree.Call,
+) (*procedureCall, error) {
+	typedExpr, err := tree.TypeCheck(ctx, call.Proc, &p.semaCtx, types.Any)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := typedExpr.(*tree.FuncExpr)
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected procedure expression %T", typedExpr)
+	}
+	o := fn.ResolvedOverload()
+	if o == nil || !o.IsProcedure {
+		return nil, errors.AssertionFailedf("%s is not a procedure", fn.Func.String())
+	}
+	params, ok := o.Types.(tree.ParamTypes)
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected parameter types %T", o.Types)
+	}
+	procDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(o.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, procDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+
+	args := make(tree.Datums, len(fn.Exprs))
+	for i, expr := range fn.Exprs {
+		args[i], err = eval.Expr(ctx, p.EvalContext(), expr.(tree.TypedExpr))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The arguments passed in place of a VARIADIC parameter are collected into
+	// an array.
+	if o.Variadic {
+		numFixed := len(params) - 1
+		arr := tree.NewDArray(params[numFixed].Typ.ArrayContents())
+		for _, d := range args[numFixed:] {
+			if err := arr.Append(d); err != nil {
+				return nil, err
+			}
+		}
+		args = append(args[:numFixed:numFixed], arr)
+	}
+	if len(args) != len(params) {
+		return nil, errors.AssertionFailedf(
+			"expected %d arguments for procedure %s, got %d", len(params), fn.Func.String(), len(args),
+		)
+	}
+
+	// Cast the arguments to the types of the parameters, which may differ if
+	// an argument was implicitly cast during overload resolution.
+	for i := range args {
+		if args[i] == tree.DNull || args[i].ResolvedType().Identical(params[i].Typ) {
+			continue
+		}
+		args[i], err = eval.PerformCast(ctx, p.EvalContext(), args[i], params[i].Typ)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := parser.Parse(o.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &procedureCall{
+		stmt:             call,
+		params:           params,
+		args:             args,
+		body:             body,
+		sessionOverrides: o.SessionOverrides,
+	}, nil
+}
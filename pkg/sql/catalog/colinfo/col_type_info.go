@@ -91,8 +91,13 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 
 	case types.ArrayFamily:
 		if t.ArrayContents().Family() == types.ArrayFamily {
-			// Nested arrays are not supported as a column type.
-			return errors.Errorf("nested array unsupported as column type: %s", t.String())
+			if !version.IsActive(ctx, clusterversion.V23_1) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"multi-dimensional arrays not supported until version 23.1")
+			}
+			if err := types.CheckArrayDimensions(t); err != nil {
+				return err
+			}
 		}
 		if t.ArrayContents().Family() == types.JsonFamily {
 			// JSON arrays are not supported as a column type.
@@ -124,6 +129,12 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 				"TSVector/TSQuery not supported until version 23.1")
 		}
 
+	case types.RangeFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"range types not supported until version 23.1")
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -146,7 +157,10 @@ func ColumnTypeIsIndexable(t *types.T) bool {
 // using an inverted index.
 func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
-	case types.ArrayFamily, types.StringFamily:
+	case types.ArrayFamily:
+		// Multi-dimensional arrays cannot be inverted indexed.
+		return t.ArrayContents().Family() != types.ArrayFamily
+	case types.StringFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -165,6 +179,7 @@ func ColumnTypeIsOnlyInvertedIndexable(t *types.T) bool {
 	case types.JsonFamily:
 	case types.GeographyFamily:
 	case types.GeometryFamily:
+	case types.TSVectorFamily:
 	default:
 		return false
 	}
@@ -253,6 +253,43 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	if desc.IsMultiRegion() {
 		desc.validateMultiRegion(vea)
 	}
+
+	desc.validatePublicationsAndSubscriptions(vea)
+}
+
+// validatePublicationsAndSubscriptions checks that the publications and the
+// subscriptions of the database have unique names.
+func (desc *immutable) validatePublicationsAndSubscriptions(
+	vea catalog.ValidationErrorAccumulator,
+) {
+	publicationNames := make(map[string]struct{}, len(desc.Publications))
+	for i := range desc.Publications {
+		pub := &desc.Publications[i]
+		if pub.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty publication name"))
+		}
+		if _, ok := publicationNames[pub.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate publication name: %q", pub.Name))
+		}
+		publicationNames[pub.Name] = struct{}{}
+		if len(pub.TableIDs) == 0 {
+			vea.Report(errors.AssertionFailedf("publication %q has no tables", pub.Name))
+		}
+	}
+	subscriptionNames := make(map[string]struct{}, len(desc.Subscriptions))
+	for i := range desc.Subscriptions {
+		sub := &desc.Subscriptions[i]
+		if sub.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty subscription name"))
+		}
+		if _, ok := subscriptionNames[sub.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate subscription name: %q", sub.Name))
+		}
+		subscriptionNames[sub.Name] = struct{}{}
+		if sub.JobID == 0 {
+			vea.Report(errors.AssertionFailedf("subscription %q has no job", sub.Name))
+		}
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
@@ -506,6 +543,64 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// FindPublication returns the publication with the given name, or nil if
+// there is none.
+func (desc *Mutable) FindPublication(name string) *descpb.DatabaseDescriptor_Publication {
+	for i := range desc.Publications {
+		if desc.Publications[i].Name == name {
+			return &desc.Publications[i]
+		}
+	}
+	return nil
+}
+
+// AddPublication adds a publication to the database. The caller is
+// responsible for checking that no publication with the same name exists.
+func (desc *Mutable) AddPublication(pub descpb.DatabaseDescriptor_Publication) {
+	desc.Publications = append(desc.Publications, pub)
+}
+
+// RemovePublication removes the publication with the given name from the
+// database, and returns whether it existed.
+func (desc *Mutable) RemovePublication(name string) bool {
+	for i := range desc.Publications {
+		if desc.Publications[i].Name == name {
+			desc.Publications = append(desc.Publications[:i], desc.Publications[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// FindSubscription returns the subscription with the given name, or nil if
+// there is none.
+func (desc *Mutable) FindSubscription(name string) *descpb.DatabaseDescriptor_Subscription {
+	for i := range desc.Subscriptions {
+		if desc.Subscriptions[i].Name == name {
+			return &desc.Subscriptions[i]
+		}
+	}
+	return nil
+}
+
+// AddSubscription adds a subscription to the database. The caller is
+// responsible for checking that no subscription with the same name exists.
+func (desc *Mutable) AddSubscription(sub descpb.DatabaseDescriptor_Subscription) {
+	desc.Subscriptions = append(desc.Subscriptions, sub)
+}
+
+// RemoveSubscription removes the subscription with the given name from the
+// database, and returns whether it existed.
+func (desc *Mutable) RemoveSubscription(name string) bool {
+	for i := range desc.Subscriptions {
+		if desc.Subscriptions[i].Name == name {
+			desc.Subscriptions = append(desc.Subscriptions[:i], desc.Subscriptions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // GetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
 // interface.
 func (desc *immutable) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
@@ -74,6 +74,12 @@ type IndexID = catid.IndexID
 // ConstraintID is a custom type for TableDescriptor constraint IDs.
 type ConstraintID = catid.ConstraintID
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID = catid.TriggerID
+
+// PolicyID is a custom type for TableDescriptor policy IDs.
+type PolicyID = catid.PolicyID
+
 // DescriptorVersion is a custom type for TableDescriptor Versions.
 type DescriptorVersion uint64
 
@@ -217,7 +223,7 @@ func (desc *TableDescriptor) Adding() bool {
 
 // IsTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsTable() bool {
-	return !desc.IsView() && !desc.IsSequence()
+	return !desc.IsView() && !desc.IsSequence() && !desc.IsForeignTable()
 }
 
 // IsView implements the TableDescriptor interface.
@@ -245,6 +251,11 @@ func (desc *TableDescriptor) IsSequence() bool {
 	return desc.SequenceOpts != nil
 }
 
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
+}
+
 // IsVirtualTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsVirtualTable() bool {
 	return IsVirtualTable(desc.ID)
@@ -323,6 +334,48 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// IsExclusion returns true if the constraint is an exclusion constraint.
+func (u *UniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(u.ExclusionOperators) > 0
+}
+
+// DeferrableMode returns whether the checks of the constraint may be
+// postponed until the end of the transaction.
+func (u *UniqueWithoutIndexConstraint) DeferrableMode() tree.ConstraintDeferrable {
+	return constraintDeferrable(u.Deferrable, u.InitiallyDeferred)
+}
+
+// DeferrableMode returns whether the checks of the constraint may be
+// postponed until the end of the transaction.
+func (fk *ForeignKeyConstraint) DeferrableMode() tree.ConstraintDeferrable {
+	return constraintDeferrable(fk.Deferrable, fk.InitiallyDeferred)
+}
+
+// SetDeferrableMode sets the Deferrable and InitiallyDeferred fields of
+// the constraint.
+func (fk *ForeignKeyConstraint) SetDeferrableMode(mode tree.ConstraintDeferrable) {
+	fk.Deferrable = mode.IsDeferrable()
+	fk.InitiallyDeferred = mode == tree.ConstraintInitiallyDeferred
+}
+
+// SetDeferrableMode sets the Deferrable and InitiallyDeferred fields of
+// the constraint.
+func (u *UniqueWithoutIndexConstraint) SetDeferrableMode(mode tree.ConstraintDeferrable) {
+	u.Deferrable = mode.IsDeferrable()
+	u.InitiallyDeferred = mode == tree.ConstraintInitiallyDeferred
+}
+
+func constraintDeferrable(deferrable, initiallyDeferred bool) tree.ConstraintDeferrable {
+	switch {
+	case !deferrable:
+		return tree.ConstraintNotDeferrable
+	case initiallyDeferred:
+		return tree.ConstraintInitiallyDeferred
+	default:
+		return tree.ConstraintInitiallyImmediate
+	}
+}
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
@@ -275,6 +275,10 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// GetPublications returns the publications defined in this database.
+	GetPublications() []descpb.DatabaseDescriptor_Publication
+	// GetSubscriptions returns the subscriptions defined in this database.
+	GetSubscriptions() []descpb.DatabaseDescriptor_Subscription
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -297,6 +301,9 @@ type TableDescriptor interface {
 	// IsSequence returns true if the TableDescriptor actually describes a
 	// Sequence resource rather than a Table.
 	IsSequence() bool
+	// IsForeignTable returns true if the TableDescriptor actually describes a
+	// foreign table, whose rows are stored on a foreign server.
+	IsForeignTable() bool
 	// IsTemporary returns true if this is a temporary table.
 	IsTemporary() bool
 	// IsVirtualTable returns true if the TableDescriptor describes a
@@ -319,6 +326,9 @@ type TableDescriptor interface {
 	// GetSequenceOpts returns the sequence options for this table. Only valid if
 	// IsSequence is true.
 	GetSequenceOpts() *descpb.TableDescriptor_SequenceOpts
+	// GetForeignTable returns the foreign table options for this table. Only
+	// valid if IsForeignTable is true.
+	GetForeignTable() *descpb.TableDescriptor_ForeignTableOptions
 
 	// GetCreateQuery returns the full CREATE TABLE AS query that was used for
 	// table's creation. Only valid if IsAs is true.
@@ -630,6 +640,31 @@ type TableDescriptor interface {
 	// It's only non-nil if IsView is true.
 	GetDependsOnTypes() []descpb.ID
 
+	// GetTriggers returns the row-level triggers defined on this table.
+	GetTriggers() []descpb.TriggerDescriptor
+	// FindTriggerByID finds the trigger with the specified ID.
+	FindTriggerByID(id descpb.TriggerID) (*descpb.TriggerDescriptor, error)
+	// FindTriggerByName finds the trigger with the specified name.
+	FindTriggerByName(name string) (*descpb.TriggerDescriptor, error)
+	// GetNextTriggerID returns the next unused trigger ID for this table.
+	// Trigger IDs are unique per table, but not unique globally.
+	GetNextTriggerID() descpb.TriggerID
+
+	// GetPolicies returns the row-level security policies defined on this
+	// table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// FindPolicyByName finds the policy with the specified name.
+	FindPolicyByName(name string) (*descpb.PolicyDescriptor, error)
+	// GetNextPolicyID returns the next unused policy ID for this table.
+	// Policy IDs are unique per table, but not unique globally.
+	GetNextPolicyID() descpb.PolicyID
+	// GetRowLevelSecurityEnabled returns true if the policies of this table
+	// apply to the statements which read or write it.
+	GetRowLevelSecurityEnabled() bool
+	// GetRowLevelSecurityForced returns true if the policies of this table also
+	// apply to its owner.
+	GetRowLevelSecurityForced() bool
+
 	// AllConstraints returns all constraints in this table, regardless if
 	// they're enforced yet or not. The ordering of the constraints within this
 	// slice is partially defined:
@@ -890,6 +925,17 @@ type FunctionDescriptor interface {
 	// GetFunctionBody returns the function body string.
 	GetFunctionBody() string
 
+	// GetSecurity returns whether the function is executed with the privileges
+	// of the caller or of the owner.
+	GetSecurity() catpb.Function_Security
+
+	// GetSettings returns the session variable values that are set while the
+	// function is executed.
+	GetSettings() []descpb.FunctionDescriptor_Setting
+
+	// GetIsProcedure returns true if the routine is a stored procedure.
+	GetIsProcedure() bool
+
 	// GetParams returns a list of argument definition from the function.
 	GetParams() []descpb.FunctionDescriptor_Parameter
 
@@ -902,6 +948,10 @@ type FunctionDescriptor interface {
 	// GetDependedOnBy returns a list of back-references of this function.
 	GetDependedOnBy() []descpb.FunctionDescriptor_Reference
 
+	// GetAggregate returns the definition of the function if it is a
+	// user-defined aggregate, and nil otherwise.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
 	// FuncDesc returns the function's underlying protobuf descriptor.
 	FuncDesc() *descpb.FunctionDescriptor
 
@@ -55,7 +55,7 @@ func TestTxnWithStepping(t *testing.T) {
 				return errors.AssertionFailedf("expected no value, got %v", got)
 			}
 		}
-		if err := txn.Step(ctx); err != nil {
+		if err := txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			return err
 		}
 		{
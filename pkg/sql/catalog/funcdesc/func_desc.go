@@ -11,6 +11,8 @@
 package funcdesc
 
 import (
+	"strings"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
@@ -172,6 +174,12 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Add(agg.StateFuncID)
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.Add(agg.FinalFuncID)
+		}
+	}
 
 	return ret, nil
 }
@@ -202,6 +210,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	for i, param := range desc.Params {
 		if param.Type == nil {
 			vea.Report(errors.AssertionFailedf("type not set for arg %d", i))
+			continue
+		}
+		if param.Class == catpb.Function_Param_VARIADIC {
+			if param.Type.Family() != types.ArrayFamily {
+				vea.Report(errors.AssertionFailedf("variadic arg %d is not an array", i))
+			}
+			for _, other := range desc.Params[i+1:] {
+				if other.Class != catpb.Function_Param_OUT {
+					vea.Report(errors.AssertionFailedf("variadic arg %d is not the last input arg", i))
+					break
+				}
+			}
 		}
 	}
 
@@ -224,6 +244,43 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	for i, setting := range desc.Settings {
+		if setting.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty session variable name in setting #%d", i))
+		}
+		for _, other := range desc.Settings[:i] {
+			if other.Name == setting.Name {
+				vea.Report(errors.AssertionFailedf("duplicate setting for session variable %q", setting.Name))
+			}
+		}
+	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if agg.StateFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("state function not set for aggregate"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("state type not set for aggregate"))
+		}
+		if desc.FunctionBody != "" {
+			vea.Report(errors.AssertionFailedf("aggregate has a function body"))
+		}
+		if desc.IsProcedure {
+			vea.Report(errors.AssertionFailedf("aggregate is marked as a procedure"))
+		}
+	}
+
+	if desc.IsProcedure {
+		if t := desc.ReturnType.Type; t != nil && t.Family() != types.VoidFamily {
+			vea.Report(errors.AssertionFailedf("procedure has non-void return type %s", t.SQLString()))
+		}
+		for i, param := range desc.Params {
+			if param.Class == catpb.Function_Param_OUT {
+				vea.Report(errors.AssertionFailedf("procedure has OUT param %d", i))
+			}
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -253,6 +310,27 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		vea.Report(validateOutboundFunctionRef(agg.StateFuncID, vdg))
+		if agg.FinalFuncID != descpb.InvalidID {
+			vea.Report(validateOutboundFunctionRef(agg.FinalFuncID, vdg))
+		}
+	}
+}
+
+// validateOutboundFunctionRef validates the reference to the function with the
+// given ID from a user-defined aggregate.
+func validateOutboundFunctionRef(depID descpb.ID, vdg catalog.ValidationDescGetter) error {
+	fn, err := vdg.GetFunctionDescriptor(depID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depends-on function reference")
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("depends-on function %q (%d) is dropped",
+			fn.GetName(), fn.GetID())
+	}
+	return nil
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -274,13 +352,59 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	if agg := desc.Aggregate; agg != nil {
+		vea.Report(desc.validateOutboundFunctionRefBackReference(agg.StateFuncID, vdg))
+		if agg.FinalFuncID != descpb.InvalidID {
+			vea.Report(desc.validateOutboundFunctionRefBackReference(agg.FinalFuncID, vdg))
+		}
+	}
+
+	// The only functions which reference other functions are user-defined
+	// aggregates. All other inbound references are from tables.
 	for _, by := range desc.DependedOnBy {
+		if fn, err := vdg.GetFunctionDescriptor(by.ID); err == nil {
+			vea.Report(desc.validateInboundAggregateRef(fn))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 }
 
+// validateOutboundFunctionRefBackReference validates that the function with
+// the given ID, which is referenced by this aggregate, has a back-reference to
+// the aggregate.
+func (desc *immutable) validateOutboundFunctionRefBackReference(
+	depID descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(depID)
+	if err != nil || fn.Dropped() {
+		// Reported when validating forward references.
+		return nil
+	}
+	for _, by := range fn.GetDependedOnBy() {
+		if by.ID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		fn.GetName(), fn.GetID())
+}
+
+// validateInboundAggregateRef validates the back-reference to this function
+// from the given user-defined aggregate.
+func (desc *immutable) validateInboundAggregateRef(fn catalog.FunctionDescriptor) error {
+	if fn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			fn.GetName(), fn.GetID())
+	}
+	if agg := fn.GetAggregate(); agg != nil &&
+		(agg.StateFuncID == desc.GetID() || agg.FinalFuncID == desc.GetID()) {
+		return nil
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding depends-on forward reference",
+		fn.GetName(), fn.GetID())
+}
+
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
 	// Check that parent Schema contains the matching function signature.
 	if _, ok := scDesc.GetFunction(desc.GetName()); !ok {
@@ -336,6 +460,24 @@ func (desc *immutable) validateInboundTableRef(
 		}
 	}
 
+	for _, trigID := range by.TriggerIDs {
+		trigger, err := backRefTbl.FindTriggerByID(trigID)
+		if err != nil {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger with ID %d",
+				backRefTbl.GetName(), by.ID, trigID)
+		}
+		if trigger.FuncID != desc.GetID() {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) trigger %q does not reference this function",
+				backRefTbl.GetName(), by.ID, trigger.Name)
+		}
+	}
+
+	// Triggers reference their function directly instead of through the
+	// relation's depends-on references.
+	if len(by.TriggerIDs) > 0 {
+		return nil
+	}
+
 	for _, id := range backRefTbl.GetDependsOn() {
 		if id == desc.GetID() {
 			return nil
@@ -383,6 +525,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -496,6 +643,43 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetSecurity sets the security attribute.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetIsProcedure sets whether the routine is a stored procedure.
+func (desc *Mutable) SetIsProcedure(v bool) {
+	desc.IsProcedure = v
+}
+
+// SetSetting sets the value of the given session variable while the function
+// is executed, replacing any existing value for it.
+func (desc *Mutable) SetSetting(name, value string) {
+	for i := range desc.Settings {
+		if desc.Settings[i].Name == name {
+			desc.Settings[i].Value = value
+			return
+		}
+	}
+	desc.Settings = append(desc.Settings, descpb.FunctionDescriptor_Setting{Name: name, Value: value})
+}
+
+// ResetSetting removes the value of the given session variable, if any.
+func (desc *Mutable) ResetSetting(name string) {
+	for i := range desc.Settings {
+		if desc.Settings[i].Name == name {
+			desc.Settings = append(desc.Settings[:i], desc.Settings[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetAllSettings removes the values of all session variables.
+func (desc *Mutable) ResetAllSettings() {
+	desc.Settings = nil
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -506,6 +690,74 @@ func (desc *Mutable) SetParentSchemaID(id descpb.ID) {
 	desc.ParentSchemaID = id
 }
 
+// AddAggregateReference adds a back-reference from the user-defined aggregate
+// with the given ID, which uses this function as its state transition or final
+// function.
+func (desc *Mutable) AddAggregateReference(aggID descpb.ID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == aggID {
+			return
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: aggID})
+}
+
+// RemoveAggregateReference removes the back-reference from the user-defined
+// aggregate with the given ID.
+func (desc *Mutable) RemoveAggregateReference(aggID descpb.ID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == aggID {
+			desc.DependedOnBy = append(desc.DependedOnBy[:i], desc.DependedOnBy[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddTriggerReference adds a back-reference from the trigger with the given
+// ID on the given table.
+func (desc *Mutable) AddTriggerReference(tableID descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		ref := &desc.DependedOnBy[i]
+		if ref.ID != tableID {
+			continue
+		}
+		for _, id := range ref.TriggerIDs {
+			if id == triggerID {
+				return
+			}
+		}
+		ref.TriggerIDs = append(ref.TriggerIDs, triggerID)
+		return
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{
+		ID:         tableID,
+		TriggerIDs: []descpb.TriggerID{triggerID},
+	})
+}
+
+// RemoveTriggerReference removes the back-reference from the trigger with the
+// given ID on the given table. The reference to the table is removed entirely
+// if nothing else in the table references this function.
+func (desc *Mutable) RemoveTriggerReference(tableID descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		ref := &desc.DependedOnBy[i]
+		if ref.ID != tableID {
+			continue
+		}
+		for j, id := range ref.TriggerIDs {
+			if id == triggerID {
+				ref.TriggerIDs = append(ref.TriggerIDs[:j], ref.TriggerIDs[j+1:]...)
+				break
+			}
+		}
+		if len(ref.TriggerIDs) == 0 && len(ref.ColumnIDs) == 0 &&
+			len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+			desc.DependedOnBy = append(desc.DependedOnBy[:i], desc.DependedOnBy[i+1:]...)
+		}
+		return
+	}
+}
+
 // ToFuncObj converts the descriptor to a tree.FuncObj.
 func (desc *immutable) ToFuncObj() tree.FuncObj {
 	ret := tree.FuncObj{
@@ -514,7 +766,8 @@ func (desc *immutable) ToFuncObj() tree.FuncObj {
 	}
 	for i := range desc.Params {
 		ret.Params[i] = tree.FuncParam{
-			Type: desc.Params[i].Type,
+			Type:  desc.Params[i].Type,
+			Class: toTreeNodeParamClass(desc.Params[i].Class),
 		}
 	}
 	return ret
@@ -537,15 +790,25 @@ func (desc *immutable) GetLanguage() catpb.Function_Language {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnType:  tree.FixedReturnType(desc.ReturnType.Type),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		Body:        desc.FunctionBody,
+		Language:    desc.getCreateExprLang(),
+		IsUDF:       true,
+		IsProcedure: desc.IsProcedure,
 	}
 
+	// Only the input parameters are passed when the function is called. OUT
+	// parameters determine the return type, which is stored in the descriptor.
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
 	for _, param := range desc.Params {
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			continue
+		case catpb.Function_Param_VARIADIC:
+			ret.Variadic = true
+		}
 		argTypes = append(
 			argTypes,
 			tree.ParamType{Name: param.Name, Typ: param.Type},
@@ -560,6 +823,29 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UDA = &tree.UserDefinedAggregate{
+			StateFuncOID: catid.FuncIDToOID(agg.StateFuncID),
+			StateType:    agg.StateType,
+			InitCond:     agg.InitCond,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.UDA.FinalFuncOID = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+	}
+	if desc.Security == catpb.Function_DEFINER || len(desc.Settings) > 0 {
+		ret.SessionOverrides = &tree.RoutineSessionOverrides{}
+		if desc.Security == catpb.Function_DEFINER {
+			ret.SessionOverrides.User = desc.Privileges.Owner()
+		}
+		for _, setting := range desc.Settings {
+			ret.SessionOverrides.Settings = append(
+				ret.SessionOverrides.Settings,
+				tree.RoutineSetting{Name: setting.Name, Value: setting.Value},
+			)
+		}
+	}
 
 	return ret, nil
 }
@@ -607,6 +893,7 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
 		},
+		IsProcedure: desc.IsProcedure,
 	}
 	ret.Params = make(tree.FuncParams, len(desc.Params))
 	for i := range desc.Params {
@@ -622,21 +909,49 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 			}
 		}
 	}
-	// We only store 5 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.FunctionOptions, 0, 5)
-	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
-	ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
-	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	// We store 6 function attributes and the session variable settings at the
+	// moment. We may extend the pre-allocated capacity in the future.
+	ret.Options = make(tree.FunctionOptions, 0, 6+len(desc.Settings))
+	if !desc.IsProcedure {
+		// Procedures do not have volatility, leakproof or null input behavior
+		// attributes.
+		ret.Options = append(ret.Options, desc.getCreateExprVolatility())
+		ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
+		ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	}
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.FunctionSecurityDefiner)
+	}
+	for _, setting := range desc.Settings {
+		ret.Options = append(ret.Options, getCreateExprSetting(setting))
+	}
 	ret.Options = append(ret.Options, tree.FunctionBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
 }
 
+// getCreateExprSetting converts a session variable setting back to a SET
+// clause.
+func getCreateExprSetting(setting descpb.FunctionDescriptor_Setting) *tree.SetVar {
+	ret := &tree.SetVar{Name: setting.Name}
+	if setting.Name == "search_path" {
+		// The search path is stored as a comma-separated list of schemas, but
+		// a single schema name given in a SET clause cannot contain commas.
+		for _, s := range strings.Split(setting.Value, ",") {
+			ret.Values = append(ret.Values, tree.NewStrVal(s))
+		}
+		return ret
+	}
+	ret.Values = tree.Exprs{tree.NewStrVal(setting.Value)}
+	return ret
+}
+
 func (desc *immutable) getCreateExprLang() tree.FunctionLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
 		return tree.FunctionLangSQL
+	case catpb.Function_PLPGSQL:
+		return tree.FunctionLangPLpgSQL
 	}
 	return 0
 }
@@ -54,11 +54,25 @@ func FunctionLangToProto(v tree.FunctionLanguage) (catpb.Function_Language, erro
 	switch v {
 	case tree.FunctionLangSQL:
 		return catpb.Function_SQL, nil
+	case tree.FunctionLangPLpgSQL:
+		return catpb.Function_PLPGSQL, nil
 	}
 
 	return -1, pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function language %q", v)
 }
 
+// SecurityToProto converts sql statement input security to protobuf type.
+func SecurityToProto(v tree.FunctionSecurity) (catpb.Function_Security, error) {
+	switch v {
+	case tree.FunctionSecurityInvoker:
+		return catpb.Function_INVOKER, nil
+	case tree.FunctionSecurityDefiner:
+		return catpb.Function_DEFINER, nil
+	}
+
+	return -1, pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function security %q", v)
+}
+
 // ParamClassToProto converts sql statement input argument class to protobuf
 // type.
 func ParamClassToProto(v tree.FuncParamClass) (catpb.Function_Param_Class, error) {
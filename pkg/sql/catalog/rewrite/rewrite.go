@@ -504,7 +504,7 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 			}
 		}
 		switch t := typ.Kind; t {
-		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM:
+		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM, descpb.TypeDescriptor_DOMAIN:
 			if rw, ok := descriptorRewrites[typ.ArrayTypeID]; ok {
 				typ.ArrayTypeID = rw.ID
 			}
@@ -697,20 +697,24 @@ func FunctionDescs(
 		fnDesc.ParentSchemaID = fnRewrite.ParentSchemaID
 		fnDesc.ParentID = fnRewrite.ParentID
 
-		// Rewrite function body.
-		fnBody := fnDesc.FunctionBody
-		if overrideDB != "" {
-			dbNameReplaced, err := rewriteFunctionBodyDBNames(fnDesc.FunctionBody, overrideDB)
+		// Rewrite function body. The body of a PL/pgSQL function is stored as
+		// written, so there are no fully qualified names or sequence IDs to
+		// rewrite.
+		if fnDesc.Lang != catpb.Function_PLPGSQL {
+			fnBody := fnDesc.FunctionBody
+			if overrideDB != "" {
+				dbNameReplaced, err := rewriteFunctionBodyDBNames(fnDesc.FunctionBody, overrideDB)
+				if err != nil {
+					return err
+				}
+				fnBody = dbNameReplaced
+			}
+			fnBody, err := rewriteSequencesInFunction(fnBody, descriptorRewrites)
 			if err != nil {
 				return err
 			}
-			fnBody = dbNameReplaced
-		}
-		fnBody, err := rewriteSequencesInFunction(fnBody, descriptorRewrites)
-		if err != nil {
-			return err
+			fnDesc.FunctionBody = fnBody
 		}
-		fnDesc.FunctionBody = fnBody
 
 		// Rewrite type IDs.
 		for _, param := range fnDesc.Params {
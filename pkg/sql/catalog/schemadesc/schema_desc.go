@@ -501,6 +501,14 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			},
 			IsUDF:                    true,
 			UDFContainsOnlySignature: true,
+			Variadic:                 funcDescPb.Overloads[i].IsVariadic,
+			IsProcedure:              funcDescPb.Overloads[i].IsProcedure,
+			// Procedures cannot be STRICT, so they are always called on NULL
+			// input.
+			CalledOnNullInput: funcDescPb.Overloads[i].IsProcedure,
+		}
+		if funcDescPb.Overloads[i].IsAggregate {
+			overload.Class = tree.AggregateClass
 		}
 		paramTypes := make(tree.ParamTypes, 0, len(funcDescPb.Overloads[i].ArgTypes))
 		for _, paramType := range funcDescPb.Overloads[i].ArgTypes {
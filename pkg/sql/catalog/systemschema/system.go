@@ -769,6 +769,25 @@ CREATE TABLE system.job_info (
 	CONSTRAINT "primary" PRIMARY KEY (job_id, info_key, written DESC),
 	FAMILY "primary" (job_id, info_key, written, value)
 );`
+
+	// notifications stores the asynchronous notifications sent with NOTIFY.
+	// They are written by the transaction sending them, and are delivered to
+	// the listening sessions of each node by a rangefeed once that transaction
+	// commits. created is the first column of the primary key so that expired
+	// notifications can be deleted efficiently. txn_id identifies the
+	// transaction that sent the notification, since several transactions can
+	// commit at the same timestamp.
+	SystemNotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	created TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	id INT8 NOT NULL DEFAULT unique_rowid(),
+	channel STRING NOT NULL,
+	payload STRING NOT NULL,
+	pid INT8 NOT NULL,
+	txn_id UUID NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (created, id),
+	FAMILY "primary" (created, id, channel, payload, pid, txn_id)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -969,6 +988,7 @@ func MakeSystemTables() []SystemTable {
 		SpanCountTable,
 		SystemPrivilegeTable,
 		SystemExternalConnectionsTable,
+		SystemNotificationsTable,
 	}
 }
 
@@ -2910,6 +2930,38 @@ var (
 			},
 		),
 	)
+
+	SystemNotificationsTable = makeSystemTable(
+		SystemNotificationsTableSchema,
+		systemTable(
+			catconstants.SystemNotificationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "created", ID: 1, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "id", ID: 2, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "channel", ID: 3, Type: types.String},
+				{Name: "payload", ID: 4, Type: types.String},
+				{Name: "pid", ID: 5, Type: types.Int},
+				{Name: "txn_id", ID: 6, Type: types.Uuid},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"created", "id", "channel", "payload", "pid", "txn_id"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"created", "id"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC},
+				KeyColumnIDs:        []descpb.ColumnID{1, 2},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
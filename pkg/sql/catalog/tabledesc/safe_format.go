@@ -47,6 +47,9 @@ func formatSafeTableProperties(w *redact.StringBuilder, desc catalog.TableDescri
 	if desc.IsVirtualTable() {
 		w.Printf(", Virtual: true")
 	}
+	if desc.IsForeignTable() {
+		w.Printf(", Foreign: true")
+	}
 	formatSafeTableColumns(w, desc)
 	formatSafeTableColumnFamilies(w, desc)
 	formatSafeTableMutationJobs(w, desc)
@@ -301,6 +301,21 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process policies.
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := f(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := f(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -1198,6 +1213,41 @@ func (desc *wrapper) FindFamilyByID(id descpb.FamilyID) (*descpb.ColumnFamilyDes
 	return nil, fmt.Errorf("family-id \"%d\" does not exist", id)
 }
 
+// FindTriggerByID implements the TableDescriptor interface.
+func (desc *wrapper) FindTriggerByID(id descpb.TriggerID) (*descpb.TriggerDescriptor, error) {
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.ID == id {
+			return trigger, nil
+		}
+	}
+	return nil, fmt.Errorf("trigger-id \"%d\" does not exist", id)
+}
+
+// FindTriggerByName implements the TableDescriptor interface.
+func (desc *wrapper) FindTriggerByName(name string) (*descpb.TriggerDescriptor, error) {
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.Name == name {
+			return trigger, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"trigger %q for table %q does not exist", name, desc.GetName())
+}
+
+// FindPolicyByName implements the TableDescriptor interface.
+func (desc *wrapper) FindPolicyByName(name string) (*descpb.PolicyDescriptor, error) {
+	for i := range desc.Policies {
+		policy := &desc.Policies[i]
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"policy %q for table %q does not exist", name, desc.GetName())
+}
+
 // NamesForColumnIDs implements the TableDescriptor interface.
 func (desc *wrapper) NamesForColumnIDs(ids descpb.ColumnIDs) ([]string, error) {
 	names := make([]string, len(ids))
@@ -611,6 +611,21 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policies.
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := renameInExpr(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := renameInExpr(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Rename the column in the TTL expiration expression.
 	if tableDesc.HasRowLevelTTL() {
 		if expirationExpr := tableDesc.GetRowLevelTTL().ExpirationExpr; expirationExpr != "" {
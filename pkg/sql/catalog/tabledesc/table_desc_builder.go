@@ -934,6 +934,8 @@ func maybeUpgradeSequenceReference(
 				hasUpgraded = true
 			}
 		}
+	} else if rel.IsForeignTable() {
+		// Foreign tables do not reference sequences.
 	} else {
 		return hasUpgraded, errors.AssertionFailedf("table descriptor %v (%d) is not a "+
 			"table, view, or sequence.", rel.Name, rel.ID)
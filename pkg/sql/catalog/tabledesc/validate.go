@@ -115,6 +115,10 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add trigger function dependencies.
+	for i := range desc.Triggers {
+		ids.Add(desc.Triggers[i].FuncID)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -192,6 +196,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check that trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Row-level TTL is not compatible with foreign keys.
 	// This check should be in ValidateSelf but interferes with AllocateIDs.
 	if desc.HasRowLevelTTL() {
@@ -270,6 +279,17 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check that trigger functions have matching back-references.
+	for i := range desc.Triggers {
+		fn, _ := vdg.GetFunctionDescriptor(desc.Triggers[i].FuncID)
+		if fn == nil {
+			// Don't follow up on backward references for invalid forward
+			// references.
+			continue
+		}
+		vea.Report(desc.validateTriggerFuncBackReference(&desc.Triggers[i], fn))
+	}
+
 	// Check relation back-references to relations and functions.
 	for _, by := range desc.DependedOnBy {
 		depDesc, err := vdg.GetDescriptor(by.ID)
@@ -327,6 +347,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReference(ref catalog.FunctionDe
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateTriggerFuncBackReference(
+	trigger *descpb.TriggerDescriptor, ref catalog.FunctionDescriptor,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == trigger.ID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("trigger %q function %q (%d) has no corresponding depended-on-by back reference",
+		trigger.Name, ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -701,6 +738,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// actually a table, not if it's just a view.
 	if desc.IsPhysicalTable() {
 		desc.validateConstraintNamesAndIDs(vea)
+		desc.validateTriggers(vea)
+		desc.validatePolicies(vea)
 		newErrs := []error{
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
@@ -933,6 +972,117 @@ func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAc
 
 }
 
+func (desc *wrapper) validateTriggers(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]descpb.TriggerID, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]string, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.ID == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger ID was missing for trigger %q", trigger.Name))
+		} else if trigger.ID >= desc.NextTriggerID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has ID %d not less than NextTriggerID value %d for table",
+				trigger.Name, trigger.ID, desc.NextTriggerID))
+		}
+		if trigger.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty trigger name"))
+		}
+		if otherID, found := names[trigger.Name]; found && trigger.ID != otherID {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate trigger name: %q", trigger.Name))
+		}
+		names[trigger.Name] = trigger.ID
+		if other, found := ids[trigger.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger ID %d in trigger %q already in use by %q",
+				trigger.ID, trigger.Name, other))
+		}
+		ids[trigger.ID] = trigger.Name
+		if trigger.FuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf(
+				"invalid function ID %d in trigger %q", trigger.FuncID, trigger.Name))
+		}
+		if len(trigger.Events) == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q does not have any events", trigger.Name))
+		}
+		seen := make(map[semenumpb.TriggerEvent]struct{}, len(trigger.Events))
+		for _, ev := range trigger.Events {
+			if _, found := seen[ev]; found {
+				vea.Report(errors.AssertionFailedf(
+					"trigger %q has duplicate event %s", trigger.Name, ev))
+			}
+			seen[ev] = struct{}{}
+		}
+	}
+}
+
+func (desc *wrapper) validatePolicies(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]descpb.PolicyID, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]string, len(desc.Policies))
+	for i := range desc.Policies {
+		policy := &desc.Policies[i]
+		if policy.ID == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"policy ID was missing for policy %q", policy.Name))
+		} else if policy.ID >= desc.NextPolicyID {
+			vea.Report(errors.AssertionFailedf(
+				"policy %q has ID %d not less than NextPolicyID value %d for table",
+				policy.Name, policy.ID, desc.NextPolicyID))
+		}
+		if policy.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty policy name"))
+		}
+		if otherID, found := names[policy.Name]; found && policy.ID != otherID {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate policy name: %q", policy.Name))
+		}
+		names[policy.Name] = policy.ID
+		if other, found := ids[policy.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"policy ID %d in policy %q already in use by %q",
+				policy.ID, policy.Name, other))
+		}
+		ids[policy.ID] = policy.Name
+		switch policy.Command {
+		case semenumpb.PolicyCommand_POLICY_INSERT:
+			if policy.UsingExpr != "" {
+				vea.Report(errors.AssertionFailedf(
+					"INSERT policy %q has a USING expression", policy.Name))
+			}
+		case semenumpb.PolicyCommand_POLICY_SELECT, semenumpb.PolicyCommand_POLICY_DELETE:
+			if policy.WithCheckExpr != "" {
+				vea.Report(errors.AssertionFailedf(
+					"policy %q with command %s has a WITH CHECK expression", policy.Name, policy.Command))
+			}
+		}
+		for _, role := range policy.RoleNames {
+			if role == "" {
+				vea.Report(errors.AssertionFailedf(
+					"policy %q has an empty role name", policy.Name))
+			}
+		}
+		for _, exprStr := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+			if exprStr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				// Parse errors are reported by ValidateSelf.
+				continue
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				vea.Report(err)
+			} else if !valid {
+				vea.Report(errors.Newf("policy %q refers to unknown columns in expression: %s",
+					policy.Name, exprStr))
+			}
+		}
+	}
+}
+
 func (desc *wrapper) validateColumns() error {
 	columnIDs := make(map[descpb.ColumnID]*descpb.ColumnDescriptor, len(desc.Columns))
 	columnNames := make(map[string]descpb.ColumnID, len(desc.Columns))
@@ -1176,6 +1326,15 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		// Verify that an exclusion constraint has an operator for each column.
+		if ucDesc := c.UniqueWithoutIndexDesc(); ucDesc.IsExclusion() &&
+			len(ucDesc.ExclusionOperators) != c.NumKeyColumns() {
+			return errors.Newf(
+				"exclusion constraint %q has %d operators for %d columns",
+				c.GetName(), len(ucDesc.ExclusionOperators), c.NumKeyColumns(),
+			)
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -148,5 +148,15 @@ func ensureTypeMetadataIsHydrated(
 		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		tm.ImplicitRecordType = true
+	case descpb.TypeDescriptor_DOMAIN:
+		domain := maybeDesc.TypeDesc().Domain
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:     domain.NotNull,
+			DefaultExpr: domain.DefaultExpr,
+			Checks:      make([]types.DomainCheck, len(domain.Checks)),
+		}
+		for i, c := range domain.Checks {
+			tm.DomainData.Checks[i] = types.DomainCheck{Name: c.Name, Expr: c.Expr}
+		}
 	}
 }
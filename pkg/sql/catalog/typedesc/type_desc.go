@@ -569,6 +569,12 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil domain"))
+		} else if desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -679,16 +685,28 @@ func (desc *immutable) ValidateForwardReferences(
 		}
 	}
 
+	if desc.GetKind() == descpb.TypeDescriptor_DOMAIN && desc.Domain != nil &&
+		desc.Domain.BaseType != nil && desc.Domain.BaseType.UserDefined() {
+		// Domains over user-defined types are currently not supported, but this
+		// should be validated elsewhere.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain %q",
+			desc.Domain.BaseType.String(), desc.GetName(),
+		))
+	}
+
 	if desc.GetKind() == descpb.TypeDescriptor_COMPOSITE {
 		for _, e := range desc.Composite.Elements {
 			t := e.ElementType
-			if t.UserDefined() {
-				// User-defined type references within user-defined types are currently
-				// not supported, but this should be validated elsewhere.
-				// See issue https://github.com/cockroachdb/cockroach/issues/91779.
-				vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from composite type %q",
-					t.String(), desc.GetName(),
-				))
+			if !t.UserDefined() {
+				continue
+			}
+			elemID := GetUserDefinedTypeDescID(t)
+			if typ, err := vdg.GetTypeDescriptor(elemID); err != nil {
+				vea.Report(errors.Wrapf(err, "type %d of composite type element %q does not exist",
+					elemID, e.ElementLabel))
+			} else if typ.Dropped() {
+				vea.Report(errors.AssertionFailedf("type %q (%d) of composite type element %q is dropped",
+					typ.GetName(), typ.GetID(), e.ElementLabel))
 			}
 		}
 	}
@@ -701,7 +719,7 @@ func (desc *immutable) ValidateBackReferences(
 
 	// Validate that the backward-referenced types exist.
 	switch desc.GetKind() {
-	case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM:
+	case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM, descpb.TypeDescriptor_DOMAIN:
 		// Ensure that the array type exists.
 		// This is considered to be a backward reference, not a forward reference,
 		// as the element type doesn't need the array type to exist, but the
@@ -842,6 +860,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
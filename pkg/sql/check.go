@@ -30,8 +30,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
@@ -506,12 +508,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc.UniqueWithoutIndexDesc(),
 				0, /* indexIDForValidation */
 				p.ExecCfg().InternalExecutor,
 				p.Txn(),
@@ -592,12 +592,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc.UniqueWithoutIndexDesc(),
 				0, /* indexIDForValidation */
 				ie,
 				txn,
@@ -711,6 +709,244 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX constraint, which may be an
+// exclusion constraint. See validateUniqueConstraint for a description of the
+// remaining arguments.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc, indexIDForValidation, ie, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.Name,
+		uc.ColumnIDs,
+		uc.Predicate,
+		indexIDForValidation,
+		ie,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// exclusionConstraintIndex returns the index that backs the given exclusion
+// constraint, or nil if there is none. It mirrors the index that is created by
+// makeExclusionConstraintIndex: if any of the constraint columns are compared
+// with =, it is a non-inverted index with those columns as a prefix, and
+// otherwise it is an inverted index on a GEOMETRY column compared with &&. In
+// both cases the index must have the same predicate as the constraint.
+func exclusionConstraintIndex(
+	tbl catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint,
+) catalog.Index {
+	var eqCols, geoCols catalog.TableColSet
+	for i, colID := range uc.ColumnIDs {
+		switch uc.ExclusionOperators[i] {
+		case treecmp.EQ.String():
+			eqCols.Add(colID)
+		case treecmp.Overlaps.String():
+			if col, err := tbl.FindColumnWithID(colID); err == nil &&
+				col.GetType().Family() == types.GeometryFamily {
+				geoCols.Add(colID)
+			}
+		}
+	}
+	for _, idx := range tbl.ActiveIndexes() {
+		if idx.IsNotVisible() || idx.GetPredicate() != uc.Predicate {
+			continue
+		}
+		start := idx.ImplicitPartitioningColumnCount()
+		if !eqCols.Empty() {
+			if idx.GetType() == descpb.IndexDescriptor_INVERTED ||
+				idx.NumKeyColumns()-start < eqCols.Len() {
+				continue
+			}
+			var prefix catalog.TableColSet
+			for j := start; j < start+eqCols.Len(); j++ {
+				prefix.Add(idx.GetKeyColumnID(j))
+			}
+			if prefix.Len() == eqCols.Len() && prefix.SubsetOf(eqCols) {
+				return idx
+			}
+		} else if idx.GetType() == descpb.IndexDescriptor_INVERTED &&
+			idx.NumKeyColumns()-1 == start && geoCols.Contains(idx.InvertedColumnID()) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// conflictingRowQuery generates and returns a query for a pair of distinct
+// rows that violate the specified exclusion constraint. The values of the
+// constraint columns for the first row are followed by the values for the
+// second row.
+//
+// For example, an exclusion constraint EXCLUDE (a WITH =, b WITH &&) on the
+// table "tbl" with primary key k, which is backed by the index tbl_a_idx,
+// would require the following query:
+//
+// SELECT t1.a, t1.b, t2.a, t2.b
+// FROM (SELECT a, b, k FROM tbl) AS t1, (SELECT a, b, k FROM tbl@tbl_a_idx) AS t2
+// WHERE t1.a = t2.a AND t1.b && t2.b AND (t1.k) != (t2.k)
+// LIMIT 1  -- if limitResults is set
+//
+// Forcing the backing index on the second subquery causes the rows that
+// conflict with each row of the table to be found with an index lookup. If the
+// constraint is partial, its predicate is added to the WHERE clause of both
+// subqueries.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	limitResults bool,
+) (sql string, colNames []string, _ error) {
+	colNames, err := srcTbl.NamesForColumnIDs(uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := srcTbl.NamesForColumnIDs(srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	srcCols := make([]string, 0, len(colNames)+len(pkColNames))
+	for _, n := range colNames {
+		srcCols = append(srcCols, tree.NameString(n))
+	}
+	for _, n := range pkColNames {
+		srcCols = append(srcCols, tree.NameString(n))
+	}
+
+	makeSubquery := func(indexID descpb.IndexID) string {
+		tbl := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+		if indexID != 0 {
+			tbl = fmt.Sprintf("%s@[%d]", tbl, indexID)
+		}
+		subquery := fmt.Sprintf("SELECT %s FROM %s", strings.Join(srcCols, ", "), tbl)
+		if uc.Predicate != "" {
+			subquery = fmt.Sprintf("%s WHERE (%s)", subquery, uc.Predicate)
+		}
+		return subquery
+	}
+	var lookupIndexID descpb.IndexID
+	if idx := exclusionConstraintIndex(srcTbl, uc); idx != nil {
+		lookupIndexID = idx.GetID()
+	}
+
+	outCols := make([]string, 0, 2*len(colNames))
+	for _, alias := range []string{"t1", "t2"} {
+		for _, n := range colNames {
+			outCols = append(outCols, fmt.Sprintf("%s.%s", alias, tree.NameString(n)))
+		}
+	}
+
+	// There will be an expression in the WHERE clause for each of the columns,
+	// and one to prevent rows from matching themselves.
+	where := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		where = append(where, fmt.Sprintf(
+			"t1.%[1]s %[2]s t2.%[1]s", tree.NameString(n), uc.ExclusionOperators[i],
+		))
+	}
+	pkCols1 := make([]string, len(pkColNames))
+	pkCols2 := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		pkCols1[i] = fmt.Sprintf("t1.%s", tree.NameString(n))
+		pkCols2[i] = fmt.Sprintf("t2.%s", tree.NameString(n))
+	}
+	where = append(where, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(pkCols1, ", "), strings.Join(pkCols2, ", "),
+	))
+
+	limit := ""
+	if limitResults {
+		limit = " LIMIT 1"
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS t1, (%[3]s) AS t2 WHERE %[4]s%[5]s`,
+		strings.Join(outCols, ", "),        // 1
+		makeSubquery(indexIDForValidation), // 2
+		makeSubquery(lookupIndexID),        // 3
+		strings.Join(where, " AND "),       // 4
+		limit,                              // 5
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two distinct rows in the
+// srcTable conflict according to the given exclusion constraint. See
+// validateUniqueConstraint for a description of the remaining arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(
+		srcTable, uc, indexIDForValidation, true, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := ie.QueryRowEx(ctx, "validate exclusion constraint", txn, sessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		cols := strings.Join(colNames, ", ")
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
+				),
+				uc.Name,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:len(colNames)], ", "),
+				cols, strings.Join(valuesStr[len(colNames):], ", "),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
@@ -309,6 +309,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.StreamIngestionData != nil:
 	case core.StreamIngestionFrontier != nil:
 	case core.HashGroupJoiner != nil:
+	case core.ForeignScan != nil:
 	default:
 		return errors.AssertionFailedf("unexpected processor core %q", core)
 	}
@@ -227,7 +227,7 @@ func _ADD_TUPLE(
 	// {{end}}
 	if groups[tupleIdx] {
 		if !a.isFirstGroup {
-			res, err := a.fn.Result()
+			res, err := a.fn.Result(a.ctx)
 			if err != nil {
 				colexecerror.ExpectedError(err)
 			}
@@ -263,7 +263,7 @@ func _ADD_TUPLE(
 func _SET_RESULT(a *default_AGGKINDAgg, outputIdx int) { // */}}
 	// {{define "setResult" -}}
 
-	res, err := a.fn.Result()
+	res, err := a.fn.Result(a.ctx)
 	if err != nil {
 		colexecerror.ExpectedError(err)
 	}
@@ -74,7 +74,7 @@ func (a *defaultHashAgg) Compute(
 }
 
 func (a *defaultHashAgg) Flush(outputIdx int) {
-	res, err := a.fn.Result()
+	res, err := a.fn.Result(a.ctx)
 	if err != nil {
 		colexecerror.ExpectedError(err)
 	}
@@ -62,6 +62,16 @@ func isIdentityCast(fromType, toType *types.T) bool {
 	if fromType.Identical(toType) {
 		return true
 	}
+	// Values of a domain are represented as values of its base type.
+	if fromType.IsDomain() || toType.IsDomain() {
+		if fromType.IsDomain() {
+			fromType = fromType.DomainBaseType()
+		}
+		if toType.IsDomain() {
+			toType = toType.DomainBaseType()
+		}
+		return isIdentityCast(fromType, toType)
+	}
 	if fromType.Family() == types.FloatFamily && toType.Family() == types.FloatFamily {
 		// Casts between floats are identical because all floats are represented
 		// by float64 physically.
@@ -21,9 +21,11 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -44,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scrun"
@@ -1017,6 +1020,10 @@ func (s *Server) newConnExecutor(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
 	)
 	ex.extraTxnState.jobs = new(jobsCollection)
+	ex.extraTxnState.deferredConstraints = &deferredConstraints{
+		keysAcc: ex.sessionMon.MakeBoundAccount(),
+	}
+	ex.extraTxnState.notifications = new(txnNotifications)
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangeJobRecords = make(map[descpb.ID]*jobs.Record)
 	ex.extraTxnState.schemaChangerState = &SchemaChangerState{
@@ -1153,6 +1160,9 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
+		if dc := ex.extraTxnState.deferredConstraints; dc != nil {
+			dc.keysAcc.Close(ctx)
+		}
 		if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
 			log.Warningf(ctx, "error closing cursors: %v", err)
 		}
@@ -1169,6 +1179,11 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		ex.eventLog = nil
 	}
 
+	if ex.listener != nil {
+		ex.listener.Close()
+		ex.listener = nil
+	}
+
 	// Stop idle timer if the connExecutor is closed to ensure cancel session
 	// is not called.
 	ex.mu.IdleInSessionTimeout.Stop()
@@ -1246,6 +1261,13 @@ type connExecutor struct {
 	// if traceSessionEventLogEnabled; it is used by ex.sessionEventf()
 	eventLog trace.EventLog
 
+	// procedureCall is the state of a procedure invoked by a CALL statement
+	// that is being executed. It outlives the transaction in which the CALL
+	// statement started, because a COMMIT or ROLLBACK in the body of the
+	// procedure finishes the transaction and the CALL statement is then
+	// re-executed in a new transaction to resume the procedure.
+	procedureCall *procedureCall
+
 	// extraTxnState groups fields scoped to a SQL txn that are not handled by
 	// ex.state, above. The rule of thumb is that, if the state influences state
 	// transitions, it should live in state, otherwise it can live here.
@@ -1282,6 +1304,18 @@ type connExecutor struct {
 		// that staged them commits.
 		jobs *jobsCollection
 
+		// deferredConstraints tracks the deferrable constraints whose checks
+		// were postponed until the transaction commits. It is nil for internal
+		// executors that run in an outer transaction, in which case constraint
+		// checks are never deferred.
+		deferredConstraints *deferredConstraints
+
+		// notifications accumulates the LISTEN, UNLISTEN and NOTIFY statements
+		// executed in the transaction, which take effect when it commits. It is
+		// nil for internal executors, which don't support asynchronous
+		// notifications.
+		notifications *txnNotifications
+
 		// schemaChangeJobRecords is a map of descriptor IDs to job Records.
 		// Used in createOrUpdateSchemaChangeJob so we can check if a job has been
 		// queued up for the given ID. The cache remains valid only for the current
@@ -1523,6 +1557,10 @@ type connExecutor struct {
 	// pgwire cancellation protocol.
 	queryCancelKey pgwirecancel.BackendKeyData
 
+	// listener receives the asynchronous notifications sent to the channels the
+	// session listens on. It is created by the first LISTEN statement.
+	listener *pgnotify.Listener
+
 	sessionID clusterunique.ID
 
 	// activated determines whether activate() was called already.
@@ -1718,13 +1756,21 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 			delete(ex.extraTxnState.schemaChangeJobRecords, k)
 		}
 		ex.extraTxnState.jobs.reset()
+		ex.extraTxnState.deferredConstraints.reset(ctx)
+		if ex.extraTxnState.notifications != nil {
+			ex.extraTxnState.notifications.reset()
+		}
 		ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 			mode: ex.sessionData().NewSchemaChangerMode,
 		}
 	}
 
-	// Close all portals.
+	// Close all portals, except for the portal of a CALL statement that is
+	// executed again to resume its procedure in a new txn.
 	for name, p := range ex.extraTxnState.prepStmtsNamespace.portals {
+		if call := ex.procedureCall; call != nil && call.keepPortal && call.portalName == name {
+			continue
+		}
 		p.close(ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc, name)
 		delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 	}
@@ -2131,6 +2177,13 @@ func (ex *connExecutor) execCmd() error {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case SendNotifications:
+		// The notifications are buffered below if the connection is idle, in
+		// which case closing the res flushes them. Otherwise, they are delivered
+		// by the Sync that ends the current transaction or batch, right before
+		// ReadyForQuery, and nothing is flushed: flushing the results of an open
+		// transaction would prevent it from being retried automatically.
+		res = ex.clientComm.CreateSendNotificationsResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2190,6 +2243,14 @@ func (ex *connExecutor) execCmd() error {
 				res.SetError(pe.errorCause())
 			}
 		}
+		// Asynchronous notifications are only delivered to the client between
+		// transactions, as in Postgres.
+		switch cmd.(type) {
+		case Sync, SendNotifications:
+			if ex.idleConn() {
+				ex.bufferNotifications(ctx, res.(NotificationResult))
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2346,6 +2407,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case SendNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -2705,9 +2768,14 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
-		return errors.AssertionFailedf(
-			"unknown isolation level: %s", errors.Safe(modes.Isolation))
+	if modes.Isolation != tree.UnspecifiedIsolation {
+		level, err := ex.txnIsolationLevelToKV(ctx, modes.Isolation)
+		if err != nil {
+			return err
+		}
+		if err := ex.state.setIsolationLevel(level); err != nil {
+			return pgerror.WithCandidateCode(err, pgcode.ActiveSQLTransaction)
+		}
 	}
 	rwMode := modes.ReadWriteMode
 	if modes.AsOf.Expr != nil && asOfTs.IsEmpty() {
@@ -2727,6 +2795,32 @@ func (ex *connExecutor) setTransactionModes(
 	return ex.state.setReadOnlyMode(rwMode)
 }
 
+// txnIsolationLevelToKV translates a SQL isolation level into the isolation
+// level used by the KV layer, falling back to the session default if the
+// isolation level is unspecified. READ COMMITTED transactions are upgraded to
+// SERIALIZABLE if READ COMMITTED isolation is disabled or if the cluster has
+// not been fully upgraded to a version that supports it.
+func (ex *connExecutor) txnIsolationLevelToKV(
+	ctx context.Context, level tree.IsolationLevel,
+) (isolation.Level, error) {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	switch level {
+	case tree.ReadCommittedIsolation:
+		st := ex.server.cfg.Settings
+		if allowReadCommittedIsolation.Get(&st.SV) &&
+			st.Version.IsActive(ctx, clusterversion.V23_1_ReadCommittedIsolation) {
+			return isolation.ReadCommitted, nil
+		}
+		return isolation.Serializable, nil
+	case tree.UnspecifiedIsolation, tree.SerializableIsolation:
+		return isolation.Serializable, nil
+	default:
+		return 0, errors.AssertionFailedf("unknown isolation level: %s", errors.Safe(level))
+	}
+}
+
 func txnPriorityToProto(mode tree.UserPriority) roachpb.UserPriority {
 	var pri roachpb.UserPriority
 	switch mode {
@@ -2828,6 +2922,9 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		Descs:                  ex.extraTxnState.descCollection,
 		TxnModesSetter:         ex,
 		Jobs:                   ex.extraTxnState.jobs,
+		DeferredConstraints:    ex.extraTxnState.deferredConstraints,
+		Notifications:          ex.extraTxnState.notifications,
+		Listener:               ex.listener,
 		SchemaChangeJobRecords: ex.extraTxnState.schemaChangeJobRecords,
 		statsProvider:          ex.server.sqlStats,
 		indexUsageStats:        ex.indexUsageStats,
@@ -3075,6 +3172,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			}
 		}
 		ex.notifyStatsRefresherOfNewTables(ex.Ctx())
+		ex.commitNotifications()
 
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionStartPostCommitJob, timeutil.Now())
 		if err := ex.server.cfg.JobRegistry.Run(
@@ -0,0 +1,224 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+)
+
+// execCallInOpenState executes a CALL statement. The statements in the body of
+// the procedure are planned and executed one at a time in the current txn, in
+// the same way as top-level statements.
+//
+// If the body contains a COMMIT or ROLLBACK statement, the current txn is
+// finished and an eventTxnFinishInCall is returned, which causes the CALL
+// statement to be executed again in a new implicit txn. The procedure is then
+// resumed at the statement following the COMMIT or ROLLBACK, with the session
+// overrides of the procedure applied again. This is only allowed if
+// canTxnControl is set, i.e. if the CALL statement is executed in an implicit
+// txn.
+func (ex *connExecutor) execCallInOpenState(
+	ctx context.Context, s *tree.Call, res RestrictedCommandResult, canTxnControl bool,
+) (retEv fsm.Event, retPayload fsm.EventPayload, retErr error) {
+	p := &ex.planner
+	makeErrEvent := func(err error) (fsm.Event, fsm.EventPayload, error) {
+		ev, payload := ex.makeErrEvent(err, s)
+		return ev, payload, nil
+	}
+
+	call := ex.procedureCall
+	if call == nil || call.stmt != s {
+		var err error
+		if call, err = p.resolveProcedureCall(ctx, s); err != nil {
+			ex.procedureCall = nil
+			return makeErrEvent(err)
+		}
+		ex.procedureCall = call
+	}
+	call.txnStart = call.next
+	call.keepPortal = false
+	defer func() {
+		// The state of the procedure is kept if the CALL statement is going to
+		// be executed again, either to resume the procedure in a new txn or to
+		// retry the current txn.
+		if _, ok := retEv.(eventTxnFinishInCall); ok {
+			return
+		}
+		if ev, ok := retEv.(eventRetriableErr); ok && ev.CanAutoRetry.Get() {
+			// The effects of the statements executed in the current txn are
+			// discarded, so they must be executed again.
+			call.next = call.txnStart
+			return
+		}
+		ex.procedureCall = nil
+	}()
+
+	// Execute the body with the session variable values of the procedure, if
+	// they differ from those of the caller.
+	overridesPushed := false
+	popOverrides := func() error {
+		if !overridesPushed {
+			return nil
+		}
+		overridesPushed = false
+		return p.EvalContext().SessionDataStack.Pop()
+	}
+	if call.sessionOverrides != nil {
+		if err := p.pushRoutineSessionOverrides(ctx, call.sessionOverrides); err != nil {
+			return makeErrEvent(err)
+		}
+		overridesPushed = true
+		defer func() {
+			if err := popOverrides(); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
+	}
+
+	callStmt := p.stmt
+	p.procedureCall = call
+	defer func() {
+		p.stmt = callStmt
+		p.procedureCall = nil
+	}()
+
+	for ; call.next < len(call.body); call.next++ {
+		bodyStmt := call.body[call.next]
+		switch t := bodyStmt.AST.(type) {
+		case *tree.CommitTransaction, *tree.RollbackTransaction:
+			if !canTxnControl {
+				return makeErrEvent(pgerror.New(
+					pgcode.InvalidTransactionTermination, "invalid transaction termination",
+				))
+			}
+			// Finishing the txn resets the session data stack, so the session
+			// overrides of the procedure are removed first. They are pushed again
+			// when the procedure is resumed.
+			if err := popOverrides(); err != nil {
+				return nil, nil, err
+			}
+			_, isCommit := t.(*tree.CommitTransaction)
+			var ev fsm.Event
+			var payload fsm.EventPayload
+			if isCommit {
+				ev, payload = ex.commitSQLTransaction(ctx, t, ex.commitSQLTransactionInternal)
+			} else {
+				ev, payload = ex.rollbackSQLTransaction(ctx, t)
+			}
+			if payloadHasError(payload) {
+				return ev, payload, nil
+			}
+			call.next++
+			call.txnStart = call.next
+			return eventTxnFinishInCall{Committed: fsm.FromBool(isCommit)}, nil, nil
+		}
+
+		if err := ex.execStmtInCall(ctx, bodyStmt, callStmt.QueryID, res); err != nil {
+			return nil, nil, err
+		}
+		if err := res.Err(); err != nil {
+			return makeErrEvent(err)
+		}
+	}
+	return nil, nil, nil
+}
+
+// execStmtInCall plans and executes a statement in the body of a procedure.
+// Errors encountered during execution are written to res; only errors that
+// require the connection to stop processing queries are returned.
+func (ex *connExecutor) execStmtInCall(
+	ctx context.Context, bodyStmt parser.Statement, queryID clusterunique.ID, res RestrictedCommandResult,
+) error {
+	p := &ex.planner
+	// The statement shares the query ID of the CALL statement, so that it can be
+	// canceled along with it.
+	p.stmt = makeStatement(bodyStmt, queryID)
+	p.semaCtx.Annotations = tree.MakeAnnotations(bodyStmt.NumAnnotations)
+	p.extendedEvalCtx.Annotations = &p.semaCtx.Annotations
+	if err := p.semaCtx.Placeholders.Assign(nil /* src */, 0 /* numPlaceholders */); err != nil {
+		return err
+	}
+	// The txn is committed when the CALL statement finishes, not by the
+	// statements in its body.
+	p.autoCommit = false
+
+	// Create a sequencing point so that the statement observes the effects of
+	// the preceding statements in the body.
+	if err := ex.state.mu.txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
+		res.SetError(err)
+		return nil
+	}
+	return ex.dispatchToExecutionEngine(ctx, p, &callBodyResult{RestrictedCommandResult: res})
+}
+
+// callBodyResult is the result of a statement in the body of a procedure. The
+// rows produced by the statement are discarded; errors are written to the
+// result of the CALL statement.
+type callBodyResult struct {
+	RestrictedCommandResult
+	rowsAffected int
+}
+
+var _ RestrictedCommandResult = &callBodyResult{}
+
+// SetColumns is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) SetColumns(context.Context, colinfo.ResultColumns) {}
+
+// ResetStmtType is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) ResetStmtType(tree.Statement) {}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) AddRow(context.Context, tree.Datums) error {
+	r.rowsAffected++
+	return nil
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) SupportsAddBatch() bool {
+	return false
+}
+
+// IncrementRowsAffected is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) IncrementRowsAffected(ctx context.Context, n int) {
+	r.rowsAffected += n
+}
+
+// RowsAffected is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) RowsAffected() int {
+	return r.rowsAffected
+}
+
+// DisableBuffering is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) DisableBuffering() {}
+
+// BufferedResultsLen is part of the RestrictedCommandResult interface.
+func (r *callBodyResult) BufferedResultsLen() int {
+	return 0
+}
+
+// TruncateBufferedResults is part of the RestrictedCommandResult interface.
+// Rows produced by the body of a procedure are discarded, so only the count of
+// rows affected needs to be reset.
+func (r *callBodyResult) TruncateBufferedResults(idx int) bool {
+	if idx != 0 {
+		return false
+	}
+	r.rowsAffected = 0
+	return true
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -218,6 +219,14 @@ func (ex *connExecutor) execPortal(
 			return nil, nil, nil
 		}
 		ev, payload, err = ex.execStmt(ctx, portal.Stmt.Statement, portal.Stmt, pinfo, stmtRes, canAutoCommit)
+		if _, ok := ev.(eventTxnFinishInCall); ok {
+			// The body of a procedure finished the txn. The portal is executed
+			// again in a new txn to resume the procedure, so it is neither
+			// exhausted nor closed along with the current txn.
+			ex.procedureCall.portalName = portalName
+			ex.procedureCall.keepPortal = true
+			return ev, payload, err
+		}
 		// Portal suspension is supported via a "side" state machine
 		// (see pgwire.limitedCommandResult for details), so when
 		// execStmt returns, we know for sure that the portal has been
@@ -656,6 +665,22 @@ func (ex *connExecutor) execStmtInOpenState(
 	// For regular statements (the ones that get to this point), we
 	// don't return any event unless an error happens.
 
+	// Schema changes rely on serializable isolation to preserve the invariants
+	// of the descriptor leasing protocol. If a READ COMMITTED transaction has
+	// not performed any work yet, it is transparently upgraded; otherwise the
+	// schema change is rejected.
+	if tree.CanModifySchema(ast) && ex.state.mu.txn.IsoLevel().ToleratesWriteSkew() {
+		if ex.state.mu.txn.Active() {
+			return makeErrEvent(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot execute %s in a %s transaction",
+				ast.StatementTag(), tree.ReadCommittedIsolation,
+			))
+		}
+		if err := ex.state.setIsolationLevel(isolation.Serializable); err != nil {
+			return makeErrEvent(err)
+		}
+	}
+
 	if err := ex.handleAOST(ctx, ast); err != nil {
 		return makeErrEvent(err)
 	}
@@ -708,7 +733,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	// well as in-between very stage of cascading actions.
 	// This TODO can be removed when the cascading code is reorganized
 	// accordingly and the missing call to Step() is introduced.
-	if err := ex.state.mu.txn.Step(ctx); err != nil {
+	//
+	// Transactions that use a per-statement read snapshot (READ COMMITTED)
+	// also advance their read timestamp here, so that each statement observes
+	// all writes committed before it began. This is not done for statements
+	// executed on behalf of an outer transaction, which owns the snapshot.
+	if err := ex.state.mu.txn.Step(ctx, !ex.extraTxnState.fromOuterTxn /* allowReadTimestampStep */); err != nil {
 		return makeErrEvent(err)
 	}
 
@@ -731,6 +761,17 @@ func (ex *connExecutor) execStmtInOpenState(
 	p.extendedEvalCtx.TxnIsSingleStmt = canAutoCommit && !ex.extraTxnState.firstStmtExecuted
 	ex.extraTxnState.firstStmtExecuted = true
 
+	// CALL statements execute the statements in the body of the procedure
+	// individually, so they are not dispatched to the execution engine. The
+	// body may finish the txn only if the txn is implicit and, in the simple
+	// protocol, if the CALL is the only statement in it. In the extended
+	// protocol, as in Postgres, the body may finish the implicit txn even if it
+	// contains statements executed before the CALL statement.
+	if call, ok := ast.(*tree.Call); ok {
+		canTxnControl := os.ImplicitTxn.Get() && (canAutoCommit || isExtendedProtocol)
+		return ex.execCallInOpenState(ctx, call, res, canTxnControl)
+	}
+
 	var stmtThresholdSpan *tracing.Span
 	alreadyRecording := ex.transitionCtx.sessionTracing.Enabled()
 	stmtTraceThreshold := TraceStmtThreshold.Get(&ex.planner.execCfg.Settings.SV)
@@ -742,7 +783,7 @@ func (ex *connExecutor) execStmtInOpenState(
 		stmtCtx = ctx
 	}
 
-	if err := ex.dispatchToExecutionEngine(stmtCtx, p, res); err != nil {
+	if err := ex.dispatchStmtToExecutionEngine(stmtCtx, p, res); err != nil {
 		stmtThresholdSpan.Finish()
 		return nil, nil, err
 	}
@@ -1005,13 +1046,19 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	// stepping mode back to what it was.
 	prevSteppingMode := ex.state.mu.txn.ConfigureStepping(ctx, kv.SteppingEnabled)
 	if prevSteppingMode == kv.SteppingEnabled {
-		if err := ex.state.mu.txn.Step(ctx); err != nil {
+		if err := ex.state.mu.txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			return err
 		}
 	} else {
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if dc := ex.extraTxnState.deferredConstraints; dc != nil && len(dc.pending) > 0 {
+		if err := ex.planner.validateDeferredConstraints(ctx, dc.pending); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1098,6 +1145,87 @@ func (ex *connExecutor) rollbackSQLTransaction(
 	return eventTxnFinishAborted{}, nil
 }
 
+// dispatchStmtToExecutionEngine is a wrapper around dispatchToExecutionEngine
+// that, for statements in explicit READ COMMITTED transactions, transparently
+// retries the statement on retryable errors.
+func (ex *connExecutor) dispatchStmtToExecutionEngine(
+	ctx context.Context, p *planner, res RestrictedCommandResult,
+) error {
+	if ex.state.mu.txn.IsoLevel().PerStatementReadSnapshot() &&
+		!p.autoCommit && !ex.extraTxnState.fromOuterTxn {
+		return ex.dispatchReadCommittedStmtToExecutionEngine(ctx, p, res)
+	}
+	return ex.dispatchToExecutionEngine(ctx, p, res)
+}
+
+// dispatchReadCommittedStmtToExecutionEngine executes a statement in a READ
+// COMMITTED transaction. Because each statement in such a transaction runs on
+// its own read snapshot, a statement that hits a retryable error can be
+// retried in isolation, without restarting the entire transaction, as long as
+// none of its results have been delivered to the client. The statement's
+// writes are rolled back to a savepoint taken before its first attempt, and
+// the statement is re-executed on a new read snapshot.
+//
+// If the statement cannot be retried, the retryable error is left on res and
+// handled like it would be in a SERIALIZABLE transaction.
+func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
+	ctx context.Context, p *planner, res RestrictedCommandResult,
+) error {
+	txn := ex.state.mu.txn
+	savepoint, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		res.SetError(err)
+		return nil
+	}
+	maxRetries := int(readCommittedStmtRetries.Get(&ex.server.cfg.Settings.SV))
+	for attempt := 0; ; attempt++ {
+		resultsIdx := res.BufferedResultsLen()
+		if err := ex.dispatchToExecutionEngine(ctx, p, res); err != nil {
+			return err
+		}
+		retryErr := res.Err()
+		if retryErr == nil {
+			break
+		}
+		var txnRetryErr *roachpb.TransactionRetryWithProtoRefreshError
+		if !errors.As(retryErr, &txnRetryErr) || txnRetryErr.PrevTxnAborted() {
+			// Not a retryable error, or one that requires a full transaction
+			// restart.
+			return nil
+		}
+		if attempt >= maxRetries {
+			res.SetError(errors.Wrapf(retryErr,
+				"read committed retry limit exceeded; set by %s=%d",
+				readCommittedStmtRetries.Key(), maxRetries,
+			))
+			return nil
+		}
+		if !res.TruncateBufferedResults(resultsIdx) {
+			// Some results have already been delivered to the client, so the
+			// statement cannot be retried transparently.
+			return nil
+		}
+		log.VEventf(ctx, 2, "retrying statement in read committed transaction after error: %v", retryErr)
+		if err := txn.PrepareForPartialRetry(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := txn.RollbackToSavepoint(ctx, savepoint); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := txn.Step(ctx, true /* allowReadTimestampStep */); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		res.SetError(nil)
+	}
+	if err := txn.ReleaseSavepoint(ctx, savepoint); err != nil {
+		res.SetError(err)
+	}
+	return nil
+}
+
 // dispatchToExecutionEngine executes the statement, writes the result to res
 // and returns an event for the connection's state machine.
 //
@@ -1717,10 +1845,15 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, err := ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		ex.sessionDataStack.PushTopClone()
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1741,9 +1874,14 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		return eventStartImplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1771,9 +1909,14 @@ func (ex *connExecutor) beginImplicitTxn(
 	if err != nil {
 		return ex.makeErrEvent(err, ast)
 	}
+	isoLevel, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+	if err != nil {
+		return ex.makeErrEvent(err, ast)
+	}
 	return eventStartImplicitTxn,
 		makeEventTxnStartPayload(
 			ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+			isoLevel,
 			mode,
 			sqlTs,
 			historicalTs,
@@ -19,6 +19,7 @@ package sql
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -112,7 +113,8 @@ type eventTxnStart struct {
 type eventTxnStartPayload struct {
 	tranCtx transitionCtx
 
-	pri roachpb.UserPriority
+	pri      roachpb.UserPriority
+	isoLevel isolation.Level
 	// txnSQLTimestamp is the timestamp that statements executed in the
 	// transaction that is started by this event will report for now(),
 	// current_timestamp(), transaction_timestamp().
@@ -127,6 +129,7 @@ type eventTxnStartPayload struct {
 // makeEventTxnStartPayload creates an eventTxnStartPayload.
 func makeEventTxnStartPayload(
 	pri roachpb.UserPriority,
+	isoLevel isolation.Level,
 	readOnly tree.ReadWriteMode,
 	txnSQLTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
@@ -135,6 +138,7 @@ func makeEventTxnStartPayload(
 ) eventTxnStartPayload {
 	return eventTxnStartPayload{
 		pri:                 pri,
+		isoLevel:            isoLevel,
 		readOnly:            readOnly,
 		txnSQLTimestamp:     txnSQLTimestamp,
 		historicalTimestamp: historicalTimestamp,
@@ -148,6 +152,14 @@ type eventTxnUpgradeToExplicit struct{}
 type eventTxnFinishCommitted struct{}
 type eventTxnFinishAborted struct{}
 
+// eventTxnFinishInCall is generated when a COMMIT or ROLLBACK statement in the
+// body of a procedure finishes an implicit txn. Unlike eventTxnFinishCommitted
+// and eventTxnFinishAborted, the CALL statement is not advanced past; it is
+// re-executed in a new implicit txn in order to resume the procedure.
+type eventTxnFinishInCall struct {
+	Committed fsm.Bool
+}
+
 // eventSavepointRollback is generated when we want to move from Aborted to Open
 // through a ROLLBACK TO SAVEPOINT <not cockroach_restart>. Note that it is not
 // generated when such a savepoint is rolled back to from the Open state. In
@@ -216,6 +228,7 @@ type payloadWithError interface {
 func (eventTxnStart) Event()                            {}
 func (eventTxnFinishCommitted) Event()                  {}
 func (eventTxnFinishAborted) Event()                    {}
+func (eventTxnFinishInCall) Event()                     {}
 func (eventSavepointRollback) Event()                   {}
 func (eventNonRetriableErr) Event()                     {}
 func (eventRetriableErr) Event()                        {}
@@ -311,6 +324,25 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 			Next:   stateNoTxn{},
 			Action: cleanupAndFinishOnError,
 		},
+		// COMMIT or ROLLBACK in the body of a procedure.
+		eventTxnFinishInCall{Committed: fsm.Any}: {
+			Description: "COMMIT or ROLLBACK in a procedure invoked by CALL",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				ev := txnRollback
+				if args.Event.(eventTxnFinishInCall).Committed.Get() {
+					ev = txnCommit
+				}
+				ts := args.Extended.(*txnState)
+				finishedTxnID, commitTimestamp := ts.finishSQLTxn()
+				// The CALL statement stays in place so that it is executed again,
+				// this time in a new implicit txn.
+				ts.setAdvanceInfo(stayInPlace, noRewind, txnEvent{
+					eventType: ev, txnID: finishedTxnID, commitTimestamp: commitTimestamp,
+				})
+				return nil
+			},
+		},
 		// Handle a txn getting upgraded to an explicit txn.
 		eventTxnUpgradeToExplicit{}: {
 			Next: stateOpen{ImplicitTxn: fsm.False, WasUpgraded: fsm.True},
@@ -505,6 +537,7 @@ func noTxnToOpen(args fsm.Args) error {
 		payload.txnSQLTimestamp,
 		payload.historicalTimestamp,
 		payload.pri,
+		payload.isoLevel,
 		payload.readOnly,
 		nil, /* txn */
 		payload.tranCtx,
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -328,6 +329,22 @@ func (Flush) String() string {
 
 var _ Command = Flush{}
 
+// SendNotifications is a Command asking for the asynchronous notifications
+// received by the session to be delivered to the client. It is pushed by the
+// session's listener when notifications arrive; the notifications are only
+// delivered if the session is idle at the time it is executed, and are
+// otherwise delivered by the next Sync.
+type SendNotifications struct{}
+
+// command implements the Command interface.
+func (SendNotifications) command() string { return "send notifications" }
+
+func (SendNotifications) String() string {
+	return "SendNotifications"
+}
+
+var _ Command = SendNotifications{}
+
 // CopyIn is the command for execution of the Copy-in pgwire subprotocol.
 type CopyIn struct {
 	ParsedStmt parser.Statement
@@ -653,6 +670,9 @@ type ClientComm interface {
 	CreateCopyInResult(pos CmdPos) CopyInResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateSendNotificationsResult creates a result for a SendNotifications
+	// command.
+	CreateSendNotificationsResult(pos CmdPos) SendNotificationsResult
 
 	// lockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -775,6 +795,17 @@ type RestrictedCommandResult interface {
 	// to this CommandResult, will be flushed immediately to the client.
 	// This is currently used for sinkless changefeeds.
 	DisableBuffering()
+
+	// BufferedResultsLen returns the length of the results buffer. The value
+	// can be passed to TruncateBufferedResults to discard any results added
+	// after this call.
+	BufferedResultsLen() int
+
+	// TruncateBufferedResults truncates the results buffer to the given length
+	// and resets the count of rows affected. It returns false if the results
+	// could not be truncated, for example because some of them have already
+	// been flushed to the client.
+	TruncateBufferedResults(idx int) bool
 }
 
 // DescribeResult represents the result of a Describe command (for either
@@ -820,12 +851,36 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationResult
 }
 
 // FlushResult represents the result of a Flush command. When this result is
 // closed, all previously accumulated results are flushed to the client.
 type FlushResult interface {
 	ResultBase
+	NotificationResult
+}
+
+// SendNotificationsResult represents the result of a SendNotifications
+// command. Unlike FlushResult, closing this result only flushes the connection
+// if notifications were buffered on it, which only happens when the session
+// is idle. This way, the notifications that arrive while a transaction is open
+// don't cause the results of that transaction to be delivered to the client,
+// which would prevent it from being retried automatically.
+type SendNotificationsResult interface {
+	ResultBase
+	NotificationResult
+}
+
+// NotificationResult is implemented by the results that can deliver
+// asynchronous notifications to the client.
+type NotificationResult interface {
+	// BufferNotification appends an asynchronous notification to the result.
+	// This gets flushed only when the result is closed.
+	BufferNotification(notification pgnotify.Notification)
+	// BufferNotice appends a notice to the result. It is used to warn the
+	// client about the notifications that were dropped.
+	BufferNotice(notice pgnotice.Notice)
 }
 
 // DrainResult represents the result of a Drain command. Closing this result
@@ -943,6 +998,12 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationResult interface.
+func (r *streamingCommandResult) BufferNotification(pgnotify.Notification) {
+	// Unimplemented: the internal executor does not support asynchronous
+	// notifications.
+}
+
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
 	panic("unimplemented")
@@ -974,6 +1035,18 @@ func (r *streamingCommandResult) DisableBuffering() {
 	panic("cannot disable buffering here")
 }
 
+// BufferedResultsLen is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) BufferedResultsLen() int {
+	// Results are streamed to the consumer as they are produced, so nothing
+	// is ever buffered.
+	return 0
+}
+
+// TruncateBufferedResults is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) TruncateBufferedResults(int) bool {
+	return false
+}
+
 // SetError is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SetError(err error) {
 	r.err = err
@@ -0,0 +1,110 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCopyTo verifies the encoding of the data sent by COPY ... TO STDOUT in
+// each of the supported formats.
+func TestCopyTo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	params, _ := tests.CreateTestServerParams()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (id INT PRIMARY KEY, s STRING, b BYTES)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a', NULL), (2, e'b\tc', 'x'), (3, 'd,"e"', NULL)`)
+
+	pgURL, cleanupGoDB := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "StartServer" /* prefix */, url.User(username.RootUser))
+	defer cleanupGoDB()
+	conn, err := pgxConn(t, pgURL)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close(ctx)) }()
+
+	for _, tc := range []struct {
+		stmt     string
+		expected string
+		rows     int64
+	}{
+		{
+			stmt: `COPY t TO STDOUT`,
+			expected: "1\ta\t\\N\n" +
+				"2\tb\\tc\t\\\\x78\n" +
+				"3\td,\"e\"\t\\N\n",
+			rows: 3,
+		},
+		{
+			stmt: `COPY t (s, id) TO STDOUT WITH NULL 'null'`,
+			expected: "a\t1\n" +
+				"b\\tc\t2\n" +
+				"d,\"e\"\t3\n",
+			rows: 3,
+		},
+		{
+			stmt: `COPY t TO STDOUT WITH CSV HEADER`,
+			expected: "id,s,b\n" +
+				"1,a,\n" +
+				"2,b\tc,\\x78\n" +
+				"3,\"d,\"\"e\"\"\",\n",
+			rows: 3,
+		},
+		{
+			stmt: `COPY (SELECT id, s FROM t WHERE id > 1 ORDER BY id DESC) TO STDOUT WITH DELIMITER '|'`,
+			expected: "3|d,\"e\"\n" +
+				"2|b\\tc\n",
+			rows: 2,
+		},
+		{
+			stmt: `COPY (SELECT id FROM t WHERE id = 1) TO STDOUT WITH BINARY`,
+			expected: "PGCOPY\n\xff\r\n\x00" + // Signature.
+				"\x00\x00\x00\x00" + // Flags.
+				"\x00\x00\x00\x00" + // Header extension length.
+				"\x00\x01" + // Field count.
+				"\x00\x00\x00\x08" + "\x00\x00\x00\x00\x00\x00\x00\x01" + // id.
+				"\xff\xff", // Trailer.
+			rows: 1,
+		},
+	} {
+		t.Run(tc.stmt, func(t *testing.T) {
+			var buf bytes.Buffer
+			tag, err := conn.PgConn().CopyTo(ctx, &buf, tc.stmt)
+			require.NoError(t, err)
+			require.Equal(t, tc.rows, tag.RowsAffected())
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+
+	// Errors are reported before any data is sent.
+	var buf bytes.Buffer
+	_, err = conn.PgConn().CopyTo(ctx, &buf, `COPY t TO STDOUT WITH BINARY HEADER`)
+	require.ErrorContains(t, err, "HEADER unsupported in BINARY format")
+	_, err = conn.PgConn().CopyTo(ctx, &buf, `COPY (INSERT INTO t VALUES (4)) TO STDOUT`)
+	require.ErrorContains(t, err, "COPY query must have a RETURNING clause")
+	require.Zero(t, buf.Len())
+}
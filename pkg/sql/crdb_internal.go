@@ -2820,6 +2820,24 @@ func writeCreateTypeDescRow(
 			tree.NewDString(tree.AsString(node)),      // create_statement
 			tree.DNull,                                // enum_members
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{typeDesc.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node, err := makeCreateDomainNode(name, typeDesc.TypeDesc().Domain)
+		if err != nil {
+			return false, err
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),       // database_id
+			tree.NewDString(db.GetName()),             // database_name
+			tree.NewDString(sc.GetName()),             // schema_name
+			tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
+			tree.NewDString(typeDesc.GetName()),       // descriptor_name
+			tree.NewDString(tree.AsString(node)),      // create_statement
+			tree.DNull,                                // enum_members
+		)
 	case descpb.TypeDescriptor_ALIAS:
 		// Alias types are created implicitly, so we don't have create
 		// statements for them.
@@ -2983,6 +3001,10 @@ CREATE TABLE crdb_internal.create_function_statements (
 			}
 			for i := range treeNode.Options {
 				if body, ok := treeNode.Options[i].(tree.FunctionBodyStr); ok {
+					if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
+						// The body of a PL/pgSQL function is displayed as written.
+						continue
+					}
 					typeReplacedBody, err := formatFunctionQueryTypesForDisplay(ctx, &p.semaCtx, p.SessionData(), string(body))
 					if err != nil {
 						return err
@@ -3073,6 +3095,9 @@ CREATE TABLE crdb_internal.create_statements (
 		} else if table.IsSequence() {
 			descType = typeSequence
 			stmt, err = ShowCreateSequence(ctx, &name, table)
+		} else if table.IsForeignTable() {
+			descType = typeTable
+			stmt, err = ShowCreateForeignTable(ctx, &name, table)
 		} else {
 			descType = typeTable
 			displayOptions := ShowCreateDisplayOptions{
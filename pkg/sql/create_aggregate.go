@@ -0,0 +1,391 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate function.
+func (p *planner) CreateAggregate(
+	ctx context.Context, n *tree.CreateAggregate,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	db, _, prefix, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	sc, err := p.getNonTemporarySchemaForCreate(ctx, db, prefix.Schema())
+	if err != nil {
+		return nil, err
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+// aggregateDefinition contains the resolved options of a CREATE AGGREGATE
+// statement.
+type aggregateDefinition struct {
+	stateFunc  *funcdesc.Mutable
+	stateType  *types.T
+	finalFunc  *funcdesc.Mutable
+	returnType *types.T
+	initCond   *string
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if !params.p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V23_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	if len(n.n.Params) == 0 {
+		return unimplemented.New("CREATE AGGREGATE", "aggregates without arguments are not supported")
+	}
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	paramTypes := make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class != tree.FunctionParamIn {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"aggregates can only have IN parameters")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return err
+		}
+		pbParams[i] = pbParam
+		paramTypes[i] = pbParam.Type
+	}
+
+	existing, err := params.p.matchUDF(
+		params.ctx, &tree.FuncObj{FuncName: n.n.Name, Params: n.n.Params}, false, /* required */
+	)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return pgerror.Newf(pgcode.DuplicateFunction,
+			"function %q already exists with same argument types", n.n.Name.Object())
+	}
+
+	def, err := n.resolveOptions(params, paramTypes)
+	if err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privileges := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		n.scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Functions,
+	)
+	aggDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		n.scDesc.GetID(),
+		n.n.Name.Object(),
+		pbParams,
+		def.returnType,
+		false, /* returnSet */
+		privileges,
+	)
+	aggDesc.Aggregate = &descpb.FunctionDescriptor_Aggregate{
+		StateFuncID: def.stateFunc.GetID(),
+		StateType:   def.stateType,
+		InitCond:    def.initCond,
+	}
+	if def.finalFunc != nil {
+		aggDesc.Aggregate.FinalFuncID = def.finalFunc.GetID()
+	}
+	// The aggregate is as volatile as the most volatile of its functions.
+	aggDesc.SetVolatility(def.stateFunc.GetVolatility())
+	if def.finalFunc != nil {
+		switch def.finalFunc.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			aggDesc.SetVolatility(catpb.Function_VOLATILE)
+		case catpb.Function_STABLE:
+			if aggDesc.GetVolatility() == catpb.Function_IMMUTABLE {
+				aggDesc.SetVolatility(catpb.Function_STABLE)
+			}
+		}
+	}
+
+	// Add references to the user-defined types used by the aggregate.
+	typeDeps := catalog.DescriptorIDSet{}
+	for _, typ := range append(paramTypes, def.stateType, def.returnType) {
+		typeIDs, err := typedesc.GetTypeDescriptorClosure(typ)
+		if err != nil {
+			return err
+		}
+		for typeID := range typeIDs {
+			typeDeps.Add(typeID)
+		}
+	}
+	for _, typeID := range typeDeps.Ordered() {
+		isTable, err := params.p.descIsTable(params.ctx, typeID)
+		if err != nil {
+			return err
+		}
+		if isTable {
+			return unimplemented.New("CREATE AGGREGATE", "aggregates over table types are not supported")
+		}
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", typeID, id)
+		if err := params.p.addTypeBackReference(params.ctx, typeID, id, jobDesc); err != nil {
+			return err
+		}
+	}
+	aggDesc.DependsOnTypes = typeDeps.Ordered()
+
+	if err := params.p.createDescriptor(
+		params.ctx, &aggDesc, tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	// Add back-references from the state transition and final functions.
+	def.stateFunc.AddAggregateReference(id)
+	if err := params.p.writeFuncSchemaChange(params.ctx, def.stateFunc); err != nil {
+		return err
+	}
+	if def.finalFunc != nil {
+		def.finalFunc.AddAggregateReference(id)
+		if err := params.p.writeFuncSchemaChange(params.ctx, def.finalFunc); err != nil {
+			return err
+		}
+	}
+
+	mutScDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Schema(params.ctx, n.scDesc.GetID())
+	if err != nil {
+		return err
+	}
+	mutScDesc.AddFunction(aggDesc.GetName(), toSchemaOverloadSignature(&aggDesc))
+	if err := params.p.writeSchemaDescChange(params.ctx, mutScDesc, "Create Aggregate"); err != nil {
+		return err
+	}
+
+	fnName := tree.MakeQualifiedFunctionName(n.dbDesc.GetName(), n.scDesc.GetName(), aggDesc.GetName())
+	return params.p.logEvent(params.ctx, aggDesc.GetID(), &eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+	})
+}
+
+// resolveOptions resolves and validates the options of the CREATE AGGREGATE
+// statement, given the types of the aggregated arguments.
+func (n *createAggregateNode) resolveOptions(
+	params runParams, paramTypes []*types.T,
+) (*aggregateDefinition, error) {
+	var sfunc, ffunc *tree.UnresolvedName
+	var stype tree.ResolvableTypeReference
+	var initCond *string
+	seen := make(map[string]struct{}, len(n.n.Options))
+	for i := range n.n.Options {
+		opt := &n.n.Options[i]
+		name := strings.ToLower(string(opt.Name))
+		if _, ok := seen[name]; ok {
+			return nil, pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		seen[name] = struct{}{}
+		switch name {
+		case "sfunc", "finalfunc":
+			fn, err := aggregateOptionFuncName(opt)
+			if err != nil {
+				return nil, err
+			}
+			if name == "sfunc" {
+				sfunc = fn
+			} else {
+				ffunc = fn
+			}
+		case "stype":
+			if opt.Type == nil {
+				return nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q requires a type", name)
+			}
+			stype = opt.Type
+		case "initcond":
+			if opt.Value != nil {
+				s := opt.Value.RawString()
+				initCond = &s
+			} else {
+				// Allow unquoted initial values, such as INITCOND = foo.
+				s := tree.AsStringWithFlags(opt.Type, tree.FmtBareIdentifiers)
+				initCond = &s
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q not recognized", name)
+		}
+	}
+	if stype == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if sfunc == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+
+	def := &aggregateDefinition{initCond: initCond}
+	var err error
+	def.stateType, err = tree.ResolveType(params.ctx, stype, params.p)
+	if err != nil {
+		return nil, err
+	}
+
+	stateArgTypes := append([]*types.T{def.stateType}, paramTypes...)
+	var stateRetType *types.T
+	def.stateFunc, stateRetType, err = n.resolveSupportFunc(params, sfunc, stateArgTypes)
+	if err != nil {
+		return nil, err
+	}
+	if !stateRetType.Equivalent(def.stateType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s", sfunc, def.stateType.SQLString())
+	}
+
+	def.returnType = def.stateType
+	if ffunc != nil {
+		def.finalFunc, def.returnType, err = n.resolveSupportFunc(
+			params, ffunc, []*types.T{def.stateType},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if initCond != nil {
+		if _, _, err := tree.ParseAndRequireString(
+			def.stateType, *initCond, params.EvalContext(),
+		); err != nil {
+			return nil, err
+		}
+	} else if def.stateFunc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		!paramTypes[0].Equivalent(def.stateType) {
+		// The first input becomes the initial state in this case.
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict "+
+				"and transition type is not compatible with input type")
+	}
+	return def, nil
+}
+
+// resolveSupportFunc resolves the state transition or final function of an
+// aggregate, which must be a user-defined function with exactly the given
+// parameter types. It returns the descriptor of the function, and its return
+// type.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams, name *tree.UnresolvedName, argTypes []*types.T,
+) (*funcdesc.Mutable, *types.T, error) {
+	fnName, err := name.ToFunctionName()
+	if err != nil {
+		return nil, nil, err
+	}
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(params.ctx, name, &path)
+	if err != nil {
+		return nil, nil, err
+	}
+	explicitSchema := ""
+	if fnName.ExplicitSchema {
+		explicitSchema = fnName.Schema()
+	}
+	ol, err := fnDef.MatchOverload(argTypes, explicitSchema, &path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ol.IsUDF {
+		return nil, nil, unimplemented.New("CREATE AGGREGATE",
+			"builtin functions cannot be used as aggregate support functions")
+	}
+	if ol.Class == tree.AggregateClass {
+		return nil, nil, pgerror.Newf(pgcode.WrongObjectType, "function %s is an aggregate", name)
+	}
+	if ol.Variadic {
+		return nil, nil, unimplemented.New("CREATE AGGREGATE",
+			"variadic functions cannot be used as aggregate support functions")
+	}
+	if ol.ReturnSet {
+		return nil, nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function %s must not return a set", name)
+	}
+	fnDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fnDesc.GetParentID() != n.dbDesc.GetID() {
+		return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the aggregate cannot refer to other databases")
+	}
+	return fnDesc, fnDesc.ReturnType.Type, nil
+}
+
+// aggregateOptionFuncName returns the function name specified by a CREATE
+// AGGREGATE option.
+func aggregateOptionFuncName(opt *tree.AggregateOption) (*tree.UnresolvedName, error) {
+	if name, ok := opt.Type.(*tree.UnresolvedObjectName); ok {
+		return name.ToUnresolvedName(), nil
+	}
+	return nil, pgerror.Newf(pgcode.Syntax,
+		"aggregate attribute %q requires a function name", strings.ToLower(string(opt.Name)))
+}
+
+func (n *createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createAggregateNode) Close(ctx context.Context)           {}
+func (n *createAggregateNode) ReadingOwnWrites()                   {}
@@ -0,0 +1,259 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+// CreateDomain creates a domain type.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(p.RunParams(ctx), n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	if !params.p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V23_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create domains",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	base, err := tree.ResolveType(params.ctx, n.n.Type, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return err
+	}
+	if base.UserDefined() {
+		return unimplemented.NewWithIssue(27796, "domains over user-defined types are not supported")
+	}
+	if base.Family() == types.ArrayFamily && base.ArrayContents().UserDefined() {
+		return unimplemented.NewWithIssue(27796, "domains over arrays of user-defined types are not supported")
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{BaseType: base}
+	if n.n.Default != nil {
+		typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+			params.ctx, n.n.Default, base, "DEFAULT", params.p.SemaCtx(),
+			volatility.Volatile, true, /* allowAssignmentCast */
+		)
+		if err != nil {
+			return err
+		}
+		s := tree.Serialize(typedExpr)
+		domain.DefaultExpr = &s
+	}
+	if err := addDomainConstraints(
+		params.ctx, params.p.SemaCtx(), n.typeName.Type(), domain, n.n.Constraints,
+	); err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           n.typeName.Type(),
+		ID:             id,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType()
+
+	return params.p.finishCreateType(params, id, n.typeName, typeDesc, n.dbDesc, schema)
+}
+
+// addDomainConstraints validates the given constraints and adds them to the
+// domain. Unnamed CHECK constraints are named as in Postgres.
+func addDomainConstraints(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	domainName string,
+	domain *descpb.TypeDescriptor_Domain,
+	constraints tree.DomainConstraints,
+) error {
+	sawNull, sawNotNull := false, false
+	for i := range constraints {
+		c := &constraints[i]
+		switch c.Kind {
+		case tree.DomainConstraintNotNull:
+			sawNotNull = true
+			domain.NotNull = true
+		case tree.DomainConstraintNull:
+			sawNull = true
+		case tree.DomainConstraintCheck:
+			if err := validateDomainCheckExpr(ctx, semaCtx, c.Expr, domain.BaseType); err != nil {
+				return err
+			}
+			name := string(c.Name)
+			if name == "" {
+				name = generateDomainCheckName(domainName, domain)
+			} else if findDomainCheck(domain, name) != -1 {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", name, domainName)
+			}
+			domain.Checks = append(domain.Checks, descpb.TypeDescriptor_Domain_Check{
+				Name: name,
+				Expr: tree.Serialize(c.Expr),
+			})
+		}
+		if sawNull && sawNotNull {
+			return pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+		}
+	}
+	return nil
+}
+
+// validateDomainCheckExpr verifies that the expression of a domain CHECK
+// constraint is a boolean expression that refers to no columns other than
+// VALUE, which is typed as the base type of the domain.
+func validateDomainCheckExpr(
+	ctx context.Context, semaCtx *tree.SemaContext, expr tree.Expr, base *types.T,
+) error {
+	replaced, err := replaceDomainValue(expr, tree.NewTypedCastExpr(tree.DNull, base))
+	if err != nil {
+		return err
+	}
+	_, err = schemaexpr.SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, "CHECK", semaCtx, volatility.Volatile, false, /* allowAssignmentCast */
+	)
+	return err
+}
+
+// replaceDomainValue returns a copy of the expression of a domain CHECK
+// constraint in which references to VALUE are replaced with the given
+// expression.
+func replaceDomainValue(expr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if n, ok := expr.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == "value" {
+			return false, value, nil
+		}
+		return true, expr, nil
+	})
+}
+
+// generateDomainCheckName returns a name for an unnamed CHECK constraint of a
+// domain which is not used by any other constraint of the domain.
+func generateDomainCheckName(domainName string, domain *descpb.TypeDescriptor_Domain) string {
+	name := fmt.Sprintf("%s_check", domainName)
+	for i := 1; findDomainCheck(domain, name) != -1; i++ {
+		name = fmt.Sprintf("%s_check%d", domainName, i)
+	}
+	return name
+}
+
+// findDomainCheck returns the index of the CHECK constraint of the domain
+// with the given name, or -1 if there is none.
+func findDomainCheck(domain *descpb.TypeDescriptor_Domain, name string) int {
+	for i := range domain.Checks {
+		if domain.Checks[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// makeCreateDomainNode returns a CREATE DOMAIN statement that recreates the
+// given domain.
+func makeCreateDomainNode(
+	name *tree.UnresolvedObjectName, domain *descpb.TypeDescriptor_Domain,
+) (*tree.CreateDomain, error) {
+	node := &tree.CreateDomain{
+		TypeName: name,
+		Type:     domain.BaseType,
+	}
+	if domain.DefaultExpr != nil {
+		expr, err := parser.ParseExpr(*domain.DefaultExpr)
+		if err != nil {
+			return nil, err
+		}
+		node.Default = expr
+	}
+	if domain.NotNull {
+		node.Constraints = append(node.Constraints, tree.DomainConstraint{
+			Kind: tree.DomainConstraintNotNull,
+		})
+	}
+	for _, c := range domain.Checks {
+		expr, err := parser.ParseExpr(c.Expr)
+		if err != nil {
+			return nil, err
+		}
+		node.Constraints = append(node.Constraints, tree.DomainConstraint{
+			Name: tree.Name(c.Name),
+			Kind: tree.DomainConstraintCheck,
+			Expr: expr,
+		})
+	}
+	return node, nil
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
@@ -69,6 +70,19 @@ func (p *planner) parseExternalConnection(
 
 func (p *planner) createExternalConnection(
 	params runParams, n *tree.CreateExternalConnection,
+) error {
+	ec, err := p.parseExternalConnection(params.ctx, n)
+	if err != nil {
+		return err
+	}
+	return p.createExternalConnectionFromURI(params, externalConnectionOp, ec)
+}
+
+// createExternalConnectionFromURI persists an External Connection named
+// ec.name for the resource at ec.endpoint, and grants ALL on it to the current
+// user. op names the statement creating the External Connection in errors.
+func (p *planner) createExternalConnectionFromURI(
+	params runParams, op string, ec externalConnection,
 ) error {
 	if !p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V22_2SystemExternalConnectionsTable) {
 		return pgerror.Newf(pgcode.FeatureNotSupported,
@@ -78,19 +92,14 @@ func (p *planner) createExternalConnection(
 
 	if err := params.p.CheckPrivilege(params.ctx, syntheticprivilege.GlobalPrivilegeObject,
 		privilege.EXTERNALCONNECTION); err != nil {
-		return pgerror.New(
+		return pgerror.Newf(
 			pgcode.InsufficientPrivilege,
-			"only users with the EXTERNALCONNECTION system privilege are allowed to CREATE EXTERNAL CONNECTION")
+			"only users with the EXTERNALCONNECTION system privilege are allowed to %s", op)
 	}
 
 	// TODO(adityamaru): Add some metrics to track CREATE EXTERNAL CONNECTION
 	// usage.
 
-	ec, err := p.parseExternalConnection(params.ctx, n)
-	if err != nil {
-		return err
-	}
-
 	ex := externalconn.NewMutableExternalConnection()
 	// TODO(adityamaru): Revisit if we need to reject certain kinds of names.
 	ex.SetConnectionName(ec.name)
@@ -99,7 +108,7 @@ func (p *planner) createExternalConnection(
 	// newly created External Connection with the appropriate privileges. We will
 	// grant root/admin, and the user that created the object ALL privileges.
 
-	if err = logAndSanitizeExternalConnectionURI(params.ctx, ec.endpoint); err != nil {
+	if err := logAndSanitizeExternalConnectionURI(params.ctx, ec.endpoint); err != nil {
 		return errors.Wrap(err, "failed to log and sanitize External Connection")
 	}
 
@@ -140,6 +149,10 @@ func logAndSanitizeExternalConnectionURI(ctx context.Context, externalConnection
 	if err != nil {
 		return err
 	}
+	// Foreign servers carry their password in the user info of the URI.
+	if u, err := url.Parse(clean); err == nil {
+		clean = u.Redacted()
+	}
 	log.Ops.Infof(ctx, "external connection planning on connecting to destination %v", redact.Safe(clean))
 	return nil
 }
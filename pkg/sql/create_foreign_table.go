@@ -0,0 +1,199 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type createForeignTableNode struct {
+	n      *tree.CreateForeignTable
+	dbDesc catalog.DatabaseDescriptor
+}
+
+// CreateForeignTable creates a foreign table.
+// Privileges: CREATE on database and USAGE on the foreign server.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if err := p.checkForeignServerUsage(ctx, string(n.Server)); err != nil {
+		return nil, err
+	}
+
+	return &createForeignTableNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireForeignTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			return nil
+		}
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+	desc, err := n.makeForeignTableDesc(params, schema.GetID(), id, privs)
+	if err != nil {
+		return err
+	}
+
+	if err := params.p.createDescriptor(
+		params.ctx, desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, params.p, desc); err != nil {
+		return err
+	}
+
+	// Log Create Table event. This is an auditable log event and is
+	// recorded in the same transaction as the table descriptor update.
+	return params.p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+// makeForeignTableDesc returns the table descriptor for a new foreign table.
+// Foreign tables only have columns: their rows are stored on the foreign
+// server, which is also where constraints are enforced.
+func (n *createForeignTableNode) makeForeignTableDesc(
+	params runParams, schemaID descpb.ID, id descpb.ID, privs *catpb.PrivilegeDescriptor,
+) (*tabledesc.Mutable, error) {
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc := tabledesc.InitTableDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		schemaID,
+		n.n.Table.Table(),
+		creationTime,
+		privs,
+		tree.PersistencePermanent,
+	)
+	opts := &descpb.TableDescriptor_ForeignTableOptions{
+		ServerName:   string(n.n.Server),
+		RemoteSchema: "public",
+		RemoteTable:  n.n.Table.Table(),
+	}
+	exprEval := params.p.ExprEvaluator("CREATE FOREIGN TABLE")
+	for _, opt := range n.n.Options {
+		v, err := exprEval.String(params.ctx, opt.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch opt.Key {
+		case "schema_name":
+			opts.RemoteSchema = v
+		case "table_name":
+			opts.RemoteTable = v
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid option %q", opt.Key)
+		}
+	}
+	desc.ForeignTable = opts
+	if n.dbDesc.IsMultiRegion() {
+		desc.SetTableLocalityRegionalByTable(tree.PrimaryRegionNotSpecifiedName)
+	}
+
+	for _, def := range n.n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s is not supported on foreign tables", tree.AsString(def))
+		}
+		if d.IsSerial || d.GeneratedIdentity.IsGeneratedAsIdentity || d.HasDefaultExpr() ||
+			d.HasOnUpdateExpr() || d.IsComputed() || d.PrimaryKey.IsPrimaryKey ||
+			d.Unique.IsUnique || d.HasFKConstraint() || len(d.CheckExprs) > 0 ||
+			d.HasColumnFamily() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %s of a foreign table can only have a type and a NOT NULL constraint", d.Name)
+		}
+		cdd, err := tabledesc.MakeColumnDefDescs(params.ctx, d, &params.p.semaCtx, params.EvalContext())
+		if err != nil {
+			return nil, err
+		}
+		if cdd.ColumnDescriptor.Type.UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %s of a foreign table cannot have a user-defined type", d.Name)
+		}
+		desc.AddColumn(cdd.ColumnDescriptor)
+	}
+	if len(desc.Columns) == 0 {
+		return nil, pgerror.New(pgcode.InvalidTableDefinition,
+			"foreign tables must have at least one column")
+	}
+
+	version := params.ExecCfg().Settings.Version.ActiveVersionOrEmpty(params.ctx)
+	if err := desc.AllocateIDs(params.ctx, version); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -23,13 +24,16 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type createFunctionNode struct {
@@ -111,8 +115,9 @@ func (*createFunctionNode) Close(ctx context.Context)           {}
 func (n *createFunctionNode) createNewFunction(
 	udfDesc *funcdesc.Mutable, scDesc *schemadesc.Mutable, params runParams,
 ) error {
+	lang := getFuncLanguage(n.cf.Options)
 	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
+		err := setFuncOption(params, udfDesc, option, lang)
 		if err != nil {
 			return err
 		}
@@ -134,23 +139,7 @@ func (n *createFunctionNode) createNewFunction(
 		return err
 	}
 
-	returnType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
-	if err != nil {
-		return err
-	}
-	paramTypes := make([]*types.T, len(udfDesc.Params))
-	for i, param := range udfDesc.Params {
-		paramTypes[i] = param.Type
-	}
-	scDesc.AddFunction(
-		udfDesc.GetName(),
-		descpb.SchemaDescriptor_FunctionOverload{
-			ID:         udfDesc.GetID(),
-			ArgTypes:   paramTypes,
-			ReturnType: returnType,
-			ReturnSet:  udfDesc.ReturnType.ReturnSet,
-		},
-	)
+	scDesc.AddFunction(udfDesc.GetName(), toSchemaOverloadSignature(udfDesc))
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
 		return err
 	}
@@ -162,27 +151,47 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	// TODO(chengxiong): add validation that the function is not referenced. This
 	// is needed when we start allowing function references from other objects.
 
-	// Make sure parameter names are not changed.
+	// Make sure return type is the same. This also ensures that the OUT
+	// parameters are not changed.
+	retType, err := n.cf.ResolveReturnType(params.ctx, params.p)
+	if err != nil {
+		return err
+	}
+	if n.cf.ReturnType.IsSet != udfDesc.ReturnType.ReturnSet || !retType.Equal(udfDesc.ReturnType.Type) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+	}
+
+	// Make sure parameter names and classes are not changed.
+	if len(n.cf.Params) != len(udfDesc.Params) {
+		// The input parameters match, so the OUT parameters must differ.
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change OUT parameters of existing function")
+	}
 	for i := range n.cf.Params {
+		class, err := funcdesc.ParamClassToProto(n.cf.Params[i].Class)
+		if err != nil {
+			return err
+		}
+		if class != udfDesc.Params[i].Class {
+			return pgerror.Newf(
+				pgcode.InvalidFunctionDefinition, "cannot change mode of parameter %q", udfDesc.Params[i].Name,
+			)
+		}
 		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
+			if class == catpb.Function_Param_OUT {
+				return pgerror.Newf(
+					pgcode.InvalidFunctionDefinition, "cannot change OUT parameters of existing function",
+				)
+			}
 			return pgerror.Newf(
 				pgcode.InvalidFunctionDefinition, "cannot change name of input parameter %q", udfDesc.Params[i].Name,
 			)
 		}
 	}
 
-	// Make sure return type is the same.
-	retType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
-	if err != nil {
-		return err
-	}
-	if n.cf.ReturnType.IsSet != udfDesc.ReturnType.ReturnSet || !retType.Equal(udfDesc.ReturnType.Type) {
-		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
-	}
-
 	resetFuncOption(udfDesc)
+	lang := getFuncLanguage(n.cf.Options)
 	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
+		err := setFuncOption(params, udfDesc, option, lang)
 		if err != nil {
 			return err
 		}
@@ -263,6 +272,16 @@ func (n *createFunctionNode) getMutableFuncDesc(
 				n.cf.FuncName.Object(),
 			)
 		}
+		if existing.IsProcedure != n.cf.IsProcedure {
+			kind := "function"
+			if existing.IsProcedure {
+				kind = "procedure"
+			}
+			return nil, false, errors.WithDetailf(
+				pgerror.New(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is a %s.", n.cf.FuncName.Object(), kind,
+			)
+		}
 		fnID := funcdesc.UserDefinedFunctionOIDToID(existing.Oid)
 		fnDesc, err = params.p.checkPrivilegesForDropFunction(params.ctx, fnID)
 		if err != nil {
@@ -276,7 +295,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		return nil, false, err
 	}
 
-	returnType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
+	returnType, err := n.cf.ResolveReturnType(params.ctx, params.p)
 	if err != nil {
 		return nil, false, err
 	}
@@ -299,6 +318,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.ReturnType.IsSet,
 		privileges,
 	)
+	newUdfDesc.SetIsProcedure(n.cf.IsProcedure)
 
 	return &newUdfDesc, true, nil
 }
@@ -392,7 +412,23 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	return nil
 }
 
-func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.FunctionOption) error {
+// getFuncLanguage returns the language specified in the given function
+// options.
+func getFuncLanguage(options tree.FunctionOptions) tree.FunctionLanguage {
+	for _, option := range options {
+		if lang, ok := option.(tree.FunctionLanguage); ok {
+			return lang
+		}
+	}
+	return tree.FunctionLangSQL
+}
+
+func setFuncOption(
+	params runParams,
+	udfDesc *funcdesc.Mutable,
+	option tree.FunctionOption,
+	lang tree.FunctionLanguage,
+) error {
 	switch t := option.(type) {
 	case tree.FunctionVolatility:
 		v, err := funcdesc.VolatilityToProto(t)
@@ -415,6 +451,12 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 		}
 		udfDesc.SetLang(v)
 	case tree.FunctionBodyStr:
+		if lang == tree.FunctionLangPLpgSQL {
+			// The body of a PL/pgSQL function is stored as written, since it is
+			// not valid SQL.
+			udfDesc.SetFuncBody(string(t))
+			return nil
+		}
 		// Replace any sequence names in the function body with IDs.
 		seqReplacedFuncBody, err := replaceSeqNamesWithIDs(params.ctx, params.p, string(t), true)
 		if err != nil {
@@ -425,6 +467,14 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 			return err
 		}
 		udfDesc.SetFuncBody(typeReplacedFuncBody)
+	case tree.FunctionSecurity:
+		v, err := funcdesc.SecurityToProto(t)
+		if err != nil {
+			return err
+		}
+		udfDesc.SetSecurity(v)
+	case *tree.SetVar:
+		return setFuncSetting(params, udfDesc, t)
 	default:
 		return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 	}
@@ -432,11 +482,82 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 	return nil
 }
 
+// setFuncSetting applies a SET or RESET clause of a function to the function
+// descriptor. The value of the session variable is evaluated and validated
+// when the clause is applied, and stored in the form that is passed to the
+// setter of the session variable when the function is executed.
+func setFuncSetting(params runParams, udfDesc *funcdesc.Mutable, n *tree.SetVar) error {
+	if n.ResetAll {
+		udfDesc.ResetAllSettings()
+		return nil
+	}
+	name := strings.ToLower(n.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return err
+	}
+	if v.Set == nil {
+		// Variables that need more than the session data to be set, e.g. to
+		// change the transaction or the current user, cannot be set for the
+		// duration of a function invocation.
+		return pgerror.Newf(pgcode.CantChangeRuntimeParam,
+			"parameter %q cannot be set in a function", name)
+	}
+	isReset := n.Reset
+	if len(n.Values) == 1 {
+		if _, ok := n.Values[0].(tree.DefaultVal); ok {
+			// "SET var = DEFAULT" means RESET.
+			isReset = true
+		}
+	}
+	if isReset {
+		// The function uses the value of the caller's session.
+		udfDesc.ResetSetting(name)
+		return nil
+	}
+
+	typedValues := make([]tree.TypedExpr, len(n.Values))
+	for i, expr := range n.Values {
+		expr = paramparse.UnresolvedNameToStrVal(expr)
+		var dummyHelper tree.IndexedVarHelper
+		typedValue, err := params.p.analyzeExpr(
+			params.ctx, expr, nil, dummyHelper, types.String, false, "SET SESSION "+name)
+		if err != nil {
+			return wrapSetVarError(err, name, expr.String())
+		}
+		typedValues[i], err = eval.Expr(params.ctx, params.EvalContext(), typedValue)
+		if err != nil {
+			return err
+		}
+	}
+	var strVal string
+	if v.GetStringVal != nil {
+		strVal, err = v.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
+	} else {
+		strVal, err = getStringVal(params.ctx, params.EvalContext(), name, typedValues)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Validate the value by applying it to a copy of the session data.
+	m := params.p.sessionDataMutatorIterator.mutator(
+		false /* applyCallbacks */, params.p.SessionData().Clone(),
+	)
+	if err := v.Set(params.ctx, m, strVal); err != nil {
+		return err
+	}
+	udfDesc.SetSetting(name, strVal)
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.ResetAllSettings()
 }
 
 func makeFunctionParam(
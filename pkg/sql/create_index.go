@@ -387,6 +387,12 @@ func populateInvertedIndexDescriptor(
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
 		indexDesc.GeoConfig = *geoindex.DefaultGeographyIndexConfig()
+	case types.TSVectorFamily:
+		switch invCol.OpClass {
+		case "tsvector_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	case types.StringFamily:
 		// Check the opclass of the last column in the list, which is the column
 		// we're going to inverted index.
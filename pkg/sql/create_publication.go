@@ -0,0 +1,108 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type createPublicationNode struct {
+	n      *tree.CreatePublication
+	dbDesc *dbdesc.Mutable
+	pub    descpb.DatabaseDescriptor_Publication
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on the database and CHANGEFEED on the tables.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if dbDesc.FindPublication(string(n.Name)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.Name)
+	}
+
+	pub := descpb.DatabaseDescriptor_Publication{
+		Name:       string(n.Name),
+		OwnerProto: p.User().EncodeProto(),
+	}
+	for i := range n.Tables {
+		tableDesc, err := p.ResolveExistingObjectEx(
+			ctx, n.Tables[i].ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if tableDesc.GetParentID() != dbDesc.GetID() {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"table %q is not in the current database %q", tableDesc.GetName(), dbDesc.GetName())
+		}
+		if tableDesc.IsTemporary() {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"cannot add temporary table %q to a publication", tableDesc.GetName())
+		}
+		// The subscriptions decode the rows of the tables from the individual
+		// KVs of the replication stream, which requires a single column
+		// family.
+		if tableDesc.NumFamilies() > 1 {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot add table %q with multiple column families to a publication",
+				tableDesc.GetName())
+		}
+		if err := p.CheckPrivilege(ctx, tableDesc, privilege.CHANGEFEED); err != nil {
+			return nil, err
+		}
+		for _, id := range pub.TableIDs {
+			if id == tableDesc.GetID() {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"table %q is specified more than once", tableDesc.GetName())
+			}
+		}
+		pub.TableIDs = append(pub.TableIDs, tableDesc.GetID())
+	}
+
+	return &createPublicationNode{n: n, dbDesc: dbDesc, pub: pub}, nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	n.dbDesc.AddPublication(n.pub)
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.dbDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPublicationNode) Close(context.Context)        {}
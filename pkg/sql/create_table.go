@@ -192,6 +192,8 @@ func getSchemaForCreateTable(
 					mismatchedType = !tableDescriptor.IsView()
 				case tree.ResolveRequireSequenceDesc:
 					mismatchedType = !tableDescriptor.IsSequence()
+				case tree.ResolveRequireForeignTableDesc:
+					mismatchedType = !tableDescriptor.IsForeignTable()
 				}
 				// If kind any is passed then there will never be a mismatch
 				// and we can return an exists error.
@@ -380,8 +382,10 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 
 		// If we have a single statement txn we want to run CTAS async, and
-		// consequently ensure it gets queued as a SchemaChange.
-		if params.extendedEvalCtx.TxnIsSingleStmt {
+		// consequently ensure it gets queued as a SchemaChange. With WITH NO
+		// DATA, there is nothing to backfill and the table is public right
+		// away.
+		if params.extendedEvalCtx.TxnIsSingleStmt && !n.n.AsWithNoData {
 			desc.State = descpb.DescriptorState_ADD
 		}
 	} else {
@@ -496,8 +500,8 @@ func (n *createTableNode) startExec(params runParams) error {
 	}
 
 	// If we are in a multi-statement txn or the source has placeholders, we
-	// execute the CTAS query synchronously.
-	if n.n.As() && !params.extendedEvalCtx.TxnIsSingleStmt {
+	// execute the CTAS query synchronously, unless WITH NO DATA was specified.
+	if n.n.As() && !n.n.AsWithNoData && !params.extendedEvalCtx.TxnIsSingleStmt {
 		err = func() error {
 			// The data fill portion of CREATE AS must operate on a read snapshot,
 			// so that it doesn't end up observing its own writes.
@@ -649,6 +653,9 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintNotDeferrable,
+		nil, /* exclusionOps */
+		"",  /* exclusionMethod */
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -659,7 +666,9 @@ func addUniqueWithoutIndexColumnTableDef(
 
 // addUniqueWithoutIndexTableDef runs various checks on the given
 // UniqueConstraintTableDef before adding it as a UNIQUE WITHOUT INDEX
-// constraint to the given table descriptor.
+// constraint to the given table descriptor. The definition may also be a
+// deferrable unique constraint with an index, in which case the caller is
+// responsible for adding the non-unique index that backs the constraint.
 func addUniqueWithoutIndexTableDef(
 	ctx context.Context,
 	evalCtx *eval.Context,
@@ -671,20 +680,22 @@ func addUniqueWithoutIndexTableDef(
 	validationBehavior tree.ValidationBehavior,
 	semaCtx *tree.SemaContext,
 ) error {
-	if !sessionData.EnableUniqueWithoutIndexConstraints {
-		return pgerror.New(pgcode.FeatureNotSupported,
-			"unique constraints without an index are not yet supported",
-		)
-	}
-	if len(d.Storing) > 0 {
-		return pgerror.New(pgcode.FeatureNotSupported,
-			"unique constraints without an index cannot store columns",
-		)
-	}
-	if d.PartitionByIndex.ContainsPartitions() {
-		return pgerror.New(pgcode.FeatureNotSupported,
-			"partitioned unique constraints without an index are not supported",
-		)
+	if d.WithoutIndex {
+		if !sessionData.EnableUniqueWithoutIndexConstraints {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"unique constraints without an index are not yet supported",
+			)
+		}
+		if len(d.Storing) > 0 {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"unique constraints without an index cannot store columns",
+			)
+		}
+		if d.PartitionByIndex.ContainsPartitions() {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"partitioned unique constraints without an index are not supported",
+			)
+		}
 	}
 	if d.NotVisible {
 		// Theoretically, this should never happen because this is not supported by
@@ -712,16 +723,197 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrable,
+		nil /* exclusionOps */, "" /* exclusionMethod */, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// resolveExclusionConstraintElems checks that the access method and the
+// operators of the given ExcludeConstraintTableDef are supported, and returns
+// the names of its columns along with their operators.
+func resolveExclusionConstraintElems(
+	d *tree.ExcludeConstraintTableDef, desc *tabledesc.Mutable,
+) (colNames []string, ops []string, _ error) {
+	switch d.Using {
+	case "", "gist", "btree":
+		// The index that backs the constraint is chosen based on its operators
+		// (see makeExclusionConstraintIndex), so the access method only needs to
+		// be one that supports exclusion constraints in Postgres.
+	default:
+		return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"access method %q does not support exclusion constraints", d.Using,
+		)
+	}
+
+	colNames = make([]string, len(d.Elems))
+	ops = make([]string, len(d.Elems))
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return nil, nil, err
+		}
+		sym := elem.Operator.Symbol
+		switch sym {
+		case treecmp.EQ, treecmp.NE, treecmp.Overlaps:
+		default:
+			return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"operator %s is not supported in exclusion constraints", sym,
+			)
+		}
+		// The NE operator is evaluated as the negation of EQ, so it is supported
+		// for the same types.
+		lookupSym := sym
+		if lookupSym == treecmp.NE {
+			lookupSym = treecmp.EQ
+		}
+		if _, ok := tree.CmpOps[lookupSym].LookupImpl(col.GetType(), col.GetType()); !ok {
+			return nil, nil, pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s",
+				col.GetType().SQLString(), sym, col.GetType().SQLString(),
+			)
+		}
+		colNames[i] = string(elem.Column)
+		ops[i] = sym.String()
+	}
+	return colNames, ops, nil
+}
+
+// makeExclusionConstraintIndex returns the descriptor of the index that backs
+// the given exclusion constraint. The index allows the rows that conflict with
+// a new row to be found with a lookup, rather than by comparing the new row
+// with every row in the table. If any of the constraint columns are compared
+// with =, it is a non-unique index on those columns which stores the other
+// constraint columns. Otherwise, it is an inverted index on a GEOMETRY column
+// that is compared with &&. Exclusion constraints which can't be backed by
+// either index are not supported.
+//
+// The index has the same predicate as the constraint, and is given a name when
+// the table's IDs are allocated.
+func makeExclusionConstraintIndex(
+	ctx context.Context,
+	st *cluster.Settings,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn *tree.TableName,
+	semaCtx *tree.SemaContext,
+) (descpb.IndexDescriptor, error) {
+	if _, _, err := resolveExclusionConstraintElems(d, desc); err != nil {
+		return descpb.IndexDescriptor{}, err
+	}
+	primaryKeyCols := make(map[string]struct{})
+	for _, name := range desc.GetPrimaryIndex().IndexDesc().KeyColumnNames {
+		primaryKeyCols[name] = struct{}{}
+	}
+	var keyCols tree.IndexElemList
+	var storing tree.NameList
+	var geoCol catalog.Column
+	var geoElem tree.IndexElem
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Operator.Symbol == treecmp.EQ {
+			keyCols = append(keyCols, tree.IndexElem{Column: elem.Column, Direction: tree.Ascending})
+			continue
+		}
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+		if geoCol == nil && elem.Operator.Symbol == treecmp.Overlaps &&
+			col.GetType().Family() == types.GeometryFamily {
+			geoCol = col
+			geoElem = tree.IndexElem{Column: elem.Column, Direction: tree.Ascending}
+		}
+		// Primary key columns are implicitly stored in every index, and virtual
+		// columns can't be stored.
+		if _, ok := primaryKeyCols[string(elem.Column)]; !ok && !col.IsVirtual() {
+			storing = append(storing, elem.Column)
+		}
+	}
+
+	var idx descpb.IndexDescriptor
+	switch {
+	case len(keyCols) > 0:
+		idx.StoreColumnNames = storing.ToStrings()
+		if err := idx.FillColumns(keyCols); err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+	case geoCol != nil:
+		idx.Type = descpb.IndexDescriptor_INVERTED
+		if err := idx.FillColumns(tree.IndexElemList{geoElem}); err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+		if err := populateInvertedIndexDescriptor(ctx, st, geoCol, &idx, geoElem); err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+	default:
+		return descpb.IndexDescriptor{}, errors.WithHint(
+			pgerror.New(pgcode.FeatureNotSupported,
+				"exclusion constraints must compare a column with = or a GEOMETRY column with &&",
+			),
+			"Conflicting rows are found with a lookup into an index on these columns.",
+		)
+	}
+
+	if d.Predicate != nil {
+		expr, err := schemaexpr.ValidatePartialIndexPredicate(ctx, desc, d.Predicate, tn, semaCtx)
+		if err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+		idx.Predicate = expr
+	}
+	return idx, nil
+}
+
+// addExclusionConstraintTableDef runs various checks on the given
+// ExcludeConstraintTableDef before adding it as an exclusion constraint to the
+// given table descriptor. Exclusion constraints are stored as UNIQUE WITHOUT
+// INDEX constraints with an operator for each column, and are enforced using
+// the same checks. The index that backs the constraint must be added
+// separately, see makeExclusionConstraintIndex.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	colNames, ops, err := resolveExclusionConstraintElems(d, desc)
+	if err != nil {
+		return err
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	method := d.Using
+	if method == "" {
+		method = "gist"
+	}
+	return ResolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrable, ops, method, ts,
+		validationBehavior,
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
-// constraint to the descriptor.
+// constraint to the descriptor. If exclusionOps is non-empty, the constraint
+// is an exclusion constraint, and exclusionOps contains the operator for each
+// column.
 //
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
@@ -732,9 +924,16 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrable tree.ConstraintDeferrable,
+	exclusionOps []string,
+	exclusionMethod string,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
+	kind := "unique"
+	if len(exclusionOps) > 0 {
+		kind = "exclusion"
+	}
 	var colSet catalog.TableColSet
 	cols := make([]catalog.Column, len(colNames))
 	for i, name := range colNames {
@@ -745,7 +944,7 @@ func ResolveUniqueWithoutIndexConstraint(
 		// Ensure that the columns don't have duplicates.
 		if colSet.Contains(col.GetID()) {
 			return pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.GetName())
+				"column %q appears twice in %s constraint", col.GetName(), kind)
 		}
 		colSet.Add(col.GetID())
 		cols[i] = col
@@ -753,8 +952,12 @@ func ResolveUniqueWithoutIndexConstraint(
 
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
+		prefix := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOps) > 0 {
+			prefix = fmt.Sprintf("%s_%s_excl", tbl.GetName(), strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			prefix,
 			func(p string) bool {
 				c, _ := tbl.FindConstraintWithName(p)
 				return c != nil
@@ -788,6 +991,11 @@ func ResolveUniqueWithoutIndexConstraint(
 		Validity:     validity,
 		ConstraintID: tbl.NextConstraintID,
 	}
+	if len(exclusionOps) > 0 {
+		uc.ExclusionOperators = exclusionOps
+		uc.ExclusionMethod = exclusionMethod
+	}
+	uc.SetDeferrableMode(deferrable)
 	tbl.NextConstraintID++
 	if ts == NewTable {
 		tbl.UniqueWithoutIndexConstraints = append(tbl.UniqueWithoutIndexConstraints, uc)
@@ -1033,6 +1241,7 @@ func ResolveFK(
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
 	}
+	ref.SetDeferrableMode(d.Deferrable)
 	tbl.NextConstraintID++
 	if ts == NewTable {
 		tbl.OutboundFKs = append(tbl.OutboundFKs, ref)
@@ -1856,10 +2065,16 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			// A deferrable unique constraint cannot be enforced by a unique index,
+			// since the index rejects duplicate keys as soon as they are written.
+			// It is instead backed by a non-unique index with an auto-generated
+			// name, and the constraint is added below as a UNIQUE WITHOUT INDEX
+			// constraint.
+			deferrable := d.Deferrable.IsDeferrable()
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
-			if d.Name != "" {
+			if d.Name != "" && !deferrable {
 				if idx, _ := desc.FindIndexWithName(d.Name.String()); idx != nil {
 					return nil, pgerror.Newf(pgcode.DuplicateRelation, "duplicate index name: %q", d.Name)
 				}
@@ -1885,6 +2100,10 @@ func NewTableDesc(
 				Version:          indexEncodingVersion,
 				NotVisible:       d.NotVisible,
 			}
+			if deferrable {
+				idx.Name = ""
+				idx.Unique = false
+			}
 			columns := d.Columns
 			if d.Sharded != nil {
 				if d.PrimaryKey && n.PartitionByTable.ContainsPartitions() && !n.PartitionByTable.All {
@@ -1965,7 +2184,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -1986,6 +2206,39 @@ func NewTableDesc(
 		}
 	}
 
+	// Add the indexes that back exclusion constraints now that the primary key
+	// is known. The constraints themselves are added below.
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ExcludeConstraintTableDef)
+		if !ok {
+			continue
+		}
+		idx, err := makeExclusionConstraintIndex(ctx, st, d, &desc, &n.Table, semaCtx)
+		if err != nil {
+			return nil, err
+		}
+		idx.Version = indexEncodingVersion
+		if desc.PartitionAllBy {
+			newImplicitCols, newPartitioning, err := CreatePartitioning(
+				ctx,
+				st,
+				evalCtx,
+				&desc,
+				idx,
+				partitionAllBy,
+				nil, /* allowedNewColumnNames */
+				allowImplicitPartitioning,
+			)
+			if err != nil {
+				return nil, err
+			}
+			tabledesc.UpdateIndexPartitioning(&idx, false /* isIndexPrimary */, newImplicitCols, newPartitioning)
+		}
+		if err := desc.AddSecondaryIndex(idx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Now that all columns are in place, add any explicit families (this is done
 	// here, rather than in the constraint pass below since we want to pick up
 	// explicit allocations before AllocateIDs adds implicit ones).
@@ -2015,7 +2268,14 @@ func NewTableDesc(
 			partitionBy = n.PartitionByTable.PartitionBy
 		}
 		// At this point, we could have PARTITION ALL BY NOTHING, so check it is != nil.
-		if partitionBy != nil {
+		if partitionBy.IsDeclarative() {
+			// A PostgreSQL-style PARTITION BY clause does not define any partitions
+			// yet. They are added to the primary index by subsequent CREATE TABLE
+			// ... PARTITION OF statements.
+			if err := checkDeclarativePartitioning(&desc, partitionBy); err != nil {
+				return nil, err
+			}
+		} else if partitionBy != nil {
 			newPrimaryIndex := desc.GetPrimaryIndex().IndexDescDeepCopy()
 			newImplicitCols, newPartitioning, err := CreatePartitioning(
 				ctx,
@@ -2126,7 +2386,7 @@ func NewTableDesc(
 			}
 
 		case *tree.UniqueConstraintTableDef:
-			if d.WithoutIndex {
+			if d.WithoutIndex || d.Deferrable.IsDeferrable() {
 				if err := addUniqueWithoutIndexTableDef(
 					ctx, evalCtx, sessionData, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
 				); err != nil {
@@ -2134,6 +2394,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
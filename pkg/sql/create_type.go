@@ -218,10 +218,6 @@ func CreateUserDefinedArrayTypeDesc(
 		elemTyp = types.MakeEnum(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id))
 	case descpb.TypeDescriptor_COMPOSITE:
 		for _, e := range typDesc.Composite.Elements {
-			if e.ElementType.UserDefined() {
-				return nil, unimplemented.NewWithIssue(91779,
-					"composite types that reference user-defined types not yet supported")
-			}
 			if e.ElementType.TypeMeta.ImplicitRecordType {
 				return nil, unimplemented.NewWithIssue(70099,
 					"cannot use table record type as part of composite type")
@@ -234,6 +230,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -413,14 +411,15 @@ func CreateCompositeTypeDesc(
 				"composite type definition contains duplicate label %q", value)
 		}
 		elts[i].ElementLabel = string(value.Label)
-		typ, err := tree.ResolveType(params.ctx, value.Type, params.p.semaCtx.TypeResolver)
+		// Types referenced by the composite type must be in the same database.
+		var typ *types.T
+		var err error
+		params.p.runWithOptions(resolveFlags{contextDatabaseID: dbDesc.GetID()}, func() {
+			typ, err = tree.ResolveType(params.ctx, value.Type, params.p.semaCtx.TypeResolver)
+		})
 		if err != nil {
 			return nil, err
 		}
-		if typ.UserDefined() {
-			return nil, unimplemented.NewWithIssue(91779,
-				"composite types that reference user-defined types not yet supported")
-		}
 		if typ.TypeMeta.ImplicitRecordType {
 			return nil, unimplemented.NewWithIssue(70099,
 				"cannot use table record type as part of composite type")
@@ -0,0 +1,592 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// deferredConstraints tracks the checking mode of deferrable constraints in
+// the current transaction, as well as the constraints whose validation was
+// postponed until the end of the transaction.
+//
+// A constraint check that fails during the execution of a statement is
+// recorded here instead of returning an error if the constraint is deferred,
+// along with the keys of the rows that violated it. These keys are validated
+// again when the transaction commits or when the constraint is made immediate
+// with SET CONSTRAINTS. Later statements that fix the violations (e.g. by
+// inserting the missing referenced rows) don't need to be tracked, and later
+// statements that introduce new violations record their own keys.
+type deferredConstraints struct {
+	// all is the mode set by SET CONSTRAINTS ALL. It applies to the constraints
+	// that are not in byConstraint.
+	all deferredConstraintMode
+	// byConstraint contains the modes set by SET CONSTRAINTS for specific
+	// constraints.
+	byConstraint map[deferredConstraintKey]bool
+	// pending contains the constraints that must be validated before the
+	// transaction commits.
+	pending []deferredConstraint
+	// keysAcc accounts for the memory used by the keys of the pending
+	// constraints. It is bound to the session monitor, since the keys are held
+	// until the end of the transaction.
+	keysAcc mon.BoundAccount
+}
+
+// deferredConstraintKey identifies a constraint of a table.
+type deferredConstraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// deferredConstraintMode is the mode set by SET CONSTRAINTS ALL.
+type deferredConstraintMode int
+
+const (
+	// deferredConstraintModeDefault indicates that every constraint uses its
+	// INITIALLY DEFERRED or INITIALLY IMMEDIATE mode.
+	deferredConstraintModeDefault deferredConstraintMode = iota
+	deferredConstraintModeDeferred
+	deferredConstraintModeImmediate
+)
+
+// deferredConstraintMaxKeys is the maximum number of keys recorded for a
+// deferred constraint. Beyond that, the whole table is validated instead.
+const deferredConstraintMaxKeys = 1000
+
+// deferredConstraint identifies a constraint that must be validated before the
+// transaction commits.
+type deferredConstraint struct {
+	tableID descpb.ID
+	name    string
+	unique  bool
+	// keys contains the values of the constraint columns for the rows that
+	// violated the constraint. It is nil if all the rows of the table must be
+	// validated.
+	keys []tree.Datums
+	// seenKeys is used to deduplicate keys.
+	seenKeys map[string]struct{}
+}
+
+// addKeys records the given keys of rows that violated the constraint. The
+// memory used by the keys must already be accounted for in acc; it is released
+// for the keys that aren't retained.
+func (c *deferredConstraint) addKeys(ctx context.Context, acc *mon.BoundAccount, keys []tree.Datums) {
+	if c.seenKeys == nil {
+		// The whole table is validated.
+		acc.Shrink(ctx, deferredKeysSize(keys))
+		return
+	}
+	for i, key := range keys {
+		s := tree.AsString(&key)
+		if _, ok := c.seenKeys[s]; ok {
+			acc.Shrink(ctx, deferredKeysSize(keys[i:i+1]))
+			continue
+		}
+		if len(c.keys) >= deferredConstraintMaxKeys {
+			c.releaseKeys(ctx, acc)
+			acc.Shrink(ctx, deferredKeysSize(keys[i:]))
+			return
+		}
+		c.seenKeys[s] = struct{}{}
+		c.keys = append(c.keys, key)
+	}
+}
+
+// releaseKeys discards the recorded keys, which means that all the rows of the
+// table must be validated, and releases their memory from acc.
+func (c *deferredConstraint) releaseKeys(ctx context.Context, acc *mon.BoundAccount) {
+	acc.Shrink(ctx, deferredKeysSize(c.keys))
+	c.keys, c.seenKeys = nil, nil
+}
+
+// record records the rows that violated the constraint according to the
+// given error.
+func (c *deferredConstraint) record(
+	ctx context.Context, acc *mon.BoundAccount, e *exec.DeferrableCheckError,
+) {
+	if e.AllRows {
+		c.releaseKeys(ctx, acc)
+		return
+	}
+	c.addKeys(ctx, acc, e.Keys)
+}
+
+// deferredKeysSize returns the memory used by the given keys.
+func deferredKeysSize(keys []tree.Datums) int64 {
+	var size int64
+	for _, key := range keys {
+		size += memsize.DatumsOverhead
+		for _, d := range key {
+			size += int64(d.Size())
+		}
+	}
+	return size
+}
+
+// isDeferred returns true if the check that produced the given error should
+// be postponed until the end of the transaction.
+func (dc *deferredConstraints) isDeferred(e *exec.DeferrableCheckError) bool {
+	key := deferredConstraintKey{tableID: descpb.ID(e.TableID), name: e.ConstraintName}
+	if deferred, ok := dc.byConstraint[key]; ok {
+		return deferred
+	}
+	switch dc.all {
+	case deferredConstraintModeDeferred:
+		return true
+	case deferredConstraintModeImmediate:
+		return false
+	default:
+		return e.InitiallyDeferred
+	}
+}
+
+// maybeDefer records the constraint that produced the given error if it is a
+// deferred constraint check failure, in which case true is returned.
+func (dc *deferredConstraints) maybeDefer(ctx context.Context, err error) bool {
+	if dc == nil {
+		// Checks are never deferred if the transaction is not committed by the
+		// current connExecutor (e.g. for internal executors with an outer txn).
+		return false
+	}
+	var e *exec.DeferrableCheckError
+	if !errors.As(err, &e) || !dc.isDeferred(e) {
+		return false
+	}
+	tableID := descpb.ID(e.TableID)
+	for i := range dc.pending {
+		c := &dc.pending[i]
+		if c.tableID == tableID && c.name == e.ConstraintName && c.unique == e.Unique {
+			c.record(ctx, &dc.keysAcc, e)
+			return true
+		}
+	}
+	dc.pending = append(dc.pending, deferredConstraint{
+		tableID:  tableID,
+		name:     e.ConstraintName,
+		unique:   e.Unique,
+		seenKeys: make(map[string]struct{}),
+	})
+	dc.pending[len(dc.pending)-1].record(ctx, &dc.keysAcc, e)
+	return true
+}
+
+// setMode implements SET CONSTRAINTS for all the constraints if all is true,
+// or for the given constraints otherwise. It returns the pending constraints
+// that became immediate and must be validated right away; their keys must be
+// released with releaseKeys once they are validated.
+func (dc *deferredConstraints) setMode(
+	all bool, constraints []deferredConstraintKey, deferred bool,
+) []deferredConstraint {
+	if all {
+		dc.byConstraint = nil
+		if deferred {
+			dc.all = deferredConstraintModeDeferred
+			return nil
+		}
+		dc.all = deferredConstraintModeImmediate
+		toValidate := dc.pending
+		dc.pending = nil
+		return toValidate
+	}
+	if dc.byConstraint == nil {
+		dc.byConstraint = make(map[deferredConstraintKey]bool)
+	}
+	for _, key := range constraints {
+		dc.byConstraint[key] = deferred
+	}
+	if deferred {
+		return nil
+	}
+	var toValidate []deferredConstraint
+	remaining := dc.pending[:0]
+	for _, c := range dc.pending {
+		key := deferredConstraintKey{tableID: c.tableID, name: c.name}
+		if isDeferred, ok := dc.byConstraint[key]; ok && !isDeferred {
+			toValidate = append(toValidate, c)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	dc.pending = remaining
+	return toValidate
+}
+
+// releaseKeys releases the memory used by the keys of the given constraints,
+// which are no longer pending.
+func (dc *deferredConstraints) releaseKeys(ctx context.Context, constraints []deferredConstraint) {
+	for i := range constraints {
+		constraints[i].releaseKeys(ctx, &dc.keysAcc)
+	}
+}
+
+// reset clears the state at the end of a transaction.
+func (dc *deferredConstraints) reset(ctx context.Context) {
+	dc.all = deferredConstraintModeDefault
+	dc.byConstraint = nil
+	dc.pending = nil
+	dc.keysAcc.Clear(ctx)
+}
+
+// constraintDeferrableMode returns whether the checks of the given constraint
+// may be postponed until the end of the transaction.
+func constraintDeferrableMode(c catalog.Constraint) tree.ConstraintDeferrable {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().DeferrableMode()
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		return uwi.UniqueWithoutIndexDesc().DeferrableMode()
+	}
+	return tree.ConstraintNotDeferrable
+}
+
+// SetConstraints sets the checking mode of deferrable constraints for the
+// current transaction. Pending constraints that become immediate are
+// validated right away.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	var constraints []deferredConstraintKey
+	for i := range n.Names {
+		keys, err := p.resolveDeferrableConstraints(ctx, &n.Names[i])
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, keys...)
+	}
+
+	dc := p.extendedEvalCtx.DeferredConstraints
+	if dc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	toValidate := dc.setMode(n.All, constraints, n.Deferred)
+	err := p.validateDeferredConstraints(ctx, toValidate)
+	dc.releaseKeys(ctx, toValidate)
+	if err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// resolveDeferrableConstraints returns the constraints named by SET
+// CONSTRAINTS. As in Postgres, a name qualified with a schema refers to the
+// constraints with that name in the schema, and an unqualified name refers to
+// the constraints with that name in the first schema of the search path which
+// has any. All the constraints must be deferrable.
+func (p *planner) resolveDeferrableConstraints(
+	ctx context.Context, name *tree.TableName,
+) ([]deferredConstraintKey, error) {
+	if name.ExplicitCatalog && string(name.CatalogName) != p.CurrentDatabase() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cross-database references are not implemented: %s", tree.ErrString(name))
+	}
+	var schemas []string
+	if name.ExplicitSchema {
+		schema, err := p.SessionData().SearchPath.MaybeResolveTemporarySchema(string(name.SchemaName))
+		if err != nil {
+			return nil, err
+		}
+		schemas = []string{schema}
+	} else {
+		iter := p.SessionData().SearchPath.Iter()
+		for schema, ok := iter.Next(); ok; schema, ok = iter.Next() {
+			schemas = append(schemas, schema)
+		}
+	}
+	for _, schema := range schemas {
+		rows, err := p.QueryBufferedEx(
+			ctx, "resolve-deferrable-constraint", sessiondata.NoSessionDataOverride,
+			`SELECT c.conrelid, c.condeferrable
+  FROM pg_catalog.pg_constraint AS c
+  JOIN pg_catalog.pg_namespace AS n ON c.connamespace = n.oid
+ WHERE c.conname = $1 AND n.nspname = $2`,
+			string(name.ObjectName), schema,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		keys := make([]deferredConstraintKey, len(rows))
+		for i, row := range rows {
+			if !bool(tree.MustBeDBool(row[1])) {
+				return nil, pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", tree.ErrString(name))
+			}
+			keys[i] = deferredConstraintKey{
+				tableID: descpb.ID(tree.MustBeDOid(row[0]).Oid),
+				name:    string(name.ObjectName),
+			}
+		}
+		return keys, nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"constraint %q does not exist", tree.ErrString(name))
+}
+
+// validateDeferredConstraints verifies that the rows of the tables with the
+// recorded keys satisfy the given constraints. Constraints that no longer
+// exist are skipped.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, constraints []deferredConstraint,
+) error {
+	for _, c := range constraints {
+		tableDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(ctx, c.tableID)
+		if err != nil {
+			if errors.Is(err, catalog.ErrDescriptorDropped) ||
+				pgerror.GetPGCode(err) == pgcode.UndefinedTable {
+				continue
+			}
+			return err
+		}
+		constraint, _ := tableDesc.FindConstraintWithName(c.name)
+		if constraint == nil {
+			continue
+		}
+		if c.unique {
+			if uwi := constraint.AsUniqueWithoutIndex(); uwi != nil {
+				err = p.validateDeferredUniqueConstraint(ctx, tableDesc, uwi.UniqueWithoutIndexDesc(), c.keys)
+			}
+		} else if fk := constraint.AsForeignKey(); fk != nil {
+			err = p.validateDeferredForeignKey(ctx, tableDesc, fk.ForeignKeyDesc(), c.keys)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDeferredForeignKey verifies that the rows of the origin table of the
+// FK with the given keys have a matching row in the referenced table. If keys
+// is nil, all the rows are validated. The returned error mirrors the error
+// produced by the checks that run at the end of statements.
+func (p *planner) validateDeferredForeignKey(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	fk *descpb.ForeignKeyConstraint,
+	keys []tree.Datums,
+) error {
+	targetTable, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(ctx, fk.ReferencedTableID)
+	if err != nil {
+		return err
+	}
+	if len(fk.OriginColumnIDs) > 1 && fk.Match == semenumpb.Match_FULL {
+		query, _, err := matchFullUnacceptableKeyQuery(srcTable, fk, keys == nil /* limitResults */)
+		if err != nil {
+			return err
+		}
+		query, args := restrictDeferredQuery(query, keys)
+		values, err := p.QueryRowEx(
+			ctx, "validate deferred fk constraint", sessiondata.NodeUserSessionDataOverride, query, args...,
+		)
+		if err != nil {
+			return err
+		}
+		if values.Len() > 0 {
+			return errors.WithDetail(
+				pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
+					"insert or update on table %s violates foreign key constraint %s",
+					lexbase.EscapeSQLIdent(srcTable.GetName()), lexbase.EscapeSQLIdent(fk.Name),
+				), fk.Name),
+				"MATCH FULL does not allow mixing of null and nonnull key values.",
+			)
+		}
+	}
+
+	query, colNames, err := nonMatchingRowQuery(
+		srcTable, fk, targetTable, 0 /* indexIDForValidation */, keys == nil, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+	query, args := restrictDeferredQuery(query, keys)
+	log.VEventf(ctx, 2, "validating deferred FK %q with query %q", fk.Name, query)
+	values, err := p.QueryRowEx(
+		ctx, "validate deferred fk constraint", sessiondata.NodeUserSessionDataOverride, query, args...,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() == 0 {
+		return nil
+	}
+	nCols := len(fk.OriginColumnIDs)
+	return errors.WithDetail(
+		pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
+			"insert or update on table %s violates foreign key constraint %s",
+			lexbase.EscapeSQLIdent(srcTable.GetName()), lexbase.EscapeSQLIdent(fk.Name),
+		), fk.Name),
+		"Key "+formatDeferredKey(colNames[:nCols], values[:nCols])+
+			" is not present in table "+lexbase.EscapeSQLIdent(targetTable.GetName())+".",
+	)
+}
+
+// validateDeferredUniqueConstraint verifies that the rows of the table with
+// the given keys have unique values for the columns of the constraint. If keys
+// is nil, all the rows are validated. The returned error mirrors the error
+// produced by the checks that run at the end of statements.
+func (p *planner) validateDeferredUniqueConstraint(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	keys []tree.Datums,
+) error {
+	if uc.IsExclusion() {
+		return p.validateDeferredExclusionConstraint(ctx, tableDesc, uc, keys)
+	}
+	query, colNames, err := duplicateRowQuery(
+		tableDesc, uc.ColumnIDs, uc.Predicate, 0 /* indexIDForValidation */, keys == nil, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+	query, args := restrictDeferredQuery(query, keys)
+	log.VEventf(ctx, 2, "validating deferred unique constraint %q with query %q", uc.Name, query)
+	values, err := p.QueryRowEx(
+		ctx, "validate deferred unique constraint", sessiondata.NodeUserSessionDataOverride, query, args...,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() == 0 {
+		return nil
+	}
+	return errors.WithDetail(
+		pgerror.WithConstraintName(pgerror.Newf(pgcode.UniqueViolation,
+			"duplicate key value violates unique constraint %s", lexbase.EscapeSQLIdent(uc.Name),
+		), uc.Name),
+		"Key "+formatDeferredKey(colNames, values)+" already exists.",
+	)
+}
+
+// validateDeferredExclusionConstraint verifies that no row of the table with
+// the given keys conflicts with another row according to the given exclusion
+// constraint. If keys is nil, all the rows are validated. The returned error
+// mirrors the error produced by the checks that run at the end of statements.
+func (p *planner) validateDeferredExclusionConstraint(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	keys []tree.Datums,
+) error {
+	query, colNames, err := conflictingRowQuery(
+		tableDesc, uc, 0 /* indexIDForValidation */, keys == nil, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+	query, args := restrictDeferredQuery(query, keys)
+	log.VEventf(ctx, 2, "validating deferred exclusion constraint %q with query %q", uc.Name, query)
+	values, err := p.QueryRowEx(
+		ctx, "validate deferred exclusion constraint", sessiondata.NodeUserSessionDataOverride, query, args...,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() == 0 {
+		return nil
+	}
+	return errors.WithDetail(
+		pgerror.WithConstraintName(pgerror.Newf(pgcode.ExclusionViolation,
+			"conflicting key value violates exclusion constraint %s", lexbase.EscapeSQLIdent(uc.Name),
+		), uc.Name),
+		"Key "+formatDeferredKey(colNames, values[:len(colNames)])+" conflicts with existing key.",
+	)
+}
+
+// restrictDeferredQuery restricts the given validation query, whose first
+// columns are the columns of a constraint, to the rows with the given keys. It
+// returns the new query, which returns at most one row, along with its
+// arguments. The query is returned as is if keys is nil.
+//
+// For example, the keys (1, 2) and (3, NULL) restrict the query to:
+//
+//	SELECT * FROM (<query>) AS v(k1, k2)
+//	WHERE (k1, k2) IN (($1, $2)) OR (k1, k2) IS NOT DISTINCT FROM ($3, $4)
+//	LIMIT 1
+func restrictDeferredQuery(query string, keys []tree.Datums) (string, []interface{}) {
+	if keys == nil {
+		return query, nil
+	}
+	if len(keys) == 0 {
+		return fmt.Sprintf("SELECT * FROM (%s) AS v WHERE false", query), nil
+	}
+	cols := make([]string, len(keys[0]))
+	for i := range cols {
+		cols[i] = fmt.Sprintf("k%d", i+1)
+	}
+	colList := strings.Join(cols, ", ")
+	args := make([]interface{}, 0, len(keys)*len(cols))
+	placeholders := func(key tree.Datums) string {
+		ph := make([]string, len(key))
+		for i, d := range key {
+			args = append(args, d)
+			ph[i] = fmt.Sprintf("$%d", len(args))
+		}
+		return "(" + strings.Join(ph, ", ") + ")"
+	}
+	var in, filters []string
+	for _, key := range keys {
+		if keyHasNull(key) {
+			// Keys with NULLs only violate MATCH FULL foreign keys.
+			filters = append(filters, fmt.Sprintf("(%s) IS NOT DISTINCT FROM %s", colList, placeholders(key)))
+		} else {
+			in = append(in, placeholders(key))
+		}
+	}
+	if len(in) > 0 {
+		filters = append(filters, fmt.Sprintf("(%s) IN (%s)", colList, strings.Join(in, ", ")))
+	}
+	return fmt.Sprintf(
+		"SELECT * FROM (%s) AS v(%s) WHERE %s LIMIT 1", query, colList, strings.Join(filters, " OR "),
+	), args
+}
+
+func keyHasNull(key tree.Datums) bool {
+	for _, d := range key {
+		if d == tree.DNull {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDeferredKey formats the given key as (a, b)=(1, 2).
+func formatDeferredKey(colNames []string, values tree.Datums) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	sb.WriteString(strings.Join(colNames, ", "))
+	sb.WriteString(")=(")
+	for i, d := range values {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(d.String())
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
@@ -69,6 +69,11 @@ func (n *discardNode) startExec(params runParams) error {
 			return err
 		}
 
+		// UNLISTEN *
+		if tn := params.p.extendedEvalCtx.Notifications; tn != nil {
+			tn.listens = append(tn.listens, listenAction{unlisten: true, all: true})
+		}
+
 	case tree.DiscardModeSequences:
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
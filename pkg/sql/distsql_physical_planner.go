@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
@@ -404,6 +405,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	case *distinctNode:
 	case *exportNode:
 	case *filterNode:
+	case *foreignScanNode:
 	case *groupNode:
 	case *indexJoinNode:
 	case *invertedFilterNode:
@@ -476,7 +478,28 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		}
 		return checkSupportForPlanNode(n.source.plan)
 
+	case *foreignScanNode:
+		// The rows are read from the foreign server on the gateway, but the rest
+		// of the plan can be distributed.
+		return canDistribute, nil
+
 	case *groupNode:
+		for _, f := range n.funcs {
+			if f.userDefined == nil {
+				continue
+			}
+			// The state transition and final functions of user-defined
+			// aggregates are evaluated by the aggregator, so they must be
+			// distributable.
+			if err := checkExpr(f.userDefined.Transition); err != nil {
+				return cannotDistribute, err
+			}
+			if f.userDefined.Final != nil {
+				if err := checkExpr(f.userDefined.Final); err != nil {
+					return cannotDistribute, err
+				}
+			}
+		}
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
@@ -663,6 +686,22 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		return canDistribute, nil
 
 	case *windowNode:
+		for _, f := range n.funcs {
+			if f.userDefined == nil {
+				continue
+			}
+			// The state transition and final functions of user-defined
+			// aggregates are evaluated by the windower, so they must be
+			// distributable.
+			if err := checkExpr(f.userDefined.Transition); err != nil {
+				return cannotDistribute, err
+			}
+			if f.userDefined.Final != nil {
+				if err := checkExpr(f.userDefined.Final); err != nil {
+					return cannotDistribute, err
+				}
+			}
+		}
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
@@ -1879,11 +1918,22 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggregateSpec(
+				ctx, planCtx, fholder.userDefined,
+			)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -1915,6 +1965,37 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregateSpec returns the specification of a user-defined
+// aggregate for an aggregator processor.
+func makeUserDefinedAggregateSpec(
+	ctx context.Context, planCtx *PlanningCtx, info *exec.UserDefinedAggInfo,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		StateType:        info.StateType,
+		StrictTransition: info.StrictTransition,
+	}
+	var err error
+	spec.Transition, err = physicalplan.MakeExpression(
+		ctx, info.Transition, planCtx, nil, /* indexVarMap */
+	)
+	if err != nil {
+		return nil, err
+	}
+	spec.ResultType = info.StateType
+	if info.Final != nil {
+		spec.Final, err = physicalplan.MakeExpression(ctx, info.Final, planCtx, nil /* indexVarMap */)
+		if err != nil {
+			return nil, err
+		}
+		spec.ResultType = info.Final.ResolvedType()
+	}
+	spec.InitState, err = physicalplan.MakeExpression(ctx, info.InitState, planCtx, nil /* indexVarMap */)
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2462,6 +2543,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
+		if agg.UserDefined != nil {
+			finalOutTypes[i] = agg.UserDefined.ResultType
+			continue
+		}
 		var err error
 		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
 		if err != nil {
@@ -3281,6 +3366,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			return nil, err
 		}
 
+	case *foreignScanNode:
+		plan, err = dsp.createPlanForForeignScan(planCtx, n)
+
 	case *groupNode:
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
 		if err != nil {
@@ -3573,6 +3661,29 @@ func (dsp *DistSQLPlanner) createValuesPlan(
 	return p, nil
 }
 
+// createPlanForForeignScan creates a physical plan for a foreignScanNode,
+// which reads the rows of a foreign table on the gateway.
+func (dsp *DistSQLPlanner) createPlanForForeignScan(
+	planCtx *PlanningCtx, n *foreignScanNode,
+) (*PhysicalPlan, error) {
+	p := planCtx.NewPhysicalPlan()
+
+	resultTypes := getTypesFromResultColumns(n.resultColumns)
+	pIdx := p.AddProcessor(physicalplan.Processor{
+		SQLInstanceID: dsp.gatewaySQLInstanceID,
+		Spec: execinfrapb.ProcessorSpec{
+			Core:        execinfrapb.ProcessorCoreUnion{ForeignScan: n.makeSpec()},
+			Output:      []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
+			ResultTypes: resultTypes,
+		},
+	})
+	p.ResultRouters = []physicalplan.ProcessorIdx{pIdx}
+	p.Distribution = physicalplan.LocalPlan
+	p.PlanToStreamColMap = identityMapInPlace(make([]int, len(resultTypes)))
+
+	return p, nil
+}
+
 // createValuesSpecFromTuples creates a ValuesCoreSpec from the results of
 // evaluating the given tuples.
 func (dsp *DistSQLPlanner) createValuesSpecFromTuples(
@@ -1798,7 +1798,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
+		if names := plan.cascades[i].TriggerNames; len(names) > 0 {
+			log.VEventf(ctx, 2, "executing AFTER triggers %v", names)
+		} else {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
+		}
 
 		// We place a sequence point before every cascade, so
 		// that each subsequent cascade can observe the writes
@@ -1807,7 +1811,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// those fall back to legacy cascades code, it will disable stepping. So we
 		// have to reenable stepping each time.
 		_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
-		if err := planner.Txn().Step(ctx); err != nil {
+		if err := planner.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			recv.SetError(err)
 			return false
 		}
@@ -1877,7 +1881,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	// those fall back to legacy cascades code, it will disable stepping. So we
 	// have to reenable stepping each time.
 	_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
-	if err := planner.Txn().Step(ctx); err != nil {
+	if err := planner.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
 		recv.SetError(err)
 		return false
 	}
@@ -1891,6 +1895,12 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			evalCtxFactory(),
 			recv,
 		); err != nil {
+			if planner.extendedEvalCtx.DeferredConstraints.maybeDefer(ctx, err) {
+				// The check failed for a deferred constraint, which will be
+				// validated when the transaction commits.
+				log.VEventf(ctx, 2, "deferring check query %d", i+1)
+				continue
+			}
 			recv.SetError(err)
 			return false
 		}
@@ -1949,9 +1959,9 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
-	// TODO(yuzefovich): at the moment, errOnlyResultWriter is sufficient here,
-	// but it may not be the case when we support cascades through the optimizer.
-	postqueryResultWriter := &errOnlyResultWriter{}
+	// The rows produced by a postquery, such as the results of AFTER trigger
+	// functions, are discarded.
+	postqueryResultWriter := &droppingResultWriter{}
 	postqueryRecv.resultWriterMu.row = postqueryResultWriter
 	postqueryRecv.resultWriterMu.batch = postqueryResultWriter
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, nil /* finishedSetupFn */)
@@ -178,6 +178,10 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 			},
 		)
 	}
+	if table.IsForeignTable() {
+		return nil, unimplemented.NewWithIssue(
+			47473, "experimental opt-driven distsql planning: foreign table scan")
+	}
 
 	// Although we don't yet recommend distributing plans where soft limits
 	// propagate to scan nodes because we don't have infrastructure to only
@@ -520,6 +524,10 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		i := len(groupCols) + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
+		if agg.UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(
+				47473, "experimental opt-driven distsql planning: user-defined aggregate")
+		}
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
@@ -0,0 +1,40 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropDomain drops domain types. It verifies that all of the named types are
+// domains, and then drops them in the same way as DROP TYPE.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	for _, name := range n.Names {
+		_, typeDesc, err := p.ResolveMutableTypeDescriptor(ctx, name, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if typeDesc != nil && typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"%q is not a domain", tree.AsStringWithFQNames(name, &p.semaCtx.Annotations))
+		}
+	}
+	return p.DropType(ctx, &tree.DropType{
+		Names:        n.Names,
+		IfExists:     n.IfExists,
+		DropBehavior: n.DropBehavior,
+	})
+}
@@ -65,24 +65,48 @@ func (p *planner) dropExternalConnection(params runParams, n *tree.DropExternalC
 		return err
 	}
 
+	// The External Connection of a foreign server cannot be dropped while
+	// foreign tables read from it. DROP SERVER ... CASCADE drops them too.
+	tables, err := p.foreignTablesUsingServer(params.ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(tables) > 0 {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop external connection %q because foreign table %q depends on it",
+				name, tables[0].GetName()),
+			"use DROP SERVER %s CASCADE to drop the dependent foreign tables too",
+			tree.Name(name))
+	}
+
+	return p.deleteExternalConnection(params, dropExternalConnectionOp, ecPrivilege)
+}
+
+// deleteExternalConnection deletes the External Connection and all the
+// privileges granted on it.
+func (p *planner) deleteExternalConnection(
+	params runParams, op string, ecPrivilege *syntheticprivilege.ExternalConnectionPrivilege,
+) error {
 	// DROP EXTERNAL CONNECTION is only allowed for users with the `DROP`
 	// privilege on this object. We run the query as `node` since the user might
 	// not have `SELECT` on the system table.
-	if _ /* rows */, err = params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+	if _ /* rows */, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
 		params.ctx,
-		dropExternalConnectionOp,
+		op,
 		params.p.Txn(),
 		sessiondata.NodeUserSessionDataOverride,
-		`DELETE FROM system.external_connections WHERE connection_name = $1`, name,
+		`DELETE FROM system.external_connections WHERE connection_name = $1`,
+		ecPrivilege.ConnectionName,
 	); err != nil {
 		return errors.Wrapf(err, "failed to delete external connection")
 	}
 
 	// We must also DELETE all rows from system.privileges that refer to
 	// external connection.
-	if _, err = params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+	if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
 		params.ctx,
-		dropExternalConnectionOp,
+		op,
 		params.p.Txn(),
 		sessiondata.NodeUserSessionDataOverride,
 		`DELETE FROM system.privileges WHERE path = $1`, ecPrivilege.GetPath(),
@@ -0,0 +1,107 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type dropForeignTableNode struct {
+	n  *tree.DropForeignTable
+	td map[descpb.ID]toDelete
+}
+
+// DropForeignTable drops foreign tables.
+// Privileges: DROP on table.
+func (p *planner) DropForeignTable(
+	ctx context.Context, n *tree.DropForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	td := make(map[descpb.ID]toDelete, len(n.Names))
+	for i := range n.Names {
+		tn := &n.Names[i]
+		droppedDesc, err := p.prepareDrop(ctx, tn, !n.IfExists, tree.ResolveRequireForeignTableDesc)
+		if err != nil {
+			return nil, err
+		}
+		if droppedDesc == nil {
+			continue
+		}
+		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
+	}
+
+	for _, toDel := range td {
+		droppedDesc := toDel.desc
+		for _, ref := range droppedDesc.DependedOnBy {
+			if _, ok := td[ref.ID]; !ok {
+				if err := p.canRemoveDependentFromTable(ctx, droppedDesc, ref, n.DropBehavior); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if len(td) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropForeignTableNode{n: n, td: td}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *dropForeignTableNode) ReadingOwnWrites() {}
+
+func (n *dropForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("foreign_table"))
+
+	for _, toDel := range n.td {
+		droppedViews, err := params.p.dropTableImpl(
+			params.ctx,
+			toDel.desc,
+			false, /* droppingParent */
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+			n.n.DropBehavior,
+		)
+		if err != nil {
+			return err
+		}
+		// Log a Drop Table event for this table. This is an auditable log event
+		// and is recorded in the same transaction as the table descriptor
+		// update.
+		if err := params.p.logEvent(params.ctx,
+			toDel.desc.ID,
+			&eventpb.DropTable{
+				TableName:           toDel.tn.FQString(),
+				CascadeDroppedViews: droppedViews,
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropForeignTableNode) Close(context.Context)        {}
@@ -18,6 +18,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -47,13 +49,70 @@ func (p *planner) DropFunction(
 		// TODO(chengxiong): remove this check when drop function cascade is supported.
 		return nil, unimplemented.Newf("DROP FUNCTION...CASCADE", "drop function cascade not supported")
 	}
+	return p.makeDropFunctionNode(ctx, n.Functions, n.IfExists, n.DropBehavior, dropFunctionKind)
+}
+
+// DropAggregate drops a user-defined aggregate.
+func (p *planner) DropAggregate(
+	ctx context.Context, n *tree.DropAggregate,
+) (ret planNode, err error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	if n.DropBehavior == tree.DropCascade {
+		return nil, unimplemented.Newf("DROP AGGREGATE...CASCADE", "drop aggregate cascade not supported")
+	}
+	return p.makeDropFunctionNode(ctx, n.Aggregates, n.IfExists, n.DropBehavior, dropAggregateKind)
+}
+
+// DropProcedure drops a stored procedure.
+func (p *planner) DropProcedure(
+	ctx context.Context, n *tree.DropProcedure,
+) (ret planNode, err error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PROCEDURE",
+	); err != nil {
+		return nil, err
+	}
+
+	if n.DropBehavior == tree.DropCascade {
+		return nil, unimplemented.Newf("DROP PROCEDURE...CASCADE", "drop procedure cascade not supported")
+	}
+	return p.makeDropFunctionNode(ctx, n.Procedures, n.IfExists, n.DropBehavior, dropProcedureKind)
+}
+
+// dropRoutineKind is the kind of routine that a DROP statement drops.
+type dropRoutineKind int
+
+const (
+	dropFunctionKind dropRoutineKind = iota
+	dropAggregateKind
+	dropProcedureKind
+)
+
+// makeDropFunctionNode resolves the functions to drop. All of the functions
+// must be of the given kind.
+func (p *planner) makeDropFunctionNode(
+	ctx context.Context,
+	fns tree.FuncObjs,
+	ifExists bool,
+	dropBehavior tree.DropBehavior,
+	kind dropRoutineKind,
+) (planNode, error) {
 	dropNode := &dropFunctionNode{
-		toDrop:       make([]*funcdesc.Mutable, 0, len(n.Functions)),
-		dropBehavior: n.DropBehavior,
+		toDrop:       make([]*funcdesc.Mutable, 0, len(fns)),
+		dropBehavior: dropBehavior,
 	}
 	fnResolved := intsets.MakeFast()
-	for _, fn := range n.Functions {
-		ol, err := p.matchUDF(ctx, &fn, !n.IfExists)
+	for _, fn := range fns {
+		ol, err := p.matchUDF(ctx, &fn, !ifExists)
 		if err != nil {
 			return nil, err
 		}
@@ -69,6 +128,9 @@ func (p *planner) DropFunction(
 		if err != nil {
 			return nil, err
 		}
+		if err := checkDropRoutineKind(mut, kind); err != nil {
+			return nil, err
+		}
 		dropNode.toDrop = append(dropNode.toDrop, mut)
 	}
 
@@ -78,9 +140,98 @@ func (p *planner) DropFunction(
 	// TODO(chengxiong): check if there is any backreference which requires
 	// CASCADE drop behavior. This is needed when we start allowing UDF
 	// references from other objects.
+	for _, fnMutable := range dropNode.toDrop {
+		if err := p.checkFunctionTriggerDependents(ctx, fnMutable); err != nil {
+			return nil, err
+		}
+		if err := p.checkFunctionAggregateDependents(ctx, fnMutable); err != nil {
+			return nil, err
+		}
+	}
 	return dropNode, nil
 }
 
+// checkDropRoutineKind returns an error if the given function is not of the
+// kind that is dropped by the DROP statement.
+func checkDropRoutineKind(fnDesc *funcdesc.Mutable, kind dropRoutineKind) error {
+	isAggregate := fnDesc.Aggregate != nil
+	switch {
+	case isAggregate && kind != dropAggregateKind:
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnDesc.GetName()),
+			"Use DROP AGGREGATE to drop aggregate functions.",
+		)
+	case fnDesc.IsProcedure && kind != dropProcedureKind:
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a procedure", fnDesc.GetName()),
+			"Use DROP PROCEDURE to drop procedures.",
+		)
+	case !isAggregate && kind == dropAggregateKind:
+		return pgerror.Newf(pgcode.WrongObjectType, "function %q is not an aggregate", fnDesc.GetName())
+	case !fnDesc.IsProcedure && kind == dropProcedureKind:
+		return pgerror.Newf(pgcode.WrongObjectType, "function %q is not a procedure", fnDesc.GetName())
+	}
+	return nil
+}
+
+// checkFunctionAggregateDependents returns an error if the function is the
+// state transition or final function of any user-defined aggregate.
+// Aggregates must be dropped before their functions.
+func (p *planner) checkFunctionAggregateDependents(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if len(ref.ColumnIDs) != 0 || len(ref.IndexIDs) != 0 || len(ref.ConstraintIDs) != 0 ||
+			len(ref.TriggerIDs) != 0 {
+			continue
+		}
+		desc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Desc(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		agg, ok := desc.(catalog.FunctionDescriptor)
+		if !ok {
+			continue
+		}
+		return errors.WithHint(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop function %q because aggregate %q depends on it",
+				fnDesc.GetName(), agg.GetName(),
+			),
+			"drop the aggregate first.",
+		)
+	}
+	return nil
+}
+
+// checkFunctionTriggerDependents returns an error if the function is executed
+// by any trigger. Triggers must be dropped before their function.
+func (p *planner) checkFunctionTriggerDependents(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if len(ref.TriggerIDs) == 0 {
+			continue
+		}
+		tbl, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		trigger, err := tbl.FindTriggerByID(ref.TriggerIDs[0])
+		if err != nil {
+			return err
+		}
+		return errors.WithHint(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop function %q because trigger %q on table %q depends on it",
+				fnDesc.GetName(), trigger.Name, tbl.GetName(),
+			),
+			"drop the trigger first.",
+		)
+	}
+	return nil
+}
+
 func (n *dropFunctionNode) startExec(params runParams) error {
 	for _, fnMutable := range n.toDrop {
 		if err := params.p.dropFunctionImpl(params.ctx, fnMutable); err != nil {
@@ -208,6 +359,23 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		return err
 	}
 
+	// Remove backreferences from the functions used by this aggregate.
+	if agg := fnMutable.Aggregate; agg != nil {
+		for _, id := range []descpb.ID{agg.StateFuncID, agg.FinalFuncID} {
+			if id == descpb.InvalidID {
+				continue
+			}
+			refMutable, err := p.Descriptors().MutableByID(p.txn).Function(ctx, id)
+			if err != nil {
+				return err
+			}
+			refMutable.RemoveAggregateReference(fnMutable.GetID())
+			if err := p.writeFuncSchemaChange(ctx, refMutable); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Remove function signature from schema.
 	scDesc, err := p.Descriptors().MutableByID(p.Txn()).Schema(ctx, fnMutable.ParentSchemaID)
 	if err != nil {
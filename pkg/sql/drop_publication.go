@@ -0,0 +1,106 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropPublicationNode struct {
+	n      *tree.DropPublication
+	dbDesc *dbdesc.Mutable
+	names  []string
+}
+
+// DropPublication drops publications of the current database.
+// Privileges: ownership of the publications.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range n.Names {
+		pub := dbDesc.FindPublication(string(name))
+		if pub == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		if err := p.checkReplicationObjectOwnership(
+			ctx, "publication", pub.Name, pub.OwnerProto.Decode(),
+		); err != nil {
+			return nil, err
+		}
+		names = append(names, pub.Name)
+	}
+
+	return &dropPublicationNode{n: n, dbDesc: dbDesc, names: names}, nil
+}
+
+// checkReplicationObjectOwnership returns an error if the current user is not
+// an admin and is not a member of the owner role of the publication or the
+// subscription.
+func (p *planner) checkReplicationObjectOwnership(
+	ctx context.Context, kind string, name string, owner username.SQLUsername,
+) error {
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return err
+	} else if hasAdmin {
+		return nil
+	}
+	isOwner, err := p.checkRolePredicate(ctx, p.User(), func(role username.SQLUsername) (bool, error) {
+		return role == owner, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of %s %s", kind, name)
+	}
+	return nil
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	if len(n.names) == 0 {
+		return nil
+	}
+	for _, name := range n.names {
+		n.dbDesc.RemovePublication(name)
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.dbDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPublicationNode) Close(context.Context)        {}
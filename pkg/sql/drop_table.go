@@ -250,6 +250,15 @@ func (p *planner) dropTableImpl(
 	}
 	tableDesc.InboundFKs = nil
 
+	// Remove trigger back references from the functions executed by the
+	// table's triggers.
+	for i := range tableDesc.Triggers {
+		if err := p.removeTriggerBackReference(ctx, tableDesc, &tableDesc.Triggers[i]); err != nil {
+			return droppedViews, err
+		}
+	}
+	tableDesc.Triggers = nil
+
 	// Remove sequence dependencies.
 	for _, col := range tableDesc.PublicColumns() {
 		if err := p.removeSequenceDependencies(ctx, tableDesc, col); err != nil {
@@ -483,6 +492,23 @@ func (p *planner) removeFKForBackReference(
 	return p.writeSchemaChange(ctx, originTableDesc, descpb.InvalidMutationID, jobDesc)
 }
 
+// removeTriggerBackReference removes the back-reference to the supplied
+// trigger from the function it executes.
+func (p *planner) removeTriggerBackReference(
+	ctx context.Context, tableDesc *tabledesc.Mutable, trigger *descpb.TriggerDescriptor,
+) error {
+	fnDesc, err := p.Descriptors().MutableByID(p.txn).Function(ctx, trigger.FuncID)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving trigger function ID %d", trigger.FuncID)
+	}
+	if fnDesc.Dropped() {
+		// The function is being dropped. No need to modify it further.
+		return nil
+	}
+	fnDesc.RemoveTriggerReference(tableDesc.ID, trigger.ID)
+	return p.writeFuncSchemaChange(ctx, fnDesc)
+}
+
 // removeFKBackReferenceFromTable edits the supplied originTableDesc to
 // remove the foreign key constraint that corresponds to the supplied
 // backreference, which is a member of the supplied referencedTableDesc.
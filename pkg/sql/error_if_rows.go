@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
 )
 
 // errorIfRowsNode wraps another planNode and returns an error if the wrapped
@@ -23,7 +24,8 @@ type errorIfRowsNode struct {
 	plan planNode
 
 	// mkErr creates the error message, given the values of the first row
-	// produced.
+	// produced. If it returns an exec.DeferrableCheckError, it is also called
+	// for the remaining rows to collect their keys.
 	mkErr exec.MkErrFn
 
 	nexted bool
@@ -43,10 +45,55 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	if ok {
-		return false, n.mkErr(n.plan.Values())
+	if !ok {
+		return false, nil
+	}
+	err = n.mkErr(n.plan.Values())
+	var deferrable *exec.DeferrableCheckError
+	if !errors.As(err, &deferrable) {
+		return false, err
+	}
+	dc := params.p.extendedEvalCtx.DeferredConstraints
+	if dc == nil || !dc.isDeferred(deferrable) {
+		return false, err
+	}
+	// The validation of the constraint is postponed until the end of the
+	// transaction, so the keys of all the rows violating the constraint are
+	// needed. Beyond deferredConstraintMaxKeys keys, the whole table is
+	// validated instead, so the remaining rows aren't needed. The memory used
+	// by the keys is accounted for by the deferred constraints, which hold on
+	// to the keys until they are validated.
+	keysSize := deferredKeysSize(deferrable.Keys)
+	if accErr := dc.keysAcc.Grow(params.ctx, keysSize); accErr != nil {
+		return false, accErr
+	}
+	for {
+		ok, nextErr := n.plan.Next(params)
+		if nextErr != nil {
+			dc.keysAcc.Shrink(params.ctx, keysSize)
+			return false, nextErr
+		}
+		if !ok {
+			return false, err
+		}
+		var next *exec.DeferrableCheckError
+		if !errors.As(n.mkErr(n.plan.Values()), &next) {
+			continue
+		}
+		if len(deferrable.Keys)+len(next.Keys) > deferredConstraintMaxKeys {
+			deferrable.Keys = nil
+			deferrable.AllRows = true
+			dc.keysAcc.Shrink(params.ctx, keysSize)
+			return false, err
+		}
+		nextSize := deferredKeysSize(next.Keys)
+		if accErr := dc.keysAcc.Grow(params.ctx, nextSize); accErr != nil {
+			dc.keysAcc.Shrink(params.ctx, keysSize)
+			return false, accErr
+		}
+		keysSize += nextSize
+		deferrable.Keys = append(deferrable.Keys, next.Keys...)
 	}
-	return false, nil
 }
 
 func (n *errorIfRowsNode) Values() tree.Datums {
@@ -74,6 +74,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
@@ -100,7 +101,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -275,6 +275,29 @@ var traceSessionEventLogEnabled = settings.RegisterBoolSetting(
 	false,
 ).WithPublic()
 
+// allowReadCommittedIsolation controls whether transactions may run at the
+// READ COMMITTED isolation level. When disabled, transactions that request
+// READ COMMITTED are upgraded to SERIALIZABLE.
+var allowReadCommittedIsolation = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.enabled",
+	"set to true to allow transactions to use the READ COMMITTED isolation level; "+
+		"if false, transactions that request READ COMMITTED run at SERIALIZABLE",
+	false,
+)
+
+// readCommittedStmtRetries controls the number of times that a statement in
+// a READ COMMITTED transaction is retried after encountering a retryable error
+// before the error is returned to the client.
+var readCommittedStmtRetries = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.max_statement_retries",
+	"maximum number of times that a statement in a READ COMMITTED transaction "+
+		"is automatically retried after a retryable error",
+	10,
+	settings.NonNegativeInt,
+)
+
 // ReorderJoinsLimitClusterSettingName is the name of the cluster setting for
 // the maximum number of joins to reorder.
 const ReorderJoinsLimitClusterSettingName = "sql.defaults.reorder_joins_limit"
@@ -1297,6 +1320,10 @@ type ExecutorConfig struct {
 	// contention observability.
 	ContentionRegistry *contention.Registry
 
+	// NotificationRegistry is a node-level registry of the sessions listening
+	// for asynchronous notifications (LISTEN / NOTIFY).
+	NotificationRegistry *pgnotify.Registry
+
 	// RootMemoryMonitor is the root memory monitor of the entire server. Do not
 	// use this for normal purposes. It is to be used to establish any new
 	// root-level memory accounts that are not related to a user sessions.
@@ -1851,6 +1878,7 @@ func checkResultType(typ *types.T) error {
 	case types.TimestampTZFamily:
 	case types.TSQueryFamily:
 	case types.TSVectorFamily:
+	case types.RangeFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
 	case types.UuidFamily:
@@ -1860,15 +1888,6 @@ func checkResultType(typ *types.T) error {
 	case types.EnumFamily:
 	case types.VoidFamily:
 	case types.ArrayFamily:
-		if typ.ArrayContents().Family() == types.ArrayFamily {
-			// Technically we could probably return arrays of arrays to a
-			// client (the encoding exists) but we don't want to give
-			// mixed signals -- that nested arrays appear to be supported
-			// in this case, and not in other cases (eg. CREATE). So we
-			// reject them in every case instead.
-			return unimplemented.NewWithIssueDetail(32552,
-				"result", "arrays cannot have arrays as element type")
-		}
 	case types.AnyFamily:
 		// Placeholder case.
 		return errors.Errorf("could not determine data type of %s", typ)
@@ -3004,6 +3023,10 @@ func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -3369,6 +3392,10 @@ func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
 	m.data.TrigramSimilarityThreshold = val
 }
 
+func (m *sessionDataMutator) SetDefaultTextSearchConfig(val string) {
+	m.data.DefaultTextSearchConfig = val
+}
+
 func (m *sessionDataMutator) SetUnconstrainedNonCoveringIndexScanEnabled(val bool) {
 	m.data.UnconstrainedNonCoveringIndexScanEnabled = val
 }
@@ -3413,6 +3440,10 @@ func (m *sessionDataMutator) SetOptimizerUseLimitOrderingForStreamingGroupBy(val
 	m.data.OptimizerUseLimitOrderingForStreamingGroupBy = val
 }
 
+func (m *sessionDataMutator) SetPlanCacheMode(val sessiondatapb.PlanCacheMode) {
+	m.data.PlanCacheMode = val
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -89,6 +89,12 @@ func GetAggregateConstructor(
 		}
 		argTypes[j] = inputTypes[c]
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = getUserDefinedAggregateConstructor(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, argTypes,
+		)
+		return
+	}
 	arguments = make(tree.Datums, len(aggInfo.Arguments))
 	var d tree.Datum
 	for j, argument := range aggInfo.Arguments {
@@ -163,3 +169,22 @@ func GetWindowFunctionInfo(
 		"no builtin aggregate/window function for %s on %v", funcStr, inputTypes,
 	)
 }
+
+// GetUserDefinedWindowFunctionInfo returns the window function constructor and
+// the return type of the user-defined aggregate with the given specification,
+// computed as a window function over arguments of the given types.
+func GetUserDefinedWindowFunctionInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	uda *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	inputTypes []*types.T,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	aggConstructor, returnType, err := getUserDefinedAggregateConstructor(
+		ctx, evalCtx, semaCtx, uda, inputTypes,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewFramableAggregateWindowFunc(aggConstructor), returnType, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// userDefinedAggregateSpec contains the state shared by all instances of a
+// user-defined aggregate that are created by the same constructor.
+type userDefinedAggregateSpec struct {
+	transition       execinfrapb.ExprHelper
+	final            execinfrapb.ExprHelper
+	hasFinal         bool
+	stateType        *types.T
+	argTypes         []*types.T
+	initState        tree.Datum
+	strictTransition bool
+}
+
+// userDefinedAggregate computes an aggregate created with CREATE AGGREGATE by
+// evaluating the state transition function for every input row, and the final
+// function on the final state.
+type userDefinedAggregate struct {
+	spec  *userDefinedAggregateSpec
+	state tree.Datum
+	row   rowenc.EncDatumRow
+	// acc accounts for the memory used by the state, which may grow with every
+	// input row.
+	acc mon.BoundAccount
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+// getUserDefinedAggregateConstructor returns the constructor and the return
+// type of the user-defined aggregate with the given specification, applied to
+// arguments of the given types.
+func getUserDefinedAggregateConstructor(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	uda *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	argTypes []*types.T,
+) (AggregateConstructor, *types.T, error) {
+	if uda == nil {
+		return nil, nil, errors.AssertionFailedf("user-defined aggregate is missing its specification")
+	}
+	spec := &userDefinedAggregateSpec{
+		stateType:        uda.StateType,
+		argTypes:         argTypes,
+		strictTransition: uda.StrictTransition,
+	}
+	transitionTypes := make([]*types.T, len(argTypes)+1)
+	transitionTypes[0] = uda.StateType
+	copy(transitionTypes[1:], argTypes)
+	if err := spec.transition.Init(ctx, uda.Transition, transitionTypes, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	if !uda.Final.Empty() {
+		spec.hasFinal = true
+		if err := spec.final.Init(ctx, uda.Final, []*types.T{uda.StateType}, semaCtx, evalCtx); err != nil {
+			return nil, nil, err
+		}
+	}
+	var h execinfrapb.ExprHelper
+	// Pass nil types and row - there are no variables in the initial state.
+	if err := h.Init(ctx, uda.InitState, nil /* types */, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	initState, err := h.Eval(ctx, nil /* row */)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec.initState = initState
+
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return &userDefinedAggregate{
+			spec:  spec,
+			state: spec.initState,
+			row:   make(rowenc.EncDatumRow, len(argTypes)+1),
+			acc:   evalCtx.Planner.Mon().MakeBoundAccount(),
+		}
+	}
+	return constructor, uda.ResultType, nil
+}
+
+// Add is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if a.spec.strictTransition {
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			// A strict transition function is never called with a NULL state;
+			// the first input becomes the state instead.
+			a.state = firstArg
+			return a.acc.ResizeTo(ctx, int64(a.state.Size()))
+		}
+	}
+	a.row[0] = rowenc.DatumToEncDatum(a.spec.stateType, a.state)
+	a.row[1] = rowenc.DatumToEncDatum(a.spec.argTypes[0], firstArg)
+	for i, arg := range otherArgs {
+		a.row[i+2] = rowenc.DatumToEncDatum(a.spec.argTypes[i+1], arg)
+	}
+	state, err := a.spec.transition.Eval(ctx, a.row)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return a.acc.ResizeTo(ctx, int64(a.state.Size()))
+}
+
+// Result is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result(ctx context.Context) (tree.Datum, error) {
+	if !a.spec.hasFinal {
+		return a.state, nil
+	}
+	row := rowenc.EncDatumRow{rowenc.DatumToEncDatum(a.spec.stateType, a.state)}
+	return a.spec.final.Eval(ctx, row)
+}
+
+// Reset is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.spec.initState
+	a.acc.Clear(ctx)
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.acc.Close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface. It only includes the fixed
+// size of the aggregate; the memory used by the state is registered with the
+// memory account of the aggregate as the state changes.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
@@ -96,6 +96,11 @@ func (v *ValuesCoreSpec) summary() (string, []string) {
 	return "Values", []string{detail}
 }
 
+// summary implements the diagramCellType interface.
+func (s *ForeignScanSpec) summary() (string, []string) {
+	return "ForeignScan", []string{s.Query}
+}
+
 // summary implements the diagramCellType interface.
 func (a *AggregatorSpec) summary() (string, []string) {
 	details := make([]string, 0, len(a.Aggregations)+1)
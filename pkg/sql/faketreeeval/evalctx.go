@@ -510,6 +510,18 @@ func (ep *DummySessionAccessor) HasRoleOption(
 	return false, errors.WithStack(errEvalSessionVar)
 }
 
+// QueueNotification is part of the eval.SessionAccessor interface.
+func (ep *DummySessionAccessor) QueueNotification(
+	ctx context.Context, channel, payload string,
+) error {
+	return errors.WithStack(errEvalSessionVar)
+}
+
+// ListeningChannels is part of the eval.SessionAccessor interface.
+func (ep *DummySessionAccessor) ListeningChannels() []string {
+	return nil
+}
+
 // DummyClientNoticeSender implements the eval.ClientNoticeSender interface.
 type DummyClientNoticeSender struct{}
 
@@ -0,0 +1,277 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// foreignScanNode reads the rows of a foreign table from its foreign server.
+// It is always executed by a ForeignScan processor, which runs the query built
+// by the node on the server.
+type foreignScanNode struct {
+	desc catalog.TableDescriptor
+
+	// serverName is the name of the foreign server of the table.
+	serverName string
+
+	// cols are the columns of the table returned by the scan.
+	cols          []catalog.Column
+	resultColumns colinfo.ResultColumns
+
+	// filters are the conjuncts of the filter evaluated by the foreign server.
+	// Their IndexedVars refer to the columns in cols.
+	filters []tree.TypedExpr
+
+	// hardLimit, if non-zero, is the maximum number of rows returned by the
+	// foreign server.
+	hardLimit int64
+}
+
+func (n *foreignScanNode) startExec(params runParams) error {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Next(params runParams) (bool, error) {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Values() tree.Datums {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Close(context.Context) {}
+
+// pushFilter pushes the conjuncts of the given filter that can be evaluated by
+// the foreign server into the scan, and returns the remaining conjuncts, or nil
+// if there are none.
+func (n *foreignScanNode) pushFilter(filter tree.TypedExpr) tree.TypedExpr {
+	if n.hardLimit != 0 {
+		// The filter must be applied before the limit.
+		return filter
+	}
+	var remaining tree.TypedExpr
+	var walk func(expr tree.TypedExpr)
+	walk = func(expr tree.TypedExpr) {
+		if and, ok := expr.(*tree.AndExpr); ok {
+			walk(and.TypedLeft())
+			walk(and.TypedRight())
+			return
+		}
+		if n.canPushFilter(expr) {
+			n.filters = append(n.filters, expr)
+		} else if remaining == nil {
+			remaining = expr
+		} else {
+			remaining = tree.NewTypedAndExpr(remaining, expr)
+		}
+	}
+	walk(filter)
+	return remaining
+}
+
+// canPushFilter returns whether the given filter can be evaluated by the
+// foreign server with the same result as if it were evaluated locally. Only
+// comparisons between a column and constants are pushed down, for the types
+// whose comparisons are known to behave the same in Postgres.
+func (n *foreignScanNode) canPushFilter(expr tree.TypedExpr) bool {
+	switch t := expr.(type) {
+	case *tree.AndExpr:
+		return n.canPushFilter(t.TypedLeft()) && n.canPushFilter(t.TypedRight())
+
+	case *tree.OrExpr:
+		return n.canPushFilter(t.TypedLeft()) && n.canPushFilter(t.TypedRight())
+
+	case *tree.NotExpr:
+		return n.canPushFilter(t.TypedInnerExpr())
+
+	case *tree.IsNullExpr:
+		_, ok := t.TypedInnerExpr().(*tree.IndexedVar)
+		return ok
+
+	case *tree.IsNotNullExpr:
+		_, ok := t.TypedInnerExpr().(*tree.IndexedVar)
+		return ok
+
+	case *tree.ComparisonExpr:
+		ivar, ok := t.TypedLeft().(*tree.IndexedVar)
+		if !ok {
+			return false
+		}
+		typ := n.resultColumns[ivar.Idx].Typ
+		switch t.Operator.Symbol {
+		case treecmp.EQ, treecmp.NE:
+			return canPushEquality(typ) && isPushableConstant(t.TypedRight(), typ)
+
+		case treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+			return canPushRange(typ) && isPushableConstant(t.TypedRight(), typ)
+
+		case treecmp.In, treecmp.NotIn:
+			tuple, ok := t.TypedRight().(*tree.DTuple)
+			if !ok || len(tuple.D) == 0 || !canPushEquality(typ) {
+				return false
+			}
+			for _, d := range tuple.D {
+				if !isPushableConstant(d, typ) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// canPushEquality returns whether equality comparisons on the given type can
+// be evaluated by the foreign server.
+func canPushEquality(typ *types.T) bool {
+	switch typ.Family() {
+	case types.BoolFamily, types.StringFamily, types.DecimalFamily, types.FloatFamily,
+		types.UuidFamily:
+		return true
+	}
+	return canPushRange(typ)
+}
+
+// canPushRange returns whether inequality comparisons on the given type can
+// be evaluated by the foreign server. Strings are excluded since their order
+// depends on the collation of the foreign server, and decimals and floats
+// since NaN is ordered differently by Postgres.
+func canPushRange(typ *types.T) bool {
+	switch typ.Family() {
+	case types.IntFamily, types.DateFamily, types.TimestampFamily, types.TimestampTZFamily:
+		return true
+	}
+	return false
+}
+
+// isPushableConstant returns whether the given expression is a non-NULL
+// constant of the same type family as typ.
+func isPushableConstant(expr tree.TypedExpr, typ *types.T) bool {
+	d, ok := expr.(tree.Datum)
+	return ok && d != tree.DNull && d.ResolvedType().Family() == typ.Family()
+}
+
+// makeSpec returns the spec of the ForeignScan processor executing the scan.
+func (n *foreignScanNode) makeSpec() *execinfrapb.ForeignScanSpec {
+	spec := &execinfrapb.ForeignScanSpec{
+		ServerName:  n.serverName,
+		ColumnTypes: getTypesFromResultColumns(n.resultColumns),
+	}
+	opts := n.desc.GetForeignTable()
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	if len(n.cols) == 0 {
+		// The rows are still needed even if none of their columns are, for
+		// example to count them.
+		buf.WriteString("NULL")
+	}
+	for i, col := range n.cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		lexbase.EncodeRestrictedSQLIdent(&buf, col.GetName(), lexbase.EncNoFlags)
+	}
+	buf.WriteString(" FROM ")
+	lexbase.EncodeRestrictedSQLIdent(&buf, opts.RemoteSchema, lexbase.EncNoFlags)
+	buf.WriteByte('.')
+	lexbase.EncodeRestrictedSQLIdent(&buf, opts.RemoteTable, lexbase.EncNoFlags)
+	for i, filter := range n.filters {
+		if i == 0 {
+			buf.WriteString(" WHERE ")
+		} else {
+			buf.WriteString(" AND ")
+		}
+		n.formatFilter(&buf, spec, filter)
+	}
+	if n.hardLimit > 0 {
+		fmt.Fprintf(&buf, " LIMIT %d", n.hardLimit)
+	}
+	spec.Query = buf.String()
+	return spec
+}
+
+// formatFilter writes the given pushed down filter to buf in the syntax of
+// Postgres. Constants are written as parameters, whose values are added to
+// the spec.
+func (n *foreignScanNode) formatFilter(
+	buf *bytes.Buffer, spec *execinfrapb.ForeignScanSpec, expr tree.TypedExpr,
+) {
+	switch t := expr.(type) {
+	case *tree.AndExpr:
+		buf.WriteByte('(')
+		n.formatFilter(buf, spec, t.TypedLeft())
+		buf.WriteString(" AND ")
+		n.formatFilter(buf, spec, t.TypedRight())
+		buf.WriteByte(')')
+
+	case *tree.OrExpr:
+		buf.WriteByte('(')
+		n.formatFilter(buf, spec, t.TypedLeft())
+		buf.WriteString(" OR ")
+		n.formatFilter(buf, spec, t.TypedRight())
+		buf.WriteByte(')')
+
+	case *tree.NotExpr:
+		buf.WriteString("(NOT ")
+		n.formatFilter(buf, spec, t.TypedInnerExpr())
+		buf.WriteByte(')')
+
+	case *tree.IsNullExpr:
+		buf.WriteByte('(')
+		n.formatFilter(buf, spec, t.TypedInnerExpr())
+		buf.WriteString(" IS NULL)")
+
+	case *tree.IsNotNullExpr:
+		buf.WriteByte('(')
+		n.formatFilter(buf, spec, t.TypedInnerExpr())
+		buf.WriteString(" IS NOT NULL)")
+
+	case *tree.ComparisonExpr:
+		buf.WriteByte('(')
+		n.formatFilter(buf, spec, t.TypedLeft())
+		fmt.Fprintf(buf, " %s ", t.Operator)
+		n.formatFilter(buf, spec, t.TypedRight())
+		buf.WriteByte(')')
+
+	case *tree.IndexedVar:
+		lexbase.EncodeRestrictedSQLIdent(buf, n.cols[t.Idx].GetName(), lexbase.EncNoFlags)
+
+	case *tree.DTuple:
+		buf.WriteByte('(')
+		for i, d := range t.D {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			n.formatFilter(buf, spec, d)
+		}
+		buf.WriteByte(')')
+
+	case tree.Datum:
+		spec.Args = append(spec.Args, tree.AsStringWithFlags(t, tree.FmtPgwireText))
+		spec.ArgOIDs = append(spec.ArgOIDs, uint32(t.ResolvedType().Oid()))
+		fmt.Fprintf(buf, "$%d", len(spec.Args))
+
+	default:
+		panic(errors.AssertionFailedf("unexpected foreign scan filter %T", expr))
+	}
+}
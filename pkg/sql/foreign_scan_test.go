@@ -0,0 +1,209 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignScanSpec(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	desc := tabledesc.NewBuilder(&descpb.TableDescriptor{
+		ID:   100,
+		Name: "ft",
+		Columns: []descpb.ColumnDescriptor{
+			{ID: 1, Name: "k", Type: types.Int},
+			{ID: 2, Name: "Name", Type: types.String, Nullable: true},
+			{ID: 3, Name: "f", Type: types.Float, Nullable: true},
+		},
+		NextColumnID: 4,
+		ForeignTable: &descpb.TableDescriptor_ForeignTableOptions{
+			ServerName:   "s",
+			RemoteSchema: "public",
+			RemoteTable:  "select",
+		},
+	}).BuildImmutableTable()
+	newScan := func() *foreignScanNode {
+		cols := desc.PublicColumns()
+		return &foreignScanNode{
+			desc:          desc,
+			cols:          cols,
+			resultColumns: colinfo.ResultColumnsFromColumns(desc.GetID(), cols),
+		}
+	}
+	k := tree.NewTypedOrdinalReference(0, types.Int)
+	name := tree.NewTypedOrdinalReference(1, types.String)
+	f := tree.NewTypedOrdinalReference(2, types.Float)
+	cmp := func(sym treecmp.ComparisonOperatorSymbol, left, right tree.TypedExpr) tree.TypedExpr {
+		return tree.NewTypedComparisonExpr(treecmp.MakeComparisonOperator(sym), left, right)
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		spec := newScan().makeSpec()
+		require.Equal(t, `SELECT k, "Name", f FROM public."select"`, spec.Query)
+		require.Empty(t, spec.Args)
+	})
+
+	t.Run("no columns", func(t *testing.T) {
+		scan := newScan()
+		scan.cols, scan.resultColumns = nil, nil
+		scan.hardLimit = 10
+		spec := scan.makeSpec()
+		require.Equal(t, `SELECT NULL FROM public."select" LIMIT 10`, spec.Query)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		scan := newScan()
+		filter := tree.NewTypedAndExpr(
+			tree.NewTypedAndExpr(
+				cmp(treecmp.GT, k, tree.NewDInt(1)),
+				tree.NewTypedOrExpr(
+					cmp(treecmp.EQ, name, tree.NewDString("it's")),
+					tree.NewTypedIsNullExpr(name),
+				),
+			),
+			tree.NewTypedAndExpr(
+				// Ranges over floats are not pushed down.
+				cmp(treecmp.LT, f, tree.NewDFloat(1.5)),
+				cmp(treecmp.In, k, tree.NewDTuple(
+					types.MakeTuple([]*types.T{types.Int, types.Int}), tree.NewDInt(2), tree.NewDInt(3),
+				)),
+			),
+		)
+		remaining := scan.pushFilter(filter)
+		require.Equal(t, "@3 < 1.5", tree.AsString(remaining))
+
+		spec := scan.makeSpec()
+		require.Equal(t,
+			`SELECT k, "Name", f FROM public."select" `+
+				`WHERE (k > $1) AND (("Name" = $2) OR ("Name" IS NULL)) AND (k IN ($3, $4))`,
+			spec.Query,
+		)
+		require.Equal(t, []string{"1", "it's", "2", "3"}, spec.Args)
+		require.Equal(t, []uint32{20, 25, 20, 20}, spec.ArgOIDs)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		scan := newScan()
+		scan.hardLimit = 1
+		// The filter must be evaluated before the limit, so it is not pushed
+		// down.
+		filter := cmp(treecmp.EQ, k, tree.NewDInt(1))
+		require.Equal(t, filter, scan.pushFilter(filter))
+		require.Empty(t, scan.filters)
+	})
+}
+
+func TestForeignTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	// The test server is its own foreign server.
+	sqlDB.Exec(t, `CREATE DATABASE remote`)
+	sqlDB.Exec(t, `CREATE TABLE remote.t (k INT PRIMARY KEY, s STRING, ts TIMESTAMPTZ)`)
+	sqlDB.Exec(t, `INSERT INTO remote.t VALUES
+		(1, 'a', '2020-01-01 00:00:00+00'),
+		(2, 'b', NULL),
+		(3, NULL, '2020-01-03 00:00:00+00')`)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), "TestForeignTable", url.User(username.RootUser))
+	defer cleanup()
+	host, port, err := net.SplitHostPort(pgURL.Host)
+	require.NoError(t, err)
+	opts := []string{
+		fmt.Sprintf("host %s", lexbase.EscapeSQLString(host)),
+		fmt.Sprintf("port %s", lexbase.EscapeSQLString(port)),
+		"dbname 'remote'",
+		"user 'root'",
+	}
+	for key, values := range pgURL.Query() {
+		opts = append(opts, fmt.Sprintf("%s %s", key, lexbase.EscapeSQLString(values[0])))
+	}
+	sqlDB.Exec(t, fmt.Sprintf(
+		`CREATE SERVER srv FOREIGN DATA WRAPPER postgres_fdw OPTIONS (%s)`, strings.Join(opts, ", "),
+	))
+	sqlDB.Exec(t, `CREATE FOREIGN TABLE ft (k INT, s STRING, ts TIMESTAMPTZ) SERVER srv
+		OPTIONS (table_name 't')`)
+
+	for _, tc := range []struct {
+		query    string
+		expected [][]string
+	}{
+		{
+			query: `SELECT k, s, ts FROM ft ORDER BY k`,
+			expected: [][]string{
+				{"1", "a", "2020-01-01 00:00:00 +0000 UTC"},
+				{"2", "b", "NULL"},
+				{"3", "NULL", "2020-01-03 00:00:00 +0000 UTC"},
+			},
+		},
+		{
+			query:    `SELECT count(*) FROM ft`,
+			expected: [][]string{{"3"}},
+		},
+		{
+			query:    `SELECT k FROM ft WHERE s = 'b'`,
+			expected: [][]string{{"2"}},
+		},
+		{
+			query:    `SELECT k FROM ft WHERE ts IS NULL OR ts > '2020-01-02'::TIMESTAMPTZ ORDER BY k`,
+			expected: [][]string{{"2"}, {"3"}},
+		},
+		{
+			query:    `SELECT k FROM ft WHERE k IN (1, 3) AND length(s) = 1`,
+			expected: [][]string{{"1"}},
+		},
+		{
+			query:    `SELECT count(*) FROM (SELECT k FROM ft LIMIT 2)`,
+			expected: [][]string{{"2"}},
+		},
+		{
+			query:    `SELECT l.v FROM (VALUES (1, 'x'), (3, 'y')) AS l(k, v) JOIN ft USING (k) ORDER BY l.v`,
+			expected: [][]string{{"x"}, {"y"}},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			sqlDB.CheckQueryResults(t, tc.query, tc.expected)
+		})
+	}
+
+	sqlDB.ExpectErr(t, `cannot mutate foreign table "ft"`, `INSERT INTO ft VALUES (4, 'd', now())`)
+	sqlDB.ExpectErr(t, `cannot drop server srv because other objects depend on it`, `DROP SERVER srv`)
+	sqlDB.Exec(t, `DROP SERVER srv CASCADE`)
+	sqlDB.ExpectErr(t, `relation "ft" does not exist`, `SELECT * FROM ft`)
+}
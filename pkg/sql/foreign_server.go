@@ -0,0 +1,308 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn/connectionpb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	createServerOp = "CREATE SERVER"
+	dropServerOp   = "DROP SERVER"
+
+	// postgresFDW is the name of the only foreign-data wrapper, which reads
+	// the rows of foreign tables from a server speaking the Postgres wire
+	// protocol.
+	postgresFDW = "postgres_fdw"
+)
+
+// Foreign servers are External Connections to a postgres:// or postgresql://
+// URI, named after the server.
+func init() {
+	for _, scheme := range []string{"postgres", "postgresql"} {
+		externalconn.RegisterConnectionDetailsFromURIFactory(
+			scheme,
+			connectionpb.ConnectionProvider_postgres,
+			externalconn.SimpleURIFactory,
+		)
+	}
+}
+
+type createServerNode struct {
+	n *tree.CreateServer
+}
+
+// CreateServer creates a foreign server.
+// Privileges: EXTERNALCONNECTION system privilege.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if n.Wrapper != postgresFDW {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+	return &createServerNode{n: n}, nil
+}
+
+func (n *createServerNode) startExec(params runParams) error {
+	name := string(n.n.Name)
+	if n.n.IfNotExists {
+		exists, err := params.p.foreignServerExists(params.ctx, name)
+		if err != nil || exists {
+			return err
+		}
+	}
+	uri, err := makeForeignServerURI(
+		params.ctx, params.p.ExprEvaluator(createServerOp), n.n.Options,
+	)
+	if err != nil {
+		return err
+	}
+	return params.p.createExternalConnectionFromURI(
+		params, createServerOp, externalConnection{name: name, endpoint: uri},
+	)
+}
+
+func (*createServerNode) Next(runParams) (bool, error) { return false, nil }
+func (*createServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createServerNode) Close(context.Context)        {}
+
+// makeForeignServerURI returns the URI of the Postgres server described by
+// the options of a CREATE SERVER statement. The host, port, dbname, user and
+// password options make up the URI, any other option is passed on as a
+// connection parameter.
+func makeForeignServerURI(
+	ctx context.Context, exprEval exprutil.Evaluator, options tree.KVOptions,
+) (string, error) {
+	values := make(map[string]string, len(options))
+	params := url.Values{}
+	for _, opt := range options {
+		key := string(opt.Key)
+		if _, ok := values[key]; ok {
+			return "", pgerror.Newf(pgcode.DuplicateObject,
+				"option %q provided more than once", key)
+		}
+		v, err := exprEval.String(ctx, opt.Value)
+		if err != nil {
+			return "", err
+		}
+		values[key] = v
+		switch key {
+		case "host", "port", "dbname", "user", "password":
+		default:
+			params.Set(key, v)
+		}
+	}
+	u := url.URL{
+		Scheme:   "postgresql",
+		Host:     values["host"],
+		RawQuery: params.Encode(),
+	}
+	if port, ok := values["port"]; ok {
+		u.Host = net.JoinHostPort(u.Host, port)
+	}
+	if dbName, ok := values["dbname"]; ok {
+		u.Path = "/" + dbName
+	}
+	if user, ok := values["user"]; ok {
+		if password, ok := values["password"]; ok {
+			u.User = url.UserPassword(user, password)
+		} else {
+			u.User = url.User(user)
+		}
+	} else if _, ok := values["password"]; ok {
+		return "", pgerror.New(pgcode.InvalidParameterValue,
+			`option "password" requires option "user"`)
+	}
+	return u.String(), nil
+}
+
+// foreignServerExists returns whether an External Connection exists with the
+// given name.
+func (p *planner) foreignServerExists(ctx context.Context, name string) (bool, error) {
+	// We run the query as `node` since the user might not have `SELECT` on the
+	// system table.
+	row, err := p.ExecCfg().InternalExecutor.QueryRowEx(
+		ctx, "foreign-server-exists", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT 1 FROM system.external_connections WHERE connection_name = $1`, name,
+	)
+	if err != nil {
+		return false, err
+	}
+	return row != nil, nil
+}
+
+// checkForeignServerUsage returns an error if the foreign server does not
+// exist, or if the current user does not have USAGE on it.
+func (p *planner) checkForeignServerUsage(ctx context.Context, name string) error {
+	if exists, err := p.foreignServerExists(ctx, name); err != nil {
+		return err
+	} else if !exists {
+		return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", name)
+	}
+	ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: name,
+	}
+	if err := p.CheckPrivilege(ctx, ecPrivilege, privilege.USAGE); err != nil {
+		return err
+	}
+	return p.checkForeignServer(ctx, name)
+}
+
+// checkForeignServer returns an error if the foreign server with the given
+// name does not exist.
+func (p *planner) checkForeignServer(ctx context.Context, name string) error {
+	return p.WithInternalExecutor(ctx, func(
+		ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor,
+	) error {
+		_, err := rowexec.LoadForeignServerURI(ctx, ie, txn, name)
+		return err
+	})
+}
+
+// foreignTablesUsingServer returns the foreign tables reading from the foreign
+// server with the given name.
+func (p *planner) foreignTablesUsingServer(
+	ctx context.Context, name string,
+) ([]catalog.TableDescriptor, error) {
+	all, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return nil, err
+	}
+	var tables []catalog.TableDescriptor
+	for _, desc := range all.OrderedDescriptors() {
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok || tbl.Dropped() || !tbl.IsForeignTable() {
+			continue
+		}
+		if tbl.GetForeignTable().ServerName == name {
+			tables = append(tables, tbl)
+		}
+	}
+	return tables, nil
+}
+
+type dropServerNode struct {
+	n *tree.DropServer
+}
+
+// DropServer drops foreign servers.
+// Privileges: DROP on the External Connection of the server.
+func (p *planner) DropServer(ctx context.Context, n *tree.DropServer) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		dropServerOp,
+	); err != nil {
+		return nil, err
+	}
+	return &dropServerNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP SERVER ... CASCADE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *dropServerNode) ReadingOwnWrites() {}
+
+func (n *dropServerNode) startExec(params runParams) error {
+	p := params.p
+	for _, serverName := range n.n.Names {
+		name := string(serverName)
+		if exists, err := p.foreignServerExists(params.ctx, name); err != nil {
+			return err
+		} else if !exists {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", name)
+		}
+		ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+			ConnectionName: name,
+		}
+		if err := p.CheckPrivilege(params.ctx, ecPrivilege, privilege.DROP); err != nil {
+			return err
+		}
+
+		tables, err := p.foreignTablesUsingServer(params.ctx, name)
+		if err != nil {
+			return err
+		}
+		if len(tables) > 0 && n.n.DropBehavior != tree.DropCascade {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop server %s because other objects depend on it", serverName),
+				"use DROP ... CASCADE to drop the dependent objects too")
+		}
+		for _, tbl := range tables {
+			if err := n.dropForeignTable(params, tbl); err != nil {
+				return err
+			}
+		}
+
+		if err := p.deleteExternalConnection(params, dropServerOp, ecPrivilege); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropForeignTable drops a foreign table reading from a dropped server.
+func (n *dropServerNode) dropForeignTable(params runParams, tbl catalog.TableDescriptor) error {
+	p := params.p
+	mut, err := p.Descriptors().MutableByID(p.txn).Table(params.ctx, tbl.GetID())
+	if err != nil {
+		return err
+	}
+	if err := p.canDropTable(params.ctx, mut, true /* checkOwnership */); err != nil {
+		return err
+	}
+	tn, err := p.getQualifiedTableName(params.ctx, mut)
+	if err != nil {
+		return err
+	}
+	droppedViews, err := p.dropTableImpl(
+		params.ctx,
+		mut,
+		false, /* droppingParent */
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+		tree.DropCascade,
+	)
+	if err != nil {
+		return err
+	}
+	return p.logEvent(params.ctx,
+		mut.ID,
+		&eventpb.DropTable{
+			TableName:           tn.FQString(),
+			CascadeDroppedViews: droppedViews,
+		})
+}
+
+func (*dropServerNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropServerNode) Close(context.Context)        {}
@@ -237,6 +237,9 @@ type schemaParsingObjects struct {
 	createTbl    map[schemaAndTableName]*tree.CreateTable
 	createSeq    map[schemaAndTableName]*tree.CreateSequence
 	tableFKs     map[schemaAndTableName][]*tree.ForeignKeyConstraintTableDef
+	// partitionTypes holds the partitioning strategy declared by the PARTITION
+	// BY clause of each partitioned table.
+	partitionTypes map[schemaAndTableName]tree.PartitionByType
 }
 
 func createPostgresSchemas(
@@ -380,6 +383,9 @@ func createPostgresTables(
 			return nil, err
 		}
 		removeDefaultRegclass(create)
+		if err := prefixPrimaryKeyWithPartitionColumns(create); err != nil {
+			return nil, err
+		}
 		// Bundle imports do not support user defined types, and so we nil out the
 		// type resolver to protect against unexpected behavior on UDT resolution.
 		semaCtxPtr := makeSemaCtxWithoutTypeResolver(p.SemaCtx())
@@ -494,10 +500,11 @@ func readPostgresCreateTable(
 	// we'd have to delete the index and row and modify the column family. This
 	// is much easier and probably safer too.
 	schemaObjects := schemaParsingObjects{
-		createSchema: make(map[string]*tree.CreateSchema),
-		createTbl:    make(map[schemaAndTableName]*tree.CreateTable),
-		createSeq:    make(map[schemaAndTableName]*tree.CreateSequence),
-		tableFKs:     make(map[schemaAndTableName][]*tree.ForeignKeyConstraintTableDef),
+		createSchema:   make(map[string]*tree.CreateSchema),
+		createTbl:      make(map[schemaAndTableName]*tree.CreateTable),
+		createSeq:      make(map[schemaAndTableName]*tree.CreateSequence),
+		tableFKs:       make(map[schemaAndTableName][]*tree.ForeignKeyConstraintTableDef),
+		partitionTypes: make(map[schemaAndTableName]tree.PartitionByType),
 	}
 	ps := newPostgreStream(ctx, input, max, unsupportedStmtLogger)
 	for {
@@ -608,6 +615,25 @@ func readPostgresStmt(
 		}
 		schemaObjects.createSchema[name] = stmt
 	case *tree.CreateTable:
+		if stmt.PartitionOf != nil {
+			parentName, err := getSchemaAndTableName(&stmt.PartitionOf.Parent)
+			if err != nil {
+				return err
+			}
+			parent, ok := schemaObjects.createTbl[parentName]
+			if !ok {
+				return errors.Errorf("parent table %s of partition %s does not exist",
+					&stmt.PartitionOf.Parent, &stmt.Table)
+			}
+			if parent == nil {
+				// The parent table is not being imported.
+				break
+			}
+			return attachPostgresPartition(
+				schemaObjects, parentName, parent, &stmt.Table, &stmt.PartitionOf.Bound,
+				stmt, unsupportedStmtLogger,
+			)
+		}
 		// If the target table columns have data type INT or INTEGER, they need to
 		// be updated to conform to the session variable `default_int_size`.
 		for _, def := range stmt.Defs {
@@ -626,6 +652,10 @@ func readPostgresStmt(
 		isMatch := match == "" || match == schemaQualifiedName.String()
 		if isMatch {
 			schemaObjects.createTbl[schemaQualifiedName] = stmt
+			if partitionBy := stmt.PartitionByTable; partitionBy.ContainsPartitions() &&
+				partitionBy.PartitionBy.IsDeclarative() {
+				schemaObjects.partitionTypes[schemaQualifiedName] = partitionBy.PartitionBy.Declarative
+			}
 		} else {
 			schemaObjects.createTbl[schemaQualifiedName] = nil
 		}
@@ -727,6 +757,13 @@ func readPostgresStmt(
 					return wrapErrorWithUnsupportedHint(errors.Errorf("unsupported statement: %s", stmt))
 				}
 				create.Defs = append(create.Defs, cmd.ColumnDef)
+			case *tree.AlterTableAttachPartition:
+				if err := attachPostgresPartition(
+					schemaObjects, schemaQualifiedTableName, create, &cmd.Partition, &cmd.Bound,
+					stmt, unsupportedStmtLogger,
+				); err != nil {
+					return err
+				}
 			case *tree.AlterTableSetNotNull:
 				found := false
 				for i, def := range create.Defs {
@@ -911,6 +948,18 @@ func readPostgresStmt(
 			return unsupportedStmtLogger.log(fmt.Sprintf("%s", stmt), false /* isParseError */)
 		}
 		return wrapErrorWithUnsupportedHint(errors.Errorf("unsupported %T statement: %s", stmt, stmt))
+	case *tree.AlterIndex:
+		for _, cmd := range stmt.Cmds {
+			// Partitions are imported into their parent table, so there is nothing to
+			// do when attaching their indexes to those of the parent table.
+			if _, ok := cmd.(*tree.AlterIndexAttachPartition); ok {
+				continue
+			}
+			if ignoreUnsupportedStmts {
+				return unsupportedStmtLogger.log(stmt.String(), false /* isParseError */)
+			}
+			return wrapErrorWithUnsupportedHint(errors.Errorf("unsupported statement: %s", stmt))
+		}
 	case *tree.CreateType:
 		return errors.New("IMPORT PGDUMP does not support user defined types; please" +
 			" remove all CREATE TYPE statements and their usages from the dump file")
@@ -927,6 +976,98 @@ func readPostgresStmt(
 	return nil
 }
 
+// attachPostgresPartition adds the partition specified by a CREATE TABLE ...
+// PARTITION OF or an ALTER TABLE ... ATTACH PARTITION statement to the
+// partitioning of the primary index of the parent table. The partition is not
+// imported as a table of its own; its rows are imported into the parent table.
+//
+// DEFAULT partitions of range partitioned tables are not supported: they hold
+// all the values outside of the other ranges, which generally can't be
+// expressed as a single range. If unsupported statements are ignored, the
+// partition is skipped and its rows are stored in the unpartitioned remainder
+// of the index.
+func attachPostgresPartition(
+	schemaObjects *schemaParsingObjects,
+	parentName schemaAndTableName,
+	parent *tree.CreateTable,
+	partition *tree.TableName,
+	bound *tree.PartitionBoundSpec,
+	stmt tree.Statement,
+	unsupportedStmtLogger *unsupportedStmtLogger,
+) error {
+	typ, ok := schemaObjects.partitionTypes[parentName]
+	if !ok {
+		return errors.Errorf("cannot attach partition %s to table %s which is not partitioned",
+			partition, &parent.Table)
+	}
+	partitionName, err := getSchemaAndTableName(partition)
+	if err != nil {
+		return err
+	}
+	delete(schemaObjects.createTbl, partitionName)
+	delete(schemaObjects.tableFKs, partitionName)
+
+	if bound.Default && typ == tree.PartitionByRange {
+		if unsupportedStmtLogger.ignoreUnsupported {
+			return unsupportedStmtLogger.log(stmt.String(), false /* isParseError */)
+		}
+		return wrapErrorWithUnsupportedHint(errors.Errorf(
+			"unsupported DEFAULT partition %s of range partitioned table %s",
+			partition, &parent.Table))
+	}
+	return parent.PartitionByTable.PartitionBy.AddPartition(typ, partition.ObjectName, bound)
+}
+
+// prefixPrimaryKeyWithPartitionColumns reorders the primary key columns of a
+// partitioned table so that the partition columns come first. Postgres only
+// requires the primary key of a partitioned table to include the partition
+// columns, whereas the primary index can only be partitioned by a prefix of
+// its columns.
+func prefixPrimaryKeyWithPartitionColumns(create *tree.CreateTable) error {
+	if !create.PartitionByTable.ContainsPartitions() {
+		return nil
+	}
+	fields := create.PartitionByTable.Fields
+	for _, def := range create.Defs {
+		switch def := def.(type) {
+		case *tree.ColumnTableDef:
+			if def.PrimaryKey.IsPrimaryKey {
+				if len(fields) != 1 || fields[0] != def.Name {
+					return errors.Errorf("primary key of partitioned table %s must include "+
+						"the partition columns (%s)", &create.Table, tree.AsString(&fields))
+				}
+				return nil
+			}
+		case *tree.UniqueConstraintTableDef:
+			if def.PrimaryKey {
+				columns := make(tree.IndexElemList, 0, len(def.Columns))
+				for _, field := range fields {
+					found := false
+					for _, col := range def.Columns {
+						if col.Column == field {
+							columns = append(columns, col)
+							found = true
+							break
+						}
+					}
+					if !found {
+						return errors.Errorf("primary key of partitioned table %s must include "+
+							"the partition columns (%s)", &create.Table, tree.AsString(&fields))
+					}
+				}
+				for _, col := range def.Columns {
+					if !fields.Contains(col.Column) {
+						columns = append(columns, col)
+					}
+				}
+				def.Columns = columns
+				return nil
+			}
+		}
+	}
+	return errors.Errorf("partitioned table %s must have a primary key", &create.Table)
+}
+
 func getSchemaName(sc *tree.ObjectNamePrefix) (string, error) {
 	if sc.ExplicitCatalog {
 		return "", unimplemented.Newf("import into database specified in dump file",
@@ -1057,6 +1198,9 @@ func (m *pgDumpReader) readFile(
 	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
 ) error {
 	tableNameToRowsProcessed := make(map[string]int64)
+	// partitionParents maps the partitions of partitioned tables to their parent
+	// table, into which their rows are imported.
+	partitionParents := make(map[schemaAndTableName]schemaAndTableName)
 	var inserts, count int64
 	rowLimit := m.opts.RowLimit
 	ps := newPostgreStream(ctx, input, int(m.opts.MaxRowSize), m.unsupportedStmtLogger)
@@ -1087,6 +1231,7 @@ func (m *pgDumpReader) readFile(
 			if err != nil {
 				return errors.Wrapf(err, "%s", i)
 			}
+			name = m.partitionParent(partitionParents, name)
 			conv, ok := m.tables[name.String()]
 			if !ok {
 				// not importing this table.
@@ -1180,6 +1325,7 @@ func (m *pgDumpReader) readFile(
 			if err != nil {
 				return errors.Wrapf(err, "%s", i)
 			}
+			name = m.partitionParent(partitionParents, name)
 			conv, importing := m.tables[name.String()]
 			if importing && conv == nil {
 				return errors.Errorf("missing schema info for requested table %q", name)
@@ -1409,8 +1555,38 @@ func (m *pgDumpReader) readFile(
 			// handled during schema extraction.
 		case *tree.SetVar, *tree.BeginTransaction, *tree.CommitTransaction, *tree.Analyze:
 			// handled during schema extraction.
-		case *tree.CreateTable, *tree.CreateSchema, *tree.AlterTable, *tree.AlterTableOwner,
-			*tree.CreateIndex, *tree.CreateSequence, *tree.DropTable:
+		case *tree.CreateTable:
+			// handled during schema extraction, except for the partitions of
+			// partitioned tables, whose rows are imported into their parent table.
+			if i.PartitionOf != nil {
+				partition, err := getSchemaAndTableName(&i.Table)
+				if err != nil {
+					return err
+				}
+				parent, err := getSchemaAndTableName(&i.PartitionOf.Parent)
+				if err != nil {
+					return err
+				}
+				partitionParents[partition] = parent
+			}
+		case *tree.AlterTable:
+			// handled during schema extraction, except for ATTACH PARTITION (see
+			// above).
+			for _, cmd := range i.Cmds {
+				if attach, ok := cmd.(*tree.AlterTableAttachPartition); ok {
+					partition, err := getSchemaAndTableName(&attach.Partition)
+					if err != nil {
+						return err
+					}
+					parent, err := getSchemaAndTableName2(i.Table)
+					if err != nil {
+						return err
+					}
+					partitionParents[partition] = parent
+				}
+			}
+		case *tree.CreateSchema, *tree.AlterTableOwner, *tree.CreateIndex, *tree.CreateSequence,
+			*tree.DropTable, *tree.AlterIndex:
 			// handled during schema extraction.
 		default:
 			err := errors.Errorf("unsupported %T statement: %v", i, i)
@@ -1432,6 +1608,20 @@ func (m *pgDumpReader) readFile(
 	return nil
 }
 
+// partitionParent returns the name of the table into which the rows of the
+// given table are imported. This is the parent table if the table is a
+// partition of an imported partitioned table, and the table itself otherwise.
+func (m *pgDumpReader) partitionParent(
+	partitionParents map[schemaAndTableName]schemaAndTableName, name schemaAndTableName,
+) schemaAndTableName {
+	if parent, ok := partitionParents[name]; ok {
+		if _, importing := m.tables[parent.String()]; importing {
+			return parent
+		}
+	}
+	return name
+}
+
 func wrapWithLineTooLongHint(err error) error {
 	return errors.WithHintf(
 		err,
@@ -0,0 +1,18 @@
+--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+
+--
+-- Name: measurement_y2006m02; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.measurement_y2006m02 PARTITION OF public.measurement
+FOR VALUES FROM ('2006-02-01') TO ('2006-03-01');
+
+--
+-- PostgreSQL database dump complete
+--
@@ -0,0 +1,59 @@
+--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+
+--
+-- Name: events; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.events (
+    id integer NOT NULL,
+    ts date NOT NULL
+)
+PARTITION BY RANGE (ts);
+
+--
+-- Name: events_2006; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.events_2006 PARTITION OF public.events
+FOR VALUES FROM ('2006-01-01') TO ('2007-01-01');
+
+--
+-- Name: events_default; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.events_default PARTITION OF public.events DEFAULT;
+
+--
+-- Data for Name: events_2006; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.events_2006 (id, ts) FROM stdin;
+1	2006-05-01
+\.
+
+
+--
+-- Data for Name: events_default; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.events_default (id, ts) FROM stdin;
+2	2007-01-15
+\.
+
+
+--
+-- Name: events events_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_pkey PRIMARY KEY (ts, id);
+
+--
+-- PostgreSQL database dump complete
+--
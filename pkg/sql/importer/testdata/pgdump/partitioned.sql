@@ -0,0 +1,180 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 15.2
+-- Dumped by pg_dump version 15.2
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET client_min_messages = warning;
+SET row_security = off;
+
+SET default_tablespace = '';
+
+--
+-- Name: measurement; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.measurement (
+    city_id integer NOT NULL,
+    logdate date NOT NULL,
+    peaktemp integer
+)
+PARTITION BY RANGE (logdate);
+
+--
+-- Name: measurement_y2006m02; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.measurement_y2006m02 (
+    city_id integer NOT NULL,
+    logdate date NOT NULL,
+    peaktemp integer
+);
+
+--
+-- Name: measurement_y2006m03; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.measurement_y2006m03 PARTITION OF public.measurement
+FOR VALUES FROM ('2006-03-01') TO ('2006-04-01');
+
+--
+-- Name: cities; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.cities (
+    region text NOT NULL,
+    name text NOT NULL
+)
+PARTITION BY LIST (region);
+
+--
+-- Name: cities_west; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.cities_west (
+    region text NOT NULL,
+    name text NOT NULL
+);
+
+--
+-- Name: cities_other; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.cities_other (
+    region text NOT NULL,
+    name text NOT NULL
+);
+
+--
+-- Name: measurement_y2006m02; Type: TABLE ATTACH; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.measurement ATTACH PARTITION public.measurement_y2006m02 FOR VALUES FROM ('2006-02-01') TO ('2006-03-01');
+
+--
+-- Name: cities_west; Type: TABLE ATTACH; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.cities ATTACH PARTITION public.cities_west FOR VALUES IN ('ca', 'or');
+
+--
+-- Name: cities_other; Type: TABLE ATTACH; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.cities ATTACH PARTITION public.cities_other DEFAULT;
+
+--
+-- Data for Name: cities_other; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.cities_other (region, name) FROM stdin;
+ny	new york
+tx	austin
+\.
+
+
+--
+-- Data for Name: cities_west; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.cities_west (region, name) FROM stdin;
+ca	los angeles
+ca	san francisco
+or	portland
+\.
+
+
+--
+-- Data for Name: measurement_y2006m02; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.measurement_y2006m02 (city_id, logdate, peaktemp) FROM stdin;
+1	2006-02-01	10
+2	2006-02-01	12
+\.
+
+
+--
+-- Data for Name: measurement_y2006m03; Type: TABLE DATA; Schema: public; Owner: postgres
+--
+
+COPY public.measurement_y2006m03 (city_id, logdate, peaktemp) FROM stdin;
+1	2006-03-15	15
+\.
+
+
+--
+-- Name: measurement measurement_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.measurement
+    ADD CONSTRAINT measurement_pkey PRIMARY KEY (city_id, logdate);
+
+--
+-- Name: measurement_y2006m02 measurement_y2006m02_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.measurement_y2006m02
+    ADD CONSTRAINT measurement_y2006m02_pkey PRIMARY KEY (city_id, logdate);
+
+--
+-- Name: cities cities_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.cities
+    ADD CONSTRAINT cities_pkey PRIMARY KEY (region, name);
+
+--
+-- Name: measurement_city_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX measurement_city_idx ON ONLY public.measurement USING btree (city_id);
+
+--
+-- Name: measurement_y2006m02_city_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX measurement_y2006m02_city_id_idx ON public.measurement_y2006m02 USING btree (city_id);
+
+--
+-- Name: measurement_y2006m02_city_id_idx; Type: INDEX ATTACH; Schema: public; Owner: postgres
+--
+
+ALTER INDEX public.measurement_city_idx ATTACH PARTITION public.measurement_y2006m02_city_id_idx;
+
+--
+-- Name: measurement_y2006m02_pkey; Type: INDEX ATTACH; Schema: public; Owner: postgres
+--
+
+ALTER INDEX public.measurement_pkey ATTACH PARTITION public.measurement_y2006m02_pkey;
+
+--
+-- PostgreSQL database dump complete
+--
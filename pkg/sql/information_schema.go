@@ -777,7 +777,8 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -823,7 +824,8 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1282,6 +1284,10 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					if u := c.AsUniqueWithoutIndex(); u != nil && u.UniqueWithoutIndexDesc().IsExclusion() {
+						// Like in Postgres, exclusion constraints are not included.
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1290,16 +1296,19 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrable := constraintDeferrableMode(c)
+					isDeferrable := deferrable.IsDeferrable()
+					initiallyDeferred := deferrable == tree.ConstraintInitiallyDeferred
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(isDeferrable),      // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1430,6 +1439,7 @@ var (
 	tableTypeSystemView = tree.NewDString("SYSTEM VIEW")
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
 )
 
@@ -1462,6 +1472,9 @@ func addTablesTableRow(
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsTemporary() {
 			tableType = tableTypeTemporary
 		}
@@ -2692,6 +2705,11 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 	return tree.DBool(createRole), err
 }
 
+func (r roleOptions) bypassRLS() (tree.DBool, error) {
+	bypassRLS, err := r.Exists("BYPASSRLS")
+	return tree.DBool(bypassRLS), err
+}
+
 func forEachRoleQuery(ctx context.Context, p *planner) string {
 	return `
 SELECT
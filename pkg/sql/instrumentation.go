@@ -153,6 +153,14 @@ type instrumentationHelper struct {
 	// statement_statistics table.
 	indexRecs []indexrec.Rec
 
+	// generic is true if the plan can be fully-optimized once and re-used
+	// without being re-optimized.
+	generic bool
+
+	// optimized is true if the plan was optimized or re-optimized during the
+	// current execution.
+	optimized bool
+
 	// maxFullScanRows is the maximum number of rows scanned by a full scan, as
 	// estimated by the optimizer.
 	maxFullScanRows float64
@@ -541,6 +549,9 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	}
 	ob.AddPlanningTime(phaseTimes.GetPlanningLatency())
 	ob.AddExecutionTime(phaseTimes.GetRunLatency())
+	if ih.generic {
+		ob.AddGenericPlanType(!ih.optimized)
+	}
 	ob.AddDistribution(ih.distribution.String())
 	ob.AddVectorized(ih.vectorized)
 
@@ -19,6 +19,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -258,6 +259,9 @@ func (ie *InternalExecutor) initConnEx(
 	}
 
 	ex.executorType = executorTypeInternal
+	// The internal executor does not support asynchronous notifications.
+	ex.extraTxnState.notifications = nil
+	ex.planner.extendedEvalCtx.Notifications = nil
 	return ex, nil
 
 }
@@ -304,6 +308,8 @@ func (ie *InternalExecutor) newConnExecutorWithTxn(
 			ex.extraTxnState.fromOuterTxn = true
 			ex.extraTxnState.schemaChangeJobRecords = ie.extraTxnState.schemaChangeJobRecords
 			ex.extraTxnState.jobs = ie.extraTxnState.jobs
+			ex.extraTxnState.deferredConstraints = nil
+			ex.extraTxnState.notifications = nil
 			ex.extraTxnState.schemaChangerState = ie.extraTxnState.schemaChangerState
 			ex.extraTxnState.shouldResetSyntheticDescriptors = shouldResetSyntheticDescriptors
 			ex.initPlanner(ctx, &ex.planner)
@@ -353,6 +359,7 @@ func (ie *InternalExecutor) newConnExecutorWithTxn(
 		txn.ReadTimestamp().GoTime(),
 		nil, /* historicalTimestamp */
 		roachpb.UnspecifiedUserPriority,
+		isolation.Serializable,
 		tree.ReadWrite,
 		txn,
 		ex.transitionCtx,
@@ -1199,6 +1206,11 @@ func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
 }
 
+// CreateSendNotificationsResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateSendNotificationsResult(pos CmdPos) SendNotificationsResult {
+	panic("unimplemented")
+}
+
 // noopClientLock is an implementation of ClientLock that says that no results
 // have been communicated to the client.
 type noopClientLock internalClientComm
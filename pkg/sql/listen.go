@@ -0,0 +1,51 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	channel := string(n.ChannelName)
+	if err := checkChannelName(channel); err != nil {
+		return nil, err
+	}
+	return &listenNode{action: listenAction{channel: channel}}, nil
+}
+
+// listenNode implements the LISTEN and UNLISTEN statements. The action is
+// recorded in the transaction's notification state and only takes effect
+// when the transaction commits.
+type listenNode struct {
+	action listenAction
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	stmt := "LISTEN"
+	if n.action.unlisten {
+		stmt = "UNLISTEN"
+	}
+	tn, err := params.p.txnNotifications(stmt)
+	if err != nil {
+		return err
+	}
+	tn.listens = append(tn.listens, n.action)
+	return nil
+}
+
+func (n *listenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums          { return nil }
+func (n *listenNode) Close(context.Context)        {}
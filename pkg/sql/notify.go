@@ -0,0 +1,201 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+const (
+	// maxChannelNameLength is the maximum length of a channel name, in bytes.
+	// It matches the maximum identifier length in Postgres.
+	maxChannelNameLength = 63
+	// maxNotificationPayloadLength is the maximum length of the payload of a
+	// notification, in bytes. It matches the limit in Postgres.
+	maxNotificationPayloadLength = 7999
+)
+
+// txnNotifications accumulates the LISTEN and UNLISTEN statements executed in
+// a transaction. As in Postgres, they only take effect once the transaction
+// commits, and are discarded if it aborts.
+//
+// The notifications sent with NOTIFY don't need to be accumulated: they are
+// written to the system.notifications table by the transaction itself, and are
+// delivered by the pgnotify.Watcher of every node once it commits.
+type txnNotifications struct {
+	// listens contains the LISTEN and UNLISTEN statements, in execution order.
+	listens []listenAction
+}
+
+// listenAction is the effect of a LISTEN or UNLISTEN statement.
+type listenAction struct {
+	channel string
+	// unlisten is set for UNLISTEN statements.
+	unlisten bool
+	// all is set for UNLISTEN *.
+	all bool
+}
+
+func (tn *txnNotifications) reset() {
+	*tn = txnNotifications{}
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	var payload string
+	if n.Payload != nil {
+		payload = n.Payload.RawString()
+	}
+	return &notifyNode{channel: string(n.ChannelName), payload: payload}, nil
+}
+
+type notifyNode struct {
+	channel string
+	payload string
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.QueueNotification(params.ctx, n.channel, n.payload)
+}
+
+func (n *notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums          { return nil }
+func (n *notifyNode) Close(context.Context)        {}
+
+// QueueNotification is part of the eval.SessionAccessor interface.
+func (p *planner) QueueNotification(ctx context.Context, channel, payload string) error {
+	if err := checkChannelName(channel); err != nil {
+		return err
+	}
+	if len(payload) > maxNotificationPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	if _, err := p.txnNotifications("NOTIFY"); err != nil {
+		return err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_1_CreateSystemNotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"NOTIFY is not supported until upgrade to version %v is finalized",
+			clusterversion.ByKey(clusterversion.V23_1_CreateSystemNotificationsTable))
+	}
+	// The notification is written in the current transaction, so that it's
+	// only delivered if that transaction commits. The ID of the transaction
+	// allows the watchers to group the notifications it sent.
+	_, err := p.ExecEx(ctx, "notify", sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, pid, txn_id) VALUES ($1, $2, $3, $4)`,
+		channel, payload, int64(p.EvalContext().QueryCancelKey.GetPGBackendPID()),
+		tree.NewDUuid(tree.DUuid{UUID: p.Txn().ID()}),
+	)
+	return err
+}
+
+// ListeningChannels is part of the eval.SessionAccessor interface.
+func (p *planner) ListeningChannels() []string {
+	if p.extendedEvalCtx.Listener == nil {
+		return nil
+	}
+	return p.extendedEvalCtx.Listener.Channels()
+}
+
+// txnNotifications returns the notification state of the current transaction,
+// or an error mentioning the given statement if the session can't send or
+// receive notifications.
+func (p *planner) txnNotifications(stmt string) (*txnNotifications, error) {
+	tn := p.extendedEvalCtx.Notifications
+	if tn == nil {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported in this context", stmt)
+	}
+	return tn, nil
+}
+
+func checkChannelName(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelNameLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// commitNotifications applies the LISTEN and UNLISTEN statements executed in
+// the transaction that just committed. The channels listened on only receive
+// the notifications committed from now on.
+func (ex *connExecutor) commitNotifications() {
+	tn := ex.extraTxnState.notifications
+	if tn == nil {
+		return
+	}
+	for _, a := range tn.listens {
+		switch {
+		case a.all:
+			if ex.listener != nil {
+				ex.listener.UnlistenAll()
+			}
+		case a.unlisten:
+			if ex.listener != nil {
+				ex.listener.Unlisten(a.channel)
+			}
+		default:
+			ex.getListener().Listen(a.channel, ex.server.cfg.Clock.Now())
+		}
+	}
+}
+
+// getListener returns the session's listener, creating it if needed.
+func (ex *connExecutor) getListener() *pgnotify.Listener {
+	if ex.listener == nil {
+		// When notifications arrive, wake up the connExecutor so that it can
+		// deliver them to the client if it's idle.
+		connCtx, stmtBuf := ex.ctxHolder.connCtx, ex.stmtBuf
+		ex.listener = ex.server.cfg.NotificationRegistry.NewListener(func() {
+			// An error means that the connection is closing, in which case the
+			// notifications can be dropped.
+			_ = stmtBuf.Push(connCtx, SendNotifications{})
+		})
+		ex.planner.extendedEvalCtx.Listener = ex.listener
+	}
+	return ex.listener
+}
+
+// bufferNotifications moves the notifications received by the session's
+// listener to the given result, which delivers them to the client when it's
+// closed. If notifications were dropped because too many were pending, the
+// client is warned with a notice. It must only be called when the session is
+// not in a transaction.
+func (ex *connExecutor) bufferNotifications(ctx context.Context, res NotificationResult) {
+	if ex.listener == nil {
+		return
+	}
+	notifications, dropped := ex.listener.Take()
+	if dropped > 0 {
+		log.Warningf(ctx, "dropped %d notifications because more than %d were pending",
+			dropped, pgnotify.MaxPendingNotifications)
+		res.BufferNotice(pgnotice.NewWithSeverityf("WARNING",
+			"dropped %d notifications because more than %d were pending",
+			dropped, pgnotify.MaxPendingNotifications,
+		))
+	}
+	for _, n := range notifications {
+		res.BufferNotification(n)
+	}
+}
@@ -104,6 +104,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterDatabaseSetZoneConfigExtension(ctx, n)
 	case *tree.AlterDefaultPrivileges:
 		return p.alterDefaultPrivileges(ctx, n)
+	case *tree.AlterDomain:
+		return p.AlterDomain(ctx, n)
 	case *tree.AlterFunctionOptions:
 		return p.AlterFunctionOptions(ctx, n)
 	case *tree.AlterFunctionRename:
@@ -154,8 +156,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CommentOnIndex(ctx, n)
 	case *tree.CommentOnTable:
 		return p.CommentOnTable(ctx, n)
+	case *tree.CreateAggregate:
+		return p.CreateAggregate(ctx, n)
 	case *tree.CreateDatabase:
 		return p.CreateDatabase(ctx, n)
+	case *tree.CreateDomain:
+		return p.CreateDomain(ctx, n)
 	case *tree.CreateIndex:
 		return p.CreateIndex(ctx, n)
 	case *tree.CreateSchema:
@@ -172,6 +178,16 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateExternalConnection(ctx, n)
 	case *tree.CreateTenant:
 		return p.CreateTenantNode(ctx, n)
+	case *tree.CreatePolicy:
+		return p.CreatePolicy(ctx, n)
+	case *tree.CreateForeignTable:
+		return p.CreateForeignTable(ctx, n)
+	case *tree.CreatePublication:
+		return p.CreatePublication(ctx, n)
+	case *tree.CreateServer:
+		return p.CreateServer(ctx, n)
+	case *tree.CreateTrigger:
+		return p.CreateTrigger(ctx, n)
 	case *tree.DropExternalConnection:
 		return p.DropExternalConnection(ctx, n)
 	case *tree.Deallocate:
@@ -180,8 +196,22 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DeclareCursor(ctx, n)
 	case *tree.Discard:
 		return p.Discard(ctx, n)
+	case *tree.DropAggregate:
+		return p.DropAggregate(ctx, n)
+	case *tree.DropPolicy:
+		return p.DropPolicy(ctx, n)
+	case *tree.DropForeignTable:
+		return p.DropForeignTable(ctx, n)
+	case *tree.DropPublication:
+		return p.DropPublication(ctx, n)
+	case *tree.DropServer:
+		return p.DropServer(ctx, n)
+	case *tree.DropProcedure:
+		return p.DropProcedure(ctx, n)
 	case *tree.DropDatabase:
 		return p.DropDatabase(ctx, n)
+	case *tree.DropDomain:
+		return p.DropDomain(ctx, n)
 	case *tree.DropFunction:
 		return p.DropFunction(ctx, n)
 	case *tree.DropIndex:
@@ -198,6 +228,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropTable(ctx, n)
 	case *tree.DropTenant:
 		return p.DropTenant(ctx, n)
+	case *tree.DropTrigger:
+		return p.DropTrigger(ctx, n)
 	case *tree.DropType:
 		return p.DropType(ctx, n)
 	case *tree.DropView:
@@ -208,8 +240,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Grant(ctx, n)
 	case *tree.GrantRole:
 		return p.GrantRole(ctx, n)
+	case *tree.Listen:
+		return p.Listen(ctx, n)
 	case *tree.MoveCursor:
 		return p.FetchCursor(ctx, &n.CursorStmt, true /* isMove */)
+	case *tree.Notify:
+		return p.Notify(ctx, n)
 	case *tree.ReassignOwnedBy:
 		return p.ReassignOwnedBy(ctx, n)
 	case *tree.RefreshMaterializedView:
@@ -234,6 +270,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Scrub(ctx, n)
 	case *tree.SetClusterSetting:
 		return p.SetClusterSetting(ctx, n)
+	case *tree.SetConstraints:
+		return p.SetConstraints(ctx, n)
 	case *tree.SetZoneConfig:
 		return p.SetZoneConfig(ctx, n)
 	case *tree.SetVar:
@@ -300,6 +338,7 @@ func init() {
 		&tree.AlterDatabaseDropSecondaryRegion{},
 		&tree.AlterDatabaseSetZoneConfigExtension{},
 		&tree.AlterDefaultPrivileges{},
+		&tree.AlterDomain{},
 		&tree.AlterFunctionOptions{},
 		&tree.AlterFunctionRename{},
 		&tree.AlterFunctionSetOwner{},
@@ -325,10 +364,17 @@ func init() {
 		&tree.CommentOnIndex{},
 		&tree.CommentOnConstraint{},
 		&tree.CommentOnTable{},
+		&tree.CreateAggregate{},
 		&tree.CreateDatabase{},
+		&tree.CreateDomain{},
 		&tree.CreateExtension{},
 		&tree.CreateExternalConnection{},
 		&tree.CreateTenant{},
+		&tree.CreatePolicy{},
+		&tree.CreateForeignTable{},
+		&tree.CreatePublication{},
+		&tree.CreateServer{},
+		&tree.CreateTrigger{},
 		&tree.CreateIndex{},
 		&tree.CreateSchema{},
 		&tree.CreateSequence{},
@@ -337,22 +383,32 @@ func init() {
 		&tree.Deallocate{},
 		&tree.DeclareCursor{},
 		&tree.Discard{},
+		&tree.DropAggregate{},
 		&tree.DropDatabase{},
+		&tree.DropDomain{},
 		&tree.DropExternalConnection{},
 		&tree.DropFunction{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
+		&tree.DropPolicy{},
+		&tree.DropForeignTable{},
+		&tree.DropPublication{},
+		&tree.DropServer{},
+		&tree.DropProcedure{},
 		&tree.DropRole{},
 		&tree.DropSchema{},
 		&tree.DropSequence{},
 		&tree.DropTable{},
 		&tree.DropTenant{},
+		&tree.DropTrigger{},
 		&tree.DropType{},
 		&tree.DropView{},
 		&tree.FetchCursor{},
 		&tree.Grant{},
 		&tree.GrantRole{},
+		&tree.Listen{},
 		&tree.MoveCursor{},
+		&tree.Notify{},
 		&tree.ReassignOwnedBy{},
 		&tree.RefreshMaterializedView{},
 		&tree.RenameColumn{},
@@ -365,6 +421,7 @@ func init() {
 		&tree.Scatter{},
 		&tree.Scrub{},
 		&tree.SetClusterSetting{},
+		&tree.SetConstraints{},
 		&tree.SetZoneConfig{},
 		&tree.SetVar{},
 		&tree.SetTransaction{},
@@ -397,6 +454,8 @@ func init() {
 		&tree.Import{},
 		&tree.ScheduledBackup{},
 		&tree.CreateTenantFromReplication{},
+		&tree.CreateSubscription{},
+		&tree.DropSubscription{},
 	} {
 		typ := optbuilder.OpaqueReadOnly
 		if tree.CanModifySchema(stmt) {
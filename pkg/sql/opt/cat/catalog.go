@@ -161,10 +161,23 @@ type Catalog interface {
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
 
+	// CheckPrivilegeForUser verifies that the given user has the given
+	// privilege on the given catalog object. If not, then CheckPrivilegeForUser
+	// returns an error.
+	CheckPrivilegeForUser(
+		ctx context.Context, o Object, priv privilege.Kind, user username.SQLUsername,
+	) error
+
 	// CheckAnyPrivilege verifies that the current user has any privilege on
 	// the given catalog object. If not, then CheckAnyPrivilege returns an error.
 	CheckAnyPrivilege(ctx context.Context, o Object) error
 
+	// CheckExecutionPrivilege verifies that the given user has the EXECUTE
+	// privilege on the user-defined function with the given OID. If user is
+	// undefined, the privilege of the current user is checked. If the user
+	// doesn't have the privilege, then CheckExecutionPrivilege returns an error.
+	CheckExecutionPrivilege(ctx context.Context, oid oid.Oid, user username.SQLUsername) error
+
 	// HasAdminRole checks that the current user has admin privileges. If yes,
 	// returns true. Returns an error if query on the `system.users` table failed
 	HasAdminRole(ctx context.Context) (bool, error)
@@ -182,6 +195,13 @@ type Catalog interface {
 	// NOLOGIN instead of LOGIN.
 	HasRoleOption(ctx context.Context, roleOption roleoption.Option) (bool, error)
 
+	// HasRoleOptionForUser is like HasRoleOption, but checks the role options
+	// of the given user rather than the current user. If user is undefined,
+	// the role options of the current user are checked.
+	HasRoleOptionForUser(
+		ctx context.Context, roleOption roleoption.Option, user username.SQLUsername,
+	) (bool, error)
+
 	// FullyQualifiedName retrieves the fully qualified name of a data source.
 	// Note that:
 	//  - this call may involve a database operation so it shouldn't be used in
@@ -192,4 +212,15 @@ type Catalog interface {
 
 	// RoleExists returns true if the role exists.
 	RoleExists(ctx context.Context, role username.SQLUsername) (bool, error)
+
+	// HasOwnership returns true if the given user, or any role the user is a
+	// member of, owns the given object. If user is undefined, the ownership of
+	// the current user is checked.
+	HasOwnership(ctx context.Context, o Object, user username.SQLUsername) (bool, error)
+
+	// IsMemberOfRole returns true if the given user is the given role, or is a
+	// direct or indirect member of it. Every user is a member of the public
+	// role. If user is undefined, the membership of the current user is
+	// checked.
+	IsMemberOfRole(ctx context.Context, role, user username.SQLUsername) (bool, error)
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Policy describes a row-level security policy of a table, exposing only the
+// information needed by the query optimizer.
+type Policy struct {
+	// Name is the name of the policy.
+	Name tree.Name
+
+	// Restrictive is true if the policy is RESTRICTIVE, in which case it is
+	// combined with the other policies of the table using AND. Otherwise, the
+	// policy is PERMISSIVE and is combined with the other permissive policies
+	// using OR.
+	Restrictive bool
+
+	// Command is the kind of statement to which the policy applies.
+	Command tree.PolicyCommand
+
+	// Roles are the roles to which the policy applies. The policy also applies
+	// to members of these roles.
+	Roles []username.SQLUsername
+
+	// UsingExpr is the serialized expression which existing rows must satisfy
+	// to be read, updated or deleted. It is empty if there is none.
+	UsingExpr string
+
+	// WithCheckExpr is the serialized expression which new rows must satisfy to
+	// be inserted or updated. It is empty if there is none.
+	WithCheckExpr string
+}
+
+// AppliesToCommand returns true if the policy applies to statements of the
+// given kind.
+func (p *Policy) AppliesToCommand(cmd tree.PolicyCommand) bool {
+	return p.Command == tree.PolicyCommandAll || p.Command == cmd
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -43,6 +44,10 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are stored on a foreign server and read from it when it's queried.
+	IsForeignTable() bool
+
 	// IsSystemTable returns true if this table is a special system table.
 	IsSystemTable() bool
 
@@ -151,6 +156,30 @@ type Table interface {
 	// such a view prior to running refresh returns an error.
 	IsRefreshViewRequired() bool
 
+	// TriggerCount returns the number of row-level triggers of the table.
+	TriggerCount() int
+
+	// Trigger returns the ith row-level trigger of the table, where
+	// i < TriggerCount. Triggers fire in the order of their ordinals.
+	Trigger(i int) *Trigger
+
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table, in which case the rows that statements may read or write
+	// are restricted by the policies of the table.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if row-level security also applies
+	// to the owner of the table.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies of the
+	// table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy of the table, where
+	// i < PolicyCount.
+	Policy(i int) *Policy
+
 	// HomeRegion returns the home region of the table, if any, for example if
 	// a table is defined with LOCALITY REGIONAL BY TABLE.
 	HomeRegion() (region string, ok bool)
@@ -301,6 +330,10 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrable returns whether the checks of the constraint may be postponed
+	// until the end of the transaction.
+	Deferrable() tree.ConstraintDeferrable
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -348,6 +381,23 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrable returns whether the checks of the constraint may be postponed
+	// until the end of the transaction. Only constraints without an index can
+	// be deferrable.
+	Deferrable() tree.ConstraintDeferrable
+
+	// IsExclusion is true if this is an exclusion constraint. Two rows violate
+	// an exclusion constraint if, for every column in the constraint, the
+	// operator returned by ExclusionOperator returns true when comparing the
+	// rows' values. Exclusion constraints are always WithoutIndex, and they do
+	// not imply that the constraint columns form a key.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator used to compare values of the ith
+	// column in this constraint. It is always treecmp.EQ if the constraint is
+	// not an exclusion constraint.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -0,0 +1,49 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/lib/pq/oid"
+)
+
+// Trigger describes a row-level trigger of a table, exposing only the
+// information needed by the query optimizer.
+type Trigger struct {
+	// Name is the name of the trigger.
+	Name tree.Name
+
+	// ActionTime indicates whether the trigger fires before or after the rows
+	// are written.
+	ActionTime tree.TriggerActionTime
+
+	// Events are the kinds of row modification which fire the trigger.
+	Events tree.TriggerEvents
+
+	// FuncOID is the OID of the trigger function. The function takes the OLD
+	// and NEW rows as arguments of the table's implicit record type, and
+	// returns a value of that type.
+	FuncOID oid.Oid
+}
+
+// FiresOn returns true if the trigger fires at the given time for rows
+// modified by the given event.
+func (t *Trigger) FiresOn(actionTime tree.TriggerActionTime, event tree.TriggerEvent) bool {
+	if t.ActionTime != actionTime {
+		return false
+	}
+	for _, ev := range t.Events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
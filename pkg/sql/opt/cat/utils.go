@@ -61,6 +61,9 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node) {
 	if tab.IsVirtualTable() {
 		child.Child("virtual table")
 	}
+	if tab.IsForeignTable() {
+		child.Child("foreign table")
+	}
 
 	var buf bytes.Buffer
 	for i := 0; i < tab.ColumnCount(); i++ {
@@ -100,11 +103,26 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node) {
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if uniq.IsExclusion() {
+			var buf bytes.Buffer
+			buf.WriteString("EXCLUDE (")
+			for j, n := 0, uniq.ColumnCount(); j < n; j++ {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				fmt.Fprintf(&buf, "%s WITH %s",
+					tab.Column(uniq.ColumnOrdinal(tab, j)).ColName(), uniq.ExclusionOperator(j))
+			}
+			buf.WriteString(")")
+			c = child.Child(buf.String())
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", pred)
 		}
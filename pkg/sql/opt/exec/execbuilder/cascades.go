@@ -166,7 +166,36 @@ func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
 			allowAutoCommit bool,
 		) (exec.Plan, error) {
 			return cb.planCascade(
-				ctx, semaCtx, evalCtx, execFactory, cascade, bufferRef, numBufferedRows, allowAutoCommit,
+				ctx, semaCtx, evalCtx, execFactory, cascade.Builder, cascade.OldValues, cascade.NewValues,
+				bufferRef, numBufferedRows, allowAutoCommit,
+			)
+		},
+	}
+}
+
+// setupAfterTriggers fills in an exec.Cascade struct for the given AFTER
+// triggers. The triggers are planned and executed in the same way as a
+// cascade.
+func (cb *cascadeBuilder) setupAfterTriggers(triggers *memo.AfterTriggers) exec.Cascade {
+	names := make([]string, len(triggers.Names))
+	for i := range triggers.Names {
+		names[i] = string(triggers.Names[i])
+	}
+	return exec.Cascade{
+		TriggerNames: names,
+		Buffer:       cb.mutationBuffer,
+		PlanFn: func(
+			ctx context.Context,
+			semaCtx *tree.SemaContext,
+			evalCtx *eval.Context,
+			execFactory exec.Factory,
+			bufferRef exec.Node,
+			numBufferedRows int,
+			allowAutoCommit bool,
+		) (exec.Plan, error) {
+			return cb.planCascade(
+				ctx, semaCtx, evalCtx, execFactory, triggers.Builder, triggers.OldValues, triggers.NewValues,
+				bufferRef, numBufferedRows, allowAutoCommit,
 			)
 		},
 	}
@@ -183,7 +212,8 @@ func (cb *cascadeBuilder) planCascade(
 	semaCtx *tree.SemaContext,
 	evalCtx *eval.Context,
 	execFactory exec.Factory,
-	cascade *memo.FKCascade,
+	builder memo.CascadeBuilder,
+	oldValues, newValues opt.ColList,
 	bufferRef exec.Node,
 	numBufferedRows int,
 	allowAutoCommit bool,
@@ -202,7 +232,7 @@ func (cb *cascadeBuilder) planCascade(
 	if bufferRef == nil {
 		// No input buffering.
 		var err error
-		relExpr, err = cascade.Builder.Build(
+		relExpr, err = builder.Build(
 			ctx,
 			semaCtx,
 			evalCtx,
@@ -247,16 +277,16 @@ func (cb *cascadeBuilder) planCascade(
 		}
 
 		// Remap the cascade columns.
-		oldVals, err := remapColumns(cascade.OldValues, withColRemap)
+		oldVals, err := remapColumns(oldValues, withColRemap)
 		if err != nil {
 			return nil, err
 		}
-		newVals, err := remapColumns(cascade.NewValues, withColRemap)
+		newVals, err := remapColumns(newValues, withColRemap)
 		if err != nil {
 			return nil, err
 		}
 
-		relExpr, err = cascade.Builder.Build(
+		relExpr, err = builder.Build(
 			ctx,
 			semaCtx,
 			evalCtx,
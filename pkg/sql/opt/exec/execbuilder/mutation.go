@@ -104,7 +104,7 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		returnOrds,
 		checkOrds,
 		b.allowAutoCommit && len(ins.UniqueChecks) == 0 &&
-			len(ins.FKChecks) == 0 && len(ins.FKCascades) == 0,
+			len(ins.FKChecks) == 0 && len(ins.FKCascades) == 0 && ins.AfterTriggers == nil,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -123,6 +123,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildAfterTriggers(ins.AfterTriggers); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -162,6 +166,11 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
+	//  - the table has no row-level triggers.
+	if tab.TriggerCount() > 0 {
+		return execPlan{}, false, nil
+	}
+
 	//  - there are no self-referencing foreign keys;
 	//  - all FK checks can be performed using direct lookups into unique indexes.
 	fkChecks := make([]exec.InsertFastPathFKCheck, len(ins.FKChecks))
@@ -172,6 +181,11 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrable().IsDeferrable() {
+			// The checks of deferrable FKs may have to be postponed until the end
+			// of the transaction, so they cannot be performed before the insert.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -367,7 +381,7 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 		checkOrds,
 		passthroughCols,
 		b.allowAutoCommit && len(upd.UniqueChecks) == 0 &&
-			len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0,
+			len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0 && upd.AfterTriggers == nil,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -385,6 +399,10 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildAfterTriggers(upd.AfterTriggers); err != nil {
+		return execPlan{}, err
+	}
+
 	// Construct the output column map.
 	ep := execPlan{root: node}
 	if upd.NeedResults() {
@@ -454,7 +472,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		returnColOrds,
 		checkOrds,
 		b.allowAutoCommit && len(ups.UniqueChecks) == 0 &&
-			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0,
+			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -472,6 +490,10 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildAfterTriggers(ups.AfterTriggers); err != nil {
+		return execPlan{}, err
+	}
+
 	// If UPSERT returns rows, they contain all non-mutation columns from the
 	// table, in the same order they're defined in the table. Each output column
 	// value is taken from an insert, fetch, or update column, depending on the
@@ -526,7 +548,8 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 		fetchColOrds,
 		returnColOrds,
 		passthroughCols,
-		b.allowAutoCommit && len(del.FKChecks) == 0 && len(del.FKCascades) == 0,
+		b.allowAutoCommit && len(del.FKChecks) == 0 && len(del.FKCascades) == 0 &&
+			del.AfterTriggers == nil,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -540,6 +563,10 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildAfterTriggers(del.AfterTriggers); err != nil {
+		return execPlan{}, err
+	}
+
 	// Construct the output column map.
 	ep := execPlan{root: node}
 	if del.NeedResults() {
@@ -763,7 +790,16 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	// or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with existing key.
+	code := pgcode.UniqueViolation
+	if uc.IsExclusion() {
+		code = pgcode.ExclusionViolation
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -782,15 +818,26 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	if uc.IsExclusion() {
+		details.WriteString(") conflicts with existing key.")
+	} else {
+		details.WriteString(") already exists.")
+	}
 
-	return errors.WithDetail(
+	err := errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
 	)
+	if d := uc.Deferrable(); d.IsDeferrable() {
+		err = exec.NewDeferrableCheckError(
+			err, tabMeta.Table.ID(), constraintName, true /* unique */, d == tree.ConstraintInitiallyDeferred,
+			keyVals,
+		)
+	}
+	return err
 }
 
 // mkFKCheckErr generates a user-friendly error describing a foreign key
@@ -802,11 +849,12 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 
 	var msg, details bytes.Buffer
 	var constraintName string
+	var fk cat.ForeignKeyConstraint
 	if c.FKOutbound {
 		// Generate an error of the form:
 		//   ERROR:  insert on table "child" violates foreign key constraint "foo"
 		//   DETAIL: Key (child_p)=(2) is not present in table "parent".
-		fk := origin.Table.OutboundForeignKey(c.FKOrdinal)
+		fk = origin.Table.OutboundForeignKey(c.FKOrdinal)
 		constraintName = fk.Name()
 		fmt.Fprintf(&msg, "%s on table ", c.OpName)
 		lexbase.EncodeEscapedSQLIdent(&msg, string(origin.Alias.ObjectName))
@@ -848,7 +896,7 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 		//   ERROR:  delete on table "parent" violates foreign key constraint
 		//           "child_child_p_fkey" on table "child"
 		//   DETAIL: Key (p)=(1) is still referenced from table "child".
-		fk := referenced.Table.InboundForeignKey(c.FKOrdinal)
+		fk = referenced.Table.InboundForeignKey(c.FKOrdinal)
 		constraintName = fk.Name()
 		fmt.Fprintf(&msg, "%s on table ", c.OpName)
 		lexbase.EncodeEscapedSQLIdent(&msg, string(referenced.Alias.ObjectName))
@@ -877,13 +925,33 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 		details.WriteByte('.')
 	}
 
-	return errors.WithDetail(
+	err := errors.WithDetail(
 		pgerror.WithConstraintName(
 			pgerror.Newf(pgcode.ForeignKeyViolation, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
 	)
+	if d := fk.Deferrable(); d.IsDeferrable() && !isRestrictCheck(fk, c) {
+		err = exec.NewDeferrableCheckError(
+			err, fk.OriginTableID(), constraintName, false /* unique */, d == tree.ConstraintInitiallyDeferred,
+			keyVals,
+		)
+	}
+	return err
+}
+
+// isRestrictCheck returns true if the check verifies that a referenced row
+// that is being deleted or updated has no references, and the FK action for
+// the operation is RESTRICT. As in Postgres, such checks are never deferred.
+func isRestrictCheck(fk cat.ForeignKeyConstraint, c *memo.FKChecksItem) bool {
+	if c.FKOutbound {
+		return false
+	}
+	if c.OpName == "delete" {
+		return fk.DeleteReferenceAction() == tree.Restrict
+	}
+	return fk.UpdateReferenceAction() == tree.Restrict
 }
 
 func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) error {
@@ -900,6 +968,21 @@ func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) e
 	return nil
 }
 
+// buildAfterTriggers sets up the query which fires the AFTER row-level triggers
+// of a mutation, if there are any. It runs after the mutation and its cascades,
+// reading the modified rows from the buffered mutation input.
+func (b *Builder) buildAfterTriggers(triggers *memo.AfterTriggers) error {
+	if triggers == nil {
+		return nil
+	}
+	cb, err := makeCascadeBuilder(b, triggers.WithID)
+	if err != nil {
+		return err
+	}
+	b.cascades = append(b.cascades, cb.setupAfterTriggers(triggers))
+	return nil
+}
+
 // canAutoCommit determines if it is safe to auto commit the mutation contained
 // in the expression.
 //
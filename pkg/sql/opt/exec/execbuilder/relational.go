@@ -254,6 +254,10 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.Max1RowExpr:
 		ep, err = b.buildMax1Row(t)
 
+	case *memo.BarrierExpr:
+		// A barrier only affects optimization; it is a no-op at execution time.
+		ep, err = b.buildRelational(t.Input)
+
 	case *memo.ProjectSetExpr:
 		ep, err = b.buildProjectSet(t)
 
@@ -737,10 +741,11 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (execPlan, error) {
 	b.IndexesUsed = util.CombineUniqueString(b.IndexesUsed, []string{fmt.Sprintf("%d@%d", tab.ID(), idx.ID())})
 
 	// Save if we planned a full table/index scan on the builder so that the
-	// planner can be made aware later. We only do this for non-virtual tables.
+	// planner can be made aware later. We only do this for tables that are
+	// neither virtual nor foreign.
 	relProps := scan.Relational()
 	stats := relProps.Statistics()
-	if !tab.IsVirtualTable() && isUnfiltered {
+	if !tab.IsVirtualTable() && !tab.IsForeignTable() && isUnfiltered {
 		large := !stats.Available || stats.RowCount > b.evalCtx.SessionData().LargeFullScanRows
 		if scan.Index == cat.PrimaryIndex {
 			b.ContainsFullTableScan = true
@@ -1490,6 +1495,31 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			argCols := make([]exec.NodeColumnOrdinal, len(uda.Args))
+			for j := range uda.Args {
+				variable, ok := uda.Args[j].(*memo.VariableExpr)
+				if !ok {
+					return execPlan{}, errors.AssertionFailedf("only VariableOp args supported")
+				}
+				argCols[j] = input.getNodeColumnOrdinal(variable.Col)
+			}
+			userDefined, err := b.buildUserDefinedAggInfo(uda)
+			if err != nil {
+				return execPlan{}, err
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:    uda.Name,
+				Distinct:    distinct,
+				ResultType:  item.Agg.DataType(),
+				ArgCols:     argCols,
+				Filter:      filterOrd,
+				UserDefined: userDefined,
+			}
+			ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
+			continue
+		}
+
 		name, _ := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -1581,6 +1611,41 @@ func (b *Builder) buildDistinct(distinct memo.RelExpr) (execPlan, error) {
 	return b.ensureColumns(ep, distinct, outCols.ToList(), distinct.ProvidedPhysical().Ordering)
 }
 
+// buildUserDefinedAggInfo builds the state transition and final function
+// calls of a user-defined aggregate. The state column is mapped to IndexedVar 0
+// and the argument columns to the following IndexedVars.
+func (b *Builder) buildUserDefinedAggInfo(
+	uda *memo.UserDefinedAggExpr,
+) (*exec.UserDefinedAggInfo, error) {
+	var colMap opt.ColMap
+	colMap.Set(int(uda.StateCol), 0)
+	for i, col := range uda.ArgCols {
+		colMap.Set(int(col), i+1)
+	}
+	ctx := buildScalarCtx{
+		ivh:     tree.MakeIndexedVarHelper(nil /* container */, colMap.Len()),
+		ivarMap: colMap,
+	}
+	transition, err := b.buildScalar(&ctx, uda.Transition)
+	if err != nil {
+		return nil, err
+	}
+	var final tree.TypedExpr
+	if uda.Final != nil {
+		final, err = b.buildScalar(&ctx, uda.Final)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &exec.UserDefinedAggInfo{
+		Transition:       transition,
+		Final:            final,
+		StateType:        uda.StateType,
+		InitState:        uda.InitState,
+		StrictTransition: uda.StrictTransition,
+	}, nil
+}
+
 func (b *Builder) buildGroupByInput(groupBy memo.RelExpr) (execPlan, error) {
 	groupByInput := groupBy.Child(0).(memo.RelExpr)
 	input, err := b.buildRelational(groupByInput)
@@ -3046,20 +3111,50 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefined []*exec.UserDefinedAggInfo
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+
+		var fnRef tree.ResolvableFunctionReference
+		var fnArgs []opt.ScalarExpr
+		var returnType *types.T
+		var props *tree.FunctionProperties
+		var overload *tree.Overload
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			// User-defined aggregates are not builtins; the windower computes
+			// them from their state transition and final functions.
+			info, err := b.buildUserDefinedAggInfo(uda)
+			if err != nil {
+				return execPlan{}, err
+			}
+			if userDefined == nil {
+				userDefined = make([]*exec.UserDefinedAggInfo, len(w.Windows))
+			}
+			userDefined[i] = info
+			fnRef = tree.WrapFunction(uda.Name)
+			fnArgs = uda.Args
+			returnType = uda.Typ
+		} else {
+			var name string
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			fnRef = b.wrapFunction(name)
+			fnArgs = make([]opt.ScalarExpr, fn.ChildCount())
+			for j := range fnArgs {
+				fnArgs[j] = fn.Child(j).(opt.ScalarExpr)
+			}
+			returnType = overload.FixedReturnType()
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, len(fnArgs))
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, len(fnArgs))
+		for j := range fnArgs {
+			col := fnArgs[j].(*memo.VariableExpr).Col
 			args[j] = b.indexedVar(&ctx, b.mem.Metadata(), col)
 			idx, _ := input.outputCols.Get(int(col))
 			argIdxs[i][j] = exec.NodeColumnOrdinal(idx)
@@ -3093,12 +3188,12 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 			Frame:      frame,
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
-			b.wrapFunction(name),
+			fnRef,
 			0,
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			returnType,
 			props,
 			overload,
 		)
@@ -3132,13 +3227,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	}
 
 	node, err := b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   input.sqlOrdering(ord),
+		Cols:        resultCols,
+		Exprs:       exprs,
+		OutputIdxs:  outputIdxs,
+		ArgIdxs:     argIdxs,
+		FilterIdxs:  filterIdxs,
+		Partition:   partitionIdxs,
+		Ordering:    input.sqlOrdering(ord),
+		UserDefined: userDefined,
 	})
 	if err != nil {
 		return execPlan{}, err
@@ -3344,6 +3440,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.SortOp:             {},
 	opt.OrdinalityOp:       {},
 	opt.Max1RowOp:          {},
+	opt.BarrierOp:          {},
 	opt.ProjectSetOp:       {},
 	opt.WindowOp:           {},
 	opt.ExplainOp:          {},
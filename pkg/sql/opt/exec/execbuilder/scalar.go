@@ -61,6 +61,7 @@ func init() {
 		opt.AnyOp:            (*Builder).buildAny,
 		opt.AnyScalarOp:      (*Builder).buildAnyScalar,
 		opt.IndirectionOp:    (*Builder).buildIndirection,
+		opt.ArraySliceOp:     (*Builder).buildArraySlice,
 		opt.CollateOp:        (*Builder).buildCollate,
 		opt.ArrayFlattenOp:   (*Builder).buildArrayFlatten,
 		opt.IfErrOp:          (*Builder).buildIfErr,
@@ -473,6 +474,34 @@ func (b *Builder) buildIndirection(
 	return tree.NewTypedIndirectionExpr(expr, index, scalar.DataType()), nil
 }
 
+func (b *Builder) buildArraySlice(
+	ctx *buildScalarCtx, scalar opt.ScalarExpr,
+) (tree.TypedExpr, error) {
+	slice := scalar.(*memo.ArraySliceExpr)
+	expr, err := b.buildScalar(ctx, slice.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dimensions preceding the sliced dimension are kept whole.
+	subscripts := make(tree.ArraySubscripts, slice.Dimension+1)
+	for i := range subscripts {
+		subscripts[i] = &tree.ArraySubscript{Slice: true}
+	}
+	last := subscripts[slice.Dimension]
+	if slice.HasBegin {
+		if last.Begin, err = b.buildScalar(ctx, slice.Begin); err != nil {
+			return nil, err
+		}
+	}
+	if slice.HasEnd {
+		if last.End, err = b.buildScalar(ctx, slice.End); err != nil {
+			return nil, err
+		}
+	}
+	return tree.NewTypedArraySliceExpr(expr, subscripts), nil
+}
+
 func (b *Builder) buildCollate(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.TypedExpr, error) {
 	expr, err := b.buildScalar(ctx, scalar.Child(0).(opt.ScalarExpr))
 	if err != nil {
@@ -661,6 +690,8 @@ func (b *Builder) buildSubquery(
 			subquery.Typ,
 			false, /* enableStepping */
 			true,  /* calledOnNullInput */
+			nil,   /* sessionOverrides */
+			nil,   /* program */
 		), nil
 	}
 
@@ -804,6 +835,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Typ,
 		enableStepping,
 		udf.CalledOnNullInput,
+		udf.SessionOverrides,
+		udf.Program,
 	), nil
 }
 
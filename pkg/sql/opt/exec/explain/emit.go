@@ -100,8 +100,13 @@ func Emit(plan *Plan, ob *OutputBuilder, spanFormatFn SpanFormatFn) error {
 	}
 
 	for i := range plan.Cascades {
-		ob.EnterMetaNode("fk-cascade")
-		ob.Attr("fk", plan.Cascades[i].FKName)
+		if names := plan.Cascades[i].TriggerNames; len(names) > 0 {
+			ob.EnterMetaNode("after-triggers")
+			ob.Attr("triggers", strings.Join(names, ", "))
+		} else {
+			ob.EnterMetaNode("fk-cascade")
+			ob.Attr("fk", plan.Cascades[i].FKName)
+		}
 		if buffer := plan.Cascades[i].Buffer; buffer != nil {
 			ob.Attr("input", buffer.(*Node).args.(*bufferArgs).Label)
 		}
@@ -186,6 +191,9 @@ func (e *emitter) nodeName(n *Node) (string, error) {
 		if a.Table.IsVirtualTable() {
 			return "virtual table", nil
 		}
+		if a.Table.IsForeignTable() {
+			return "foreign table", nil
+		}
 		if a.Params.Reverse {
 			return "revscan", nil
 		}
@@ -531,8 +539,9 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 				} else if n.op == scanOp {
 					// In non-verbose mode, don't show the row count (which is not based
 					// on reality); only show a "missing stats" field for scans. Don't
-					// show it for virtual tables though, where we expect no stats.
-					if !n.args.(*scanArgs).Table.IsVirtualTable() {
+					// show it for virtual or foreign tables though, where we expect no
+					// stats.
+					if t := n.args.(*scanArgs).Table; !t.IsVirtualTable() && !t.IsForeignTable() {
 						e.ob.AddField("missing stats", "")
 					}
 				}
@@ -557,8 +566,10 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			))
 		}
 		e.emitTableAndIndex("table", a.Table, a.Index, suffix)
-		// Omit spans for virtual tables, unless we actually have a constraint.
-		if a.Table != nil && !(a.Table.IsVirtualTable() && a.Params.IndexConstraint == nil) {
+		// Omit spans for virtual tables, unless we actually have a constraint, and
+		// for foreign tables, which don't have any.
+		if a.Table != nil && !a.Table.IsForeignTable() &&
+			!(a.Table.IsVirtualTable() && a.Params.IndexConstraint == nil) {
 			e.emitSpans("spans", a.Table, a.Index, a.Params)
 		}
 
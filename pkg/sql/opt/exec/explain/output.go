@@ -308,6 +308,17 @@ func (ob *OutputBuilder) AddExecutionTime(delta time.Duration) {
 	ob.AddTopLevelField("execution time", string(humanizeutil.Duration(delta)))
 }
 
+// AddGenericPlanType adds a top-level field indicating that a generic query
+// plan was used, and whether it was reused or re-optimized during the current
+// execution. Cannot be called while inside a node.
+func (ob *OutputBuilder) AddGenericPlanType(reused bool) {
+	if reused {
+		ob.AddTopLevelField("plan type", "generic, reused")
+	} else {
+		ob.AddTopLevelField("plan type", "generic, re-optimized")
+	}
+}
+
 // AddKVReadStats adds a top-level field for the bytes/rows read from KV as well
 // as for the number of BatchRequests issued.
 func (ob *OutputBuilder) AddKVReadStats(rows, bytes, batchRequests int64) {
@@ -490,6 +490,10 @@ func (u *unknownTable) IsVirtualTable() bool {
 	return false
 }
 
+func (u *unknownTable) IsForeignTable() bool {
+	return false
+}
+
 func (u *unknownTable) IsSystemTable() bool {
 	return false
 }
@@ -582,6 +586,36 @@ func (u *unknownTable) IsRefreshViewRequired() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (u *unknownTable) Trigger(i int) *cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (u *unknownTable) Policy(i int) *cat.Policy {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 // HomeRegion is part of the cat.Table interface.
 func (u *unknownTable) HomeRegion() (region string, ok bool) {
 	return "", false
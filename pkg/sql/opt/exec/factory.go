@@ -152,6 +152,33 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate was created with CREATE AGGREGATE,
+	// in which case FuncName is the name of the aggregate.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo contains the information needed to compute a user-defined
+// aggregate.
+type UserDefinedAggInfo struct {
+	// Transition computes the next state of the aggregate. It refers to the
+	// current state as IndexedVar 0, and to the arguments of the aggregate as
+	// IndexedVars 1 through n.
+	Transition tree.TypedExpr
+
+	// Final computes the result of the aggregate from the final state, which it
+	// refers to as IndexedVar 0. It is nil if the final state is the result.
+	Final tree.TypedExpr
+
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+
+	// InitState is the initial state of the aggregate.
+	InitState tree.Datum
+
+	// StrictTransition is true if rows with a NULL argument are skipped, and if
+	// a NULL state is replaced by the first non-NULL argument.
+	StrictTransition bool
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -181,6 +208,11 @@ type WindowInfo struct {
 
 	// Ordering is the set of input columns to order on.
 	Ordering colinfo.ColumnOrdering
+
+	// UserDefined contains the user-defined aggregates computed as window
+	// functions, in the same order as Exprs. It is nil if there are none, and
+	// its entries are nil for builtin functions.
+	UserDefined []*UserDefinedAggInfo
 }
 
 // ExplainEnvData represents the data that's going to be displayed in EXPLAIN (env).
@@ -213,9 +245,15 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
-	// FKName is the name of the foreign key constraint.
+	// FKName is the name of the foreign key constraint. It is empty if the
+	// query fires AFTER triggers instead.
 	FKName string
 
+	// TriggerNames are the names of the AFTER row-level triggers fired by the
+	// query, in the order in which they fire. It is empty for foreign key
+	// cascades.
+	TriggerNames []string
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
@@ -271,6 +309,54 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableCheckError wraps the error generated by the check of a deferrable
+// FK or unique constraint. It allows the executor to postpone the validation
+// of the constraint until the end of the transaction when the constraint is
+// deferred (see SET CONSTRAINTS).
+type DeferrableCheckError struct {
+	cause error
+	// TableID is the ID of the table on which the constraint is defined.
+	TableID cat.StableID
+	// ConstraintName is the name of the violated constraint.
+	ConstraintName string
+	// Unique is true for unique constraints and false for FK constraints.
+	Unique bool
+	// InitiallyDeferred is true if the constraint is deferred unless it was
+	// made immediate with SET CONSTRAINTS.
+	InitiallyDeferred bool
+	// Keys contains the values of the constraint columns for the rows that
+	// violate the constraint. If the constraint is deferred, only these keys
+	// need to be validated when the transaction commits.
+	Keys []tree.Datums
+	// AllRows is set if too many rows violate the constraint for their keys to
+	// be recorded, in which case Keys is nil and all the rows of the table need
+	// to be validated.
+	AllRows bool
+}
+
+// NewDeferrableCheckError wraps the given constraint violation error, which
+// was generated for a row with the given key.
+func NewDeferrableCheckError(
+	cause error,
+	tableID cat.StableID,
+	constraintName string,
+	unique, initiallyDeferred bool,
+	key tree.Datums,
+) *DeferrableCheckError {
+	return &DeferrableCheckError{
+		cause:             cause,
+		TableID:           tableID,
+		ConstraintName:    constraintName,
+		Unique:            unique,
+		InitiallyDeferred: initiallyDeferred,
+		Keys:              []tree.Datums{key},
+	}
+}
+
+func (e *DeferrableCheckError) Error() string { return e.cause.Error() }
+func (e *DeferrableCheckError) Cause() error  { return e.cause }
+func (e *DeferrableCheckError) Unwrap() error { return e.cause }
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
@@ -377,8 +377,8 @@ func addIndexToCandidates(
 	currTable cat.Table,
 	indexCandidates map[cat.Table][][]cat.IndexColumn,
 ) {
-	// Do not add candidates from system or virtual tables.
-	if currTable.IsVirtualTable() || currTable.IsSystemTable() {
+	// Do not add candidates from system, virtual or foreign tables.
+	if currTable.IsVirtualTable() || currTable.IsForeignTable() || currTable.IsSystemTable() {
 		return
 	}
 
@@ -116,13 +116,20 @@ func TryFilterInvertedIndex(
 	} else {
 		col := index.InvertedColumn().InvertedSourceColumnOrdinal()
 		typ = factory.Metadata().Table(tabID).Column(col).DatumType()
-		if typ.Family() == types.StringFamily {
+		switch typ.Family() {
+		case types.StringFamily:
 			filterPlanner = &trigramFilterPlanner{
 				tabID:           tabID,
 				index:           index,
 				computedColumns: computedColumns,
 			}
-		} else {
+		case types.TSVectorFamily:
+			filterPlanner = &tsqueryFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+			}
+		default:
 			filterPlanner = &jsonOrArrayFilterPlanner{
 				tabID:           tabID,
 				index:           index,
@@ -203,6 +210,10 @@ func TryJoinInvertedIndex(
 			getSpanExpr: getSpanExprForGeometryIndex,
 		}
 	} else {
+		// Inverted joins are not supported for tsvector indexes: a query from
+		// the input such as !foo matches documents which aren't in the index
+		// at all, so the index can't be used to find the matches of every
+		// input row.
 		joinPlanner = &jsonOrArrayJoinPlanner{
 			factory:   factory,
 			tabID:     tabID,
@@ -0,0 +1,85 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type tsqueryFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &tsqueryFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (t *tsqueryFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	_ context.Context, _ *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var constantVal opt.ScalarExpr
+	switch e := expr.(type) {
+	case *memo.TSMatchesExpr:
+		// The @@ operator is commutative: both tsvector @@ tsquery and
+		// tsquery @@ tsvector are supported.
+		if isIndexColumn(t.tabID, t.index, e.Left, t.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			constantVal = e.Right
+		} else if isIndexColumn(t.tabID, t.index, e.Right, t.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			constantVal = e.Left
+		} else {
+			// Can only accelerate with a single constant value.
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+	default:
+		// Only the @@ operator is supported.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	d := memo.ExtractConstDatum(constantVal)
+	q, ok := d.(*tree.DTSQuery)
+	if !ok {
+		panic(errors.AssertionFailedf(
+			"trying to apply inverted index to unsupported type %s", d.ResolvedType(),
+		))
+	}
+	var err error
+	invertedExpr, err = q.TSQuery.GetInvertedExpr()
+	if err != nil {
+		// An inverted expression could not be extracted. This is the case for
+		// queries such as !foo, which match documents that aren't in the index
+		// at all.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// If the extracted inverted expression is not tight then remaining filters
+	// must be applied after the inverted index scan.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for tsvector indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
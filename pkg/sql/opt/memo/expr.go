@@ -1252,6 +1252,33 @@ type FKCascade struct {
 	NewValues opt.ColList
 }
 
+// AfterTriggers stores metadata necessary for firing the AFTER row-level
+// triggers of a mutation. Like cascades, the triggers are planned as a separate
+// query which runs after the original query, reading the modified rows from
+// the buffered mutation input.
+type AfterTriggers struct {
+	// Names are the names of the triggers, in the order in which they fire.
+	Names []tree.Name
+
+	// Builder is an object that can be used as the "optbuilder" for the query
+	// which calls the trigger functions.
+	Builder CascadeBuilder
+
+	// WithID identifies the buffer for the mutation input in the original
+	// expression tree.
+	WithID opt.WithID
+
+	// OldValues are column IDs from the mutation input that correspond to the
+	// old values of the table's visible columns. It is empty for inserts. For
+	// upserts, the last column is the canary column, which is NULL for rows
+	// that are inserted.
+	OldValues opt.ColList
+
+	// NewValues are column IDs from the mutation input that correspond to the
+	// new values of the table's visible columns. It is empty for deletions.
+	NewValues opt.ColList
+}
+
 // CascadeBuilder is an interface used to construct a cascading query for a
 // specific FK relation. For example: if we are deleting rows from a parent
 // table, after deleting the rows from the parent table this interface will be
@@ -1426,6 +1426,12 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 			c.Child(p.FKCascades[i].FKName)
 		}
 	}
+	if p.AfterTriggers != nil {
+		c := tp.Childf("after-triggers")
+		for _, name := range p.AfterTriggers.Names {
+			c.Child(string(name))
+		}
+	}
 }
 
 // ColumnString returns the column in the same format as formatColSimple.
@@ -1653,6 +1659,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
@@ -133,6 +133,15 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		for _, arg := range uda.Args {
+			if variable, ok := arg.(*VariableExpr); ok {
+				res.Add(variable.Col)
+			}
+		}
+		return res
+	}
+
 	for i, n := 0, e.ChildCount(); i < n; i++ {
 		if variable, ok := e.Child(i).(*VariableExpr); ok {
 			res.Add(variable.Col)
@@ -146,11 +155,19 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 // expression for the first argument, skipping past modifiers like AggDistinct.
 func ExtractAggFirstVar(e opt.ScalarExpr) *VariableExpr {
 	e = ExtractAggFunc(e)
-	if e.ChildCount() == 0 {
+	var first opt.Expr
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		if len(uda.Args) > 0 {
+			first = uda.Args[0]
+		}
+	} else if e.ChildCount() > 0 {
+		first = e.Child(0)
+	}
+	if first == nil {
 		panic(errors.AssertionFailedf("aggregate does not have any arguments"))
 	}
 
-	if variable, ok := e.Child(0).(*VariableExpr); ok {
+	if variable, ok := first.(*VariableExpr); ok {
 		return variable
 	}
 
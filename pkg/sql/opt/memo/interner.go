@@ -437,6 +437,12 @@ func (h *hasher) HashStatement(val tree.Statement) {
 	}
 }
 
+func (h *hasher) HashRoutineProgram(val tree.RoutineProgram) {
+	if val != nil {
+		h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+	}
+}
+
 func (h *hasher) HashColumnID(val opt.ColumnID) {
 	h.HashUint64(uint64(val))
 }
@@ -910,6 +916,10 @@ func (h *hasher) IsStatementEqual(l, r tree.Statement) bool {
 	return l == r
 }
 
+func (h *hasher) IsRoutineProgramEqual(l, r tree.RoutineProgram) bool {
+	return l == r
+}
+
 func (h *hasher) IsColumnIDEqual(l, r opt.ColumnID) bool {
 	return l == r
 }
@@ -1264,6 +1264,42 @@ func (b *logicalPropsBuilder) buildMax1RowProps(max1Row *Max1RowExpr, rel *props
 	}
 }
 
+func (b *logicalPropsBuilder) buildBarrierProps(barrier *BarrierExpr, rel *props.Relational) {
+	BuildSharedProps(barrier, &rel.Shared, b.evalCtx)
+
+	inputProps := barrier.Input.Relational()
+
+	// Output Columns
+	// --------------
+	// Output columns are inherited from input.
+	rel.OutputCols = inputProps.OutputCols
+
+	// Not Null Columns
+	// ----------------
+	// Not null columns are inherited from input.
+	rel.NotNullCols = inputProps.NotNullCols
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// Functional dependencies are inherited from input.
+	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
+
+	// Cardinality
+	// -----------
+	// Barrier returns exactly the rows of its input.
+	rel.Cardinality = inputProps.Cardinality
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildBarrier(barrier, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *props.Relational) {
 	BuildSharedProps(ord, &rel.Shared, b.evalCtx)
 
@@ -1794,9 +1830,9 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 		allCols.Add(tabID.ColumnID(i))
 	}
 	var excludeColumn opt.ColumnID
-	if tab.IsVirtualTable() {
-		// Don't advertise any functional dependencies for virtual table primary
-		// keys, since they are composed of a fake, unusable column.
+	if tab.IsVirtualTable() || tab.IsForeignTable() {
+		// Don't advertise any functional dependencies for virtual or foreign table
+		// primary keys, since they are composed of a fake, unusable column.
 		dummyPKOrd := tab.Index(cat.PrimaryIndex).Column(0).Ordinal()
 		excludeColumn = tabID.ColumnID(dummyPKOrd)
 	}
@@ -1861,6 +1897,13 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.IsExclusion() {
+			// Exclusion constraints don't guarantee that their columns form a key,
+			// since rows with equal values may not conflict according to the
+			// constraint's operators (e.g. "WITH <>").
+			continue
+		}
+
 		// If any of the columns are nullable, add a lax key FD. Otherwise, add a
 		// strict key.
 		var keyCols opt.ColSet
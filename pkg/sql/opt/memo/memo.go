@@ -13,6 +13,7 @@ package memo
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
@@ -162,6 +163,11 @@ type Memo struct {
 	useImprovedDisjunctionStats            bool
 	useLimitOrderingForStreamingGroupBy    bool
 
+	// txnIsoLevel is the isolation level of the transaction in which the memo
+	// was built. Mutations are planned differently under READ COMMITTED
+	// isolation.
+	txnIsoLevel isolation.Level
+
 	// curRank is the highest currently in-use scalar expression rank.
 	curRank opt.ScalarRank
 
@@ -217,6 +223,7 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		allowOrdinalColumnReferences:           evalCtx.SessionData().AllowOrdinalColumnReferences,
 		useImprovedDisjunctionStats:            evalCtx.SessionData().OptimizerUseImprovedDisjunctionStats,
 		useLimitOrderingForStreamingGroupBy:    evalCtx.SessionData().OptimizerUseLimitOrderingForStreamingGroupBy,
+		txnIsoLevel:                            txnIsoLevel(evalCtx),
 	}
 	m.metadata.Init()
 	m.logPropsBuilder.init(ctx, evalCtx, m)
@@ -360,6 +367,11 @@ func (m *Memo) IsStale(
 		return true, nil
 	}
 
+	// Memo is stale if the isolation level of the transaction has changed.
+	if m.txnIsoLevel != txnIsoLevel(evalCtx) {
+		return true, nil
+	}
+
 	// Memo is stale if the fingerprint of any object in the memo's metadata has
 	// changed, or if the current user no longer has sufficient privilege to
 	// access the object.
@@ -371,6 +383,15 @@ func (m *Memo) IsStale(
 	return false, nil
 }
 
+// txnIsoLevel returns the isolation level of the transaction in the given
+// context.
+func txnIsoLevel(evalCtx *eval.Context) isolation.Level {
+	if evalCtx.Txn == nil {
+		return isolation.Serializable
+	}
+	return evalCtx.Txn.IsoLevel()
+}
+
 // InternPhysicalProps adds the given physical props to the memo if they haven't
 // yet been added. If the same props was added previously, then return a pointer
 // to the previously added props. This allows interned physical props to be
@@ -462,6 +462,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.Max1RowOp:
 		return sb.colStatMax1Row(colSet, e.(*Max1RowExpr))
 
+	case opt.BarrierOp:
+		return sb.colStatBarrier(colSet, e.(*BarrierExpr))
+
 	case opt.OrdinalityOp:
 		return sb.colStatOrdinality(colSet, e.(*OrdinalityExpr))
 
@@ -2330,6 +2333,37 @@ func (sb *statisticsBuilder) colStatMax1Row(
 	return colStat
 }
 
+// +---------+
+// | Barrier |
+// +---------+
+
+func (sb *statisticsBuilder) buildBarrier(barrier *BarrierExpr, relProps *props.Relational) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(barrier)
+
+	inputStats := barrier.Input.Relational().Statistics()
+
+	s.RowCount = inputStats.RowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatBarrier(
+	colSet opt.ColSet, barrier *BarrierExpr,
+) *props.ColumnStatistic {
+	s := barrier.Relational().Statistics()
+	colStat, _ := s.ColStats.Add(colSet)
+
+	inputColStat := sb.colStatFromChild(colSet, barrier, 0 /* childIdx */)
+	colStat.DistinctCount = inputColStat.DistinctCount
+	colStat.NullCount = inputColStat.NullCount
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +------------+
 // | Row Number |
 // +------------+
@@ -4534,8 +4568,8 @@ func isEqualityWithTwoVars(cond opt.ScalarExpr) bool {
 }
 
 // isInvertedJoinCond returns true if the given condition is either an index-
-// accelerated geospatial function, a bounding box operation, or a contains
-// operation with two variable arguments.
+// accelerated geospatial function, a bounding box operation, a contains
+// operation, or a text search match operation with two variable arguments.
 func isInvertedJoinCond(cond opt.ScalarExpr) bool {
 	switch t := cond.(type) {
 	case *FunctionExpr:
@@ -4543,7 +4577,7 @@ func isInvertedJoinCond(cond opt.ScalarExpr) bool {
 			return t.Args[0].Op() == opt.VariableOp && t.Args[1].Op() == opt.VariableOp
 		}
 
-	case *BBoxIntersectsExpr, *BBoxCoversExpr, *ContainsExpr:
+	case *BBoxIntersectsExpr, *BBoxCoversExpr, *ContainsExpr, *TSMatchesExpr:
 		return t.Child(0).Op() == opt.VariableOp && t.Child(1).Op() == opt.VariableOp
 	}
 
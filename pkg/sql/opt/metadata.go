@@ -16,6 +16,7 @@ import (
 	"math/bits"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -110,6 +111,11 @@ type Metadata struct {
 	// we want to verify the resolution of both names.
 	deps []mdDep
 
+	// routineDeps stores the OIDs of the user-defined functions invoked by the
+	// query with the privileges of the current user, which must have the EXECUTE
+	// privilege on them.
+	routineDeps []oid.Oid
+
 	// views stores the list of referenced views. This information is only
 	// needed for EXPLAIN (opt, env).
 	views []cat.View
@@ -205,7 +211,8 @@ func (md *Metadata) Init() {
 // expression.
 func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 	if len(md.schemas) != 0 || len(md.cols) != 0 || len(md.tables) != 0 ||
-		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.views) != 0 ||
+		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.routineDeps) != 0 ||
+		len(md.views) != 0 ||
 		len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
@@ -244,6 +251,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 
 	md.sequences = append(md.sequences, from.sequences...)
 	md.deps = append(md.deps, from.deps...)
+	md.routineDeps = append(md.routineDeps, from.routineDeps...)
 	md.views = append(md.views, from.views...)
 	md.currUniqueID = from.currUniqueID
 
@@ -282,6 +290,19 @@ func (md *Metadata) AddDependency(name MDDepName, ds cat.DataSource, priv privil
 	})
 }
 
+// AddRoutineDependency tracks a user-defined function invoked by the query with
+// the privileges of the current user. If the Memo using this metadata is
+// cached, then a call to CheckDependencies verifies that the user still has the
+// EXECUTE privilege on the function.
+func (md *Metadata) AddRoutineDependency(funcOID oid.Oid) {
+	for _, o := range md.routineDeps {
+		if o == funcOID {
+			return
+		}
+	}
+	md.routineDeps = append(md.routineDeps, funcOID)
+}
+
 // CheckDependencies resolves (again) each data source on which this metadata
 // depends, in order to check that all data source names resolve to the same
 // objects, and that the user still has sufficient privileges to access the
@@ -330,6 +351,16 @@ func (md *Metadata) CheckDependencies(
 			privs &= ^(1 << priv)
 		}
 	}
+	// Check that the user can still execute the user-defined functions.
+	for _, funcOID := range md.routineDeps {
+		if err := catalog.CheckExecutionPrivilege(ctx, funcOID, username.SQLUsername{}); err != nil {
+			// Handle when the function no longer exists.
+			if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+				return false, nil
+			}
+			return false, err
+		}
+	}
 	// Check that all of the user defined types present have not changed.
 	for _, typ := range md.AllUserDefinedTypes() {
 		toCheck, err := catalog.ResolveTypeByOID(ctx, typ.Oid())
@@ -382,7 +382,7 @@ func (c *CustomFuncs) TryAddKeyToScan(in memo.RelExpr) (_ memo.RelExpr, ok bool)
 		private := scan.ScanPrivate
 		tableID := private.Table
 		table := c.f.Metadata().Table(tableID)
-		if !table.IsVirtualTable() {
+		if !table.IsVirtualTable() && !table.IsForeignTable() {
 			keyCols := c.PrimaryKeyCols(tableID)
 			private.Cols = private.Cols.Union(keyCols)
 			return c.f.ConstructScan(&private), true
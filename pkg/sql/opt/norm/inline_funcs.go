@@ -411,6 +411,9 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 //     leak-proof.
 //  2. It has a single statement.
 //  3. Its arguments are non-volatile expressions.
+//  4. It is not executed as a different user or with different session
+//     variable values than the caller.
+//  5. It is not a PL/pgSQL function.
 //
 // UDFs with mutations (INSERT, UPDATE, UPSERT, DELETE) cannot be inlined, but
 // we do not need an explicit check for this because immutable UDFs cannot
@@ -426,6 +429,9 @@ func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFPri
 	if udfp.Volatility == volatility.Volatile || len(udfp.Body) > 1 {
 		return false
 	}
+	if udfp.SessionOverrides != nil || udfp.Program != nil {
+		return false
+	}
 	for i := range args {
 		var p props.Shared
 		memo.BuildSharedProps(args[i], &p, c.f.EvalContext())
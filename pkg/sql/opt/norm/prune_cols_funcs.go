@@ -78,6 +78,11 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if t := private.AfterTriggers; t != nil {
+		// The AFTER triggers read the OLD and NEW rows from the buffered input.
+		cols.UnionWith(t.OldValues.ToSet())
+		cols.UnionWith(t.NewValues.ToSet())
+	}
 
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
@@ -661,6 +666,15 @@ func DerivePruneCols(e memo.RelExpr, disabledRules intsets.Fast) opt.ColSet {
 			relProps.Rule.PruneCols.DifferenceWith(w.ScalarProps().OuterCols)
 		}
 
+	case opt.BarrierOp:
+		if disabledRules.Contains(int(opt.PruneBarrierCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Barrier passes through its input unchanged, so it has the same pruning
+		// characteristics as its input.
+		relProps.Rule.PruneCols = DerivePruneCols(e.(*memo.BarrierExpr).Input, disabledRules)
+
 	case opt.WithOp:
 		if disabledRules.Contains(int(opt.PruneWithCols)) {
 			// Avoid rule cycles.
@@ -417,3 +417,68 @@ func (c *CustomFuncs) addConjuncts(
 	}
 	return filters, true
 }
+
+// IsLeakproofFilter returns true if the given filter is bound by the given
+// columns and only compares columns with constants using operators that
+// cannot raise an error or have side effects. Evaluating such a filter
+// reveals nothing about a row other than whether it satisfies the filter.
+func (c *CustomFuncs) IsLeakproofFilter(item *memo.FiltersItem, cols opt.ColSet) bool {
+	if !c.IsBoundBy(item, cols) || !item.ScalarProps().VolatilitySet.IsLeakproof() {
+		return false
+	}
+	return c.isLeakproofComparison(item.Condition)
+}
+
+// isLeakproofComparison returns true if the given expression is a comparison
+// between columns and constants, or a conjunction, disjunction or negation of
+// such comparisons.
+func (c *CustomFuncs) isLeakproofComparison(e opt.ScalarExpr) bool {
+	switch t := e.(type) {
+	case *memo.AndExpr:
+		return c.isLeakproofComparison(t.Left) && c.isLeakproofComparison(t.Right)
+	case *memo.OrExpr:
+		return c.isLeakproofComparison(t.Left) && c.isLeakproofComparison(t.Right)
+	case *memo.NotExpr:
+		return c.isLeakproofComparison(t.Input)
+	case *memo.RangeExpr:
+		return c.isLeakproofComparison(t.And)
+	}
+	if !opt.IsComparisonOp(e) {
+		return false
+	}
+	for i := 0; i < 2; i++ {
+		child := e.Child(i).(opt.ScalarExpr)
+		if child.Op() != opt.VariableOp && !c.IsConstValueOrGroupOfConstValues(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// ExtractLeakproofFilters returns the filters for which IsLeakproofFilter
+// returns true.
+func (c *CustomFuncs) ExtractLeakproofFilters(
+	filters memo.FiltersExpr, cols opt.ColSet,
+) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if c.IsLeakproofFilter(&filters[i], cols) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
+// ExtractNonLeakproofFilters is the opposite of ExtractLeakproofFilters. It
+// returns the filters for which IsLeakproofFilter returns false.
+func (c *CustomFuncs) ExtractNonLeakproofFilters(
+	filters memo.FiltersExpr, cols opt.ColSet,
+) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if !c.IsLeakproofFilter(&filters[i], cols) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
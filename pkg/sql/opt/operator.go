@@ -146,6 +146,7 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
 	TSMatchesOp:      treecmp.TSMatches,
+	AdjacentOp:       treecmp.Adjacent,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -330,7 +331,7 @@ func AggregateIgnoresNulls(op Operator) bool {
 		return true
 
 	case ArrayAggOp, ConcatAggOp, ConstAggOp, CountRowsOp, FirstAggOp, JsonAggOp,
-		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -358,6 +359,11 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 	case CountOp, CountRowsOp, RegressionCountOp:
 		return false
 
+	case UserDefinedAggOp:
+		// The result of a user-defined aggregate on empty input is the result of
+		// its final function on the initial state, which may not be NULL.
+		return false
+
 	default:
 		panic(errors.AssertionFailedf("unhandled op %s", redact.Safe(op)))
 	}
@@ -384,7 +390,7 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
-		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp:
+		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp, UserDefinedAggOp:
 		// These aggregations can return NULL even with non-null input values.
 		return false
 
@@ -435,7 +441,7 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		SqrDiffOp, STCollectOp, StdDevOp, StringAggOp, VarianceOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -457,7 +463,7 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		VarPopOp, JsonObjectAggOp, JsonbObjectAggOp, STCollectOp, CovarPopOp,
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
-		RegressionSYYOp, RegressionCountOp:
+		RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -70,6 +71,13 @@ type Builder struct {
 	// This is used when re-preparing invalidated queries.
 	KeepPlaceholders bool
 
+	// ProcedureParams and ProcedureArgs are control knobs: if set, the statement
+	// is part of the body of a procedure invoked by a CALL statement, and
+	// references to the procedure's parameters, either by name or by ordinal
+	// placeholder, are replaced with the given argument values.
+	ProcedureParams tree.ParamTypes
+	ProcedureArgs   tree.Datums
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
@@ -108,6 +116,11 @@ type Builder struct {
 	// be used with care.
 	skipSelectPrivilegeChecks bool
 
+	// If set, privileges are checked for this user instead of the current user.
+	// This is used when building the body of a SECURITY DEFINER function, which
+	// is executed with the privileges of the function owner.
+	privilegeUser username.SQLUsername
+
 	// views contains a cache of views that have already been parsed, in case they
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
@@ -124,6 +137,15 @@ type Builder struct {
 	// are disabled and only statements whitelisted are allowed.
 	insideFuncDef bool
 
+	// If set, we are processing a procedure definition. This is only set if
+	// insideFuncDef is also set. Procedures may contain mutation statements in
+	// addition to the statements allowed in functions.
+	insideProcDef bool
+
+	// procedureParamCols is the set of columns that represent the parameters of
+	// the procedure whose body is being built. See ProcedureParams.
+	procedureParamCols opt.ColSet
+
 	// If set, we are collecting view dependencies in schemaDeps. This can only
 	// happen inside view/function definitions.
 	//
@@ -245,6 +267,9 @@ func (b *Builder) buildStmtAtRoot(stmt tree.Statement, desiredTypes []*types.T)
 	// always start with an empty scope.
 	inScope := b.allocScope()
 	inScope.atRoot = true
+	if b.ProcedureArgs != nil {
+		b.addProcedureParamCols(inScope)
+	}
 
 	// Save any CTEs above the boundary.
 	prevCTEs := b.ctes
@@ -256,6 +281,23 @@ func (b *Builder) buildStmtAtRoot(stmt tree.Statement, desiredTypes []*types.T)
 	return outScope
 }
 
+// addProcedureParamCols adds columns representing the parameters of the
+// procedure being called to the given scope, so that references to them can be
+// resolved. Each reference is later replaced with the corresponding argument
+// value in buildScalar.
+func (b *Builder) addProcedureParamCols(inScope *scope) {
+	// The argument values are inlined into the memo, so it cannot be reused for
+	// another invocation of the procedure.
+	b.DisableMemoReuse = true
+	for i := range b.ProcedureParams {
+		param := &b.ProcedureParams[i]
+		colName := funcParamColName(tree.Name(param.Name), i)
+		col := b.synthesizeColumn(inScope, colName, param.Typ, nil /* expr */, nil /* scalar */)
+		col.setParamOrd(i)
+		b.procedureParamCols.Add(col.id)
+	}
+}
+
 // buildStmt builds a set of memo groups that represent the given SQL
 // statement.
 //
@@ -281,8 +323,8 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
-			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable,
+			*tree.CreateView, *tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateFunction:
 			panic(pgerror.Newf(
@@ -293,10 +335,14 @@ func (b *Builder) buildStmt(
 
 	// An allowlist of statements supported for user defined function.
 	if b.insideFuncDef {
-		switch stmt := stmt.(type) {
-		case *tree.Select:
-		case tree.SelectStatement:
-		default:
+		allowed := false
+		switch stmt.(type) {
+		case *tree.Select, tree.SelectStatement:
+			allowed = true
+		case *tree.Delete, *tree.Insert, *tree.Update:
+			allowed = b.insideProcDef
+		}
+		if !allowed {
 			panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition", stmt.StatementTag()))
 		}
 	}
@@ -323,6 +369,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -389,6 +440,9 @@ func (b *Builder) buildStmt(
 	case *tree.Export:
 		return b.buildExport(stmt, inScope)
 
+	case *tree.CopyTo:
+		return b.buildCopyTo(stmt, inScope)
+
 	default:
 		// See if this statement can be rewritten to another statement using the
 		// delegate functionality.
@@ -0,0 +1,101 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// buildCopyTo builds a COPY ... TO STDOUT statement. The rows to copy are
+// produced by the statement's query or, if there is none, by a SELECT of the
+// requested columns of the table. Encoding the rows in the requested format is
+// the responsibility of the pgwire layer.
+func (b *Builder) buildCopyTo(copyTo *tree.CopyTo, inScope *scope) (outScope *scope) {
+	checkCopyToOptions(&copyTo.Options)
+
+	if copyTo.Statement != nil {
+		if copyTo.Statement.StatementReturnType() != tree.Rows {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"COPY query must have a RETURNING clause",
+			))
+		}
+		return b.buildStmt(copyTo.Statement, nil /* desiredTypes */, inScope)
+	}
+
+	exprs := tree.SelectExprs{tree.StarSelectExpr()}
+	if len(copyTo.Columns) > 0 {
+		exprs = make(tree.SelectExprs, len(copyTo.Columns))
+		for i := range copyTo.Columns {
+			exprs[i].Expr = &tree.ColumnItem{ColumnName: copyTo.Columns[i]}
+		}
+	}
+	table := copyTo.Table
+	sel := &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  tree.From{Tables: tree.TableExprs{&table}},
+		},
+	}
+	return b.buildStmt(sel, nil /* desiredTypes */, inScope)
+}
+
+// checkCopyToOptions validates the options of a COPY ... TO STDOUT statement.
+func checkCopyToOptions(opts *tree.CopyOptions) {
+	if opts.Destination != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"DESTINATION cannot be specified with COPY TO",
+		))
+	}
+	if opts.CopyFormat == tree.CopyFormatBinary {
+		switch {
+		case opts.Header:
+			panic(pgerror.Newf(pgcode.FeatureNotSupported, "HEADER unsupported in BINARY format"))
+		case opts.Delimiter != nil:
+			panic(pgerror.Newf(pgcode.Syntax, "DELIMITER unsupported in BINARY format"))
+		case opts.Null != nil:
+			panic(pgerror.Newf(pgcode.Syntax, "NULL unsupported in BINARY format"))
+		}
+	}
+	if opts.Delimiter != nil {
+		if delim := copyToOptionString(opts.Delimiter, "DELIMITER"); len(delim) != 1 {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"delimiter must be a single-byte character",
+			))
+		}
+	}
+	if opts.Null != nil {
+		copyToOptionString(opts.Null, "NULL")
+	}
+	if opts.Escape != nil {
+		if len(opts.Escape.RawString()) != 1 {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"ESCAPE must be a single one-byte character",
+			))
+		}
+		if opts.CopyFormat != tree.CopyFormatCSV {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"ESCAPE can only be specified for CSV",
+			))
+		}
+	}
+}
+
+// copyToOptionString returns the value of a COPY ... TO STDOUT option, which
+// must be a string constant.
+func copyToOptionString(expr tree.Expr, name string) string {
+	s, ok := expr.(*tree.StrVal)
+	if !ok {
+		panic(pgerror.Newf(pgcode.Syntax, "%s must be a string constant", name))
+	}
+	return s.RawString()
+}
@@ -46,11 +46,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	b.semaCtx.FunctionResolver = nil
 
 	b.insideFuncDef = true
+	b.insideProcDef = cf.IsProcedure
 	b.trackSchemaDeps = true
 	// Make sure datasource names are qualified.
 	b.qualifyDataSourceNamesInAST = true
 	defer func() {
 		b.insideFuncDef = false
+		b.insideProcDef = false
 		b.trackSchemaDeps = false
 		b.schemaDeps = nil
 		b.schemaTypeDeps = intsets.Fast{}
@@ -67,12 +69,21 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	if err := tree.ValidateFuncOptions(cf.Options); err != nil {
 		panic(err)
 	}
+	if cf.IsProcedure {
+		for _, option := range cf.Options {
+			switch option.(type) {
+			case tree.FunctionVolatility, tree.FunctionLeakproof, tree.FunctionNullInputBehavior:
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition, "invalid attribute in procedure definition"))
+			}
+		}
+	}
 
 	// Look for function body string from function options.
 	// Note that function body can be an empty string.
 	funcBodyFound := false
 	languageFound := false
 	var funcBodyStr string
+	var language tree.FunctionLanguage
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.FunctionBodyStr:
@@ -80,6 +91,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			funcBodyStr = string(opt)
 		case tree.FunctionLanguage:
 			languageFound = true
+			language = opt
 		}
 	}
 
@@ -89,6 +101,9 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	if !languageFound {
 		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "no language specified"))
 	}
+	if language == tree.FunctionLangPLpgSQL && cf.IsProcedure {
+		panic(unimplemented.New("procedures", "PL/pgSQL procedures are not supported"))
+	}
 
 	// Track the dependencies in the arguments, return type, and statements in
 	// the function body.
@@ -96,9 +111,11 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	var typeDeps opt.SchemaTypeDeps
 
 	// bodyScope is the base scope for each statement in the body. We add the
-	// named parameters to the scope so that references to them in the body can
-	// be resolved.
+	// named input parameters to the scope so that references to them in the
+	// body can be resolved. OUT parameters cannot be referenced.
 	bodyScope := b.allocScope()
+	inParamOrd := 0
+	var inParams tree.ParamTypes
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -106,11 +123,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			panic(err)
 		}
 
-		// Add the parameter to the base scope of the body.
-		paramColName := funcParamColName(param.Name, i)
-		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
-		col.setParamOrd(i)
-
 		// Collect the user defined type dependencies.
 		typeIDs, err := typedesc.GetTypeDescriptorClosure(typ)
 		if err != nil {
@@ -119,10 +131,39 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		for typeID := range typeIDs {
 			typeDeps.Add(int(typeID))
 		}
+
+		if cf.IsProcedure && param.IsOutParam() {
+			panic(unimplemented.New("procedures", "OUT and INOUT parameters are not supported in procedures"))
+		}
+		if language == tree.FunctionLangPLpgSQL && param.IsOutParam() {
+			panic(unimplemented.New("plpgsql", "OUT and INOUT parameters are not supported in PL/pgSQL functions"))
+		}
+		if param.Class == tree.FunctionParamVariadic {
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition, "VARIADIC parameter must be an array"))
+			}
+			for j := i + 1; j < len(cf.Params); j++ {
+				if cf.Params[j].IsInParam() {
+					panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+						"VARIADIC parameter must be the last input parameter"))
+				}
+			}
+		}
+		if !param.IsInParam() {
+			continue
+		}
+
+		// Add the parameter to the base scope of the body.
+		paramColName := funcParamColName(param.Name, inParamOrd)
+		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
+		col.setParamOrd(inParamOrd)
+		inParamOrd++
+		inParams = append(inParams, tree.ParamType{Name: string(param.Name), Typ: typ})
 	}
 
-	// Collect the user defined type dependency of the return type.
-	funcReturnType, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
+	// Collect the user defined type dependency of the return type, which may
+	// be derived from the OUT parameters.
+	funcReturnType, err := cf.ResolveReturnType(b.ctx, b.semaCtx.TypeResolver)
 	if err != nil {
 		panic(err)
 	}
@@ -134,6 +175,26 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		typeDeps.Add(int(typeID))
 	}
 
+	// The body of a PL/pgSQL function is built to validate it and to collect
+	// its dependencies. It is stored as written, so references in the body are
+	// not qualified.
+	if language == tree.FunctionLangPLpgSQL {
+		b.buildPLpgSQL(funcBodyStr, inParams, funcReturnType)
+		deps = append(deps, b.schemaDeps...)
+		typeDeps.UnionWith(b.schemaTypeDeps)
+
+		outScope = b.allocScope()
+		outScope.expr = b.factory.ConstructCreateFunction(
+			&memo.CreateFunctionPrivate{
+				Schema:   schID,
+				Syntax:   cf,
+				Deps:     deps,
+				TypeDeps: typeDeps,
+			},
+		)
+		return outScope
+	}
+
 	// Parse the function body.
 	stmts, err := parser.Parse(funcBodyStr)
 	if err != nil {
@@ -143,6 +204,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	// Validate each statement and collect the dependencies.
 	fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
 	for i, stmt := range stmts {
+		if cf.IsProcedure && isProcedureTxnControl(stmt.AST) {
+			// Transaction control statements in procedures are executed by the
+			// CALL statement, so there is nothing to build.
+			formatFuncBodyStmt(fmtCtx, stmt.AST, i > 0 /* newLine */)
+			continue
+		}
 		stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
 
 		// Format the statements with qualified datasource names.
@@ -187,6 +254,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	return outScope
 }
 
+// isProcedureTxnControl returns true if the given statement is a transaction
+// control statement that is allowed in the body of a procedure.
+func isProcedureTxnControl(stmt tree.Statement) bool {
+	switch stmt.(type) {
+	case *tree.CommitTransaction, *tree.RollbackTransaction:
+		return true
+	}
+	return false
+}
+
 func formatFuncBodyStmt(fmtCtx *tree.FmtCtx, ast tree.Statement, newLine bool) {
 	if newLine {
 		fmtCtx.WriteString("\n")
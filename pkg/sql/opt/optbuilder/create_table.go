@@ -18,12 +18,16 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 // buildCreateTable constructs a CreateTable operator based on the CREATE TABLE
 // statement.
 func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outScope *scope) {
 	b.DisableMemoReuse = true
+	if ct.PartitionOf != nil {
+		return b.buildCreateTablePartitionOf(ct, inScope)
+	}
 	isTemp := resolveTemporaryStatus(&ct.Table, ct.Persistence)
 	if isTemp {
 		// Postgres allows using `pg_temp` as an alias for the session specific temp
@@ -109,3 +113,28 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 	)
 	return outScope
 }
+
+// buildCreateTablePartitionOf builds a CREATE TABLE ... PARTITION OF statement.
+// No new table is created. Instead, the statement is executed as
+//
+//	ALTER TABLE parent ATTACH PARTITION child FOR VALUES ...
+//
+// which adds a partition named after the child to the primary index of the
+// parent table.
+func (b *Builder) buildCreateTablePartitionOf(ct *tree.CreateTable, inScope *scope) *scope {
+	if ct.IfNotExists {
+		panic(unimplemented.New("CREATE TABLE ... PARTITION OF",
+			"CREATE TABLE IF NOT EXISTS ... PARTITION OF is not supported"))
+	}
+	if ct.Persistence.IsTemporary() {
+		panic(unimplemented.New("CREATE TABLE ... PARTITION OF",
+			"temporary partitions are not supported"))
+	}
+	return b.tryBuildOpaque(&tree.AlterTable{
+		Table: ct.PartitionOf.Parent.ToUnresolvedObjectName(),
+		Cmds: tree.AlterTableCmds{&tree.AlterTableAttachPartition{
+			Partition: ct.Table,
+			Bound:     ct.PartitionOf.Bound,
+		}},
+	}, inScope)
+}
@@ -49,7 +49,14 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		maybePanicOnUnknownFunction("view query")
 	}()
 
-	defScope := b.buildStmtAtRoot(cv.AsSource, nil /* desiredTypes */)
+	source := cv.AsSource
+	if cv.Recursive {
+		if len(cv.ColumnNames) == 0 {
+			panic(sqlerrors.NewSyntaxErrorf("CREATE RECURSIVE VIEW requires a column list"))
+		}
+		source = makeRecursiveViewSelect(cv)
+	}
+	defScope := b.buildStmtAtRoot(source, nil /* desiredTypes */)
 
 	p := defScope.makePhysicalProps().Presentation
 	if len(cv.ColumnNames) != 0 {
@@ -93,7 +100,7 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 			Replace:      cv.Replace,
 			Persistence:  cv.Persistence,
 			Materialized: cv.Materialized,
-			ViewQuery:    tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			ViewQuery:    tree.AsStringWithFlags(source, tree.FmtParsable),
 			Columns:      p,
 			Deps:         b.schemaDeps,
 			TypeDeps:     b.schemaTypeDeps,
@@ -103,6 +110,37 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	return outScope
 }
 
+// makeRecursiveViewSelect returns the query of a recursive view, desugared
+// into a recursive CTE named after the view, like Postgres does. That is,
+//
+//	CREATE RECURSIVE VIEW v (a, b) AS <query>
+//
+// is equivalent to
+//
+//	CREATE VIEW v AS WITH RECURSIVE v (a, b) AS (<query>) SELECT a, b FROM v
+func makeRecursiveViewSelect(cv *tree.CreateView) *tree.Select {
+	cteName := tree.Name(cv.Name.Object())
+	cols := make(tree.ColumnDefList, len(cv.ColumnNames))
+	exprs := make(tree.SelectExprs, len(cv.ColumnNames))
+	for i, name := range cv.ColumnNames {
+		cols[i].Name = name
+		exprs[i].Expr = tree.NewUnresolvedName(string(name))
+	}
+	return &tree.Select{
+		With: &tree.With{
+			Recursive: true,
+			CTEList: []*tree.CTE{{
+				Name: tree.AliasClause{Alias: cteName, Cols: cols},
+				Stmt: cv.AsSource,
+			}},
+		},
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  tree.From{Tables: tree.TableExprs{tree.NewUnqualifiedTableName(cteName)}},
+		},
+	}
+}
+
 func maybePanicOnUnknownFunction(target string) {
 	// TODO(chengxiong,mgartner): this is a hack to disallow UDF usage in view and
 	// we will need to lift this hack when we plan to allow it.
@@ -0,0 +1,127 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// checkDomainFuncName is the name of the builtin function used to enforce the
+// constraints of a domain.
+const checkDomainFuncName = "crdb_internal.check_domain"
+
+// buildDomainCast builds a cast of the given scalar expression to the given
+// domain type. The input is first cast to the base type of the domain, using
+// an assignment cast if assignment is true. The result is then checked
+// against the NOT NULL and CHECK constraints of the domain, and the returned
+// expression errors if any of them are violated.
+//
+// The returned expression has the following form:
+//
+//	CASE WHEN
+//	  crdb_internal.check_domain(val IS NOT NULL, 'd', '') AND
+//	  crdb_internal.check_domain((<check>) IS NOT false, 'd', 'd_check')
+//	THEN val END::d
+//
+// where val is the input cast to the base type, and references to VALUE in
+// the CHECK constraint are replaced with val.
+//
+// The constraints are checked even if the input is already of the domain
+// type, because placeholders and values decoded from the wire are typed as
+// the domain without having been checked against it.
+func (b *Builder) buildDomainCast(
+	input opt.ScalarExpr, domain *types.T, assignment bool,
+) opt.ScalarExpr {
+	// Track the domain so that cached plans are invalidated when its
+	// constraints change.
+	b.factory.Metadata().AddUserDefinedType(domain)
+
+	base := domain.DomainBaseType()
+	val := input
+	if val.DataType().Identical(domain) {
+		val = b.factory.ConstructCast(val, base)
+	} else if !val.DataType().Identical(base) {
+		if assignment {
+			val = b.factory.ConstructAssignmentCast(val, base)
+		} else {
+			val = b.factory.ConstructCast(val, base)
+		}
+	}
+
+	data := domain.TypeMeta.DomainData
+	if data == nil || (!data.NotNull && len(data.Checks) == 0) {
+		return b.factory.ConstructCast(val, domain)
+	}
+
+	props, overloads := builtinsregistry.GetBuiltinProperties(checkDomainFuncName)
+	private := &memo.FunctionPrivate{
+		Name:       checkDomainFuncName,
+		Typ:        types.Bool,
+		Properties: props,
+		Overload:   &overloads[0],
+	}
+	domainName := tree.NewDString(domain.Name())
+	var cond opt.ScalarExpr
+	addCheck := func(ok opt.ScalarExpr, constraint string) {
+		check := b.factory.ConstructFunction(memo.ScalarListExpr{
+			ok,
+			b.factory.ConstructConstVal(domainName, types.String),
+			b.factory.ConstructConstVal(tree.NewDString(constraint), types.String),
+		}, private)
+		if cond == nil {
+			cond = check
+		} else {
+			cond = b.factory.ConstructAnd(cond, check)
+		}
+	}
+
+	if data.NotNull {
+		addCheck(b.factory.ConstructIsNot(val, memo.NullSingleton), "" /* constraint */)
+	}
+	if len(data.Checks) > 0 {
+		// Build the CHECK constraints with a single column named VALUE in
+		// scope, and then replace references to the column with the value.
+		checkScope := b.allocScope()
+		valueCol := b.synthesizeColumn(checkScope, scopeColName("value"), base, nil /* expr */, nil /* scalar */)
+		var replace norm.ReplaceFunc
+		replace = func(e opt.Expr) opt.Expr {
+			if v, ok := e.(*memo.VariableExpr); ok && v.Col == valueCol.id {
+				return val
+			}
+			return b.factory.Replace(e, replace)
+		}
+		for i := range data.Checks {
+			expr, err := parser.ParseExpr(data.Checks[i].Expr)
+			if err != nil {
+				panic(err)
+			}
+			texpr := checkScope.resolveAndRequireType(expr, types.Bool)
+			check := b.buildScalar(texpr, checkScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+			check = replace(check).(opt.ScalarExpr)
+			// As with table CHECK constraints, a NULL result satisfies the
+			// constraint.
+			addCheck(b.factory.ConstructIsNot(check, memo.FalseSingleton), data.Checks[i].Name)
+		}
+	}
+
+	checked := b.factory.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{b.factory.ConstructWhen(cond, val)},
+		b.factory.ConstructNull(base),
+	)
+	return b.factory.ConstructCast(checked, domain)
+}
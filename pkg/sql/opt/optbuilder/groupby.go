@@ -45,7 +45,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -100,8 +102,55 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is non-nil if the GROUP BY clause contains GROUPING SETS,
+	// ROLLUP or CUBE.
+	groupingSets *groupingSets
 }
 
+// groupingSets describes the grouping sets of a GROUP BY clause. A query with
+// multiple grouping sets is built as a UNION ALL of one aggregation per
+// grouping set. The pre-projection of the aggregation is bound to a With
+// expression, so that the input is only computed once, and each aggregation
+// groups a scan of it by the grouping columns of its set. The grouping columns
+// which are not part of a set are NULL in the rows of that set, and an
+// additional column holds the ordinal of the grouping set of each row, which
+// is used by the GROUPING function. For example:
+//
+//	SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//	WITH input AS (SELECT a, b FROM t)
+//	SELECT a, b, count(*), 0 AS set FROM input GROUP BY a, b
+//	UNION ALL
+//	SELECT a, NULL, count(*), 1 FROM input GROUP BY a
+//	UNION ALL
+//	SELECT NULL, NULL, count(*), 2 FROM input
+//
+// The aggregation of an empty grouping set, like the last one above, is a
+// scalar aggregation, so it produces a row even if the input is empty.
+type groupingSets struct {
+	// col is the column which holds the ordinal of the grouping set of each
+	// row.
+	col scopeColumn
+
+	// numSets is the number of grouping sets.
+	numSets int
+
+	// ords maps the symbolic representation of each grouping expression to
+	// the ordinals of the grouping sets which contain it.
+	ords map[string]intsets.Fast
+
+	// sets contains the grouping columns of each grouping set, indexed by its
+	// ordinal.
+	sets []opt.ColSet
+}
+
+// maxGroupingSets is the maximum number of grouping sets of a GROUP BY clause,
+// as in Postgres.
+const maxGroupingSets = 4096
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -250,6 +299,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if a.def.Overload != nil && a.def.Overload.UDA != nil {
+		// The result of a user-defined aggregate can depend on the order in
+		// which its state transition function is applied.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "concat_agg", "string_agg", "json_agg", "jsonb_agg", "json_object_agg", "jsonb_object_agg",
 		"st_makeline", "st_collect", "st_memcollect":
@@ -384,7 +438,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -425,16 +479,42 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		g.aggInScope.copyOrdering(fromScope)
 	}
 
-	// Construct the pre-projection, which renders the grouping columns and the
-	// aggregate arguments, as well as any additional order by columns.
-	b.constructProjectForScope(fromScope, g.aggInScope)
-
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		ordering := g.aggInScope.ordering
+		g.aggOutScope.expr = b.constructGroupingSets(g, fromScope, func(
+			input memo.RelExpr, groupingColSet opt.ColSet, colMap opt.ColMap,
+		) (memo.RelExpr, opt.ColList) {
+			md := b.factory.Metadata()
+			setAggCols := make([]scopeColumn, len(aggCols))
+			ids := make(opt.ColList, len(aggCols))
+			var aggMap opt.ColMap
+			for i := range aggCols {
+				setAggCols[i] = aggCols[i]
+				if id, ok := aggMap.Get(int(aggCols[i].id)); ok {
+					setAggCols[i].id = opt.ColumnID(id)
+				} else {
+					setAggCols[i].id = md.AddColumn(md.ColumnMeta(aggCols[i].id).Alias, aggCols[i].typ)
+					aggMap.Set(int(aggCols[i].id), int(setAggCols[i].id))
+				}
+				setAggCols[i].scalar = b.factory.RemapCols(aggCols[i].scalar, colMap)
+				ids[i] = setAggCols[i].id
+			}
+			return b.constructGroupBy(
+				input, groupingColSet, setAggCols, remapOrdering(ordering, colMap),
+			), ids
+		})
+	} else {
+		// Construct the pre-projection, which renders the grouping columns and
+		// the aggregate arguments, as well as any additional order by columns.
+		b.constructProjectForScope(fromScope, g.aggInScope)
+
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -508,12 +588,374 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, projectionsScope, fromScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
 	}
 	g.buildingGroupingCols = false
 }
 
+// hasGroupingSets returns true if the GROUP BY clause contains GROUPING SETS,
+// ROLLUP or CUBE.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSets); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expandGroupingSets returns the grouping sets of a list of GROUP BY items,
+// which is the cross product of the grouping sets of each item. Each grouping
+// set is a list of expressions, which may be tuples of expressions.
+func expandGroupingSets(items tree.Exprs) [][]tree.Expr {
+	res := [][]tree.Expr{nil}
+	for _, item := range items {
+		itemSets := expandGroupingItem(item)
+		prod := make([][]tree.Expr, 0, len(res)*len(itemSets))
+		for _, l := range res {
+			for _, r := range itemSets {
+				set := make([]tree.Expr, 0, len(l)+len(r))
+				set = append(set, l...)
+				prod = append(prod, append(set, r...))
+			}
+		}
+		if len(prod) > maxGroupingSets {
+			panic(pgerror.Newf(pgcode.StatementTooComplex,
+				"too many grouping sets present (maximum %d)", maxGroupingSets))
+		}
+		res = prod
+	}
+	return res
+}
+
+// expandGroupingItem returns the grouping sets of a single GROUP BY item.
+func expandGroupingItem(item tree.Expr) [][]tree.Expr {
+	gs, ok := item.(*tree.GroupingSets)
+	if !ok {
+		return [][]tree.Expr{{item}}
+	}
+	switch gs.Type {
+	case tree.RollupGroupingSets:
+		// ROLLUP (a, b) is GROUPING SETS ((a, b), (a), ()).
+		res := make([][]tree.Expr, 0, len(gs.Exprs)+1)
+		for i := len(gs.Exprs); i >= 0; i-- {
+			res = append(res, gs.Exprs[:i:i])
+		}
+		return res
+
+	case tree.CubeGroupingSets:
+		// CUBE (a, b) is GROUPING SETS ((a, b), (a), (b), ()).
+		if len(gs.Exprs) > 12 {
+			panic(pgerror.New(pgcode.ProgramLimitExceeded, "CUBE is limited to 12 elements"))
+		}
+		n := len(gs.Exprs)
+		res := make([][]tree.Expr, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set []tree.Expr
+			for i := 0; i < n; i++ {
+				if mask&(1<<(n-1-i)) != 0 {
+					set = append(set, gs.Exprs[i])
+				}
+			}
+			res = append(res, set)
+		}
+		return res
+
+	default:
+		// Each element of GROUPING SETS is itself a GROUP BY item, except that
+		// a parenthesized list of expressions is a single grouping set.
+		var res [][]tree.Expr
+		for _, e := range gs.Exprs {
+			if t, ok := tree.StripParens(e).(*tree.Tuple); ok {
+				res = append(res, t.Exprs)
+			} else {
+				res = append(res, expandGroupingSets(tree.Exprs{e})...)
+			}
+			if len(res) > maxGroupingSets {
+				panic(pgerror.Newf(pgcode.StatementTooComplex,
+					"too many grouping sets present (maximum %d)", maxGroupingSets))
+			}
+		}
+		return res
+	}
+}
+
+// buildGroupingSets builds the grouping columns of a GROUP BY clause which
+// contains GROUPING SETS, ROLLUP or CUBE. See groupingSets for details.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) {
+	g := fromScope.groupby
+	sets := expandGroupingSets(tree.Exprs(groupBy))
+	gs := &groupingSets{
+		numSets: len(sets),
+		ords:    make(map[string]intsets.Fast),
+	}
+	gs.col = scopeColumn{
+		name: scopeColName(""),
+		typ:  types.Int,
+		id:   b.factory.Metadata().AddColumn("grouping_set", types.Int),
+	}
+	g.groupingSets = gs
+
+	// Resolve the expressions of every grouping set, and collect the distinct
+	// grouping expressions in order of appearance.
+	var exprs []tree.TypedExpr
+	var aliases []string
+	for i, set := range sets {
+		for _, e := range set {
+			resolved, alias := b.resolveGrouping(e, selects, projectionsScope, fromScope)
+			for _, re := range resolved {
+				exprStr := symbolicExprStr(re)
+				ords, ok := gs.ords[exprStr]
+				if !ok {
+					exprs = append(exprs, re)
+					aliases = append(aliases, alias)
+				}
+				ords.Add(i)
+				gs.ords[exprStr] = ords
+			}
+		}
+	}
+
+	// The grouping columns are always synthesized, since they are output by the
+	// aggregation of each grouping set, while the input columns are only output
+	// by the scans of the pre-projection. See constructGroupingSets.
+	gs.sets = make([]opt.ColSet, gs.numSets)
+	for i, e := range exprs {
+		exprStr := symbolicExprStr(e)
+		col := g.aggInScope.addColumn(scopeColName(tree.Name(aliases[i])), e)
+		b.populateSynthesizedColumn(col, b.buildScalar(e, fromScope, nil, nil, nil))
+		g.groupStrs[exprStr] = col
+		id := col.id
+		gs.ords[exprStr].ForEach(func(ord int) {
+			gs.sets[ord].Add(id)
+		})
+	}
+
+	// The ordinal of the grouping set is the last grouping column.
+	g.aggInScope.appendColumn(&gs.col)
+	g.groupStrs[symbolicExprStr(&gs.col)] = &g.aggInScope.cols[len(g.aggInScope.cols)-1]
+}
+
+// constructGroupingSets constructs the aggregation of a GROUP BY clause with
+// grouping sets. See groupingSets for details. The pre-projection of the
+// aggregation is bound to a With expression, and constructAgg is called to
+// construct the aggregation of each grouping set over a scan of it, given the
+// grouping columns of the set and a map from the columns of the pre-projection
+// to the columns of the scan. constructAgg returns the aggregation and its
+// aggregate columns, in the same order as g.aggs.
+func (b *Builder) constructGroupingSets(
+	g *groupby,
+	fromScope *scope,
+	constructAgg func(
+		input memo.RelExpr, groupingColSet opt.ColSet, colMap opt.ColMap,
+	) (memo.RelExpr, opt.ColList),
+) memo.RelExpr {
+	md := b.factory.Metadata()
+	gs := g.groupingSets
+	var groupingColSet opt.ColSet
+	for i := range gs.sets {
+		groupingColSet.UnionWith(gs.sets[i])
+	}
+
+	// Construct the pre-projection, which renders the grouping columns and the
+	// aggregate arguments, as well as any additional order by columns. The
+	// grouping columns are output by the aggregations, so the pre-projection
+	// renders them with new IDs. The column with the ordinal of the grouping
+	// set is only rendered by the aggregations.
+	inCols := make([]scopeColumn, 0, len(g.aggInScope.cols)+len(g.aggInScope.extraCols))
+	var origCols opt.ColList
+	var colSet opt.ColSet
+	for _, cols := range [][]scopeColumn{g.aggInScope.cols, g.aggInScope.extraCols} {
+		for _, col := range cols {
+			if col.id == gs.col.id || colSet.Contains(col.id) {
+				continue
+			}
+			colSet.Add(col.id)
+			origCols = append(origCols, col.id)
+			if groupingColSet.Contains(col.id) {
+				col.id = md.AddColumn(md.ColumnMeta(col.id).Alias, col.typ)
+			}
+			inCols = append(inCols, col)
+		}
+	}
+	withID := b.factory.Memo().NextWithID()
+	binding := b.constructProject(fromScope.expr, inCols)
+	md.AddWithBinding(withID, binding)
+	const withName = "grouping_sets"
+
+	// The aggregations output the aggregate columns, the grouping columns and
+	// the ordinal of the grouping set.
+	aggCols := g.aggregateResultCols()
+	outCols := make(opt.ColList, 0, len(aggCols)+groupingColSet.Len()+1)
+	colSet = opt.ColSet{}
+	for i := range aggCols {
+		if !colSet.Contains(aggCols[i].id) {
+			colSet.Add(aggCols[i].id)
+			outCols = append(outCols, aggCols[i].id)
+		}
+	}
+	outCols = append(outCols, groupingColSet.ToList()...)
+	outCols = append(outCols, gs.col.id)
+
+	var out memo.RelExpr
+	var unionCols opt.ColList
+	for ord := 0; ord < gs.numSets; ord++ {
+		// Scan the pre-projection.
+		var colMap opt.ColMap
+		scanInCols := make(opt.ColList, len(inCols))
+		scanOutCols := make(opt.ColList, len(inCols))
+		for i := range inCols {
+			scanInCols[i] = inCols[i].id
+			scanOutCols[i] = md.AddColumn(md.ColumnMeta(inCols[i].id).Alias, inCols[i].typ)
+			colMap.Set(int(origCols[i]), int(scanOutCols[i]))
+		}
+		expr := b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    withID,
+			Name:    withName,
+			InCols:  scanInCols,
+			OutCols: scanOutCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		// Aggregate the rows of the grouping set.
+		expr, setAggCols := constructAgg(expr, gs.sets[ord].CopyAndMaybeRemap(colMap), colMap)
+		for i := range aggCols {
+			colMap.Set(int(aggCols[i].id), int(setAggCols[i]))
+		}
+
+		// Project the ordinal of the grouping set, and NULL for the grouping
+		// columns which are not part of it.
+		setCols := make(opt.ColList, len(outCols))
+		var projections memo.ProjectionsExpr
+		var passthrough opt.ColSet
+		for i, col := range outCols {
+			if col != gs.col.id && (!groupingColSet.Contains(col) || gs.sets[ord].Contains(col)) {
+				setCols[i] = remapColumn(col, colMap)
+				passthrough.Add(setCols[i])
+				continue
+			}
+			setCols[i] = md.AddColumn(md.ColumnMeta(col).Alias, md.ColumnMeta(col).Type)
+			scalar := b.factory.ConstructNull(md.ColumnMeta(col).Type)
+			if col == gs.col.id {
+				scalar = b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(ord)), types.Int)
+			}
+			projections = append(projections, b.factory.ConstructProjectionsItem(scalar, setCols[i]))
+		}
+		expr = b.factory.ConstructProject(expr, projections, passthrough)
+
+		if out == nil {
+			out, unionCols = expr, setCols
+			continue
+		}
+		cols := outCols
+		if ord < gs.numSets-1 {
+			cols = make(opt.ColList, len(outCols))
+			for i := range cols {
+				cols[i] = md.AddColumn(md.ColumnMeta(outCols[i]).Alias, md.ColumnMeta(outCols[i]).Type)
+			}
+		}
+		out = b.factory.ConstructUnionAll(out, expr, &memo.SetPrivate{
+			LeftCols:  unionCols,
+			RightCols: setCols,
+			OutCols:   cols,
+		})
+		unionCols = cols
+	}
+
+	if gs.numSets == 1 {
+		// Output the original columns of the single grouping set.
+		projections := make(memo.ProjectionsExpr, len(outCols))
+		for i := range outCols {
+			projections[i] = b.factory.ConstructProjectionsItem(
+				b.factory.ConstructVariable(unionCols[i]), outCols[i],
+			)
+		}
+		out = b.factory.ConstructProject(out, projections, opt.ColSet{})
+	}
+
+	return b.factory.ConstructWith(binding, out, &memo.WithPrivate{ID: withID, Name: withName})
+}
+
+// remapColumn returns the column that the given column is mapped to by colMap,
+// or the column itself if it is not mapped.
+func remapColumn(col opt.ColumnID, colMap opt.ColMap) opt.ColumnID {
+	if to, ok := colMap.Get(int(col)); ok {
+		return opt.ColumnID(to)
+	}
+	return col
+}
+
+// remapOrdering returns a copy of the ordering with its columns remapped by
+// colMap.
+func remapOrdering(ordering opt.Ordering, colMap opt.ColMap) opt.Ordering {
+	if len(ordering) == 0 {
+		return nil
+	}
+	res := make(opt.Ordering, len(ordering))
+	for i, c := range ordering {
+		res[i] = opt.MakeOrderingColumn(remapColumn(c.ID(), colMap), c.Descending())
+	}
+	return res
+}
+
+// buildGroupingFunction builds a call to the GROUPING function, which returns
+// a bit mask of its arguments which are not part of the grouping set of the
+// current row. The last argument is the least significant bit.
+func (b *Builder) buildGroupingFunction(
+	f *tree.FuncExpr, inScope, outScope *scope, outCol *scopeColumn,
+) opt.ScalarExpr {
+	if len(f.Exprs) > 31 {
+		panic(pgerror.New(pgcode.TooManyArguments, "GROUPING must have fewer than 32 arguments"))
+	}
+	g := inScope.groupby
+	numSets := 1
+	if g.groupingSets != nil {
+		numSets = g.groupingSets.numSets
+	}
+	masks := make([]int64, numSets)
+	for i, arg := range f.Exprs {
+		exprStr := symbolicExprStr(arg)
+		if _, ok := g.groupStrs[exprStr]; !ok {
+			panic(pgerror.New(pgcode.Grouping,
+				"arguments to GROUPING must be grouping expressions of the associated query level"))
+		}
+		if g.groupingSets == nil {
+			continue
+		}
+		ords := g.groupingSets.ords[exprStr]
+		bit := int64(1) << (len(f.Exprs) - 1 - i)
+		for ord := range masks {
+			if !ords.Contains(ord) {
+				masks[ord] |= bit
+			}
+		}
+	}
+
+	var out opt.ScalarExpr
+	if g.groupingSets == nil {
+		out = b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(masks[0])), types.Int)
+	} else {
+		whens := make(memo.ScalarListExpr, len(masks))
+		for ord, mask := range masks {
+			whens[ord] = b.factory.ConstructWhen(
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(ord)), types.Int),
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(mask)), types.Int),
+			)
+		}
+		out = b.factory.ConstructCase(
+			b.factory.ConstructVariable(g.groupingSets.col.id), whens, b.factory.ConstructNull(types.Int),
+		)
+	}
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
 // groupStrs and to the aggInScope.
@@ -537,9 +979,35 @@ func (b *Builder) buildGroupingList(
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
 ) {
+	exprs, alias := b.resolveGrouping(groupBy, selects, projectionsScope, fromScope)
+
+	// Finally, build each of the GROUP BY columns.
+	for _, e := range exprs {
+		// If a grouping column has already been added, don't add it again.
+		// GROUP BY a, a is semantically equivalent to GROUP BY a.
+		exprStr := symbolicExprStr(e)
+		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			continue
+		}
+
+		// Save a representation of the GROUP BY expression for validation of the
+		// SELECT and HAVING expressions. This enables queries such as:
+		//   SELECT x+y FROM t GROUP BY x+y
+		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
+		b.buildScalar(e, fromScope, aggInScope, col, nil)
+		fromScope.groupby.groupStrs[exprStr] = col
+	}
+}
+
+// resolveGrouping resolves the types of a GROUP BY expression, and returns the
+// resulting grouping expressions along with the alias of the SELECT target
+// the GROUP BY expression refers to, if any. Stars are expanded and tuples
+// are flattened, so there may be any number of grouping expressions.
+func (b *Builder) resolveGrouping(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) (exprs []tree.TypedExpr, alias string) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
-	alias := ""
 
 	// Comment below pasted from PostgreSQL (findTargetListEntrySQL92 in
 	// src/backend/parser/parse_clause.c).
@@ -626,25 +1094,8 @@ func (b *Builder) buildGrouping(
 	fromScope.context = exprKindGroupBy
 
 	// Resolve types, expand stars, and flatten tuples.
-	exprs := b.expandStarAndResolveType(groupBy, fromScope)
-	exprs = flattenTuples(exprs)
-
-	// Finally, build each of the GROUP BY columns.
-	for _, e := range exprs {
-		// If a grouping column has already been added, don't add it again.
-		// GROUP BY a, a is semantically equivalent to GROUP BY a.
-		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
-			continue
-		}
-
-		// Save a representation of the GROUP BY expression for validation of the
-		// SELECT and HAVING expressions. This enables queries such as:
-		//   SELECT x+y FROM t GROUP BY x+y
-		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
-		b.buildScalar(e, fromScope, aggInScope, col, nil)
-		fromScope.groupby.groupStrs[exprStr] = col
-	}
+	exprs = b.expandStarAndResolveType(groupBy, fromScope)
+	return flattenTuples(exprs), alias
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -773,8 +1224,10 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -798,11 +1251,17 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if def.Overload != nil && def.Overload.UDA != nil {
+		return b.constructUserDefinedAggregate(def, args)
+	}
+	name := def.Name
 	switch name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
@@ -893,6 +1352,81 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
 }
 
+// constructUserDefinedAggregate constructs a UserDefinedAgg expression for an
+// aggregate created with CREATE AGGREGATE. The state transition and final
+// functions are built as calls over synthesized columns that represent the
+// aggregate state and arguments, and which are replaced with the actual values
+// during execution.
+func (b *Builder) constructUserDefinedAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	uda := def.Overload.UDA
+	paramTypes, ok := def.Overload.Types.(tree.ParamTypes)
+	if !ok || len(paramTypes) != len(args) {
+		panic(errors.AssertionFailedf("unexpected arguments for aggregate %s", def.Name))
+	}
+
+	aggScope := b.allocScope()
+	stateCol := b.synthesizeColumn(
+		aggScope, scopeColName("state"), uda.StateType, nil /* expr */, nil, /* scalar */
+	).id
+	argCols := make(opt.ColList, len(args))
+	for i := range paramTypes {
+		argColName := funcParamColName(tree.Name(paramTypes[i].Name), i)
+		argCols[i] = b.synthesizeColumn(
+			aggScope, argColName, paramTypes[i].Typ, nil /* expr */, nil, /* scalar */
+		).id
+	}
+
+	// buildCall builds a call to the function with the given OID, with the
+	// given number of leading columns of aggScope as its arguments.
+	buildCall := func(funcOID oid.Oid, numArgs int) (opt.ScalarExpr, *tree.Overload) {
+		name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, funcOID)
+		if err != nil {
+			panic(err)
+		}
+		callArgs := make(tree.Exprs, numArgs)
+		for i := range callArgs {
+			callArgs[i] = &aggScope.cols[i]
+		}
+		f := &tree.FuncExpr{
+			Func: tree.ResolvableFunctionReference{
+				FunctionReference: &tree.ResolvedFunctionDefinition{
+					Name:      name,
+					Overloads: []tree.QualifiedOverload{{Overload: o}},
+				},
+			},
+			Exprs: callArgs,
+		}
+		texpr := aggScope.resolveType(f, types.Any)
+		return b.buildScalar(texpr, aggScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */), o
+	}
+
+	private := memo.UserDefinedAggPrivate{
+		Name:      def.Name,
+		Typ:       uda.StateType,
+		StateType: uda.StateType,
+		StateCol:  stateCol,
+		ArgCols:   argCols,
+		InitState: tree.DNull,
+	}
+	var transitionOverload *tree.Overload
+	private.Transition, transitionOverload = buildCall(uda.StateFuncOID, len(args)+1)
+	private.StrictTransition = !transitionOverload.CalledOnNullInput
+	if uda.FinalFuncOID != 0 {
+		private.Final, _ = buildCall(uda.FinalFuncOID, 1 /* numArgs */)
+		private.Typ = private.Final.DataType()
+	}
+	if uda.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(uda.StateType, *uda.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		private.InitState = d
+	}
+	return b.factory.ConstructUserDefinedAgg(args, &private)
+}
+
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
@@ -925,6 +1459,10 @@ func newGroupingError(name tree.Name) error {
 // table. In that case, we can allow col as an "implicit" grouping column, even
 // if it is not specified in the query.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The PK columns may be NULL in some grouping sets.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -289,6 +289,9 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	switch {
 	// Case 1: Simple INSERT statement.
 	case ins.OnConflict == nil:
+		// Enforce the row-level security policies of the table.
+		mb.addRowLevelSecurityCheck(tree.PolicyCommandInsert, returning != nil)
+
 		// Build the final insert statement, including any returned expressions.
 		mb.buildInsert(returning)
 
@@ -299,6 +302,9 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// details.
 		mb.buildInputForDoNothing(inScope, ins.OnConflict)
 
+		// Enforce the row-level security policies of the table.
+		mb.addRowLevelSecurityCheck(tree.PolicyCommandInsert, returning != nil)
+
 		// Since buildInputForDoNothing filters out rows with conflicts, always
 		// insert rows that are not filtered.
 		mb.buildInsert(returning)
@@ -330,11 +336,15 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// as the join condition.
 		mb.buildInputForUpsert(inScope, ins.OnConflict, ins.OnConflict.Where)
 
+		// Assignments to fields of composite type columns are combined into
+		// assignments to the columns themselves.
+		exprs := mb.combineFieldUpdateExprs(ins.OnConflict.Exprs)
+
 		// Derive the columns that will be updated from the SET expressions.
-		mb.addTargetColsForUpdate(ins.OnConflict.Exprs)
+		mb.addTargetColsForUpdate(exprs)
 
 		// Build each of the SET expressions.
-		mb.addUpdateCols(ins.OnConflict.Exprs)
+		mb.addUpdateCols(exprs)
 
 		// Build the final upsert statement, including any returned expressions.
 		mb.buildUpsert(returning)
@@ -355,6 +365,10 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //     values specified for them.
 //  4. Each update value is the same as the corresponding insert value.
 //  5. There are no inbound foreign keys containing non-key columns.
+//  6. Row-level security does not apply to the table. Otherwise, the existing
+//     rows must be checked against its policies.
+//  7. The table has no row-level triggers. Otherwise, the triggers must be
+//     passed the existing rows, and must know which rows are inserted.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -366,6 +380,17 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	// #6: The existing rows must satisfy the row-level security policies of
+	// the table.
+	if mb.b.rowLevelSecurityApplies(mb.tab) {
+		return true
+	}
+
+	// #7: Row-level triggers are passed the existing rows.
+	if mb.tab.TriggerCount() > 0 {
+		return true
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -660,6 +685,9 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Fire the BEFORE INSERT triggers, which may modify or skip the new rows.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -684,6 +712,8 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -882,6 +912,9 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Enforce the row-level security policies of the table.
+	mb.addRowLevelSecurityCheckForUpsert(returning != nil)
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
@@ -907,6 +940,8 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert, tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
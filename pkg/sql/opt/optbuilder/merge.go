@@ -0,0 +1,605 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// mergeDupErrText is the error returned when a target row is joined to several
+// source rows that would modify it.
+const mergeDupErrText = "MERGE command cannot affect row a second time"
+
+// mergeInput describes the rows that are acted upon by a MERGE statement. See
+// buildMergeInput.
+type mergeInput struct {
+	// withID is the ID of the With binding of the input rows.
+	withID opt.WithID
+
+	// cols contains the fetch columns of the target table, followed by the
+	// columns of the source, followed by the branch column.
+	cols []scopeColumn
+
+	// numFetchCols is the number of fetch columns of the target table in cols.
+	numFetchCols int
+}
+
+// buildMerge builds a memo group for a MERGE statement:
+//
+//	MERGE INTO <target> USING <source> ON <cond>
+//	WHEN MATCHED [AND <cond>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//	WHEN NOT MATCHED [AND <cond>] THEN { INSERT ... | DO NOTHING }
+//	...
+//
+// The source is left-joined to the target table, and each joined row is
+// assigned to the first WHEN clause that applies to it (see buildMergeInput).
+// The rows are then dispatched to at most one Delete, one Update and one
+// Insert operator, in this order. Each operator is built by a mutationBuilder
+// in the same way as the corresponding statement, so the mutations are subject
+// to the usual constraint, uniqueness and foreign key checks. Like the other
+// mutation statements, MERGE returns the number of rows that it affected: its
+// root expression counts the rows returned by the mutations.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, and check the SELECT privilege, since
+	// existing rows must be read.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Target, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	// Collect the WHEN clauses of each action, and check the privileges that
+	// they require.
+	var deletes, updates, inserts []int
+	for i, w := range merge.Whens {
+		switch w.Action {
+		case tree.MergeActionDelete:
+			deletes = append(deletes, i)
+		case tree.MergeActionUpdate:
+			updates = append(updates, i)
+		case tree.MergeActionInsert:
+			inserts = append(inserts, i)
+		}
+	}
+	if len(deletes) > 0 {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+	if len(updates) > 0 {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if len(inserts) > 0 {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, false /* simpleInsert */)
+
+	input := b.buildMergeInput(merge, tab, alias, inScope)
+
+	// Build the mutations. Each of them returns one row per affected row.
+	var mutations []*scope
+	if len(deletes) > 0 {
+		var mb mutationBuilder
+		mb.init(b, "delete", tab, alias)
+		mb.buildInputForMerge(inScope, input, deletes, true /* fetch */)
+		mb.addRowLevelSecurityCheckForMerge(tree.PolicyCommandDelete)
+		mb.buildDelete(mergeReturning)
+		mutations = append(mutations, mb.outScope)
+	}
+	if len(updates) > 0 {
+		var mb mutationBuilder
+		mb.init(b, "update", tab, alias)
+		mb.buildInputForMerge(inScope, input, updates, true /* fetch */)
+		mb.addUpdateColsForMerge(merge.Whens, updates)
+		mb.addRowLevelSecurityCheckForMerge(tree.PolicyCommandUpdate)
+		mb.buildUpdate(mergeReturning)
+		mutations = append(mutations, mb.outScope)
+	}
+	if len(inserts) > 0 {
+		var mb mutationBuilder
+		mb.init(b, "insert", tab, alias)
+		mb.buildInputForMerge(inScope, input, inserts, false /* fetch */)
+		mb.addInsertColsForMerge(input, merge.Whens, inserts)
+		mb.addRowLevelSecurityCheckForMerge(tree.PolicyCommandInsert)
+		mb.buildInsert(mergeReturning)
+		mutations = append(mutations, mb.outScope)
+	}
+
+	// Bind the mutations to With expressions, and count the rows that they
+	// return.
+	md := b.factory.Metadata()
+	withIDs := make([]opt.WithID, len(mutations))
+	var rows memo.RelExpr
+	var rowsCol opt.ColumnID
+	for i, mutation := range mutations {
+		withIDs[i] = b.factory.Memo().NextWithID()
+		md.AddWithBinding(withIDs[i], mutation.expr)
+		col := md.AddColumn("merge", types.Int)
+		scan := b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    withIDs[i],
+			InCols:  opt.ColList{mutation.cols[0].id},
+			OutCols: opt.ColList{col},
+			ID:      md.NextUniqueID(),
+		})
+		if rows == nil {
+			rows, rowsCol = scan, col
+			continue
+		}
+		unionCol := md.AddColumn("merge", types.Int)
+		rows = b.factory.ConstructUnionAll(rows, scan, &memo.SetPrivate{
+			LeftCols:  opt.ColList{rowsCol},
+			RightCols: opt.ColList{col},
+			OutCols:   opt.ColList{unionCol},
+		})
+		rowsCol = unionCol
+	}
+
+	outScope = inScope.push()
+	countCol := b.synthesizeColumn(outScope, scopeColName("count"), types.Int, nil, nil)
+	if rows == nil {
+		// All the WHEN clauses are DO NOTHING.
+		outScope.expr = b.factory.ConstructValues(memo.ScalarListExpr{
+			b.factory.ConstructTuple(
+				memo.ScalarListExpr{b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)},
+				types.MakeTuple([]*types.T{types.Int}),
+			),
+		}, &memo.ValuesPrivate{
+			Cols: opt.ColList{countCol.id},
+			ID:   md.NextUniqueID(),
+		})
+	} else {
+		outScope.expr = b.factory.ConstructScalarGroupBy(
+			rows,
+			memo.AggregationsExpr{
+				b.factory.ConstructAggregationsItem(b.factory.ConstructCountRows(), countCol.id),
+			},
+			memo.EmptyGroupingPrivate,
+		)
+	}
+
+	// The mutations are executed in order, after the input rows are buffered.
+	for i := len(mutations) - 1; i >= 0; i-- {
+		outScope.expr = b.factory.ConstructWith(mutations[i].expr, outScope.expr, &memo.WithPrivate{
+			ID:   withIDs[i],
+			Name: "merge",
+		})
+	}
+	outScope.expr = b.factory.ConstructWith(
+		md.WithBinding(input.withID).(memo.RelExpr), outScope.expr, &memo.WithPrivate{
+			ID:   input.withID,
+			Name: "merge_input",
+		},
+	)
+	return outScope
+}
+
+// mergeReturning is the RETURNING clause of the mutations built for a MERGE
+// statement, which only need to return a row per affected row.
+var mergeReturning = tree.ReturningExprs{{Expr: tree.NewDInt(1)}}
+
+// buildMergeInput builds the rows that are acted upon by a MERGE statement,
+// and binds them to a With expression. The rows are built by a query similar
+// to this:
+//
+//	SELECT DISTINCT ON (<target primary key>) *
+//	FROM (
+//	  SELECT <target>.*, <source>.*,
+//	         CASE
+//	           WHEN <canary> IS NOT NULL AND <cond1> THEN 1
+//	           WHEN <canary> IS NULL AND <cond2> THEN 2
+//	           ...
+//	           ELSE 0
+//	         END AS branch
+//	  FROM <source> LEFT JOIN <target> ON <cond>
+//	)
+//	WHERE branch != 0
+//
+// where the canary column is the first primary key column of the target
+// table, which is only NULL for the source rows that don't match any target
+// row. The branch column holds the 1-based ordinal of the WHEN clause that
+// applies to the row; it is 0 for the rows that are not acted upon, which
+// include the rows of DO NOTHING clauses. The DISTINCT ON raises an error if a
+// target row would be modified by more than one source row, as in Postgres.
+func (b *Builder) buildMergeInput(
+	merge *tree.Merge, tab cat.Table, alias tree.TableName, inScope *scope,
+) *mergeInput {
+	var indexFlags *tree.IndexFlags
+	if source, ok := merge.Target.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		indexFlags = source.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	// reason other than as "fetch columns". See buildScan comment.
+	fetchScope := b.buildScan(
+		b.addTable(tab, &alias),
+		tableOrdinals(tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// As in Postgres, the target rows which the SELECT policies of the table
+	// hide are not matched. The policies of the mutations are checked against
+	// the matched rows by the mutations (see addRowLevelSecurityCheckForMerge).
+	b.buildRowLevelSecurityFilter(tab, tree.PolicyCommandSelect, fetchScope)
+
+	sourceScope := b.buildFromTables(tree.TableExprs{merge.Source}, noRowLocking, inScope)
+
+	// Check that the same table name is not used for the source and target.
+	b.validateJoinTableNames(fetchScope, sourceScope)
+
+	// Left-join the source to the target.
+	joinScope := inScope.push()
+	joinScope.appendColumnsFromScope(fetchScope)
+	joinScope.appendColumnsFromScope(sourceScope)
+	on := b.resolveAndBuildScalar(
+		merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications,
+		joinScope,
+	)
+	joinScope.expr = b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		fetchScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(on)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// Project the branch column.
+	primaryIndex := tab.Index(cat.PrimaryIndex)
+	canaryCol := fetchScope.getColumnForTableOrdinal(primaryIndex.Column(0).Ordinal())
+	branch := &tree.CaseExpr{Else: tree.NewDInt(0)}
+	for i, w := range merge.Whens {
+		var cond tree.Expr
+		if w.Matched {
+			cond = &tree.IsNotNullExpr{Expr: canaryCol}
+		} else {
+			cond = &tree.IsNullExpr{Expr: canaryCol}
+		}
+		if w.Cond != nil {
+			cond = &tree.AndExpr{Left: cond, Right: w.Cond}
+		}
+		val := tree.NewDInt(0)
+		if w.Action != tree.MergeActionDoNothing {
+			val = tree.NewDInt(tree.DInt(i + 1))
+		}
+		branch.Whens = append(branch.Whens, &tree.When{Cond: cond, Val: val})
+	}
+
+	scalarProps := &b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	b.semaCtx.Properties.Require("MERGE WHEN", tree.RejectSpecial)
+
+	projectionsScope := joinScope.replace()
+	projectionsScope.appendColumnsFromScope(joinScope)
+	texpr := joinScope.resolveAndRequireType(branch, types.Int)
+	branchCol := projectionsScope.addColumn(scopeColName("").WithMetadataName("merge_branch"), texpr)
+	b.buildScalar(texpr, joinScope, projectionsScope, branchCol, nil)
+	b.constructProjectForScope(joinScope, projectionsScope)
+
+	// Filter out the rows that are not acted upon.
+	projectionsScope.expr = b.factory.ConstructSelect(
+		projectionsScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(b.factory.ConstructNe(
+			b.factory.ConstructVariable(branchCol.id),
+			b.factory.ConstructConstVal(tree.NewDInt(0), types.Int),
+		))},
+	)
+
+	// Ensure that each target row is acted upon at most once. Source rows that
+	// don't match any target row have NULL primary key values, and are all
+	// distinct.
+	var pkCols opt.ColSet
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		col := fetchScope.getColumnForTableOrdinal(primaryIndex.Column(i).Ordinal())
+		pkCols.Add(col.id)
+	}
+	outScope := b.buildDistinctOn(pkCols, projectionsScope, true /* nullsAreDistinct */, mergeDupErrText)
+
+	// The input rows are buffered, since they are scanned by several
+	// mutations.
+	withID := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(withID, outScope.expr)
+
+	return &mergeInput{
+		withID:       withID,
+		cols:         outScope.cols,
+		numFetchCols: len(fetchScope.cols),
+	}
+}
+
+// buildInputForMerge constructs the input of a mutation built for a MERGE
+// statement, which scans the input rows of the given WHEN clauses. All the
+// input columns are accessible to the expressions of the WHEN clauses. If fetch
+// is true, the columns of the target table are used as the fetch columns of the
+// mutation.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope, input *mergeInput, whens []int, fetch bool,
+) {
+	md := mb.b.factory.Metadata()
+	mb.outScope = inScope.push()
+	inCols := make(opt.ColList, len(input.cols))
+	for i := range input.cols {
+		col := input.cols[i]
+		inCols[i] = col.id
+		col.id = md.AddColumn(md.ColumnMeta(col.id).Alias, col.typ)
+		col.scalar = nil
+		col.expr = nil
+		mb.outScope.cols = append(mb.outScope.cols, col)
+	}
+	mb.outScope.expr = mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    input.withID,
+		InCols:  inCols,
+		OutCols: mb.outScope.colList(),
+		ID:      md.NextUniqueID(),
+	})
+
+	// Only keep the rows of the given WHEN clauses.
+	branchCol := mb.mergeBranchCol()
+	branches := make(memo.ScalarListExpr, len(whens))
+	branchTypes := make([]*types.T, len(whens))
+	for i, w := range whens {
+		branches[i] = mb.b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(w+1)), types.Int)
+		branchTypes[i] = types.Int
+	}
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(mb.b.factory.ConstructIn(
+			mb.b.factory.ConstructVariable(branchCol.id),
+			mb.b.factory.ConstructTuple(branches, types.MakeTuple(branchTypes)),
+		))},
+	)
+
+	if !fetch {
+		return
+	}
+	fetchCols := mb.outScope.cols[:input.numFetchCols]
+	mb.fetchScope = mb.b.allocScope()
+	mb.fetchScope.appendColumns(fetchCols)
+	mb.setFetchColIDs(fetchCols)
+}
+
+// mergeBranchCol returns the branch column of the input of a mutation built by
+// buildInputForMerge.
+func (mb *mutationBuilder) mergeBranchCol() *scopeColumn {
+	return &mb.outScope.cols[len(mb.outScope.cols)-1]
+}
+
+// addUpdateColsForMerge adds the update columns of the given UPDATE SET
+// clauses of a MERGE statement. If there are several clauses, the new value of
+// each column is chosen by a CASE expression on the branch column:
+//
+//	SET a = CASE branch WHEN 1 THEN <expr1> WHEN 3 THEN <expr3> ELSE a END
+func (mb *mutationBuilder) addUpdateColsForMerge(whens tree.MergeWhens, branches []int) {
+	// Use a stable pointer to the branch column, since the scope columns are
+	// reallocated as columns are added.
+	branchCol := *mb.mergeBranchCol()
+
+	// ords contains the ordinals of the updated columns, in the order in which
+	// they are first assigned, and exprs contains their new value for each
+	// branch.
+	var ords []int
+	exprs := make(map[int]*tree.CaseExpr)
+	for _, i := range branches {
+		// Assignments to fields of composite type columns are combined into
+		// assignments to the columns themselves.
+		sets := mb.combineFieldUpdateExprs(whens[i].Exprs)
+
+		var assigned intsets.Fast
+		assign := func(name tree.Name, expr tree.Expr) {
+			ord := findPublicTableColumnByName(mb.tab, name)
+			if ord == -1 {
+				panic(colinfo.NewUndefinedColumnError(string(name)))
+			}
+			if mb.tab.Column(ord).Kind() == cat.System {
+				panic(pgerror.Newf(pgcode.InvalidColumnReference, "cannot modify system column %q", name))
+			}
+			if _, ok := expr.(tree.DefaultVal); !ok && mb.tab.Column(ord).IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(name)))
+			}
+			if assigned.Contains(ord) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column %q", name))
+			}
+			assigned.Add(ord)
+			c, ok := exprs[ord]
+			if !ok {
+				mb.addTargetCol(ord)
+				ords = append(ords, ord)
+				c = &tree.CaseExpr{Expr: &branchCol, Else: mb.fetchScope.getColumnForTableOrdinal(ord)}
+				exprs[ord] = c
+			}
+			c.Whens = append(c.Whens, &tree.When{Cond: tree.NewDInt(tree.DInt(i + 1)), Val: expr})
+		}
+
+		for _, set := range sets {
+			if !set.Tuple {
+				assign(set.Names[0], set.Expr)
+				continue
+			}
+			t, ok := set.Expr.(*tree.Tuple)
+			if !ok {
+				panic(unimplementedWithIssueDetailf(35713, "merge",
+					"source for a multiple-column MERGE UPDATE item must be a ROW() expression"))
+			}
+			if len(set.Names) != len(t.Exprs) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"number of columns (%d) does not match number of values (%d)",
+					len(set.Names), len(t.Exprs)))
+			}
+			for j := range set.Names {
+				assign(set.Names[j], t.Exprs[j])
+			}
+		}
+	}
+
+	updateExprs := make(tree.UpdateExprs, len(ords))
+	for i, ord := range ords {
+		c := exprs[ord]
+		updateExprs[i] = &tree.UpdateExpr{Names: tree.NameList{mb.tab.Column(ord).ColName()}}
+		if len(branches) == 1 {
+			// The CASE expression is not needed if there is a single branch.
+			updateExprs[i].Expr = c.Whens[0].Val
+			continue
+		}
+		// DEFAULT is only allowed at the top level of a SET expression.
+		for _, w := range c.Whens {
+			if _, ok := w.Val.(tree.DefaultVal); ok {
+				w.Val = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+			}
+		}
+		updateExprs[i].Expr = c
+	}
+
+	mb.addUpdateCols(updateExprs)
+}
+
+// addInsertColsForMerge adds the insert columns of the given INSERT clauses of
+// a MERGE statement. If there are several clauses, the value of each column is
+// chosen by a CASE expression on the branch column, as in
+// addUpdateColsForMerge. Only the columns of the source are accessible to the
+// inserted values.
+func (mb *mutationBuilder) addInsertColsForMerge(
+	input *mergeInput, whens tree.MergeWhens, branches []int,
+) {
+	branchCol := *mb.mergeBranchCol()
+
+	// The inserted values can't reference the columns of the target table, nor
+	// the branch column.
+	inScope := mb.outScope.replace()
+	inScope.appendColumns(mb.outScope.cols[input.numFetchCols : len(mb.outScope.cols)-1])
+	inScope.expr = mb.outScope.expr
+
+	// ords contains the ordinals of the inserted columns, in the order in which
+	// they are first assigned, and exprs contains their value for each branch.
+	var ords []int
+	exprs := make(map[int]map[int]tree.Expr)
+	for _, i := range branches {
+		w := whens[i]
+		var targets []int
+		if w.Columns != nil {
+			for _, name := range w.Columns {
+				ord := findPublicTableColumnByName(mb.tab, name)
+				if ord == -1 {
+					panic(colinfo.NewUndefinedColumnError(string(name)))
+				}
+				if mb.tab.Column(ord).Kind() == cat.System {
+					panic(pgerror.Newf(pgcode.InvalidColumnReference, "cannot modify system column %q", name))
+				}
+				targets = append(targets, ord)
+			}
+			mb.checkNumCols(len(targets), len(w.Values))
+		} else {
+			// The values are mapped to the visible columns of the table, in the
+			// order in which they are defined.
+			for ord, n := 0, mb.tab.ColumnCount(); ord < n && len(targets) < len(w.Values); ord++ {
+				col := mb.tab.Column(ord)
+				if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+					targets = append(targets, ord)
+				}
+			}
+			mb.checkNumCols(len(targets), len(w.Values))
+		}
+
+		for j, ord := range targets {
+			m, ok := exprs[ord]
+			if !ok {
+				mb.addTargetCol(ord)
+				ords = append(ords, ord)
+				m = make(map[int]tree.Expr)
+				exprs[ord] = m
+			}
+			if _, ok := m[i]; ok {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column %q", mb.tab.Column(ord).ColName()))
+			}
+			val := w.Values[j]
+			if _, ok := val.(tree.DefaultVal); ok {
+				val = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+			} else if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+			}
+			m[i] = val
+		}
+	}
+
+	// Ensure that primary key and foreign key columns are in the target column
+	// list, or that they have default values.
+	mb.checkPrimaryKeyForInsert()
+	mb.checkForeignKeysForInsert()
+
+	// The inserted values should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require(exprKindValues.String(), tree.RejectSpecial)
+
+	projectionsScope := inScope.replace()
+	for _, ord := range ords {
+		colID := mb.tabID.ColumnID(ord)
+		var expr tree.Expr
+		if len(branches) == 1 {
+			expr = exprs[ord][branches[0]]
+		} else {
+			c := &tree.CaseExpr{Expr: &branchCol}
+			for _, i := range branches {
+				val, ok := exprs[ord][i]
+				if !ok {
+					// The column is not assigned by this branch, so it takes its
+					// default value.
+					val = mb.parseDefaultExpr(colID)
+				}
+				c.Whens = append(c.Whens, &tree.When{Cond: tree.NewDInt(tree.DInt(i + 1)), Val: val})
+			}
+			expr = c
+		}
+
+		targetCol := mb.tab.Column(ord)
+		texpr := inScope.resolveType(expr, targetCol.DatumType())
+		scopeCol := projectionsScope.addColumn(scopeColName(targetCol.ColName()), texpr)
+		mb.b.buildScalar(texpr, inScope, projectionsScope, scopeCol, nil)
+
+		// Record the ID of the column that contains the value to be inserted
+		// into the corresponding target table column.
+		mb.insertColIDs[ord] = scopeCol.id
+	}
+	mb.b.constructProjectForScope(inScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add assignment casts for insert columns.
+	mb.addAssignmentCasts(mb.insertColIDs)
+
+	// Add default columns that were not assigned, as well as computed columns.
+	mb.addSynthesizedColsForInsert()
+
+	// Set insertExpr. This expression is used when building uniqueness checks.
+	// See mutationBuilder.buildCheckInputScan.
+	mb.insertExpr = mb.outScope.expr
+}
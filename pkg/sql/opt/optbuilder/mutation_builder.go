@@ -178,8 +178,12 @@ type mutationBuilder struct {
 	// cascades contains foreign key check cascades; see buildFK* methods.
 	cascades memo.FKCascades
 
+	// afterTriggers contains the AFTER row-level triggers fired by the
+	// mutation, if any; see buildAfterTriggers.
+	afterTriggers *memo.AfterTriggers
+
 	// withID is nonzero if we need to buffer the input for FK or uniqueness
-	// checks.
+	// checks, cascades or AFTER triggers.
 	withID opt.WithID
 
 	// extraAccessibleCols stores all the columns that are available to the
@@ -302,6 +306,10 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only rows which the policies of the table allow to be read and updated
+	// are fetched.
+	mb.b.buildRowLevelSecurityFilter(mb.tab, tree.PolicyCommandUpdate, mb.fetchScope)
+
 	// If there is a FROM clause present, we must join all the tables
 	// together with the table being updated.
 	fromClausePresent := len(from) > 0
@@ -412,6 +420,10 @@ func (mb *mutationBuilder) buildInputForDelete(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only rows which the policies of the table allow to be read and deleted
+	// are fetched.
+	mb.b.buildRowLevelSecurityFilter(mb.tab, tree.PolicyCommandDelete, mb.fetchScope)
+
 	// USING
 	usingClausePresent := len(using) > 0
 	if usingClausePresent {
@@ -956,10 +968,13 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 		PartialIndexPutCols: checkEmptyList(mb.partialIndexPutColIDs),
 		PartialIndexDelCols: checkEmptyList(mb.partialIndexDelColIDs),
 		FKCascades:          mb.cascades,
+		AfterTriggers:       mb.afterTriggers,
 	}
 
-	// If we didn't actually plan any checks or cascades, don't buffer the input.
-	if len(mb.uniqueChecks) > 0 || len(mb.fkChecks) > 0 || len(mb.cascades) > 0 {
+	// If we didn't actually plan any checks, cascades or AFTER triggers, don't
+	// buffer the input.
+	if len(mb.uniqueChecks) > 0 || len(mb.fkChecks) > 0 || len(mb.cascades) > 0 ||
+		mb.afterTriggers != nil {
 		private.WithID = mb.withID
 	}
 
@@ -1296,8 +1311,9 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		targetType := mb.tab.Column(ord).DatumType()
 
 		// An assignment cast is not necessary if the source and target types
-		// are identical.
-		if srcType.Identical(targetType) {
+		// are identical. Domain constraints are always checked, since a value
+		// of the domain type, such as a placeholder, may not satisfy them.
+		if srcType.Identical(targetType) && !targetType.IsDomain() {
 			continue
 		}
 
@@ -1307,9 +1323,15 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
 		}
 
-		// Create the cast expression.
+		// Create the cast expression. Casts to a domain also enforce the
+		// constraints of the domain.
 		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		var cast opt.ScalarExpr
+		if targetType.IsDomain() {
+			cast = mb.b.buildDomainCast(variable, targetType, true /* assignment */)
+		} else {
+			cast = mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1456,3 +1478,22 @@ func (mb *mutationBuilder) buildCheckInputScan(
 
 	return outScope, notNullOutCols
 }
+
+// txnToleratesWriteSkew returns true if the current transaction runs at an
+// isolation level weaker than SERIALIZABLE, such as READ COMMITTED.
+func (b *Builder) txnToleratesWriteSkew() bool {
+	return b.evalCtx.Txn != nil && b.evalCtx.Txn.IsoLevel().ToleratesWriteSkew()
+}
+
+// checkIsolationLevelForConstraintCheck raises an error if the current
+// transaction runs at READ COMMITTED isolation, since a check of the given
+// constraint could then miss conflicting rows written by concurrent
+// transactions. This applies to UNIQUE WITHOUT INDEX, exclusion and deferrable
+// constraints, which are enforced by checks that scan the table rather than by
+// a unique index or by locks.
+func (b *Builder) checkIsolationLevelForConstraintCheck(kind, name string) {
+	if b.txnToleratesWriteSkew() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot check %s constraint %q in a READ COMMITTED transaction", kind, name))
+	}
+}
@@ -81,6 +81,10 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
+				if constraint.IsExclusion() {
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"ON CONFLICT is not supported with exclusion constraints"))
+				}
 				return makeSingleUniqueConstraintArbiterSet(mb, i)
 			}
 		}
@@ -165,7 +169,7 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			if u := mb.tab.Unique(uc); u.WithoutIndex() && !u.IsExclusion() {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -223,6 +227,10 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints cannot be used as arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
@@ -612,13 +612,15 @@ func resolveTable(ctx context.Context, catalog cat.Catalog, id cat.StableID) cat
 }
 
 // buildOtherTableScan builds a Scan of the "other" table.
-func (h *fkCheckHelper) buildOtherTableScan() (outScope *scope, tabMeta *opt.TableMeta) {
+func (h *fkCheckHelper) buildOtherTableScan(
+	locking lockingSpec,
+) (outScope *scope, tabMeta *opt.TableMeta) {
 	otherTabMeta := h.mb.b.addTable(h.otherTab, tree.NewUnqualifiedTableName(h.otherTab.Name()))
 	return h.mb.b.buildScan(
 		otherTabMeta,
 		h.otherTabOrdinals,
 		&tree.IndexFlags{IgnoreForeignKeys: true},
-		noRowLocking,
+		locking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	), otherTabMeta
@@ -634,6 +636,9 @@ func (h *fkCheckHelper) allocOrdinals(numCols int) {
 // The input to the insertion check will be produced from the input to the
 // mutation operator.
 func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
+	if h.fk.Deferrable().IsDeferrable() {
+		h.mb.b.checkIsolationLevelForConstraintCheck("deferrable foreign key", h.fk.Name())
+	}
 	withScanScope, notNullWithScanCols := h.mb.buildCheckInputScan(
 		checkInputScanNewVals, h.tabOrdinals, true, /* isFK */
 	)
@@ -710,7 +715,18 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 	// Build an anti-join, with the origin FK columns on the left and the
 	// referenced columns on the right.
 
-	scanScope, refTabMeta := h.buildOtherTableScan()
+	// Under READ COMMITTED isolation, the referenced rows are locked so that
+	// they cannot be deleted or updated by concurrent transactions before this
+	// transaction commits. SERIALIZABLE transactions detect such conflicts when
+	// refreshing their reads instead.
+	locking := noRowLocking
+	if h.mb.b.txnToleratesWriteSkew() {
+		locking = lockingSpec{&tree.LockingItem{
+			Strength:   tree.ForShare,
+			WaitPolicy: tree.LockWaitBlock,
+		}}
+	}
+	scanScope, refTabMeta := h.buildOtherTableScan(locking)
 
 	// Build the join filters:
 	//   (origin_a = referenced_a) AND (origin_b = referenced_b) AND ...
@@ -746,9 +762,13 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 func (h *fkCheckHelper) buildDeletionCheck(
 	deletedRows memo.RelExpr, deleteCols opt.ColList,
 ) memo.FKChecksItem {
+	if h.fk.Deferrable().IsDeferrable() {
+		h.mb.b.checkIsolationLevelForConstraintCheck("deferrable foreign key", h.fk.Name())
+	}
+
 	// Build a semi join, with the referenced FK columns on the left and the
 	// origin columns on the right.
-	scanScope, origTabMeta := h.buildOtherTableScan()
+	scanScope, origTabMeta := h.buildOtherTableScan(noRowLocking)
 
 	// Note that it's impossible to orphan a row whose FK key columns contain a
 	// NULL, since by definition a NULL never refers to an actual row (in
@@ -0,0 +1,429 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Row-level triggers are planned by the optimizer as calls to the trigger
+// functions. Each trigger function is called with the OLD and NEW rows as
+// values of the table's implicit record type, whose fields are the table's
+// visible columns:
+//
+//   - BEFORE triggers are called in the input of the mutation, once the new
+//     values of the columns have been assigned and before computed columns,
+//     CHECK constraints, partial index predicates, row-level security policies
+//     and foreign key and uniqueness checks are derived from them. The row
+//     returned by each trigger is passed as NEW to the next one, and the row
+//     returned by the last trigger replaces the NEW row. If a trigger returns
+//     NULL, the row is skipped.
+//   - AFTER triggers are called once the mutation and its cascades have
+//     completed, by a query which reads the modified rows from the buffered
+//     mutation input. It is planned and executed like a cascade (see
+//     afterTriggersBuilder). Their result is ignored.
+//
+// Triggers differ from Postgres in the following ways:
+//
+//   - Trigger functions take the OLD and NEW rows as arguments of the table's
+//     row type and return that type, instead of returning the trigger
+//     pseudo-type and reading the rows from the OLD and NEW variables.
+//   - BEFORE triggers are called as the mutation input is produced, so the
+//     statements in a trigger function do not observe the rows written by the
+//     mutation for earlier rows.
+//   - Columns with an ON UPDATE expression which are not assigned by an UPDATE
+//     statement take the value of that expression, even if a BEFORE UPDATE
+//     trigger modifies them.
+
+// buildRowLevelBeforeTriggers wraps the mutation input with calls to the
+// BEFORE row-level triggers of the table which fire on the given event, if
+// there are any. The rows for which a trigger returns NULL are filtered out,
+// and for inserts and updates the fields of the row returned by the last
+// trigger become the new values of the visible, non-computed columns of the
+// table. For example:
+//
+//	UPDATE t SET v = 1
+//
+// with BEFORE UPDATE triggers tr1 and tr2 is built as:
+//
+//	SELECT ..., (tr2).k AS k_new, (tr2).v AS v_new, ...
+//	FROM (
+//	  SELECT ..., CASE WHEN tr1 IS NULL THEN NULL ELSE f2((k, v), tr1) END AS tr2
+//	  FROM (SELECT ..., f1((k, v), (k, v_new)) AS tr1 FROM ...)
+//	)
+//	WHERE tr2 IS DISTINCT FROM NULL
+//
+// When building the update of an UPSERT, the triggers only fire for rows which
+// conflict with an existing row.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(event tree.TriggerEvent) {
+	triggers := mb.triggersFiringOn(tree.TriggerActionTimeBefore, event)
+	if len(triggers) == 0 {
+		return
+	}
+	f := mb.b.factory
+	ords := visibleColumnOrdinals(mb.tab)
+
+	// Rows which are inserted by an UPSERT do not fire UPDATE triggers.
+	var skip opt.ScalarExpr
+	if event == tree.TriggerEventUpdate && mb.canaryColID != 0 {
+		skip = f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+	}
+
+	var oldCols, newCols opt.OptionalColList
+	switch event {
+	case tree.TriggerEventInsert:
+		newCols = mb.insertColIDs
+	case tree.TriggerEventUpdate:
+		oldCols = mb.fetchColIDs
+		newCols = make(opt.OptionalColList, len(mb.updateColIDs))
+		for i := range newCols {
+			if newCols[i] = mb.updateColIDs[i]; newCols[i] == 0 {
+				newCols[i] = mb.fetchColIDs[i]
+			}
+		}
+	case tree.TriggerEventDelete:
+		oldCols = mb.fetchColIDs
+	}
+
+	var rowType *types.T
+	var oldRow, newRow opt.ScalarExpr
+	var resultCol opt.ColumnID
+	for _, t := range triggers {
+		name, o := mb.b.resolveTriggerFunction(t)
+		if rowType == nil {
+			rowType = o.Types.(tree.ParamTypes)[0].Typ
+			oldRow = makeTriggerRow(f, rowType, ords, oldCols)
+			newRow = makeTriggerRow(f, rowType, ords, newCols)
+		}
+		call := mb.b.buildRoutine(name, o, memo.ScalarListExpr{oldRow, newRow}, rowType)
+		if skip != nil {
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(skip, f.ConstructNull(rowType))},
+				call,
+			)
+		}
+
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		col := mb.b.synthesizeColumn(
+			projectionsScope, scopeColName("").WithMetadataName(string(t.Name)), rowType, nil /* expr */, call,
+		)
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+		resultCol = col.id
+
+		// Subsequent triggers are not fired for skipped rows. The row returned
+		// by an INSERT or UPDATE trigger is passed as NEW to the next trigger.
+		skip = f.ConstructIs(f.ConstructVariable(resultCol), memo.NullSingleton)
+		if event != tree.TriggerEventDelete {
+			newRow = f.ConstructVariable(resultCol)
+		}
+	}
+
+	// Filter out the skipped rows. When building the update of an UPSERT, the
+	// rows which are inserted are kept.
+	filter := f.ConstructIsNot(f.ConstructVariable(resultCol), memo.NullSingleton)
+	if event == tree.TriggerEventUpdate && mb.canaryColID != 0 {
+		filter = f.ConstructOr(
+			f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+			filter,
+		)
+	}
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(filter)},
+	)
+	if event == tree.TriggerEventDelete {
+		return
+	}
+
+	// Project the fields of the row returned by the last trigger as the new
+	// values of the columns.
+	colIDs := mb.insertColIDs
+	if event == tree.TriggerEventUpdate {
+		colIDs = mb.updateColIDs
+	}
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, ord := range ords {
+		tabCol := mb.tab.Column(ord)
+		if tabCol.IsComputed() {
+			// Computed columns are derived from the new row below.
+			continue
+		}
+		if event == tree.TriggerEventUpdate && colIDs[ord] == 0 &&
+			tabCol.UseOnUpdate(mb.b.evalCtx.SessionData()) {
+			// The ON UPDATE expression of the column is applied below.
+			continue
+		}
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(
+			string(tabCol.ColName()) + "_trigger",
+		)
+		access := f.ConstructColumnAccess(f.ConstructVariable(resultCol), memo.TupleOrdinal(i))
+		col := mb.b.synthesizeColumn(projectionsScope, colName, tabCol.DatumType(), nil /* expr */, access)
+		if colIDs[ord] == 0 {
+			tabColID := mb.tabID.ColumnID(ord)
+			mb.targetColList = append(mb.targetColList, tabColID)
+			mb.targetColSet.Add(tabColID)
+		}
+		colIDs[ord] = col.id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Disambiguate names so that references in computed column expressions
+	// refer to the values returned by the triggers.
+	mb.disambiguateColumns()
+}
+
+// buildAfterTriggers plans the AFTER row-level triggers of the table which
+// fire on any of the given events, if there are any. The mutation input is
+// buffered, and the triggers are fired by a separate query which reads the
+// modified rows from the buffer once the mutation has completed. It must be
+// called once the mutation input has been fully built.
+func (mb *mutationBuilder) buildAfterTriggers(events ...tree.TriggerEvent) {
+	var triggers []*cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		t := mb.tab.Trigger(i)
+		for _, ev := range events {
+			if t.FiresOn(tree.TriggerActionTimeAfter, ev) {
+				triggers = append(triggers, t)
+				break
+			}
+		}
+	}
+	if len(triggers) == 0 {
+		return
+	}
+	mb.ensureWithID()
+
+	var hasOld, hasNew bool
+	for _, ev := range events {
+		hasOld = hasOld || ev != tree.TriggerEventInsert
+		hasNew = hasNew || ev != tree.TriggerEventDelete
+	}
+	ords := visibleColumnOrdinals(mb.tab)
+	var oldCols, newCols opt.ColList
+	if hasOld {
+		oldCols = make(opt.ColList, len(ords), len(ords)+1)
+		for i, ord := range ords {
+			oldCols[i] = mb.fetchColIDs[ord]
+		}
+		if mb.canaryColID != 0 {
+			oldCols = append(oldCols, mb.canaryColID)
+		}
+	}
+	if hasNew {
+		newCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			newCols[i] = mb.mapToReturnColID(ord)
+		}
+	}
+
+	names := make([]tree.Name, len(triggers))
+	for i, t := range triggers {
+		names[i] = t.Name
+	}
+	mb.afterTriggers = &memo.AfterTriggers{
+		Names: names,
+		Builder: &afterTriggersBuilder{
+			mutatedTable: mb.tab,
+			triggers:     triggers,
+			upsert:       mb.canaryColID != 0,
+		},
+		WithID:    mb.withID,
+		OldValues: oldCols,
+		NewValues: newCols,
+	}
+}
+
+// triggersFiringOn returns the row-level triggers of the table which fire on
+// the given event at the given time, in the order in which they fire.
+func (mb *mutationBuilder) triggersFiringOn(
+	actionTime tree.TriggerActionTime, event tree.TriggerEvent,
+) []*cat.Trigger {
+	var triggers []*cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		if t := mb.tab.Trigger(i); t.FiresOn(actionTime, event) {
+			triggers = append(triggers, t)
+		}
+	}
+	return triggers
+}
+
+// resolveTriggerFunction resolves the function called by the given trigger.
+// As in Postgres, the EXECUTE privilege on the function is checked when the
+// trigger is created rather than when it fires.
+func (b *Builder) resolveTriggerFunction(t *cat.Trigger) (string, *tree.Overload) {
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, t.FuncOID)
+	if err != nil {
+		panic(err)
+	}
+	if paramTypes, ok := o.Types.(tree.ParamTypes); !ok || len(paramTypes) != 2 {
+		panic(errors.AssertionFailedf("unexpected parameters of function %s of trigger %q", name, t.Name))
+	}
+	return name, o
+}
+
+// visibleColumnOrdinals returns the ordinals of the visible columns of the
+// table, which are the fields of its implicit record type.
+func visibleColumnOrdinals(tab cat.Table) []int {
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// makeTriggerRow returns a value of the given row type made of the columns in
+// colIDs at the given table ordinals. Columns which have no value are NULL. If
+// colIDs is nil, the row itself is NULL.
+func makeTriggerRow(
+	f *norm.Factory, rowType *types.T, ords []int, colIDs opt.OptionalColList,
+) opt.ScalarExpr {
+	if colIDs == nil {
+		return f.ConstructNull(rowType)
+	}
+	elems := make(memo.ScalarListExpr, len(ords))
+	for i, ord := range ords {
+		if colIDs[ord] == 0 {
+			elems[i] = f.ConstructNull(rowType.TupleContents()[i])
+		} else {
+			elems[i] = f.ConstructVariable(colIDs[ord])
+		}
+	}
+	return f.ConstructTuple(elems, rowType)
+}
+
+// afterTriggersBuilder is a memo.CascadeBuilder implementation which builds
+// the query firing the AFTER row-level triggers of a mutation. The query is
+// equivalent to:
+//
+//	SELECT f1(old, new), f2(old, new), ... FROM original_mutation_input
+//
+// where old and new are values of the table's implicit record type made of
+// the OLD and NEW values of the modified rows. Each trigger function is called
+// once per row, in the order in which the triggers fire. For an UPSERT, the
+// OLD row of the inserted rows is NULL, and triggers which only fire on INSERT
+// or on UPDATE are only called for the inserted or updated rows respectively.
+type afterTriggersBuilder struct {
+	mutatedTable cat.Table
+	triggers     []*cat.Trigger
+
+	// upsert is true if the mutation is an UPSERT, in which case the canary
+	// column follows the OLD values of the mutation input.
+	upsert bool
+}
+
+var _ memo.CascadeBuilder = &afterTriggersBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggersBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		f := b.factory
+		md := f.Metadata()
+
+		// Scan the OLD and NEW values of the modified rows.
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range outCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+		md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		input := f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+		oldCols, newCols := outCols[:len(oldValues)], outCols[len(oldValues):]
+
+		var inserted opt.ScalarExpr
+		if tb.upsert {
+			canary := oldCols[len(oldCols)-1]
+			oldCols = oldCols[:len(oldCols)-1]
+			inserted = f.ConstructIs(f.ConstructVariable(canary), memo.NullSingleton)
+		}
+		ords := visibleColumnOrdinals(tb.mutatedTable)
+		if (len(oldCols) > 0 && len(oldCols) != len(ords)) ||
+			(len(newCols) > 0 && len(newCols) != len(ords)) {
+			panic(errors.AssertionFailedf("unexpected number of OLD and NEW values"))
+		}
+		makeRow := func(rowType *types.T, cols opt.ColList) opt.ScalarExpr {
+			if len(cols) == 0 {
+				return f.ConstructNull(rowType)
+			}
+			elems := make(memo.ScalarListExpr, len(cols))
+			for i := range cols {
+				elems[i] = f.ConstructVariable(cols[i])
+			}
+			return f.ConstructTuple(elems, rowType)
+		}
+		// nullIf returns CASE WHEN cond THEN NULL ELSE expr END.
+		nullIf := func(cond, expr opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+			return f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(cond, f.ConstructNull(typ))},
+				expr,
+			)
+		}
+
+		projections := make(memo.ProjectionsExpr, len(tb.triggers))
+		for i, t := range tb.triggers {
+			name, o := b.resolveTriggerFunction(t)
+			rowType := o.Types.(tree.ParamTypes)[0].Typ
+			oldRow, newRow := makeRow(rowType, oldCols), makeRow(rowType, newCols)
+			if inserted != nil {
+				oldRow = nullIf(inserted, oldRow, rowType)
+			}
+			call := b.buildRoutine(name, o, memo.ScalarListExpr{oldRow, newRow}, rowType)
+			if inserted != nil {
+				onInsert := t.FiresOn(tree.TriggerActionTimeAfter, tree.TriggerEventInsert)
+				onUpdate := t.FiresOn(tree.TriggerActionTimeAfter, tree.TriggerEventUpdate)
+				if !onInsert {
+					call = nullIf(inserted, call, rowType)
+				} else if !onUpdate {
+					call = nullIf(f.ConstructNot(inserted), call, rowType)
+				}
+			}
+			projections[i] = f.ConstructProjectionsItem(call, md.AddColumn(string(t.Name), rowType))
+		}
+		return f.ConstructProject(input, projections, opt.ColSet{})
+	})
+}
@@ -17,9 +17,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
 )
 
 // UniquenessChecksForGenRandomUUIDClusterMode controls the cluster setting for
@@ -197,8 +199,13 @@ type uniqueCheckHelper struct {
 	// UniqueConstraint.
 	uniqueOrdinals intsets.Fast
 
+	// eqOrdinals are the ordinals of the unique columns that are compared with
+	// the = operator. It is equal to uniqueOrdinals unless the constraint is an
+	// exclusion constraint.
+	eqOrdinals intsets.Fast
+
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not included in eqOrdinals.
 	primaryKeyOrdinals intsets.Fast
 
 	// The scope and column ordinals of the scan that will serve as the right
@@ -220,9 +227,13 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	var uniqueOrds, eqOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if h.unique.ExclusionOperator(i) == treecmp.EQ {
+			eqOrds.Add(ord)
+		}
 	}
 
 	// Find the primary key columns that are not part of the unique constraint.
@@ -232,8 +243,12 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// Similarly, we don't need a check for a partial unique constraint if there
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
+	//
+	// For exclusion constraints, only the columns compared with = are taken into
+	// account: if the primary key columns are a subset of those, then no two
+	// distinct rows can conflict.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -241,6 +256,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	}
 
 	h.uniqueOrdinals = uniqueOrds
+	h.eqOrdinals = eqOrds
 	h.primaryKeyOrdinals = primaryOrds
 
 	for tabOrd, ok := h.uniqueOrdinals.Next(0); ok; tabOrd, ok = h.uniqueOrdinals.Next(tabOrd + 1) {
@@ -254,7 +270,9 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 
 		// If one of the columns is a UUID set to gen_random_uuid() and we don't
 		// require uniqueness checks for gen_random_uuid(), unique check not needed.
-		if mb.md.ColumnMeta(colID).Type.Family() == types.UuidFamily &&
+		// This only applies to columns compared with =.
+		if h.eqOrdinals.Contains(tabOrd) &&
+			mb.md.ColumnMeta(colID).Type.Family() == types.UuidFamily &&
 			columnIsGenRandomUUID(mb.outScope.expr, colID) {
 			requireCheck := UniquenessChecksForGenRandomUUIDClusterMode.Get(&mb.b.evalCtx.Settings.SV)
 			if !requireCheck {
@@ -290,8 +308,13 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	if h.eqOrdinals.Empty() {
+		// None of the columns of an exclusion constraint are compared with =, so
+		// they can't form a key.
+		return true
+	}
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	h.eqOrdinals.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -303,6 +326,14 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 // table. The input to the insertion check will be produced from the input to
 // the mutation operator.
 func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
+	// The check cannot see rows inserted by concurrent transactions, so it can
+	// only be relied upon at SERIALIZABLE isolation.
+	kind := "unique"
+	if h.unique.IsExclusion() {
+		kind = "exclusion"
+	}
+	h.mb.b.checkIsolationLevelForConstraintCheck(kind, h.unique.Name())
+
 	f := h.mb.b.factory
 
 	// Build a self semi-join, with the new values on the left and the
@@ -315,6 +346,10 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// For exclusion constraints, the constraint's operator is used in place of
+	// = for each column:
+	//   (new_a <op_a> existing_a) AND (new_b <op_b> existing_b) AND ...
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -326,11 +361,13 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 		numFilters += 2
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+		ord := h.unique.ColumnOrdinal(h.mb.tab, i)
 		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(withScanScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
+			h.constructColumnComparison(
+				h.unique.ExclusionOperator(i),
+				withScanScope.cols[ord],
+				h.scanScope.cols[ord],
 			),
 		))
 	}
@@ -375,8 +412,17 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, withScanScope.cols[i].id)
+	if h.unique.IsExclusion() {
+		// The columns of an exclusion constraint are shown in the order in which
+		// they were declared.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			keyCols = append(keyCols, withScanScope.cols[ord].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, withScanScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -393,6 +439,30 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	})
 }
 
+// constructColumnComparison constructs the comparison between a new value and
+// an existing value of a unique constraint column, using the given operator.
+func (h *uniqueCheckHelper) constructColumnComparison(
+	op treecmp.ComparisonOperatorSymbol, newCol, existingCol scopeColumn,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	left := f.ConstructVariable(newCol.id)
+	right := f.ConstructVariable(existingCol.id)
+	switch op {
+	case treecmp.EQ:
+		return f.ConstructEq(left, right)
+	case treecmp.NE:
+		return f.ConstructNe(left, right)
+	case treecmp.Overlaps:
+		if fam := newCol.typ.Family(); fam == types.GeometryFamily || fam == types.Box2DFamily {
+			// The && operator means "intersects" when used with geometry or bounding
+			// box operands.
+			return f.ConstructBBoxIntersects(left, right)
+		}
+		return f.ConstructOverlaps(left, right)
+	}
+	panic(errors.AssertionFailedf("unsupported exclusion constraint operator: %s", op))
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
@@ -402,18 +472,74 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 		includeSystem:    false,
 		includeInverted:  false,
 	})
+	// After the update we can't guarantee that the constraints are unique
+	// (which is why we need the uniqueness checks in the first place).
+	indexFlags := &tree.IndexFlags{IgnoreUniqueWithoutIndexKeys: true}
+	if h.unique.IsExclusion() {
+		// The conflicting rows of an exclusion constraint must be found with a
+		// lookup into the index that backs it. Otherwise, the check could compare
+		// each new row with every row in the table.
+		if idx := h.exclusionIndex(); idx != nil {
+			indexFlags.Index = tree.UnrestrictedName(idx.Name())
+		}
+	}
 	return h.mb.b.buildScan(
 		tabMeta,
 		ordinals,
-		// After the update we can't guarantee that the constraints are unique
-		// (which is why we need the uniqueness checks in the first place).
-		&tree.IndexFlags{IgnoreUniqueWithoutIndexKeys: true},
+		indexFlags,
 		noRowLocking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	), ordinals
 }
 
+// exclusionIndex returns the index that backs the exclusion constraint, or nil
+// if there is none. If any of the constraint columns are compared with =, it is
+// a non-inverted index with those columns as a prefix. Otherwise, it is an
+// inverted index on a GEOMETRY column that is compared with &&. In both cases,
+// the index must have the same predicate as the constraint. See
+// makeExclusionConstraintIndex in the sql package, which creates the index.
+func (h *uniqueCheckHelper) exclusionIndex() cat.Index {
+	tab := h.mb.tab
+	constraintPred, _ := h.unique.Predicate()
+	var geoOrdinals intsets.Fast
+	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+		ord := h.unique.ColumnOrdinal(tab, i)
+		if h.unique.ExclusionOperator(i) == treecmp.Overlaps &&
+			tab.Column(ord).DatumType().Family() == types.GeometryFamily {
+			geoOrdinals.Add(ord)
+		}
+	}
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		idx := tab.Index(i)
+		if idx.IsNotVisible() {
+			continue
+		}
+		if pred, _ := idx.Predicate(); pred != constraintPred {
+			continue
+		}
+		// Skip the implicit partitioning columns, which are constrained to the
+		// values of the table's partitions when looking up rows.
+		start := idx.ImplicitPartitioningColumnCount()
+		if !h.eqOrdinals.Empty() {
+			if idx.IsInverted() || idx.KeyColumnCount()-start < h.eqOrdinals.Len() {
+				continue
+			}
+			var prefix intsets.Fast
+			for j := start; j < start+h.eqOrdinals.Len(); j++ {
+				prefix.Add(idx.Column(j).Ordinal())
+			}
+			if prefix.Equals(h.eqOrdinals) {
+				return idx
+			}
+		} else if idx.IsInverted() && idx.NonInvertedPrefixColumnCount() == start &&
+			geoOrdinals.Contains(idx.InvertedColumn().InvertedSourceColumnOrdinal()) {
+			return idx
+		}
+	}
+	return nil
+}
+
 // columnIsGenRandomUUID returns true if the expression returns the function
 // gen_random_uuid() for the given column.
 func columnIsGenRandomUUID(e memo.RelExpr, col opt.ColumnID) bool {
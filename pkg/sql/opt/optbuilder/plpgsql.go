@@ -0,0 +1,468 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// buildPLpgSQL builds the body of a PL/pgSQL function with the given
+// parameters and return type.
+//
+// Each variable of the function, including its parameters, is represented by a
+// column. The SQL expressions and queries embedded in the statements of the
+// function are built as separate relational expressions that may reference
+// these columns, in the same way that the statements of a SQL function
+// reference the columns that represent its parameters. The control flow of the
+// function is not built into the expressions; instead, the statements of the
+// body are executed by an interpreter, which runs the expressions as needed and
+// passes the current values of the variables as arguments.
+//
+// buildPLpgSQL returns the program that is executed by the interpreter, the
+// columns that represent the variables of the program, and the expressions
+// embedded in the program.
+func (b *Builder) buildPLpgSQL(
+	body string, params tree.ParamTypes, returnType *types.T,
+) (*plpgsqltree.Program, opt.ColList, memo.RelListExpr) {
+	block, err := plpgsqlparser.Parse(body)
+	if err != nil {
+		panic(err)
+	}
+	pb := plpgsqlBuilder{
+		b:          b,
+		prog:       &plpgsqltree.Program{Block: block},
+		returnType: returnType,
+	}
+
+	// The FOUND variable is declared in an outer scope, so that it can be
+	// shadowed by a parameter with the same name.
+	globalScope := pb.pushScope(nil /* parent */)
+	paramScope := pb.pushScope(globalScope)
+	for i := range params {
+		col := pb.addVar(paramScope, tree.Name(params[i].Name), params[i].Typ, false /* notNull */)
+		col.setParamOrd(i)
+	}
+	pb.prog.FoundIdx = pb.varIdx(pb.addVar(globalScope, "found", types.Bool, false /* notNull */))
+
+	pb.buildBlock(block, paramScope)
+	return pb.prog, pb.cols, pb.body
+}
+
+// plpgsqlBuilder holds the state for building the body of a PL/pgSQL function.
+type plpgsqlBuilder struct {
+	b *Builder
+
+	// prog is the program being built.
+	prog *plpgsqltree.Program
+
+	// cols contains the column that represents each variable in prog.Vars.
+	cols opt.ColList
+
+	// body contains the expressions that are embedded in the program.
+	body memo.RelListExpr
+
+	// constants contains the indexes of the variables that are declared
+	// CONSTANT.
+	constants intsets.Fast
+
+	// returnType is the return type of the function.
+	returnType *types.T
+
+	// labels contains the labeled blocks and loops, and the unlabeled loops,
+	// that enclose the statement being built.
+	labels []plpgsqlLabel
+}
+
+// plpgsqlLabel describes a block or loop that encloses the statement being
+// built.
+type plpgsqlLabel struct {
+	name   string
+	isLoop bool
+}
+
+// plpgsqlScope contains the variables that are declared in a block of a
+// PL/pgSQL function, and the scope in which expressions in the block are built.
+type plpgsqlScope struct {
+	parent *plpgsqlScope
+	vars   map[tree.Name]int
+	s      *scope
+}
+
+// pushScope returns a new scope with the given parent.
+func (pb *plpgsqlBuilder) pushScope(parent *plpgsqlScope) *plpgsqlScope {
+	s := &plpgsqlScope{parent: parent, vars: make(map[tree.Name]int)}
+	if parent == nil {
+		s.s = pb.b.allocScope()
+	} else {
+		s.s = parent.s.push()
+	}
+	return s
+}
+
+// addVar declares a new variable in the given scope and returns the column
+// that represents it. Unnamed variables, i.e. unnamed parameters, can only be
+// referenced by ordinal.
+func (pb *plpgsqlBuilder) addVar(
+	s *plpgsqlScope, name tree.Name, typ *types.T, notNull bool,
+) *scopeColumn {
+	idx := len(pb.prog.Vars)
+	if name != "" {
+		if _, ok := s.vars[name]; ok {
+			panic(pgerror.Newf(pgcode.DuplicateObject, "duplicate declaration of variable %q", name))
+		}
+		s.vars[name] = idx
+	}
+	col := pb.b.synthesizeColumn(s.s, funcParamColName(name, idx), typ, nil /* expr */, nil /* scalar */)
+	pb.prog.Vars = append(pb.prog.Vars, plpgsqltree.Variable{Name: name, Typ: typ, NotNull: notNull})
+	pb.cols = append(pb.cols, col.id)
+	return col
+}
+
+// varIdx returns the index of the variable represented by the given column.
+func (pb *plpgsqlBuilder) varIdx(col *scopeColumn) int {
+	for i := range pb.cols {
+		if pb.cols[i] == col.id {
+			return i
+		}
+	}
+	panic(errors.AssertionFailedf("column %d does not represent a variable", col.id))
+}
+
+// lookupVar returns the index of the variable with the given name that is
+// visible in the given scope. If assign is true, the variable must not be
+// CONSTANT.
+func (pb *plpgsqlBuilder) lookupVar(s *plpgsqlScope, name tree.Name, assign bool) int {
+	for ; s != nil; s = s.parent {
+		if idx, ok := s.vars[name]; ok {
+			if assign && pb.constants.Contains(idx) {
+				panic(pgerror.Newf(pgcode.ErrorInAssignment, "variable %q is declared CONSTANT", name))
+			}
+			return idx
+		}
+	}
+	panic(pgerror.Newf(pgcode.Syntax, "%q is not a known variable", name))
+}
+
+// buildBlock builds the declarations, statements and exception handlers of a
+// block.
+func (pb *plpgsqlBuilder) buildBlock(block *plpgsqltree.Block, parent *plpgsqlScope) {
+	if block.Label != "" {
+		pb.labels = append(pb.labels, plpgsqlLabel{name: block.Label})
+		defer func() { pb.labels = pb.labels[:len(pb.labels)-1] }()
+	}
+	s := pb.pushScope(parent)
+	for _, decl := range block.Decls {
+		typ, err := tree.ResolveType(pb.b.ctx, decl.Typ, pb.b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		pb.trackTypeDeps(typ)
+		if decl.NotNull && decl.Default == nil {
+			panic(pgerror.Newf(pgcode.NullValueNotAllowed,
+				"variable %q must have a default value, since it's declared NOT NULL", decl.Var))
+		}
+		// The default value can reference the variables declared before it.
+		if decl.Default != nil {
+			decl.DefaultIdx = pb.buildAssignedExpr(decl.Default, typ, decl.Var, s)
+		}
+		decl.VarIdx = pb.varIdx(pb.addVar(s, decl.Var, typ, decl.NotNull))
+		if decl.Constant {
+			pb.constants.Add(decl.VarIdx)
+		}
+	}
+	pb.buildStmts(block.Body, s)
+
+	if len(block.Exceptions) > 0 {
+		// The SQLSTATE and SQLERRM variables contain the code and the message of
+		// the error that is being handled.
+		hs := pb.pushScope(s)
+		block.SQLStateIdx = pb.varIdx(pb.addVar(hs, "sqlstate", types.String, false /* notNull */))
+		block.SQLErrMIdx = pb.varIdx(pb.addVar(hs, "sqlerrm", types.String, false /* notNull */))
+		for _, e := range block.Exceptions {
+			pb.buildStmts(e.Body, hs)
+		}
+	}
+}
+
+// trackTypeDeps adds the given type to the type dependencies of the function,
+// if it is a user-defined type and dependencies are being tracked.
+func (pb *plpgsqlBuilder) trackTypeDeps(typ *types.T) {
+	if !pb.b.trackSchemaDeps || !typ.UserDefined() {
+		return
+	}
+	typeIDs, err := typedesc.GetTypeDescriptorClosure(typ)
+	if err != nil {
+		panic(err)
+	}
+	for typeID := range typeIDs {
+		pb.b.schemaTypeDeps.Add(int(typeID))
+	}
+}
+
+// buildStmts builds the given statements in the given scope.
+func (pb *plpgsqlBuilder) buildStmts(stmts []plpgsqltree.Statement, s *plpgsqlScope) {
+	for _, stmt := range stmts {
+		pb.buildStmt(stmt, s)
+	}
+}
+
+// buildStmt builds the expressions embedded in the given statement, and
+// resolves the variables that it references.
+func (pb *plpgsqlBuilder) buildStmt(stmt plpgsqltree.Statement, s *plpgsqlScope) {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		pb.buildBlock(t, s)
+
+	case *plpgsqltree.Assignment:
+		t.VarIdx = pb.lookupVar(s, t.Var, true /* assign */)
+		t.ValueIdx = pb.buildAssignedExpr(t.Value, pb.prog.Vars[t.VarIdx].Typ, t.Var, s)
+
+	case *plpgsqltree.If:
+		t.CondIdx = pb.buildCondition(t.Condition, "IF", s)
+		pb.buildStmts(t.ThenBody, s)
+		for i := range t.ElseIfs {
+			t.ElseIfs[i].CondIdx = pb.buildCondition(t.ElseIfs[i].Condition, "ELSIF", s)
+			pb.buildStmts(t.ElseIfs[i].Body, s)
+		}
+		pb.buildStmts(t.ElseBody, s)
+
+	case *plpgsqltree.Loop:
+		pb.buildLoopBody(t.Label, t.Body, s)
+
+	case *plpgsqltree.While:
+		t.CondIdx = pb.buildCondition(t.Condition, "WHILE", s)
+		pb.buildLoopBody(t.Label, t.Body, s)
+
+	case *plpgsqltree.ForInt:
+		t.LowerIdx = pb.buildIntExpr(t.Lower, "lower bound of FOR loop", s)
+		t.UpperIdx = pb.buildIntExpr(t.Upper, "upper bound of FOR loop", s)
+		if t.Step != nil {
+			t.StepIdx = pb.buildIntExpr(t.Step, "BY value of FOR loop", s)
+		}
+		// The loop variable is only visible inside the loop.
+		ls := pb.pushScope(s)
+		t.VarIdx = pb.varIdx(pb.addVar(ls, t.Var, types.Int, false /* notNull */))
+		pb.buildLoopBody(t.Label, t.Body, ls)
+
+	case *plpgsqltree.ForQuery:
+		var cols []*types.T
+		t.QueryIdx, cols = pb.buildQuery(t.Query, s)
+		t.TargetIdxs = pb.resolveTargets(t.Targets, cols, s)
+		pb.buildLoopBody(t.Label, t.Body, s)
+
+	case *plpgsqltree.Exit:
+		pb.resolveLabel(t.Label, "EXIT")
+		if t.Condition != nil {
+			t.CondIdx = pb.buildCondition(t.Condition, "EXIT", s)
+		}
+
+	case *plpgsqltree.Continue:
+		pb.resolveLabel(t.Label, "CONTINUE")
+		if t.Condition != nil {
+			t.CondIdx = pb.buildCondition(t.Condition, "CONTINUE", s)
+		}
+
+	case *plpgsqltree.Return:
+		if pb.returnType.Family() == types.VoidFamily {
+			if t.Expr != nil {
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"RETURN cannot have a parameter in function returning void"))
+			}
+			return
+		}
+		if t.Expr == nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"RETURN must have a parameter in function returning %s", pb.returnType.Name()))
+		}
+		t.ExprIdx = pb.buildAssignedExpr(t.Expr, pb.returnType, "" /* target */, s)
+
+	case *plpgsqltree.Raise:
+		// The parameters and options are converted to strings by the
+		// expressions, so that they are formatted like the results of a query.
+		t.ParamIdxs = make([]int, len(t.Params))
+		for i := range t.Params {
+			t.ParamIdxs[i] = pb.buildStringExpr(t.Params[i], s)
+		}
+		for i := range t.Options {
+			t.Options[i].ValueIdx = pb.buildStringExpr(t.Options[i].Value, s)
+		}
+
+	case *plpgsqltree.ExecSQL:
+		var cols []*types.T
+		t.SQLIdx, cols = pb.buildQuery(t.SQL, s)
+		if len(t.Into) > 0 {
+			t.IntoIdxs = pb.resolveTargets(t.Into, cols, s)
+		} else if t.SQL.StatementReturnType() == tree.Rows {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.Syntax, "query has no destination for result data"),
+				"If you want to discard the results of a SELECT, use PERFORM instead.",
+			))
+		}
+
+	case *plpgsqltree.Perform:
+		t.QueryIdx, _ = pb.buildQuery(t.Query, s)
+
+	case *plpgsqltree.Null:
+
+	default:
+		panic(errors.AssertionFailedf("unexpected PL/pgSQL statement %T", t))
+	}
+}
+
+// buildLoopBody builds the body of a loop with the given label.
+func (pb *plpgsqlBuilder) buildLoopBody(
+	label string, body []plpgsqltree.Statement, s *plpgsqlScope,
+) {
+	pb.labels = append(pb.labels, plpgsqlLabel{name: label, isLoop: true})
+	pb.buildStmts(body, s)
+	pb.labels = pb.labels[:len(pb.labels)-1]
+}
+
+// resolveLabel checks that the label of an EXIT or CONTINUE statement refers
+// to an enclosing block or loop. If the label is empty, the statement must be
+// inside a loop.
+func (pb *plpgsqlBuilder) resolveLabel(label string, stmtName string) {
+	for i := len(pb.labels) - 1; i >= 0; i-- {
+		l := &pb.labels[i]
+		if label == "" && l.isLoop {
+			return
+		}
+		if label != "" && l.name == label {
+			if !l.isLoop && stmtName == "CONTINUE" {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"block label %q cannot be used in CONTINUE", label))
+			}
+			return
+		}
+	}
+	if label == "" {
+		if stmtName == "EXIT" {
+			panic(pgerror.New(pgcode.Syntax, "EXIT cannot be used outside a loop, unless it has a label"))
+		}
+		panic(pgerror.Newf(pgcode.Syntax, "%s cannot be used outside a loop", stmtName))
+	}
+	panic(pgerror.Newf(pgcode.Syntax,
+		"there is no label %q attached to any block or loop enclosing this statement", label))
+}
+
+// resolveTargets resolves the variables that the columns of a query are
+// assigned to, and checks that the column types can be assigned to them.
+func (pb *plpgsqlBuilder) resolveTargets(
+	targets []tree.Name, cols []*types.T, s *plpgsqlScope,
+) []int {
+	if len(targets) != len(cols) {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"query returns %d columns, but %d variables are specified as targets", len(cols), len(targets)))
+	}
+	idxs := make([]int, len(targets))
+	for i := range targets {
+		idxs[i] = pb.lookupVar(s, targets[i], true /* assign */)
+		pb.checkAssignable(cols[i], pb.prog.Vars[idxs[i]].Typ, targets[i])
+	}
+	return idxs
+}
+
+// checkAssignable checks that a value of type from can be assigned to a
+// variable of type to.
+func (pb *plpgsqlBuilder) checkAssignable(from, to *types.T, target tree.Name) {
+	if !from.Equivalent(to) && !cast.ValidCast(from, to, cast.ContextAssignment) {
+		if target == "" {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"return type mismatch in function declared to return %s", to.Name()))
+		}
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"value type %s doesn't match type %s of variable %q", from.Name(), to.Name(), target))
+	}
+}
+
+// buildAssignedExpr builds an expression whose value is assigned to a variable
+// of the given type, or returned from the function if target is empty.
+func (pb *plpgsqlBuilder) buildAssignedExpr(
+	expr tree.Expr, typ *types.T, target tree.Name, s *plpgsqlScope,
+) int {
+	idx, resultType := pb.buildExpr(expr, typ, s)
+	pb.checkAssignable(resultType, typ, target)
+	return idx
+}
+
+// buildCondition builds a boolean condition of the statement with the given
+// name.
+func (pb *plpgsqlBuilder) buildCondition(expr tree.Expr, stmtName string, s *plpgsqlScope) int {
+	idx, resultType := pb.buildExpr(expr, types.Bool, s)
+	if resultType.Family() != types.BoolFamily && resultType.Family() != types.UnknownFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"argument of %s must be type bool, not type %s", stmtName, resultType.Name()))
+	}
+	return idx
+}
+
+// buildIntExpr builds an integer expression with the given description.
+func (pb *plpgsqlBuilder) buildIntExpr(expr tree.Expr, desc string, s *plpgsqlScope) int {
+	idx, resultType := pb.buildExpr(expr, types.Int, s)
+	if resultType.Family() != types.IntFamily && resultType.Family() != types.UnknownFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"%s must be type int, not type %s", desc, resultType.Name()))
+	}
+	return idx
+}
+
+// buildStringExpr builds an expression that converts the given expression to
+// a string.
+func (pb *plpgsqlBuilder) buildStringExpr(expr tree.Expr, s *plpgsqlScope) int {
+	idx, _ := pb.buildExpr(&tree.CastExpr{Expr: expr, Type: types.String}, types.String, s)
+	return idx
+}
+
+// buildExpr builds the given expression as a query that returns a single
+// column, and returns the index of the query and the type of its result.
+func (pb *plpgsqlBuilder) buildExpr(
+	expr tree.Expr, desired *types.T, s *plpgsqlScope,
+) (idx int, resultType *types.T) {
+	sel := &tree.Select{
+		Select: &tree.SelectClause{Exprs: tree.SelectExprs{{Expr: expr}}},
+	}
+	idx, cols := pb.buildQuery(sel, s, desired)
+	if len(cols) != 1 {
+		panic(errors.AssertionFailedf("expected a single column, found %d", len(cols)))
+	}
+	return idx, cols[0]
+}
+
+// buildQuery builds the given SQL statement, and returns its index and the
+// types of its result columns.
+func (pb *plpgsqlBuilder) buildQuery(
+	stmt tree.Statement, s *plpgsqlScope, desiredTypes ...*types.T,
+) (idx int, cols []*types.T) {
+	stmtScope := pb.b.buildStmt(stmt, desiredTypes, s.s)
+	physProps := stmtScope.makePhysicalProps()
+	cols = make([]*types.T, len(physProps.Presentation))
+	md := pb.b.factory.Metadata()
+	for i := range physProps.Presentation {
+		cols[i] = md.ColumnMeta(physProps.Presentation[i].ID).Type
+	}
+	idx = len(pb.body)
+	pb.body = append(pb.body, memo.RelRequiredPropsExpr{
+		RelExpr:   stmtScope.expr,
+		PhysProps: physProps,
+	})
+	return idx, cols
+}
@@ -0,0 +1,299 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// checkRowLevelSecurityFuncName is the name of the builtin function used to
+// enforce the WITH CHECK expressions of row-level security policies.
+const checkRowLevelSecurityFuncName = "crdb_internal.check_row_level_security"
+
+// rowLevelSecurityApplies returns true if the row-level security policies of
+// the given table must be enforced for the privilege user (see
+// Builder.privilegeUser). Policies are not enforced if row-level security is
+// disabled on the table, for users with the BYPASSRLS role option (which
+// includes admins), and for the owner of the table unless row-level security
+// is forced on it.
+func (b *Builder) rowLevelSecurityApplies(tab cat.Table) bool {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return false
+	}
+	// The policies which apply depend on the current user, so the memo cannot
+	// be reused by other users.
+	b.DisableMemoReuse = true
+	bypass, err := b.catalog.HasRoleOptionForUser(b.ctx, roleoption.BYPASSRLS, b.privilegeUser)
+	if err != nil {
+		panic(err)
+	}
+	if bypass {
+		return false
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		isOwner, err := b.catalog.HasOwnership(b.ctx, tab, b.privilegeUser)
+		if err != nil {
+			panic(err)
+		}
+		if isOwner {
+			return false
+		}
+	}
+	return true
+}
+
+// buildRowLevelSecurityFilter restricts the rows of the given scope, which
+// must contain the columns of the table, to those which the privilege user
+// may access with statements of the given kind. Since UPDATE and DELETE
+// statements read the rows they modify, those rows must also be visible
+// according to the SELECT policies of the table. It is a no-op if row-level
+// security does not apply to the privilege user.
+//
+// The filter is wrapped in a Barrier so that user-supplied filters, which may
+// have side effects or raise errors revealing the contents of rows, are never
+// evaluated on rows that the policies hide.
+func (b *Builder) buildRowLevelSecurityFilter(tab cat.Table, cmd tree.PolicyCommand, s *scope) {
+	if !b.rowLevelSecurityApplies(tab) {
+		return
+	}
+	filter := b.buildPolicyExpr(tab, cmd, s, false /* withCheck */)
+	if cmd != tree.PolicyCommandSelect {
+		selectFilter := b.buildPolicyExpr(tab, tree.PolicyCommandSelect, s, false /* withCheck */)
+		filter = b.factory.ConstructAnd(filter, selectFilter)
+	}
+	s.expr = b.factory.ConstructBarrier(
+		b.factory.ConstructSelect(s.expr, memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)}),
+	)
+}
+
+// buildPolicyExpr builds the boolean expression which rows of the given scope
+// must satisfy according to the policies of the table which apply to the
+// current user and to statements of the given kind. If withCheck is true, the
+// expression applies to new rows and is built from the WITH CHECK expressions
+// of the policies, falling back to their USING expressions; otherwise it is
+// built from the USING expressions.
+//
+// The expressions of the permissive policies are combined with OR, and the
+// result is combined with the expressions of the restrictive policies using
+// AND. If no permissive policy applies, no row satisfies the expression.
+func (b *Builder) buildPolicyExpr(
+	tab cat.Table, cmd tree.PolicyCommand, s *scope, withCheck bool,
+) opt.ScalarExpr {
+	var permissive, restrictive opt.ScalarExpr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		p := tab.Policy(i)
+		if !p.AppliesToCommand(cmd) || !b.policyAppliesToUser(p) {
+			continue
+		}
+		exprStr := p.UsingExpr
+		if withCheck && p.WithCheckExpr != "" {
+			exprStr = p.WithCheckExpr
+		}
+		if exprStr == "" {
+			// The policy has no expression which applies to these rows.
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		texpr := s.resolveAndRequireType(expr, types.Bool)
+		scalar := b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		if p.Restrictive {
+			if restrictive == nil {
+				restrictive = scalar
+			} else {
+				restrictive = b.factory.ConstructAnd(restrictive, scalar)
+			}
+		} else {
+			if permissive == nil {
+				permissive = scalar
+			} else {
+				permissive = b.factory.ConstructOr(permissive, scalar)
+			}
+		}
+	}
+	if permissive == nil {
+		return memo.FalseSingleton
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return b.factory.ConstructAnd(permissive, restrictive)
+}
+
+// policyAppliesToUser returns true if the privilege user is a member of any of
+// the roles to which the policy applies.
+func (b *Builder) policyAppliesToUser(p *cat.Policy) bool {
+	for _, role := range p.Roles {
+		isMember, err := b.catalog.IsMemberOfRole(b.ctx, role, b.privilegeUser)
+		if err != nil {
+			panic(err)
+		}
+		if isMember {
+			return true
+		}
+	}
+	return false
+}
+
+// addRowLevelSecurityCheck ensures that the new rows of an INSERT or UPDATE
+// satisfy the policies of the target table, raising an error otherwise. If
+// returning is true, the new rows are read by the statement, so they must also
+// satisfy the USING expressions of the SELECT policies of the table. The check
+// has the following form:
+//
+//	SELECT * FROM <input>
+//	WHERE crdb_internal.check_row_level_security((<check>) IS true, 't')
+func (mb *mutationBuilder) addRowLevelSecurityCheck(cmd tree.PolicyCommand, returning bool) {
+	if !mb.b.rowLevelSecurityApplies(mb.tab) {
+		return
+	}
+	// Disambiguate names so that references in the policy expressions refer
+	// to the new values of the columns.
+	mb.disambiguateColumns()
+	mb.buildRowLevelSecurityCheck(mb.newRowPolicyExpr(cmd, returning))
+}
+
+// addRowLevelSecurityCheckForUpsert ensures that the rows written by an UPSERT
+// or INSERT ... ON CONFLICT DO UPDATE statement satisfy the policies of the
+// target table, raising an error otherwise. As in Postgres, the inserted rows
+// are checked like the rows of an INSERT. When a row conflicts with an
+// existing row, the existing row must satisfy the USING expressions of the
+// UPDATE and SELECT policies, and the updated row must satisfy the WITH CHECK
+// expressions of the UPDATE policies as well as the USING expressions of the
+// SELECT policies. The existing rows are checked rather than filtered, since
+// an existing row that is hidden would otherwise cause a duplicate key error.
+// The check has the following form:
+//
+//	SELECT * FROM <input>
+//	WHERE crdb_internal.check_row_level_security(
+//	  CASE WHEN <canary> IS NULL THEN <insert check> ELSE <update check> END IS true,
+//	  't'
+//	)
+//
+// It must be called after the upsert columns are projected and disambiguated.
+func (mb *mutationBuilder) addRowLevelSecurityCheckForUpsert(returning bool) {
+	if !mb.b.rowLevelSecurityApplies(mb.tab) {
+		return
+	}
+	f := mb.b.factory
+	insertCheck := mb.newRowPolicyExpr(tree.PolicyCommandInsert, returning)
+	updateCheck := f.ConstructAnd(
+		mb.existingRowPolicyExpr(tree.PolicyCommandUpdate, true /* selectRequired */),
+		mb.newRowPolicyExpr(tree.PolicyCommandUpdate, true /* returning */),
+	)
+	check := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(
+			f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+			insertCheck,
+		)},
+		updateCheck,
+	)
+	mb.buildRowLevelSecurityCheck(check)
+}
+
+// addRowLevelSecurityCheckForMerge ensures that the rows acted upon by the
+// mutation of the given kind built for a MERGE statement satisfy the policies
+// of the target table, raising an error otherwise. The target rows that are
+// hidden by the SELECT policies of the table are not matched by the MERGE
+// statement (see buildMergeInput). As in Postgres, the matched rows must
+// satisfy the USING expressions of the UPDATE or DELETE policies, and the new
+// rows must satisfy the WITH CHECK expressions of the UPDATE or INSERT
+// policies as well as the USING expressions of the SELECT policies, since the
+// target table is always read by a MERGE statement.
+//
+// For UPDATE and INSERT, it must be called after the update or insert columns
+// are added.
+func (mb *mutationBuilder) addRowLevelSecurityCheckForMerge(cmd tree.PolicyCommand) {
+	if !mb.b.rowLevelSecurityApplies(mb.tab) {
+		return
+	}
+	switch cmd {
+	case tree.PolicyCommandDelete:
+		mb.buildRowLevelSecurityCheck(
+			mb.existingRowPolicyExpr(cmd, false /* selectRequired */),
+		)
+	case tree.PolicyCommandUpdate:
+		existingCheck := mb.existingRowPolicyExpr(cmd, false /* selectRequired */)
+		mb.disambiguateColumns()
+		mb.buildRowLevelSecurityCheck(mb.b.factory.ConstructAnd(
+			existingCheck, mb.newRowPolicyExpr(cmd, true /* returning */),
+		))
+	default:
+		mb.disambiguateColumns()
+		mb.buildRowLevelSecurityCheck(mb.newRowPolicyExpr(cmd, true /* returning */))
+	}
+}
+
+// newRowPolicyExpr builds the expression which the new rows of the mutation
+// must satisfy according to the WITH CHECK expressions of the policies for
+// statements of the given kind. If returning is true, the new rows must also
+// satisfy the USING expressions of the SELECT policies. The columns of the
+// output scope of the mutation must have been disambiguated, so that the
+// policy expressions refer to the new values of the columns.
+func (mb *mutationBuilder) newRowPolicyExpr(
+	cmd tree.PolicyCommand, returning bool,
+) opt.ScalarExpr {
+	check := mb.b.buildPolicyExpr(mb.tab, cmd, mb.outScope, true /* withCheck */)
+	if returning {
+		selectCheck := mb.b.buildPolicyExpr(
+			mb.tab, tree.PolicyCommandSelect, mb.outScope, false, /* withCheck */
+		)
+		check = mb.b.factory.ConstructAnd(check, selectCheck)
+	}
+	return check
+}
+
+// existingRowPolicyExpr builds the expression which the existing rows fetched
+// by the mutation must satisfy according to the USING expressions of the
+// policies for statements of the given kind. If selectRequired is true, the
+// existing rows must also satisfy the USING expressions of the SELECT
+// policies.
+func (mb *mutationBuilder) existingRowPolicyExpr(
+	cmd tree.PolicyCommand, selectRequired bool,
+) opt.ScalarExpr {
+	check := mb.b.buildPolicyExpr(mb.tab, cmd, mb.fetchScope, false /* withCheck */)
+	if selectRequired {
+		selectCheck := mb.b.buildPolicyExpr(
+			mb.tab, tree.PolicyCommandSelect, mb.fetchScope, false, /* withCheck */
+		)
+		check = mb.b.factory.ConstructAnd(check, selectCheck)
+	}
+	return check
+}
+
+// buildRowLevelSecurityCheck filters the input of the mutation with the given
+// policy expression, raising an error for the rows which don't satisfy it.
+func (mb *mutationBuilder) buildRowLevelSecurityCheck(check opt.ScalarExpr) {
+	props, overloads := builtinsregistry.GetBuiltinProperties(checkRowLevelSecurityFuncName)
+	private := &memo.FunctionPrivate{
+		Name:       checkRowLevelSecurityFuncName,
+		Typ:        types.Bool,
+		Properties: props,
+		Overload:   &overloads[0],
+	}
+	fn := mb.b.factory.ConstructFunction(memo.ScalarListExpr{
+		mb.b.factory.ConstructIs(check, memo.TrueSingleton),
+		mb.b.factory.ConstructConstVal(tree.NewDString(string(mb.tab.Name())), types.String),
+	}, private)
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr, memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(fn)},
+	)
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
@@ -79,6 +80,12 @@ func (b *Builder) buildScalar(
 
 	switch t := scalar.(type) {
 	case *scopeColumn:
+		if b.procedureParamCols.Contains(t.id) {
+			// A reference to a procedure parameter is replaced with the value of
+			// the argument passed to the CALL statement.
+			out = b.factory.ConstructConstVal(b.ProcedureArgs[t.paramOrd-1], t.typ)
+			return b.finishBuildScalar(t, out, inScope, outScope, outCol)
+		}
 		if inGroupingContext {
 			// Non-grouping column was referenced. Note that a column that is part
 			// of a larger grouping expression would have been detected by the
@@ -173,21 +180,44 @@ func (b *Builder) buildScalar(
 		out = b.factory.ConstructArrayFlatten(s.node, &subqueryPrivate)
 
 	case *tree.IndirectionExpr:
-		if len(t.Indirection) != 1 && t.Expr.(tree.TypedExpr).ResolvedType().Family() == types.ArrayFamily {
-			panic(unimplementedWithIssueDetailf(32552, "ind", "multidimensional indexing is not supported"))
-		}
-
 		out = b.buildScalar(t.Expr.(tree.TypedExpr), inScope, nil, nil, colRefs)
 
+		isSlice := false
 		for _, subscript := range t.Indirection {
 			if subscript.Slice {
-				panic(unimplementedWithIssueDetailf(32551, "", "array slicing is not supported"))
+				isSlice = true
+				break
 			}
+		}
 
-			out = b.factory.ConstructIndirection(
-				out,
-				b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs),
-			)
+		for i, subscript := range t.Indirection {
+			if !isSlice {
+				out = b.factory.ConstructIndirection(
+					out,
+					b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs),
+				)
+				continue
+			}
+
+			// If any of the subscripts is a slice, a subscript that is not a
+			// slice is treated as a slice from 1 to the subscript.
+			private := memo.ArraySlicePrivate{Dimension: i}
+			begin := opt.ScalarExpr(memo.NullSingleton)
+			end := opt.ScalarExpr(memo.NullSingleton)
+			if !subscript.Slice {
+				private.HasEnd = true
+				end = b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs)
+			} else {
+				if subscript.Begin != nil {
+					private.HasBegin = true
+					begin = b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs)
+				}
+				if subscript.End != nil {
+					private.HasEnd = true
+					end = b.buildScalar(subscript.End.(tree.TypedExpr), inScope, nil, nil, colRefs)
+				}
+			}
+			out = b.factory.ConstructArraySlice(out, begin, end, &private)
 		}
 
 	case *tree.IfErrExpr:
@@ -281,7 +311,11 @@ func (b *Builder) buildScalar(
 	case *tree.CastExpr:
 		texpr := t.Expr.(tree.TypedExpr)
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
-		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		if typ := t.ResolvedType(); typ.IsDomain() {
+			out = b.buildDomainCast(arg, typ, false /* assignment */)
+		} else {
+			out = b.factory.ConstructCast(arg, typ)
+		}
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
@@ -552,6 +586,13 @@ func (b *Builder) buildFunction(
 		return b.buildUDF(f, def, inScope, outScope, outCol, colRefs)
 	}
 
+	// GROUPING is evaluated using the grouping columns of the enclosing
+	// aggregation.
+	if def.Name == "grouping" && inScope.inGroupingContext() && !inScope.inAgg &&
+		!inScope.groupby.buildingGroupingCols {
+		return b.buildGroupingFunction(f, inScope, outScope, outCol)
+	}
+
 	if overload.Class == tree.AggregateClass {
 		panic(errors.AssertionFailedf("aggregate function should have been replaced"))
 	}
@@ -635,6 +676,69 @@ func (b *Builder) buildUDF(
 		}
 	}
 
+	// The arguments passed in place of a VARIADIC parameter are collected into
+	// an array.
+	if o.Variadic {
+		paramTypes := o.Types.(tree.ParamTypes)
+		numFixed := len(paramTypes) - 1
+		varArgs := make(memo.ScalarListExpr, len(args)-numFixed)
+		copy(varArgs, args[numFixed:])
+		arr := b.factory.ConstructArray(varArgs, paramTypes[numFixed].Typ)
+		args = append(args[:numFixed:numFixed], arr)
+	}
+
+	// The caller must have the EXECUTE privilege on the function. Inside the
+	// body of a SECURITY DEFINER function, the caller is the owner of that
+	// function.
+	b.checkExecutionPrivilege(o.Oid)
+
+	out = b.buildRoutine(def.Name, o, args, f.ResolvedType())
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// buildRoutine builds the body of the given user-defined function overload
+// and returns a UDF expression which invokes it with the given arguments. The
+// caller is responsible for checking that the function may be executed.
+func (b *Builder) buildRoutine(
+	name string, o *tree.Overload, args memo.ScalarListExpr, typ *types.T,
+) opt.ScalarExpr {
+	// The body of a SECURITY DEFINER function is built with the privileges of
+	// the function owner. Memo reuse is disabled because the privileges of the
+	// owner are not re-checked when the memo is reused.
+	if so := o.SessionOverrides; so != nil && !so.User.Undefined() {
+		b.DisableMemoReuse = true
+		defer func(prevUser username.SQLUsername) { b.privilegeUser = prevUser }(b.privilegeUser)
+		b.privilegeUser = so.User
+	}
+
+	// The body of a PL/pgSQL function is built into a program that is executed
+	// by an interpreter. The variables of the program, including the
+	// parameters, are passed to its statements as arguments.
+	if o.Language == tree.FunctionLangPLpgSQL {
+		var paramTypes tree.ParamTypes
+		if o.Types.Length() > 0 {
+			var ok bool
+			paramTypes, ok = o.Types.(tree.ParamTypes)
+			if !ok {
+				panic(errors.AssertionFailedf("unexpected parameter types %T", o.Types))
+			}
+		}
+		prog, cols, rels := b.buildPLpgSQL(o.Body, paramTypes, typ)
+		return b.factory.ConstructUDF(
+			args,
+			&memo.UDFPrivate{
+				Name:              name,
+				Params:            cols,
+				Body:              rels,
+				Typ:               typ,
+				Volatility:        o.Volatility,
+				CalledOnNullInput: o.CalledOnNullInput,
+				SessionOverrides:  o.SessionOverrides,
+				Program:           prog,
+			},
+		)
+	}
+
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
 	// refer to anything from the outer expression. If there are function
@@ -648,8 +752,7 @@ func (b *Builder) buildUDF(
 	if o.Types.Length() > 0 {
 		paramTypes, ok := o.Types.(tree.ParamTypes)
 		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+			panic(errors.AssertionFailedf("unexpected parameter types %T", o.Types))
 		}
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
@@ -695,9 +798,9 @@ func (b *Builder) buildUDF(
 				for i := range cols {
 					elems[i] = b.factory.ConstructVariable(cols[i].ID)
 				}
-				tup := b.factory.ConstructTuple(elems, f.ResolvedType())
+				tup := b.factory.ConstructTuple(elems, typ)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, tup)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), typ, nil /* expr */, tup)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -706,17 +809,17 @@ func (b *Builder) buildUDF(
 			// its type matches the function return type.
 			returnCol := physProps.Presentation[0].ID
 			returnColMeta := b.factory.Metadata().ColumnMeta(returnCol)
-			if !returnColMeta.Type.Identical(f.ResolvedType()) {
-				if !cast.ValidCast(returnColMeta.Type, f.ResolvedType(), cast.ContextAssignment) {
+			if !returnColMeta.Type.Identical(typ) {
+				if !cast.ValidCast(returnColMeta.Type, typ, cast.ContextAssignment) {
 					panic(sqlerrors.NewInvalidAssignmentCastError(
-						returnColMeta.Type, f.ResolvedType(), returnColMeta.Alias))
+						returnColMeta.Type, typ, returnColMeta.Alias))
 				}
 				cast := b.factory.ConstructAssignmentCast(
 					b.factory.ConstructVariable(physProps.Presentation[0].ID),
-					f.ResolvedType(),
+					typ,
 				)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, cast)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), typ, nil /* expr */, cast)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -728,18 +831,18 @@ func (b *Builder) buildUDF(
 		}
 	}
 
-	out = b.factory.ConstructUDF(
+	return b.factory.ConstructUDF(
 		args,
 		&memo.UDFPrivate{
-			Name:              def.Name,
+			Name:              name,
 			Params:            params,
 			Body:              rels,
-			Typ:               f.ResolvedType(),
+			Typ:               typ,
 			Volatility:        o.Volatility,
 			CalledOnNullInput: o.CalledOnNullInput,
+			SessionOverrides:  o.SessionOverrides,
 		},
 	)
-	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
 }
 
 // buildRangeCond builds a RANGE clause as a simpler expression. Examples:
@@ -916,6 +1019,8 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
 
@@ -120,7 +121,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -130,6 +131,8 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			b.buildRowLevelSecurityFilter(t, tree.PolicyCommandSelect, outScope)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -396,7 +399,12 @@ func (b *Builder) buildScanFromTableRef(
 	locking lockingSpec,
 	inScope *scope,
 ) (outScope *scope) {
-	var ordinals []int
+	allOrdinals := tableOrdinals(tab, columnKinds{
+		includeMutations: false,
+		includeSystem:    true,
+		includeInverted:  false,
+	})
+	ordinals := allOrdinals
 	if ref.Columns != nil {
 		// See tree.TableRef: "Note that a nil [Columns] array means 'unspecified'
 		// (all columns). whereas an array of length 0 means 'zero columns'.
@@ -406,18 +414,38 @@ func (b *Builder) buildScanFromTableRef(
 				"an explicit list of column IDs must include at least one column"))
 		}
 		ordinals = resolveNumericColumnRefs(tab, ref.Columns)
-	} else {
-		ordinals = tableOrdinals(tab, columnKinds{
-			includeMutations: false,
-			includeSystem:    true,
-			includeInverted:  false,
-		})
 	}
 
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
 
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	if ref.Columns == nil || !b.rowLevelSecurityApplies(tab) {
+		outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+		b.buildRowLevelSecurityFilter(tab, tree.PolicyCommandSelect, outScope)
+		return outScope
+	}
+
+	// The policies of the table may reference any of its columns, so all of
+	// them are scanned and filtered before the requested columns are
+	// projected.
+	var scanOrds intsets.Fast
+	for _, ord := range allOrdinals {
+		scanOrds.Add(ord)
+	}
+	for _, ord := range ordinals {
+		if !scanOrds.Contains(ord) {
+			scanOrds.Add(ord)
+			allOrdinals = append(allOrdinals, ord)
+		}
+	}
+	scanScope := b.buildScan(tabMeta, allOrdinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	b.buildRowLevelSecurityFilter(tab, tree.PolicyCommandSelect, scanScope)
+	outScope = scanScope.replace()
+	for _, ord := range ordinals {
+		outScope.appendColumn(scanScope.getColumnForTableOrdinal(ord))
+	}
+	b.constructProjectForScope(scanScope, outScope)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -547,6 +575,16 @@ func (b *Builder) buildScan(
 		// Note: virtual tables should not be collected as view dependencies.
 		return outScope
 	}
+	if tab.IsForeignTable() {
+		if indexFlags != nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"index flags not allowed with foreign tables"))
+		}
+		if locking.isSet() {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"%s not allowed with foreign tables", locking.get().Strength))
+		}
+	}
 
 	// Scanning tables in databases that don't use the SURVIVE ZONE FAILURE option
 	// is disallowed when EnforceHomeRegion is true.
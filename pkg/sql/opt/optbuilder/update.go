@@ -13,7 +13,9 @@ package optbuilder
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -100,11 +102,18 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	// All columns from the update table will be projected.
 	mb.buildInputForUpdate(inScope, upd.Table, upd.From, upd.Where, upd.Limit, upd.OrderBy)
 
+	// Assignments to fields of composite type columns are combined into
+	// assignments to the columns themselves.
+	exprs := mb.combineFieldUpdateExprs(upd.Exprs)
+
 	// Derive the columns that will be updated from the SET expressions.
-	mb.addTargetColsForUpdate(upd.Exprs)
+	mb.addTargetColsForUpdate(exprs)
 
 	// Build each of the SET expressions.
-	mb.addUpdateCols(upd.Exprs)
+	mb.addUpdateCols(exprs)
+
+	// Enforce the row-level security policies of the table.
+	mb.addRowLevelSecurityCheck(tree.PolicyCommandUpdate, resultsNeeded(upd.Returning))
 
 	// Build the final update statement, including any returned expressions.
 	if resultsNeeded(upd.Returning) {
@@ -288,6 +297,127 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	mb.addSynthesizedColsForUpdate()
 }
 
+// combineFieldUpdateExprs returns the given SET expressions with assignments to
+// fields of composite type columns, such as SET a.b = 1, replaced by
+// assignments to the columns themselves. All assignments to fields of the same
+// column are combined into a single assignment of a new composite value, which
+// takes the values of unassigned fields from the existing value:
+//
+//	UPDATE t SET a.b = 1, a.c.d = 2
+//	=>
+//	UPDATE t SET a = ROW(1, ROW(2, ((t.a).c).e)::typ2, (t.a).f)::typ1
+func (mb *mutationBuilder) combineFieldUpdateExprs(exprs tree.UpdateExprs) tree.UpdateExprs {
+	var ret tree.UpdateExprs
+	var assignments map[tree.Name]*fieldAssignment
+	for _, set := range exprs {
+		if len(set.Fields) == 0 {
+			ret = append(ret, set)
+			continue
+		}
+		name := set.Names[0]
+		a, ok := assignments[name]
+		if !ok {
+			ord := findPublicTableColumnByName(mb.tab, name)
+			if ord == -1 {
+				panic(colinfo.NewUndefinedColumnError(string(name)))
+			}
+			if assignments == nil {
+				assignments = make(map[tree.Name]*fieldAssignment)
+			}
+			a = &fieldAssignment{typ: mb.tab.Column(ord).DatumType()}
+			assignments[name] = a
+			// The combined assignment takes the place of the first assignment to
+			// a field of the column.
+			a.set = &tree.UpdateExpr{Names: tree.NameList{name}}
+			ret = append(ret, a.set)
+		}
+		a.add(name, set.Fields, set.Expr)
+	}
+	for name, a := range assignments {
+		a.set.Expr = a.build(tree.NewColumnItem(&mb.alias, name))
+	}
+	return ret
+}
+
+// fieldAssignment is a tree of assignments to a composite type value and its
+// fields, built by combineFieldUpdateExprs.
+type fieldAssignment struct {
+	// set is the combined SET expression for the column, only set at the root
+	// of the tree.
+	set *tree.UpdateExpr
+
+	// typ is the type of the assigned value.
+	typ *types.T
+
+	// expr is the value assigned to the whole value, if any.
+	expr tree.Expr
+
+	// fields contains the assignments to individual fields, keyed by field
+	// ordinal.
+	fields map[int]*fieldAssignment
+}
+
+// add adds an assignment of the given expression to the field of column
+// colName at the given path.
+func (a *fieldAssignment) add(colName tree.Name, path tree.NameList, expr tree.Expr) {
+	for _, field := range path {
+		if a.expr != nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"multiple assignments to the same column %q", colName))
+		}
+		if a.typ.Family() != types.TupleFamily || a.typ.TupleLabels() == nil {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"cannot assign to field %q of column %q because its type %s is not a composite type",
+				field, colName, a.typ))
+		}
+		idx := -1
+		for i, label := range a.typ.TupleLabels() {
+			if label == string(field) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			panic(pgerror.Newf(pgcode.UndefinedColumn,
+				"cannot assign to field %q of column %q because there is no such column in data type %s",
+				field, colName, a.typ))
+		}
+		if a.fields == nil {
+			a.fields = make(map[int]*fieldAssignment)
+		}
+		next, ok := a.fields[idx]
+		if !ok {
+			next = &fieldAssignment{typ: a.typ.TupleContents()[idx]}
+			a.fields[idx] = next
+		}
+		a = next
+	}
+	if a.expr != nil || a.fields != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"multiple assignments to the same column %q", colName))
+	}
+	a.expr = expr
+}
+
+// build returns an expression for the new value, given an expression for the
+// existing value.
+func (a *fieldAssignment) build(existing tree.Expr) tree.Expr {
+	if a.expr != nil {
+		return a.expr
+	}
+	labels := a.typ.TupleLabels()
+	tuple := &tree.Tuple{Exprs: make(tree.Exprs, len(labels))}
+	for i := range labels {
+		field := &tree.ColumnAccessExpr{Expr: existing, ColName: tree.Name(labels[i])}
+		if f, ok := a.fields[i]; ok {
+			tuple.Exprs[i] = f.build(field)
+		} else {
+			tuple.Exprs[i] = field
+		}
+	}
+	return &tree.CastExpr{Expr: tuple, Type: a.typ, SyntaxMode: tree.CastShort}
+}
+
 // addSynthesizedColsForUpdate wraps an Update input expression with a Project
 // operator containing any computed columns that need to be updated. This
 // includes write-only mutation columns that are computed.
@@ -300,6 +430,9 @@ func (mb *mutationBuilder) addSynthesizedColsForUpdate() {
 		mb.outScope.cols[i].mutation = false
 	}
 
+	// Fire the BEFORE UPDATE triggers, which may modify or skip the new rows.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventUpdate)
+
 	// Add non-computed columns that are being dropped or added (mutated) to the
 	// table. These are not visible to queries, and will always be updated to
 	// their default values. This is necessary because they may not yet have been
@@ -346,6 +479,8 @@ func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpdate()
 
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
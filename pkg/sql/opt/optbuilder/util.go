@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // TODO(michae2): Remove this when #70731 is fixed.
@@ -598,6 +599,11 @@ func (b *Builder) resolveTableForMutation(
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// Foreign tables are read-only.
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported, "cannot mutate foreign table %q", tab.Name()))
+	}
+
 	return tab, depName, alias, columns
 }
 
@@ -686,9 +692,19 @@ func (b *Builder) resolveDataSourceRef(
 // of the memo.
 func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv privilege.Kind) {
 	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) {
-		err := b.catalog.CheckPrivilege(b.ctx, ds, priv)
-		if err != nil {
-			panic(err)
+		if b.privilegeUser.Undefined() {
+			if err := b.catalog.CheckPrivilege(b.ctx, ds, priv); err != nil {
+				panic(err)
+			}
+		} else {
+			if err := b.catalog.CheckPrivilegeForUser(b.ctx, ds, priv, b.privilegeUser); err != nil {
+				panic(err)
+			}
+			// The privileges of the current user must not be re-checked when
+			// dependencies are checked. Memo reuse is disabled when calling a
+			// SECURITY DEFINER function, so the privileges of the function
+			// owner are checked every time the statement is built.
+			priv = 0
 		}
 	} else {
 		// The check is skipped, so don't recheck when dependencies are checked.
@@ -700,6 +716,20 @@ func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv pri
 	b.factory.Metadata().AddDependency(name, ds, priv)
 }
 
+// checkExecutionPrivilege ensures that the current user, or the privilege user
+// if it is set, has the EXECUTE privilege on the user-defined function with
+// the given OID. If not, then checkExecutionPrivilege raises an error. As with
+// checkPrivilege, the privilege of the current user is re-checked on reuse of
+// the memo.
+func (b *Builder) checkExecutionPrivilege(funcOID oid.Oid) {
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, funcOID, b.privilegeUser); err != nil {
+		panic(err)
+	}
+	if b.privilegeUser.Undefined() {
+		b.factory.Metadata().AddRoutineDependency(funcOID)
+	}
+}
+
 // resolveNumericColumnRefs converts a list of tree.ColumnIDs from a
 // tree.TableRef to a list of ordinal positions within the given table. Mutation
 // columns are not visible. See tree.Table for more information on column
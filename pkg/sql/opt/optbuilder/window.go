@@ -143,7 +143,7 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		fn := b.constructWindowFn(&w.def, argLists[i])
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -250,29 +250,28 @@ func (b *Builder) buildAggregationAsWindow(
 
 	// Create the window frames based on the orderings and groupings specified.
 	argLists := make([][]opt.ScalarExpr, len(g.aggs))
-	partitions := make([]opt.ColSet, len(g.aggs))
-	orderings := make([]props.OrderingChoice, len(g.aggs))
+	orderings := make([]opt.Ordering, len(g.aggs))
 	filterCols := make([]opt.ColumnID, len(g.aggs))
 
 	// Construct the pre-projection, which renders the grouping columns and the
-	// aggregate arguments, as well as any additional order by columns.
+	// aggregate arguments, as well as any additional order by columns. With
+	// grouping sets, the pre-projection is constructed by
+	// constructGroupingSets.
 	g.aggInScope.appendColumnsFromScope(fromScope)
-	b.constructProjectForScope(fromScope, g.aggInScope)
+	if g.groupingSets == nil {
+		b.constructProjectForScope(fromScope, g.aggInScope)
+	}
 
-	// Build the arguments, partitions and orderings for each aggregate.
+	// Build the arguments, orderings and filters for each aggregate.
 	for i, agg := range g.aggs {
 		argExprs := getTypedExprs(agg.Exprs)
 
 		// Build the appropriate arguments.
 		argLists[i] = b.buildWindowArgs(argExprs, i, agg.def.Name, fromScope, g.aggInScope)
 
-		// Build appropriate partitions.
-		partitions[i] = groupingColSet.Copy()
-
 		// Build appropriate orderings.
 		if !agg.isCommutative() {
-			ord := b.buildWindowOrdering(agg.OrderBy, i, agg.def.Name, fromScope, g.aggInScope, false /* isRangeModeWithOffsets */)
-			orderings[i].FromOrdering(ord)
+			orderings[i] = b.buildWindowOrdering(agg.OrderBy, i, agg.def.Name, fromScope, g.aggInScope, false /* isRangeModeWithOffsets */)
 		}
 
 		if agg.Filter != nil {
@@ -281,14 +280,56 @@ func (b *Builder) buildAggregationAsWindow(
 		}
 	}
 
-	// Initialize the aggregate expression.
-	aggregateExpr := g.aggInScope.expr
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSets(g, fromScope, func(
+			input memo.RelExpr, groupingColSet opt.ColSet, colMap opt.ColMap,
+		) (memo.RelExpr, opt.ColList) {
+			return b.constructGroupingSetWindowGroup(
+				input, groupingColSet, g.aggs, argLists, orderings, filterCols, colMap,
+			)
+		})
+	} else {
+		aggCols := make(opt.ColList, len(g.aggs))
+		for i := range g.aggs {
+			aggCols[i] = g.aggs[i].col.id
+		}
+		aggregateExpr := b.constructAggregateWindows(
+			g.aggInScope.expr, groupingColSet, g.aggs, argLists, orderings, filterCols, aggCols,
+		)
 
+		// Construct a grouping so the values per group are squashed down. Each of
+		// the aggregations built as window functions emit an aggregated value for
+		// each row instead of each group. To rectify this, we must 'squash' the
+		// values down by wrapping it with a GroupBy or ScalarGroupBy.
+		g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, g.aggOutScope)
+	}
+
+	// Wrap with having filter if it exists.
+	if having != nil {
+		input := g.aggOutScope.expr
+		filters := memo.FiltersExpr{b.factory.ConstructFiltersItem(having)}
+		g.aggOutScope.expr = b.factory.ConstructSelect(input, filters)
+	}
+	return g.aggOutScope
+}
+
+// constructAggregateWindows constructs the given aggregates as window
+// functions over the input, partitioned by the grouping columns. The result of
+// each aggregate is output in the corresponding column of aggCols.
+func (b *Builder) constructAggregateWindows(
+	input memo.RelExpr,
+	groupingColSet opt.ColSet,
+	aggInfos []aggregateInfo,
+	argLists [][]opt.ScalarExpr,
+	orderings []opt.Ordering,
+	filterCols []opt.ColumnID,
+	aggCols opt.ColList,
+) memo.RelExpr {
 	// frames accumulates the set of distinct window frames we're computing over
 	// so that we can group functions over the same partition and ordering.
-	frames := make([]memo.WindowExpr, 0, len(g.aggs))
-	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+	frames := make([]memo.WindowExpr, 0, len(aggInfos))
+	for i := range aggInfos {
+		fn := b.constructAggregate(&aggInfos[i].def, argLists[i])
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -296,36 +337,100 @@ func (b *Builder) buildAggregationAsWindow(
 			)
 		}
 
-		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
+		var ordering props.OrderingChoice
+		ordering.FromOrdering(orderings[i])
+		frameIdx := b.findMatchingFrameIndex(&frames, groupingColSet.Copy(), ordering)
 
 		frames[frameIdx].Windows = append(frames[frameIdx].Windows,
 			b.factory.ConstructWindowsItem(
 				fn,
 				&memo.WindowsItemPrivate{
 					Frame: windowAggregateFrame(),
-					Col:   agg.col.id,
+					Col:   aggCols[i],
 				},
 			),
 		)
 	}
 
 	for _, f := range frames {
-		aggregateExpr = b.factory.ConstructWindow(aggregateExpr, f.Windows, &f.WindowPrivate)
+		input = b.factory.ConstructWindow(input, f.Windows, &f.WindowPrivate)
+	}
+	return input
+}
+
+// constructGroupingSetWindowGroup constructs the aggregation of a grouping set
+// with the given grouping columns when the aggregates are built as window
+// functions. See constructGroupingSets for details. The arguments, orderings
+// and filters of the aggregates are remapped with colMap to the columns of the
+// input. Returns the aggregation and the columns of the aggregates.
+func (b *Builder) constructGroupingSetWindowGroup(
+	input memo.RelExpr,
+	groupingColSet opt.ColSet,
+	aggInfos []aggregateInfo,
+	argLists [][]opt.ScalarExpr,
+	orderings []opt.Ordering,
+	filterCols []opt.ColumnID,
+	colMap opt.ColMap,
+) (memo.RelExpr, opt.ColList) {
+	md := b.factory.Metadata()
+	setArgLists := make([][]opt.ScalarExpr, len(aggInfos))
+	setOrderings := make([]opt.Ordering, len(aggInfos))
+	setFilterCols := make([]opt.ColumnID, len(aggInfos))
+	aggCols := make(opt.ColList, len(aggInfos))
+	for i := range aggInfos {
+		setArgLists[i] = make([]opt.ScalarExpr, len(argLists[i]))
+		for j, arg := range argLists[i] {
+			setArgLists[i][j] = b.factory.RemapCols(arg, colMap)
+		}
+		setOrderings[i] = remapOrdering(orderings[i], colMap)
+		if filterCols[i] != 0 {
+			setFilterCols[i] = remapColumn(filterCols[i], colMap)
+		}
+		aggCols[i] = md.AddColumn(md.ColumnMeta(aggInfos[i].col.id).Alias, aggInfos[i].col.typ)
 	}
+	input = b.constructAggregateWindows(
+		input, groupingColSet, aggInfos, setArgLists, setOrderings, setFilterCols, aggCols,
+	)
 
-	// Construct a grouping so the values per group are squashed down. Each of the
-	// aggregations built as window functions emit an aggregated value for each row
-	// instead of each group. To rectify this, we must 'squash' the values down by
-	// wrapping it with a GroupBy or ScalarGroupBy.
-	g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, g.aggOutScope)
+	// Squash the values of each group, as in constructWindowGroup.
+	private := memo.GroupingPrivate{GroupingCols: groupingColSet}
+	private.Ordering.FromOrderingWithOptCols(nil, groupingColSet)
+	aggs := make(memo.AggregationsExpr, len(aggInfos))
+	for i := range aggInfos {
+		aggs[i] = b.factory.ConstructAggregationsItem(
+			b.factory.ConstructConstAgg(b.factory.ConstructVariable(aggCols[i])),
+			aggCols[i],
+		)
+	}
+	if !groupingColSet.Empty() {
+		return b.factory.ConstructGroupBy(input, aggs, &private), aggCols
+	}
 
-	// Wrap with having filter if it exists.
-	if having != nil {
-		input := g.aggOutScope.expr
-		filters := memo.FiltersExpr{b.factory.ConstructFiltersItem(having)}
-		g.aggOutScope.expr = b.factory.ConstructSelect(input, filters)
+	// Replace the NULL values with the default values of the aggregates, as in
+	// constructScalarWindowGroup.
+	scalarAggExpr := b.factory.ConstructScalarGroupBy(input, aggs, &private)
+	var projections memo.ProjectionsExpr
+	var passthrough opt.ColSet
+	for i := range aggInfos {
+		defaultNullVal, requiresProjection := b.overrideDefaultNullValue(aggInfos[i])
+		if !requiresProjection {
+			passthrough.Add(aggCols[i])
+			continue
+		}
+		col := md.AddColumn(md.ColumnMeta(aggCols[i]).Alias, aggInfos[i].col.typ)
+		projections = append(projections, b.factory.ConstructProjectionsItem(
+			b.replaceDefaultReturn(
+				b.factory.ConstructVariable(aggCols[i]),
+				memo.NullSingleton,
+				defaultNullVal),
+			col,
+		))
+		aggCols[i] = col
 	}
-	return g.aggOutScope
+	if len(projections) != 0 {
+		return b.factory.ConstructProject(scalarAggExpr, projections, passthrough), aggCols
+	}
+	return scalarAggExpr, aggCols
 }
 
 // getTypedWindowArgs returns the arguments to the window function as
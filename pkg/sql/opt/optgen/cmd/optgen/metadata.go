@@ -204,6 +204,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"JoinFlags":           {fullName: "memo.JoinFlags", passByVal: true},
 		"WindowFrame":         {fullName: "memo.WindowFrame", passByVal: true},
 		"FKCascades":          {fullName: "memo.FKCascades", passByVal: true},
+		"AfterTriggers":       {fullName: "memo.AfterTriggers", isPointer: true, usePointerIntern: true},
 		"ExplainOptions":      {fullName: "tree.ExplainOptions", passByVal: true},
 		"StatementReturnType": {fullName: "tree.StatementReturnType", passByVal: true},
 		"StatementType":       {fullName: "tree.StatementType", passByVal: true},
@@ -225,6 +226,8 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"Constraint":          {fullName: "constraint.Constraint", isPointer: true, usePointerIntern: true},
 		"FuncProps":           {fullName: "tree.FunctionProperties", isPointer: true, usePointerIntern: true},
 		"FuncOverload":        {fullName: "tree.Overload", isPointer: true, usePointerIntern: true},
+		"RoutineOverrides":    {fullName: "tree.RoutineSessionOverrides", isPointer: true, usePointerIntern: true},
+		"RoutineProgram":      {fullName: "tree.RoutineProgram", isInterface: true},
 		"PhysProps":           {fullName: "physical.Required", isPointer: true},
 		"Presentation":        {fullName: "physical.Presentation", passByVal: true},
 		"RelProps":            {fullName: "props.Relational"},
@@ -119,8 +119,8 @@ func (d *Distribution) FromIndexScan(
 ) {
 	tab := tabMeta.Table
 	index := tab.Index(ord)
-	if index.Table().IsVirtualTable() {
-		// Virtual tables do not have zone configurations.
+	if index.Table().IsVirtualTable() || index.Table().IsForeignTable() {
+		// Virtual and foreign tables do not have zone configurations.
 		return
 	}
 
@@ -223,6 +223,10 @@ type Flags struct {
 	// stable operators.
 	NoStableFolds bool
 
+	// Generic enables exploration rules which only apply when building a
+	// generic query plan.
+	Generic bool
+
 	// IndexVersion controls the version of the index descriptor created in the
 	// test catalog. This field is only used by the exec-ddl command for CREATE
 	// INDEX statements.
@@ -450,6 +454,9 @@ func New(catalog cat.Catalog, sql string) *OptTester {
 //   - no-stable-folds: disallows constant folding for stable operators; only
 //     used with "norm".
 //
+//   - generic: enables exploration rules which only apply when building a
+//     generic query plan; only used with "opt".
+//
 //   - fully-qualify-names: fully qualify all column names in the test output.
 //
 //   - expect: fail the test if the rules specified by name are not "applied".
@@ -945,6 +952,9 @@ func (f *Flags) Set(arg datadriven.CmdArg) error {
 	case "no-stable-folds":
 		f.NoStableFolds = true
 
+	case "generic":
+		f.Generic = true
+
 	case "disable":
 		if len(arg.Vals) == 0 {
 			return fmt.Errorf("disable requires arguments")
@@ -1170,6 +1180,9 @@ func (ot *OptTester) OptimizeWithTables(tables map[cat.StableID]cat.Table) (opt.
 		return !ot.Flags.DisableRules.Contains(int(ruleName))
 	})
 	o.Factory().FoldingControl().AllowStableFolds()
+	if ot.Flags.Generic {
+		o.EnableGenericRules()
+	}
 	return ot.optimizeExpr(o, tables)
 }
 
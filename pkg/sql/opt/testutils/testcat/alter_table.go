@@ -48,6 +48,18 @@ func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 				panic(errors.AssertionFailedf("unsupported constraint type %v", d))
 			}
 
+		case *tree.AlterTableRowLevelSecurity:
+			switch t.Mode {
+			case tree.RowLevelSecurityEnable:
+				tab.rlsEnabled = true
+			case tree.RowLevelSecurityDisable:
+				tab.rlsEnabled = false
+			case tree.RowLevelSecurityForce:
+				tab.rlsForced = true
+			case tree.RowLevelSecurityNoForce:
+				tab.rlsForced = false
+			}
+
 		default:
 			panic(errors.AssertionFailedf("unsupported ALTER TABLE command %T", t))
 		}
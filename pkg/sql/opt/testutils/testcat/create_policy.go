@@ -0,0 +1,44 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package testcat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreatePolicy handles the CREATE POLICY statement.
+func (tc *Catalog) CreatePolicy(stmt *tree.CreatePolicy) {
+	tn := stmt.Table.ToTableName()
+	tc.qualifyTableName(&tn)
+	tab := tc.Table(&tn)
+
+	p := cat.Policy{
+		Name:        stmt.Name,
+		Restrictive: stmt.Type == tree.PolicyTypeRestrictive,
+		Command:     stmt.Command,
+	}
+	if len(stmt.Roles) == 0 {
+		p.Roles = []username.SQLUsername{username.PublicRoleName()}
+	} else {
+		for i := range stmt.Roles {
+			p.Roles = append(p.Roles, username.MakeSQLUsernameFromPreNormalizedString(stmt.Roles[i].Name))
+		}
+	}
+	if stmt.Using != nil {
+		p.UsingExpr = tree.Serialize(stmt.Using)
+	}
+	if stmt.WithCheck != nil {
+		p.WithCheckExpr = tree.Serialize(stmt.WithCheck)
+	}
+	tab.policies = append(tab.policies, p)
+}
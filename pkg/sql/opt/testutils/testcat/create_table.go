@@ -362,11 +362,14 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrable)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -381,6 +384,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.ConstraintNotDeferrable,
 					)
 				} else {
 					tab.addIndex(
@@ -682,13 +686,18 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrable,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrable tree.ConstraintDeferrable,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -709,6 +718,7 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+		deferrable:     deferrable,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -727,6 +737,39 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	// Unlike unique constraints, the columns are not sorted, since each column
+	// is paired with an operator.
+	cols := make([]int, len(def.Elems))
+	ops := make([]treecmp.ComparisonOperatorSymbol, len(def.Elems))
+	var buf bytes.Buffer
+	buf.WriteString(string(tt.TabName.ObjectName))
+	for i := range def.Elems {
+		cols[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		ops[i] = def.Elems[i].Operator.Symbol
+		buf.WriteRune('_')
+		buf.WriteString(string(def.Elems[i].Column))
+	}
+	buf.WriteString("_excl")
+
+	u := UniqueConstraint{
+		name:           string(def.Name),
+		tabID:          tt.TabID,
+		columnOrdinals: cols,
+		withoutIndex:   true,
+		validated:      true,
+		deferrable:     def.Deferrable,
+		exclusionOps:   ops,
+	}
+	if u.name == "" {
+		u.name = buf.String()
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -849,7 +892,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintNotDeferrable,
+		)
 	}
 
 	idx := &Index{
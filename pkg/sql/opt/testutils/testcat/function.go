@@ -68,19 +68,24 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		panic(fmt.Errorf("routine body of BEGIN ATOMIC is not supported"))
 	}
 
-	// Resolve the parameter names and types.
-	paramTypes := make(tree.ParamTypes, len(c.Params))
+	// Resolve the names and types of the input parameters.
+	paramTypes := make(tree.ParamTypes, 0, len(c.Params))
+	variadic := false
 	for i := range c.Params {
 		param := &c.Params[i]
+		if !param.IsInParam() {
+			continue
+		}
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
 		if err != nil {
 			panic(err)
 		}
-		paramTypes.SetAt(i, string(param.Name), typ)
+		paramTypes = append(paramTypes, tree.ParamType{Name: string(param.Name), Typ: typ})
+		variadic = param.Class == tree.FunctionParamVariadic
 	}
 
 	// Resolve the return type.
-	retType, err := tree.ResolveType(context.Background(), c.ReturnType.Type, tc)
+	retType, err := c.ResolveReturnType(context.Background(), tc)
 	if err != nil {
 		panic(err)
 	}
@@ -94,6 +99,7 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 
 	overload := &tree.Overload{
 		Types:             paramTypes,
+		Variadic:          variadic,
 		ReturnType:        tree.FixedReturnType(retType),
 		IsUDF:             true,
 		Body:              body,
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
@@ -249,6 +250,20 @@ func (tc *Catalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privil
 	return tc.CheckAnyPrivilege(ctx, o)
 }
 
+// CheckPrivilegeForUser is part of the cat.Catalog interface.
+func (tc *Catalog) CheckPrivilegeForUser(
+	ctx context.Context, o cat.Object, priv privilege.Kind, user username.SQLUsername,
+) error {
+	return tc.CheckAnyPrivilege(ctx, o)
+}
+
+// CheckExecutionPrivilege is part of the cat.Catalog interface.
+func (tc *Catalog) CheckExecutionPrivilege(
+	ctx context.Context, oid oid.Oid, user username.SQLUsername,
+) error {
+	return nil
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (tc *Catalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	switch t := o.(type) {
@@ -289,6 +304,15 @@ func (tc *Catalog) HasRoleOption(ctx context.Context, roleOption roleoption.Opti
 	return true, nil
 }
 
+// HasRoleOptionForUser is part of the cat.Catalog interface.
+func (tc *Catalog) HasRoleOptionForUser(
+	ctx context.Context, roleOption roleoption.Option, user username.SQLUsername,
+) (bool, error) {
+	// Row-level security policies are not bypassed, so that they can be
+	// tested.
+	return roleOption != roleoption.BYPASSRLS, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (tc *Catalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -301,6 +325,20 @@ func (tc *Catalog) RoleExists(ctx context.Context, role username.SQLUsername) (b
 	return true, nil
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (tc *Catalog) HasOwnership(
+	ctx context.Context, o cat.Object, user username.SQLUsername,
+) (bool, error) {
+	return true, nil
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRole(
+	ctx context.Context, role, user username.SQLUsername,
+) (bool, error) {
+	return true, nil
+}
+
 func (tc *Catalog) resolveSchema(toResolve *cat.SchemaName) (cat.Schema, cat.SchemaName, error) {
 	if string(toResolve.CatalogName) != testDB {
 		return nil, cat.SchemaName{}, pgerror.Newf(pgcode.InvalidSchemaName,
@@ -478,6 +516,10 @@ func (tc *Catalog) ExecuteDDLWithIndexVersion(
 		tc.SetZoneConfig(stmt)
 		return "", nil
 
+	case *tree.CreatePolicy:
+		tc.CreatePolicy(stmt)
+		return "", nil
+
 	case *tree.ShowCreate:
 		tn := stmt.Name.ToTableName()
 		ds, _, err := tc.ResolveDataSource(context.Background(), cat.Flags{}, &tn)
@@ -699,6 +741,13 @@ type Table struct {
 	implicitRBRIndexElem *tree.IndexElem
 
 	homeRegion string
+
+	// rlsEnabled and rlsForced are set by ALTER TABLE ... {ENABLE | FORCE} ROW
+	// LEVEL SECURITY.
+	rlsEnabled bool
+	rlsForced  bool
+
+	policies []cat.Policy
 }
 
 var _ cat.Table = &Table{}
@@ -750,6 +799,11 @@ func (tt *Table) IsVirtualTable() bool {
 	return tt.IsVirtual
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (tt *Table) IsForeignTable() bool {
+	return false
+}
+
 // IsSystemTable is part of the cat.Table interface.
 func (tt *Table) IsSystemTable() bool {
 	return tt.IsSystem
@@ -959,6 +1013,36 @@ func (tt *Table) IsRefreshViewRequired() bool {
 	return false
 }
 
+// TriggerCount is a part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is a part of the cat.Table interface.
+func (tt *Table) Trigger(i int) *cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// IsRowLevelSecurityEnabled is a part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return tt.rlsEnabled
+}
+
+// IsRowLevelSecurityForced is a part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityForced() bool {
+	return tt.rlsForced
+}
+
+// PolicyCount is a part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return len(tt.policies)
+}
+
+// Policy is a part of the cat.Table interface.
+func (tt *Table) Policy(i int) *cat.Policy {
+	return &tt.policies[i]
+}
+
 // Index implements the cat.Index interface for testing purposes.
 type Index struct {
 	IdxName string
@@ -1330,6 +1414,7 @@ type ForeignKeyConstraint struct {
 	matchMethod  tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+	deferrable   tree.ConstraintDeferrable
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1379,7 +1464,7 @@ func (fk *ForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.Tabl
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *ForeignKeyConstraint) Validated() bool {
-	return fk.validated
+	return fk.validated && !fk.deferrable.IsDeferrable()
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -1397,6 +1482,11 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() tree.ConstraintDeferrable {
+	return fk.deferrable
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1406,6 +1496,8 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+	deferrable     tree.ConstraintDeferrable
+	exclusionOps   []treecmp.ComparisonOperatorSymbol
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1448,7 +1540,7 @@ func (u *UniqueConstraint) WithoutIndex() bool {
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *UniqueConstraint) Validated() bool {
-	return u.validated
+	return u.validated && !u.deferrable.IsDeferrable()
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1457,6 +1549,24 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() tree.ConstraintDeferrable {
+	return u.deferrable
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOps == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOps[i]
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
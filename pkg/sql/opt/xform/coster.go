@@ -1584,7 +1584,7 @@ func (c *coster) rowScanCost(tabID opt.TableID, idxOrd int, scannedCols opt.ColS
 	// Adjust cost based on how well the current locality matches the index's
 	// zone constraints.
 	var costFactor memo.Cost = cpuCostFactor
-	if !tab.IsVirtualTable() && len(c.locality.Tiers) != 0 {
+	if !tab.IsVirtualTable() && !tab.IsForeignTable() && len(c.locality.Tiers) != 0 {
 		// If 0% of locality tiers have matching constraints, then add additional
 		// cost. If 100% of locality tiers have matching constraints, then add no
 		// additional cost. Anything in between is proportional to the number of
@@ -98,6 +98,11 @@ type Optimizer struct {
 	// JoinOrderBuilder adds new join orderings to the memo.
 	jb JoinOrderBuilder
 
+	// genericRules is true if exploration rules which only apply to generic
+	// query plans are enabled. It can be set via a call to the
+	// EnableGenericRules method.
+	genericRules bool
+
 	// rng is used to deterministically perturb costs and/or disable rules.
 	rng *rand.Rand
 
@@ -196,6 +201,13 @@ func (o *Optimizer) JoinOrderBuilder() *JoinOrderBuilder {
 	return &o.jb
 }
 
+// EnableGenericRules enables exploration rules which only apply to generic
+// query plans, i.e., plans that are optimized without assigning values to
+// their placeholders and are reused for any placeholder values.
+func (o *Optimizer) EnableGenericRules() {
+	o.genericRules = true
+}
+
 // DisableOptimizations disables all transformation rules, including normalize
 // and explore rules. The unaltered input expression tree becomes the output
 // expression tree (because no transforms are applied).
@@ -11,6 +11,7 @@
 package xform
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
@@ -19,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/partition"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -1862,3 +1864,78 @@ func (c *CustomFuncs) AddPrimaryKeyColsToScanPrivate(sp *memo.ScanPrivate) *memo
 func (c *CustomFuncs) TableIDFromScanPrivate(sp *memo.ScanPrivate) opt.TableID {
 	return sp.Table
 }
+
+// GenericRulesEnabled returns true if exploration rules which only apply to
+// generic query plans are enabled. They are only enabled while a generic plan
+// is being built; see Optimizer.EnableGenericRules.
+func (c *CustomFuncs) GenericRulesEnabled() bool {
+	return c.e.o.genericRules
+}
+
+// GenerateParameterizedJoinValuesAndFilters returns a single-row Values
+// expression which produces the placeholders referenced by the given filters,
+// and a copy of the filters in which each placeholder is replaced by a
+// reference to the corresponding column of the Values expression. ok is false
+// if no filter constrains a column to be equal to a placeholder, since a lookup
+// join could not be built in that case.
+func (c *CustomFuncs) GenerateParameterizedJoinValuesAndFilters(
+	filters memo.FiltersExpr,
+) (values memo.RelExpr, newFilters memo.FiltersExpr, ok bool) {
+	if !hasPlaceholderEquality(filters) {
+		return nil, nil, false
+	}
+
+	var cols opt.ColList
+	var exprs memo.ScalarListExpr
+	var typs []*types.T
+	placeholderCols := make(map[tree.PlaceholderIdx]opt.ColumnID)
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if p, ok := e.(*memo.PlaceholderExpr); ok {
+			idx := p.Value.(*tree.Placeholder).Idx
+			col, ok := placeholderCols[idx]
+			if !ok {
+				col = c.e.f.Metadata().AddColumn(fmt.Sprintf("$%d", idx+1), p.DataType())
+				placeholderCols[idx] = col
+				cols = append(cols, col)
+				exprs = append(exprs, p)
+				typs = append(typs, p.DataType())
+			}
+			return c.e.f.ConstructVariable(col)
+		}
+		return c.e.f.Replace(e, replace)
+	}
+
+	newFilters = make(memo.FiltersExpr, len(filters))
+	for i := range filters {
+		newFilters[i] = c.e.f.ConstructFiltersItem(replace(filters[i].Condition).(opt.ScalarExpr))
+	}
+	values = c.e.f.ConstructValues(
+		memo.ScalarListExpr{c.e.f.ConstructTuple(exprs, types.MakeTuple(typs))},
+		&memo.ValuesPrivate{
+			Cols: cols,
+			ID:   c.e.mem.Metadata().NextUniqueID(),
+		},
+	)
+	return values, newFilters, true
+}
+
+// hasPlaceholderEquality returns true if any of the given filters is an
+// equality between a column and a placeholder.
+func hasPlaceholderEquality(filters memo.FiltersExpr) bool {
+	for i := range filters {
+		if !filters[i].ScalarProps().HasPlaceholder {
+			continue
+		}
+		eq, ok := filters[i].Condition.(*memo.EqExpr)
+		if !ok {
+			continue
+		}
+		left, right := eq.Left.Op(), eq.Right.Op()
+		if (left == opt.VariableOp && right == opt.PlaceholderOp) ||
+			(left == opt.PlaceholderOp && right == opt.VariableOp) {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -37,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -357,6 +359,8 @@ func getDescFromCatalogObjectForPermissions(o cat.Object) (catalog.Descriptor, e
 		return t.desc, nil
 	case *optVirtualTable:
 		return t.desc, nil
+	case *optForeignTable:
+		return t.desc, nil
 	case *optView:
 		return t.desc, nil
 	case *optSequence:
@@ -372,6 +376,8 @@ func getDescForDataSource(o cat.DataSource) (catalog.TableDescriptor, error) {
 		return t.desc, nil
 	case *optVirtualTable:
 		return t.desc, nil
+	case *optForeignTable:
+		return t.desc, nil
 	case *optView:
 		return t.desc, nil
 	case *optSequence:
@@ -393,6 +399,20 @@ func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv pri
 	return oc.planner.CheckPrivilege(ctx, desc, priv)
 }
 
+// CheckPrivilegeForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) CheckPrivilegeForUser(
+	ctx context.Context, o cat.Object, priv privilege.Kind, user username.SQLUsername,
+) error {
+	if o.ID() == 0 {
+		return oc.planner.CheckPrivilegeForUser(ctx, syntheticprivilege.GlobalPrivilegeObject, priv, user)
+	}
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return err
+	}
+	return oc.planner.CheckPrivilegeForUser(ctx, desc, priv, user)
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (oc *optCatalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	desc, err := getDescFromCatalogObjectForPermissions(o)
@@ -402,6 +422,22 @@ func (oc *optCatalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error
 	return oc.planner.CheckAnyPrivilege(ctx, desc)
 }
 
+// CheckExecutionPrivilege is part of the cat.Catalog interface.
+func (oc *optCatalog) CheckExecutionPrivilege(
+	ctx context.Context, oid oid.Oid, user username.SQLUsername,
+) error {
+	desc, err := oc.planner.Descriptors().ByIDWithLeased(oc.planner.Txn()).WithoutNonPublic().Get().Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(oid),
+	)
+	if err != nil {
+		return err
+	}
+	if user.Undefined() {
+		return oc.planner.CheckPrivilege(ctx, desc, privilege.EXECUTE)
+	}
+	return oc.planner.CheckPrivilegeForUser(ctx, desc, privilege.EXECUTE, user)
+}
+
 // HasAdminRole is part of the cat.Catalog interface.
 func (oc *optCatalog) HasAdminRole(ctx context.Context) (bool, error) {
 	return oc.planner.HasAdminRole(ctx)
@@ -419,6 +455,16 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasRoleOptionForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) HasRoleOptionForUser(
+	ctx context.Context, roleOption roleoption.Option, user username.SQLUsername,
+) (bool, error) {
+	if user.Undefined() {
+		return oc.planner.HasRoleOption(ctx, roleOption)
+	}
+	return oc.planner.UserHasRoleOption(ctx, user, roleOption)
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -470,6 +516,38 @@ func (oc *optCatalog) RoleExists(ctx context.Context, role username.SQLUsername)
 	return RoleExists(ctx, oc.planner.ExecCfg().InternalExecutor, oc.planner.Txn(), role)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(
+	ctx context.Context, o cat.Object, user username.SQLUsername,
+) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	if user.Undefined() {
+		return oc.planner.HasOwnership(ctx, desc)
+	}
+	return oc.planner.UserHasOwnership(ctx, desc, user)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(
+	ctx context.Context, role, user username.SQLUsername,
+) (bool, error) {
+	if user.Undefined() {
+		user = oc.planner.User()
+	}
+	if role.IsPublicRole() || role == user {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -494,6 +572,9 @@ func (oc *optCatalog) dataSourceForDesc(
 	case desc.IsSequence():
 		ds = newOptSequence(desc)
 
+	case desc.IsForeignTable():
+		ds = newOptForeignTable(desc)
+
 	default:
 		return nil, errors.AssertionFailedf("unexpected table descriptor: %+v", desc)
 	}
@@ -734,6 +815,12 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// policies is the set of row-level security policies for this table.
+	policies []cat.Policy
+
+	// triggers is the set of row-level triggers for this table.
+	triggers []cat.Trigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -792,6 +879,12 @@ func newOptTable(
 		// written to the primary index and all secondary indexes.
 		if !col.IsVirtual() || pkCols.Contains(col.GetID()) {
 			cd := col.ColumnDesc()
+			defaultExpr := cd.DefaultExpr
+			if typ := col.GetType(); defaultExpr == nil && typ.IsDomain() && typ.TypeMeta.DomainData != nil {
+				// A column of a domain type without a default of its own uses the
+				// default of the domain, if any.
+				defaultExpr = typ.TypeMeta.DomainData.DefaultExpr
+			}
 			ot.columns[col.Ordinal()].Init(
 				col.Ordinal(),
 				cat.StableID(col.GetID()),
@@ -800,7 +893,7 @@ func newOptTable(
 				col.GetType(),
 				col.IsNullable(),
 				visibility,
-				cd.DefaultExpr,
+				defaultExpr,
 				cd.ComputeExpr,
 				cd.OnUpdateExpr,
 				mapGeneratedAsIdentityType(col.GetGeneratedAsIdentityType()),
@@ -873,6 +966,23 @@ func newOptTable(
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+			deferrable:   u.UniqueWithoutIndexDesc().DeferrableMode(),
+		}
+		if ucDesc := u.UniqueWithoutIndexDesc(); ucDesc.IsExclusion() {
+			// The operators correspond to the columns in the order in which they
+			// were declared, so don't sort them.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = ucDesc.ColumnIDs
+			uc.exclusionOps = make([]treecmp.ComparisonOperatorSymbol, len(ucDesc.ExclusionOperators))
+			for j, op := range ucDesc.ExclusionOperators {
+				sym, ok := treecmp.ComparisonOperatorSymbolFromString(op)
+				if !ok {
+					return nil, errors.AssertionFailedf(
+						"invalid operator %q in exclusion constraint %q", op, ucDesc.Name,
+					)
+				}
+				uc.exclusionOps[j] = sym
+			}
 		}
 	}
 
@@ -977,6 +1087,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().DeferrableMode(),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -990,6 +1101,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().DeferrableMode(),
 		})
 	}
 
@@ -1035,6 +1147,48 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Move the row-level security policies into the opt table.
+	if policies := desc.GetPolicies(); len(policies) > 0 {
+		ot.policies = make([]cat.Policy, len(policies))
+		for i := range policies {
+			p := &policies[i]
+			roles := make([]username.SQLUsername, len(p.RoleNames))
+			for j, name := range p.RoleNames {
+				roles[j] = username.MakeSQLUsernameFromPreNormalizedString(name)
+			}
+			ot.policies[i] = cat.Policy{
+				Name:          tree.Name(p.Name),
+				Restrictive:   p.Type == semenumpb.PolicyType_RESTRICTIVE,
+				Command:       policyCommandFromProto(p.Command),
+				Roles:         roles,
+				UsingExpr:     p.UsingExpr,
+				WithCheckExpr: p.WithCheckExpr,
+			}
+		}
+	}
+
+	// Move the row-level triggers into the opt table.
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]cat.Trigger, len(triggers))
+		for i := range triggers {
+			t := &triggers[i]
+			events := make(tree.TriggerEvents, len(t.Events))
+			for j, ev := range t.Events {
+				events[j] = triggerEventFromProto(ev)
+			}
+			actionTime := tree.TriggerActionTimeBefore
+			if t.ActionTime == semenumpb.TriggerActionTime_AFTER {
+				actionTime = tree.TriggerActionTimeAfter
+			}
+			ot.triggers[i] = cat.Trigger{
+				Name:       tree.Name(t.Name),
+				ActionTime: actionTime,
+				Events:     events,
+				FuncOID:    catid.FuncIDToOID(t.FuncID),
+			}
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1146,6 +1300,11 @@ func (ot *optTable) IsVirtualTable() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return false
+}
+
 // IsSystemTable is part of the cat.Table interface.
 func (ot *optTable) IsSystemTable() bool {
 	return catalog.IsSystemDescriptor(ot.desc)
@@ -1375,6 +1534,58 @@ func (ot *optTable) IsRefreshViewRequired() bool {
 	return ot.desc.IsRefreshViewRequired()
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) *cat.Trigger {
+	return &ot.triggers[i]
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.GetRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.GetRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) *cat.Policy {
+	return &ot.policies[i]
+}
+
+// policyCommandFromProto converts a semenumpb.PolicyCommand to the
+// corresponding tree.PolicyCommand.
+func policyCommandFromProto(cmd semenumpb.PolicyCommand) tree.PolicyCommand {
+	for i, v := range tree.PolicyCommandValue {
+		if v == cmd {
+			return tree.PolicyCommand(i)
+		}
+	}
+	panic(errors.AssertionFailedf("unknown policy command %s", cmd))
+}
+
+// triggerEventFromProto converts a semenumpb.TriggerEvent to the corresponding
+// tree.TriggerEvent.
+func triggerEventFromProto(ev semenumpb.TriggerEvent) tree.TriggerEvent {
+	for i, v := range tree.TriggerEventValue {
+		if v == ev {
+			return tree.TriggerEvent(i)
+		}
+	}
+	panic(errors.AssertionFailedf("unknown trigger event %s", ev))
+}
+
 // optIndex is a wrapper around catalog.Index that caches some
 // commonly accessed information and keeps a reference to the table wrapper.
 type optIndex struct {
@@ -1831,6 +2042,11 @@ type optUniqueConstraint struct {
 
 	withoutIndex bool
 	validity     descpb.ConstraintValidity
+	deferrable   tree.ConstraintDeferrable
+
+	// exclusionOps is non-nil for exclusion constraints, and contains the
+	// operator for each column in columns.
+	exclusionOps []treecmp.ComparisonOperatorSymbol
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -1877,7 +2093,9 @@ func (u *optUniqueConstraint) WithoutIndex() bool {
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	// The rows of a deferrable constraint may violate it until the end of the
+	// transaction, so the optimizer cannot rely on it.
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrable.IsDeferrable()
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1888,6 +2106,24 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOps == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOps[i]
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() tree.ConstraintDeferrable {
+	return u.deferrable
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1905,6 +2141,7 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+	deferrable   tree.ConstraintDeferrable
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1958,7 +2195,9 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	// The rows of a deferrable constraint may violate it until the end of the
+	// transaction, so the optimizer cannot rely on it.
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrable.IsDeferrable()
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -1976,6 +2215,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() tree.ConstraintDeferrable {
+	return fk.deferrable
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2159,6 +2403,11 @@ func (ot *optVirtualTable) IsVirtualTable() bool {
 	return true
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return false
+}
+
 // IsSystemTable is part of the cat.Table interface.
 func (ot *optVirtualTable) IsSystemTable() bool {
 	return false
@@ -2325,6 +2574,36 @@ func (ot *optVirtualTable) IsRefreshViewRequired() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) *cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) *cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
 // optVirtualIndex is a dummy implementation of cat.Index for the indexes
 // reported by a virtual table. The index assumes that table column 0 is a dummy
 // PK column.
@@ -2545,6 +2824,454 @@ func (oi *optVirtualFamily) Table() cat.Table {
 	return oi.tab
 }
 
+// optForeignTable is similar to optVirtualTable but is used with foreign
+// tables, whose rows are read from a foreign server when they're queried.
+type optForeignTable struct {
+	desc catalog.TableDescriptor
+
+	// columns contains all the columns presented to the catalog. This includes
+	// the dummy PK column and the columns in the table descriptor. The rows of
+	// a foreign table are not necessarily unique, so the dummy PK column is
+	// needed to avoid the optimizer inferring a key from them.
+	columns []cat.Column
+
+	// index is a synthesized primary index.
+	index optForeignIndex
+
+	// family is a synthesized primary family.
+	family optForeignFamily
+}
+
+var _ cat.Table = &optForeignTable{}
+
+func newOptForeignTable(desc catalog.TableDescriptor) *optForeignTable {
+	ot := &optForeignTable{desc: desc}
+
+	ot.columns = make([]cat.Column, len(desc.PublicColumns())+1)
+	// Init dummy PK column.
+	ot.columns[0].Init(
+		0,
+		math.MaxInt64, /* stableID */
+		"crdb_internal_foreign_pk",
+		cat.Ordinary,
+		types.Int,
+		false,      /* nullable */
+		cat.Hidden, /* hidden */
+		nil,        /* defaultExpr */
+		nil,        /* computedExpr */
+		nil,        /* onUpdateExpr */
+		cat.NotGeneratedAsIdentity,
+		nil, /* generatedAsIdentitySequenceOption */
+	)
+	for i, d := range desc.PublicColumns() {
+		ot.columns[i+1].Init(
+			i+1,
+			cat.StableID(d.GetID()),
+			tree.Name(d.GetName()),
+			cat.Ordinary,
+			d.GetType(),
+			d.IsNullable(),
+			cat.MaybeHidden(d.IsHidden()),
+			nil, /* defaultExpr */
+			nil, /* computedExpr */
+			nil, /* onUpdateExpr */
+			cat.NotGeneratedAsIdentity,
+			nil, /* generatedAsIdentitySequenceOption */
+		)
+	}
+	ot.index.tab = ot
+	ot.family.tab = ot
+	return ot
+}
+
+// ID is part of the cat.Object interface.
+func (ot *optForeignTable) ID() cat.StableID {
+	return cat.StableID(ot.desc.GetID())
+}
+
+// PostgresDescriptorID is part of the cat.Object interface.
+func (ot *optForeignTable) PostgresDescriptorID() catid.DescID {
+	return ot.desc.GetID()
+}
+
+// Equals is part of the cat.Object interface.
+func (ot *optForeignTable) Equals(other cat.Object) bool {
+	otherTable, ok := other.(*optForeignTable)
+	if !ok {
+		return false
+	}
+	if ot == otherTable {
+		// Fast path when it is the same object.
+		return true
+	}
+	return ot.desc.GetID() == otherTable.desc.GetID() &&
+		ot.desc.GetVersion() == otherTable.desc.GetVersion()
+}
+
+// Name is part of the cat.Table interface.
+func (ot *optForeignTable) Name() tree.Name {
+	return tree.Name(ot.desc.GetName())
+}
+
+// IsVirtualTable is part of the cat.Table interface.
+func (ot *optForeignTable) IsVirtualTable() bool {
+	return false
+}
+
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optForeignTable) IsForeignTable() bool {
+	return true
+}
+
+// IsSystemTable is part of the cat.Table interface.
+func (ot *optForeignTable) IsSystemTable() bool {
+	return false
+}
+
+// IsMaterializedView implements the cat.Table interface.
+func (ot *optForeignTable) IsMaterializedView() bool {
+	return false
+}
+
+// ColumnCount is part of the cat.Table interface.
+func (ot *optForeignTable) ColumnCount() int {
+	return len(ot.columns)
+}
+
+// Column is part of the cat.Table interface.
+func (ot *optForeignTable) Column(i int) *cat.Column {
+	return &ot.columns[i]
+}
+
+// getCol is part of optCatalogTableInterface.
+func (ot *optForeignTable) getCol(i int) catalog.Column {
+	if i > 0 && i <= len(ot.desc.PublicColumns()) {
+		return ot.desc.PublicColumns()[i-1]
+	}
+	return nil
+}
+
+// IndexCount is part of the cat.Table interface.
+func (ot *optForeignTable) IndexCount() int {
+	return 1
+}
+
+// WritableIndexCount is part of the cat.Table interface.
+func (ot *optForeignTable) WritableIndexCount() int {
+	return 1
+}
+
+// DeletableIndexCount is part of the cat.Table interface.
+func (ot *optForeignTable) DeletableIndexCount() int {
+	return 1
+}
+
+// Index is part of the cat.Table interface.
+func (ot *optForeignTable) Index(i cat.IndexOrdinal) cat.Index {
+	return &ot.index
+}
+
+// StatisticCount is part of the cat.Table interface.
+func (ot *optForeignTable) StatisticCount() int {
+	return 0
+}
+
+// Statistic is part of the cat.Table interface.
+func (ot *optForeignTable) Statistic(i int) cat.TableStatistic {
+	panic(errors.AssertionFailedf("no stats"))
+}
+
+// CheckCount is part of the cat.Table interface.
+func (ot *optForeignTable) CheckCount() int {
+	return 0
+}
+
+// Check is part of the cat.Table interface.
+func (ot *optForeignTable) Check(i int) cat.CheckConstraint {
+	panic(errors.AssertionFailedf("no checks"))
+}
+
+// FamilyCount is part of the cat.Table interface.
+func (ot *optForeignTable) FamilyCount() int {
+	return 1
+}
+
+// Family is part of the cat.Table interface.
+func (ot *optForeignTable) Family(i int) cat.Family {
+	return &ot.family
+}
+
+// OutboundForeignKeyCount is part of the cat.Table interface.
+func (ot *optForeignTable) OutboundForeignKeyCount() int {
+	return 0
+}
+
+// OutboundForeignKey is part of the cat.Table interface.
+func (ot *optForeignTable) OutboundForeignKey(i int) cat.ForeignKeyConstraint {
+	panic(errors.AssertionFailedf("no FKs"))
+}
+
+// InboundForeignKeyCount is part of the cat.Table interface.
+func (ot *optForeignTable) InboundForeignKeyCount() int {
+	return 0
+}
+
+// InboundForeignKey is part of the cat.Table interface.
+func (ot *optForeignTable) InboundForeignKey(i int) cat.ForeignKeyConstraint {
+	panic(errors.AssertionFailedf("no FKs"))
+}
+
+// UniqueCount is part of the cat.Table interface.
+func (ot *optForeignTable) UniqueCount() int {
+	return 0
+}
+
+// Unique is part of the cat.Table interface.
+func (ot *optForeignTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
+	panic(errors.AssertionFailedf("no unique constraints"))
+}
+
+// Zone is part of the cat.Table interface.
+func (ot *optForeignTable) Zone() cat.Zone {
+	panic(errors.AssertionFailedf("no zone"))
+}
+
+// IsPartitionAllBy is part of the cat.Table interface.
+func (ot *optForeignTable) IsPartitionAllBy() bool {
+	return false
+}
+
+// HomeRegion is part of the cat.Table interface.
+func (ot *optForeignTable) HomeRegion() (region string, ok bool) {
+	return "", false
+}
+
+// IsGlobalTable is part of the cat.Table interface.
+func (ot *optForeignTable) IsGlobalTable() bool {
+	return false
+}
+
+// IsRegionalByRow is part of the cat.Table interface.
+func (ot *optForeignTable) IsRegionalByRow() bool {
+	return false
+}
+
+// IsMultiregion is part of the cat.Table interface.
+func (ot *optForeignTable) IsMultiregion() bool {
+	return false
+}
+
+// HomeRegionColName is part of the cat.Table interface.
+func (ot *optForeignTable) HomeRegionColName() (colName string, ok bool) {
+	return "", false
+}
+
+// GetDatabaseID is part of the cat.Table interface.
+func (ot *optForeignTable) GetDatabaseID() descpb.ID {
+	return ot.desc.GetParentID()
+}
+
+// CollectTypes is part of the cat.DataSource interface.
+func (ot *optForeignTable) CollectTypes(ord int) (descpb.IDs, error) {
+	col := ot.getCol(ord)
+	if col == nil {
+		return nil, nil
+	}
+	return collectTypes(col)
+}
+
+// IsRefreshViewRequired is part of the cat.Table interface.
+func (ot *optForeignTable) IsRefreshViewRequired() bool {
+	return false
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optForeignTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optForeignTable) Trigger(i int) *cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optForeignTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optForeignTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optForeignTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optForeignTable) Policy(i int) *cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// optForeignIndex is a dummy implementation of cat.Index for the only index
+// reported by a foreign table. Its only key column is the dummy PK column, and
+// it stores all the other columns.
+type optForeignIndex struct {
+	tab *optForeignTable
+}
+
+var _ cat.Index = &optForeignIndex{}
+
+// ID is part of the cat.Index interface.
+func (oi *optForeignIndex) ID() cat.StableID {
+	return cat.StableID(0)
+}
+
+// Name is part of the cat.Index interface.
+func (oi *optForeignIndex) Name() tree.Name {
+	return "primary"
+}
+
+// IsUnique is part of the cat.Index interface.
+func (oi *optForeignIndex) IsUnique() bool {
+	return false
+}
+
+// IsInverted is part of the cat.Index interface.
+func (oi *optForeignIndex) IsInverted() bool {
+	return false
+}
+
+// IsNotVisible is part of the cat.Index interface.
+func (oi *optForeignIndex) IsNotVisible() bool {
+	return false
+}
+
+// ExplicitColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) ExplicitColumnCount() int {
+	return 1
+}
+
+// ColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) ColumnCount() int {
+	return oi.tab.ColumnCount()
+}
+
+// KeyColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) KeyColumnCount() int {
+	return 1
+}
+
+// LaxKeyColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) LaxKeyColumnCount() int {
+	return 1
+}
+
+// NonInvertedPrefixColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) NonInvertedPrefixColumnCount() int {
+	panic(errors.AssertionFailedf("foreign indexes are not inverted"))
+}
+
+// Column is part of the cat.Index interface.
+func (oi *optForeignIndex) Column(i int) cat.IndexColumn {
+	return cat.IndexColumn{Column: oi.tab.Column(i)}
+}
+
+// InvertedColumn is part of the cat.Index interface.
+func (oi *optForeignIndex) InvertedColumn() cat.IndexColumn {
+	panic(errors.AssertionFailedf("foreign indexes are not inverted"))
+}
+
+// Predicate is part of the cat.Index interface.
+func (oi *optForeignIndex) Predicate() (string, bool) {
+	return "", false
+}
+
+// Zone is part of the cat.Index interface.
+func (oi *optForeignIndex) Zone() cat.Zone {
+	panic(errors.AssertionFailedf("no zone"))
+}
+
+// Span is part of the cat.Index interface.
+func (oi *optForeignIndex) Span() roachpb.Span {
+	panic(errors.AssertionFailedf("no span"))
+}
+
+// Table is part of the cat.Index interface.
+func (oi *optForeignIndex) Table() cat.Table {
+	return oi.tab
+}
+
+// Ordinal is part of the cat.Index interface.
+func (oi *optForeignIndex) Ordinal() cat.IndexOrdinal {
+	return 0
+}
+
+// ImplicitColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) ImplicitColumnCount() int {
+	return 0
+}
+
+// ImplicitPartitioningColumnCount is part of the cat.Index interface.
+func (oi *optForeignIndex) ImplicitPartitioningColumnCount() int {
+	return 0
+}
+
+// GeoConfig is part of the cat.Index interface.
+func (oi *optForeignIndex) GeoConfig() geoindex.Config {
+	return geoindex.Config{}
+}
+
+// Version is part of the cat.Index interface.
+func (oi *optForeignIndex) Version() descpb.IndexDescriptorVersion {
+	return 0
+}
+
+// PartitionCount is part of the cat.Index interface.
+func (oi *optForeignIndex) PartitionCount() int {
+	return 0
+}
+
+// Partition is part of the cat.Index interface.
+func (oi *optForeignIndex) Partition(i int) cat.Partition {
+	return nil
+}
+
+// optForeignFamily is a dummy implementation of cat.Family for the only family
+// reported by a foreign table.
+type optForeignFamily struct {
+	tab *optForeignTable
+}
+
+var _ cat.Family = &optForeignFamily{}
+
+// ID is part of the cat.Family interface.
+func (oi *optForeignFamily) ID() cat.StableID {
+	return 0
+}
+
+// Name is part of the cat.Family interface.
+func (oi *optForeignFamily) Name() tree.Name {
+	return "primary"
+}
+
+// ColumnCount is part of the cat.Family interface.
+func (oi *optForeignFamily) ColumnCount() int {
+	return oi.tab.ColumnCount()
+}
+
+// Column is part of the cat.Family interface.
+func (oi *optForeignFamily) Column(i int) cat.FamilyColumn {
+	return cat.FamilyColumn{Column: oi.tab.Column(i), Ordinal: i}
+}
+
+// Table is part of the cat.Family interface.
+func (oi *optForeignFamily) Table() cat.Table {
+	return oi.tab
+}
+
 type optCatalogTableInterface interface {
 	// getCol returns the catalog.Column interface backing a given column,
 	// (or nil if it is a virtual column).
@@ -2553,6 +3280,7 @@ type optCatalogTableInterface interface {
 
 var _ optCatalogTableInterface = &optTable{}
 var _ optCatalogTableInterface = &optVirtualTable{}
+var _ optCatalogTableInterface = &optForeignTable{}
 
 // collectTypes walks the given column's default and computed expression,
 // and collects any user defined types it finds. If the column itself is of
@@ -116,6 +116,9 @@ func (ef *execFactory) ConstructScan(
 	if table.IsVirtualTable() {
 		return ef.constructVirtualScan(table, index, params, reqOrdering)
 	}
+	if table.IsForeignTable() {
+		return ef.constructForeignScan(table, params, reqOrdering)
+	}
 
 	tabDesc := table.(*optTable).desc
 	idx := index.(*optIndex).idx
@@ -190,6 +193,45 @@ func (ef *execFactory) constructVirtualScan(
 	)
 }
 
+func (ef *execFactory) constructForeignScan(
+	table cat.Table, params exec.ScanParams, reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	// Check for explicit use of the dummy column.
+	if params.NeededCols.Contains(0) {
+		return nil, errors.Errorf("use of %s column not allowed.", table.Column(0).ColName())
+	}
+	if params.Locking.IsLocking() {
+		// We shouldn't have allowed SELECT FOR UPDATE for a foreign table.
+		return nil, errors.AssertionFailedf("locking cannot be used with foreign table")
+	}
+	ot := table.(*optForeignTable)
+	serverName := ot.desc.GetForeignTable().ServerName
+	if err := ef.planner.checkForeignServer(ef.ctx, serverName); err != nil {
+		return nil, err
+	}
+	scan := &foreignScanNode{
+		desc:       ot.desc,
+		serverName: serverName,
+		hardLimit:  params.HardLimit,
+	}
+	for ord, ok := params.NeededCols.Next(0); ok; ord, ok = params.NeededCols.Next(ord + 1) {
+		col := ot.getCol(ord)
+		scan.cols = append(scan.cols, col)
+		scan.resultColumns = append(scan.resultColumns, colinfo.ResultColumn{
+			Name:           col.GetName(),
+			Typ:            col.GetType(),
+			TableID:        ot.desc.GetID(),
+			PGAttributeNum: uint32(col.GetPGAttributeNum()),
+		})
+	}
+	// Foreign servers don't provide any ordering, so we have to sort if we have
+	// a required ordering.
+	if len(reqOrdering) != 0 {
+		return ef.ConstructSort(scan, reqOrdering, 0 /* alreadyOrderedPrefix */)
+	}
+	return scan, nil
+}
+
 func asDataSource(n exec.Node) planDataSource {
 	plan := n.(planNode)
 	return planDataSource{
@@ -202,6 +244,13 @@ func asDataSource(n exec.Node) planDataSource {
 func (ef *execFactory) ConstructFilter(
 	n exec.Node, filter tree.TypedExpr, reqOrdering exec.OutputOrdering,
 ) (exec.Node, error) {
+	// Push down the filter into a foreign scan if possible.
+	if scan, ok := n.(*foreignScanNode); ok {
+		if filter = scan.pushFilter(filter); filter == nil {
+			return scan, nil
+		}
+	}
+
 	// Create a filterNode.
 	src := asDataSource(n)
 	f := &filterNode{
@@ -527,6 +576,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1127,6 +1177,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefined != nil {
+			p.funcs[i].userDefined = wi.UserDefined[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
@@ -63,6 +63,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`ALTER TENANT foo RENAME TO bar ??`, `ALTER TENANT RENAME`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+
 		{`ALTER TYPE ??`, `ALTER TYPE`},
 		{`ALTER TYPE t ??`, `ALTER TYPE`},
 		{`ALTER TYPE t ADD VALUE ??`, `ALTER TYPE`},
@@ -171,6 +174,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -280,6 +285,9 @@ func TestContextualHelp(t *testing.T) {
 		{`UPDATE blah SET x = 3 ??`, `UPDATE`},
 		{`UPDATE blah SET x = 3 WHERE ??`, `UPDATE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah ??`, `MERGE`},
+
 		{`GRANT ALL ??`, `GRANT`},
 		{`GRANT ALL ON foo TO ??`, `GRANT`},
 		{`GRANT ALL ON foo TO bar ??`, `GRANT`},
@@ -470,6 +478,7 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
 		{`SET TIME ??`, `SET SESSION`},
 		{`SET TIME ZONE 'UTC' ??`, `SET SESSION`},
 		{`SET blah TO ??`, `SET SESSION`},
@@ -526,6 +535,44 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
 		{`ALTER FUNCTION ??`, `ALTER FUNCTION`},
 		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE AGGREGATE a(int) ??`, `CREATE AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+
+		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+		{`CALL ??`, `CALL`},
+
+		{`LISTEN ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+		{`UNLISTEN ??`, `UNLISTEN`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER tr BEFORE ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+		{`DROP TRIGGER tr ??`, `DROP TRIGGER`},
+
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`CREATE POLICY p ON t USING ??`, `CREATE POLICY`},
+		{`DROP POLICY ??`, `DROP POLICY`},
+		{`DROP POLICY p ??`, `DROP POLICY`},
+
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+
+		{`CREATE SERVER ??`, `CREATE SERVER`},
+		{`CREATE SERVER s FOREIGN ??`, `CREATE SERVER`},
+		{`DROP SERVER ??`, `DROP SERVER`},
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE t (a INT) ??`, `CREATE FOREIGN TABLE`},
+		{`DROP FOREIGN TABLE ??`, `DROP FOREIGN TABLE`},
+
+		{`CREATE SUBSCRIPTION ??`, `CREATE SUBSCRIPTION`},
+		{`CREATE SUBSCRIPTION s CONNECTION 'uri' ??`, `CREATE SUBSCRIPTION`},
+		{`DROP SUBSCRIPTION ??`, `DROP SUBSCRIPTION`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -70,17 +70,19 @@ type Statement struct {
 	NumAnnotations tree.AnnotationIdx
 }
 
-// IsANSIDML returns true if the AST is one of the 4 DML statements,
-// SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of these statements.
+// IsANSIDML returns true if the AST is one of the 5 DML statements,
+// SELECT, UPDATE, INSERT, DELETE, MERGE, or an EXPLAIN of one of these
+// statements.
 func (stmt Statement) IsANSIDML() bool {
 	return IsANSIDML(stmt.AST)
 }
 
-// IsANSIDML returns true if the AST is one of the 4 DML statements,
-// SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of these statements.
+// IsANSIDML returns true if the AST is one of the 5 DML statements,
+// SELECT, UPDATE, INSERT, DELETE, MERGE, or an EXPLAIN of one of these
+// statements.
 func IsANSIDML(stmt tree.Statement) bool {
 	switch t := stmt.(type) {
-	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update:
+	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge:
 		return true
 	case *tree.Explain:
 		return IsANSIDML(t.Statement)
@@ -512,10 +514,16 @@ func newDecimal(prec, scale int32) (*types.T, error) {
 }
 
 // arrayOf creates a type alias for an array of the given element type and fixed
-// bounds. The bounds are currently ignored.
+// bounds. Each bound adds a dimension to the array, and a nil bounds slice
+// creates a one-dimensional array. The lengths of the bounds are currently
+// ignored.
 func arrayOf(
 	ref tree.ResolvableTypeReference, bounds []int32,
 ) (tree.ResolvableTypeReference, error) {
+	numDims := len(bounds)
+	if numDims == 0 {
+		numDims = 1
+	}
 	// If the reference is a statically known type, then return an array type,
 	// rather than an array type reference.
 	if typ, ok := tree.GetStaticallyKnownType(ref); ok {
@@ -529,7 +537,21 @@ func arrayOf(
 		if err := types.CheckArrayElementType(typ); err != nil {
 			return nil, err
 		}
-		return types.MakeArray(typ), nil
+		for i := 0; i < numDims; i++ {
+			typ = types.MakeArray(typ)
+		}
+		if err := types.CheckArrayDimensions(typ); err != nil {
+			return nil, err
+		}
+		return typ, nil
+	}
+	if numDims > types.MaxArrayDimensions {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			numDims, types.MaxArrayDimensions)
+	}
+	for i := 0; i < numDims; i++ {
+		ref = &tree.ArrayTypeReference{ElementType: ref}
 	}
-	return &tree.ArrayTypeReference{ElementType: ref}, nil
+	return ref, nil
 }
@@ -536,6 +536,12 @@ func (u *sqlSymUnion) privilegeList() privilege.List {
 func (u *sqlSymUnion) onConflict() *tree.OnConflict {
     return u.val.(*tree.OnConflict)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) orderBy() tree.OrderBy {
     return u.val.(tree.OrderBy)
 }
@@ -620,6 +626,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
@@ -635,6 +647,9 @@ func (u *sqlSymUnion) partitionByTable() *tree.PartitionByTable {
 func (u *sqlSymUnion) partitionByIndex() *tree.PartitionByIndex {
     return u.val.(*tree.PartitionByIndex)
 }
+func (u *sqlSymUnion) partitionBoundSpec() tree.PartitionBoundSpec {
+    return u.val.(tree.PartitionBoundSpec)
+}
 func (u *sqlSymUnion) createTableOnCommitSetting() tree.CreateTableOnCommitSetting {
     return u.val.(tree.CreateTableOnCommitSetting)
 }
@@ -710,6 +725,21 @@ func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
 func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
+func (u *sqlSymUnion) constraintDeferrable() tree.ConstraintDeferrable {
+    return u.val.(tree.ConstraintDeferrable)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() tree.DomainConstraints {
+    return u.val.(tree.DomainConstraints)
+}
 func (u *sqlSymUnion) createStatsOptions() *tree.CreateStatsOptions {
     return u.val.(*tree.CreateStatsOptions)
 }
@@ -831,6 +861,21 @@ func (u *sqlSymUnion) functionObj() tree.FuncObj {
 func (u *sqlSymUnion) functionObjs() tree.FuncObjs {
     return u.val.(tree.FuncObjs)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEvent {
+    return u.val.(tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
 func (u *sqlSymUnion) tenantReplicationOptions() *tree.TenantReplicationOptions {
   return u.val.(*tree.TenantReplicationOptions)
 }
@@ -859,15 +904,15 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AT_AT
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTACH ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
-%token <str> BOOLEAN BOTH BOX2D BUNDLE BY
+%token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
-%token <str> CACHE CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -879,9 +924,9 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_PAUSE_ON DEC DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -904,7 +949,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -914,34 +959,34 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
-%token <str> PROCEDURAL PUBLIC PUBLICATION
+%token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
@@ -951,7 +996,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
 
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -966,7 +1011,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY_BACKUP_TABLE_DATA VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VOLATILE VOTERS
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -1019,6 +1064,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
 %type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
@@ -1118,7 +1164,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 
 %type <tree.Statement> comment_stmt
 %type <tree.Statement> commit_stmt
-%type <tree.Statement> copy_from_stmt
+%type <tree.Statement> copy_from_stmt copy_to_stmt copy_to_query
 
 %type <tree.Statement> create_stmt
 %type <tree.Statement> create_schedule_stmt
@@ -1138,6 +1184,16 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_proc_stmt
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_policy_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.Statement> create_subscription_stmt
 
 %type <tree.Statement> create_stats_stmt
 %type <*tree.CreateStatsOptions> opt_create_stats_options
@@ -1145,6 +1201,10 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
+%type <tree.Expr> opt_domain_default
+%type <tree.DomainConstraints> opt_domain_constraint_list domain_constraint_list
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1157,9 +1217,18 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_policy_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_foreign_table_stmt
+%type <tree.Statement> drop_subscription_stmt
 %type <tree.Statement> drop_tenant_stmt
 %type <bool>           opt_immediate
 
@@ -1198,6 +1267,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1258,17 +1328,21 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> merge_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
 
 %type <tree.Statement> close_cursor_stmt
+%type <tree.Statement> call_stmt
 %type <tree.Statement> declare_cursor_stmt
 %type <tree.Statement> fetch_cursor_stmt
 %type <tree.Statement> move_cursor_stmt
 %type <tree.CursorStmt> cursor_movement_specifier
-%type <bool> opt_hold opt_binary
+%type <bool> opt_hold opt_binary set_constraints_mode
 %type <tree.CursorSensitivity> opt_sensitivity
 %type <tree.CursorScrollOption> opt_scroll
 %type <int64> opt_forward_backward forward_backward
@@ -1277,8 +1351,8 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.Statement> reindex_stmt
 
 %type <[]string> opt_incremental
-%type <tree.KVOption> kv_option
-%type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
+%type <tree.KVOption> kv_option generic_option
+%type <[]tree.KVOption> kv_option_list generic_option_list opt_generic_options opt_with_options var_set_list opt_with_schedule_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_tenant_replication_options tenant_replication_options tenant_replication_options_list
@@ -1328,6 +1402,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.SuperRegion> super_region_clause opt_super_region_clause
 %type <tree.DataPlacement> opt_placement_clause placement_clause
 %type <tree.NameList> region_name_list
+%type <tree.NameList> field_access_ops
 %type <tree.SurvivalGoal> survival_goal_clause opt_survival_goal_clause
 %type <*tree.Locality> locality opt_locality
 %type <int32> opt_connection_limit
@@ -1370,7 +1445,8 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.LikeTableOption> like_table_option
 %type <tree.CreateTableOnCommitSetting> opt_create_table_on_commit
 %type <*tree.PartitionBy> opt_partition_by partition_by partition_by_inner
-%type <*tree.PartitionByTable> opt_partition_by_table partition_by_table
+%type <*tree.PartitionByTable> opt_partition_by_table partition_by_table opt_create_table_partition_by
+%type <tree.PartitionBoundSpec> partition_bound_spec
 %type <*tree.PartitionByIndex> opt_partition_by_index partition_by_index
 %type <str> partition opt_partition
 %type <str> opt_create_table_inherits
@@ -1380,12 +1456,15 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_create_as_data opt_view_recursive
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExcludeElem> exclude_elem
+%type <tree.ExcludeElemList> exclude_elem_list
+%type <str> opt_exclude_access_method
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
 %type <tree.From> from_clause
@@ -1441,6 +1520,9 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.ColumnDefList> opt_col_def_list col_def_list opt_col_def_list_no_types col_def_list_no_types
 %type <tree.ColumnDef> col_def
 %type <*tree.OnConflict> on_conflict
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_insert
+%type <tree.Expr> opt_merge_when_cond
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -1539,6 +1621,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
 %type <tree.ReferenceActions> reference_actions
+%type <tree.ConstraintDeferrable> opt_deferrable
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
@@ -1608,6 +1691,17 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %type <tree.FunctionOptions> opt_create_func_opt_list create_func_opt_list alter_func_opt_list
 %type <tree.FunctionOption> create_func_opt_item common_func_opt_item
 %type <tree.FuncParamClass> func_param_class
+
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvent> trigger_event
+%type <tree.TriggerEvents> trigger_event_list
+
+// Policy relevant components.
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
 %type <*tree.UnresolvedObjectName> func_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
 %type <tree.Statements> routine_body_stmt_list
@@ -1663,7 +1757,7 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND ADJACENT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1707,7 +1801,9 @@ stmt:
 stmt_without_legacy_transaction:
   preparable_stmt            // help texts in sub-rule
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
+| call_stmt                  // EXTEND WITH HELP: CALL
 | copy_from_stmt
+| copy_to_stmt
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt            // EXTEND WITH HELP: DEALLOCATE
@@ -1727,7 +1823,9 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
-| unlisten_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1750,6 +1848,7 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
@@ -1785,9 +1884,12 @@ alter_ddl_stmt:
 //   ALTER TABLE ... PARTITION BY RANGE ( <name...> ) ( <rangespec> )
 //   ALTER TABLE ... PARTITION BY LIST ( <name...> ) ( <listspec> )
 //   ALTER TABLE ... PARTITION BY NOTHING
+//   ALTER TABLE ... ATTACH PARTITION <name> { FOR VALUES <boundspec> | DEFAULT }
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE} ROW LEVEL SECURITY
+//   ALTER TABLE ... [NO] FORCE ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -2757,6 +2859,14 @@ alter_table_cmd:
       PartitionByTable: $1.partitionByTable(),
     }
   }
+  // ALTER TABLE <name> ATTACH PARTITION <name> FOR VALUES ...
+| ATTACH PARTITION table_name partition_bound_spec
+  {
+    $$.val = &tree.AlterTableAttachPartition{
+      Partition: $3.unresolvedObjectName().ToTableName(),
+      Bound: $4.partitionBoundSpec(),
+    }
+  }
   // ALTER TABLE <name> INJECT STATISTICS <json>
 | INJECT STATISTICS a_expr
   {
@@ -2777,6 +2887,26 @@ alter_table_cmd:
       Params: $3.storageParamKeys(),
     }
   }
+  // ALTER TABLE <name> ENABLE ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityEnable}
+  }
+  // ALTER TABLE <name> DISABLE ROW LEVEL SECURITY
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityDisable}
+  }
+  // ALTER TABLE <name> FORCE ROW LEVEL SECURITY
+| FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityForce}
+  }
+  // ALTER TABLE <name> NO FORCE ROW LEVEL SECURITY
+| NO FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityNoForce}
+  }
 
 audit_mode:
   READ WRITE { $$.val = tree.AuditModeReadWrite }
@@ -2799,6 +2929,12 @@ alter_index_cmd:
       PartitionByIndex: $1.partitionByIndex(),
     }
   }
+| ATTACH PARTITION table_index_name
+  {
+    $$.val = &tree.AlterIndexAttachPartition{
+      Index: $3.tableIndexName(),
+    }
+  }
 
 alter_column_default:
   SET DEFAULT a_expr
@@ -2865,6 +3001,78 @@ opt_validate_behavior:
     $$.val = tree.ValidationDefault
   }
 
+// %Help: ALTER DOMAIN - change the definition of a domain.
+// %Category: DDL
+// %Text: ALTER DOMAIN <domain_name> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>) [NOT VALID]
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [RESTRICT | CASCADE]
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD domain_constraint opt_validate_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: $5.domainConstraint(),
+        NotValid: $6.validationBehavior() == tree.ValidationSkip,
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        IfExists: false,
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 // %Help: ALTER TYPE - change the definition of a type.
 // %Category: DDL
 // %Text: ALTER TYPE <typename> <command>
@@ -3711,11 +3919,7 @@ import_format:
   }
 
 alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
+  ALTER AGGREGATE error
   {
     return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
   }
@@ -3894,6 +4098,45 @@ copy_from_stmt:
     return unimplemented(sqllex, "copy from unsupported format")
   }
 
+copy_to_stmt:
+  COPY table_name opt_column_list TO STDOUT opt_with_copy_options
+  {
+    /* FORCE DOC */
+    $$.val = &tree.CopyTo{
+       Table: $2.unresolvedObjectName().ToTableName(),
+       Columns: $3.nameList(),
+       Options: *$6.copyOptions(),
+    }
+  }
+| COPY '(' copy_to_query ')' TO STDOUT opt_with_copy_options
+  {
+    /* FORCE DOC */
+    $$.val = &tree.CopyTo{
+       Statement: $3.stmt(),
+       Options: *$7.copyOptions(),
+    }
+  }
+| COPY table_name opt_column_list TO error
+  {
+    return unimplemented(sqllex, "copy to unsupported destination")
+  }
+| COPY '(' copy_to_query ')' TO error
+  {
+    return unimplemented(sqllex, "copy to unsupported destination")
+  }
+
+// copy_to_query is the set of statements that can produce the rows of a
+// COPY ... TO statement.
+copy_to_query:
+  select_stmt
+  {
+    $$.val = $1.slct()
+  }
+| insert_stmt
+| update_stmt
+| upsert_stmt
+| delete_stmt
+
 opt_with_copy_options:
   opt_with copy_options_list
   {
@@ -4158,6 +4401,7 @@ create_stmt:
 | create_extension_stmt  // EXTEND WITH HELP: CREATE EXTENSION
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
 | create_tenant_stmt // EXTEND WITH HELP: CREATE TENANT
+| create_subscription_stmt // EXTEND WITH HELP: CREATE SUBSCRIPTION
 | create_schedule_stmt
 | create_unsupported   {}
 | CREATE error         // SHOW HELP: CREATE
@@ -4281,8 +4525,91 @@ create_func_stmt:
       RoutineBody: $12.routineBody(),
     }
   }
+| CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    // The return type is derived from the OUT parameters.
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
+    }
+  }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
 
+// %Help: CREATE PROCEDURE - define a new procedure
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] PROCEDURE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//  { LANGUAGE lang_name
+//    | AS 'definition'
+//  } ...
+// %SeeAlso: CALL, DROP PROCEDURE
+create_proc_stmt:
+  CREATE opt_or_replace PROCEDURE func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: true,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      ReturnType: tree.FuncReturnType{
+        Type: types.Void,
+      },
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
+    }
+  }
+| CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE AGGREGATE name ( [ argname ] argtype [, ...] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE AGGREGATE func_create_name '(' opt_func_param_with_default_list ')' '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Name: $3.unresolvedObjectName().ToFunctionName(),
+      Params: $5.functionParams(),
+      Options: $8.aggregateOptions(),
+    }
+  }
+| CREATE AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  name '=' typename
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), Type: $3.typeReference()}
+  }
+| name '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), Value: tree.NewStrVal($3)}
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
@@ -4362,10 +4689,10 @@ func_param:
 
 func_param_class:
   IN { $$.val = tree.FunctionParamIn }
-| OUT { return unimplemented(sqllex, "create function with 'OUT' argument class") }
-| INOUT { return unimplemented(sqllex, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplemented(sqllex, "create function with 'IN OUT' argument class") }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| OUT { $$.val = tree.FunctionParamOut }
+| INOUT { $$.val = tree.FunctionParamInOut }
+| IN OUT { $$.val = tree.FunctionParamInOut }
+| VARIADIC { $$.val = tree.FunctionParamVariadic }
 
 func_param_type:
   typename
@@ -4431,19 +4758,19 @@ common_func_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.FunctionSecurityDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.FunctionSecurityInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.FunctionSecurityDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.FunctionSecurityInvoker
   }
 | LEAKPROOF
   {
@@ -4465,11 +4792,19 @@ common_func_opt_item:
   {
     return unimplemented(sqllex, "create function...support")
   }
-
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function...set") }
+| SET generic_set
+  {
+    $$.val = $2.setVar()
+  }
+| SET var_name FROM CURRENT { return unimplemented(sqllex, "create function...set from current") }
+| RESET session_var
+  {
+    $$.val = &tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}, Reset: true}
+  }
+| RESET_ALL ALL
+  {
+    $$.val = &tree.SetVar{ResetAll: true, Reset: true}
+  }
 | PARALLEL { return unimplemented(sqllex, "create function...parallel") }
 
 func_as:
@@ -4515,6 +4850,87 @@ opt_routine_body:
     $$.val = (*tree.RoutineBody)(nil)
   }
 
+// %Help: DROP POLICY - remove a row-level security policy
+// %Category: DDL
+// %Text: DROP POLICY [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      IfExists: true,
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [ IF EXISTS ] name [, ...]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList()}
+  }
+| DROP PUBLICATION IF EXISTS name_list
+  {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true}
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: DROP SUBSCRIPTION - remove a subscription
+// %Category: CCL
+// %Text: DROP SUBSCRIPTION [ IF EXISTS ] name
+//
+// Dropping a subscription cancels the job replicating the changes of its
+// publication.
+// %SeeAlso: CREATE SUBSCRIPTION
+drop_subscription_stmt:
+  DROP SUBSCRIPTION name
+  {
+    $$.val = &tree.DropSubscription{Name: tree.Name($3)}
+  }
+| DROP SUBSCRIPTION IF EXISTS name
+  {
+    $$.val = &tree.DropSubscription{Name: tree.Name($5), IfExists: true}
+  }
+| DROP SUBSCRIPTION error // SHOW HELP: DROP SUBSCRIPTION
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
 // %Help: DROP FUNCTION - remove a function
 // %Category: DDL
 // %Text:
@@ -4539,6 +4955,54 @@ drop_func_stmt:
   }
 | DROP FUNCTION error // SHOW HELP: DROP FUNCTION
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ argname ] argtype [, ...] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: DROP FUNCTION
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropAggregate{
+      Aggregates: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropAggregate{
+      IfExists: true,
+      Aggregates: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PROCEDURE
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropProcedure{
+      Procedures: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropProcedure{
+      IfExists: true,
+      Procedures: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -4654,24 +5118,287 @@ opt_no:
     $$.val = false
   }
 
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER } event [ OR ... ]
+//    ON table_name
+//    FOR [ EACH ] ROW
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( )
+//
+// where event can be one of:
+//    INSERT
+//    UPDATE
+//    DELETE
+//
+// The trigger function must accept the OLD and NEW rows, in that order, as
+// arguments of the table's row type and return a value of that same type.
+// Functions returning the trigger pseudo-type are not supported.
+// %SeeAlso: CREATE FUNCTION, DROP TRIGGER
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list ON table_name
+  FOR opt_each trigger_for_type EXECUTE function_or_procedure db_object_name '(' ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      Table: $8.unresolvedObjectName(),
+      FuncName: $14.unresolvedObjectName().ToFunctionName(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE { $$.val = tree.TriggerActionTimeBefore }
+| AFTER { $$.val = tree.TriggerActionTimeAfter }
+| INSTEAD OF { return unimplementedWithIssueDetail(sqllex, 28296, "instead of trigger") }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT { $$.val = tree.TriggerEventInsert }
+| UPDATE { $$.val = tree.TriggerEventUpdate }
+| UPDATE OF error { return unimplementedWithIssueDetail(sqllex, 28296, "update of trigger") }
+| DELETE { $$.val = tree.TriggerEventDelete }
+| TRUNCATE { return unimplementedWithIssueDetail(sqllex, 28296, "truncate trigger") }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_for_type:
+  ROW {}
+| STATEMENT { return unimplementedWithIssueDetail(sqllex, 28296, "statement-level trigger") }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+// %Help: CREATE POLICY - define a new row-level security policy for a table
+// %Category: DDL
+// %Text:
+// CREATE POLICY name ON table_name
+//    [ AS { PERMISSIVE | RESTRICTIVE } ]
+//    [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//    [ TO { role_name | PUBLIC | CURRENT_USER | SESSION_USER } [, ...] ]
+//    [ USING ( using_expression ) ]
+//    [ WITH CHECK ( check_expression ) ]
+//
+// Policies only apply to tables on which row-level security has been enabled
+// with ALTER TABLE ... ENABLE ROW LEVEL SECURITY.
+// %SeeAlso: DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command opt_policy_roles opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      Type: $6.policyType(),
+      Command: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Using: $9.expr(),
+      WithCheck: $10.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE { $$.val = tree.PolicyTypePermissive }
+| AS RESTRICTIVE { $$.val = tree.PolicyTypeRestrictive }
+| /* EMPTY */ { $$.val = tree.PolicyTypePermissive }
+
+opt_policy_command:
+  FOR ALL { $$.val = tree.PolicyCommandAll }
+| FOR SELECT { $$.val = tree.PolicyCommandSelect }
+| FOR INSERT { $$.val = tree.PolicyCommandInsert }
+| FOR UPDATE { $$.val = tree.PolicyCommandUpdate }
+| FOR DELETE { $$.val = tree.PolicyCommandDelete }
+| /* EMPTY */ { $$.val = tree.PolicyCommandAll }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// %Help: CREATE PUBLICATION - define a set of tables to replicate
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION name FOR TABLE table_name [, ...]
+//
+// The changes to the tables of a publication can be replicated to another
+// cluster with CREATE SUBSCRIPTION.
+// %SeeAlso: DROP PUBLICATION, CREATE SUBSCRIPTION
+create_publication_stmt:
+  CREATE PUBLICATION name FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{
+      Name: tree.Name($3),
+      Tables: $6.tableNames(),
+    }
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: CREATE SUBSCRIPTION - replicate the tables of a publication
+// %Category: CCL
+// %Text:
+// CREATE SUBSCRIPTION name CONNECTION <uri> PUBLICATION publication_name
+//    [ WITH <option> [= <value>] [, ...] ]
+//
+// Options:
+//    conflict_resolution = 'last_write_wins' | 'error'
+//
+// The changes to the tables of the publication on the cluster at <uri> are
+// applied to the tables with the same names in the current database.
+// %SeeAlso: DROP SUBSCRIPTION, CREATE PUBLICATION
+create_subscription_stmt:
+  CREATE SUBSCRIPTION name CONNECTION string_or_placeholder PUBLICATION name opt_with_options
+  {
+    $$.val = &tree.CreateSubscription{
+      Name: tree.Name($3),
+      ConnectionURI: $5.expr(),
+      Publication: tree.Name($7),
+      Options: $8.kvOptions(),
+    }
+  }
+| CREATE SUBSCRIPTION error // SHOW HELP: CREATE SUBSCRIPTION
+
+// %Help: CREATE SERVER - define a foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [IF NOT EXISTS] <name> FOREIGN DATA WRAPPER postgres_fdw
+//    [ OPTIONS ( <option> '<value>' [, ...] ) ]
+//
+// Options:
+//    host, port, dbname, user, password, and any other connection parameter
+//
+// The connection details of the server are stored as an external connection
+// with the same name.
+// %SeeAlso: DROP SERVER, CREATE FOREIGN TABLE
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_generic_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.kvOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_generic_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($6),
+      IfNotExists: true,
+      Wrapper: tree.Name($10),
+      Options: $11.kvOptions(),
+    }
+  }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+// %Help: CREATE FOREIGN TABLE - define a table stored on a foreign server
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <coldef> [, ...] )
+//    SERVER <server_name> [ OPTIONS ( <option> '<value>' [, ...] ) ]
+//
+// Options:
+//    schema_name = name of the remote schema (default: public)
+//    table_name  = name of the remote table (default: <tablename>)
+// %SeeAlso: DROP FOREIGN TABLE, CREATE SERVER
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_generic_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_generic_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_generic_options:
+  OPTIONS '(' generic_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+generic_option_list:
+  generic_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+| generic_option_list ',' generic_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+generic_option:
+  name SCONST
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.NewStrVal($2)}
+  }
+
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
-| CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -4681,25 +5408,48 @@ opt_procedural:
   PROCEDURAL {}
 | /* EMPTY */ {}
 
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE SERVER
+drop_server_stmt:
+  DROP SERVER name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropServer{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP SERVER IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropServer{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP SERVER error // SHOW HELP: DROP SERVER
+
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropForeignTable{Names: $4.tableNames(), DropBehavior: $5.dropBehavior()}
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropForeignTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior()}
+  }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
+
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
-| DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -4710,9 +5460,17 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
+| create_server_stmt   // EXTEND WITH HELP: CREATE SERVER
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5051,6 +5809,7 @@ drop_stmt:
 | drop_schedule_stmt // EXTEND WITH HELP: DROP SCHEDULES
 | drop_external_connection_stmt // EXTEND WITH HELP: DROP EXTERNAL CONNECTION
 | drop_tenant_stmt              // EXTEND WITH HELP: DROP TENANT
+| drop_subscription_stmt        // EXTEND WITH HELP: DROP SUBSCRIPTION
 | drop_unsupported   {}
 | DROP error         // SHOW HELP: DROP
 
@@ -5062,7 +5821,15 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
+| drop_server_stmt   // EXTEND WITH HELP: DROP SERVER
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5197,6 +5964,28 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <domain_name> [, ...] [CASCADE | RESTRICT]
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP TENANT - remove a tenant
 // %Category: Experimental
 // %Text: DROP TENANT [IF EXISTS] <tenant_spec> [IMMEDIATE]
@@ -5414,6 +6203,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6044,7 +6834,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6367,14 +7157,41 @@ set_local_stmt:
 set_transaction_stmt:
   SET TRANSACTION transaction_mode_list
   {
-    $$.val = &tree.SetTransaction{Modes: $3.transactionModes()}
+    $$.val = &tree.SetTransaction{Modes: $3.transactionModes()}
+  }
+| SET TRANSACTION error // SHOW HELP: SET TRANSACTION
+| SET SESSION TRANSACTION transaction_mode_list
+  {
+    $$.val = &tree.SetTransaction{Modes: $4.transactionModes()}
+  }
+| SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
+
+// %Help: SET CONSTRAINTS - set the check timing of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | [<schema>.]<name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// %SeeAlso: SET TRANSACTION, WEBDOCS/foreign-key.html
+set_constraints_stmt:
+  SET CONSTRAINTS ALL set_constraints_mode
+  {
+    $$.val = &tree.SetConstraints{All: true, Deferred: $4.bool()}
   }
-| SET TRANSACTION error // SHOW HELP: SET TRANSACTION
-| SET SESSION TRANSACTION transaction_mode_list
+| SET CONSTRAINTS db_object_name_list set_constraints_mode
   {
-    $$.val = &tree.SetTransaction{Modes: $4.transactionModes()}
+    $$.val = &tree.SetConstraints{Names: $3.tableNames(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+set_constraints_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
   }
-| SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
 generic_set:
   var_name to_or_eq var_list
@@ -6498,11 +7315,11 @@ var_list:
 iso_level:
   READ UNCOMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | READ COMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | SNAPSHOT
   {
@@ -6618,6 +7435,22 @@ show_stmt:
 | show_default_privileges_stmt // EXTEND WITH HELP: SHOW DEFAULT PRIVILEGES
 | show_completions_stmt
 
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text: CALL name ( [ argument ] [, ...] )
+// %SeeAlso: CREATE PROCEDURE
+call_stmt:
+  CALL func_application
+  {
+    proc, ok := $2.expr().(*tree.FuncExpr)
+    if !ok {
+      return setErr(sqllex, errors.New("invalid procedure call"))
+    }
+    proc.InCall = true
+    $$.val = &tree.Call{Proc: proc}
+  }
+| CALL error // SHOW HELP: CALL
+
 // %Help: CLOSE - close SQL cursor
 // %Category: Misc
 // %Text: CLOSE [ ALL | <name> ]
@@ -8562,7 +9395,8 @@ alter_schema_stmt:
 // %Category: DDL
 // %Text:
 // CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<on_commit>]
-// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [<on commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [WITH [NO] DATA] [<on commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> PARTITION OF <tablename> { FOR VALUES <boundspec> | DEFAULT }
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
@@ -8576,6 +9410,7 @@ alter_schema_stmt:
 //    FOREIGN KEY ( <colnames...> ) REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //    UNIQUE ( <colnames...> ) [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    CHECK ( <expr> )
+//    EXCLUDE [USING <method>] ( <colname> WITH <operator> [, ...] ) [WHERE ( <predicate> )]
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | NOT VISIBLE | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr> | ON UPDATE <expr> | GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [( <opt_sequence_option_list> )]}
@@ -8591,7 +9426,7 @@ alter_schema_stmt:
 // WEBDOCS/create-table.html
 // WEBDOCS/create-table-as.html
 create_table_stmt:
-  CREATE opt_persistence_temp_table TABLE table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
+  CREATE opt_persistence_temp_table TABLE table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_create_table_partition_by opt_table_with opt_create_table_on_commit opt_locality
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateTable{
@@ -8606,7 +9441,7 @@ create_table_stmt:
       Locality: $12.locality(),
     }
   }
-| CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
+| CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_create_table_partition_by opt_table_with opt_create_table_on_commit opt_locality
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateTable{
@@ -8621,6 +9456,50 @@ create_table_stmt:
       Locality: $15.locality(),
     }
   }
+| CREATE opt_persistence_temp_table TABLE table_name PARTITION OF table_name partition_bound_spec
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: false,
+      Persistence: $2.persistence(),
+      PartitionOf: &tree.PartitionOf{
+        Parent: $7.unresolvedObjectName().ToTableName(),
+        Bound: $8.partitionBoundSpec(),
+      },
+    }
+  }
+| CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name PARTITION OF table_name partition_bound_spec
+  {
+    name := $7.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: true,
+      Persistence: $2.persistence(),
+      PartitionOf: &tree.PartitionOf{
+        Parent: $10.unresolvedObjectName().ToTableName(),
+        Bound: $11.partitionBoundSpec(),
+      },
+    }
+  }
+
+partition_bound_spec:
+  FOR VALUES IN '(' expr_list ')'
+  {
+    $$.val = tree.PartitionBoundSpec{In: $5.exprs()}
+  }
+| FOR VALUES FROM '(' expr_list ')' TO '(' expr_list ')'
+  {
+    $$.val = tree.PartitionBoundSpec{From: $5.exprs(), To: $9.exprs()}
+  }
+| FOR VALUES WITH error
+  {
+    return unimplemented(sqllex, "partition by hash")
+  }
+| DEFAULT
+  {
+    $$.val = tree.PartitionBoundSpec{Default: true}
+  }
 
 opt_locality:
   locality
@@ -8721,6 +9600,7 @@ create_table_as_stmt:
       IfNotExists: false,
       Defs: $5.tblDefs(),
       AsSource: $8.slct(),
+      AsWithNoData: !$9.bool(),
       StorageParams: $6.storageParams(),
       OnCommit: $10.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -8734,6 +9614,7 @@ create_table_as_stmt:
       IfNotExists: true,
       Defs: $8.tblDefs(),
       AsSource: $11.slct(),
+      AsWithNoData: !$12.bool(),
       StorageParams: $9.storageParams(),
       OnCommit: $13.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -8741,9 +9622,19 @@ create_table_as_stmt:
   }
 
 opt_create_as_data:
-  /* EMPTY */  { /* no error */ }
-| WITH DATA    { /* SKIP DOC */ /* This is the default */ }
-| WITH NO DATA { return unimplemented(sqllex, "create table as with no data") }
+  /* EMPTY */
+  {
+    $$.val = true
+  }
+| WITH DATA
+  {
+    /* SKIP DOC */
+    $$.val = true
+  }
+| WITH NO DATA
+  {
+    $$.val = false
+  }
 
 /*
  * Redundancy here is needed to avoid shift/reduce conflicts,
@@ -8908,6 +9799,34 @@ opt_partition_by_table:
     $$.val = (*tree.PartitionByTable)(nil)
   }
 
+// opt_create_table_partition_by additionally accepts the PostgreSQL form of
+// PARTITION BY, which does not list the partitions. These are defined by
+// subsequent CREATE TABLE ... PARTITION OF statements instead.
+opt_create_table_partition_by:
+  opt_partition_by_table
+| PARTITION BY LIST '(' name_list ')'
+  {
+    $$.val = &tree.PartitionByTable{
+      PartitionBy: &tree.PartitionBy{
+        Fields: $5.nameList(),
+        Declarative: tree.PartitionByList,
+      },
+    }
+  }
+| PARTITION BY RANGE '(' name_list ')'
+  {
+    $$.val = &tree.PartitionByTable{
+      PartitionBy: &tree.PartitionBy{
+        Fields: $5.nameList(),
+        Declarative: tree.PartitionByRange,
+      },
+    }
+  }
+| PARTITION BY HASH error
+  {
+    return unimplemented(sqllex, "partition by hash")
+  }
+
 partition_by:
   PARTITION BY partition_by_inner
   {
@@ -9086,7 +10005,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -9094,6 +10013,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrable: $6.constraintDeferrable(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -9244,6 +10164,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrable().IsDeferrable() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable")
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -9259,6 +10182,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrable: $8.constraintDeferrable(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -9282,11 +10206,48 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrable: $11.constraintDeferrable(),
+    }
+  }
+| EXCLUDE opt_exclude_access_method '(' exclude_elem_list ')' opt_where_clause opt_deferrable
+  {
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Using: $2,
+      Elems: $4.excludeElems(),
+      Predicate: $6.expr(),
+      Deferrable: $7.constraintDeferrable(),
     }
   }
-| EXCLUDE USING error
+
+opt_exclude_access_method:
+  USING name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclude_elem_list:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elem_list ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  name WITH all_op
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s cannot be used in an exclusion constraint", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -9395,12 +10356,30 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
 
 storing:
   COVERING
@@ -9776,7 +10755,7 @@ role_or_group_or_user:
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
 // %Text:
-// CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
+// CREATE [TEMPORARY | TEMP] [RECURSIVE] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
 // CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
@@ -9788,6 +10767,7 @@ create_view_stmt:
       ColumnNames: $6.nameList(),
       AsSource: $8.slct(),
       Persistence: $2.persistence(),
+      Recursive: $3.bool(),
       IfNotExists: false,
       Replace: false,
     }
@@ -9802,6 +10782,7 @@ create_view_stmt:
       ColumnNames: $8.nameList(),
       AsSource: $10.slct(),
       Persistence: $4.persistence(),
+      Recursive: $5.bool(),
       IfNotExists: false,
       Replace: true,
     }
@@ -9814,6 +10795,7 @@ create_view_stmt:
       ColumnNames: $9.nameList(),
       AsSource: $11.slct(),
       Persistence: $2.persistence(),
+      Recursive: $3.bool(),
       IfNotExists: true,
       Replace: false,
     }
@@ -9906,6 +10888,14 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| BYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOBYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
 | VIEWACTIVITY
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
@@ -9988,8 +10978,14 @@ valid_until_clause:
   }
 
 opt_view_recursive:
-  /* EMPTY */ { /* no error */ }
-| RECURSIVE { return unimplemented(sqllex, "create recursive view") }
+  /* EMPTY */
+  {
+    $$.val = false
+  }
+| RECURSIVE
+  {
+    $$.val = true
+  }
 
 
 // %Help: CREATE TYPE - create a type
@@ -10039,8 +11035,86 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <domain_name> [AS] <type> [DEFAULT <expr>] [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] { NOT NULL | NULL | CHECK (<expr>) }
+//
+// A CHECK constraint refers to the value being checked as VALUE.
+create_domain_stmt:
+  CREATE DOMAIN type_name AS typename opt_domain_default opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+      Default: $6.expr(),
+      Constraints: $7.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN type_name typename opt_domain_default opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $4.typeReference(),
+      Default: $5.expr(),
+      Constraints: $6.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+opt_domain_default:
+  DEFAULT b_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_domain_constraint_list:
+  domain_constraint_list
+| /* EMPTY */
+  {
+    $$.val = tree.DomainConstraints(nil)
+  }
+
+domain_constraint_list:
+  domain_constraint
+  {
+    $$.val = tree.DomainConstraints{$1.domainConstraint()}
+  }
+| domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = tree.DomainConstraint{Kind: tree.DomainConstraintNotNull}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{Kind: tree.DomainConstraintNull}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Kind: tree.DomainConstraintCheck, Expr: $3.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -10096,7 +11170,7 @@ composite_type_list:
 // %Category: DDL
 // %Text:
 // CREATE [UNIQUE | INVERTED] INDEX [CONCURRENTLY] [IF NOT EXISTS] [<idxname>]
-//        ON <tablename> ( <colname> [ASC | DESC] [, ...] )
+//        ON [ONLY] <tablename> ( <colname> [ASC | DESC] [, ...] )
 //        [USING HASH] [STORING ( <colnames...> )]
 //        [PARTITION BY <partition params>]
 //        [WITH <storage_parameter_list] [WHERE <where_conds...>]
@@ -10104,7 +11178,7 @@ composite_type_list:
 // %SeeAlso: CREATE TABLE, SHOW INDEXES, SHOW CREATE,
 // WEBDOCS/create-index.html
 create_index_stmt:
-  CREATE opt_unique INDEX opt_concurrently opt_index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
+  CREATE opt_unique INDEX opt_concurrently opt_index_name ON relation_expr opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -10122,7 +11196,7 @@ create_index_stmt:
       NotVisible:       $17.bool(),
     }
   }
-| CREATE opt_unique INDEX opt_concurrently IF NOT EXISTS index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
+| CREATE opt_unique INDEX opt_concurrently IF NOT EXISTS index_name ON relation_expr opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $10.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -11463,6 +12537,86 @@ update_stmt:
   }
 | opt_with_clause UPDATE error // SHOW HELP: UPDATE
 
+// %Help: MERGE - insert, update or delete rows of a table based on a join
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//        USING <source> ON <join_condition>
+//        WHEN MATCHED [AND <condition>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//        WHEN NOT MATCHED [AND <condition>] THEN
+//          { INSERT [( <colnames...> )] { VALUES ( <exprs...> ) | DEFAULT VALUES } | DO NOTHING }
+//        [...]
+// %SeeAlso: INSERT, UPDATE, DELETE, UPSERT
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Target: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_cond THEN UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $3.expr(), Action: tree.MergeActionUpdate, Exprs: $7.updateExprs()}
+  }
+| WHEN MATCHED opt_merge_when_cond THEN DELETE
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $3.expr(), Action: tree.MergeActionDelete}
+  }
+| WHEN MATCHED opt_merge_when_cond THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $3.expr(), Action: tree.MergeActionDoNothing}
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN INSERT merge_insert
+  {
+    $$.val = $7.mergeWhen()
+    $$.val.(*tree.MergeWhen).Cond = $4.expr()
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Cond: $4.expr(), Action: tree.MergeActionDoNothing}
+  }
+
+opt_merge_when_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_insert:
+  VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Values: $3.exprs()}
+  }
+| '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Columns: $2.nameList(), Values: $6.exprs()}
+  }
+| DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert}
+  }
+
 opt_from_list:
   FROM from_list {
     $$.val = $2.tblExprs()
@@ -11493,7 +12647,10 @@ single_set_clause:
   {
     $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Expr: $3.expr()}
   }
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+| column_name field_access_ops '=' a_expr
+  {
+    $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Fields: $2.nameList(), Expr: $4.expr()}
+  }
 
 multiple_set_clause:
   '(' insert_column_list ')' '=' in_expr
@@ -12144,9 +13301,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSets{Type: tree.RollupGroupingSets, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSets{Type: tree.CubeGroupingSets, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSets{Type: tree.ExplicitGroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -12721,16 +13887,46 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - listen for asynchronous notifications
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send an asynchronous notification
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: tree.NewStrVal($4)}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for asynchronous notifications
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
-   UNLISTEN type_name
-    {
-        $$.val = &tree.Unlisten{ ChannelName: $2.unresolvedObjectName(), Star: false}
-    }
-|  UNLISTEN '*'
-      {
-          $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
-      }
+  UNLISTEN name
+  {
+    $$.val = &tree.Unlisten{ChannelName: tree.Name($2)}
+  }
+| UNLISTEN '*'
+  {
+    $$.val = &tree.Unlisten{Star: true}
+  }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -12854,20 +14050,16 @@ cast_target:
   }
 
 opt_array_bounds:
-  // TODO(justin): reintroduce multiple array bounds
-  // opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
-  '[' ']' { $$.val = []int32{-1} }
-| '[' ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
-| '[' ICONST ']'
+  opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+| opt_array_bounds '[' ICONST ']'
   {
     /* SKIP DOC */
-    bound, err := $2.numVal().AsInt32()
+    bound, err := $3.numVal().AsInt32()
     if err != nil {
       return setErr(sqllex, err)
     }
-    $$.val = []int32{bound}
+    $$.val = append($1.int32s(), bound)
   }
-| '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | /* EMPTY */ { $$.val = []int32(nil) }
 
 // general_type_name is a variant of type_or_function_name but does not
@@ -13614,6 +14806,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -14026,33 +15222,24 @@ c_expr:
 
 // Productions that can be followed by a postfix operator.
 //
-// Currently we support array indexing (see c_expr above).
-//
-// TODO(knz/jordan): this is the rule that can be extended to support
-// composite types (#27792) with e.g.:
-//
-//     | '(' a_expr ')' field_access_ops
-//
-//     [...]
+// Currently we support array indexing (see c_expr above) and composite
+// type field access (see field_access_ops below).
 //
-//     // field_access_ops supports the notations:
-//     // - .a
-//     // - .a[123]
-//     // - .a.b[123][5456].c.d
-//     // NOT [123] directly, this is handled in c_expr above.
-//
-//     field_access_ops:
-//       field_access_op
-//     | field_access_op other_subscripts
-//
-//     field_access_op:
-//       '.' name
-//     other_subscripts:
-//       other_subscript
-//     | other_subscripts other_subscript
-//     other_subscript:
-//        field_access_op
-//     |  array_subscripts
+// TODO(knz/jordan): field access could be extended to support array
+// subscripts between field names, e.g. (x).a[123].b.
+
+// field_access_ops supports the notations:
+// - .a
+// - .a.b.c
+field_access_ops:
+  '.' unrestricted_name
+  {
+    $$.val = tree.NameList{tree.Name($2)}
+  }
+| field_access_ops '.' unrestricted_name
+  {
+    $$.val = append($1.nameList(), tree.Name($3))
+  }
 
 d_expr:
   ICONST
@@ -14117,14 +15304,17 @@ d_expr:
     sqllex.(*lexer).UpdateNumPlaceholders(p)
     $$.val = p
   }
-// TODO(knz/jordan): extend this for compound types. See explanation above.
 | '(' a_expr ')' '.' '*'
   {
     $$.val = &tree.TupleStar{Expr: $2.expr()}
   }
-| '(' a_expr ')' '.' unrestricted_name
+| '(' a_expr ')' field_access_ops
   {
-    $$.val = &tree.ColumnAccessExpr{Expr: $2.expr(), ColName: tree.Name($5) }
+    expr := $2.expr()
+    for _, name := range $4.nameList() {
+      expr = &tree.ColumnAccessExpr{Expr: expr, ColName: name}
+    }
+    $$.val = expr
   }
 | '(' a_expr ')' '.' '@' ICONST
   {
@@ -14161,7 +15351,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("grouping"), Exprs: $3.exprs()}
+  }
 
 func_application:
   func_name '(' ')'
@@ -14172,8 +15365,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_name '(' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
-| func_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
+| func_name '(' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_name '(' ALL expr_list opt_sort_clause ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -14806,6 +16005,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -15737,6 +16937,7 @@ unreserved_keyword:
 | ASENSITIVE
 | AT
 | ATOMIC
+| ATTACH
 | ATTRIBUTE
 | AUTOMATIC
 | AVAILABILITY
@@ -15749,7 +16950,9 @@ unreserved_keyword:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
+| CALL
 | CALLED
 | CANCEL
 | CANCELQUERY
@@ -15803,10 +17006,13 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -15873,6 +17079,7 @@ unreserved_keyword:
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -15900,6 +17107,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
@@ -15907,6 +17115,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -15935,8 +17144,10 @@ unreserved_keyword:
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -15978,6 +17189,7 @@ unreserved_keyword:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -15985,6 +17197,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -15994,6 +17207,7 @@ unreserved_keyword:
 | PRIOR
 | PRIORITY
 | PRIVILEGES
+| PROCEDURE
 | PUBLIC
 | PUBLICATION
 | QUERIES
@@ -16024,6 +17238,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -16080,9 +17295,11 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
+| STDOUT
 | STORAGE
 | STORE
 | STORED
@@ -16147,6 +17364,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -16157,6 +17375,8 @@ unreserved_keyword:
 // Any new keyword should be added to this list.
 bare_label_keywords:
   ATOMIC
+| ATTACH
+| CALL
 | CALLED
 | COST
 | DEFINER
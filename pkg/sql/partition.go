@@ -14,6 +14,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
@@ -135,14 +137,81 @@ func partitionTupleToExprs(t *rowenc.PartitionTuple) (tree.Exprs, error) {
 	for i := 0; i < t.SpecialCount; i++ {
 		switch t.Special {
 		case rowenc.PartitionDefaultVal:
-			exprs[i+len(t.Datums)] = &tree.DefaultVal{}
+			exprs[i+len(t.Datums)] = tree.DefaultVal{}
 		case rowenc.PartitionMinVal:
-			exprs[i+len(t.Datums)] = &tree.PartitionMinVal{}
+			exprs[i+len(t.Datums)] = tree.PartitionMinVal{}
 		case rowenc.PartitionMaxVal:
-			exprs[i+len(t.Datums)] = &tree.PartitionMaxVal{}
+			exprs[i+len(t.Datums)] = tree.PartitionMaxVal{}
 		default:
 			return nil, errors.AssertionFailedf("unknown special value found: %v", t.Special)
 		}
 	}
 	return exprs, nil
 }
+
+// checkDeclarativePartitioning checks that the columns of a PostgreSQL-style
+// PARTITION BY clause, whose partitions are added later on, are a prefix of the
+// primary key of the table. This is required to translate the partitions onto
+// the primary index.
+func checkDeclarativePartitioning(
+	tableDesc catalog.TableDescriptor, partBy *tree.PartitionBy,
+) error {
+	if partBy.Declarative == tree.PartitionByList && len(partBy.Fields) > 1 {
+		return pgerror.New(pgcode.InvalidTableDefinition,
+			`cannot use "list" partition strategy with more than one column`)
+	}
+	idx := tableDesc.GetPrimaryIndex()
+	for i, field := range partBy.Fields {
+		if i >= idx.NumKeyColumns() || idx.GetKeyColumnName(i) != string(field) {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidTableDefinition,
+					"partition columns (%s) must be a prefix of the primary key of table %q",
+					tree.AsString(&partBy.Fields), tableDesc.GetName()),
+				"reorder the primary key columns so that the partition columns come first",
+			)
+		}
+	}
+	return nil
+}
+
+// partitionByWithAttachedPartition constructs the PartitionBy clause of the
+// primary index of a table with the partition specified by an ALTER TABLE ...
+// ATTACH PARTITION command added to it. If the table is not partitioned yet, the
+// partition columns are the leading primary key columns: one for a list
+// partition and one per bound value for a range partition.
+func partitionByWithAttachedPartition(
+	codec keys.SQLCodec, tableDesc *tabledesc.Mutable, t *tree.AlterTableAttachPartition,
+) (*tree.PartitionBy, error) {
+	partitionBy, err := partitionByFromTableDesc(codec, tableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if partitionBy == nil {
+		if t.Bound.Default {
+			return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+				"cannot attach DEFAULT partition %q to table %q without other partitions",
+				t.Partition.Object(), tableDesc.GetName())
+		}
+		partitionBy = &tree.PartitionBy{Declarative: tree.PartitionByList}
+		numCols := 1
+		if t.Bound.In == nil {
+			partitionBy.Declarative = tree.PartitionByRange
+			numCols = len(t.Bound.From)
+		}
+		idx := tableDesc.GetPrimaryIndex()
+		if numCols > idx.NumKeyColumns() {
+			return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+				"partition bound of %q has more values than the primary key of table %q has columns",
+				t.Partition.Object(), tableDesc.GetName())
+		}
+		for i := 0; i < numCols; i++ {
+			partitionBy.Fields = append(partitionBy.Fields, tree.Name(idx.GetKeyColumnName(i)))
+		}
+	}
+	if err := partitionBy.AddPartition(
+		partitionBy.PartitionType(), t.Partition.ObjectName, &t.Bound,
+	); err != nil {
+		return nil, err
+	}
+	return partitionBy, nil
+}
@@ -56,6 +56,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 	"golang.org/x/text/collate"
@@ -392,7 +393,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-attrdef.html`,
 		return nil
 	})
 
-var pgCatalogAttributeTable = makeAllRelationsVirtualTableWithDescriptorIDIndex(
+var pgCatalogAttributeTable = withCompositeTypeRows(makeAllRelationsVirtualTableWithDescriptorIDIndex(
 	`table columns (incomplete - see also information_schema.columns)
 https://www.postgresql.org/docs/12/catalog-pg-attribute.html`,
 	vtable.PGCatalogAttribute,
@@ -503,7 +504,52 @@ https://www.postgresql.org/docs/12/catalog-pg-attribute.html`,
 			}
 			return nil
 		})
-	})
+	}), addPGAttributeRowsForCompositeType)
+
+// addPGAttributeRowsForCompositeType adds a pg_attribute row for each element
+// of a user-defined composite type.
+func addPGAttributeRowsForCompositeType(
+	ctx context.Context,
+	p *planner,
+	h oidHasher,
+	sc catalog.SchemaDescriptor,
+	typDesc catalog.TypeDescriptor,
+	typ *types.T,
+	addRow func(...tree.Datum) error,
+) error {
+	for i, elemTyp := range typ.TupleContents() {
+		if err := addRow(
+			tableOid(typDesc.GetID()),           // attrelid
+			tree.NewDName(typ.TupleLabels()[i]), // attname
+			typOid(elemTyp),                     // atttypid
+			zeroVal,                             // attstattarget
+			typLen(elemTyp),                     // attlen
+			tree.NewDInt(tree.DInt(i+1)),        // attnum
+			zeroVal,                             // attndims
+			negOneVal,                           // attcacheoff
+			tree.NewDInt(tree.DInt(elemTyp.TypeModifier())), // atttypmod
+			tree.DNull,          // attbyval (see pg_type.typbyval)
+			tree.DNull,          // attstorage
+			tree.DNull,          // attalign
+			tree.DBoolFalse,     // attnotnull
+			tree.DBoolFalse,     // atthasdef
+			tree.NewDString(""), // attidentity
+			tree.NewDString(""), // attgenerated
+			tree.DBoolFalse,     // attisdropped
+			tree.DBoolTrue,      // attislocal
+			zeroVal,             // attinhcount
+			typColl(elemTyp, h), // attcollation
+			tree.DNull,          // attacl
+			tree.DNull,          // attoptions
+			tree.DNull,          // attfdwoptions
+			tree.DNull,          // atthasmissing
+			tree.DNull,          // attmissingval
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 var pgCatalogCastTable = virtualSchemaTable{
 	comment: `casts (empty - needs filling out)
@@ -565,6 +611,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
@@ -580,7 +630,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(isRoot || bypassRLS),  // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -641,12 +691,14 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
+	relKindCompositeType    = tree.NewDString("c")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
 )
 
-var pgCatalogClassTable = makeAllRelationsVirtualTableWithDescriptorIDIndex(
+var pgCatalogClassTable = withCompositeTypeRows(makeAllRelationsVirtualTableWithDescriptorIDIndex(
 	`tables and relation-like objects (incomplete - see also information_schema.tables/sequences/views)
 https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 	vtable.PGCatalogClass,
@@ -666,6 +718,9 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		} else if table.IsSequence() {
 			relKind = relKindSequence
 			relAm = oidZero
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -717,12 +772,12 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
-			tree.DNull, // relforcerowsecurity
+			tree.MakeDBool(tree.DBool(table.GetRowLevelSecurityForced())), // relforcerowsecurity
 			tree.DNull, // relispartition
 			tree.DNull, // relispopulated
 			tree.DNull, // relreplident
 			tree.DNull, // relrewrite
-			tree.DNull, // relrowsecurity
+			tree.MakeDBool(tree.DBool(table.GetRowLevelSecurityEnabled())), // relrowsecurity
 			tree.DNull, // relpartbound
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relminmxid
@@ -788,7 +843,62 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 				tree.DNull, // relminmxid
 			)
 		})
-	})
+	}), addPGClassRowForCompositeType)
+
+// addPGClassRowForCompositeType adds the pg_class row for the relation which
+// describes the elements of a user-defined composite type.
+func addPGClassRowForCompositeType(
+	ctx context.Context,
+	p *planner,
+	_ oidHasher,
+	sc catalog.SchemaDescriptor,
+	typDesc catalog.TypeDescriptor,
+	typ *types.T,
+	addRow func(...tree.Datum) error,
+) error {
+	ownerOid, err := getOwnerOID(ctx, p, typDesc)
+	if err != nil {
+		return err
+	}
+	return addRow(
+		tableOid(typDesc.GetID()),        // oid
+		tree.NewDName(typDesc.GetName()), // relname
+		schemaOid(sc.GetID()),            // relnamespace
+		tree.NewDOid(typ.Oid()),          // reltype
+		oidZero,                          // reloftype
+		ownerOid,                         // relowner
+		oidZero,                          // relam
+		oidZero,                          // relfilenode
+		oidZero,                          // reltablespace
+		tree.DNull,                       // relpages
+		tree.DNull,                       // reltuples
+		zeroVal,                          // relallvisible
+		oidZero,                          // reltoastrelid
+		tree.DBoolFalse,                  // relhasindex
+		tree.DBoolFalse,                  // relisshared
+		relPersistencePermanent,          // relpersistence
+		tree.DBoolFalse,                  // relistemp
+		relKindCompositeType,             // relkind
+		tree.NewDInt(tree.DInt(len(typ.TupleContents()))), // relnatts
+		zeroVal,         // relchecks
+		tree.DBoolFalse, // relhasoids
+		tree.DBoolFalse, // relhaspkey
+		tree.DBoolFalse, // relhasrules
+		tree.DBoolFalse, // relhastriggers
+		tree.DBoolFalse, // relhassubclass
+		zeroVal,         // relfrozenxid
+		tree.DNull,      // relacl
+		tree.DNull,      // reloptions
+		tree.DNull,      // relforcerowsecurity
+		tree.DNull,      // relispartition
+		tree.DNull,      // relispopulated
+		tree.DNull,      // relreplident
+		tree.DNull,      // relrewrite
+		tree.DNull,      // relrowsecurity
+		tree.DNull,      // relpartbound
+		tree.DNull,      // relminmxid
+	)
+}
 
 var pgCatalogCollationTable = virtualSchemaTable{
 	comment: `available collations (incomplete)
@@ -839,7 +949,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -891,6 +1000,9 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrable := constraintDeferrableMode(c)
+		condeferrable := tree.MakeDBool(tree.DBool(deferrable.IsDeferrable()))
+		condeferred := tree.MakeDBool(tree.DBool(deferrable == tree.ConstraintInitiallyDeferred))
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -978,6 +1090,24 @@ func populateTableConstraints(
 				return err
 			}
 			condef = tree.NewDString(buf.String())
+		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+			contype = conTypeExclusion
+			conoid = h.UniqueWithoutIndexConstraintOid(
+				db.GetID(), sc.GetID(), table.GetID(), uwoi,
+			)
+			if conkey, err = colIDArrayToDatum(uwoi.UniqueWithoutIndexDesc().ColumnIDs); err != nil {
+				return err
+			}
+			f := tree.NewFmtCtx(tree.FmtSimple)
+			if err := formatExclusionConstraint(
+				ctx, table, uwoi, p.SemaCtx(), p.SessionData(), f, tree.FmtPGCatalog,
+			); err != nil {
+				return err
+			}
+			if !uwoi.IsConstraintValidated() {
+				f.WriteString(" NOT VALID")
+			}
+			condef = tree.NewDString(f.CloseAndGetString())
 		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
 			contype = conTypeUnique
 			f := tree.NewFmtCtx(tree.FmtSimple)
@@ -991,6 +1121,10 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			if d := uwoi.UniqueWithoutIndexDesc().DeferrableMode(); d.IsDeferrable() {
+				f.WriteByte(' ')
+				f.WriteString(d.String())
+			}
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1026,8 +1160,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1162,6 +1296,41 @@ func makeAllRelationsVirtualTableWithDescriptorIDIndex(
 	}
 }
 
+// withCompositeTypeRows extends a virtual table built by
+// makeAllRelationsVirtualTableWithDescriptorIDIndex with rows for the relation
+// that describes the elements of each user-defined composite type, like
+// PostgreSQL creates for composite types. The virtual table must include index
+// entries, so that its descriptor ID index is incomplete and lookups of the ID
+// of a composite type fall back to a full scan.
+func withCompositeTypeRows(
+	vt virtualSchemaTable,
+	populateFromType func(ctx context.Context, p *planner, h oidHasher, sc catalog.SchemaDescriptor,
+		typDesc catalog.TypeDescriptor, typ *types.T, addRow func(...tree.Datum) error,
+	) error,
+) virtualSchemaTable {
+	populateRelations := vt.populate
+	vt.populate = func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := populateRelations(ctx, p, dbContext, addRow); err != nil {
+			return err
+		}
+		h := makeOidHasher()
+		return forEachTypeDesc(ctx, p, dbContext, func(
+			db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typDesc catalog.TypeDescriptor,
+		) error {
+			if typDesc.GetKind() != descpb.TypeDescriptor_COMPOSITE {
+				return nil
+			}
+			tn := tree.NewQualifiedTypeName(db.GetName(), sc.GetName(), typDesc.GetName())
+			typ, err := typedesc.HydratedTFromDesc(ctx, tn, typDesc, p)
+			if err != nil {
+				return err
+			}
+			return populateFromType(ctx, p, h, sc, typDesc, typ, addRow)
+		})
+	}
+	return vt
+}
+
 var pgCatalogConstraintTable = makeAllRelationsVirtualTableWithDescriptorIDIndex(
 	`table constraints (incomplete - see also information_schema.table_constraints)
 https://www.postgresql.org/docs/9.5/catalog-pg-constraint.html`,
@@ -2454,16 +2623,40 @@ func addPgProcUDFRow(
 ) error {
 	isStrict := fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT
 	argTypes := tree.NewDArray(types.Oid)
+	allArgTypes := tree.NewDArray(types.Oid)
 	argModes := tree.NewDArray(types.String)
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
+	foundNonInArgs := false
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
-		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+		// Only input arguments are part of proargtypes. proallargtypes
+		// includes the OUT arguments as well.
+		var mode string
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			mode = "o"
+		case catpb.Function_Param_IN_OUT:
+			mode = "b"
+		case catpb.Function_Param_VARIADIC:
+			mode = "v"
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
+		default:
+			mode = "i"
+		}
+		if mode != "o" {
+			if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+				return err
+			}
+		}
+		if mode != "i" {
+			foundNonInArgs = true
+		}
+		if err := allArgTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		if err := argModes.Append(tree.NewDString(mode)); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2477,6 +2670,27 @@ func addPgProcUDFRow(
 	if foundAnyArgNames {
 		argNames = argNamesArray
 	}
+	var allArgTypesDatum tree.Datum = tree.DNull
+	if foundNonInArgs {
+		allArgTypesDatum = allArgTypes
+	}
+	isSecurityDefiner := fnDesc.GetSecurity() == catpb.Function_DEFINER
+	var config tree.Datum = tree.DNull
+	if settings := fnDesc.GetSettings(); len(settings) > 0 {
+		configArray := tree.NewDArray(types.String)
+		for _, setting := range settings {
+			if err := configArray.Append(tree.NewDString(setting.Name + "=" + setting.Value)); err != nil {
+				return err
+			}
+		}
+		config = configArray
+	}
+	kind := "f"
+	if fnDesc.GetAggregate() != nil {
+		kind = "a"
+	} else if fnDesc.GetIsProcedure() {
+		kind = "p"
+	}
 
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
@@ -2488,32 +2702,32 @@ func addPgProcUDFRow(
 		tree.NewDOid(14), // prolang
 		tree.DNull,       // procost
 		tree.DNull,       // prorows
-		oidZero,          // provariadic
+		variadicType,     // provariadic
 		tree.DNull,       // protransform
-		tree.DBoolFalse,  // proisagg
-		tree.DBoolFalse,  // proiswindow
-		tree.DBoolFalse,  // prosecdef
+		tree.MakeDBool(tree.DBool(fnDesc.GetAggregate() != nil)), // proisagg
+		tree.DBoolFalse, // proiswindow
+		tree.MakeDBool(tree.DBool(isSecurityDefiner)),                // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
 		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
-		tree.DNull, // proparallel
-		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))), // pronargs
-		tree.NewDInt(tree.DInt(0)),                       // pronargdefaults
-		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),  // prorettype
-		tree.NewDOidVectorFromDArray(argTypes),           // proargtypes
-		tree.DNull,                                       // proallargtypes
-		argModes,                                         // proargmodes
-		argNames,                                         // proargnames
-		tree.DNull,                                       // proargdefaults
-		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
-		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
-		tree.DNull,                                       // proacl
+		tree.DNull,                                      // proparallel
+		tree.NewDInt(tree.DInt(argTypes.Len())),         // pronargs
+		tree.NewDInt(tree.DInt(0)),                      // pronargdefaults
+		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()), // prorettype
+		tree.NewDOidVectorFromDArray(argTypes),          // proargtypes
+		allArgTypesDatum,                                // proallargtypes
+		argModes,                                        // proargmodes
+		argNames,                                        // proargnames
+		tree.DNull,                                      // proargdefaults
+		tree.DNull,                                      // protrftypes
+		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
+		tree.DNull,                                      // probin
+		config,                                          // proconfig
+		tree.DNull,                                      // proacl
 		// These columns were automatically created by pg_catalog_test's missing column generator.
-		tree.DNull, // prokind
-		tree.DNull, // prosupport
+		tree.NewDString(kind), // prokind
+		tree.DNull,            // prosupport
 	)
 }
 
@@ -2619,16 +2833,24 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		for _, typ := range types.Ranges {
+			if err := addRow(
+				tree.NewDOid(typ.Oid()),                 // rngtypid
+				tree.NewDOid(typ.RangeContents().Oid()), // rngsubtype
+				oidZero,                                 // rngcollation
+				oidZero,                                 // rngsubopc
+				oidZero,                                 // rngcanonical
+				oidZero,                                 // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -2700,6 +2922,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -2718,7 +2944,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                            // rolconnlimit
 					passwdStarString,                     // rolpassword
 					rolValidUntil,                        // rolvaliduntil
-					tree.DBoolFalse,                      // rolbypassrls
+					tree.MakeDBool(isRoot || bypassRLS),  // rolbypassrls
 					settings,                             // rolconfig
 				)
 			})
@@ -3001,15 +3227,69 @@ https://www.postgresql.org/docs/9.5/catalog-pg-tablespace.html`,
 	},
 }
 
+// See the TRIGGER_TYPE_* constants in postgres' pg_trigger.h.
+const (
+	triggerTypeRow    = 1 << 0
+	triggerTypeBefore = 1 << 1
+	triggerTypeInsert = 1 << 2
+	triggerTypeDelete = 1 << 3
+	triggerTypeUpdate = 1 << 4
+)
+
+var triggerEnabledOrigin = tree.NewDString("O")
+
 var pgCatalogTriggerTable = virtualSchemaTable{
-	comment: `triggers (empty - feature does not exist)
+	comment: `triggers
 https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 	schema: vtable.PGCatalogTrigger,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Triggers are unsupported.
-		return nil
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have triggers */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				triggers := table.GetTriggers()
+				for i := range triggers {
+					t := &triggers[i]
+					tgType := triggerTypeRow
+					if t.ActionTime == semenumpb.TriggerActionTime_BEFORE {
+						tgType |= triggerTypeBefore
+					}
+					for _, ev := range t.Events {
+						switch ev {
+						case semenumpb.TriggerEvent_INSERT:
+							tgType |= triggerTypeInsert
+						case semenumpb.TriggerEvent_UPDATE:
+							tgType |= triggerTypeUpdate
+						case semenumpb.TriggerEvent_DELETE:
+							tgType |= triggerTypeDelete
+						}
+					}
+					if err := addRow(
+						h.TriggerOid(table.GetID(), t.ID),         // oid
+						tableOid(table.GetID()),                   // tgrelid
+						tree.NewDName(t.Name),                     // tgname
+						tree.NewDOid(catid.FuncIDToOID(t.FuncID)), // tgfoid
+						tree.NewDInt(tree.DInt(tgType)),           // tgtype
+						triggerEnabledOrigin,                      // tgenabled
+						tree.DBoolFalse,                           // tgisinternal
+						oidZero,                                   // tgconstrrelid
+						oidZero,                                   // tgconstrindid
+						oidZero,                                   // tgconstraint
+						tree.DBoolFalse,                           // tgdeferrable
+						tree.DBoolFalse,                           // tginitdeferred
+						zeroVal,                                   // tgnargs
+						tree.DNull,                                // tgattr
+						tree.NewDBytes(""),                        // tgargs
+						tree.DNull,                                // tgqual
+						tree.DNull,                                // tgoldtable
+						tree.DNull,                                // tgnewtable
+						oidZero,                                   // tgparentid
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var (
@@ -3021,9 +3301,7 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3111,6 +3389,7 @@ func addPGTypeRow(
 ) error {
 	cat := typCategory(typ)
 	typType := typTypeBase
+	typRelID := oidZero
 	typElem := oidZero
 	typArray := oidZero
 	builtinPrefix := builtins.PGIOBuiltinPrefix(typ)
@@ -3141,6 +3420,14 @@ func addPGTypeRow(
 		builtinPrefix = "record_"
 		typType = typTypeComposite
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		if typ.UserDefined() {
+			// User-defined composite types have a corresponding pg_class row.
+			typRelID = tableOid(typedesc.GetUserDefinedTypeDescID(typ))
+		}
+	case types.RangeFamily:
+		// Arrays of ranges are not supported, so ranges do not have an array
+		// type.
+		typType = typTypeRange
 	case types.VoidFamily:
 		// void does not have an array type.
 	default:
@@ -3149,6 +3436,22 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typTypmod := negOneVal
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		base := typ.DomainBaseType()
+		typBaseType = tree.NewDOid(base.Oid())
+		typTypmod = tree.NewDInt(tree.DInt(base.TypeModifier()))
+		if data := typ.TypeMeta.DomainData; data != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(data.NotNull))
+			if data.DefaultExpr != nil {
+				typDefault = tree.NewDString(*data.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3163,7 +3466,7 @@ func addPGTypeRow(
 		tree.DBoolFalse,         // typispreferred
 		tree.DBoolTrue,          // typisdefined
 		typDelim,                // typdelim
-		oidZero,                 // typrelid
+		typRelID,                // typrelid
 		typElem,                 // typelem
 		typArray,                // typarray
 
@@ -3178,13 +3481,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
-		negOneVal,       // typtypmod
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
+		typTypmod,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3377,6 +3680,10 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -3388,7 +3695,7 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 					tree.MakeDBool(isRoot || createDB),   // usecreatedb
 					tree.MakeDBool(isRoot || isSuper),    // usesuper
 					tree.DBoolFalse,                      // userepl
-					tree.DBoolFalse,                      // usebypassrls
+					tree.MakeDBool(isRoot || bypassRLS),  // usebypassrls
 					passwdStarString,                     // passwd
 					validUntil,                           // valuntil
 					settings,                             // useconfig
@@ -3499,6 +3806,10 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
 				return err
@@ -3510,7 +3821,7 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 				tree.MakeDBool(isRoot || createDB),   // usecreatedb
 				tree.MakeDBool(isRoot || isSuper),    // usesuper
 				tree.DBoolFalse,                      // userepl
-				tree.DBoolFalse,                      // usebypassrls
+				tree.MakeDBool(isRoot || bypassRLS),  // usebypassrls
 				passwdStarString,                     // passwd
 				rolValidUntil,                        // valuntil
 				settings,                             // useconfig
@@ -3782,12 +4093,26 @@ var pgCatalogStatioSysTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigTable = virtualSchemaTable{
-	comment: "pg_ts_config was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfig,
+	comment: `text search configurations (incomplete)
+https://www.postgresql.org/docs/9.5/catalog-pg-ts-config.html`,
+	schema: vtable.PgCatalogTsConfig,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		namespaceOid := tree.NewDOid(catconstants.PgCatalogID)
+		names, _ := tsearch.Configs()
+		for _, name := range names {
+			if err := addRow(
+				h.TSConfigOid(name), // oid
+				tree.NewDName(name), // cfgname
+				namespaceOid,        // cfgnamespace
+				tree.DNull,          // cfgowner
+				tree.DNull,          // cfgparser
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatsTable = virtualSchemaTable{
@@ -3809,12 +4134,74 @@ var pgCatalogStatProgressBasebackupTable = virtualSchemaTable{
 }
 
 var pgCatalogPolicyTable = virtualSchemaTable{
-	comment: "pg_policy was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPolicy,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `row-level security policies
+https://www.postgresql.org/docs/15/catalog-pg-policy.html`,
+	schema: vtable.PgCatalogPolicy,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have policies */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				policies := table.GetPolicies()
+				for i := range policies {
+					pol := &policies[i]
+					roles := tree.NewDArray(types.Oid)
+					for _, role := range pol.RoleNames {
+						roleOid := oidZero
+						if role != username.PublicRole {
+							roleOid = h.UserOid(username.MakeSQLUsernameFromPreNormalizedString(role))
+						}
+						if err := roles.Append(roleOid); err != nil {
+							return err
+						}
+					}
+					qual, withCheck := tree.DNull, tree.DNull
+					if pol.UsingExpr != "" {
+						qual = tree.NewDString(pol.UsingExpr)
+					}
+					if pol.WithCheckExpr != "" {
+						withCheck = tree.NewDString(pol.WithCheckExpr)
+					}
+					if err := addRow(
+						h.PolicyOid(table.GetID(), pol.ID), // oid
+						tree.NewDName(pol.Name),            // polname
+						tableOid(table.GetID()),            // polrelid
+						policyCommandChar(pol.Command),     // polcmd
+						tree.MakeDBool(tree.DBool(pol.Type != semenumpb.PolicyType_RESTRICTIVE)), // polpermissive
+						roles,     // polroles
+						qual,      // polqual
+						withCheck, // polwithcheck
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
+}
+
+var (
+	polCmdAll    = tree.NewDString("*")
+	polCmdSelect = tree.NewDString("r")
+	polCmdInsert = tree.NewDString("a")
+	polCmdUpdate = tree.NewDString("w")
+	polCmdDelete = tree.NewDString("d")
+)
+
+// policyCommandChar returns the pg_policy.polcmd value for the given policy
+// command.
+func policyCommandChar(cmd semenumpb.PolicyCommand) tree.Datum {
+	switch cmd {
+	case semenumpb.PolicyCommand_POLICY_SELECT:
+		return polCmdSelect
+	case semenumpb.PolicyCommand_POLICY_INSERT:
+		return polCmdInsert
+	case semenumpb.PolicyCommand_POLICY_UPDATE:
+		return polCmdUpdate
+	case semenumpb.PolicyCommand_POLICY_DELETE:
+		return polCmdDelete
+	default:
+		return polCmdAll
+	}
 }
 
 var pgCatalogStatArchiverTable = virtualSchemaTable{
@@ -3845,12 +4232,33 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications
+https://www.postgresql.org/docs/15/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, false, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				pubs := db.GetPublications()
+				for i := range pubs {
+					pub := &pubs[i]
+					if err := addRow(
+						h.PublicationOid(db.GetID(), pub.Name), // oid
+						tree.NewDName(pub.Name),                // pubname
+						h.UserOid(pub.OwnerProto.Decode()),     // pubowner
+						tree.DBoolFalse,                        // puballtables
+						tree.DBoolTrue,                         // pubinsert
+						tree.DBoolTrue,                         // pubupdate
+						tree.DBoolTrue,                         // pubdelete
+						tree.DBoolFalse,                        // pubtruncate
+						tree.DBoolFalse,                        // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4005,12 +4413,71 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables of publications
+https://www.postgresql.org/docs/15/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachPublicationTable(ctx, p, dbContext,
+			func(
+				_ catalog.DatabaseDescriptor,
+				pub *descpb.DatabaseDescriptor_Publication,
+				sc catalog.SchemaDescriptor,
+				table catalog.TableDescriptor,
+			) error {
+				return addRow(
+					tree.NewDName(pub.Name),        // pubname
+					tree.NewDName(sc.GetName()),    // schemaname
+					tree.NewDName(table.GetName()), // tablename
+				)
+			})
 	},
-	unimplemented: true,
+}
+
+// forEachPublicationTable calls fn for each table of each publication of the
+// given database, or of all databases if dbContext is nil. The tables which
+// have been dropped since they were added to a publication are skipped.
+func forEachPublicationTable(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(
+		catalog.DatabaseDescriptor,
+		*descpb.DatabaseDescriptor_Publication,
+		catalog.SchemaDescriptor,
+		catalog.TableDescriptor,
+	) error,
+) error {
+	type tableWithSchema struct {
+		sc    catalog.SchemaDescriptor
+		table catalog.TableDescriptor
+	}
+	return forEachDatabaseDesc(ctx, p, dbContext, false, /* requiresPrivileges */
+		func(db catalog.DatabaseDescriptor) error {
+			pubs := db.GetPublications()
+			if len(pubs) == 0 {
+				return nil
+			}
+			tables := make(map[descpb.ID]tableWithSchema)
+			if err := forEachTableDesc(ctx, p, db, hideVirtual,
+				func(_ catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+					tables[table.GetID()] = tableWithSchema{sc: sc, table: table}
+					return nil
+				}); err != nil {
+				return err
+			}
+			for i := range pubs {
+				for _, id := range pubs[i].TableIDs {
+					t, ok := tables[id]
+					if !ok {
+						continue
+					}
+					if err := fn(db, &pubs[i], t.sc, t.table); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4155,12 +4622,26 @@ func addRowForTimezoneNames(tz string, t time.Time, addRow func(...tree.Datum) e
 }
 
 var pgCatalogTsDictTable = virtualSchemaTable{
-	comment: "pg_ts_dict was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsDict,
+	comment: `text search dictionaries (incomplete)
+https://www.postgresql.org/docs/9.5/catalog-pg-ts-dict.html`,
+	schema: vtable.PgCatalogTsDict,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		namespaceOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, name := range tsearch.Dictionaries() {
+			if err := addRow(
+				h.TSDictOid(name),   // oid
+				tree.NewDName(name), // dictname
+				namespaceOid,        // dictnamespace
+				tree.DNull,          // dictowner
+				tree.DNull,          // dicttemplate
+				tree.DNull,          // dictinitoption
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatUserTablesTable = virtualSchemaTable{
@@ -4173,12 +4654,37 @@ var pgCatalogStatUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogSubscriptionTable = virtualSchemaTable{
-	comment: "pg_subscription was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogSubscription,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `subscriptions
+https://www.postgresql.org/docs/15/catalog-pg-subscription.html`,
+	schema: vtable.PgCatalogSubscription,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, false, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				subs := db.GetSubscriptions()
+				for i := range subs {
+					sub := &subs[i]
+					publications := tree.NewDArray(types.String)
+					if err := publications.Append(tree.NewDString(sub.Publication)); err != nil {
+						return err
+					}
+					if err := addRow(
+						h.SubscriptionOid(db.GetID(), sub.Name), // oid
+						dbOid(db.GetID()),                       // subdbid
+						tree.NewDName(sub.Name),                 // subname
+						h.UserOid(sub.OwnerProto.Decode()),      // subowner
+						tree.DBoolTrue,                          // subenabled
+						tree.NewDString(sub.ConnectionURI),      // subconninfo
+						tree.DNull,                              // subslotname
+						tree.NewDString("off"),                  // subsynccommit
+						publications,                            // subpublications
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogShmemAllocationsTable = virtualSchemaTable{
@@ -4317,12 +4823,25 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `mapping of publications to tables
+https://www.postgresql.org/docs/15/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachPublicationTable(ctx, p, dbContext,
+			func(
+				db catalog.DatabaseDescriptor,
+				pub *descpb.DatabaseDescriptor_Publication,
+				_ catalog.SchemaDescriptor,
+				table catalog.TableDescriptor,
+			) error {
+				return addRow(
+					h.PublicationRelOid(db.GetID(), pub.Name, table.GetID()), // oid
+					h.PublicationOid(db.GetID(), pub.Name),                   // prpubid
+					tableOid(table.GetID()),                                  // prrelid
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4398,6 +4917,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.ArrayFamily:       typCategoryArray,
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
+	types.RangeFamily:       typCategoryRange,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
@@ -4623,6 +5143,13 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	triggerTypeTag
+	policyTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
+	subscriptionTypeTag
+	tsConfigTypeTag
+	tsDictTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4774,6 +5301,54 @@ func (h oidHasher) EnumEntryOid(typOID *tree.DOid, physicalRep []byte) *tree.DOi
 	return h.getOid()
 }
 
+func (h oidHasher) TriggerOid(tableID descpb.ID, triggerID descpb.TriggerID) *tree.DOid {
+	h.writeTypeTag(triggerTypeTag)
+	h.writeTable(tableID)
+	h.writeUInt32(uint32(triggerID))
+	return h.getOid()
+}
+
+func (h oidHasher) PolicyOid(tableID descpb.ID, policyID descpb.PolicyID) *tree.DOid {
+	h.writeTypeTag(policyTypeTag)
+	h.writeTable(tableID)
+	h.writeUInt32(uint32(policyID))
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationRelOid(dbID descpb.ID, name string, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
+func (h oidHasher) SubscriptionOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(subscriptionTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) TSConfigOid(name string) *tree.DOid {
+	h.writeTypeTag(tsConfigTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) TSDictOid(name string) *tree.DOid {
+	h.writeTypeTag(tsDictTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func (h oidHasher) rewriteOid(source descpb.ID, depended descpb.ID) *tree.DOid {
 	h.writeTypeTag(rewriteTypeTag)
 	h.writeUInt32(uint32(source))
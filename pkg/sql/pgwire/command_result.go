@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -42,6 +43,9 @@ const (
 	flush
 	// Some commands, like Describe, don't need a completion message.
 	noCompletionMsg
+	// The result of a SendNotifications command has no completion message, and
+	// only flushes the connection if it delivers notifications.
+	sendNotifications
 )
 
 // commandResult is an implementation of sql.CommandResult that streams a
@@ -59,6 +63,7 @@ type commandResult struct {
 	buffer struct {
 		notices            []pgnotice.Notice
 		paramStatusUpdates []paramStatusUpdate
+		notifications      []pgnotify.Notification
 	}
 
 	err error
@@ -91,6 +96,10 @@ type commandResult struct {
 	// statements.
 	bufferingDisabled bool
 
+	// copyOut is set for the results of COPY ... TO STDOUT statements, which
+	// are sent to the client using the CopyOut sub-protocol.
+	copyOut *copyOutState
+
 	// released is set when the command result has been released so that its
 	// memory can be reused. It is also used to assert against use-after-free
 	// errors.
@@ -144,9 +153,18 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, notification := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(notification); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
+		if r.copyOut != nil {
+			r.conn.bufferCopyDone(r.copyOut)
+		}
 		tag := cookTag(
 			r.cmdCompleteTag, r.conn.writerState.tagBuf[:0], r.stmtType, r.rowsAffected,
 		)
@@ -170,6 +188,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		r.conn.maybeReallocate()
 	case noCompletionMsg:
 		// nothing to do
+	case sendNotifications:
+		if len(r.buffer.notices) > 0 || len(r.buffer.notifications) > 0 {
+			// The error is saved on conn.err.
+			_ /* err */ = r.conn.Flush(r.pos)
+			r.conn.maybeReallocate()
+		}
 	default:
 		panic(errors.AssertionFailedf("unknown type: %v", r.typ))
 	}
@@ -218,6 +242,9 @@ func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 		return err
 	}
 	r.rowsAffected++
+	if r.copyOut != nil {
+		return r.conn.bufferCopyData(ctx, row, r)
+	}
 	return r.conn.bufferRow(ctx, row, r)
 }
 
@@ -232,7 +259,8 @@ func (r *commandResult) AddBatch(ctx context.Context, batch coldata.Batch) error
 
 // SupportsAddBatch is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SupportsAddBatch() bool {
-	return true
+	// The results of COPY ... TO STDOUT are only encoded row by row.
+	return r.copyOut == nil
 }
 
 // DisableBuffering is part of the sql.RestrictedCommandResult interface.
@@ -241,6 +269,27 @@ func (r *commandResult) DisableBuffering() {
 	r.bufferingDisabled = true
 }
 
+// BufferedResultsLen is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) BufferedResultsLen() int {
+	r.assertNotReleased()
+	return r.conn.writerState.buf.Len()
+}
+
+// TruncateBufferedResults is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) TruncateBufferedResults(idx int) bool {
+	r.assertNotReleased()
+	if r.bufferingDisabled || r.conn.writerState.fi.lastFlushed >= r.pos {
+		// Some results of this command have already been sent to the client.
+		return false
+	}
+	if idx < 0 || idx > r.conn.writerState.buf.Len() {
+		return false
+	}
+	r.conn.writerState.buf.Truncate(idx)
+	r.rowsAffected = 0
+	return true
+}
+
 // BufferParamStatusUpdate is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) BufferParamStatusUpdate(param string, val string) {
 	r.buffer.paramStatusUpdates = append(
@@ -254,11 +303,19 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationResult interface.
+func (r *commandResult) BufferNotification(notification pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, notification)
+}
+
 // SetColumns is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
-	if r.descOpt == sql.NeedRowDesc {
+	if r.copyOut != nil {
+		// The CopyOutResponse message takes the place of the row description.
+		r.conn.bufferCopyOutResponse(cols, r.copyOut)
+	} else if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
 	r.types = make([]*types.T, len(cols))
@@ -367,6 +424,9 @@ func (c *conn) newCommandResult(
 		descOpt:        descOpt,
 		formatCodes:    formatCodes,
 	}
+	if copyTo, ok := stmt.(*tree.CopyTo); ok {
+		r.copyOut = newCopyOutState(&copyTo.Options)
+	}
 	if limit == 0 {
 		return r
 	}
@@ -419,6 +479,13 @@ type limitedCommandResult struct {
 	limit int
 }
 
+// TruncateBufferedResults is part of the sql.RestrictedCommandResult
+// interface. Results of a portal may span multiple executions, so they are
+// never truncated.
+func (r *limitedCommandResult) TruncateBufferedResults(int) bool {
+	return false
+}
+
 // AddRow is part of the sql.RestrictedCommandResult interface.
 func (r *limitedCommandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	if err := r.commandResult.AddRow(ctx, row); err != nil {
@@ -505,6 +572,10 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.SendNotifications:
+			// The connection isn't idle, so the notifications will be delivered
+			// by the next Sync. Just advance the position.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -748,6 +749,14 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(notification pgnotify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(int32(notification.PID))
+	c.msgBuilder.writeTerminatedString(notification.Channel)
+	c.msgBuilder.writeTerminatedString(notification.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context, sqlServer *sql.Server, onDefaultIntSizeChange func(newSize int32),
 ) (sql.ConnectionHandler, error) {
@@ -999,6 +1008,11 @@ func (c *conn) handleParse(
 		// https://www.postgresql.org/message-id/flat/CAMsr%2BYGvp2wRx9pPSxaKFdaObxX8DzWse%2BOkWk2xpXSvT0rq-g%40mail.gmail.com#CAMsr+YGvp2wRx9pPSxaKFdaObxX8DzWse+OkWk2xpXSvT0rq-g@mail.gmail.com
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyFrom not supported in extended protocol mode")})
 	}
+	if _, ok := stmt.AST.(*tree.CopyTo); ok {
+		// The results of COPY ... TO STDOUT are sent using the CopyOut
+		// sub-protocol, which cannot be described as a row-returning statement.
+		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyTo not supported in extended protocol mode")})
+	}
 
 	return c.stmtBuf.Push(
 		ctx,
@@ -1370,6 +1384,207 @@ func (c *conn) bufferBatch(ctx context.Context, batch coldata.Batch, r *commandR
 	return nil
 }
 
+// copyOutState holds the encoding settings for the results of a
+// COPY ... TO STDOUT statement. These results are sent to the client as
+// CopyData messages rather than DataRow messages.
+type copyOutState struct {
+	format    tree.CopyFormat
+	delimiter byte
+	null      string
+	header    bool
+	// escape is the character that escapes quotes in the CSV format.
+	escape byte
+	// scratch is used to encode datums in the text format before they are
+	// escaped.
+	scratch writeBuffer
+}
+
+// binaryCopySignature is the signature that begins the COPY binary format.
+var binaryCopySignature = []byte("PGCOPY\n\377\r\n\000")
+
+// newCopyOutState creates a copyOutState from the options of a COPY ... TO
+// STDOUT statement. The options are expected to have been validated during
+// planning.
+func newCopyOutState(opts *tree.CopyOptions) *copyOutState {
+	s := &copyOutState{
+		format: opts.CopyFormat,
+		header: opts.Header,
+	}
+	s.scratch.init(nil /* bytecount */)
+	switch s.format {
+	case tree.CopyFormatText:
+		s.delimiter = '\t'
+		s.null = `\N`
+	case tree.CopyFormatCSV:
+		s.delimiter = ','
+		s.escape = '"'
+	}
+	if delim, ok := opts.Delimiter.(*tree.StrVal); ok && len(delim.RawString()) == 1 {
+		s.delimiter = delim.RawString()[0]
+	}
+	if null, ok := opts.Null.(*tree.StrVal); ok {
+		s.null = null.RawString()
+	}
+	if opts.Escape != nil && len(opts.Escape.RawString()) == 1 {
+		s.escape = opts.Escape.RawString()[0]
+	}
+	return s
+}
+
+// writeField writes a single non-NULL field, escaping it as required by the
+// text and CSV formats.
+func (s *copyOutState) writeField(b *writeBuffer, v string) {
+	if s.format == tree.CopyFormatCSV {
+		s.writeCSVField(b, v)
+		return
+	}
+	for i := 0; i < len(v); i++ {
+		switch ch := v[i]; ch {
+		case '\\':
+			b.writeString(`\\`)
+		case '\b':
+			b.writeString(`\b`)
+		case '\f':
+			b.writeString(`\f`)
+		case '\n':
+			b.writeString(`\n`)
+		case '\r':
+			b.writeString(`\r`)
+		case '\t':
+			b.writeString(`\t`)
+		case '\v':
+			b.writeString(`\v`)
+		default:
+			if ch == s.delimiter {
+				b.writeByte('\\')
+			}
+			b.writeByte(ch)
+		}
+	}
+}
+
+// writeCSVField writes a single non-NULL field in the CSV format. The field is
+// quoted if it contains special characters, or if it could otherwise be
+// mistaken for a NULL or for the end-of-data marker.
+func (s *copyOutState) writeCSVField(b *writeBuffer, v string) {
+	needsQuotes := v == s.null || v == `\.`
+	for i := 0; i < len(v) && !needsQuotes; i++ {
+		switch ch := v[i]; ch {
+		case '"', '\n', '\r':
+			needsQuotes = true
+		default:
+			needsQuotes = ch == s.delimiter
+		}
+	}
+	if !needsQuotes {
+		b.writeString(v)
+		return
+	}
+	b.writeByte('"')
+	for i := 0; i < len(v); i++ {
+		if ch := v[i]; ch == '"' || ch == s.escape {
+			b.writeByte(s.escape)
+		}
+		b.writeByte(v[i])
+	}
+	b.writeByte('"')
+}
+
+// bufferCopyOutResponse buffers the CopyOutResponse message that starts the
+// CopyOut sub-protocol, followed by the header of the data, if any.
+func (c *conn) bufferCopyOutResponse(columns colinfo.ResultColumns, s *copyOutState) {
+	format := pgwirebase.FormatText
+	if s.format == tree.CopyFormatBinary {
+		format = pgwirebase.FormatBinary
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyOutResponse)
+	c.msgBuilder.writeByte(byte(format))
+	c.msgBuilder.putInt16(int16(len(columns)))
+	for range columns {
+		c.msgBuilder.putInt16(int16(format))
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+
+	switch {
+	case s.format == tree.CopyFormatBinary:
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.write(binaryCopySignature)
+		c.msgBuilder.putInt32(0) // Flags field.
+		c.msgBuilder.putInt32(0) // Header extension area length.
+	case s.header:
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		for i := range columns {
+			if i > 0 {
+				c.msgBuilder.writeByte(s.delimiter)
+			}
+			s.writeField(&c.msgBuilder, columns[i].Name)
+		}
+		c.msgBuilder.writeByte('\n')
+	default:
+		return
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
+// bufferCopyData encodes a row in the format of a COPY ... TO STDOUT statement
+// and adds it to the buffer as a CopyData message. Depending on the buffer size
+// limit, bufferCopyData may flush the buffered data to the connection.
+func (c *conn) bufferCopyData(ctx context.Context, row tree.Datums, r *commandResult) error {
+	s := r.copyOut
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	if s.format == tree.CopyFormatBinary {
+		// Each field is length-prefixed, like in a DataRow message.
+		c.msgBuilder.putInt16(int16(len(row)))
+		for i, col := range row {
+			c.msgBuilder.writeBinaryDatum(ctx, col, r.location, r.types[i])
+		}
+	} else {
+		for i, col := range row {
+			if i > 0 {
+				c.msgBuilder.writeByte(s.delimiter)
+			}
+			if col == tree.DNull {
+				c.msgBuilder.writeString(s.null)
+				continue
+			}
+			s.scratch.reset()
+			writeTextDatumNotNull(&s.scratch, col, r.conv, r.location, r.types[i])
+			if s.scratch.err != nil {
+				c.msgBuilder.setError(s.scratch.err)
+				break
+			}
+			// Skip the length prefix written by the text encoder.
+			s.writeField(&c.msgBuilder, unsafeBytesToString(s.scratch.wrapped.Bytes()[4:]))
+		}
+		c.msgBuilder.writeByte('\n')
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+	return c.maybeFlush(r.pos, r.bufferingDisabled)
+}
+
+// bufferCopyDone buffers the trailer of the data of a COPY ... TO STDOUT
+// statement, if any, and the CopyDone message that ends the CopyOut
+// sub-protocol.
+func (c *conn) bufferCopyDone(s *copyOutState) {
+	if s.format == tree.CopyFormatBinary {
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.putInt16(-1) // File trailer.
+		if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+		}
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDone)
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
 // tenantEgressCounter implements the sql.TenantNetworkEgressCounter interface.
 type tenantEgressCounter struct {
 	buf  writeBuffer
@@ -1748,6 +1963,11 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateSendNotificationsResult is part of the sql.ClientComm interface.
+func (c *conn) CreateSendNotificationsResult(pos sql.CmdPos) sql.SendNotificationsResult {
+	return c.newMiscResult(pos, sendNotifications)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -15,3 +15,12 @@ sed -E 's|^([A-Z0-9]{5})    .    ERRCODE_([A-Z_]+).*$|\2 = MakeCode("\1")|' |
 sed -E 's|// Section: Class 58 - System Error \(errors external to PostgreSQL itself\)|// Section: Class 58 - System Error|' |
 awk '{$1=tolower($1); print $0}' |
 perl -pe 's/(^|_)./uc($&)/ge;s/_//g' > errcodes.generated
+
+# This will generate the mapping from PL/pgSQL condition names to error codes,
+# which is used to resolve the conditions in the EXCEPTION clause of a PL/pgSQL
+# block. The output is used as the body of the map in plpgsql.go.
+sed '/^\s*$/d' errcodes.txt |
+sed '/^#.*$/d' |
+awk '$1 ~ /^[0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z][0-9A-Z]$/ && NF >= 4 {print $4, $1}' |
+awk '{codes[$1] = codes[$1] (codes[$1] == "" ? "" : ", ") "MakeCode(\"" $2 "\")"; if (!($1 in seen)) {seen[$1] = 1; order[n++] = $1}}
+END {for (i = 0; i < n; i++) print "\"" order[i] "\": {" codes[order[i]] "},"}' > plpgsql.generated
@@ -0,0 +1,97 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListenNotify checks that notifications sent from any node are
+// delivered to the sessions listening on their channel once the transaction
+// that sent them commits.
+func TestListenNotify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartNewTestCluster(t, 3, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	connect := func(idx int) *pgx.Conn {
+		pgURL, cleanup := sqlutils.PGUrl(
+			t, tc.Server(idx).ServingSQLAddr(), "TestListenNotify", url.User(username.RootUser),
+		)
+		defer cleanup()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+	listener := connect(0)
+	defer func() { _ = listener.Close(ctx) }()
+	notifier := connect(2)
+	defer func() { _ = notifier.Close(ctx) }()
+
+	_, err := listener.Exec(ctx, "LISTEN foo")
+	require.NoError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, testutils.DefaultSucceedsSoonDuration)
+	defer cancel()
+
+	// Notifications are only sent once the transaction commits. The first
+	// notification received must therefore be the committed one.
+	for _, stmt := range []string{
+		"BEGIN; NOTIFY foo, 'rolled back'; ROLLBACK",
+		"BEGIN; NOTIFY bar, 'other channel'; NOTIFY foo, 'committed'; NOTIFY foo, 'committed'; COMMIT",
+		"SELECT pg_notify('foo', 'builtin')",
+	} {
+		_, err := notifier.Exec(ctx, stmt)
+		require.NoError(t, err)
+	}
+	for _, payload := range []string{"committed", "builtin"} {
+		n, err := listener.WaitForNotification(waitCtx)
+		require.NoError(t, err)
+		require.Equal(t, "foo", n.Channel)
+		require.Equal(t, payload, n.Payload)
+		require.Equal(t, notifier.PgConn().PID(), n.PID)
+	}
+
+	// A session receives its own notifications.
+	_, err = listener.Exec(ctx, "NOTIFY foo")
+	require.NoError(t, err)
+	n, err := listener.WaitForNotification(waitCtx)
+	require.NoError(t, err)
+	require.Equal(t, "foo", n.Channel)
+	require.Equal(t, "", n.Payload)
+	require.Equal(t, listener.PgConn().PID(), n.PID)
+
+	// Notifications sent after UNLISTEN are not delivered.
+	_, err = listener.Exec(ctx, "UNLISTEN foo")
+	require.NoError(t, err)
+	for _, stmt := range []string{"NOTIFY foo, 'unlistened'", "LISTEN foo", "NOTIFY foo, 'listening again'"} {
+		_, err := listener.Exec(ctx, stmt)
+		require.NoError(t, err)
+	}
+	n, err = listener.WaitForNotification(waitCtx)
+	require.NoError(t, err)
+	require.Equal(t, "listening again", n.Payload)
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgnotify implements the delivery of asynchronous notifications
+// (LISTEN / NOTIFY) to the SQL sessions of a node.
+//
+// Each node has a Registry, which tracks the channels that the sessions
+// connected to it listen on. Notifications are written to the
+// system.notifications table by the transaction that sends them. Once that
+// transaction commits, the Watcher of every node reads them from a rangefeed
+// over the table and passes them to its Registry, which queues them on the
+// Listener of every session listening on their channel until that session
+// delivers them to its client.
+package pgnotify
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// MaxPendingNotifications is the maximum number of notifications that can be
+// queued on a Listener until its session delivers them to its client. The
+// notifications received once the limit is reached are dropped, so that a
+// session that doesn't read its notifications (e.g., because it stays in a
+// transaction) doesn't use an unbounded amount of memory.
+const MaxPendingNotifications = 10000
+
+// Registry is a node-level registry of the sessions listening for
+// asynchronous notifications, indexed by channel.
+type Registry struct {
+	mu struct {
+		// The registry's mutex must be acquired before the mutex of any of its
+		// listeners.
+		syncutil.Mutex
+		// listeners maps each channel to the set of listeners subscribed to it.
+		listeners map[string]map[*Listener]struct{}
+	}
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.mu.listeners = make(map[string]map[*Listener]struct{})
+	return r
+}
+
+// NewListener creates a Listener, initially not subscribed to any channel.
+//
+// The signal function is called whenever notifications are queued on the
+// listener while it had no notifications pending. It is called without holding
+// any lock and must not block.
+func (r *Registry) NewListener(signal func()) *Listener {
+	l := &Listener{registry: r, signal: signal}
+	l.mu.channels = make(map[string]hlc.Timestamp)
+	return l
+}
+
+// Notify queues the given notifications, sent by a transaction that committed
+// at the given timestamp, on the listeners subscribed to their channels. As in
+// Postgres, listeners that subscribed to a channel after that transaction
+// committed don't receive them.
+func (r *Registry) Notify(ts hlc.Timestamp, notifications []Notification) {
+	var toSignal []*Listener
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i := range notifications {
+			for l := range r.mu.listeners[notifications[i].Channel] {
+				if l.enqueue(ts, notifications[i]) {
+					toSignal = append(toSignal, l)
+				}
+			}
+		}
+	}()
+	for _, l := range toSignal {
+		l.signal()
+	}
+}
+
+// Listener tracks the channels a session listens on, along with the
+// notifications sent to these channels that the session hasn't delivered to
+// its client yet.
+type Listener struct {
+	registry *Registry
+	signal   func()
+
+	mu struct {
+		syncutil.Mutex
+		// channels maps each channel the listener is subscribed to to the
+		// timestamp at which it subscribed.
+		channels map[string]hlc.Timestamp
+		// pending contains the notifications that haven't been taken yet, in
+		// the order in which they were received. It contains at most
+		// MaxPendingNotifications notifications.
+		pending []Notification
+		// dropped is the number of notifications that were dropped since the
+		// pending notifications were last taken, because there were too many.
+		dropped int
+		// signaled is set once signal has been called for the pending
+		// notifications, and cleared when they are taken.
+		signaled bool
+	}
+}
+
+// enqueue appends the notification, committed at the given timestamp, to the
+// pending ones, unless the listener subscribed to its channel at or after that
+// timestamp. It returns true if the listener needs to be signaled.
+func (l *Listener) enqueue(ts hlc.Timestamp, n Notification) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if listenTS, ok := l.mu.channels[n.Channel]; !ok || ts.LessEq(listenTS) {
+		return false
+	}
+	if len(l.mu.pending) >= MaxPendingNotifications {
+		l.mu.dropped++
+	} else {
+		l.mu.pending = append(l.mu.pending, n)
+	}
+	if l.mu.signaled {
+		return false
+	}
+	l.mu.signaled = true
+	return true
+}
+
+// Listen subscribes the listener to the given channel, as of the given
+// timestamp: only the notifications committed after that timestamp are queued
+// on the listener. It is a no-op if the listener is already subscribed to the
+// channel.
+func (l *Listener) Listen(channel string, ts hlc.Timestamp) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.mu.channels[channel]; ok {
+		return
+	}
+	l.mu.channels[channel] = ts
+	set, ok := r.mu.listeners[channel]
+	if !ok {
+		set = make(map[*Listener]struct{})
+		r.mu.listeners[channel] = set
+	}
+	set[l] = struct{}{}
+}
+
+// Unlisten unsubscribes the listener from the given channel. The pending
+// notifications sent to that channel are discarded. It is a no-op if the
+// listener isn't subscribed to the channel.
+func (l *Listener) Unlisten(channel string) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.unlistenLocked(channel)
+	pending := l.mu.pending[:0]
+	for _, n := range l.mu.pending {
+		if n.Channel != channel {
+			pending = append(pending, n)
+		}
+	}
+	l.mu.pending = pending
+}
+
+// UnlistenAll unsubscribes the listener from all channels, and discards all
+// pending notifications.
+func (l *Listener) UnlistenAll() {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for channel := range l.mu.channels {
+		l.unlistenLocked(channel)
+	}
+	l.mu.pending = nil
+	l.mu.dropped = 0
+}
+
+// unlistenLocked removes the subscription of the listener to the given
+// channel. Both the registry's and the listener's mutexes must be held.
+func (l *Listener) unlistenLocked(channel string) {
+	if _, ok := l.mu.channels[channel]; !ok {
+		return
+	}
+	delete(l.mu.channels, channel)
+	r := l.registry
+	set := r.mu.listeners[channel]
+	delete(set, l)
+	if len(set) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// Channels returns the channels the listener is subscribed to, in sorted
+// order.
+func (l *Listener) Channels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	channels := make([]string, 0, len(l.mu.channels))
+	for channel := range l.mu.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Take removes and returns the pending notifications, in the order in which
+// they were received, along with the number of notifications that were
+// dropped since the last call because too many were pending. Once Take is
+// called, the listener is signaled again the next time a notification is
+// queued on it.
+func (l *Listener) Take() (_ []Notification, dropped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending, dropped := l.mu.pending, l.mu.dropped
+	l.mu.pending = nil
+	l.mu.dropped = 0
+	l.mu.signaled = false
+	return pending, dropped
+}
+
+// Close unsubscribes the listener from all channels. The listener must not be
+// used afterwards.
+func (l *Listener) Close() {
+	l.UnlistenAll()
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry()
+	var signals1, signals2 int
+	l1 := r.NewListener(func() { signals1++ })
+	l2 := r.NewListener(func() { signals2++ })
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	l1.Listen("a", ts(1))
+	l1.Listen("b", ts(1))
+	l1.Listen("a", ts(5))
+	l2.Listen("b", ts(1))
+	require.Equal(t, []string{"a", "b"}, l1.Channels())
+	require.Equal(t, []string{"b"}, l2.Channels())
+
+	a1 := Notification{Channel: "a", Payload: "1", PID: 7}
+	b2 := Notification{Channel: "b", Payload: "2", PID: 7}
+	c3 := Notification{Channel: "c", Payload: "3", PID: 7}
+	r.Notify(ts(2), []Notification{a1, b2, c3})
+	// Listeners are only signaled once until their notifications are taken.
+	r.Notify(ts(2), []Notification{b2})
+	require.Equal(t, 1, signals1)
+	require.Equal(t, 1, signals2)
+	require.Equal(t, []Notification{a1, b2, b2}, take(t, l1))
+	require.Equal(t, []Notification{b2, b2}, take(t, l2))
+	require.Empty(t, take(t, l1))
+
+	r.Notify(ts(2), []Notification{a1})
+	require.Equal(t, 2, signals1)
+	require.Equal(t, 1, signals2)
+
+	// Unlistening discards the pending notifications sent to the channel.
+	r.Notify(ts(2), []Notification{b2})
+	l1.Unlisten("a")
+	require.Equal(t, []string{"b"}, l1.Channels())
+	require.Equal(t, []Notification{b2}, take(t, l1))
+	r.Notify(ts(2), []Notification{a1})
+	require.Empty(t, take(t, l1))
+
+	l2.Close()
+	r.Notify(ts(2), []Notification{b2})
+	require.Empty(t, take(t, l2))
+	require.Equal(t, []Notification{b2}, take(t, l1))
+
+	// Notifications committed before a listener subscribed to their channel are
+	// not queued on it.
+	l2 = r.NewListener(func() { signals2++ })
+	l2.Listen("a", ts(3))
+	r.Notify(ts(3), []Notification{a1})
+	require.Empty(t, take(t, l2))
+	r.Notify(ts(4), []Notification{a1})
+	require.Equal(t, []Notification{a1}, take(t, l2))
+	l2.Close()
+
+	l1.UnlistenAll()
+	require.Empty(t, l1.Channels())
+	require.Empty(t, r.mu.listeners)
+}
+
+// TestListenerMaxPending checks that the notifications received once
+// MaxPendingNotifications are pending are dropped and counted.
+func TestListenerMaxPending(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry()
+	l := r.NewListener(func() {})
+	defer l.Close()
+	l.Listen("a", hlc.Timestamp{})
+
+	n := Notification{Channel: "a", PID: 1}
+	for i := 0; i < MaxPendingNotifications+3; i++ {
+		r.Notify(hlc.Timestamp{WallTime: 1}, []Notification{n})
+	}
+	pending, dropped := l.Take()
+	require.Len(t, pending, MaxPendingNotifications)
+	require.Equal(t, 3, dropped)
+
+	// The count is reset once the notifications are taken.
+	r.Notify(hlc.Timestamp{WallTime: 2}, []Notification{n})
+	require.Equal(t, []Notification{n}, take(t, l))
+}
+
+// take returns the notifications pending on the listener, and checks that
+// none were dropped.
+func take(t *testing.T, l *Listener) []Notification {
+	pending, dropped := l.Take()
+	require.Zero(t, dropped)
+	return pending
+}
@@ -0,0 +1,328 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// retention is the duration for which notifications are kept in the
+// system.notifications table. They only need to be kept until every node has
+// read them from its rangefeed, which catches up from the MVCC history of the
+// table if it's restarted, so the retention can be much lower than the GC TTL.
+var retention = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.notifications.retention",
+	"duration for which the notifications sent with NOTIFY are kept in system.notifications",
+	10*time.Minute,
+	settings.PositiveDuration,
+)
+
+// cleanupInterval is the interval at which each node deletes the expired
+// notifications.
+const cleanupInterval = time.Minute
+
+// startRetryOptions are the options used to wait for the system.notifications
+// table to be created before the Watcher starts its rangefeed.
+var startRetryOptions = retry.Options{
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+	Multiplier:     2,
+}
+
+// Watcher reads the notifications committed to the system.notifications table
+// with a rangefeed, and passes them to the node's Registry.
+//
+// Notifications are delivered in commit order: the notifications received
+// from the rangefeed are buffered until its frontier advances past their
+// commit timestamp, at which point no notification with a lower timestamp can
+// be received anymore. The notifications committed by a transaction, which
+// are identified by the ID of that transaction, are delivered together, in the
+// order in which they were sent, and duplicate notifications (with the same
+// channel and payload) are only delivered once per transaction.
+type Watcher struct {
+	codec    keys.SQLCodec
+	clock    *hlc.Clock
+	f        *rangefeed.Factory
+	db       *kv.DB
+	stopper  *stop.Stopper
+	st       *cluster.Settings
+	registry *Registry
+}
+
+// NewWatcher constructs a new Watcher delivering notifications to the given
+// Registry.
+func NewWatcher(
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	f *rangefeed.Factory,
+	db *kv.DB,
+	stopper *stop.Stopper,
+	st *cluster.Settings,
+	registry *Registry,
+) *Watcher {
+	return &Watcher{
+		codec:    codec,
+		clock:    clock,
+		f:        f,
+		db:       db,
+		stopper:  stopper,
+		st:       st,
+		registry: registry,
+	}
+}
+
+// Start starts the Watcher in the background. The rangefeed is only set up
+// once the system.notifications table exists, which requires the upgrade to
+// V23_1_CreateSystemNotificationsTable to be finalized.
+func (w *Watcher) Start(ctx context.Context, sysTableResolver catalog.SystemTableIDResolver) error {
+	return w.stopper.RunAsyncTask(ctx, "notifications-watcher", func(ctx context.Context) {
+		ctx, cancel := w.stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		if err := w.run(ctx, sysTableResolver); err != nil && ctx.Err() == nil {
+			log.Warningf(ctx, "notifications watcher stopped: %v", err)
+		}
+	})
+}
+
+func (w *Watcher) run(ctx context.Context, sysTableResolver catalog.SystemTableIDResolver) error {
+	var tableID uint32
+	for r := retry.StartWithCtx(ctx, startRetryOptions); ; {
+		if !r.Next() {
+			return ctx.Err()
+		}
+		if !w.st.Version.IsActive(ctx, clusterversion.V23_1_CreateSystemNotificationsTable) {
+			continue
+		}
+		id, err := sysTableResolver.LookupSystemTableID(ctx, systemschema.SystemNotificationsTable.GetName())
+		if err != nil {
+			log.Warningf(ctx, "failed to look up the notifications table: %v", err)
+			continue
+		}
+		tableID = uint32(id)
+		break
+	}
+
+	indexPrefix := w.codec.IndexPrefix(tableID, uint32(systemschema.SystemNotificationsTable.GetPrimaryIndexID()))
+	indexSpan := roachpb.Span{Key: indexPrefix, EndKey: indexPrefix.PrefixEnd()}
+	b := notificationBuffer{dec: makeRowDecoder(w.codec)}
+	// The callbacks of the rangefeed are invoked sequentially, so the buffer
+	// doesn't need to be synchronized.
+	rf, err := w.f.RangeFeed(ctx, "notifications-watcher", []roachpb.Span{indexSpan}, w.clock.Now(),
+		b.add,
+		rangefeed.WithSystemTablePriority(),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, frontier hlc.Timestamp) {
+			b.flush(frontier, w.registry)
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(cleanupInterval)
+		select {
+		case <-timer.C:
+			timer.Read = true
+			if err := w.deleteExpired(ctx, indexPrefix); err != nil {
+				log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// deleteExpired deletes the notifications created before the retention
+// period. Since the primary key of the table starts with the creation time of
+// the notifications, they are all contained in a prefix of the index.
+func (w *Watcher) deleteExpired(ctx context.Context, indexPrefix roachpb.Key) error {
+	cutoff := w.clock.PhysicalTime().Add(-retention.Get(&w.st.SV))
+	endKey, err := keyside.Encode(
+		indexPrefix.Clone(), tree.MustMakeDTimestampTZ(cutoff, time.Microsecond), encoding.Ascending,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = w.db.DelRange(ctx, indexPrefix, roachpb.Key(endKey), false /* returnKeys */)
+	return err
+}
+
+// bufferedNotification is a notification received from the rangefeed that
+// hasn't been delivered yet.
+type bufferedNotification struct {
+	// ts is the commit timestamp of the transaction that sent the
+	// notification.
+	ts hlc.Timestamp
+	// txnID is the ID of the transaction that sent the notification. Several
+	// transactions can commit at the same timestamp.
+	txnID uuid.UUID
+	key   roachpb.Key
+	n     Notification
+}
+
+// sameTxn returns whether the two notifications were sent by the same
+// transaction.
+func (bn *bufferedNotification) sameTxn(other *bufferedNotification) bool {
+	return bn.ts == other.ts && bn.txnID == other.txnID
+}
+
+// notificationBuffer buffers the notifications received from the rangefeed
+// until its frontier advances past their commit timestamp.
+type notificationBuffer struct {
+	dec rowDecoder
+	// frontier is the timestamp up to which notifications were delivered.
+	frontier hlc.Timestamp
+	pending  []bufferedNotification
+}
+
+func (b *notificationBuffer) add(ctx context.Context, kv *roachpb.RangeFeedValue) {
+	// Deletions of expired notifications are ignored, and so are the events
+	// that the rangefeed replays after it's restarted.
+	if !kv.Value.IsPresent() || kv.Value.Timestamp.LessEq(b.frontier) {
+		return
+	}
+	n, txnID, err := b.dec.decodeRow(kv.Key, kv.Value)
+	if err != nil {
+		log.Warningf(ctx, "failed to decode notification %v: %v", kv.Key, err)
+		return
+	}
+	b.pending = append(b.pending, bufferedNotification{
+		ts: kv.Value.Timestamp, txnID: txnID, key: kv.Key, n: n,
+	})
+}
+
+// flush delivers the buffered notifications committed at or before the given
+// frontier to the registry.
+func (b *notificationBuffer) flush(frontier hlc.Timestamp, registry *Registry) {
+	if !b.frontier.Less(frontier) {
+		return
+	}
+	b.frontier = frontier
+	sort.Slice(b.pending, func(i, j int) bool {
+		if b.pending[i].ts != b.pending[j].ts {
+			return b.pending[i].ts.Less(b.pending[j].ts)
+		}
+		if c := bytes.Compare(b.pending[i].txnID.GetBytes(), b.pending[j].txnID.GetBytes()); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(b.pending[i].key, b.pending[j].key) < 0
+	})
+	var batch []Notification
+	i := 0
+	for ; i < len(b.pending) && b.pending[i].ts.LessEq(frontier); i++ {
+		if i > 0 && !b.pending[i].sameTxn(&b.pending[i-1]) {
+			registry.Notify(b.pending[i-1].ts, batch)
+			batch = nil
+		}
+		if !containsNotification(batch, b.pending[i].n) {
+			batch = append(batch, b.pending[i].n)
+		}
+	}
+	if len(batch) > 0 {
+		registry.Notify(b.pending[i-1].ts, batch)
+	}
+	b.pending = append(b.pending[:0], b.pending[i:]...)
+}
+
+// containsNotification returns whether the given notifications, sent by the
+// same transaction, contain a duplicate of n.
+func containsNotification(notifications []Notification, n Notification) bool {
+	for i := range notifications {
+		if notifications[i].Channel == n.Channel && notifications[i].Payload == n.Payload &&
+			notifications[i].PID == n.PID {
+			return true
+		}
+	}
+	return false
+}
+
+// rowDecoder decodes rows from the system.notifications table.
+type rowDecoder struct {
+	codec   keys.SQLCodec
+	alloc   tree.DatumAlloc
+	columns []catalog.Column
+	decoder valueside.Decoder
+}
+
+func makeRowDecoder(codec keys.SQLCodec) rowDecoder {
+	columns := systemschema.SystemNotificationsTable.PublicColumns()
+	return rowDecoder{
+		codec:   codec,
+		columns: columns,
+		decoder: valueside.MakeDecoder(columns),
+	}
+}
+
+// decodeRow decodes the notification stored in a row of the
+// system.notifications table, along with the ID of the transaction that sent
+// it.
+func (d *rowDecoder) decodeRow(
+	key roachpb.Key, value roachpb.Value,
+) (Notification, uuid.UUID, error) {
+	// The key columns (created and id) don't need to be decoded, but the key
+	// must still be checked.
+	keyTypes := []*types.T{d.columns[0].GetType(), d.columns[1].GetType()}
+	keyVals := make([]rowenc.EncDatum, 2)
+	if _, _, err := rowenc.DecodeIndexKey(d.codec, keyTypes, keyVals, nil, key); err != nil {
+		return Notification{}, uuid.UUID{}, errors.Wrap(err, "failed to decode key")
+	}
+	// The rest of the columns are stored as a family.
+	tuple, err := value.GetTuple()
+	if err != nil {
+		return Notification{}, uuid.UUID{}, err
+	}
+	datums, err := d.decoder.Decode(&d.alloc, tuple)
+	if err != nil {
+		return Notification{}, uuid.UUID{}, err
+	}
+	for _, i := range []int{2, 3, 4, 5} {
+		if datums[i] == tree.DNull {
+			return Notification{}, uuid.UUID{}, errors.AssertionFailedf(
+				"unexpected NULL in column %s", d.columns[i].GetName(),
+			)
+		}
+	}
+	return Notification{
+		Channel: string(tree.MustBeDString(datums[2])),
+		Payload: string(tree.MustBeDString(datums[3])),
+		PID:     uint32(tree.MustBeDInt(datums[4])),
+	}, datums[5].(*tree.DUuid).UUID, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationBuffer checks that the buffered notifications are delivered
+// in commit order once the frontier advances past their commit timestamp, and
+// that duplicates are only delivered once per transaction, even when several
+// transactions commit at the same timestamp.
+func TestNotificationBuffer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry()
+	l := r.NewListener(func() {})
+	l.Listen("a", hlc.Timestamp{})
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	txn1, txn2 := uuid.UUID{1}, uuid.UUID{2}
+	var b notificationBuffer
+	add := func(wallTime int64, txnID uuid.UUID, key string, payload string) {
+		b.pending = append(b.pending, bufferedNotification{
+			ts:    ts(wallTime),
+			txnID: txnID,
+			key:   roachpb.Key(key),
+			n:     Notification{Channel: "a", Payload: payload, PID: 1},
+		})
+	}
+	add(3, txn1, "c", "3")
+	add(1, txn1, "b", "1b")
+	add(1, txn1, "a", "1a")
+	add(1, txn1, "d", "1a")
+	add(1, txn2, "e", "1a")
+	add(2, txn1, "a", "1a")
+
+	b.flush(ts(2), r)
+	require.Equal(t, []Notification{
+		{Channel: "a", Payload: "1a", PID: 1},
+		{Channel: "a", Payload: "1b", PID: 1},
+		{Channel: "a", Payload: "1a", PID: 1},
+		{Channel: "a", Payload: "1a", PID: 1},
+	}, take(t, l))
+	require.Len(t, b.pending, 1)
+
+	// The frontier never regresses.
+	b.flush(ts(1), r)
+	require.Empty(t, take(t, l))
+	require.Equal(t, ts(2), b.frontier)
+
+	b.flush(ts(3), r)
+	require.Equal(t, []Notification{{Channel: "a", Payload: "3", PID: 1}}, take(t, l))
+	require.Empty(t, b.pending)
+}
@@ -293,11 +293,12 @@ func NewInvalidBinaryRepresentationErrorf(format string, args ...interface{}) er
 	return pgerror.Newf(pgcode.InvalidBinaryRepresentation, format, args...)
 }
 
-// validateArrayDimensions takes the number of dimensions and elements and
-// returns an error if we don't support that combination.
-func validateArrayDimensions(nDimensions int, nElements int) error {
+// validateArrayDimensions takes the number of dimensions and elements of an
+// array and returns an error if they are not valid for an array type with the
+// given number of dimensions.
+func validateArrayDimensions(nDimensions int, nElements int, typDimensions int) error {
 	switch nDimensions {
-	case 1:
+	case typDimensions:
 		break
 	case 0:
 		// 0-dimensional array means 0-length array: validate that.
@@ -306,8 +307,20 @@ func validateArrayDimensions(nDimensions int, nElements int) error {
 		}
 		fallthrough
 	default:
-		return unimplemented.NewWithIssuef(32552,
-			"%d-dimension arrays not supported; only 1-dimension", nDimensions)
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%d-dimensional array cannot be decoded as a %d-dimensional array type",
+			nDimensions, typDimensions)
+	}
+	return nil
+}
+
+// validateArrayLowerBounds returns an error if a dimension of an array has a
+// lower bound other than 1, which is not supported. See tree.ErrArrayLowerBound.
+func validateArrayLowerBounds(dims []pgtype.ArrayDimension) error {
+	for _, dim := range dims {
+		if dim.LowerBound != 1 {
+			return tree.ErrArrayLowerBound
+		}
 	}
 	return nil
 }
@@ -321,6 +334,12 @@ func DecodeDatum(
 	id := typ.Oid()
 	switch code {
 	case FormatText:
+		// Multi-dimensional array types share the OID of the corresponding
+		// one-dimensional array type, so they are handled by the generic array
+		// case below rather than by the OID-specific cases.
+		if typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.ArrayFamily {
+			id = oid.T_anyarray
+		}
 		switch id {
 		case oid.T_record:
 			d, _, err := tree.ParseDTupleFromString(evalCtx, string(b), typ)
@@ -452,7 +471,10 @@ func DecodeDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
+			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements), 1); err != nil {
+				return nil, err
+			}
+			if err := validateArrayLowerBounds(arr.Dimensions); err != nil {
 				return nil, err
 			}
 			out := tree.NewDArray(types.Int)
@@ -476,7 +498,10 @@ func DecodeDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
+			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements), 1); err != nil {
+				return nil, err
+			}
+			if err := validateArrayLowerBounds(arr.Dimensions); err != nil {
 				return nil, err
 			}
 			out := tree.NewDArray(types.String)
@@ -524,6 +549,16 @@ func DecodeDatum(
 			}
 			return tree.NewDString(string(b)), nil
 		}
+		if typ.Family() == types.RangeFamily {
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		}
 	case FormatBinary:
 		switch id {
 		case oid.T_record:
@@ -828,11 +863,14 @@ func DecodeDatum(
 			return tree.NewDGeography(ret), nil
 		default:
 			if typ.Family() == types.ArrayFamily {
-				return decodeBinaryArray(ctx, evalCtx, typ.ArrayContents(), b, code)
+				return decodeBinaryArray(ctx, evalCtx, typ, b, code)
 			}
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b)
+			}
 		}
 	default:
 		return nil, errors.AssertionFailedf(
@@ -993,51 +1031,127 @@ func decodeBinaryArray(
 		ElemOid int32
 	}
 	var dim struct {
-		// The next two fields should be arrays of size Ndims. However, since
-		// we only support 1-dimensional arrays for now, for convenience we can
-		// leave them in this struct as such for `binary.Read` to parse for us.
-		DimSize int32
-		// Dim lower bound
-		_ int32
+		DimSize    int32
+		LowerBound int32
 	}
 	r := bytes.NewBuffer(b)
 	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
 		return nil, err
 	}
-	if t.Oid() != oid.Oid(hdr.ElemOid) {
+	elemTyp := t.ArrayInnermostContents()
+	if elemTyp.Oid() != oid.Oid(hdr.ElemOid) {
 		return nil, pgerror.Newf(pgcode.ProtocolViolation, "wrong element type")
 	}
-	arr := tree.NewDArray(t)
+	if hdr.Ndims < 0 || hdr.Ndims > types.MaxArrayDimensions {
+		return nil, NewInvalidBinaryRepresentationErrorf(
+			"invalid number of array dimensions %d", hdr.Ndims)
+	}
 	if hdr.Ndims == 0 {
-		return arr, nil
+		return tree.NewDArray(t.ArrayContents()), nil
 	}
-	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
-		return nil, err
+	// The dimensions are followed by the elements of the array in row-major
+	// order. Each element is prefixed by its length, so the number of elements
+	// cannot exceed the number of remaining 4-byte words. Checking this for
+	// every dimension also prevents the product of the dimensions from
+	// overflowing.
+	dims := make([]int, hdr.Ndims)
+	nElems := 1
+	for i := range dims {
+		if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+			return nil, err
+		}
+		if dim.DimSize <= 0 {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid array dimension %d", dim.DimSize)
+		}
+		if dim.LowerBound != 1 {
+			return nil, tree.ErrArrayLowerBound
+		}
+		dims[i] = int(dim.DimSize)
+		nElems *= dims[i]
+		if nElems > r.Len()/4 {
+			return nil, NewInvalidBinaryRepresentationErrorf(
+				"array dimensions exceed the size of the message")
+		}
 	}
-	if err := validateArrayDimensions(int(hdr.Ndims), int(dim.DimSize)); err != nil {
-		return nil, err
+	// Postgres uses the same OID for array types of any dimensionality, so the
+	// type of the array is determined by the number of dimensions of the value
+	// rather than by the type the value is bound to.
+	arrTyp := elemTyp
+	for range dims {
+		arrTyp = types.MakeArray(arrTyp)
 	}
+	var elems tree.Datums
+	arr := tree.NewDArray(elemTyp)
 	var vlen int32
-	for i := int32(0); i < dim.DimSize; i++ {
+	for i := 0; i < nElems; i++ {
 		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
-		if vlen < 0 {
-			if err := arr.Append(tree.DNull); err != nil {
+		elem := tree.Datum(tree.DNull)
+		if vlen >= 0 {
+			if int(vlen) > r.Len() {
+				return nil, NewInvalidBinaryRepresentationErrorf(
+					"array element length %d exceeds the size of the message", vlen)
+			}
+			var err error
+			if elem, err = DecodeDatum(ctx, evalCtx, elemTyp, code, r.Next(int(vlen))); err != nil {
 				return nil, err
 			}
-			continue
 		}
-		buf := r.Next(int(vlen))
-		elem, err := DecodeDatum(ctx, evalCtx, t, code, buf)
-		if err != nil {
+		if len(dims) == 1 {
+			if err := arr.Append(elem); err != nil {
+				return nil, err
+			}
+		} else {
+			elems = append(elems, elem)
+		}
+	}
+	if len(dims) == 1 {
+		return arr, nil
+	}
+	return tree.NewDArrayFromDimensions(arrTyp, dims, elems)
+}
+
+// decodeBinaryRange decodes the binary format of a range, which consists of
+// the range flags followed by each finite bound as a length-prefixed value of
+// the range's element type.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (tree.Datum, error) {
+	if len(b) < 1 {
+		return nil, NewInvalidBinaryRepresentationErrorf("range requires at least 1 byte")
+	}
+	flags := b[0]
+	r := bytes.NewBuffer(b[1:])
+	if flags&tree.RangeFlagEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	decodeBound := func() (tree.Datum, error) {
+		var vlen int32
+		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
-		if err := arr.Append(elem); err != nil {
+		if vlen < 0 || int(vlen) > r.Len() {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for range bound")
+		}
+		return DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, r.Next(int(vlen)))
+	}
+	var lower, upper tree.Datum
+	var err error
+	if flags&tree.RangeFlagLowerInf == 0 {
+		if lower, err = decodeBound(); err != nil {
+			return nil, err
+		}
+	}
+	if flags&tree.RangeFlagUpperInf == 0 {
+		if upper, err = decodeBound(); err != nil {
 			return nil, err
 		}
 	}
-	return arr, nil
+	if r.Len() != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("unexpected data after range bounds")
+	}
+	return tree.NewDRangeFromFlags(t, lower, upper, flags)
 }
 
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
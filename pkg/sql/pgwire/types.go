@@ -30,7 +30,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -59,6 +58,11 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// As in Postgres, columns of a domain type are described using the base
+	// type of the domain.
+	if t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	size := -1
 	if s, variable := tree.DatumTypeSize(t); !variable {
 		size = int(s)
@@ -248,6 +252,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -755,37 +763,54 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
-	case *tree.DArray:
-		if v.ParamTyp.Family() == types.ArrayFamily {
-			b.setError(unimplemented.NewWithIssueDetail(32552,
-				"binenc", "unsupported binary serialization of multidimensional arrays"))
-			return
+	case *tree.DRange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		// The range flags are followed by each finite bound, which is written
+		// as a length-prefixed value of the range's element type.
+		b.writeByte(v.Flags())
+		elemTyp := v.ResolvedType().RangeContents()
+		for _, bound := range []tree.Datum{v.Lower, v.Upper} {
+			if !v.Empty && bound != nil {
+				writeBinaryDatumNotNull(ctx, b, bound, sessionLoc, elemTyp)
+			}
 		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DArray:
 		initialLen := b.Len()
 
 		// Reserve bytes for writing length later.
 		b.putInt32(int32(0))
 
-		// Put the number of dimensions. We currently support 1d arrays only.
-		var ndims int32 = 1
-		if v.Len() == 0 {
-			ndims = 0
-		}
-		b.putInt32(ndims)
+		// Put the number of dimensions. An empty array has no dimensions. The
+		// elements of a multi-dimensional array are written in row-major order.
+		dims := v.Dimensions()
+		b.putInt32(int32(len(dims)))
+		elems := v.FlatElements()
 		hasNulls := 0
-		if v.HasNulls {
-			hasNulls = 1
+		for _, elem := range elems {
+			if elem == tree.DNull {
+				hasNulls = 1
+				break
+			}
+		}
+		elemTyp := v.ParamTyp
+		if elemTyp.Family() == types.ArrayFamily {
+			elemTyp = elemTyp.ArrayInnermostContents()
 		}
-		oid := v.ParamTyp.Oid()
 		b.putInt32(int32(hasNulls))
-		b.putInt32(int32(oid))
-		if v.Len() > 0 {
-			b.putInt32(int32(v.Len()))
-			// Lower bound, we only support a lower bound of 1.
-			b.putInt32(1)
-			for _, elem := range v.Array {
-				b.writeBinaryDatum(ctx, elem, sessionLoc, v.ParamTyp)
+		b.putInt32(int32(elemTyp.Oid()))
+		if len(dims) > 0 {
+			for _, dim := range dims {
+				b.putInt32(int32(dim))
+				// Lower bound, we only support a lower bound of 1.
+				b.putInt32(1)
+			}
+			for _, elem := range elems {
+				b.writeBinaryDatum(ctx, elem, sessionLoc, elemTyp)
 			}
 		}
 
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -74,8 +75,8 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 		*tree.ReleaseSavepoint, *tree.RenameColumn, *tree.RenameDatabase,
 		*tree.RenameIndex, *tree.RenameTable, *tree.Revoke, *tree.RevokeRole,
 		*tree.RollbackToSavepoint, *tree.RollbackTransaction,
-		*tree.Savepoint, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
-		*tree.SetSessionCharacteristics:
+		*tree.Savepoint, *tree.SetConstraints, *tree.SetTransaction, *tree.SetTracing,
+		*tree.SetSessionAuthorizationDefault, *tree.SetSessionCharacteristics:
 		// These statements do not have result columns and do not support placeholders
 		// so there is no need to do anything during prepare.
 		//
@@ -115,6 +116,14 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 		stmt.Prepared.Columns = colinfo.ShowCommitTimestampColumns
 		return opc.flags, nil
 
+	case *tree.Call:
+		// CALL does not return result columns. Type-check the procedure
+		// invocation in order to infer the types of any placeholders.
+		if _, err := tree.TypeCheck(ctx, t.Proc, &p.semaCtx, types.Any); err != nil {
+			return opc.flags, err
+		}
+		return opc.flags, nil
+
 	case *tree.DeclareCursor:
 		// Build memo for the purposes of typing placeholders.
 		// TODO(jordan): converting DeclareCursor to not be an opaque statement
@@ -351,13 +360,13 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 	opc.optimizer.Init(ctx, p.EvalContext(), opc.catalog)
 	opc.flags = 0
 
-	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE. We could
+	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE/MERGE. We could
 	// support it for all statements in principle, but it would increase the
 	// surface of potential issues (conditions we need to detect to invalidate a
 	// cached memo).
 	switch p.stmt.AST.(type) {
 	case *tree.ParenSelect, *tree.Select, *tree.SelectClause, *tree.UnionClause, *tree.ValuesClause,
-		*tree.Insert, *tree.Update, *tree.Delete, *tree.CannedOptPlan:
+		*tree.Insert, *tree.Update, *tree.Delete, *tree.Merge, *tree.CannedOptPlan:
 		// If the current transaction has uncommitted DDL statements, we cannot rely
 		// on descriptor versions for detecting a "stale" memo. This is because
 		// descriptor versions are bumped at most once per transaction, even if there
@@ -377,6 +386,13 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 		opc.allowMemoReuse = false
 		opc.useCache = false
 	}
+
+	// The arguments of a procedure are inlined into the memos of the statements
+	// in its body, so these memos cannot be cached.
+	if p.procedureCall != nil {
+		opc.allowMemoReuse = false
+		opc.useCache = false
+	}
 }
 
 func (opc *optPlanningCtx) log(ctx context.Context, msg redact.SafeString) {
@@ -504,6 +520,125 @@ func (opc *optPlanningCtx) reuseMemo(
 	return f.Memo(), nil
 }
 
+// fetchPreparedMemo returns a fully optimized memo for the prepared statement
+// being executed. Depending on the plan_cache_mode session setting, it either
+// uses the generic plan of the statement, which is built once and reused as-is,
+// or builds a custom plan by assigning the values of the placeholders to the
+// prepared memo and re-optimizing it.
+//
+// When plan_cache_mode is auto, a generic plan is considered only after
+// customPlanThreshold custom plans have been built, and it is used only if its
+// cost is not significantly greater than the average cost of the custom plans.
+func (opc *optPlanningCtx) fetchPreparedMemo(ctx context.Context) (_ *memo.Memo, err error) {
+	p := opc.p
+	prepared := p.stmt.Prepared
+
+	// If the prepared memo has been invalidated by schema or other changes,
+	// re-prepare it. The generic memo, which was built from the prepared memo,
+	// is discarded as well, and the costs of the plans built so far are
+	// forgotten, since they may not reflect the costs of new plans.
+	if isStale, err := prepared.Memo.IsStale(ctx, p.EvalContext(), opc.catalog); err != nil {
+		return nil, err
+	} else if isStale {
+		opc.log(ctx, "rebuilding cached memo")
+		prepared.Memo, err = opc.buildReusableMemo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		prepared.clearGenericMemo(ctx)
+		prepared.Costs.Reset()
+	}
+
+	// A fully optimized prepared memo (e.g., if the statement has no
+	// placeholders or the placeholder fast path succeeded) is already generic.
+	if !prepared.Memo.IsOptimized() && opc.allowMemoReuse {
+		mode := p.SessionData().PlanCacheMode
+		if mode == sessiondatapb.PlanCacheModeForceGeneric ||
+			(mode == sessiondatapb.PlanCacheModeAuto && prepared.Costs.NumCustom() >= customPlanThreshold) {
+			optimized := false
+			if prepared.GenericMemo == nil {
+				opc.log(ctx, "building generic memo")
+				var genericMemo *memo.Memo
+				genericMemo, err = opc.buildGenericMemo(ctx, prepared.Memo)
+				if err != nil {
+					return nil, err
+				}
+				if err = prepared.setGenericMemo(ctx, genericMemo); err != nil {
+					return nil, err
+				}
+				prepared.Costs.SetGeneric(prepared.GenericMemo.RootExpr().(memo.RelExpr).Cost())
+				optimized = true
+			}
+			if mode == sessiondatapb.PlanCacheModeForceGeneric ||
+				prepared.Costs.Generic() <= prepared.Costs.AvgCustom()+genericPlanOverhead(prepared.GenericMemo) {
+				opc.log(ctx, "using generic memo")
+				opc.flags.Set(planFlagGeneric)
+				if optimized {
+					opc.flags.Set(planFlagOptimized)
+				}
+				return prepared.GenericMemo, nil
+			}
+		}
+	}
+
+	opc.log(ctx, "reusing cached memo")
+	customMemo, err := opc.reuseMemo(ctx, prepared.Memo)
+	if err != nil {
+		return nil, err
+	}
+	if !prepared.Memo.IsOptimized() {
+		prepared.Costs.AddCustom(customMemo.RootExpr().(memo.RelExpr).Cost())
+	}
+	return customMemo, nil
+}
+
+// genericPlanOverhead returns the estimated cost of the planning that is
+// avoided by using a generic plan instead of building a custom plan. Like in
+// Postgres, it is proportional to the number of tables in the query.
+func genericPlanOverhead(genericMemo *memo.Memo) memo.Cost {
+	return memo.Cost(2.5 * float64(len(genericMemo.Metadata().AllTables())+1))
+}
+
+// buildGenericMemo builds a fully optimized memo from the given prepared memo
+// without assigning values to its placeholders. The resulting memo can be used
+// to execute the statement with any placeholder values. Like the prepared memo,
+// it is fully detached from the planner.
+func (opc *optPlanningCtx) buildGenericMemo(
+	ctx context.Context, preparedMemo *memo.Memo,
+) (_ *memo.Memo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate internal errors without having to add
+			// error checks everywhere throughout the code. This is only possible
+			// because the code does not update shared state and does not manipulate
+			// locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				// Other panic objects can't be considered "safe" and thus are
+				// propagated as crashes that terminate the session.
+				panic(r)
+			}
+		}
+	}()
+
+	// Stable operators are not constant-folded, since their results could
+	// change between executions of the generic plan.
+	f := opc.optimizer.Factory()
+	f.CopyAndReplace(
+		preparedMemo.RootExpr().(memo.RelExpr),
+		preparedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	// Exploration rules which only apply to generic plans are enabled only
+	// while the generic memo is optimized.
+	opc.optimizer.EnableGenericRules()
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, err
+	}
+	return opc.optimizer.DetachMemo(ctx), nil
+}
+
 // buildExecMemo creates a fully optimized memo, possibly reusing a previously
 // cached memo as a starting point.
 //
@@ -515,21 +650,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	if opc.allowMemoReuse && prepared != nil && prepared.Memo != nil {
 		// We are executing a previously prepared statement and a reusable memo is
 		// available.
-
-		// If the prepared memo has been invalidated by schema or other changes,
-		// re-prepare it.
-		if isStale, err := prepared.Memo.IsStale(ctx, p.EvalContext(), opc.catalog); err != nil {
-			return nil, err
-		} else if isStale {
-			opc.log(ctx, "rebuilding cached memo")
-			prepared.Memo, err = opc.buildReusableMemo(ctx)
-			if err != nil {
-				return nil, err
-			}
-		}
-		opc.log(ctx, "reusing cached memo")
-		memo, err := opc.reuseMemo(ctx, prepared.Memo)
-		return memo, err
+		return opc.fetchPreparedMemo(ctx)
 	}
 
 	if opc.useCache {
@@ -567,6 +688,10 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
+	if call := p.procedureCall; call != nil {
+		bld.ProcedureParams = call.params
+		bld.ProcedureArgs = call.args
+	}
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
@@ -645,6 +770,8 @@ func (opc *optPlanningCtx) runExecBuilder(
 		result = explainPlan.WrappedPlan.(*planComponents)
 		planTop.instrumentation.RecordExplainPlan(explainPlan)
 	}
+	planTop.instrumentation.generic = opc.flags.IsSet(planFlagGeneric)
+	planTop.instrumentation.optimized = opc.flags.IsSet(planFlagOptimized)
 	planTop.instrumentation.maxFullScanRows = bld.MaxFullScanRows
 	planTop.instrumentation.totalScanRows = bld.TotalScanRows
 	planTop.instrumentation.totalScanRowsWithoutForecasts = bld.TotalScanRowsWithoutForecasts
@@ -126,6 +126,8 @@ type PlanHookState interface {
 	Txn() *kv.Txn
 	LookupTenantInfo(ctx context.Context, tenantSpec *tree.TenantSpec, op string) (*descpb.TenantInfo, error)
 	GetAvailableTenantID(ctx context.Context, name roachpb.TenantName) (roachpb.TenantID, error)
+	AddSubscription(ctx context.Context, sub descpb.DatabaseDescriptor_Subscription) error
+	RemoveSubscription(ctx context.Context, name string) (descpb.DatabaseDescriptor_Subscription, error)
 }
 
 // AddPlanHook adds a hook used to short-circuit creating a planNode from a
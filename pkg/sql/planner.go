@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
@@ -98,6 +99,19 @@ type extendedEvalContext struct {
 	// jobsCollection.
 	Jobs *jobsCollection
 
+	// DeferredConstraints refers to deferredConstraints in extraTxnState. It is
+	// nil if constraint checks cannot be deferred until the end of the
+	// transaction.
+	DeferredConstraints *deferredConstraints
+
+	// Notifications refers to notifications in extraTxnState. It is nil if the
+	// session does not support asynchronous notifications.
+	Notifications *txnNotifications
+
+	// Listener is the session's listener for asynchronous notifications. It is
+	// nil until the session executes its first LISTEN statement.
+	Listener *pgnotify.Listener
+
 	// SchemaChangeJobRecords refers to schemaChangeJobsCache in extraTxnState of
 	// in sql.connExecutor. sql.connExecutor.createJobs() enqueues jobs with these
 	// records when transaction is committed.
@@ -212,6 +226,11 @@ type planner struct {
 	// auto-commit. This is dependent on information from the optimizer.
 	autoCommit bool
 
+	// procedureCall is set while the statements in the body of a procedure
+	// invoked by a CALL statement are planned and executed. It is used to bind
+	// the procedure's arguments to references to its parameters.
+	procedureCall *procedureCall
+
 	// cancelChecker is used by planNodes to check for cancellation of the associated
 	// query.
 	cancelChecker cancelchecker.CancelChecker
@@ -0,0 +1,756 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package parser contains the parser for the body of PL/pgSQL routines.
+//
+// The control structures of PL/pgSQL are parsed by a recursive descent parser
+// that operates on the tokens produced by the SQL scanner. The SQL expressions
+// and statements embedded in the control structures are delimited by keywords
+// and punctuation, and are parsed by the SQL parser.
+package parser
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	sqlparser "github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/scanner"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// Parse parses the body of a PL/pgSQL routine, which consists of a single
+// block.
+func Parse(body string) (_ *plpgsqltree.Block, err error) {
+	p := parser{body: body, toks: scanner.Inspect(body)}
+	if last := p.toks[len(p.toks)-1]; last.ID == lexbase.ERROR || last.ID == -1 {
+		return nil, pgerror.Newf(pgcode.Syntax, "at or near %q: syntax error: %s",
+			body[last.Start:], last.Str)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if parseErr, ok := r.(parseError); ok {
+				err = parseErr.error
+				return
+			}
+			panic(r)
+		}
+	}()
+	block := p.parseBlock(p.parseLabel())
+	p.skip(";")
+	if !p.atEOF() {
+		p.errorf("expected end of routine body")
+	}
+	return block, nil
+}
+
+// parseError wraps errors that are raised during parsing, so that they can be
+// distinguished from other panics.
+type parseError struct {
+	error
+}
+
+// parser holds the state of the parser.
+type parser struct {
+	body string
+	toks []scanner.InspectToken
+	pos  int
+}
+
+// peek returns the current token.
+func (p *parser) peek() scanner.InspectToken {
+	return p.toks[p.pos]
+}
+
+// peekN returns the token n positions after the current token, or the EOF
+// token if there are fewer tokens.
+func (p *parser) peekN(n int) scanner.InspectToken {
+	if p.pos+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.pos+n]
+}
+
+// next returns the current token and advances to the next one.
+func (p *parser) next() scanner.InspectToken {
+	tok := p.toks[p.pos]
+	if !p.atEOF() {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) atEOF() bool {
+	return p.peek().ID == 0
+}
+
+// isWord returns true if the token is the given unquoted keyword or
+// identifier. Keywords are recognized case-insensitively.
+func isWord(tok scanner.InspectToken, word string) bool {
+	return isIdent(tok) && !tok.Quoted && tok.Str == word
+}
+
+// isIdent returns true if the token is an identifier or a keyword.
+func isIdent(tok scanner.InspectToken) bool {
+	return tok.ID == lexbase.IDENT || (!tok.Quoted && lexbase.GetKeywordID(tok.Str) == tok.ID)
+}
+
+// isPunct returns true if the token is the given punctuation character.
+func isPunct(tok scanner.InspectToken, ch byte) bool {
+	return tok.ID == int32(ch)
+}
+
+// isAssign returns true if the current token starts an assignment operator,
+// which is either := or =.
+func (p *parser) isAssign() bool {
+	tok := p.peek()
+	if isPunct(tok, '=') {
+		return true
+	}
+	eq := p.peekN(1)
+	return isPunct(tok, ':') && isPunct(eq, '=') && tok.End == eq.Start
+}
+
+// skipAssign consumes an assignment operator.
+func (p *parser) skipAssign() {
+	if !p.isAssign() {
+		p.errorf("expected :=")
+	}
+	if isPunct(p.next(), ':') {
+		p.next()
+	}
+}
+
+// is returns true if the current token is the given word or, if the word is
+// a single punctuation character, that character.
+func (p *parser) is(word string) bool {
+	tok := p.peek()
+	if len(word) == 1 && !lexbase.IsIdentStart(int(word[0])) {
+		return isPunct(tok, word[0])
+	}
+	return isWord(tok, word)
+}
+
+// skip consumes the current token if it is the given word or punctuation, and
+// returns true if it was consumed.
+func (p *parser) skip(word string) bool {
+	if p.is(word) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// expect consumes the current token, which must be the given word or
+// punctuation.
+func (p *parser) expect(word string) {
+	if !p.skip(word) {
+		p.errorf("expected %s", strings.ToUpper(word))
+	}
+}
+
+// errorf raises a syntax error at the current token.
+func (p *parser) errorf(format string, args ...interface{}) {
+	tok := p.peek()
+	near := "EOF"
+	if isIdent(tok) {
+		near = tok.Str
+	} else if !p.atEOF() {
+		near = p.body[tok.Start:tok.End]
+	}
+	err := pgerror.Newf(pgcode.Syntax, "at or near %q: syntax error", near)
+	panic(parseError{errors.WithDetailf(err, format, args...)})
+}
+
+// raise raises the given error.
+func raise(err error) {
+	panic(parseError{err})
+}
+
+// parseIdent consumes and returns an identifier.
+func (p *parser) parseIdent() tree.Name {
+	tok := p.peek()
+	if !isIdent(tok) {
+		p.errorf("expected identifier")
+	}
+	p.next()
+	return tree.Name(tok.Str)
+}
+
+// parseIdentList consumes a comma-separated list of identifiers.
+func (p *parser) parseIdentList() []tree.Name {
+	names := []tree.Name{p.parseIdent()}
+	for p.skip(",") {
+		names = append(names, p.parseIdent())
+	}
+	return names
+}
+
+// parseLabel consumes a <<label>>, if there is one.
+func (p *parser) parseLabel() string {
+	if p.peek().ID != lexbase.LSHIFT {
+		return ""
+	}
+	p.next()
+	label := p.parseIdent()
+	if p.peek().ID != lexbase.RSHIFT {
+		p.errorf("expected >>")
+	}
+	p.next()
+	return string(label)
+}
+
+// parseEndLabel consumes the optional label following END or END LOOP, which
+// must match the label of the block or loop.
+func (p *parser) parseEndLabel(label string) {
+	if isIdent(p.peek()) {
+		endLabel := string(p.parseIdent())
+		if endLabel != label {
+			raise(pgerror.Newf(pgcode.Syntax,
+				"end label %q differs from block's label %q", endLabel, label))
+		}
+	}
+	p.expect(";")
+}
+
+// parseBlock parses a block, starting at DECLARE or BEGIN.
+func (p *parser) parseBlock(label string) *plpgsqltree.Block {
+	block := &plpgsqltree.Block{Label: label}
+	if p.skip("declare") {
+		for !p.is("begin") && !p.atEOF() {
+			block.Decls = append(block.Decls, p.parseDeclaration())
+		}
+	}
+	p.expect("begin")
+	block.Body = p.parseStmts()
+	if p.skip("exception") {
+		for p.is("when") {
+			block.Exceptions = append(block.Exceptions, p.parseException())
+		}
+		if len(block.Exceptions) == 0 {
+			p.errorf("expected WHEN")
+		}
+	}
+	p.expect("end")
+	if isIdent(p.peek()) {
+		endLabel := string(p.parseIdent())
+		if endLabel != label {
+			raise(pgerror.Newf(pgcode.Syntax,
+				"end label %q differs from block's label %q", endLabel, label))
+		}
+	}
+	return block
+}
+
+// parseDeclaration parses a variable declaration.
+func (p *parser) parseDeclaration() *plpgsqltree.Declaration {
+	decl := &plpgsqltree.Declaration{Var: p.parseIdent()}
+	if p.is("alias") || p.is("cursor") || p.is("refcursor") || p.is("scroll") {
+		raise(unimplemented.Newf("plpgsql declaration", "%s declarations are not supported",
+			strings.ToUpper(p.peek().Str)))
+	}
+	decl.Constant = p.skip("constant")
+
+	// The type extends up to the first token that can follow it.
+	typStart := p.pos
+	p.skipUntil(func() bool {
+		return p.is(";") || p.is("not") || p.is("default") || p.is("collate") || p.isAssign()
+	})
+	typText := p.text(typStart, p.pos)
+	if typText == "" {
+		p.errorf("expected type")
+	}
+	if strings.Contains(typText, "%") {
+		raise(unimplemented.New("plpgsql %TYPE", "%TYPE and %ROWTYPE are not supported"))
+	}
+	typ, err := sqlparser.GetTypeFromValidSQLSyntax(typText)
+	if err != nil {
+		raise(err)
+	}
+	decl.Typ = typ
+
+	if p.is("collate") {
+		raise(unimplemented.New("plpgsql collate", "COLLATE is not supported in declarations"))
+	}
+	if p.skip("not") {
+		p.expect("null")
+		decl.NotNull = true
+	}
+	if p.skip("default") || p.isAssign() {
+		if p.isAssign() {
+			p.skipAssign()
+		}
+		decl.Default = p.parseExpr(";")
+	}
+	p.expect(";")
+	return decl
+}
+
+// parseException parses an exception handler, starting at WHEN.
+func (p *parser) parseException() *plpgsqltree.Exception {
+	p.expect("when")
+	e := &plpgsqltree.Exception{}
+	for {
+		var cond plpgsqltree.Condition
+		if p.skip("sqlstate") {
+			tok := p.next()
+			if tok.ID != lexbase.SCONST {
+				p.pos--
+				p.errorf("expected SQLSTATE code")
+			}
+			cond.SQLState = validateSQLState(tok.Str)
+		} else {
+			cond.Name = string(p.parseIdent())
+			if _, ok := pgcode.PLpgSQLConditionNameToCode[cond.Name]; !ok && cond.Name != "others" {
+				raise(pgerror.Newf(pgcode.UndefinedObject,
+					"unrecognized exception condition %q", cond.Name))
+			}
+		}
+		e.Conditions = append(e.Conditions, cond)
+		if !p.skip("or") {
+			break
+		}
+	}
+	p.expect("then")
+	e.Body = p.parseStmts()
+	return e
+}
+
+// validateSQLState returns the given SQLSTATE code if it is valid.
+func validateSQLState(code string) string {
+	if len(code) != 5 {
+		raise(pgerror.Newf(pgcode.Syntax, "invalid SQLSTATE code %q", code))
+	}
+	for _, ch := range code {
+		if !(ch >= '0' && ch <= '9') && !(ch >= 'A' && ch <= 'Z') {
+			raise(pgerror.Newf(pgcode.Syntax, "invalid SQLSTATE code %q", code))
+		}
+	}
+	return code
+}
+
+// parseStmts parses statements up to the END, ELSE, ELSIF, EXCEPTION or WHEN
+// keyword that terminates them.
+func (p *parser) parseStmts() []plpgsqltree.Statement {
+	stmts := []plpgsqltree.Statement{}
+	for !p.atEOF() {
+		if p.is("end") || p.is("else") || p.is("elsif") || p.is("elseif") ||
+			p.is("exception") || p.is("when") {
+			break
+		}
+		stmts = append(stmts, p.parseStmt())
+	}
+	return stmts
+}
+
+// parseStmt parses a single statement.
+func (p *parser) parseStmt() plpgsqltree.Statement {
+	label := p.parseLabel()
+	if label != "" {
+		switch {
+		case p.is("declare"), p.is("begin"):
+			block := p.parseBlock(label)
+			p.expect(";")
+			return block
+		case p.is("loop"), p.is("while"), p.is("for"):
+		default:
+			p.errorf("expected block or loop after label")
+		}
+	}
+
+	tok := p.peek()
+	if !isIdent(tok) || tok.Quoted {
+		if isIdent(tok) && p.isAssignmentAfterIdent() {
+			return p.parseAssignment()
+		}
+		return p.parseExecSQL()
+	}
+	switch tok.Str {
+	case "declare", "begin":
+		block := p.parseBlock("")
+		p.expect(";")
+		return block
+	case "if":
+		return p.parseIf()
+	case "loop":
+		p.next()
+		loop := &plpgsqltree.Loop{Label: label}
+		loop.Body = p.parseLoopBody(label)
+		return loop
+	case "while":
+		p.next()
+		while := &plpgsqltree.While{Label: label}
+		while.Condition = p.parseExpr("loop")
+		while.Body = p.parseLoopBody(label)
+		return while
+	case "for":
+		return p.parseFor(label)
+	case "exit", "continue":
+		p.next()
+		var loopLabel string
+		if isIdent(p.peek()) && !p.is("when") {
+			loopLabel = string(p.parseIdent())
+		}
+		var cond tree.Expr
+		if p.skip("when") {
+			cond = p.parseExpr(";")
+		}
+		p.expect(";")
+		if tok.Str == "exit" {
+			return &plpgsqltree.Exit{Label: loopLabel, Condition: cond}
+		}
+		return &plpgsqltree.Continue{Label: loopLabel, Condition: cond}
+	case "return":
+		p.next()
+		if p.is("next") || p.is("query") {
+			raise(unimplemented.Newf("plpgsql return", "RETURN %s is not supported",
+				strings.ToUpper(p.peek().Str)))
+		}
+		ret := &plpgsqltree.Return{}
+		if !p.is(";") {
+			ret.Expr = p.parseExpr(";")
+		}
+		p.expect(";")
+		return ret
+	case "raise":
+		return p.parseRaise()
+	case "perform":
+		p.next()
+		start := p.pos
+		p.skipUntil(func() bool { return p.is(";") })
+		stmt, err := sqlparser.ParseOne("SELECT " + p.text(start, p.pos))
+		if err != nil {
+			raise(err)
+		}
+		p.expect(";")
+		return &plpgsqltree.Perform{Query: stmt.AST}
+	case "null":
+		if isPunct(p.peekN(1), ';') {
+			p.next()
+			p.next()
+			return &plpgsqltree.Null{}
+		}
+	case "case", "foreach", "execute", "get", "open", "fetch", "move", "close", "assert",
+		"commit", "rollback", "call":
+		raise(unimplemented.Newf("plpgsql "+tok.Str, "%s statement is not supported in PL/pgSQL",
+			strings.ToUpper(tok.Str)))
+	}
+	if p.isAssignmentAfterIdent() {
+		return p.parseAssignment()
+	}
+	return p.parseExecSQL()
+}
+
+// isAssignmentAfterIdent returns true if the current token is an identifier
+// that is followed by an assignment operator.
+func (p *parser) isAssignmentAfterIdent() bool {
+	p.pos++
+	defer func() { p.pos-- }()
+	return p.isAssign()
+}
+
+// parseAssignment parses an assignment to a variable.
+func (p *parser) parseAssignment() *plpgsqltree.Assignment {
+	a := &plpgsqltree.Assignment{Var: p.parseIdent()}
+	p.skipAssign()
+	a.Value = p.parseExpr(";")
+	p.expect(";")
+	return a
+}
+
+// parseIf parses an IF statement.
+func (p *parser) parseIf() *plpgsqltree.If {
+	p.expect("if")
+	s := &plpgsqltree.If{}
+	s.Condition = p.parseExpr("then")
+	p.expect("then")
+	s.ThenBody = p.parseStmts()
+	for p.skip("elsif") || p.skip("elseif") {
+		var elseIf plpgsqltree.ElseIf
+		elseIf.Condition = p.parseExpr("then")
+		p.expect("then")
+		elseIf.Body = p.parseStmts()
+		s.ElseIfs = append(s.ElseIfs, elseIf)
+	}
+	if p.skip("else") {
+		s.ElseBody = p.parseStmts()
+	}
+	p.expect("end")
+	p.expect("if")
+	p.expect(";")
+	return s
+}
+
+// parseLoopBody parses the body of a loop, starting at LOOP.
+func (p *parser) parseLoopBody(label string) []plpgsqltree.Statement {
+	p.expect("loop")
+	body := p.parseStmts()
+	p.expect("end")
+	p.expect("loop")
+	p.parseEndLabel(label)
+	return body
+}
+
+// parseFor parses a FOR loop over a range of integers or over the rows of a
+// query.
+func (p *parser) parseFor(label string) plpgsqltree.Statement {
+	p.expect("for")
+	targets := p.parseIdentList()
+	p.expect("in")
+
+	// The loop iterates over a range of integers if there is a .. before LOOP.
+	isIntLoop := false
+	for i, depth := p.pos, 0; i < len(p.toks)-1; i++ {
+		tok := p.toks[i]
+		if depth == 0 && (isWord(tok, "loop") || tok.ID == lexbase.DOT_DOT) {
+			isIntLoop = tok.ID == lexbase.DOT_DOT
+			break
+		}
+		depth += nestingDelta(tok)
+	}
+
+	if !isIntLoop {
+		if p.is("reverse") {
+			p.errorf("REVERSE is only allowed in FOR loops over integers")
+		}
+		start := p.pos
+		p.skipUntil(func() bool { return p.is("loop") })
+		stmt, err := sqlparser.ParseOne(p.text(start, p.pos))
+		if err != nil {
+			raise(err)
+		}
+		s := &plpgsqltree.ForQuery{Label: label, Targets: targets, Query: stmt.AST}
+		s.Body = p.parseLoopBody(label)
+		return s
+	}
+
+	if len(targets) != 1 {
+		p.errorf("expected a single loop variable")
+	}
+	s := &plpgsqltree.ForInt{Label: label, Var: targets[0]}
+	s.Reverse = p.skip("reverse")
+	isDotDot := func() bool { return p.peek().ID == lexbase.DOT_DOT }
+	s.Lower = p.parseExprUntil(isDotDot)
+	p.next()
+	s.Upper = p.parseExpr("by", "loop")
+	if p.skip("by") {
+		s.Step = p.parseExpr("loop")
+	}
+	s.Body = p.parseLoopBody(label)
+	return s
+}
+
+// parseRaise parses a RAISE statement.
+func (p *parser) parseRaise() *plpgsqltree.Raise {
+	p.expect("raise")
+	s := &plpgsqltree.Raise{}
+	if p.skip(";") {
+		return s
+	}
+	for _, level := range []string{"debug", "log", "info", "notice", "warning", "exception"} {
+		if p.skip(level) {
+			s.Level = strings.ToUpper(level)
+			break
+		}
+	}
+	switch tok := p.peek(); {
+	case tok.ID == lexbase.SCONST:
+		p.next()
+		s.Message = tok.Str
+		for p.skip(",") {
+			s.Params = append(s.Params, p.parseExpr(",", ";", "using"))
+		}
+		if n := countRaisePlaceholders(s.Message); n > len(s.Params) {
+			raise(pgerror.New(pgcode.Syntax, "too few parameters specified for RAISE"))
+		} else if n < len(s.Params) {
+			raise(pgerror.New(pgcode.Syntax, "too many parameters specified for RAISE"))
+		}
+	case isWord(tok, "sqlstate"):
+		p.next()
+		code := p.next()
+		if code.ID != lexbase.SCONST {
+			p.pos--
+			p.errorf("expected SQLSTATE code")
+		}
+		s.SQLState = validateSQLState(code.Str)
+	case isWord(tok, "using"):
+	case isIdent(tok):
+		s.CondName = string(p.parseIdent())
+		if _, ok := pgcode.PLpgSQLConditionNameToCode[s.CondName]; !ok {
+			raise(pgerror.Newf(pgcode.UndefinedObject,
+				"unrecognized exception condition %q", s.CondName))
+		}
+	}
+	if p.skip("using") {
+		for {
+			name := strings.ToUpper(string(p.parseIdent()))
+			switch name {
+			case "MESSAGE", "DETAIL", "HINT", "ERRCODE":
+			case "COLUMN", "CONSTRAINT", "DATATYPE", "TABLE", "SCHEMA":
+				raise(unimplemented.Newf("plpgsql raise option",
+					"RAISE option %s is not supported", name))
+			default:
+				raise(pgerror.Newf(pgcode.Syntax, "unrecognized RAISE statement option %q",
+					strings.ToLower(name)))
+			}
+			for _, opt := range s.Options {
+				if opt.Name == name {
+					raise(pgerror.Newf(pgcode.Syntax, "RAISE option already specified: %s", name))
+				}
+			}
+			if name == "MESSAGE" && (s.Message != "" || s.CondName != "" || s.SQLState != "") {
+				raise(pgerror.New(pgcode.Syntax, "RAISE option already specified: MESSAGE"))
+			}
+			if name == "ERRCODE" && (s.CondName != "" || s.SQLState != "") {
+				raise(pgerror.New(pgcode.Syntax, "RAISE option already specified: ERRCODE"))
+			}
+			p.skipAssign()
+			value := p.parseExpr(",", ";")
+			s.Options = append(s.Options, plpgsqltree.RaiseOption{Name: name, Value: value})
+			if !p.skip(",") {
+				break
+			}
+		}
+	}
+	if s.Level == "" && s.Message == "" && s.CondName == "" && s.SQLState == "" && len(s.Options) == 0 {
+		p.errorf("expected RAISE message")
+	}
+	p.expect(";")
+	return s
+}
+
+// countRaisePlaceholders returns the number of % placeholders in the format
+// string of a RAISE statement. The sequence %% is not a placeholder.
+func countRaisePlaceholders(format string) int {
+	n := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' {
+			if i+1 < len(format) && format[i+1] == '%' {
+				i++
+				continue
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// parseExecSQL parses a SQL statement that is terminated by a semicolon, with
+// an optional INTO clause.
+func (p *parser) parseExecSQL() *plpgsqltree.ExecSQL {
+	s := &plpgsqltree.ExecSQL{}
+	start := p.pos
+	into, afterInto := -1, -1
+	for depth := 0; !p.atEOF(); p.next() {
+		tok := p.peek()
+		if depth == 0 && isPunct(tok, ';') {
+			break
+		}
+		// The INTO clause of INSERT and similar statements is not a target
+		// list.
+		if depth == 0 && into == -1 && isWord(tok, "into") && p.pos > start {
+			prev := p.toks[p.pos-1]
+			if !isWord(prev, "insert") && !isWord(prev, "merge") && !isWord(prev, "import") {
+				into = p.pos
+				p.next()
+				s.Strict = p.skip("strict")
+				s.Into = p.parseIdentList()
+				afterInto = p.pos
+				p.pos--
+				continue
+			}
+		}
+		depth += nestingDelta(tok)
+	}
+	if p.pos == start {
+		p.errorf("expected statement")
+	}
+	text := p.text(start, p.pos)
+	if into != -1 {
+		text = p.text(start, into) + " " + p.text(afterInto, p.pos)
+	}
+	stmt, err := sqlparser.ParseOne(text)
+	if err != nil {
+		raise(err)
+	}
+	s.SQL = stmt.AST
+	p.expect(";")
+	return s
+}
+
+// parseExpr parses a SQL expression that is terminated by one of the given
+// words or punctuation characters, at the top level of nesting.
+func (p *parser) parseExpr(terminators ...string) tree.Expr {
+	return p.parseExprUntil(func() bool {
+		for _, t := range terminators {
+			if p.is(t) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// parseExprUntil parses a SQL expression that ends before the first token at
+// the top level of nesting for which done returns true.
+func (p *parser) parseExprUntil(done func() bool) tree.Expr {
+	start := p.pos
+	p.skipUntil(done)
+	if p.pos == start {
+		p.errorf("expected expression")
+	}
+	expr, err := sqlparser.ParseExpr(p.text(start, p.pos))
+	if err != nil {
+		raise(err)
+	}
+	return expr
+}
+
+// skipUntil advances to the first token at the top level of nesting for which
+// done returns true. It raises an error if there is no such token.
+func (p *parser) skipUntil(done func() bool) {
+	for depth := 0; ; p.next() {
+		if p.atEOF() {
+			p.errorf("unexpected end of routine body")
+		}
+		if depth == 0 && done() {
+			return
+		}
+		depth += nestingDelta(p.peek())
+		if depth < 0 {
+			p.errorf("unexpected %s", p.peek().Str)
+		}
+	}
+}
+
+// nestingDelta returns the change in the level of nesting of SQL syntax
+// caused by the given token. Parentheses, brackets and CASE expressions are
+// nested.
+func nestingDelta(tok scanner.InspectToken) int {
+	switch {
+	case isPunct(tok, '('), isPunct(tok, '['), isWord(tok, "case"):
+		return 1
+	case isPunct(tok, ')'), isPunct(tok, ']'), isWord(tok, "end"):
+		return -1
+	}
+	return 0
+}
+
+// text returns the text of the body spanned by the tokens in [start, end).
+func (p *parser) text(start, end int) string {
+	if start >= end {
+		return ""
+	}
+	return p.body[p.toks[start].Start:p.toks[end-1].End]
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/datadriven"
+)
+
+// TestParseDataDriven verifies that PL/pgSQL routine bodies are parsed into
+// the expected syntax trees, and that the trees can be formatted back into the
+// equivalent PL/pgSQL.
+func TestParseDataDriven(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	datadriven.Walk(t, datapathutils.TestDataPath(t), func(t *testing.T, path string) {
+		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "parse":
+				block, err := parser.Parse(d.Input)
+				if err != nil {
+					d.Fatalf(t, "unexpected parse error: %v", err)
+				}
+				formatted := tree.AsString(block)
+
+				// The formatted body must parse to the same syntax tree.
+				reparsed, err := parser.Parse(formatted)
+				if err != nil {
+					d.Fatalf(t, "error parsing formatted body %q: %v", formatted, err)
+				}
+				if reformatted := tree.AsString(reparsed); reformatted != formatted {
+					d.Fatalf(t, "formatting does not round-trip:\n%s\n%s", formatted, reformatted)
+				}
+				return formatted
+
+			case "error":
+				_, err := parser.Parse(d.Input)
+				if err == nil {
+					d.Fatalf(t, "expected parse error")
+				}
+				return err.Error()
+
+			default:
+				d.Fatalf(t, "unsupported command %s", d.Cmd)
+				return ""
+			}
+		})
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/errors"
+)
+
+// CreatePolicy is only implemented in the declarative schema changer.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	return nil, errRequiresDeclarativeSchemaChanger("CREATE POLICY")
+}
+
+// DropPolicy is only implemented in the declarative schema changer.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	return nil, errRequiresDeclarativeSchemaChanger("DROP POLICY")
+}
+
+// setRowLevelSecurity applies an ALTER TABLE ... ROW LEVEL SECURITY command to
+// the table descriptor and reports whether the descriptor changed.
+func setRowLevelSecurity(desc *tabledesc.Mutable, mode tree.RowLevelSecurityMode) bool {
+	enabled, forced := desc.RowLevelSecurityEnabled, desc.RowLevelSecurityForced
+	switch mode {
+	case tree.RowLevelSecurityEnable:
+		desc.RowLevelSecurityEnabled = true
+	case tree.RowLevelSecurityDisable:
+		desc.RowLevelSecurityEnabled = false
+	case tree.RowLevelSecurityForce:
+		desc.RowLevelSecurityForced = true
+	case tree.RowLevelSecurityNoForce:
+		desc.RowLevelSecurityForced = false
+	}
+	return enabled != desc.RowLevelSecurityEnabled || forced != desc.RowLevelSecurityForced
+}
+
+// dropPoliciesUsingColumn removes the policies of the table whose expressions
+// reference the column being dropped. Unless the drop cascades, such policies
+// prevent the column from being dropped.
+func dropPoliciesUsingColumn(
+	desc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	policies := desc.Policies[:0]
+	for i := range desc.Policies {
+		policy := &desc.Policies[i]
+		used, err := policyUsesColumn(desc, policy, col.GetID())
+		if err != nil {
+			return err
+		}
+		if !used {
+			policies = append(policies, *policy)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return errors.WithHint(sqlerrors.NewDependentObjectErrorf(
+				"cannot drop column %q because policy %q depends on it", col.GetName(), policy.Name),
+				"use CASCADE to drop the policy as well.")
+		}
+	}
+	desc.Policies = policies
+	return nil
+}
+
+func policyUsesColumn(
+	desc *tabledesc.Mutable, policy *descpb.PolicyDescriptor, colID descpb.ColumnID,
+) (bool, error) {
+	for _, exprStr := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+		if exprStr == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			return false, err
+		}
+		colIDs, err := schemaexpr.ExtractColumnIDs(desc, expr)
+		if err != nil {
+			return false, err
+		}
+		if colIDs.Contains(colID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -53,6 +53,17 @@ type PreparedStatement struct {
 	// if it is used by the optimizer as a starting point.
 	Memo *memo.Memo
 
+	// GenericMemo, if present, is a fully optimized memo that can be executed
+	// as-is, without replacing placeholders with their values and re-optimizing.
+	// It is built lazily, depending on the plan_cache_mode session setting, and
+	// is discarded whenever Memo becomes stale.
+	GenericMemo *memo.Memo
+
+	// Costs tracks the costs of the generic and custom plans built for this
+	// statement. It is used to decide whether to use the generic plan when
+	// plan_cache_mode is set to auto.
+	Costs planCosts
+
 	// refCount keeps track of the number of references to this PreparedStatement.
 	// New references are registered through incRef().
 	// Once refCount hits 0 (through calls to decRef()), the following memAcc is
@@ -80,9 +91,85 @@ func (p *PreparedStatement) MemoryEstimate() int64 {
 	if p.Memo != nil {
 		size += p.Memo.MemoryEstimate()
 	}
+	if p.GenericMemo != nil {
+		size += p.GenericMemo.MemoryEstimate()
+	}
 	return size
 }
 
+// setGenericMemo sets the generic memo of the prepared statement and accounts
+// for its memory usage. The generic memo must not already be set.
+func (p *PreparedStatement) setGenericMemo(ctx context.Context, genericMemo *memo.Memo) error {
+	if err := p.memAcc.Grow(ctx, genericMemo.MemoryEstimate()); err != nil {
+		return err
+	}
+	p.GenericMemo = genericMemo
+	return nil
+}
+
+// clearGenericMemo discards the generic memo of the prepared statement, if
+// any, and releases the memory accounted for it.
+func (p *PreparedStatement) clearGenericMemo(ctx context.Context) {
+	if p.GenericMemo == nil {
+		return
+	}
+	p.memAcc.Shrink(ctx, p.GenericMemo.MemoryEstimate())
+	p.GenericMemo = nil
+}
+
+// customPlanThreshold is the number of custom plans that must be built for a
+// prepared statement before a generic plan is considered when plan_cache_mode
+// is set to auto. This matches the behavior of Postgres.
+const customPlanThreshold = 5
+
+// planCosts tracks the cost of the generic plan of a prepared statement and
+// the average cost of its custom plans.
+type planCosts struct {
+	// generic is the cost of the generic plan. It is zero if no generic plan
+	// has been built.
+	generic memo.Cost
+	// customTotal is the sum of the costs of all custom plans built so far.
+	customTotal memo.Cost
+	// customCount is the number of custom plans built so far.
+	customCount int
+}
+
+// Generic returns the cost of the generic plan.
+func (c *planCosts) Generic() memo.Cost {
+	return c.generic
+}
+
+// SetGeneric records the cost of the generic plan.
+func (c *planCosts) SetGeneric(cost memo.Cost) {
+	c.generic = cost
+}
+
+// Reset removes the costs of the generic plan and of all custom plans, e.g.,
+// when the prepared memo is rebuilt and the plans built so far are no longer
+// representative.
+func (c *planCosts) Reset() {
+	*c = planCosts{}
+}
+
+// AddCustom records the cost of a custom plan.
+func (c *planCosts) AddCustom(cost memo.Cost) {
+	c.customTotal += cost
+	c.customCount++
+}
+
+// NumCustom returns the number of custom plans built so far.
+func (c *planCosts) NumCustom() int {
+	return c.customCount
+}
+
+// AvgCustom returns the average cost of the custom plans built so far.
+func (c *planCosts) AvgCustom() memo.Cost {
+	if c.customCount == 0 {
+		return 0
+	}
+	return c.customTotal / memo.Cost(c.customCount)
+}
+
 func (p *PreparedStatement) decRef(ctx context.Context) {
 	if p.refCount <= 0 {
 		log.Fatal(ctx, "corrupt PreparedStatement refcount")
@@ -284,6 +284,19 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.RangeFamily:
+		// NULL bounds are infinite.
+		lower := RandDatum(rng, typ.RangeContents(), true /* nullOk */)
+		upper := RandDatum(rng, typ.RangeContents(), true /* nullOk */)
+		lowerInc, upperInc := rng.Intn(2) == 1, rng.Intn(2) == 1
+		if r, err := tree.NewDRange(typ, lower, upper, lowerInc, upperInc); err == nil {
+			return r
+		}
+		// The bounds may be out of order, so try swapping them.
+		if r, err := tree.NewDRange(typ, upper, lower, lowerInc, upperInc); err == nil {
+			return r
+		}
+		return tree.NewDEmptyRange(typ)
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
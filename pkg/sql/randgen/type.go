@@ -70,6 +70,12 @@ func init() {
 
 // IsAllowedForArray returns true iff the passed in type can be a valid ArrayContents()
 func IsAllowedForArray(typ *types.T) bool {
+	// Don't include array types; random multi-dimensional arrays are not
+	// generated.
+	if typ.Family() == types.ArrayFamily {
+		return false
+	}
+
 	// Don't include un-encodable types.
 	encTyp, err := valueside.DatumTypeToArrayElementEncodingType(typ)
 	if err != nil || encTyp == 0 {
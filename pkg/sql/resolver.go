@@ -586,6 +586,12 @@ func (p *planner) getFullyQualifiedTableNamesFromIDs(
 				return nil, err
 			}
 			fullyQualifiedNames = append(fullyQualifiedNames, fName.FQString())
+		case catalog.TypeDescriptor:
+			typName, err := p.getQualifiedTypeName(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			fullyQualifiedNames = append(fullyQualifiedNames, typName.FQString())
 		}
 	}
 	return fullyQualifiedNames, nil
@@ -34,11 +34,13 @@ func _() {
 	_ = x[NOSQLLOGIN-24]
 	_ = x[VIEWCLUSTERSETTING-25]
 	_ = x[NOVIEWCLUSTERSETTING-26]
+	_ = x[BYPASSRLS-27]
+	_ = x[NOBYPASSRLS-28]
 }
 
-const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALID UNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTINGVIEWACTIVITYREDACTEDNOVIEWACTIVITYREDACTEDSQLLOGINNOSQLLOGINVIEWCLUSTERSETTINGNOVIEWCLUSTERSETTING"
+const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALID UNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTINGVIEWACTIVITYREDACTEDNOVIEWACTIVITYREDACTEDSQLLOGINNOSQLLOGINVIEWCLUSTERSETTINGNOVIEWCLUSTERSETTINGBYPASSRLSNOBYPASSRLS"
 
-var _Option_index = [...]uint16{0, 10, 22, 30, 35, 42, 53, 63, 75, 92, 111, 119, 129, 140, 153, 165, 179, 190, 203, 223, 245, 265, 287, 295, 305, 323, 343}
+var _Option_index = [...]uint16{0, 10, 22, 30, 35, 42, 53, 63, 75, 92, 111, 119, 129, 140, 153, 165, 179, 190, 203, 223, 245, 265, 287, 295, 305, 323, 343, 352, 363}
 
 func (i Option) String() string {
 	i -= 1
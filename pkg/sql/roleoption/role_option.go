@@ -66,6 +66,10 @@ const (
 	NOSQLLOGIN
 	VIEWCLUSTERSETTING
 	NOVIEWCLUSTERSETTING
+	// BYPASSRLS exempts the role from the row-level security policies of
+	// every table.
+	BYPASSRLS
+	NOBYPASSRLS
 )
 
 // toSQLStmts is a map of Kind -> SQL statement string for applying the
@@ -96,6 +100,8 @@ var toSQLStmts = map[Option]string{
 	NOVIEWACTIVITYREDACTED: `DELETE FROM system.role_options WHERE username = $1 AND option = 'VIEWACTIVITYREDACTED'`,
 	VIEWCLUSTERSETTING:     `INSERT INTO system.role_options (username, option) VALUES ($1, 'VIEWCLUSTERSETTING') ON CONFLICT DO NOTHING`,
 	NOVIEWCLUSTERSETTING:   `DELETE FROM system.role_options WHERE username = $1 AND option = 'VIEWCLUSTERSETTING'`,
+	BYPASSRLS:              `INSERT INTO system.role_options (username, option) VALUES ($1, 'BYPASSRLS') ON CONFLICT DO NOTHING`,
+	NOBYPASSRLS:            `DELETE FROM system.role_options WHERE username = $1 AND option = 'BYPASSRLS'`,
 }
 
 // toSQLStmtsWithID is a map of Kind -> SQL statement string for applying the
@@ -127,6 +133,8 @@ var toSQLStmtsWithID = map[Option]string{
 	NOVIEWACTIVITYREDACTED: `DELETE FROM system.role_options WHERE username = $1 AND user_id = $2 AND option = 'VIEWACTIVITYREDACTED'`,
 	VIEWCLUSTERSETTING:     `INSERT INTO system.role_options (username, option, user_id) VALUES ($1, 'VIEWCLUSTERSETTING', $2) ON CONFLICT DO NOTHING`,
 	NOVIEWCLUSTERSETTING:   `DELETE FROM system.role_options WHERE username = $1 AND user_id = $2 AND option = 'VIEWCLUSTERSETTING'`,
+	BYPASSRLS:              `INSERT INTO system.role_options (username, option, user_id) VALUES ($1, 'BYPASSRLS', $2) ON CONFLICT DO NOTHING`,
+	NOBYPASSRLS:            `DELETE FROM system.role_options WHERE username = $1 AND user_id = $2 AND option = 'BYPASSRLS'`,
 }
 
 // Mask returns the bitmask for a given role option.
@@ -162,6 +170,8 @@ var ByName = map[string]Option{
 	"NOSQLLOGIN":             NOSQLLOGIN,
 	"VIEWCLUSTERSETTING":     VIEWCLUSTERSETTING,
 	"NOVIEWCLUSTERSETTING":   NOVIEWCLUSTERSETTING,
+	"BYPASSRLS":              BYPASSRLS,
+	"NOBYPASSRLS":            NOBYPASSRLS,
 }
 
 // ToOption takes a string and returns the corresponding Option.
@@ -314,7 +324,9 @@ func (rol List) CheckRoleOptionConflicts() error {
 		(roleOptionBits&SQLLOGIN.Mask() != 0 &&
 			roleOptionBits&NOSQLLOGIN.Mask() != 0) ||
 		(roleOptionBits&VIEWCLUSTERSETTING.Mask() != 0 &&
-			roleOptionBits&NOVIEWCLUSTERSETTING.Mask() != 0) {
+			roleOptionBits&NOVIEWCLUSTERSETTING.Mask() != 0) ||
+		(roleOptionBits&BYPASSRLS.Mask() != 0 &&
+			roleOptionBits&NOBYPASSRLS.Mask() != 0) {
 		return pgerror.Newf(pgcode.Syntax, "conflicting role options")
 	}
 	return nil
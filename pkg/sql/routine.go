@@ -14,10 +14,13 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
 )
 
 // EvalRoutineExpr returns the result of evaluating the routine. It calls the
@@ -37,15 +40,19 @@ func (p *planner) EvalRoutineExpr(
 		}
 	}
 
-	retTypes := []*types.T{expr.ResolvedType()}
-
-	// The result of the routine is the result of the last statement. The result
-	// of any preceding statements is ignored. We set up a rowResultWriter that
-	// can store the results of the final statement here.
-	var rch rowContainerHelper
-	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
-	defer rch.Close(ctx)
-	rrw := NewRowResultWriter(&rch)
+	// Execute the routine as the function owner and with the session variable
+	// values of the function, if they differ from those of the caller. The
+	// changes are scoped to the routine invocation.
+	if expr.SessionOverrides != nil {
+		if err := p.pushRoutineSessionOverrides(ctx, expr.SessionOverrides); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if popErr := p.EvalContext().SessionDataStack.Pop(); err == nil {
+				err = popErr
+			}
+		}()
+	}
 
 	// Configure stepping for volatile routines so that mutations made by the
 	// invoking statement are visible to the routine.
@@ -64,6 +71,22 @@ func (p *planner) EvalRoutineExpr(
 		}()
 	}
 
+	// The statements of a PL/pgSQL routine are executed by an interpreter, as
+	// the control flow of the program requires.
+	if expr.Program != nil {
+		return p.evalPLpgSQLRoutine(ctx, expr, expr.Program.(*plpgsqltree.Program), input)
+	}
+
+	retTypes := []*types.T{expr.ResolvedType()}
+
+	// The result of the routine is the result of the last statement. The result
+	// of any preceding statements is ignored. We set up a rowResultWriter that
+	// can store the results of the final statement here.
+	var rch rowContainerHelper
+	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
+	defer rch.Close(ctx)
+	rrw := NewRowResultWriter(&rch)
+
 	// Execute each statement in the routine sequentially.
 	ef := newExecFactory(ctx, p)
 	for i := 0; i < expr.NumStmts; i++ {
@@ -90,13 +113,13 @@ func (p *planner) EvalRoutineExpr(
 			// Place a sequence point before each statement in the routine for
 			// volatile functions.
 			if expr.EnableStepping {
-				if err := txn.Step(ctx); err != nil {
+				if err := txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
 					return err
 				}
 			}
 
 			// Run the plan.
-			err = runPlanInsidePlan(ctx, p.RunParams(ctx), plan.(*planComponents), w)
+			err = runPlanInsidePlan(ctx, p.RunParams(ctx), plan.(*planComponents), w, tree.Rows)
 			if err != nil {
 				return err
 			}
@@ -122,6 +145,38 @@ func (p *planner) EvalRoutineExpr(
 	return res[0], nil
 }
 
+// pushRoutineSessionOverrides pushes a copy of the current session data with
+// the given overrides applied onto the session data stack. The caller must pop
+// it from the stack once the routine has been executed.
+func (p *planner) pushRoutineSessionOverrides(
+	ctx context.Context, so *tree.RoutineSessionOverrides,
+) error {
+	sd := p.SessionData().Clone()
+	if !so.User.Undefined() {
+		// As with SET ROLE, the session user remains the same while the current
+		// user is changed.
+		sd.SessionUserProto = sd.SessionUser().EncodeProto()
+		sd.UserProto = so.User.EncodeProto()
+	}
+	// Callbacks are not applied, since the changes are not visible outside of
+	// the routine.
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sd)
+	for _, setting := range so.Settings {
+		_, v, err := getSessionVar(setting.Name, false /* missingOk */)
+		if err != nil {
+			return err
+		}
+		if v.Set == nil {
+			return errors.AssertionFailedf("session variable %q cannot be set in a routine", setting.Name)
+		}
+		if err := v.Set(ctx, m, setting.Value); err != nil {
+			return err
+		}
+	}
+	p.EvalContext().SessionDataStack.Push(sd)
+	return nil
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
@@ -133,6 +188,11 @@ func (d *droppingResultWriter) AddRow(ctx context.Context, row tree.Datums) erro
 	return nil
 }
 
+// AddBatch is part of the batchResultWriter interface.
+func (d *droppingResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // IncrementRowsAffected is part of the rowResultWriter interface.
 func (d *droppingResultWriter) IncrementRowsAffected(ctx context.Context, n int) {}
 
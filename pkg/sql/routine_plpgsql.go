@@ -0,0 +1,785 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// plpgsqlControl describes how the execution of a PL/pgSQL statement affects
+// the control flow of the program.
+type plpgsqlControl int
+
+const (
+	// plpgsqlNext continues with the next statement.
+	plpgsqlNext plpgsqlControl = iota
+	// plpgsqlExit exits the innermost loop, or the enclosing block or loop
+	// with the label of the EXIT statement.
+	plpgsqlExit
+	// plpgsqlContinue continues with the next iteration of the innermost
+	// loop, or of the enclosing loop with the label of the CONTINUE statement.
+	plpgsqlContinue
+	// plpgsqlReturn returns from the routine.
+	plpgsqlReturn
+)
+
+// plpgsqlExecutor interprets the program of a PL/pgSQL routine. The embedded
+// SQL expressions and queries of the program are the statements of the
+// routine. They are planned with the current values of the variables of the
+// program as arguments, and executed as the control flow of the program
+// requires.
+type plpgsqlExecutor struct {
+	p    *planner
+	expr *tree.RoutineExpr
+	prog *plpgsqltree.Program
+	ef   *execFactory
+
+	// vars contains the current value of each variable of the program.
+	vars tree.Datums
+
+	// label is the label of the last EXIT or CONTINUE statement that was
+	// executed, if any.
+	label string
+
+	// result is the value of the last RETURN statement that was executed.
+	result tree.Datum
+
+	// caught contains the errors that are being handled by the enclosing
+	// exception handlers, innermost last. It is used by RAISE statements
+	// without parameters, which re-raise the innermost error.
+	caught []error
+}
+
+// evalPLpgSQLRoutine executes the program of a PL/pgSQL routine with the given
+// arguments, and returns its result.
+func (p *planner) evalPLpgSQLRoutine(
+	ctx context.Context, expr *tree.RoutineExpr, prog *plpgsqltree.Program, input tree.Datums,
+) (tree.Datum, error) {
+	e := plpgsqlExecutor{
+		p:    p,
+		expr: expr,
+		prog: prog,
+		ef:   newExecFactory(ctx, p),
+		vars: make(tree.Datums, len(prog.Vars)),
+	}
+	for i := range e.vars {
+		e.vars[i] = tree.DNull
+	}
+	copy(e.vars, input)
+	e.vars[prog.FoundIdx] = tree.DBoolFalse
+
+	c, err := e.execBlock(ctx, prog.Block)
+	if err != nil {
+		return nil, err
+	}
+	if c != plpgsqlReturn {
+		if expr.ResolvedType().Family() != types.VoidFamily {
+			return nil, pgerror.New(pgcode.RoutineExceptionFunctionExecutedNoReturnStatement,
+				"control reached end of function without RETURN")
+		}
+		return tree.DVoidDatum, nil
+	}
+	if e.result == nil {
+		return tree.DVoidDatum, nil
+	}
+	return eval.PerformAssignmentCast(ctx, p.EvalContext(), e.result, expr.ResolvedType())
+}
+
+// execBlock executes the declarations, statements and exception handlers of a
+// block.
+func (e *plpgsqlExecutor) execBlock(
+	ctx context.Context, block *plpgsqltree.Block,
+) (plpgsqlControl, error) {
+	// The variables of the block are initialized each time the block is
+	// entered.
+	for _, decl := range block.Decls {
+		val := tree.Datum(tree.DNull)
+		if decl.Default != nil {
+			var err error
+			if val, err = e.evalExpr(ctx, decl.DefaultIdx); err != nil {
+				return plpgsqlNext, err
+			}
+		}
+		if err := e.assign(ctx, decl.VarIdx, val); err != nil {
+			return plpgsqlNext, err
+		}
+	}
+
+	var c plpgsqlControl
+	var err error
+	if len(block.Exceptions) == 0 {
+		c, err = e.execStmts(ctx, block.Body)
+	} else {
+		c, err = e.execBlockWithExceptions(ctx, block)
+	}
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	if c == plpgsqlExit && block.Label != "" && e.label == block.Label {
+		e.label = ""
+		return plpgsqlNext, nil
+	}
+	return c, nil
+}
+
+// execBlockWithExceptions executes the statements of a block that has
+// exception handlers. The changes made by the statements are rolled back to a
+// savepoint if an error is handled.
+func (e *plpgsqlExecutor) execBlockWithExceptions(
+	ctx context.Context, block *plpgsqltree.Block,
+) (plpgsqlControl, error) {
+	txn := e.p.Txn()
+	sp, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	c, err := e.execStmts(ctx, block.Body)
+	if err == nil {
+		return c, txn.ReleaseSavepoint(ctx, sp)
+	}
+
+	// Errors that require the transaction to be retried cannot be handled.
+	if errIsRetriable(err) {
+		return plpgsqlNext, err
+	}
+	code := pgerror.GetPGCode(err)
+	var handler *plpgsqltree.Exception
+	for _, exc := range block.Exceptions {
+		if plpgsqlExceptionMatches(exc, code) {
+			handler = exc
+			break
+		}
+	}
+	if handler == nil {
+		return plpgsqlNext, err
+	}
+	if rbErr := txn.RollbackToSavepoint(ctx, sp); rbErr != nil {
+		return plpgsqlNext, errors.CombineErrors(err, rbErr)
+	}
+
+	e.vars[block.SQLStateIdx] = tree.NewDString(code.String())
+	e.vars[block.SQLErrMIdx] = tree.NewDString(err.Error())
+	e.caught = append(e.caught, err)
+	defer func() { e.caught = e.caught[:len(e.caught)-1] }()
+	return e.execStmts(ctx, handler.Body)
+}
+
+// plpgsqlExceptionMatches returns true if the given exception handler handles
+// errors with the given code.
+func plpgsqlExceptionMatches(exc *plpgsqltree.Exception, code pgcode.Code) bool {
+	for _, cond := range exc.Conditions {
+		if cond.SQLState != "" {
+			if plpgsqlCodeMatches(pgcode.MakeCode(cond.SQLState), code) {
+				return true
+			}
+			continue
+		}
+		if cond.Name == "others" {
+			// OTHERS does not match errors that indicate that the query was
+			// canceled or that an assertion failed.
+			if code != pgcode.QueryCanceled && code != pgcode.AssertFailure {
+				return true
+			}
+			continue
+		}
+		for _, condCode := range pgcode.PLpgSQLConditionNameToCode[cond.Name] {
+			if plpgsqlCodeMatches(condCode, code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// plpgsqlCodeMatches returns true if the given code matches the code of an
+// exception condition. A condition whose code ends in "000" matches all codes
+// in its class.
+func plpgsqlCodeMatches(condCode, code pgcode.Code) bool {
+	c := condCode.String()
+	if strings.HasSuffix(c, "000") {
+		return strings.HasPrefix(code.String(), c[:2])
+	}
+	return condCode == code
+}
+
+// execStmts executes the given statements sequentially.
+func (e *plpgsqlExecutor) execStmts(
+	ctx context.Context, stmts []plpgsqltree.Statement,
+) (plpgsqlControl, error) {
+	for _, stmt := range stmts {
+		c, err := e.execStmt(ctx, stmt)
+		if err != nil || c != plpgsqlNext {
+			return c, err
+		}
+	}
+	return plpgsqlNext, nil
+}
+
+// execStmt executes a single statement.
+func (e *plpgsqlExecutor) execStmt(
+	ctx context.Context, stmt plpgsqltree.Statement,
+) (plpgsqlControl, error) {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		return e.execBlock(ctx, t)
+
+	case *plpgsqltree.Assignment:
+		val, err := e.evalExpr(ctx, t.ValueIdx)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		return plpgsqlNext, e.assign(ctx, t.VarIdx, val)
+
+	case *plpgsqltree.If:
+		ok, err := e.evalCondition(ctx, t.CondIdx)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if ok {
+			return e.execStmts(ctx, t.ThenBody)
+		}
+		for i := range t.ElseIfs {
+			ok, err := e.evalCondition(ctx, t.ElseIfs[i].CondIdx)
+			if err != nil {
+				return plpgsqlNext, err
+			}
+			if ok {
+				return e.execStmts(ctx, t.ElseIfs[i].Body)
+			}
+		}
+		return e.execStmts(ctx, t.ElseBody)
+
+	case *plpgsqltree.Loop:
+		for {
+			if ctx.Err() != nil {
+				return plpgsqlNext, cancelchecker.QueryCanceledError
+			}
+			c, err := e.execStmts(ctx, t.Body)
+			if err != nil {
+				return plpgsqlNext, err
+			}
+			if done, c := e.loopControl(t.Label, c); done {
+				return c, nil
+			}
+		}
+
+	case *plpgsqltree.While:
+		for {
+			if ctx.Err() != nil {
+				return plpgsqlNext, cancelchecker.QueryCanceledError
+			}
+			ok, err := e.evalCondition(ctx, t.CondIdx)
+			if err != nil || !ok {
+				return plpgsqlNext, err
+			}
+			c, err := e.execStmts(ctx, t.Body)
+			if err != nil {
+				return plpgsqlNext, err
+			}
+			if done, c := e.loopControl(t.Label, c); done {
+				return c, nil
+			}
+		}
+
+	case *plpgsqltree.ForInt:
+		return e.execForInt(ctx, t)
+
+	case *plpgsqltree.ForQuery:
+		return e.execForQuery(ctx, t)
+
+	case *plpgsqltree.Exit:
+		if t.Condition != nil {
+			if ok, err := e.evalCondition(ctx, t.CondIdx); err != nil || !ok {
+				return plpgsqlNext, err
+			}
+		}
+		e.label = t.Label
+		return plpgsqlExit, nil
+
+	case *plpgsqltree.Continue:
+		if t.Condition != nil {
+			if ok, err := e.evalCondition(ctx, t.CondIdx); err != nil || !ok {
+				return plpgsqlNext, err
+			}
+		}
+		e.label = t.Label
+		return plpgsqlContinue, nil
+
+	case *plpgsqltree.Return:
+		if t.Expr != nil {
+			val, err := e.evalExpr(ctx, t.ExprIdx)
+			if err != nil {
+				return plpgsqlNext, err
+			}
+			e.result = val
+		}
+		return plpgsqlReturn, nil
+
+	case *plpgsqltree.Raise:
+		return plpgsqlNext, e.execRaise(ctx, t)
+
+	case *plpgsqltree.ExecSQL:
+		return plpgsqlNext, e.execSQL(ctx, t)
+
+	case *plpgsqltree.Perform:
+		res, err := e.runQuery(ctx, t.QueryIdx, tree.Rows)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		defer res.close(ctx)
+		e.setFound(res.rch.Len() > 0)
+		return plpgsqlNext, nil
+
+	case *plpgsqltree.Null:
+		return plpgsqlNext, nil
+
+	default:
+		return plpgsqlNext, errors.AssertionFailedf("unexpected PL/pgSQL statement %T", t)
+	}
+}
+
+// loopControl determines whether a loop with the given label stops after an
+// iteration whose body resulted in the given control flow. If the loop stops,
+// it also returns the control flow that is propagated to the enclosing
+// statements.
+func (e *plpgsqlExecutor) loopControl(
+	label string, c plpgsqlControl,
+) (done bool, _ plpgsqlControl) {
+	switch c {
+	case plpgsqlExit:
+		if e.label == "" || e.label == label {
+			e.label = ""
+			return true, plpgsqlNext
+		}
+		return true, c
+	case plpgsqlContinue:
+		if e.label == "" || e.label == label {
+			e.label = ""
+			return false, plpgsqlNext
+		}
+		return true, c
+	case plpgsqlReturn:
+		return true, c
+	}
+	return false, plpgsqlNext
+}
+
+// execForInt executes a FOR loop over a range of integers.
+func (e *plpgsqlExecutor) execForInt(
+	ctx context.Context, t *plpgsqltree.ForInt,
+) (plpgsqlControl, error) {
+	evalBound := func(idx int, desc string) (int64, error) {
+		d, err := e.evalExpr(ctx, idx)
+		if err != nil {
+			return 0, err
+		}
+		if d == tree.DNull {
+			return 0, pgerror.Newf(pgcode.NullValueNotAllowed, "%s cannot be null", desc)
+		}
+		return int64(tree.MustBeDInt(d)), nil
+	}
+	lower, err := evalBound(t.LowerIdx, "lower bound of FOR loop")
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	upper, err := evalBound(t.UpperIdx, "upper bound of FOR loop")
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	step := int64(1)
+	if t.Step != nil {
+		if step, err = evalBound(t.StepIdx, "BY value of FOR loop"); err != nil {
+			return plpgsqlNext, err
+		}
+		if step <= 0 {
+			return plpgsqlNext, pgerror.New(pgcode.InvalidParameterValue,
+				"BY value of FOR loop must be greater than zero")
+		}
+	}
+
+	found := false
+	for i := lower; (!t.Reverse && i <= upper) || (t.Reverse && i >= upper); {
+		if ctx.Err() != nil {
+			return plpgsqlNext, cancelchecker.QueryCanceledError
+		}
+		found = true
+		e.vars[t.VarIdx] = tree.NewDInt(tree.DInt(i))
+		c, err := e.execStmts(ctx, t.Body)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if done, c := e.loopControl(t.Label, c); done {
+			e.setFound(found)
+			return c, nil
+		}
+		// Stop before the loop variable overflows.
+		if t.Reverse {
+			if i < upper+step {
+				break
+			}
+			i -= step
+		} else {
+			if i > upper-step {
+				break
+			}
+			i += step
+		}
+	}
+	e.setFound(found)
+	return plpgsqlNext, nil
+}
+
+// execForQuery executes a FOR loop over the rows returned by a query.
+func (e *plpgsqlExecutor) execForQuery(
+	ctx context.Context, t *plpgsqltree.ForQuery,
+) (plpgsqlControl, error) {
+	res, err := e.runQuery(ctx, t.QueryIdx, tree.Rows)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	defer res.close(ctx)
+	it := newRowContainerIterator(ctx, res.rch, res.typs)
+	defer it.Close()
+	found := false
+	for {
+		row, err := it.Next()
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if row == nil {
+			break
+		}
+		found = true
+		if err := e.assignRow(ctx, t.TargetIdxs, row); err != nil {
+			return plpgsqlNext, err
+		}
+		c, err := e.execStmts(ctx, t.Body)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if done, c := e.loopControl(t.Label, c); done {
+			e.setFound(found)
+			return c, nil
+		}
+	}
+	e.setFound(found)
+	return plpgsqlNext, nil
+}
+
+// execSQL executes an embedded SQL statement, and assigns the first row that
+// it returns to the INTO targets, if any.
+func (e *plpgsqlExecutor) execSQL(ctx context.Context, t *plpgsqltree.ExecSQL) error {
+	stmtType := t.SQL.StatementReturnType()
+	if stmtType != tree.Rows {
+		stmtType = tree.RowsAffected
+	}
+	res, err := e.runQuery(ctx, t.SQLIdx, stmtType)
+	if err != nil {
+		return err
+	}
+	defer res.close(ctx)
+	if len(t.Into) == 0 {
+		e.setFound(res.rch.Len() > 0 || res.rowsAffected > 0)
+		return nil
+	}
+
+	n := res.rch.Len()
+	if t.Strict {
+		if n == 0 {
+			return pgerror.New(pgcode.NoDataFound, "query returned no rows")
+		}
+		if n > 1 {
+			return pgerror.New(pgcode.TooManyRows, "query returned more than one row")
+		}
+	}
+	e.setFound(n > 0)
+	if n == 0 {
+		// The targets are set to NULL if there are no rows.
+		for _, idx := range t.IntoIdxs {
+			if err := e.assign(ctx, idx, tree.DNull); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	it := newRowContainerIterator(ctx, res.rch, res.typs)
+	defer it.Close()
+	row, err := it.Next()
+	if err != nil {
+		return err
+	}
+	return e.assignRow(ctx, t.IntoIdxs, row)
+}
+
+// execRaise executes a RAISE statement. Statements with the EXCEPTION level
+// return an error, and other statements send a notice to the client.
+func (e *plpgsqlExecutor) execRaise(ctx context.Context, t *plpgsqltree.Raise) error {
+	if t.Level == "" && t.Message == "" && t.CondName == "" && t.SQLState == "" && len(t.Options) == 0 {
+		// RAISE without parameters re-raises the error that is being handled.
+		if len(e.caught) == 0 {
+			return pgerror.New(pgcode.StackedDiagnosticsAccessedWithoutActiveHandler,
+				"RAISE without parameters cannot be used outside an exception handler")
+		}
+		return e.caught[len(e.caught)-1]
+	}
+
+	code := pgcode.RaiseException
+	msg := t.Message
+	switch {
+	case t.CondName != "":
+		code = pgcode.PLpgSQLConditionNameToCode[t.CondName][0]
+		msg = t.CondName
+	case t.SQLState != "":
+		code = pgcode.MakeCode(t.SQLState)
+		msg = t.SQLState
+	default:
+		var err error
+		if msg, err = e.formatRaiseMessage(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	var detail, hint string
+	for i := range t.Options {
+		d, err := e.evalExpr(ctx, t.Options[i].ValueIdx)
+		if err != nil {
+			return err
+		}
+		if d == tree.DNull {
+			return pgerror.Newf(pgcode.NullValueNotAllowed,
+				"RAISE statement option cannot be null")
+		}
+		val := string(tree.MustBeDString(d))
+		switch t.Options[i].Name {
+		case "MESSAGE":
+			msg = val
+		case "DETAIL":
+			detail = val
+		case "HINT":
+			hint = val
+		case "ERRCODE":
+			if code, err = plpgsqlErrCode(val); err != nil {
+				return err
+			}
+		}
+	}
+
+	level := t.Level
+	if level == "" || level == "EXCEPTION" {
+		err := pgerror.New(code, msg)
+		if detail != "" {
+			err = errors.WithDetail(err, detail)
+		}
+		if hint != "" {
+			err = errors.WithHint(err, hint)
+		}
+		return err
+	}
+	if level == "DEBUG" {
+		// DEBUG messages are displayed with the DEBUG1 severity.
+		level = "DEBUG1"
+	}
+	notice := pgnotice.NewWithSeverityf(level, "%s", msg)
+	if detail != "" {
+		notice = pgnotice.Notice(errors.WithDetail(notice, detail))
+	}
+	if hint != "" {
+		notice = pgnotice.Notice(errors.WithHint(notice, hint))
+	}
+	e.p.BufferClientNotice(ctx, notice)
+	return nil
+}
+
+// formatRaiseMessage substitutes the parameters of a RAISE statement for the
+// placeholders in its message. Each "%" is replaced by the next parameter, and
+// "%%" is replaced by "%".
+func (e *plpgsqlExecutor) formatRaiseMessage(
+	ctx context.Context, t *plpgsqltree.Raise,
+) (string, error) {
+	var sb strings.Builder
+	param := 0
+	for i := 0; i < len(t.Message); i++ {
+		if t.Message[i] != '%' {
+			sb.WriteByte(t.Message[i])
+			continue
+		}
+		if i+1 < len(t.Message) && t.Message[i+1] == '%' {
+			sb.WriteByte('%')
+			i++
+			continue
+		}
+		d, err := e.evalExpr(ctx, t.ParamIdxs[param])
+		if err != nil {
+			return "", err
+		}
+		param++
+		if d == tree.DNull {
+			sb.WriteString("<NULL>")
+		} else {
+			sb.WriteString(string(tree.MustBeDString(d)))
+		}
+	}
+	return sb.String(), nil
+}
+
+// plpgsqlErrCode returns the error code for the value of an ERRCODE option of
+// a RAISE statement, which is either a condition name or a SQLSTATE code.
+func plpgsqlErrCode(val string) (pgcode.Code, error) {
+	if codes, ok := pgcode.PLpgSQLConditionNameToCode[strings.ToLower(val)]; ok {
+		return codes[0], nil
+	}
+	if len(val) == 5 {
+		valid := true
+		for i := 0; i < len(val); i++ {
+			if (val[i] < '0' || val[i] > '9') && (val[i] < 'A' || val[i] > 'Z') {
+				valid = false
+			}
+		}
+		if valid {
+			return pgcode.MakeCode(val), nil
+		}
+	}
+	return pgcode.Code{}, pgerror.Newf(pgcode.UndefinedObject,
+		"unrecognized exception condition %q", val)
+}
+
+// setFound sets the value of the FOUND variable.
+func (e *plpgsqlExecutor) setFound(found bool) {
+	e.vars[e.prog.FoundIdx] = tree.MakeDBool(tree.DBool(found))
+}
+
+// assign assigns the given value to a variable. The value is cast to the type
+// of the variable.
+func (e *plpgsqlExecutor) assign(ctx context.Context, idx int, val tree.Datum) error {
+	v := &e.prog.Vars[idx]
+	if val == tree.DNull && v.NotNull {
+		return pgerror.Newf(pgcode.NullValueNotAllowed,
+			"null value cannot be assigned to variable %q declared NOT NULL", v.Name)
+	}
+	val, err := eval.PerformAssignmentCast(ctx, e.p.EvalContext(), val, v.Typ)
+	if err != nil {
+		return err
+	}
+	e.vars[idx] = val
+	return nil
+}
+
+// assignRow assigns the columns of the given row to the variables with the
+// given indexes.
+func (e *plpgsqlExecutor) assignRow(ctx context.Context, idxs []int, row tree.Datums) error {
+	for i, idx := range idxs {
+		if err := e.assign(ctx, idx, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evalCondition evaluates a boolean condition. A NULL condition is false.
+func (e *plpgsqlExecutor) evalCondition(ctx context.Context, idx int) (bool, error) {
+	d, err := e.evalExpr(ctx, idx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := tree.AsDBool(d)
+	return ok && bool(b), nil
+}
+
+// evalExpr evaluates an expression, which is a statement of the routine that
+// returns a single column. The expression must return at most one row.
+func (e *plpgsqlExecutor) evalExpr(ctx context.Context, idx int) (tree.Datum, error) {
+	res, err := e.runQuery(ctx, idx, tree.Rows)
+	if err != nil {
+		return nil, err
+	}
+	defer res.close(ctx)
+	switch res.rch.Len() {
+	case 0:
+		return tree.DNull, nil
+	case 1:
+	default:
+		return nil, pgerror.New(pgcode.CardinalityViolation, "query returned more than one row")
+	}
+	it := newRowContainerIterator(ctx, res.rch, res.typs)
+	defer it.Close()
+	row, err := it.Next()
+	if err != nil {
+		return nil, err
+	}
+	return row[0], nil
+}
+
+// plpgsqlQueryResult contains the result of a statement of the routine.
+type plpgsqlQueryResult struct {
+	rch          rowContainerHelper
+	typs         []*types.T
+	rowsAffected int
+}
+
+func (r *plpgsqlQueryResult) close(ctx context.Context) {
+	r.rch.Close(ctx)
+}
+
+// runQuery plans and runs the statement of the routine with the given index,
+// with the current values of the variables as arguments. The caller must close
+// the result.
+func (e *plpgsqlExecutor) runQuery(
+	ctx context.Context, idx int, stmtType tree.StatementReturnType,
+) (_ *plpgsqlQueryResult, err error) {
+	opName := "udf-stmt-" + e.expr.Name + "-" + strconv.Itoa(idx)
+	ctx, sp := tracing.ChildSpan(ctx, opName)
+	defer sp.Finish()
+
+	plan, err := e.expr.PlanFn(ctx, e.ef, idx, e.vars)
+	if err != nil {
+		return nil, err
+	}
+	pc := plan.(*planComponents)
+	cols := pc.main.planColumns()
+	res := &plpgsqlQueryResult{typs: make([]*types.T, len(cols))}
+	for i := range cols {
+		res.typs[i] = cols[i].Typ
+	}
+	res.rch.Init(ctx, res.typs, e.p.ExtendedEvalContext(), "plpgsql" /* opName */)
+	defer func() {
+		if err != nil {
+			res.close(ctx)
+		}
+	}()
+
+	// Place a sequence point before each statement in the routine for
+	// volatile functions.
+	if e.expr.EnableStepping {
+		if err := e.p.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
+			pc.close(ctx)
+			return nil, err
+		}
+	}
+	w := NewRowResultWriter(&res.rch)
+	if err := runPlanInsidePlan(ctx, e.p.RunParams(ctx), pc, w, stmtType); err != nil {
+		return nil, err
+	}
+	res.rowsAffected = w.rowsAffected
+	return res, nil
+}
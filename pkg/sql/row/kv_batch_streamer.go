@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvstreamer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -52,12 +53,13 @@ var _ KVBatchFetcher = &txnKVStreamer{}
 func newTxnKVStreamer(
 	streamer *kvstreamer.Streamer,
 	lockStrength descpb.ScanLockingStrength,
+	isoLevel isolation.Level,
 	acc *mon.BoundAccount,
 	batchRequestsIssued *int64,
 ) KVBatchFetcher {
 	f := &txnKVStreamer{
 		streamer:   streamer,
-		keyLocking: getKeyLockingStrength(lockStrength),
+		keyLocking: getKeyLockingStrength(lockStrength, isoLevel),
 		acc:        acc,
 	}
 	f.kvBatchFetcherHelper.init(f.nextBatch, batchRequestsIssued)
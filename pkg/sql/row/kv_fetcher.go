@@ -99,6 +99,7 @@ func newTxnKVFetcher(
 		// In most cases, the txn is non-nil; however, in some code paths (e.g.
 		// when executing EXPLAIN (VEC)) it might be nil, so we need to have
 		// this check.
+		fetcherArgs.isoLevel = txn.IsoLevel()
 		fetcherArgs.requestAdmissionHeader = txn.AdmissionHeader()
 		fetcherArgs.responseAdmissionQ = txn.DB().SQLKVResponseAdmissionQ
 	}
@@ -156,7 +157,7 @@ func NewStreamingKVFetcher(
 		streamerBudgetLimit,
 		streamerBudgetAcc,
 		&batchRequestsIssued,
-		getKeyLockingStrength(lockStrength),
+		getKeyLockingStrength(lockStrength, txn.IsoLevel()),
 	)
 	mode := kvstreamer.OutOfOrder
 	if maintainOrdering {
@@ -171,7 +172,9 @@ func NewStreamingKVFetcher(
 		maxKeysPerRow,
 		diskBuffer,
 	)
-	return newKVFetcher(newTxnKVStreamer(streamer, lockStrength, kvFetcherMemAcc, &batchRequestsIssued))
+	return newKVFetcher(newTxnKVStreamer(
+		streamer, lockStrength, txn.IsoLevel(), kvFetcherMemAcc, &batchRequestsIssued,
+	))
 }
 
 func newKVFetcher(batchFetcher KVBatchFetcher) *KVFetcher {
@@ -11,14 +11,18 @@
 package row
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
 )
 
 // getKeyLockingStrength returns the configured per-key locking strength to use
-// for key-value scans.
-func getKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strength {
+// for key-value scans performed by a transaction with the given isolation
+// level.
+func getKeyLockingStrength(
+	lockStrength descpb.ScanLockingStrength, isoLevel isolation.Level,
+) lock.Strength {
 	switch lockStrength {
 	case descpb.ScanLockingStrength_FOR_NONE:
 		return lock.None
@@ -28,7 +32,13 @@ func getKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_SHARE:
 		// We currently perform no per-key locking when FOR_SHARE is used
-		// because Shared locks have not yet been implemented.
+		// because Shared locks have not yet been implemented. Transactions
+		// running at isolation levels that tolerate write skew rely on these
+		// locks for correctness (e.g. for foreign key checks), so they acquire
+		// Exclusive locks instead.
+		if isoLevel.ToleratesWriteSkew() {
+			return lock.Exclusive
+		}
 		return lock.None
 
 	case descpb.ScanLockingStrength_FOR_NO_KEY_UPDATE:
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
 	"github.com/cockroachdb/errors"
 )
@@ -568,8 +569,9 @@ func EncodeInvertedIndexPrefixKeys(
 }
 
 // EncodeInvertedIndexTableKeys produces one inverted index key per element in
-// the input datum, which should be a container (either JSON or Array). For
-// JSON, "element" means unique path through the document. Each output key is
+// the input datum, which should be a container (either JSON or Array), a
+// string or a tsvector. For JSON, "element" means unique path through the
+// document, and for tsvectors it means unique lexeme. Each output key is
 // prefixed by inKey, and is guaranteed to be lexicographically sortable, but
 // not guaranteed to be round-trippable during decoding. If the input Datum
 // is (SQL) NULL, no inverted index keys will be produced, because inverted
@@ -605,6 +607,8 @@ func EncodeInvertedIndexTableKeys(
 		// val could be a DOidWrapper, so we need to use the unwrapped datum
 		// here.
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
+	case types.TSVectorFamily:
+		return tsearch.EncodeInvertedIndexKeys(inKey, datum.(*tree.DTSVector).TSVector), nil
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType())
 }
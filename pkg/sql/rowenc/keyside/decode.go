@@ -46,6 +46,8 @@ func Decode(
 	switch valType.Family() {
 	case types.ArrayFamily:
 		return decodeArrayKey(a, valType, key, dir)
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
 	case types.BitFamily:
 		var r bitarray.BitArray
 		if dir == encoding.Ascending {
@@ -0,0 +1,141 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Markers used in the ordered key encoding of a range. See encodeRangeKey.
+const (
+	rangeEmptyMarker    byte = 0x00
+	rangeNonEmptyMarker byte = 0x01
+
+	rangeLowerInfMarker  byte = 0x00
+	rangeFiniteMarker    byte = 0x01
+	rangeUpperInfMarker  byte = 0x02
+	rangeBoundLowMarker  byte = 0x00
+	rangeBoundHighMarker byte = 0x01
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The encoding
+// sorts ranges in the same order as tree.DRange.Compare: the empty range
+// sorts first, and the other ranges are ordered by their lower and then upper
+// bounds. The encoding of a non-empty range [a, b) is as follows:
+// [rangeNonEmptyMarker, lower(a), upper(b)], where a finite bound is encoded
+// as [rangeFiniteMarker, enc(val), marker], and marker orders an inclusive
+// lower bound before an exclusive one, and an exclusive upper bound before an
+// inclusive one. Infinite bounds are encoded as a single marker that sorts
+// before (for lower bounds) or after (for upper bounds) all finite bounds.
+//
+// The whole encoding is wrapped as a byte string, so that the length of an
+// encoded range key can be determined without decoding it.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var buf []byte
+	if r.Empty {
+		buf = append(buf, rangeEmptyMarker)
+	} else {
+		buf = append(buf, rangeNonEmptyMarker)
+		var err error
+		if r.Lower == nil {
+			buf = append(buf, rangeLowerInfMarker)
+		} else {
+			buf = append(buf, rangeFiniteMarker)
+			if buf, err = Encode(buf, r.Lower, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			if r.LowerInc {
+				buf = append(buf, rangeBoundLowMarker)
+			} else {
+				buf = append(buf, rangeBoundHighMarker)
+			}
+		}
+		if r.Upper == nil {
+			buf = append(buf, rangeUpperInfMarker)
+		} else {
+			buf = append(buf, rangeFiniteMarker)
+			if buf, err = Encode(buf, r.Upper, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			if r.UpperInc {
+				buf = append(buf, rangeBoundHighMarker)
+			} else {
+				buf = append(buf, rangeBoundLowMarker)
+			}
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, buf), nil
+	}
+	return encoding.EncodeBytesDescending(b, buf), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var buf []byte
+	var err error
+	if dir == encoding.Ascending {
+		key, buf, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		key, buf, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(buf) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	if buf[0] == rangeEmptyMarker {
+		return tree.NewDEmptyRange(t), key, nil
+	}
+	buf = buf[1:]
+	// decodeBound returns the bound value, which is nil for an infinite bound,
+	// and whether the bound was followed by rangeBoundHighMarker.
+	decodeBound := func(infMarker byte) (_ tree.Datum, high bool, _ error) {
+		if len(buf) == 0 {
+			return nil, false, errors.AssertionFailedf("invalid range encoding (missing bound)")
+		}
+		marker := buf[0]
+		buf = buf[1:]
+		if marker == infMarker {
+			return nil, false, nil
+		}
+		var d tree.Datum
+		d, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(buf) == 0 {
+			return nil, false, errors.AssertionFailedf("invalid range encoding (missing bound marker)")
+		}
+		marker = buf[0]
+		buf = buf[1:]
+		return d, marker == rangeBoundHighMarker, nil
+	}
+	lower, lowerHigh, err := decodeBound(rangeLowerInfMarker)
+	if err != nil {
+		return nil, nil, err
+	}
+	upper, upperHigh, err := decodeBound(rangeUpperInfMarker)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := tree.NewDRange(t, lower, upper, lower != nil && !lowerHigh, upperHigh)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, key, nil
+}
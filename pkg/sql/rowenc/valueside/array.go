@@ -20,7 +20,9 @@ import (
 	"github.com/cockroachdb/redact"
 )
 
-// encodeArray produces the value encoding for an array.
+// encodeArray produces the value encoding for an array. A multi-dimensional
+// array is encoded as the length of each of its dimensions, followed by its
+// scalar elements in row-major order.
 func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 	if err := d.Validate(); err != nil {
 		return scratch, err
@@ -32,27 +34,50 @@ func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 		return nil, err
 	}
 	header := arrayHeader{
-		hasNulls: d.HasNulls,
-		// TODO(justin): support multiple dimensions.
+		hasNulls:      d.HasNulls,
 		numDimensions: 1,
 		elementType:   elementType,
 		length:        uint64(d.Len()),
 		// We don't encode the NULL bitmap in this function because we do it in lockstep with the
 		// main data.
 	}
+	elems := d.Array
+	if d.ParamTyp.Family() == types.ArrayFamily {
+		header.numDimensions = d.ResolvedType().NumArrayDimensions()
+		if dims := d.Dimensions(); dims != nil {
+			header.dimensions = make([]uint64, len(dims))
+			for i, dim := range dims {
+				header.dimensions[i] = uint64(dim)
+			}
+			elems = d.FlatElements()
+		} else {
+			// An array with no elements is encoded with a zero-length outer
+			// dimension.
+			header.dimensions = make([]uint64, header.numDimensions)
+			elems = nil
+		}
+		header.length = uint64(len(elems))
+		header.hasNulls = false
+		for _, e := range elems {
+			if e == tree.DNull {
+				header.hasNulls = true
+				break
+			}
+		}
+	}
 	scratch, err = encodeArrayHeader(header, scratch)
 	if err != nil {
 		return nil, err
 	}
 	nullBitmapStart := len(scratch)
-	if d.HasNulls {
-		for i := 0; i < numBytesInBitArray(d.Len()); i++ {
+	if header.hasNulls {
+		for i := 0; i < numBytesInBitArray(len(elems)); i++ {
 			scratch = append(scratch, 0)
 		}
 	}
-	for i, e := range d.Array {
+	for i, e := range elems {
 		var err error
-		if d.HasNulls && e == tree.DNull {
+		if header.hasNulls && e == tree.DNull {
 			setBit(scratch[nullBitmapStart:], i)
 		} else {
 			scratch, err = encodeArrayElement(scratch, e)
@@ -70,26 +95,47 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 	if err != nil {
 		return nil, b, err
 	}
-	elementType := arrayType.ArrayContents()
-	result := tree.DArray{
-		Array:    make(tree.Datums, header.length),
-		ParamTyp: elementType,
-	}
-	if err = result.MaybeSetCustomOid(arrayType); err != nil {
-		return nil, b, err
+	if header.numDimensions != arrayType.NumArrayDimensions() && header.length != 0 {
+		return nil, b, errors.AssertionFailedf(
+			"array with %d dimensions cannot be decoded as %s", header.numDimensions, arrayType)
 	}
+	elementType := arrayType.ArrayInnermostContents()
+	elems := make(tree.Datums, header.length)
 	var val tree.Datum
 	for i := uint64(0); i < header.length; i++ {
 		if header.isNull(i) {
-			result.Array[i] = tree.DNull
-			result.HasNulls = true
+			elems[i] = tree.DNull
 		} else {
-			result.HasNonNulls = true
 			val, b, err = DecodeUntaggedDatum(a, elementType, b)
 			if err != nil {
 				return nil, b, err
 			}
-			result.Array[i] = val
+			elems[i] = val
+		}
+	}
+	if arrayType.ArrayContents().Family() == types.ArrayFamily {
+		var dims []int
+		if header.length > 0 {
+			dims = make([]int, len(header.dimensions))
+			for i, dim := range header.dimensions {
+				dims[i] = int(dim)
+			}
+		}
+		result, err := tree.NewDArrayFromDimensions(arrayType, dims, elems)
+		return result, b, err
+	}
+	result := tree.DArray{
+		Array:    elems,
+		ParamTyp: arrayType.ArrayContents(),
+	}
+	if err = result.MaybeSetCustomOid(arrayType); err != nil {
+		return nil, b, err
+	}
+	for _, e := range elems {
+		if e == tree.DNull {
+			result.HasNulls = true
+		} else {
+			result.HasNonNulls = true
 		}
 	}
 	return &result, b, nil
@@ -105,6 +151,9 @@ type arrayHeader struct {
 	hasNulls bool
 	// numDimensions is the number of dimensions in the array.
 	numDimensions int
+	// dimensions is the length of each dimension of a multi-dimensional array.
+	// It is nil for one-dimensional arrays, whose length is given by length.
+	dimensions []uint64
 	// elementType is the encoding type of the array elements.
 	elementType encoding.Type
 	// length is the total number of elements encoded.
@@ -156,6 +205,13 @@ func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
 	}
 	buf = append(buf, byte(headerByte))
 	buf = encoding.EncodeValueTag(buf, encoding.NoColumnID, h.elementType)
+	// A multi-dimensional array encodes the length of each dimension before
+	// the total number of elements.
+	if h.numDimensions > 1 {
+		for _, dim := range h.dimensions {
+			buf = encoding.EncodeNonsortingUvarint(buf, dim)
+		}
+	}
 	buf = encoding.EncodeNonsortingUvarint(buf, h.length)
 	return buf, nil
 }
@@ -167,12 +223,22 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		return arrayHeader{}, b, errors.Errorf("buffer too small")
 	}
 	hasNulls := b[0]&hasNullFlag != 0
+	numDimensions := int(b[0] & (hasNullFlag - 1))
 	b = b[1:]
 	_, dataOffset, _, encType, err := encoding.DecodeValueTag(b)
 	if err != nil {
 		return arrayHeader{}, b, err
 	}
 	b = b[dataOffset:]
+	var dimensions []uint64
+	if numDimensions > 1 {
+		dimensions = make([]uint64, numDimensions)
+		for i := range dimensions {
+			if b, _, dimensions[i], err = encoding.DecodeNonsortingUvarint(b); err != nil {
+				return arrayHeader{}, b, err
+			}
+		}
+	}
 	b, _, length, err := encoding.DecodeNonsortingUvarint(b)
 	if err != nil {
 		return arrayHeader{}, b, err
@@ -182,9 +248,9 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		b, nullBitmap = makeBitVec(b, int(length))
 	}
 	return arrayHeader{
-		hasNulls: hasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: numDimensions,
+		dimensions:    dimensions,
 		elementType:   encType,
 		length:        length,
 		nullBitmap:    nullBitmap,
@@ -196,6 +262,10 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 // type is then used to encode/decode array elements.
 func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 	switch t.Family() {
+	case types.ArrayFamily:
+		// The elements of a multi-dimensional array are encoded using the
+		// encoding of its scalar elements.
+		return DatumTypeToArrayElementEncodingType(t.ArrayInnermostContents())
 	case types.IntFamily:
 		return encoding.Int, nil
 	case types.OidFamily:
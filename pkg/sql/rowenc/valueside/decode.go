@@ -226,6 +226,16 @@ func DecodeUntaggedDatum(
 		return decodeArray(a, t, b)
 	case types.TupleFamily:
 		return decodeTuple(a, t, buf)
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, err := decodeRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return r, b, nil
 	case types.EnumFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
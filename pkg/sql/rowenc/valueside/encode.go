@@ -101,6 +101,12 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 		return encoding.EncodeArrayValue(appendTo, uint32(colID), a), nil
 	case *tree.DTuple:
 		return encodeTuple(t, appendTo, uint32(colID), scratch)
+	case *tree.DRange:
+		encoded, err := encodeRange(t, scratch)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeRangeValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DCollatedString:
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Contents)), nil
 	case *tree.DOid:
@@ -0,0 +1,60 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRange produces the value encoding of a range, without a value tag.
+// The encoding consists of the range flags, followed by the value encoding of
+// each finite bound.
+func encodeRange(r *tree.DRange, scratch []byte) ([]byte, error) {
+	b := append(scratch[:0], r.Flags())
+	var err error
+	for _, bound := range []tree.Datum{r.Lower, r.Upper} {
+		if r.Empty || bound == nil {
+			continue
+		}
+		if b, err = Encode(b, NoColumnID, bound, nil); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range from the data produced by encodeRange. It is
+// the counterpart of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, error) {
+	if len(b) == 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&tree.RangeFlagEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	var lower, upper tree.Datum
+	var err error
+	if flags&tree.RangeFlagLowerInf == 0 {
+		if lower, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, err
+		}
+	}
+	if flags&tree.RangeFlagUpperInf == 0 {
+		if upper, _, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, err
+		}
+	}
+	return tree.NewDRangeFromFlags(t, lower, upper, flags)
+}
@@ -552,7 +552,7 @@ func (ag *aggregatorBase) getAggResults(
 	defer bucket.close(ag.Ctx())
 
 	for i, b := range bucket {
-		result, err := b.Result()
+		result, err := b.Result(ag.Ctx())
 		if err != nil {
 			ag.MoveToDraining(err)
 			return aggStateUnknown, nil, nil
@@ -0,0 +1,204 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn/connectionpb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
+)
+
+// foreignScanProcessor is a processor that has no inputs and reads the rows of
+// a foreign table from its foreign server. It connects to the server and runs
+// the query of its spec, whose results are decoded from the text format.
+type foreignScanProcessor struct {
+	execinfra.ProcessorBase
+
+	spec *execinfrapb.ForeignScanSpec
+	typs []*types.T
+
+	conn   *pgconn.PgConn
+	result *pgconn.ResultReader
+	rowBuf rowenc.EncDatumRow
+}
+
+var _ execinfra.Processor = &foreignScanProcessor{}
+var _ execinfra.RowSource = &foreignScanProcessor{}
+var _ execopnode.OpNode = &foreignScanProcessor{}
+
+const foreignScanProcName = "foreign scan"
+
+func newForeignScanProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.ForeignScanSpec,
+	post *execinfrapb.PostProcessSpec,
+	output execinfra.RowReceiver,
+) (*foreignScanProcessor, error) {
+	if len(spec.Args) != len(spec.ArgOIDs) {
+		return nil, errors.AssertionFailedf(
+			"malformed ForeignScanSpec: len(Args) = %d does not equal len(ArgOIDs) = %d",
+			len(spec.Args), len(spec.ArgOIDs),
+		)
+	}
+	s := &foreignScanProcessor{
+		spec:   spec,
+		typs:   spec.ColumnTypes,
+		rowBuf: make(rowenc.EncDatumRow, len(spec.ColumnTypes)),
+	}
+	if err := s.Init(
+		ctx, s, post, s.typs, flowCtx, processorID, output, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				s.close()
+				return nil
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start is part of the RowSource interface.
+func (s *foreignScanProcessor) Start(ctx context.Context) {
+	ctx = s.StartInternal(ctx, foreignScanProcName)
+
+	// The URI is loaded outside of the transaction of the flow, which may be a
+	// leaf transaction. The existence of the server and the privileges of the
+	// user were checked when the scan was planned.
+	uri, err := LoadForeignServerURI(ctx, s.FlowCtx.Cfg.Executor, nil /* txn */, s.spec.ServerName)
+	if err != nil {
+		s.MoveToDraining(err)
+		return
+	}
+	conn, err := pgconn.Connect(ctx, uri)
+	if err != nil {
+		s.MoveToDraining(pgerror.Wrap(err, pgcode.ConnectionFailure,
+			"could not connect to the foreign server"))
+		return
+	}
+	s.conn = conn
+
+	args := make([][]byte, len(s.spec.Args))
+	for i := range s.spec.Args {
+		args[i] = []byte(s.spec.Args[i])
+	}
+	s.result = conn.ExecParams(
+		ctx, s.spec.Query, args, s.spec.ArgOIDs, nil /* paramFormats */, nil, /* resultFormats */
+	)
+}
+
+// LoadForeignServerURI returns the URI of the foreign server with the given
+// name, which is stored as an External Connection. The URI contains the
+// unredacted password of the server, and must not be persisted, logged or
+// sent to other nodes.
+func LoadForeignServerURI(
+	ctx context.Context, ie sqlutil.InternalExecutor, txn *kv.Txn, name string,
+) (string, error) {
+	ec, err := externalconn.LoadExternalConnection(ctx, name, ie, txn)
+	if err != nil {
+		return "", pgerror.Wrapf(err, pgcode.UndefinedObject, "server %q does not exist", name)
+	}
+	if ec.ConnectionType() != connectionpb.TypeForeignServer {
+		return "", pgerror.Newf(pgcode.WrongObjectType,
+			"external connection %q is not a foreign server", name)
+	}
+	return ec.ConnectionProto().UnredactedURI(), nil
+}
+
+// Next is part of the RowSource interface.
+func (s *foreignScanProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for s.State == execinfra.StateRunning {
+		if !s.result.NextRow() {
+			_, err := s.result.Close()
+			s.result = nil
+			if err != nil {
+				err = errors.Wrap(err, "error reading from the foreign server")
+			}
+			s.MoveToDraining(err)
+			continue
+		}
+
+		values := s.result.Values()
+		if len(values) < len(s.typs) {
+			s.MoveToDraining(errors.AssertionFailedf(
+				"foreign server returned %d columns, expected %d", len(values), len(s.typs),
+			))
+			continue
+		}
+		for i, typ := range s.typs {
+			if values[i] == nil {
+				s.rowBuf[i] = rowenc.EncDatum{Datum: tree.DNull}
+				continue
+			}
+			d, err := pgwirebase.DecodeDatum(
+				s.Ctx(), s.FlowCtx.EvalCtx, typ, pgwirebase.FormatText, values[i],
+			)
+			if err != nil {
+				s.MoveToDraining(err)
+				return nil, s.DrainHelper()
+			}
+			s.rowBuf[i] = rowenc.DatumToEncDatum(typ, d)
+		}
+
+		if outRow := s.ProcessRowHelper(s.rowBuf); outRow != nil {
+			return outRow, nil
+		}
+	}
+
+	return nil, s.DrainHelper()
+}
+
+func (s *foreignScanProcessor) close() {
+	if s.InternalClose() {
+		if s.result != nil {
+			// The error was either already reported, or is irrelevant since the
+			// consumer doesn't need more rows.
+			_, _ = s.result.Close()
+			s.result = nil
+		}
+		if s.conn != nil {
+			_ = s.conn.Close(s.Ctx())
+			s.conn = nil
+		}
+	}
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (s *foreignScanProcessor) ConsumerClosed() {
+	s.close()
+}
+
+// ChildCount is part of the execopnode.OpNode interface.
+func (s *foreignScanProcessor) ChildCount(verbose bool) int {
+	return 0
+}
+
+// Child is part of the execopnode.OpNode interface.
+func (s *foreignScanProcessor) Child(nth int, verbose bool) execopnode.OpNode {
+	panic(errors.AssertionFailedf("invalid index %d", nth))
+}
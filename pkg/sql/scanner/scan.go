@@ -405,6 +405,12 @@ func (s *Scanner) Scan(lval ScanSymType) {
 
 	case '-':
 		switch s.peek() {
+		case '|': // -|-
+			if s.peekN(1) == '-' {
+				s.pos += 2
+				lval.SetID(lexbase.ADJACENT)
+				return
+			}
 		case '>': // ->
 			if s.peekN(1) == '>' {
 				// ->>
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -169,6 +170,17 @@ func (b *builderState) CurrentUserHasAdminOrIsMemberOf(role username.SQLUsername
 	return ok
 }
 
+// CheckRoleExists implements the scbuildstmt.PrivilegeChecker interface.
+func (b *builderState) CheckRoleExists(role username.SQLUsername) {
+	exists, err := b.auth.RoleExists(b.ctx, role)
+	if err != nil {
+		panic(err)
+	}
+	if !exists {
+		panic(sqlerrors.NewUndefinedUserError(role))
+	}
+}
+
 var _ scbuildstmt.TableHelpers = (*builderState)(nil)
 
 // NextTableColumnID implements the scbuildstmt.TableHelpers interface.
@@ -253,6 +265,52 @@ func (b *builderState) NextTableConstraintID(id catid.DescID) (ret catid.Constra
 	return ret
 }
 
+// NextTableTriggerID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTableTriggerID(id catid.DescID) (ret catid.TriggerID) {
+	{
+		b.ensureDescriptor(id)
+		desc := b.descCache[id].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextTriggerID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	scpb.ForEachTrigger(b.QueryByID(id), func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.Trigger) {
+		if e.TriggerID >= ret {
+			ret = e.TriggerID + 1
+		}
+	})
+	return ret
+}
+
+// NextTablePolicyID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTablePolicyID(id catid.DescID) (ret catid.PolicyID) {
+	{
+		b.ensureDescriptor(id)
+		desc := b.descCache[id].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextPolicyID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	scpb.ForEachPolicy(b.QueryByID(id), func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.Policy) {
+		if e.PolicyID >= ret {
+			ret = e.PolicyID + 1
+		}
+	})
+	return ret
+}
+
 func (b *builderState) IsTableEmpty(table *scpb.Table) bool {
 	// Scan the table for any rows, if they exist the lack of a default value
 	// should lead to an error.
@@ -381,6 +439,55 @@ func (b *builderState) ResolveTypeRef(ref tree.ResolvableTypeReference) scpb.Typ
 	return newTypeT(toType)
 }
 
+// ResolveTriggerFunction implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) ResolveTriggerFunction(
+	tableID catid.DescID, name *tree.FunctionName,
+) catalog.FunctionDescriptor {
+	path := &b.evalCtx.SessionData().SearchPath
+	fd, err := b.cr.ResolveFunction(b.ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), path)
+	if err != nil {
+		panic(err)
+	}
+	rowType := typedesc.TableIDToImplicitTypeOID(tableID)
+	isRowType := func(t *types.T) bool {
+		return t != nil && t.Oid() == rowType
+	}
+	var fn catalog.FunctionDescriptor
+	for _, ol := range fd.Overloads {
+		if !ol.IsUDF {
+			continue
+		}
+		desc := b.readDescriptor(funcdesc.UserDefinedFunctionOIDToID(ol.Oid))
+		f, ok := desc.(catalog.FunctionDescriptor)
+		if !ok {
+			continue
+		}
+		params := f.GetParams()
+		if len(params) != 2 || !isRowType(params[0].Type) || !isRowType(params[1].Type) ||
+			params[0].Class != catpb.Function_Param_IN || params[1].Class != catpb.Function_Param_IN {
+			continue
+		}
+		fn = f
+		break
+	}
+	if fn == nil {
+		panic(pgerror.Newf(pgcode.UndefinedFunction,
+			"function %s must take two arguments of the trigger table's row type", fd.Name))
+	}
+	if ret := fn.GetReturnType(); ret.ReturnSet || !isRowType(ret.Type) {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return the trigger table's row type", fd.Name))
+	}
+	if fn.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must be CALLED ON NULL INPUT to be used in a trigger", fd.Name))
+	}
+	if err := b.auth.CheckPrivilege(b.ctx, fn, privilege.EXECUTE); err != nil {
+		panic(err)
+	}
+	return fn
+}
+
 func newTypeT(t *types.T) scpb.TypeT {
 	m, err := typedesc.GetTypeDescriptorClosure(t)
 	if err != nil {
@@ -680,6 +787,8 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "domain types not supported in declarative schema changer"))
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -940,6 +1049,58 @@ func (b *builderState) ResolveConstraint(
 	})
 }
 
+// ResolveTrigger implements the scbuildstmt.NameResolver interface.
+func (b *builderState) ResolveTrigger(
+	relationID catid.DescID, triggerName tree.Name, p scbuildstmt.ResolveParams,
+) scbuildstmt.ElementResultSet {
+	b.ensureDescriptor(relationID)
+	c := b.descCache[relationID]
+	rel := c.desc.(catalog.TableDescriptor)
+	var triggerID catid.TriggerID
+	scpb.ForEachTrigger(c.ers, func(_ scpb.Status, target scpb.TargetStatus, e *scpb.Trigger) {
+		if target == scpb.ToPublic && e.TableID == relationID && tree.Name(e.Name) == triggerName {
+			triggerID = e.TriggerID
+		}
+	})
+	if triggerID == 0 {
+		if p.IsExistenceOptional {
+			return nil
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", triggerName, rel.GetName()))
+	}
+	return c.ers.Filter(func(_ scpb.Status, _ scpb.TargetStatus, e scpb.Element) bool {
+		idI, _ := screl.Schema.GetAttribute(screl.TriggerID, e)
+		return idI != nil && idI.(catid.TriggerID) == triggerID
+	})
+}
+
+// ResolvePolicy implements the scbuildstmt.NameResolver interface.
+func (b *builderState) ResolvePolicy(
+	relationID catid.DescID, policyName tree.Name, p scbuildstmt.ResolveParams,
+) scbuildstmt.ElementResultSet {
+	b.ensureDescriptor(relationID)
+	c := b.descCache[relationID]
+	rel := c.desc.(catalog.TableDescriptor)
+	var policyID catid.PolicyID
+	scpb.ForEachPolicy(c.ers, func(_ scpb.Status, target scpb.TargetStatus, e *scpb.Policy) {
+		if target == scpb.ToPublic && e.TableID == relationID && tree.Name(e.Name) == policyName {
+			policyID = e.PolicyID
+		}
+	})
+	if policyID == 0 {
+		if p.IsExistenceOptional {
+			return nil
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", policyName, rel.GetName()))
+	}
+	return c.ers.Filter(func(_ scpb.Status, _ scpb.TargetStatus, e scpb.Element) bool {
+		idI, _ := screl.Schema.GetAttribute(screl.PolicyID, e)
+		return idI != nil && idI.(catid.PolicyID) == policyID
+	})
+}
+
 func (b *builderState) ensureDescriptor(id catid.DescID) {
 	if _, found := b.descCache[id]; found {
 		return
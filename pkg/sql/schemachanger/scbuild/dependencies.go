@@ -174,6 +174,9 @@ type AuthorizationAccessor interface {
 	// MemberOfWithAdminOption looks up all the roles 'member' belongs to (direct
 	// and indirect) and returns a map of "role" -> "isAdmin".
 	MemberOfWithAdminOption(ctx context.Context, member username.SQLUsername) (map[username.SQLUsername]bool, error)
+
+	// RoleExists returns true iff the role exists.
+	RoleExists(ctx context.Context, role username.SQLUsername) (bool, error)
 }
 
 // AstFormatter provides interfaces for formatting AST nodes.
@@ -54,7 +54,7 @@ var supportedAlterTableStatements = map[reflect.Type]supportedAlterTableCommand{
 		// Support ALTER TABLE ... ADD PRIMARY KEY
 		if d, ok := t.ConstraintDef.(*tree.UniqueConstraintTableDef); ok && d.PrimaryKey && t.ValidationBehavior == tree.ValidationDefault {
 			return true
-		} else if ok && d.WithoutIndex && t.ValidationBehavior == tree.ValidationDefault {
+		} else if ok && d.WithoutIndex && !d.Deferrable.IsDeferrable() && t.ValidationBehavior == tree.ValidationDefault {
 			return true
 		}
 
@@ -64,7 +64,8 @@ var supportedAlterTableStatements = map[reflect.Type]supportedAlterTableCommand{
 		}
 
 		// Support ALTER TABLE ... ADD CONSTRAINT FOREIGN KEY
-		if _, ok := t.ConstraintDef.(*tree.ForeignKeyConstraintTableDef); ok && t.ValidationBehavior == tree.ValidationDefault {
+		// Deferrable constraints are only supported by the legacy schema changer.
+		if d, ok := t.ConstraintDef.(*tree.ForeignKeyConstraintTableDef); ok && !d.Deferrable.IsDeferrable() && t.ValidationBehavior == tree.ValidationDefault {
 			return true
 		}
 
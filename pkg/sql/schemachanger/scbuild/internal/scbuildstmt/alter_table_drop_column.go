@@ -227,6 +227,13 @@ func dropColumn(
 			// TODO(ajwerner): Support dropping FOREIGN KEY constraints.
 			panic(errors.Wrap(scerrors.NotImplementedError(n),
 				"dropping of FOREIGN KEY constraints not supported"))
+		case *scpb.Policy:
+			if behavior != tree.DropCascade {
+				panic(errors.WithHint(sqlerrors.NewDependentObjectErrorf(
+					"cannot drop column %q because policy %q depends on it", cn.Name, e.Name),
+					"use CASCADE to drop the policy as well."))
+			}
+			b.Drop(e)
 		case *scpb.View:
 			if behavior != tree.DropCascade {
 				_, _, ns := scpb.FindNamespace(b.QueryByID(col.TableID))
@@ -299,7 +306,7 @@ func walkDropColumnDependencies(b BuildCtx, col *scpb.Column, fn func(e scpb.Ele
 				fn(e)
 			case *scpb.ColumnDefaultExpression, *scpb.ColumnOnUpdateExpression:
 				fn(e)
-			case *scpb.UniqueWithoutIndexConstraint, *scpb.CheckConstraint:
+			case *scpb.UniqueWithoutIndexConstraint, *scpb.CheckConstraint, *scpb.Policy:
 				fn(e)
 			case *scpb.ColumnType:
 				if elt.ColumnID == col.ColumnID {
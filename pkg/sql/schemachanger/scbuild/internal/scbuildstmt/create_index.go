@@ -333,6 +333,12 @@ func processColNodeType(
 			}
 			indexSpec.secondary.GeoConfig = geoindex.DefaultGeographyIndexConfig()
 			b.IncrementSchemaChangeIndexCounter("geography_inverted")
+		case types.TSVectorFamily:
+			switch columnNode.OpClass {
+			case "tsvector_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
 		case types.StringFamily:
 			// Check the opclass of the last column in the list, which is the column
 			// we're going to inverted index.
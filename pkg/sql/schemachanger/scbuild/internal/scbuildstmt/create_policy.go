@@ -0,0 +1,119 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// CreatePolicy implements CREATE POLICY.
+func CreatePolicy(b BuildCtx, n *tree.CreatePolicy) {
+	tableElts := b.ResolveTable(n.Table, ResolveParams{
+		IsExistenceOptional: false,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(tableElts)
+	_, _, ns := scpb.FindNamespace(tableElts)
+	if tbl == nil || ns == nil {
+		panic(pgerror.Newf(pgcode.UndefinedTable, "relation %q does not exist", n.Table.String()))
+	}
+	if existing := b.ResolvePolicy(tbl.TableID, n.Name, ResolveParams{
+		IsExistenceOptional: true,
+		RequiredPrivilege:   privilege.CREATE,
+	}); existing != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"policy %q for table %q already exists", n.Name, ns.Name))
+	}
+	if n.Using != nil && n.Command == tree.PolicyCommandInsert {
+		panic(pgerror.New(pgcode.Syntax, "only WITH CHECK expression allowed for INSERT"))
+	}
+	if n.WithCheck != nil &&
+		(n.Command == tree.PolicyCommandSelect || n.Command == tree.PolicyCommandDelete) {
+		panic(pgerror.New(pgcode.Syntax, "WITH CHECK cannot be applied to SELECT or DELETE"))
+	}
+	tn := n.Table.ToTableName()
+	tn.ObjectNamePrefix = b.NamePrefix(tbl)
+	b.Add(&scpb.Policy{
+		TableID:       tbl.TableID,
+		PolicyID:      b.NextTablePolicyID(tbl.TableID),
+		Name:          string(n.Name),
+		Type:          tree.PolicyTypeValue[n.Type],
+		Command:       tree.PolicyCommandValue[n.Command],
+		RoleNames:     resolvePolicyRoles(b, n.Roles),
+		UsingExpr:     wrapPolicyExpr(b, &tn, tbl, n.Using, "POLICY USING"),
+		WithCheckExpr: wrapPolicyExpr(b, &tn, tbl, n.WithCheck, "POLICY WITH CHECK"),
+	})
+	b.IncrementSchemaChangeAlterCounter("table", "create_policy")
+}
+
+// resolvePolicyRoles returns the normalized names of the roles to which a
+// policy applies. A policy without roles applies to the public role.
+func resolvePolicyRoles(b BuildCtx, roles tree.RoleSpecList) []string {
+	if len(roles) == 0 {
+		return []string{username.PublicRole}
+	}
+	users, err := decodeusername.FromRoleSpecList(b.SessionData(), username.PurposeValidation, roles)
+	if err != nil {
+		panic(err)
+	}
+	ret := make([]string, 0, len(users))
+	seen := make(map[username.SQLUsername]struct{}, len(users))
+	for _, u := range users {
+		if _, ok := seen[u]; ok {
+			continue
+		}
+		seen[u] = struct{}{}
+		if !u.IsPublicRole() {
+			b.CheckRoleExists(u)
+		}
+		ret = append(ret, u.Normalized())
+	}
+	return ret
+}
+
+// wrapPolicyExpr validates a boolean policy expression and wraps it into an
+// element expression. It returns nil if expr is nil.
+func wrapPolicyExpr(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, expr tree.Expr, context string,
+) *scpb.Expression {
+	if expr == nil {
+		return nil
+	}
+	validExpr, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, expr, types.Bool,
+		context, b.SemaCtx(), volatility.Volatile, tn,
+		func() colinfo.ResultColumns {
+			return getNonDropResultColumns(b, tbl.TableID)
+		},
+		func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+			return columnLookupFn(b, tbl.TableID, columnName)
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	parsedExpr, err := parser.ParseExpr(validExpr)
+	if err != nil {
+		panic(err)
+	}
+	return b.WrapExpression(tbl.TableID, parsedExpr)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreateTrigger implements CREATE TRIGGER.
+func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
+	tableElts := b.ResolveTable(n.Table, ResolveParams{
+		IsExistenceOptional: false,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(tableElts)
+	_, _, ns := scpb.FindNamespace(tableElts)
+	if tbl == nil || ns == nil {
+		panic(pgerror.Newf(pgcode.UndefinedTable, "relation %q does not exist", n.Table.String()))
+	}
+	if existing := b.ResolveTrigger(tbl.TableID, n.Name, ResolveParams{
+		IsExistenceOptional: true,
+		RequiredPrivilege:   privilege.CREATE,
+	}); existing != nil {
+		if !n.Replace {
+			panic(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", n.Name, ns.Name))
+		}
+		existing.ForEachElementStatus(func(_ scpb.Status, target scpb.TargetStatus, e scpb.Element) {
+			if target == scpb.ToPublic {
+				b.Drop(e)
+			}
+		})
+	}
+	events := make([]semenumpb.TriggerEvent, 0, len(n.Events))
+	seen := make(map[tree.TriggerEvent]struct{}, len(n.Events))
+	for _, ev := range n.Events {
+		if _, ok := seen[ev]; ok {
+			panic(pgerror.Newf(pgcode.Syntax, "duplicate trigger events specified"))
+		}
+		seen[ev] = struct{}{}
+		events = append(events, tree.TriggerEventValue[ev])
+	}
+	fn := b.ResolveTriggerFunction(tbl.TableID, &n.FuncName)
+	b.Add(&scpb.Trigger{
+		TableID:    tbl.TableID,
+		TriggerID:  b.NextTableTriggerID(tbl.TableID),
+		Name:       string(n.Name),
+		ActionTime: tree.TriggerActionTimeValue[n.ActionTime],
+		Events:     events,
+		FunctionID: fn.GetID(),
+	})
+	b.IncrementSchemaChangeAlterCounter("table", "create_trigger")
+}
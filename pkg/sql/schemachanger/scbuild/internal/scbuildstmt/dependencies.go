@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
@@ -185,6 +186,9 @@ type PrivilegeChecker interface {
 	// CurrentUserHasAdminOrIsMemberOf returns true iff the current user is (1)
 	// an admin or (2) has membership in the specified role.
 	CurrentUserHasAdminOrIsMemberOf(member username.SQLUsername) bool
+
+	// CheckRoleExists panics if the specified role does not exist.
+	CheckRoleExists(role username.SQLUsername)
 }
 
 // TableHelpers has methods useful for creating new table elements.
@@ -210,6 +214,14 @@ type TableHelpers interface {
 	// added to this table.
 	NextTableConstraintID(id catid.DescID) catid.ConstraintID
 
+	// NextTableTriggerID returns the ID that should be used for any new trigger
+	// added to this table.
+	NextTableTriggerID(id catid.DescID) catid.TriggerID
+
+	// NextTablePolicyID returns the ID that should be used for any new
+	// row-level security policy added to this table.
+	NextTablePolicyID(id catid.DescID) catid.PolicyID
+
 	// IndexPartitioningDescriptor creates a new partitioning descriptor
 	// for the secondary index element, or panics.
 	IndexPartitioningDescriptor(indexName string,
@@ -219,6 +231,10 @@ type TableHelpers interface {
 	// ResolveTypeRef resolves a type reference.
 	ResolveTypeRef(typeref tree.ResolvableTypeReference) scpb.TypeT
 
+	// ResolveTriggerFunction resolves the function executed by a trigger on
+	// the given table, and panics if its signature is unsuitable for a trigger.
+	ResolveTriggerFunction(tableID catid.DescID, name *tree.FunctionName) catalog.FunctionDescriptor
+
 	// WrapExpression constructs an expression wrapper given an AST.
 	WrapExpression(parentID catid.DescID, expr tree.Expr) *scpb.Expression
 
@@ -316,4 +332,11 @@ type NameResolver interface {
 
 	// ResolveConstraint retrieves a constraint by name and returns its elements.
 	ResolveConstraint(relationID catid.DescID, constraintName tree.Name, p ResolveParams) ElementResultSet
+
+	// ResolveTrigger retrieves a trigger by name and returns its elements.
+	ResolveTrigger(relationID catid.DescID, triggerName tree.Name, p ResolveParams) ElementResultSet
+
+	// ResolvePolicy retrieves a row-level security policy by name and returns
+	// its elements.
+	ResolvePolicy(relationID catid.DescID, policyName tree.Name, p ResolveParams) ElementResultSet
 }
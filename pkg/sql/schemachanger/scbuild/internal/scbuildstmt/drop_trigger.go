@@ -0,0 +1,40 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropTrigger implements DROP TRIGGER.
+func DropTrigger(b BuildCtx, n *tree.DropTrigger) {
+	tableElts := b.ResolveTable(n.Table, ResolveParams{
+		IsExistenceOptional: false,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(tableElts)
+	triggerElts := b.ResolveTrigger(tbl.TableID, n.Name, ResolveParams{
+		IsExistenceOptional: n.IfExists,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	if triggerElts == nil {
+		// The trigger does not exist but IF EXISTS is set.
+		return
+	}
+	triggerElts.ForEachElementStatus(func(_ scpb.Status, target scpb.TargetStatus, e scpb.Element) {
+		if target == scpb.ToPublic {
+			b.Drop(e)
+		}
+	})
+	b.IncrementSchemaChangeDropCounter("trigger")
+}
@@ -103,6 +103,10 @@ var supportedStatements = map[reflect.Type]supportedStatement{
 	reflect.TypeOf((*tree.CommentOnIndex)(nil)):      {fn: CommentOnIndex, on: true, minSupportedClusterVersion: clusterversion.V22_2Start},
 	reflect.TypeOf((*tree.CommentOnConstraint)(nil)): {fn: CommentOnConstraint, on: true, minSupportedClusterVersion: clusterversion.V22_2Start},
 	reflect.TypeOf((*tree.DropIndex)(nil)):           {fn: DropIndex, on: true, minSupportedClusterVersion: clusterversion.V23_1Start},
+	reflect.TypeOf((*tree.CreateTrigger)(nil)):       {fn: CreateTrigger, on: true, minSupportedClusterVersion: clusterversion.V23_1},
+	reflect.TypeOf((*tree.DropTrigger)(nil)):         {fn: DropTrigger, on: true, minSupportedClusterVersion: clusterversion.V23_1},
+	reflect.TypeOf((*tree.CreatePolicy)(nil)):        {fn: CreatePolicy, on: true, minSupportedClusterVersion: clusterversion.V23_1},
+	reflect.TypeOf((*tree.DropPolicy)(nil)):          {fn: DropPolicy, on: true, minSupportedClusterVersion: clusterversion.V23_1},
 }
 
 func init() {
@@ -108,6 +108,11 @@ func (w *walkCtx) walkRoot() {
 	case catalog.TypeDescriptor:
 		w.walkType(d)
 	case catalog.TableDescriptor:
+		if d.IsForeignTable() {
+			// Fall back to legacy schema changer if there is any foreign table
+			// descriptor in the drop cascade dependency graph.
+			panic(scerrors.NotImplementedErrorf(nil, "foreign table descriptor not supported in declarative schema changer"))
+		}
 		w.walkRelation(d)
 	case catalog.FunctionDescriptor:
 		// TODO (Chengxiong) #83235 implement DROP FUNCTION.
@@ -190,6 +195,9 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				LogicalRepresentation:  typ.GetMemberLogicalRepresentation(ord),
 			})
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		// Fall back to the legacy schema changer for domains.
+		panic(scerrors.NotImplementedErrorf(nil, "domain types not supported in declarative schema changer"))
 	case descpb.TypeDescriptor_COMPOSITE:
 		w.ev(descriptorStatus(typ), &scpb.CompositeType{
 			TypeID:      typ.GetID(),
@@ -337,6 +345,12 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	for i := range tbl.GetTriggers() {
+		w.walkTrigger(tbl, &tbl.GetTriggers()[i])
+	}
+	for i := range tbl.GetPolicies() {
+		w.walkPolicy(tbl, &tbl.GetPolicies()[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -651,6 +665,46 @@ func (w *walkCtx) walkCheckConstraint(tbl catalog.TableDescriptor, c catalog.Che
 	}
 }
 
+func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TriggerDescriptor) {
+	w.ev(scpb.Status_PUBLIC, &scpb.Trigger{
+		TableID:    tbl.GetID(),
+		TriggerID:  t.ID,
+		Name:       t.Name,
+		ActionTime: t.ActionTime,
+		Events:     t.Events,
+		FunctionID: t.FuncID,
+	})
+}
+
+func (w *walkCtx) walkPolicy(tbl catalog.TableDescriptor, p *descpb.PolicyDescriptor) {
+	policy := &scpb.Policy{
+		TableID:   tbl.GetID(),
+		PolicyID:  p.ID,
+		Name:      p.Name,
+		Type:      p.Type,
+		Command:   p.Command,
+		RoleNames: p.RoleNames,
+	}
+	for _, e := range []struct {
+		expr string
+		dst  **scpb.Expression
+	}{
+		{p.UsingExpr, &policy.UsingExpr},
+		{p.WithCheckExpr, &policy.WithCheckExpr},
+	} {
+		if e.expr == "" {
+			continue
+		}
+		expr, err := w.newExpression(e.expr)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "policy %q in table %q (%d)",
+				p.Name, tbl.GetName(), tbl.GetID()))
+		}
+		*e.dst = expr
+	}
+	w.ev(scpb.Status_PUBLIC, policy)
+}
+
 func (w *walkCtx) walkForeignKeyConstraint(
 	tbl catalog.TableDescriptor, c catalog.ForeignKeyConstraint,
 ) {
@@ -177,6 +177,11 @@ func (s *TestState) MemberOfWithAdminOption(
 	return nil, nil
 }
 
+// RoleExists implements the scbuild.AuthorizationAccessor interface.
+func (s *TestState) RoleExists(ctx context.Context, role username.SQLUsername) (bool, error) {
+	return true, nil
+}
+
 // IndexPartitioningCCLCallback implements the scbuild.Dependencies interface.
 func (s *TestState) IndexPartitioningCCLCallback() scbuild.CreatePartitioningCCLCallback {
 	if ccl := scdeps.CreatePartitioningCCL; ccl != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -77,6 +78,18 @@ func (m *visitor) checkOutType(ctx context.Context, id descpb.ID) (*typedesc.Mut
 	return mut, nil
 }
 
+func (m *visitor) checkOutFunction(ctx context.Context, id descpb.ID) (*funcdesc.Mutable, error) {
+	desc, err := m.s.CheckOutDescriptor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mut, ok := desc.(*funcdesc.Mutable)
+	if !ok {
+		return nil, catalog.WrapFunctionDescRefErr(id, catalog.NewDescriptorTypeError(desc))
+	}
+	return mut, nil
+}
+
 func mutationStateChange(
 	tbl *tabledesc.Mutable,
 	f MutationSelector,
@@ -0,0 +1,65 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (m *visitor) AddPolicy(ctx context.Context, op scop.AddPolicy) error {
+	tbl, err := m.checkOutTable(ctx, op.Policy.TableID)
+	if err != nil {
+		return err
+	}
+	for i := range tbl.Policies {
+		if tbl.Policies[i].ID == op.Policy.PolicyID {
+			return errors.AssertionFailedf("policy %d already exists on table %d",
+				op.Policy.PolicyID, op.Policy.TableID)
+		}
+	}
+	policy := descpb.PolicyDescriptor{
+		ID:        op.Policy.PolicyID,
+		Name:      op.Policy.Name,
+		Type:      op.Policy.Type,
+		Command:   op.Policy.Command,
+		RoleNames: append([]string(nil), op.Policy.RoleNames...),
+	}
+	if op.Policy.UsingExpr != nil {
+		policy.UsingExpr = string(op.Policy.UsingExpr.Expr)
+	}
+	if op.Policy.WithCheckExpr != nil {
+		policy.WithCheckExpr = string(op.Policy.WithCheckExpr.Expr)
+	}
+	tbl.Policies = append(tbl.Policies, policy)
+	if op.Policy.PolicyID >= tbl.NextPolicyID {
+		tbl.NextPolicyID = op.Policy.PolicyID + 1
+	}
+	return nil
+}
+
+func (m *visitor) RemovePolicy(ctx context.Context, op scop.RemovePolicy) error {
+	tbl, err := m.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		// Exit early if the table is getting dropped.
+		return err
+	}
+	for i := range tbl.Policies {
+		if tbl.Policies[i].ID == op.PolicyID {
+			tbl.Policies = append(tbl.Policies[:i], tbl.Policies[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
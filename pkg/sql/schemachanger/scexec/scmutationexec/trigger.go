@@ -0,0 +1,80 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/errors"
+)
+
+func (m *visitor) AddTrigger(ctx context.Context, op scop.AddTrigger) error {
+	tbl, err := m.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil {
+		return err
+	}
+	if _, err := tbl.FindTriggerByID(op.Trigger.TriggerID); err == nil {
+		return errors.AssertionFailedf("trigger %d already exists on table %d",
+			op.Trigger.TriggerID, op.Trigger.TableID)
+	}
+	tbl.Triggers = append(tbl.Triggers, descpb.TriggerDescriptor{
+		ID:         op.Trigger.TriggerID,
+		Name:       op.Trigger.Name,
+		ActionTime: op.Trigger.ActionTime,
+		Events:     append([]semenumpb.TriggerEvent(nil), op.Trigger.Events...),
+		FuncID:     op.Trigger.FunctionID,
+	})
+	if op.Trigger.TriggerID >= tbl.NextTriggerID {
+		tbl.NextTriggerID = op.Trigger.TriggerID + 1
+	}
+	return nil
+}
+
+func (m *visitor) RemoveTrigger(ctx context.Context, op scop.RemoveTrigger) error {
+	tbl, err := m.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		// Exit early if the table is getting dropped.
+		return err
+	}
+	for i := range tbl.Triggers {
+		if tbl.Triggers[i].ID == op.TriggerID {
+			tbl.Triggers = append(tbl.Triggers[:i], tbl.Triggers[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *visitor) AddTriggerBackReferenceInFunction(
+	ctx context.Context, op scop.AddTriggerBackReferenceInFunction,
+) error {
+	fn, err := m.checkOutFunction(ctx, op.FunctionID)
+	if err != nil {
+		return err
+	}
+	fn.AddTriggerReference(op.TableID, op.TriggerID)
+	return nil
+}
+
+func (m *visitor) RemoveTriggerBackReferenceInFunction(
+	ctx context.Context, op scop.RemoveTriggerBackReferenceInFunction,
+) error {
+	fn, err := m.checkOutFunction(ctx, op.FunctionID)
+	if err != nil || fn.Dropped() {
+		// Exit early if the trigger function is getting dropped.
+		return err
+	}
+	fn.RemoveTriggerReference(op.TableID, op.TriggerID)
+	return nil
+}
@@ -717,3 +717,47 @@ type RemoveColumnFromIndex struct {
 	Ordinal      uint32
 	InvertedKind catpb.InvertedIndexColumnKind
 }
+
+// AddTrigger adds a trigger to a table.
+type AddTrigger struct {
+	mutationOp
+	Trigger scpb.Trigger
+}
+
+// RemoveTrigger removes a trigger from a table.
+type RemoveTrigger struct {
+	mutationOp
+	TableID   descpb.ID
+	TriggerID descpb.TriggerID
+}
+
+// AddTriggerBackReferenceInFunction adds a trigger back-reference to the
+// function executed by the trigger.
+type AddTriggerBackReferenceInFunction struct {
+	mutationOp
+	FunctionID descpb.ID
+	TableID    descpb.ID
+	TriggerID  descpb.TriggerID
+}
+
+// RemoveTriggerBackReferenceInFunction removes a trigger back-reference from
+// the function executed by the trigger.
+type RemoveTriggerBackReferenceInFunction struct {
+	mutationOp
+	FunctionID descpb.ID
+	TableID    descpb.ID
+	TriggerID  descpb.TriggerID
+}
+
+// AddPolicy adds a row-level security policy to a table.
+type AddPolicy struct {
+	mutationOp
+	Policy scpb.Policy
+}
+
+// RemovePolicy removes a row-level security policy from a table.
+type RemovePolicy struct {
+	mutationOp
+	TableID  descpb.ID
+	PolicyID descpb.PolicyID
+}
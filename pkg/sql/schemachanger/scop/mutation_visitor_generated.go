@@ -107,6 +107,12 @@ type MutationVisitor interface {
 	RefreshStats(context.Context, RefreshStats) error
 	AddColumnToIndex(context.Context, AddColumnToIndex) error
 	RemoveColumnFromIndex(context.Context, RemoveColumnFromIndex) error
+	AddTrigger(context.Context, AddTrigger) error
+	RemoveTrigger(context.Context, RemoveTrigger) error
+	AddTriggerBackReferenceInFunction(context.Context, AddTriggerBackReferenceInFunction) error
+	RemoveTriggerBackReferenceInFunction(context.Context, RemoveTriggerBackReferenceInFunction) error
+	AddPolicy(context.Context, AddPolicy) error
+	RemovePolicy(context.Context, RemovePolicy) error
 }
 
 // Visit is part of the MutationOp interface.
@@ -533,3 +539,33 @@ func (op AddColumnToIndex) Visit(ctx context.Context, v MutationVisitor) error {
 func (op RemoveColumnFromIndex) Visit(ctx context.Context, v MutationVisitor) error {
 	return v.RemoveColumnFromIndex(ctx, op)
 }
+
+// Visit is part of the MutationOp interface.
+func (op AddTrigger) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.AddTrigger(ctx, op)
+}
+
+// Visit is part of the MutationOp interface.
+func (op RemoveTrigger) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.RemoveTrigger(ctx, op)
+}
+
+// Visit is part of the MutationOp interface.
+func (op AddTriggerBackReferenceInFunction) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.AddTriggerBackReferenceInFunction(ctx, op)
+}
+
+// Visit is part of the MutationOp interface.
+func (op RemoveTriggerBackReferenceInFunction) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.RemoveTriggerBackReferenceInFunction(ctx, op)
+}
+
+// Visit is part of the MutationOp interface.
+func (op AddPolicy) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.AddPolicy(ctx, op)
+}
+
+// Visit is part of the MutationOp interface.
+func (op RemovePolicy) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.RemovePolicy(ctx, op)
+}
@@ -947,6 +947,37 @@ func FindOwner(b ElementStatusIterator) (current Status, target TargetStatus, el
 	return current, target, element
 }
 
+func (e Policy) element() {}
+
+// ForEachPolicy iterates over elements of type Policy.
+func ForEachPolicy(
+	b ElementStatusIterator, fn func(current Status, target TargetStatus, e *Policy),
+) {
+  if b == nil {
+    return
+  }
+	b.ForEachElementStatus(func(current Status, target TargetStatus, e Element) {
+		if elt, ok := e.(*Policy); ok {
+			fn(current, target, elt)
+		}
+	})
+}
+
+// FindPolicy finds the first element of type Policy.
+func FindPolicy(b ElementStatusIterator) (current Status, target TargetStatus, element *Policy) {
+  if b == nil {
+    return current, target, element
+  }
+	b.ForEachElementStatus(func(c Status, t TargetStatus, e Element) {
+		if elt, ok := e.(*Policy); ok {
+			element = elt
+			current = c
+			target = t
+		}
+	})
+	return current, target, element
+}
+
 func (e PrimaryIndex) element() {}
 
 // ForEachPrimaryIndex iterates over elements of type PrimaryIndex.
@@ -1536,6 +1567,37 @@ func FindTemporaryIndex(b ElementStatusIterator) (current Status, target TargetS
 	return current, target, element
 }
 
+func (e Trigger) element() {}
+
+// ForEachTrigger iterates over elements of type Trigger.
+func ForEachTrigger(
+	b ElementStatusIterator, fn func(current Status, target TargetStatus, e *Trigger),
+) {
+  if b == nil {
+    return
+  }
+	b.ForEachElementStatus(func(current Status, target TargetStatus, e Element) {
+		if elt, ok := e.(*Trigger); ok {
+			fn(current, target, elt)
+		}
+	})
+}
+
+// FindTrigger finds the first element of type Trigger.
+func FindTrigger(b ElementStatusIterator) (current Status, target TargetStatus, element *Trigger) {
+  if b == nil {
+    return current, target, element
+  }
+	b.ForEachElementStatus(func(c Status, t TargetStatus, e Element) {
+		if elt, ok := e.(*Trigger); ok {
+			element = elt
+			current = c
+			target = t
+		}
+	})
+	return current, target, element
+}
+
 func (e UniqueWithoutIndexConstraint) element() {}
 
 // ForEachUniqueWithoutIndexConstraint iterates over elements of type UniqueWithoutIndexConstraint.
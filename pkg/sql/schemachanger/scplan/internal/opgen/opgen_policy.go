@@ -0,0 +1,47 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+func init() {
+	opRegistry.register((*scpb.Policy)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.Policy) *scop.AddPolicy {
+					return &scop.AddPolicy{Policy: *protoutil.Clone(this).(*scpb.Policy)}
+				}),
+				emit(func(this *scpb.Policy, md *opGenContext) *scop.LogEvent {
+					return newLogEventOp(this, md)
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.Policy) *scop.RemovePolicy {
+					return &scop.RemovePolicy{
+						TableID:  this.TableID,
+						PolicyID: this.PolicyID,
+					}
+				}),
+				emit(func(this *scpb.Policy, md *opGenContext) *scop.LogEvent {
+					return newLogEventOp(this, md)
+				}),
+			),
+		),
+	)
+}
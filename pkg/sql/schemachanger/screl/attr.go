@@ -68,6 +68,10 @@ const (
 	// SourceIndexID is the index ID of the source index for a newly created
 	// index.
 	SourceIndexID
+	// TriggerID is the ID of a trigger.
+	TriggerID
+	// PolicyID is the ID of a row-level security policy.
+	PolicyID
 
 	// TargetStatus is the target status of an element.
 	TargetStatus
@@ -338,6 +342,17 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.TablePartitioning)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
+	rel.EntityMapping(t((*scpb.Trigger)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(Name, "Name"),
+		rel.EntityAttr(ReferencedDescID, "FunctionID"),
+	),
+	rel.EntityMapping(t((*scpb.Policy)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(PolicyID, "PolicyID"),
+		rel.EntityAttr(Name, "Name"),
+	),
 }
 
 // Schema is the schema exported by this package covering the elements of scpb.
@@ -18,17 +18,19 @@ func _() {
 	_ = x[Comment-8]
 	_ = x[TemporaryIndexID-9]
 	_ = x[SourceIndexID-10]
-	_ = x[TargetStatus-11]
-	_ = x[CurrentStatus-12]
-	_ = x[Element-13]
-	_ = x[Target-14]
-	_ = x[ReferencedTypeIDs-15]
-	_ = x[ReferencedSequenceIDs-16]
+	_ = x[TriggerID-11]
+	_ = x[PolicyID-12]
+	_ = x[TargetStatus-13]
+	_ = x[CurrentStatus-14]
+	_ = x[Element-15]
+	_ = x[Target-16]
+	_ = x[ReferencedTypeIDs-17]
+	_ = x[ReferencedSequenceIDs-18]
 }
 
-const _Attr_name = "DescIDIndexIDColumnFamilyIDColumnIDConstraintIDNameReferencedDescIDCommentTemporaryIndexIDSourceIndexIDTargetStatusCurrentStatusElementTargetReferencedTypeIDsReferencedSequenceIDs"
+const _Attr_name = "DescIDIndexIDColumnFamilyIDColumnIDConstraintIDNameReferencedDescIDCommentTemporaryIndexIDSourceIndexIDTriggerIDPolicyIDTargetStatusCurrentStatusElementTargetReferencedTypeIDsReferencedSequenceIDs"
 
-var _Attr_index = [...]uint8{0, 6, 13, 27, 35, 47, 51, 67, 74, 90, 103, 115, 128, 135, 141, 158, 179}
+var _Attr_index = [...]uint8{0, 6, 13, 27, 35, 47, 51, 67, 74, 90, 103, 112, 120, 132, 145, 152, 158, 175, 196}
 
 func (i Attr) String() string {
 	i -= 1
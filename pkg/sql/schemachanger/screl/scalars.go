@@ -120,7 +120,8 @@ func MinVersion(el scpb.Element) clusterversion.Key {
 		return clusterversion.V23_1
 	case *scpb.IndexColumn, *scpb.EnumTypeValue, *scpb.TableZoneConfig:
 		return clusterversion.V22_2UseDelRangeInGCJob
-	case *scpb.DatabaseData, *scpb.TableData, *scpb.IndexData, *scpb.TablePartitioning:
+	case *scpb.DatabaseData, *scpb.TableData, *scpb.IndexData, *scpb.TablePartitioning,
+		*scpb.Trigger, *scpb.Policy:
 		return clusterversion.V23_1
 	default:
 		panic(errors.AssertionFailedf("unknown element %T", el))
@@ -869,7 +869,7 @@ func (agg *stMakeLineAgg) Add(
 }
 
 // Result implements the AggregateFunc interface.
-func (agg *stMakeLineAgg) Result() (tree.Datum, error) {
+func (agg *stMakeLineAgg) Result(context.Context) (tree.Datum, error) {
 	if len(agg.flatCoords) == 0 {
 		return tree.DNull, nil
 	}
@@ -940,7 +940,7 @@ func (agg *stUnionAgg) Add(
 }
 
 // Result implements the AggregateFunc interface.
-func (agg *stUnionAgg) Result() (tree.Datum, error) {
+func (agg *stUnionAgg) Result(context.Context) (tree.Datum, error) {
 	if !agg.set {
 		return tree.DNull, nil
 	}
@@ -1081,7 +1081,7 @@ func (agg *stCollectAgg) multiToCollection(multi geom.T) (*geom.GeometryCollecti
 }
 
 // Result implements the AggregateFunc interface.
-func (agg *stCollectAgg) Result() (tree.Datum, error) {
+func (agg *stCollectAgg) Result(context.Context) (tree.Datum, error) {
 	if agg.coll == nil {
 		return tree.DNull, nil
 	}
@@ -1127,7 +1127,7 @@ func (agg *stExtentAgg) Add(_ context.Context, firstArg tree.Datum, otherArgs ..
 }
 
 // Result implements the AggregateFunc interface.
-func (agg *stExtentAgg) Result() (tree.Datum, error) {
+func (agg *stExtentAgg) Result(context.Context) (tree.Datum, error) {
 	if agg.bbox == nil {
 		return tree.DNull, nil
 	}
@@ -1404,7 +1404,7 @@ func (a *anyNotNullAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tr
 }
 
 // Result returns the value most recently passed to Add.
-func (a *anyNotNullAggregate) Result() (tree.Datum, error) {
+func (a *anyNotNullAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.val, nil
 }
 
@@ -1448,7 +1448,7 @@ func (a *arrayAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Da
 }
 
 // Result returns a copy of the array of all datums passed to Add.
-func (a *arrayAggregate) Result() (tree.Datum, error) {
+func (a *arrayAggregate) Result(context.Context) (tree.Datum, error) {
 	if len(a.arr.Array) > 0 {
 		arrCopy := *a.arr
 		return &arrCopy, nil
@@ -1512,8 +1512,8 @@ func (a *avgAggregate) Add(ctx context.Context, datum tree.Datum, other ...tree.
 }
 
 // Result returns the average of all datums passed to Add.
-func (a *avgAggregate) Result() (tree.Datum, error) {
-	sum, err := a.agg.Result()
+func (a *avgAggregate) Result(ctx context.Context) (tree.Datum, error) {
+	sum, err := a.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1624,7 +1624,7 @@ func (a *concatAggregate) Add(ctx context.Context, datum tree.Datum, others ...t
 	return nil
 }
 
-func (a *concatAggregate) Result() (tree.Datum, error) {
+func (a *concatAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1683,7 +1683,7 @@ func (a *intBitAndAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.
 }
 
 // Result returns the bitwise AND.
-func (a *intBitAndAggregate) Result() (tree.Datum, error) {
+func (a *intBitAndAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1738,7 +1738,7 @@ func (a *bitBitAndAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.
 }
 
 // Result returns the bitwise AND.
-func (a *bitBitAndAggregate) Result() (tree.Datum, error) {
+func (a *bitBitAndAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1789,7 +1789,7 @@ func (a *intBitOrAggregate) Add(
 }
 
 // Result returns the bitwise OR.
-func (a *intBitOrAggregate) Result() (tree.Datum, error) {
+func (a *intBitOrAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1846,7 +1846,7 @@ func (a *bitBitOrAggregate) Add(
 }
 
 // Result returns the bitwise OR.
-func (a *bitBitOrAggregate) Result() (tree.Datum, error) {
+func (a *bitBitOrAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1888,7 +1888,7 @@ func (a *boolAndAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Da
 	return nil
 }
 
-func (a *boolAndAggregate) Result() (tree.Datum, error) {
+func (a *boolAndAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -1927,7 +1927,7 @@ func (a *boolOrAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Dat
 	return nil
 }
 
-func (a *boolOrAggregate) Result() (tree.Datum, error) {
+func (a *boolOrAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -2000,7 +2000,7 @@ func makeTransitionRegressionAccumulatorDecimalBase(
 // It is only used for the local stage when computing regression functions in a
 // distributed fashion. Both the final stage of the distributed execution, and
 // the only stage of the local execution override this.
-func (a *regressionAccumulatorDecimalBase) Result() (tree.Datum, error) {
+func (a *regressionAccumulatorDecimalBase) Result(context.Context) (tree.Datum, error) {
 	res := tree.NewDArray(types.Decimal)
 	vals := []*apd.Decimal{&a.n, &a.sx, &a.sxx, &a.sy, &a.syy, &a.sxy}
 	for _, v := range vals {
@@ -2485,7 +2485,7 @@ func newCorrAggregate(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) eval.A
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *corrAggregate) Result() (tree.Datum, error) {
+func (a *corrAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.corrLastStage()
 }
 
@@ -2503,7 +2503,7 @@ func newFinalCorrAggregate(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) e
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalCorrAggregate) Result() (tree.Datum, error) {
+func (a *finalCorrAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.corrLastStage()
 }
 
@@ -2519,7 +2519,7 @@ func newCovarPopAggregate(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) ev
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *covarPopAggregate) Result() (tree.Datum, error) {
+func (a *covarPopAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.covarPopLastStage()
 }
 
@@ -2553,7 +2553,7 @@ func (a *finalRegressionAccumulatorDecimalBase) Size() int64 {
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalCovarPopAggregate) Result() (tree.Datum, error) {
+func (a *finalCovarPopAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.covarPopLastStage()
 }
 
@@ -2573,7 +2573,7 @@ func newFinalRegrSXXAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegrSXXAggregate) Result() (tree.Datum, error) {
+func (a *finalRegrSXXAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSXXLastStage()
 }
 
@@ -2594,7 +2594,7 @@ func newFinalRegrSXYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegrSXYAggregate) Result() (tree.Datum, error) {
+func (a *finalRegrSXYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSXYLastStage()
 }
 
@@ -2614,7 +2614,7 @@ func newFinalRegrSYYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegrSYYAggregate) Result() (tree.Datum, error) {
+func (a *finalRegrSYYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSYYLastStage()
 }
 
@@ -2630,7 +2630,7 @@ func newCovarSampAggregate(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) e
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *covarSampAggregate) Result() (tree.Datum, error) {
+func (a *covarSampAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.covarSampLastStage()
 }
 
@@ -2650,7 +2650,7 @@ func newFinalCovarSampAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalCovarSampAggregate) Result() (tree.Datum, error) {
+func (a *finalCovarSampAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.covarSampLastStage()
 }
 
@@ -2669,7 +2669,7 @@ func newRegressionAvgXAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionAvgXAggregate) Result() (tree.Datum, error) {
+func (a *regressionAvgXAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionAvgXLastStage()
 }
 
@@ -2690,7 +2690,7 @@ func newFinalRegressionAvgXAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegressionAvgXAggregate) Result() (tree.Datum, error) {
+func (a *finalRegressionAvgXAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionAvgXLastStage()
 }
 
@@ -2709,7 +2709,7 @@ func newRegressionAvgYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionAvgYAggregate) Result() (tree.Datum, error) {
+func (a *regressionAvgYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionAvgYLastStage()
 }
 
@@ -2730,7 +2730,7 @@ func newFinalRegressionAvgYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegressionAvgYAggregate) Result() (tree.Datum, error) {
+func (a *finalRegressionAvgYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionAvgYLastStage()
 }
 
@@ -2748,7 +2748,7 @@ func newRegressionInterceptAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionInterceptAggregate) Result() (tree.Datum, error) {
+func (a *regressionInterceptAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionInterceptLastStage()
 }
 
@@ -2768,7 +2768,7 @@ func newFinalRegressionInterceptAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegressionInterceptAggregate) Result() (tree.Datum, error) {
+func (a *finalRegressionInterceptAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionInterceptLastStage()
 }
 
@@ -2786,7 +2786,7 @@ func newRegressionR2Aggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionR2Aggregate) Result() (tree.Datum, error) {
+func (a *regressionR2Aggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionR2LastStage()
 }
 
@@ -2806,7 +2806,7 @@ func newFinalRegressionR2Aggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegressionR2Aggregate) Result() (tree.Datum, error) {
+func (a *finalRegressionR2Aggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionR2LastStage()
 }
 
@@ -2825,7 +2825,7 @@ func newRegressionSlopeAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionSlopeAggregate) Result() (tree.Datum, error) {
+func (a *regressionSlopeAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionSlopeLastStage()
 }
 
@@ -2846,7 +2846,7 @@ func newFinalRegressionSlopeAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *finalRegressionSlopeAggregate) Result() (tree.Datum, error) {
+func (a *finalRegressionSlopeAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regressionSlopeLastStage()
 }
 
@@ -2864,7 +2864,7 @@ func newRegressionSXXAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionSXXAggregate) Result() (tree.Datum, error) {
+func (a *regressionSXXAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSXXLastStage()
 }
 
@@ -2883,7 +2883,7 @@ func newRegressionSXYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionSXYAggregate) Result() (tree.Datum, error) {
+func (a *regressionSXYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSXYLastStage()
 }
 
@@ -2901,7 +2901,7 @@ func newRegressionSYYAggregate(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionSYYAggregate) Result() (tree.Datum, error) {
+func (a *regressionSYYAggregate) Result(context.Context) (tree.Datum, error) {
 	return a.regrSYYLastStage()
 }
 
@@ -2932,7 +2932,7 @@ func (a *regressionCountAggregate) Add(
 }
 
 // Result implements eval.AggregateFunc interface.
-func (a *regressionCountAggregate) Result() (tree.Datum, error) {
+func (a *regressionCountAggregate) Result(context.Context) (tree.Datum, error) {
 	return tree.NewDInt(tree.DInt(a.count)), nil
 }
 
@@ -2965,7 +2965,7 @@ func (a *countAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Datu
 	return nil
 }
 
-func (a *countAggregate) Result() (tree.Datum, error) {
+func (a *countAggregate) Result(context.Context) (tree.Datum, error) {
 	return tree.NewDInt(tree.DInt(a.count)), nil
 }
 
@@ -2995,7 +2995,7 @@ func (a *countRowsAggregate) Add(_ context.Context, _ tree.Datum, _ ...tree.Datu
 	return nil
 }
 
-func (a *countRowsAggregate) Result() (tree.Datum, error) {
+func (a *countRowsAggregate) Result(context.Context) (tree.Datum, error) {
 	return tree.NewDInt(tree.DInt(a.count)), nil
 }
 
@@ -3062,7 +3062,7 @@ func (a *maxAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datu
 }
 
 // Result returns the largest value passed to Add.
-func (a *maxAggregate) Result() (tree.Datum, error) {
+func (a *maxAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.max == nil {
 		return tree.DNull, nil
 	}
@@ -3135,7 +3135,7 @@ func (a *minAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datu
 }
 
 // Result returns the smallest value passed to Add.
-func (a *minAggregate) Result() (tree.Datum, error) {
+func (a *minAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.min == nil {
 		return tree.DNull, nil
 	}
@@ -3183,7 +3183,7 @@ func (a *smallIntSumAggregate) Add(_ context.Context, datum tree.Datum, _ ...tre
 }
 
 // Result returns the sum.
-func (a *smallIntSumAggregate) Result() (tree.Datum, error) {
+func (a *smallIntSumAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.seenNonNull {
 		return tree.DNull, nil
 	}
@@ -3261,7 +3261,7 @@ func (a *intSumAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.D
 }
 
 // Result returns the sum.
-func (a *intSumAggregate) Result() (tree.Datum, error) {
+func (a *intSumAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.seenNonNull {
 		return tree.DNull, nil
 	}
@@ -3326,7 +3326,7 @@ func (a *decimalSumAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tr
 }
 
 // Result returns the sum.
-func (a *decimalSumAggregate) Result() (tree.Datum, error) {
+func (a *decimalSumAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -3373,7 +3373,7 @@ func (a *floatSumAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.D
 }
 
 // Result returns the sum.
-func (a *floatSumAggregate) Result() (tree.Datum, error) {
+func (a *floatSumAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -3415,7 +3415,7 @@ func (a *intervalSumAggregate) Add(_ context.Context, datum tree.Datum, _ ...tre
 }
 
 // Result returns the sum.
-func (a *intervalSumAggregate) Result() (tree.Datum, error) {
+func (a *intervalSumAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -3482,8 +3482,8 @@ func (a *intSqrDiffAggregate) intermediateResult() (tree.Datum, error) {
 	return a.agg.intermediateResult()
 }
 
-func (a *intSqrDiffAggregate) Result() (tree.Datum, error) {
-	return a.agg.Result()
+func (a *intSqrDiffAggregate) Result(ctx context.Context) (tree.Datum, error) {
+	return a.agg.Result(ctx)
 }
 
 // Reset implements eval.AggregateFunc interface.
@@ -3543,7 +3543,7 @@ func (a *floatSqrDiffAggregate) Add(_ context.Context, datum tree.Datum, _ ...tr
 	return nil
 }
 
-func (a *floatSqrDiffAggregate) Result() (tree.Datum, error) {
+func (a *floatSqrDiffAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.count < 1 {
 		return tree.DNull, nil
 	}
@@ -3647,7 +3647,7 @@ func (a *decimalSqrDiffAggregate) intermediateResult() (tree.Datum, error) {
 	return dd, nil
 }
 
-func (a *decimalSqrDiffAggregate) Result() (tree.Datum, error) {
+func (a *decimalSqrDiffAggregate) Result(context.Context) (tree.Datum, error) {
 	res, err := a.intermediateResult()
 	if err != nil || res == tree.DNull {
 		return res, err
@@ -3757,7 +3757,7 @@ func (a *floatSumSqrDiffsAggregate) Add(
 	return nil
 }
 
-func (a *floatSumSqrDiffsAggregate) Result() (tree.Datum, error) {
+func (a *floatSumSqrDiffsAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.count < 1 {
 		return tree.DNull, nil
 	}
@@ -3880,7 +3880,7 @@ func (a *decimalSumSqrDiffsAggregate) intermediateResult() (tree.Datum, error) {
 	return dd, nil
 }
 
-func (a *decimalSumSqrDiffsAggregate) Result() (tree.Datum, error) {
+func (a *decimalSumSqrDiffsAggregate) Result(context.Context) (tree.Datum, error) {
 	res, err := a.intermediateResult()
 	if err != nil || res == tree.DNull {
 		return res, err
@@ -3993,11 +3993,11 @@ func (a *decimalVarianceAggregate) Add(
 }
 
 // Result calculates the variance from the member square difference aggregator.
-func (a *floatVarianceAggregate) Result() (tree.Datum, error) {
+func (a *floatVarianceAggregate) Result(ctx context.Context) (tree.Datum, error) {
 	if a.agg.Count() < 2 {
 		return tree.DNull, nil
 	}
-	sqrDiff, err := a.agg.Result()
+	sqrDiff, err := a.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4005,7 +4005,7 @@ func (a *floatVarianceAggregate) Result() (tree.Datum, error) {
 }
 
 // Result calculates the variance from the member square difference aggregator.
-func (a *decimalVarianceAggregate) Result() (tree.Datum, error) {
+func (a *decimalVarianceAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.agg.Count().Cmp(decimalTwo) < 0 {
 		return tree.DNull, nil
 	}
@@ -4109,11 +4109,11 @@ func (a *decimalVarPopAggregate) Add(
 }
 
 // Result calculates the population variance from the member square difference aggregator.
-func (a *floatVarPopAggregate) Result() (tree.Datum, error) {
+func (a *floatVarPopAggregate) Result(ctx context.Context) (tree.Datum, error) {
 	if a.agg.Count() < 1 {
 		return tree.DNull, nil
 	}
-	sqrDiff, err := a.agg.Result()
+	sqrDiff, err := a.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4121,7 +4121,7 @@ func (a *floatVarPopAggregate) Result() (tree.Datum, error) {
 }
 
 // Result calculates the population variance from the member square difference aggregator.
-func (a *decimalVarPopAggregate) Result() (tree.Datum, error) {
+func (a *decimalVarPopAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.agg.Count().Cmp(decimalOne) < 0 {
 		return tree.DNull, nil
 	}
@@ -4268,8 +4268,8 @@ func (a *decimalStdDevAggregate) Add(
 }
 
 // Result computes the square root of the variance aggregator.
-func (a *floatStdDevAggregate) Result() (tree.Datum, error) {
-	variance, err := a.agg.Result()
+func (a *floatStdDevAggregate) Result(ctx context.Context) (tree.Datum, error) {
+	variance, err := a.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4280,14 +4280,14 @@ func (a *floatStdDevAggregate) Result() (tree.Datum, error) {
 }
 
 // Result computes the square root of the variance aggregator.
-func (a *decimalStdDevAggregate) Result() (tree.Datum, error) {
+func (a *decimalStdDevAggregate) Result(ctx context.Context) (tree.Datum, error) {
 	// TODO(richardwu): both decimalVarianceAggregate and
 	// finalDecimalVarianceAggregate return a decimal result with
 	// default tree.DecimalCtx precision. We want to be able to specify that the
 	// varianceAggregate use tree.IntermediateCtx (with the extra precision)
 	// since it is returning an intermediate value for stdDevAggregate (of
 	// which we take the Sqrt).
-	variance, err := a.agg.Result()
+	variance, err := a.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4365,7 +4365,7 @@ func (a *bytesXorAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree
 }
 
 // Result returns the xor.
-func (a *bytesXorAggregate) Result() (tree.Datum, error) {
+func (a *bytesXorAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -4410,7 +4410,7 @@ func (a *intXorAggregate) Add(_ context.Context, datum tree.Datum, _ ...tree.Dat
 }
 
 // Result returns the xor.
-func (a *intXorAggregate) Result() (tree.Datum, error) {
+func (a *intXorAggregate) Result(context.Context) (tree.Datum, error) {
 	if !a.sawNonNull {
 		return tree.DNull, nil
 	}
@@ -4467,7 +4467,7 @@ func (a *jsonAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Dat
 }
 
 // Result returns an DJSON from the array of JSON.
-func (a *jsonAggregate) Result() (tree.Datum, error) {
+func (a *jsonAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.sawNonNull {
 		return tree.NewDJSON(a.builder.Build()), nil
 	}
@@ -4574,7 +4574,7 @@ func (a *percentileDiscAggregate) Add(
 }
 
 // Result finds the discrete percentile.
-func (a *percentileDiscAggregate) Result() (tree.Datum, error) {
+func (a *percentileDiscAggregate) Result(context.Context) (tree.Datum, error) {
 	// Return null if there are no values.
 	if a.arr.Len() == 0 {
 		return tree.DNull, nil
@@ -4672,7 +4672,7 @@ func (a *percentileContAggregate) Add(
 }
 
 // Result finds the continuous percentile.
-func (a *percentileContAggregate) Result() (tree.Datum, error) {
+func (a *percentileContAggregate) Result(context.Context) (tree.Datum, error) {
 	// Return null if there are no values.
 	if a.arr.Len() == 0 {
 		return tree.DNull, nil
@@ -4811,7 +4811,7 @@ func (a *jsonObjectAggregate) Add(
 }
 
 // Result returns a DJSON from the array of JSON.
-func (a *jsonObjectAggregate) Result() (tree.Datum, error) {
+func (a *jsonObjectAggregate) Result(context.Context) (tree.Datum, error) {
 	if a.sawNonNull {
 		return tree.NewDJSON(a.builder.Build()), nil
 	}
@@ -56,7 +56,7 @@ func testAggregateResultDeepCopy(
 		if err := aggImpl.Add(context.Background(), firstArgs[i], otherArgs[i]...); err != nil {
 			t.Fatal(err)
 		}
-		res, err := aggImpl.Result()
+		res, err := aggImpl.Result(context.Background())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -593,7 +593,7 @@ func runBenchmarkAggregate(
 					b.Fatal(err)
 				}
 			}
-			res, err := aggImpl.Result()
+			res, err := aggImpl.Result(context.Background())
 			if err != nil || res == nil {
 				b.Errorf("taking result of aggregate implementation %T failed", aggImpl)
 			}
@@ -264,14 +264,20 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToLower(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their lower-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToLower(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their lower-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(
+			func(r *tree.DRange) tree.Datum { return r.Lower },
+			"Returns the lower bound of the range, or NULL if the range is empty or "+
+				"the lower bound is infinite.",
+		)...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -295,14 +301,20 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToUpper(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their to their upper-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToUpper(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their to their upper-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(
+			func(r *tree.DRange) tree.Datum { return r.Upper },
+			"Returns the upper bound of the range, or NULL if the range is empty or "+
+				"the upper bound is infinite.",
+		)...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -2352,6 +2364,29 @@ var regularBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// grouping is replaced by the optimizer with the grouping set bit mask of
+	// each output row, so it is never evaluated.
+	"grouping": makeBuiltin(
+		tree.FunctionProperties{
+			Category: builtinconstants.CategoryComparison,
+		},
+		tree.Overload{
+			Types: tree.VariadicType{
+				VarType: types.Any,
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return nil, pgerror.New(pgcode.Grouping,
+					"GROUPING must be used in a query with a GROUP BY clause")
+			},
+			Info: "Returns a bit mask of the arguments which are not included in the " +
+				"current grouping set, where the last argument is the least significant " +
+				"bit. The arguments must be grouping expressions of the enclosing query.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"least": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategoryComparison,
@@ -3547,9 +3582,7 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the length of `input` on the provided `array_dimension`. However, " +
-				"because CockroachDB doesn't yet support multi-dimensional arrays, the only supported" +
-				" `array_dimension` is **1**.",
+			Info:       "Calculates the length of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3576,9 +3609,7 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLower(arr, dimen), nil
 			},
-			Info: "Calculates the minimum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the minimum value of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3592,9 +3623,45 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the maximum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the maximum value of `input` on the provided `array_dimension`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"array_ndims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				dims := arr.Dimensions()
+				if dims == nil {
+					return tree.DNull, nil
+				}
+				return tree.NewDInt(tree.DInt(len(dims))), nil
+			},
+			Info:       "Returns the number of dimensions of `input`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"array_dims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				dims := arr.Dimensions()
+				if dims == nil {
+					return tree.DNull, nil
+				}
+				var buf strings.Builder
+				for _, dim := range dims {
+					fmt.Fprintf(&buf, "[1:%d]", dim)
+				}
+				return tree.NewDString(buf.String()), nil
+			},
+			Info:       "Returns a text representation of the dimensions of `input`, such as `[1:2][1:3]`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3777,37 +3844,6 @@ value if you rely on the HLC for accuracy.`,
 		}
 	})),
 
-	// Full text search functions.
-	"ts_match_qv":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_match_vq":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_cmp":                   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"numnode":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"plainto_tsquery":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"phraseto_tsquery":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"querytree":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"setweight":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"strip":                          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"to_tsquery":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"to_tsvector":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"json_to_tsvector":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"jsonb_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_delete":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_filter":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank_cd":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rewrite":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsquery_phrase":                 makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_to_array":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger_column": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-
 	// Fuzzy String Matching
 	"soundex": makeBuiltin(
 		tree.FunctionProperties{Category: builtinconstants.CategoryFuzzyStringMatching},
@@ -6034,6 +6070,64 @@ value if you rely on the HLC for accuracy.`,
 		},
 	),
 
+	"crdb_internal.check_domain": makeBuiltin(
+		tree.FunctionProperties{
+			Category: builtinconstants.CategorySystemInfo,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "ok", Typ: types.Bool},
+				{Name: "domain", Typ: types.String},
+				{Name: "constraint", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if tree.MustBeDBool(args[0]) {
+					return tree.DBoolTrue, nil
+				}
+				domain := string(tree.MustBeDString(args[1]))
+				constraint := string(tree.MustBeDString(args[2]))
+				if constraint == "" {
+					return nil, pgerror.Newf(pgcode.NotNullViolation,
+						"domain %s does not allow null values", domain)
+				}
+				return nil, pgerror.Newf(pgcode.CheckViolation,
+					"value for domain %s violates check constraint %q", domain, constraint)
+			},
+			Info: "This function is used internally to enforce the constraints of a domain. " +
+				"It returns an error if ok is false, naming the violated constraint, or the " +
+				"NOT NULL constraint of the domain if constraint is empty.",
+			// The function is volatile so that the optimizer doesn't fold or
+			// hoist it out of the conditional expressions that guard it, which
+			// would raise errors for values that are never checked.
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.check_row_level_security": makeBuiltin(
+		tree.FunctionProperties{
+			Category: builtinconstants.CategorySystemInfo,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "ok", Typ: types.Bool},
+				{Name: "table", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if tree.MustBeDBool(args[0]) {
+					return tree.DBoolTrue, nil
+				}
+				return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+					"new row violates row-level security policy for table %q",
+					string(tree.MustBeDString(args[1])))
+			},
+			Info: "This function is used internally to enforce the row-level security " +
+				"policies of a table. It returns an error if ok is false.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	"crdb_internal.round_decimal_values": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
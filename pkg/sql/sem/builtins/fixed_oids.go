@@ -2044,6 +2044,115 @@ var builtinOidsArray = []string{
 	2067: `crdb_internal.gen_rand_ident(name_pattern: string, count: int) -> string`,
 	2068: `crdb_internal.gen_rand_ident(name_pattern: string, count: int, parameters: jsonb) -> string`,
 	2069: `crdb_internal.create_tenant(parameters: jsonb) -> int`,
+	2070: `grouping(anyelement...) -> int`,
+	2071: `crdb_internal.check_domain(ok: bool, domain: string, constraint: string) -> bool`,
+	2072: `to_tsvector(config: string, document: string) -> tsvector`,
+	2073: `to_tsvector(document: string) -> tsvector`,
+	2074: `to_tsquery(config: string, query: string) -> tsquery`,
+	2075: `to_tsquery(query: string) -> tsquery`,
+	2076: `plainto_tsquery(config: string, query: string) -> tsquery`,
+	2077: `plainto_tsquery(query: string) -> tsquery`,
+	2078: `phraseto_tsquery(config: string, query: string) -> tsquery`,
+	2079: `phraseto_tsquery(query: string) -> tsquery`,
+	2080: `websearch_to_tsquery(config: string, query: string) -> tsquery`,
+	2081: `websearch_to_tsquery(query: string) -> tsquery`,
+	2082: `ts_rank(weights: float[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2083: `ts_rank(weights: float[], vector: tsvector, query: tsquery) -> float4`,
+	2084: `ts_rank(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2085: `ts_rank(vector: tsvector, query: tsquery) -> float4`,
+	2086: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2087: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery) -> float4`,
+	2088: `ts_rank_cd(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2089: `ts_rank_cd(vector: tsvector, query: tsquery) -> float4`,
+	2090: `ts_headline(config: string, document: string, query: tsquery, options: string) -> string`,
+	2091: `ts_headline(config: string, document: string, query: tsquery) -> string`,
+	2092: `ts_headline(document: string, query: tsquery, options: string) -> string`,
+	2093: `ts_headline(document: string, query: tsquery) -> string`,
+	2094: `setweight(vector: tsvector, weight: string) -> tsvector`,
+	2095: `setweight(vector: tsvector, weight: string, lexemes: string[]) -> tsvector`,
+	2096: `strip(vector: tsvector) -> tsvector`,
+	2097: `ts_delete(vector: tsvector, lexeme: string) -> tsvector`,
+	2098: `ts_delete(vector: tsvector, lexemes: string[]) -> tsvector`,
+	2099: `ts_filter(vector: tsvector, weights: string[]) -> tsvector`,
+	2100: `tsvector_concat(left: tsvector, right: tsvector) -> tsvector`,
+	2101: `tsvector_to_array(vector: tsvector) -> string[]`,
+	2102: `array_to_tsvector(lexemes: string[]) -> tsvector`,
+	2103: `numnode(query: tsquery) -> int`,
+	2104: `querytree(query: tsquery) -> string`,
+	2105: `tsquery_phrase(left: tsquery, right: tsquery) -> tsquery`,
+	2106: `tsquery_phrase(left: tsquery, right: tsquery, distance: int) -> tsquery`,
+	2107: `ts_match_vq(vector: tsvector, query: tsquery) -> bool`,
+	2108: `ts_match_qv(query: tsquery, vector: tsvector) -> bool`,
+	2109: `ts_lexize(dictionary: string, token: string) -> string[]`,
+	2110: `get_current_ts_config() -> regconfig`,
+	2111: `int4rangesend(int4range: int4range) -> bytes`,
+	2112: `int4rangerecv(input: anyelement) -> int4range`,
+	2113: `int4rangeout(int4range: int4range) -> bytes`,
+	2114: `int4rangein(input: anyelement) -> int4range`,
+	2115: `int8rangesend(int8range: int8range) -> bytes`,
+	2116: `int8rangerecv(input: anyelement) -> int8range`,
+	2117: `int8rangeout(int8range: int8range) -> bytes`,
+	2118: `int8rangein(input: anyelement) -> int8range`,
+	2119: `daterangesend(daterange: daterange) -> bytes`,
+	2120: `daterangerecv(input: anyelement) -> daterange`,
+	2121: `daterangeout(daterange: daterange) -> bytes`,
+	2122: `daterangein(input: anyelement) -> daterange`,
+	2123: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2124: `tsrangerecv(input: anyelement) -> tsrange`,
+	2125: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2126: `tsrangein(input: anyelement) -> tsrange`,
+	2127: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2128: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2129: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2130: `tstzrangein(input: anyelement) -> tstzrange`,
+	2131: `int4range(lower: int4, upper: int4) -> int4range`,
+	2132: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2133: `int8range(lower: int, upper: int) -> int8range`,
+	2134: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2135: `daterange(lower: date, upper: date) -> daterange`,
+	2136: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2137: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2138: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2139: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2140: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2141: `lower(range: int4range) -> int4`,
+	2142: `lower(range: int8range) -> int`,
+	2143: `lower(range: daterange) -> date`,
+	2144: `lower(range: tsrange) -> timestamp`,
+	2145: `lower(range: tstzrange) -> timestamptz`,
+	2146: `upper(range: int4range) -> int4`,
+	2147: `upper(range: int8range) -> int`,
+	2148: `upper(range: daterange) -> date`,
+	2149: `upper(range: tsrange) -> timestamp`,
+	2150: `upper(range: tstzrange) -> timestamptz`,
+	2151: `isempty(range: anyrange) -> bool`,
+	2152: `lower_inc(range: anyrange) -> bool`,
+	2153: `upper_inc(range: anyrange) -> bool`,
+	2154: `lower_inf(range: anyrange) -> bool`,
+	2155: `upper_inf(range: anyrange) -> bool`,
+	2156: `range_merge(left: int4range, right: int4range) -> int4range`,
+	2157: `range_merge(left: int8range, right: int8range) -> int8range`,
+	2158: `range_merge(left: daterange, right: daterange) -> daterange`,
+	2159: `range_merge(left: tsrange, right: tsrange) -> tsrange`,
+	2160: `range_merge(left: tstzrange, right: tstzrange) -> tstzrange`,
+	2161: `array_ndims(input: anyelement[]) -> int`,
+	2162: `array_dims(input: anyelement[]) -> string`,
+	2163: `pg_notify(channel: string, payload: string) -> void`,
+	2164: `pg_listening_channels() -> string`,
+	2165: `crdb_internal.check_row_level_security(ok: bool, table: string) -> bool`,
+	2166: `crdb_internal.start_replication_stream_for_publication(publication_name: string) -> bytes`,
+	2167: `regconfigsend(regconfig: regconfig) -> bytes`,
+	2168: `regconfigrecv(input: anyelement) -> regconfig`,
+	2169: `regconfigout(regconfig: regconfig) -> bytes`,
+	2170: `regconfigin(input: anyelement) -> regconfig`,
+	2171: `crdb_internal.create_regconfig(oid: oid, name: string) -> regconfig`,
+	2172: `to_tsvector(config: regconfig, document: string) -> tsvector`,
+	2173: `to_tsquery(config: regconfig, query: string) -> tsquery`,
+	2174: `plainto_tsquery(config: regconfig, query: string) -> tsquery`,
+	2175: `phraseto_tsquery(config: regconfig, query: string) -> tsquery`,
+	2176: `websearch_to_tsquery(config: regconfig, query: string) -> tsquery`,
+	2177: `ts_headline(config: regconfig, document: string, query: tsquery, options: string) -> string`,
+	2178: `ts_headline(config: regconfig, document: string, query: tsquery) -> string`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
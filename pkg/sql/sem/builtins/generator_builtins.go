@@ -239,6 +239,22 @@ var generators = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Class:            tree.GeneratorClass,
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Returns the names of the channels the current session is listening on.",
+			volatility.Volatile,
+		),
+	),
 	`pg_options_to_table`: makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -1074,6 +1090,18 @@ func makeArrayGenerator(
 	return &arrayValueGenerator{array: arr}, nil
 }
 
+func makeListeningChannelsGenerator(
+	_ context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	arr := tree.NewDArray(types.String)
+	for _, channel := range evalCtx.SessionAccessor.ListeningChannels() {
+		if err := arr.Append(tree.NewDString(channel)); err != nil {
+			return nil, err
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
 // arrayValueGenerator is a value generator that returns each element of an
 // array.
 type arrayValueGenerator struct {
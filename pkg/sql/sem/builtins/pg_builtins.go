@@ -148,6 +148,9 @@ func init() {
 		registerBuiltin("crdb_internal.create_"+typName, makeCreateRegDef(b.typ))
 		registerBuiltin("to_"+typName, makeToRegOverload(b.typ, b.toRegOverloadHelpText))
 	}
+	// Postgres has no to_regconfig, but regconfig values still need to be
+	// serialized with their names.
+	registerBuiltin("crdb_internal.create_regconfig", makeCreateRegDef(types.RegConfig))
 
 }
 
@@ -1755,6 +1758,30 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// pg_notify sends an asynchronous notification, like the NOTIFY statement.
+	// https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				channel := string(tree.MustBeDString(args[0]))
+				payload := string(tree.MustBeDString(args[1]))
+				if err := evalCtx.SessionAccessor.QueueNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload to the sessions listening " +
+				"on the given channel, when the current transaction commits.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	// inet_{client,server}_{addr,port} return either an INet address or integer
 	// port that corresponds to either the client or server side of the current
 	// session's connection.
@@ -0,0 +1,171 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+	for _, typ := range types.Ranges {
+		registerBuiltin(typ.Name(), makeRangeConstructorBuiltin(typ))
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Empty },
+		"Returns true if the range is empty.",
+	),
+	"lower_inc": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.LowerInc },
+		"Returns true if the lower bound of the range is inclusive.",
+	),
+	"upper_inc": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.UpperInc },
+		"Returns true if the upper bound of the range is inclusive.",
+	),
+	"lower_inf": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return !r.Empty && r.Lower == nil },
+		"Returns true if the lower bound of the range is infinite.",
+	),
+	"upper_inf": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return !r.Empty && r.Upper == nil },
+		"Returns true if the upper bound of the range is infinite.",
+	),
+	"range_merge": makeRangeMergeBuiltin(),
+}
+
+// makeRangeConstructorBuiltin returns the builtin which constructs a range of
+// the given range type from its bounds. A NULL bound is infinite.
+func makeRangeConstructorBuiltin(typ *types.T) builtinDefinition {
+	elemTyp := typ.RangeContents()
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		if len(bounds) != 2 ||
+			(bounds[0] != '[' && bounds[0] != '(') ||
+			(bounds[1] != ']' && bounds[1] != ')') {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		return tree.NewDRange(typ, args[0], args[1], bounds[0] == '[', bounds[1] == ']')
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{
+			Category:                builtinconstants.CategoryRange,
+			AvailableOnPublicSchema: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "lower", Typ: elemTyp}, {Name: "upper", Typ: elemTyp}},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			Info: "Constructs a range from the given bounds, which are inclusive and " +
+				"exclusive respectively. A NULL bound is infinite.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elemTyp},
+				{Name: "upper", Typ: elemTyp},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException,
+						"range constructor flags argument must not be null")
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			Info: "Constructs a range from the given bounds. The bounds argument " +
+				"specifies whether the bounds are inclusive, and is one of `[]`, `[)`, " +
+				"`(]` or `()`. A NULL bound is infinite.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	)
+}
+
+// makeRangeBoundOverloads returns the overloads of the lower and upper
+// builtins, which return a bound of a range, or NULL if the bound is infinite
+// or the range is empty.
+func makeRangeBoundOverloads(bound func(r *tree.DRange) tree.Datum, info string) []tree.Overload {
+	overloads := make([]tree.Overload, len(types.Ranges))
+	for i, typ := range types.Ranges {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: typ}},
+			ReturnType: tree.FixedReturnType(typ.RangeContents()),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// The bounds of an empty range are nil.
+				if d := bound(tree.MustBeDRange(args[0])); d != nil {
+					return d, nil
+				}
+				return tree.DNull, nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeRangePredicateBuiltin returns a builtin which tests a property of a
+// range of any range type.
+func makeRangePredicateBuiltin(pred func(r *tree.DRange) bool, info string) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(pred(tree.MustBeDRange(args[0])))), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeRangeMergeBuiltin returns the range_merge builtin, which returns the
+// smallest range which includes both of the given ranges.
+func makeRangeMergeBuiltin() builtinDefinition {
+	overloads := make([]tree.Overload, len(types.Ranges))
+	for i, typ := range types.Ranges {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: typ}, {Name: "right", Typ: typ}},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MustBeDRange(args[0]).Merge(tree.MustBeDRange(args[1])), nil
+			},
+			Info:       "Returns the smallest range which includes both of the given ranges.",
+			Volatility: volatility.Immutable,
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
@@ -151,6 +151,40 @@ var replicationBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"crdb_internal.start_replication_stream_for_publication": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryStreamIngestion,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "publication_name", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Bytes),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				mgr, err := evalCtx.StreamManagerFactory.GetReplicationStreamManager(ctx)
+				if err != nil {
+					return nil, err
+				}
+				publication := string(tree.MustBeDString(args[0]))
+				replicationProducerSpec, err := mgr.StartReplicationStreamForPublication(ctx, publication)
+				if err != nil {
+					return nil, err
+				}
+				rawReplicationProducerSpec, err := protoutil.Marshal(&replicationProducerSpec)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDBytes(tree.DBytes(rawReplicationProducerSpec)), err
+			},
+			Info: "This function can be used on the producer side to start a replication stream for " +
+				"the tables of the specified publication of the current database. The returned stream " +
+				"ID uniquely identifies created stream. The caller must periodically invoke " +
+				"crdb_internal.heartbeat_stream() function to notify that the replication is still ongoing.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	"crdb_internal.replication_stream_progress": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         builtinconstants.CategoryStreamIngestion,
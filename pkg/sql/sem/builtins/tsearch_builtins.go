@@ -0,0 +1,646 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func init() {
+	for k, v := range tsearchBuiltins {
+		v.props.Category = builtinconstants.CategoryFullTextSearch
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+}
+
+var tsearchBuiltins = map[string]builtinDefinition{
+	// Parsing functions.
+	"to_tsvector": makeTSConfigBuiltin(
+		"document", types.TSVector,
+		func(config, input string) (tree.Datum, error) {
+			v, err := tsearch.DocumentToTSVector(config, input)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSVector(v), nil
+		},
+		"Converts the input document into a tsvector, normalizing its words into "+
+			"lexemes according to the text search configuration.",
+	),
+	"to_tsquery": makeTSConfigBuiltin(
+		"query", types.TSQuery,
+		func(config, input string) (tree.Datum, error) {
+			q, err := tsearch.ToTSQuery(config, input)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts the input text, which must consist of lexemes separated by "+
+			"tsquery operators, into a tsquery, normalizing the lexemes according to "+
+			"the text search configuration.",
+	),
+	"plainto_tsquery": makeTSConfigBuiltin(
+		"query", types.TSQuery,
+		func(config, input string) (tree.Datum, error) {
+			q, err := tsearch.PlainToTSQuery(config, input)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts the input text into a tsquery that matches all of its words, "+
+			"normalizing them according to the text search configuration. "+
+			"Punctuation in the input is ignored.",
+	),
+	"phraseto_tsquery": makeTSConfigBuiltin(
+		"query", types.TSQuery,
+		func(config, input string) (tree.Datum, error) {
+			q, err := tsearch.PhraseToTSQuery(config, input)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts the input text into a tsquery that matches its words as a "+
+			"phrase, normalizing them according to the text search configuration. "+
+			"Punctuation in the input is ignored.",
+	),
+	"websearch_to_tsquery": makeTSConfigBuiltin(
+		"query", types.TSQuery,
+		func(config, input string) (tree.Datum, error) {
+			q, err := tsearch.WebSearchToTSQuery(config, input)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts the input text into a tsquery using a syntax similar to the "+
+			"one used by web search engines: quoted text is matched as a phrase, "+
+			"`or` separates alternatives and `-` negates a word.",
+	),
+
+	// Ranking and highlighting functions.
+	"ts_rank": makeTSRankBuiltin(
+		tsearch.Rank,
+		"Ranks the vector by how well it matches the query, based on the "+
+			"frequency of its matching lexemes.",
+	),
+	"ts_rank_cd": makeTSRankBuiltin(
+		tsearch.RankCD,
+		"Ranks the vector by how well it matches the query, using the cover "+
+			"density method, which takes the proximity of matching lexemes into "+
+			"account.",
+	),
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{},
+		makeTSHeadlineOverload(types.RegConfig, true /* hasOptions */),
+		makeTSHeadlineOverload(types.RegConfig, false /* hasOptions */),
+		makeTSHeadlineOverload(types.String, true /* hasOptions */),
+		makeTSHeadlineOverload(types.String, false /* hasOptions */),
+		makeTSHeadlineOverload(nil /* configType */, true /* hasOptions */),
+		makeTSHeadlineOverload(nil /* configType */, false /* hasOptions */),
+	),
+
+	// Manipulation functions.
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "weight", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				ret, err := v.SetWeight(string(tree.MustBeDString(args[1])), nil /* lexemes */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Assigns the given weight to each position of the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weight", Typ: types.String},
+				{Name: "lexemes", Typ: types.StringArray},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				lexemes, err := tsLexemeArray(args[2])
+				if err != nil {
+					return nil, err
+				}
+				ret, err := v.SetWeight(string(tree.MustBeDString(args[1])), lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Assigns the given weight to each position of the given lexemes in the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strip": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				return tree.NewDTSVector(v.StripPositions()), nil
+			},
+			Info:       "Removes the positions and weights from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_delete": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "lexeme", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				return tree.NewDTSVector(v.Delete([]string{string(tree.MustBeDString(args[1]))})), nil
+			},
+			Info:       "Removes any occurrence of the given lexeme from the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "lexemes", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				lexemes, err := tsLexemeArray(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v.Delete(lexemes)), nil
+			},
+			Info:       "Removes any occurrence of the given lexemes from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_filter": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "weights", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				weights, ok := darrayToStringSlice(*tree.MustBeDArray(args[1]))
+				if !ok {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "weight array may not contain nulls")
+				}
+				ret, err := v.Filter(weights)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Selects only the positions of the vector that have one of the given weights.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_concat": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.TSVector}, {Name: "right", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSVector(args[0]), tree.MustBeDTSVector(args[1])
+				return tree.NewDTSVector(l.Concat(r.TSVector)), nil
+			},
+			Info: "Concatenates the two vectors. The positions of the right vector are " +
+				"shifted to follow the largest position of the left vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_to_array": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				lexemes := v.Lexemes()
+				ret := tree.NewDArray(types.String)
+				ret.Array = make(tree.Datums, 0, len(lexemes))
+				for _, l := range lexemes {
+					if err := ret.Append(tree.NewDString(l)); err != nil {
+						return nil, err
+					}
+				}
+				return ret, nil
+			},
+			Info:       "Returns an array of the lexemes in the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"array_to_tsvector": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "lexemes", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := tsLexemeArray(args[0])
+				if err != nil {
+					return nil, err
+				}
+				v, err := tsearch.TSVectorFromLexemes(lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(v), nil
+			},
+			Info:       "Converts an array of lexemes into a vector without positions.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"numnode": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q := tree.MustBeDTSQuery(args[0])
+				return tree.NewDInt(tree.DInt(q.NumNodes())), nil
+			},
+			Info:       "Returns the number of lexemes and operators in the query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"querytree": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q := tree.MustBeDTSQuery(args[0])
+				return tree.NewDString(q.QueryTree()), nil
+			},
+			Info: "Returns the portion of the query that can be used for searching an " +
+				"index, or `T` if the query can't be used for searching an index.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsquery_phrase": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.TSQuery}, {Name: "right", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSQuery(args[0]), tree.MustBeDTSQuery(args[1])
+				q, err := tsearch.TSQueryPhrase(l.TSQuery, r.TSQuery, 1 /* distance */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info:       "Returns a query that searches for the left query followed by the right query.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "left", Typ: types.TSQuery},
+				{Name: "right", Typ: types.TSQuery},
+				{Name: "distance", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSQuery(args[0]), tree.MustBeDTSQuery(args[1])
+				distance := int(tree.MustBeDInt(args[2]))
+				q, err := tsearch.TSQueryPhrase(l.TSQuery, r.TSQuery, distance)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSQuery(q), nil
+			},
+			Info: "Returns a query that searches for the left query followed by the " +
+				"right query at exactly the given distance.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_vq": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "query", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v, q := tree.MustBeDTSVector(args[0]), tree.MustBeDTSQuery(args[1])
+				ret, err := tsearch.EvalTSQuery(q.TSQuery, v.TSVector)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ret)), nil
+			},
+			Info:       "Returns whether the vector matches the query. Equivalent to `vector @@ query`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_qv": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}, {Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q, v := tree.MustBeDTSQuery(args[0]), tree.MustBeDTSVector(args[1])
+				ret, err := tsearch.EvalTSQuery(q.TSQuery, v.TSVector)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(ret)), nil
+			},
+			Info:       "Returns whether the vector matches the query. Equivalent to `query @@ vector`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	// Debugging and configuration functions.
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "dictionary", Typ: types.String}, {Name: "token", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := tsearch.TSLexize(
+					string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				ret := tree.NewDArray(types.String)
+				ret.Array = make(tree.Datums, 0, len(lexemes))
+				for _, l := range lexemes {
+					if err := ret.Append(tree.NewDString(l)); err != nil {
+						return nil, err
+					}
+				}
+				return ret, nil
+			},
+			Info: "Returns the lexemes that the text search dictionary produces for the " +
+				"token. The result is empty if the token is a stop word.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"get_current_ts_config": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.RegConfig),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				config := strings.TrimPrefix(getDefaultTSConfig(evalCtx), "pg_catalog.")
+				ret, _, err := evalCtx.Planner.ResolveOIDFromString(ctx, types.RegConfig, tree.NewDString(config))
+				return ret, err
+			},
+			Info:       "Returns the name of the current default text search configuration.",
+			Volatility: volatility.Stable,
+		},
+	),
+
+	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_rewrite":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_cmp":                   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_update_trigger":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_update_trigger_column": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"json_to_tsvector":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"jsonb_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+}
+
+// getDefaultTSConfig returns the text search configuration of the session,
+// which is used when a function isn't given a configuration explicitly.
+func getDefaultTSConfig(evalCtx *eval.Context) string {
+	if config := evalCtx.SessionData().DefaultTextSearchConfig; config != "" {
+		return config
+	}
+	return "pg_catalog.english"
+}
+
+// tsLexemeArray converts an array of lexemes into a slice of strings,
+// returning an error if any of them is NULL.
+func tsLexemeArray(d tree.Datum) ([]string, error) {
+	lexemes, ok := darrayToStringSlice(*tree.MustBeDArray(d))
+	if !ok {
+		return nil, pgerror.New(pgcode.NullValueNotAllowed, "lexeme array may not contain nulls")
+	}
+	return lexemes, nil
+}
+
+// tsConfigName returns the name of the text search configuration referenced
+// by the given regconfig.
+func tsConfigName(ctx context.Context, evalCtx *eval.Context, d tree.Datum) (string, error) {
+	config := tree.MustBeDOid(d)
+	if name := config.Name(); name != "" {
+		return name, nil
+	}
+	// The name isn't known when the regconfig was decoded from its OID, so
+	// look it up.
+	resolved, _, err := evalCtx.Planner.ResolveOIDFromOID(ctx, types.RegConfig, config)
+	if err != nil {
+		return "", err
+	}
+	return resolved.Name(), nil
+}
+
+// makeTSConfigBuiltin returns a builtin with overloads that apply the given
+// function to the input: ones that take an explicit text search configuration,
+// either as a regconfig or by name, and one that uses the session's default
+// configuration.
+func makeTSConfigBuiltin(
+	inputName string,
+	retType *types.T,
+	fn func(config, input string) (tree.Datum, error),
+	info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.RegConfig}, {Name: inputName, Typ: types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := tsConfigName(ctx, evalCtx, args[0])
+				if err != nil {
+					return nil, err
+				}
+				return fn(config, string(tree.MustBeDString(args[1])))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: inputName, Typ: types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+			// Prefer to resolve the configuration by name when its type isn't
+			// known, as it was before the regconfig overload was added.
+			PreferredOverload: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: inputName, Typ: types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(getDefaultTSConfig(evalCtx), string(tree.MustBeDString(args[0])))
+			},
+			Info:       info + " Uses the default_text_search_config session variable.",
+			Volatility: volatility.Stable,
+		},
+	)
+}
+
+// makeTSRankBuiltin returns a builtin for the given ranking function, with
+// overloads that optionally take an array of weights and a normalization
+// option.
+func makeTSRankBuiltin(
+	rank func(weights []float64, v tsearch.TSVector, q tsearch.TSQuery, method int) (float32, error),
+	info string,
+) builtinDefinition {
+	makeOverload := func(hasWeights, hasMethod bool) tree.Overload {
+		var params tree.ParamTypes
+		if hasWeights {
+			params = append(params, tree.ParamType{Name: "weights", Typ: types.FloatArray})
+		}
+		params = append(params,
+			tree.ParamType{Name: "vector", Typ: types.TSVector},
+			tree.ParamType{Name: "query", Typ: types.TSQuery},
+		)
+		if hasMethod {
+			params = append(params, tree.ParamType{Name: "normalization", Typ: types.Int})
+		}
+		overloadInfo := info
+		if hasWeights {
+			overloadInfo += " The weights array assigns a weight to each of the D, C, B and A " +
+				"lexeme weights, in that order."
+		}
+		if hasMethod {
+			overloadInfo += " The normalization option is a bit mask that controls how the " +
+				"length of the document affects the rank."
+		}
+		return tree.Overload{
+			Types:      params,
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				var weights []float64
+				if hasWeights {
+					arr := tree.MustBeDArray(args[0])
+					weights = make([]float64, len(arr.Array))
+					for i, d := range arr.Array {
+						if d == tree.DNull {
+							return nil, pgerror.New(pgcode.NullValueNotAllowed,
+								"array of weight must not contain nulls")
+						}
+						weights[i] = float64(tree.MustBeDFloat(d))
+					}
+					args = args[1:]
+				}
+				v, q := tree.MustBeDTSVector(args[0]), tree.MustBeDTSQuery(args[1])
+				var method int
+				if hasMethod {
+					method = int(tree.MustBeDInt(args[2]))
+				}
+				ret, err := rank(weights, v.TSVector, q.TSQuery, method)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(ret)), nil
+			},
+			Info:       overloadInfo,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		makeOverload(true /* hasWeights */, true /* hasMethod */),
+		makeOverload(true /* hasWeights */, false /* hasMethod */),
+		makeOverload(false /* hasWeights */, true /* hasMethod */),
+		makeOverload(false /* hasWeights */, false /* hasMethod */),
+	)
+}
+
+// makeTSHeadlineOverload returns an overload of ts_headline that optionally
+// takes a text search configuration of the given type, which is either
+// regconfig or a string, and a list of options.
+func makeTSHeadlineOverload(configType *types.T, hasOptions bool) tree.Overload {
+	hasConfig := configType != nil
+	var params tree.ParamTypes
+	if hasConfig {
+		params = append(params, tree.ParamType{Name: "config", Typ: configType})
+	}
+	params = append(params,
+		tree.ParamType{Name: "document", Typ: types.String},
+		tree.ParamType{Name: "query", Typ: types.TSQuery},
+	)
+	if hasOptions {
+		params = append(params, tree.ParamType{Name: "options", Typ: types.String})
+	}
+	info := "Returns an excerpt of the document in which the words that match the " +
+		"query are highlighted."
+	vol := volatility.Immutable
+	if !hasConfig {
+		info += " Uses the default_text_search_config session variable."
+		vol = volatility.Stable
+	}
+	if hasOptions {
+		info += " The options are a comma-separated list of option=value pairs, " +
+			"such as `StartSel`, `StopSel`, `MaxWords`, `MinWords`, `ShortWord` " +
+			"and `MaxFragments`."
+	}
+	return tree.Overload{
+		Types:      params,
+		ReturnType: tree.FixedReturnType(types.String),
+		Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			var config string
+			if configType == types.RegConfig {
+				var err error
+				if config, err = tsConfigName(ctx, evalCtx, args[0]); err != nil {
+					return nil, err
+				}
+				args = args[1:]
+			} else if hasConfig {
+				config = string(tree.MustBeDString(args[0]))
+				args = args[1:]
+			} else {
+				config = getDefaultTSConfig(evalCtx)
+			}
+			document := string(tree.MustBeDString(args[0]))
+			q := tree.MustBeDTSQuery(args[1])
+			var options string
+			if hasOptions {
+				options = string(tree.MustBeDString(args[2]))
+			}
+			ret, err := tsearch.Headline(config, document, q.TSQuery, options)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDString(ret), nil
+		},
+		Info:       info,
+		Volatility: vol,
+		// Prefer to resolve the configuration by name when its type isn't
+		// known, as it was before the regconfig overloads were added.
+		PreferredOverload: configType == types.String,
+	}
+}
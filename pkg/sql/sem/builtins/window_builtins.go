@@ -295,7 +295,7 @@ func (w *aggregateWindowFunc) Compute(
 	}
 
 	// Retrieve the value for the entire peer group, save it, and return it.
-	peerRes, err := w.agg.Result()
+	peerRes, err := w.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +333,17 @@ type framableAggregateWindowFunc struct {
 	shouldReset    bool
 }
 
+// NewFramableAggregateWindowFunc returns a constructor of window functions
+// which compute the aggregate created by aggConstructor over the window frame
+// of each row.
+func NewFramableAggregateWindowFunc(
+	aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
+) func(*eval.Context) eval.WindowFunc {
+	return func(evalCtx *eval.Context) eval.WindowFunc {
+		return newFramableAggregateWindow(aggConstructor(evalCtx, nil /* arguments */), aggConstructor)
+	}
+}
+
 func newFramableAggregateWindow(
 	agg eval.AggregateFunc, aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
 ) eval.WindowFunc {
@@ -419,7 +430,7 @@ func (w *framableAggregateWindowFunc) Compute(
 	}
 
 	// Retrieve the value for the entire peer group, save it, and return it.
-	peerRes, err := w.agg.agg.Result()
+	peerRes, err := w.agg.agg.Result(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -296,7 +296,7 @@ func (w *slidingWindowSumFunc) Compute(
 				return nil, err
 			}
 		}
-		return w.agg.Result()
+		return w.agg.Result(ctx)
 	}
 
 	// We need to discard all values that are no longer in the frame.
@@ -336,7 +336,7 @@ func (w *slidingWindowSumFunc) Compute(
 		// so we return NULL as per spec.
 		return tree.DNull, nil
 	}
-	return w.agg.Result()
+	return w.agg.Result(ctx)
 }
 
 // Reset implements tree.WindowFunc interface.
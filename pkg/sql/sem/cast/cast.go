@@ -204,6 +204,35 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. Casts
+	// involving a domain are resolved as casts involving its base type. As in
+	// Postgres, a domain can be implicitly cast to its base type, and casts to
+	// a domain are allowed in assignment contexts at most.
+	if src.IsDomain() {
+		base := src.DomainBaseType()
+		if base.Identical(tgt) {
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		return LookupCast(base, tgt)
+	}
+	if tgt.IsDomain() {
+		base := tgt.DomainBaseType()
+		if src.Identical(base) {
+			return Cast{
+				MaxContext: ContextAssignment,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		c, ok := LookupCast(src, base)
+		if ok && c.MaxContext > ContextAssignment {
+			c.MaxContext = ContextAssignment
+		}
+		return c, ok
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
@@ -274,6 +303,23 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts between string and range types are stable because the range bounds
+	// may be dates or timestamps, which depend on the session when formatted
+	// or parsed. Casts to strings are allowed in assignment contexts, and casts
+	// from strings are allowed in explicit contexts.
+	if srcFamily == types.RangeFamily && tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if srcFamily == types.StringFamily && tgtFamily == types.RangeFamily {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
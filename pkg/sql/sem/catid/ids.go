@@ -78,6 +78,18 @@ type ConstraintID uint32
 // SafeValue implements the redact.SafeValue interface.
 func (ConstraintID) SafeValue() {}
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID uint32
+
+// SafeValue implements the redact.SafeValue interface.
+func (TriggerID) SafeValue() {}
+
+// PolicyID is a custom type for TableDescriptor policy IDs.
+type PolicyID uint32
+
+// SafeValue implements the redact.SafeValue interface.
+func (PolicyID) SafeValue() {}
+
 // PGAttributeNum is a custom type for Column's logical order.
 type PGAttributeNum uint32
 
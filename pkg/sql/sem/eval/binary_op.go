@@ -40,6 +40,12 @@ func BinaryOp(
 	return op.Eval(ctx, (*evaluator)(evalCtx), left, right)
 }
 
+func (e *evaluator) EvalAdjacentRangeOp(
+	ctx context.Context, _ *tree.AdjacentRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Adjacent(tree.MustBeDRange(right)))), nil
+}
+
 func (e *evaluator) EvalAppendToMaybeNullArrayOp(
 	ctx context.Context, op *tree.AppendToMaybeNullArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -303,6 +309,18 @@ func (e *evaluator) EvalContainedByJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainedByRangeElemOp(
+	ctx context.Context, _ *tree.ContainedByRangeElemOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).ContainsElem(a))), nil
+}
+
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).Contains(tree.MustBeDRange(a)))), nil
+}
+
 func (e *evaluator) EvalContainsArrayOp(
 	ctx context.Context, _ *tree.ContainsArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -321,6 +339,18 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeElemOp(
+	ctx context.Context, _ *tree.ContainsRangeElemOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).ContainsElem(b))), nil
+}
+
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).Contains(tree.MustBeDRange(b)))), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1200,6 +1230,12 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Overlaps(tree.MustBeDRange(right)))), nil
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
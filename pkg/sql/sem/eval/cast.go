@@ -64,6 +64,11 @@ func ReType(expr tree.TypedExpr, wantedType *types.T) (_ tree.TypedExpr, ok bool
 func PerformCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T,
 ) (tree.Datum, error) {
+	if t.IsDomain() {
+		// Values of a domain are represented as values of its base type. The
+		// constraints of the domain are enforced by the optimizer.
+		t = t.DomainBaseType()
+	}
 	ret, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, true /* truncateWidth */)
 	if err != nil {
 		return nil, err
@@ -88,6 +93,9 @@ func PerformAssignmentCast(
 			"invalid assignment cast: %s -> %s", d.ResolvedType(), t,
 		)
 	}
+	if t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, false /* truncateWidth */)
 	if err != nil {
 		return nil, err
@@ -444,7 +452,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtPgwireText,
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -877,6 +885,17 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return &tree.DTSVector{TSVector: vec}, nil
 		}
+	case types.RangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use range types",
+				clusterversion.ByKey(clusterversion.V23_1))
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		}
 	case types.ArrayFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -725,6 +725,11 @@ type ReplicationStreamManager interface {
 	// tenant on the producer side.
 	StartReplicationStream(ctx context.Context, tenantName roachpb.TenantName) (streampb.ReplicationProducerSpec, error)
 
+	// StartReplicationStreamForPublication starts a stream replication job for
+	// the tables of the specified publication of the current database on the
+	// producer side.
+	StartReplicationStreamForPublication(ctx context.Context, publication string) (streampb.ReplicationProducerSpec, error)
+
 	// HeartbeatReplicationStream sends a heartbeat to the replication stream producer, indicating
 	// consumer has consumed until the given 'frontier' timestamp. This updates the producer job
 	// progress and extends its life, and the new producer progress will be returned.
@@ -419,6 +419,15 @@ type SessionAccessor interface {
 	// HasRoleOption returns nil iff the current session user has the specified
 	// role option.
 	HasRoleOption(ctx context.Context, roleOption roleoption.Option) (bool, error)
+
+	// QueueNotification queues an asynchronous notification on the given
+	// channel, which is sent when the current transaction commits (as in
+	// NOTIFY).
+	QueueNotification(ctx context.Context, channel, payload string) error
+
+	// ListeningChannels returns the channels the session listens on, in sorted
+	// order.
+	ListeningChannels() []string
 }
 
 // PreparedStatementState is a limited interface that exposes metadata about
@@ -12,6 +12,7 @@ package eval
 
 import (
 	"context"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -291,8 +292,6 @@ func (e *evaluator) EvalIndexedVar(ctx context.Context, iv *tree.IndexedVar) (tr
 func (e *evaluator) EvalIndirectionExpr(
 	ctx context.Context, expr *tree.IndirectionExpr,
 ) (tree.Datum, error) {
-	var subscriptIdx int
-
 	d, err := expr.Expr.(tree.TypedExpr).Eval(ctx, e)
 	if err != nil {
 		return nil, err
@@ -303,32 +302,35 @@ func (e *evaluator) EvalIndirectionExpr(
 
 	switch d.ResolvedType().Family() {
 	case types.ArrayFamily:
-		for i, t := range expr.Indirection {
-			if t.Slice || i > 0 {
-				return nil, errors.AssertionFailedf("unsupported feature should have been rejected during planning")
+		for _, t := range expr.Indirection {
+			if t.Slice {
+				return e.evalArraySlice(ctx, tree.MustBeDArray(d), expr.Indirection)
 			}
+		}
 
+		// Index into the DArray, using 1-indexing. Each subscript selects an
+		// element from one dimension of the array.
+		for _, t := range expr.Indirection {
 			beginDatum, err := t.Begin.(tree.TypedExpr).Eval(ctx, e)
 			if err != nil {
 				return nil, err
 			}
-			if beginDatum == tree.DNull {
+			if beginDatum == tree.DNull || d == tree.DNull {
 				return tree.DNull, nil
 			}
-			subscriptIdx = int(tree.MustBeDInt(beginDatum))
-		}
-
-		// Index into the DArray, using 1-indexing.
-		arr := tree.MustBeDArray(d)
+			subscriptIdx := int(tree.MustBeDInt(beginDatum))
+			arr := tree.MustBeDArray(d)
 
-		// VECTOR types use 0-indexing.
-		if arr.FirstIndex() == 0 {
-			subscriptIdx++
-		}
-		if subscriptIdx < 1 || subscriptIdx > arr.Len() {
-			return tree.DNull, nil
+			// VECTOR types use 0-indexing.
+			if arr.FirstIndex() == 0 {
+				subscriptIdx++
+			}
+			if subscriptIdx < 1 || subscriptIdx > arr.Len() {
+				return tree.DNull, nil
+			}
+			d = arr.Array[subscriptIdx-1]
 		}
-		return arr.Array[subscriptIdx-1], nil
+		return d, nil
 	case types.JsonFamily:
 		j := tree.MustBeDJSON(d)
 		curr := j.JSON
@@ -365,6 +367,84 @@ func (e *evaluator) EvalIndirectionExpr(
 	return nil, errors.AssertionFailedf("unsupported feature should have been rejected during planning")
 }
 
+// evalArraySlice evaluates the given subscripts, at least one of which is a
+// slice, against the array. As in Postgres, a subscript that is not a slice
+// is treated as a slice from 1 to the subscript, omitted bounds are replaced
+// by the bounds of the array, and bounds outside of the array are clamped to
+// the array's bounds. Dimensions without a subscript are kept whole. If any
+// bound is NULL, the result is NULL. If any of the resulting dimensions is
+// empty, the result is an empty array.
+func (e *evaluator) evalArraySlice(
+	ctx context.Context, arr *tree.DArray, subscripts tree.ArraySubscripts,
+) (tree.Datum, error) {
+	evalBound := func(bound tree.Expr, dflt int) (_ int, isNull bool, _ error) {
+		if bound == nil {
+			return dflt, false, nil
+		}
+		d, err := bound.(tree.TypedExpr).Eval(ctx, e)
+		if err != nil {
+			return 0, false, err
+		}
+		if d == tree.DNull {
+			return 0, true, nil
+		}
+		return int(tree.MustBeDInt(d)), false, nil
+	}
+	bounds := make([][2]int, len(subscripts))
+	for i, t := range subscripts {
+		begin, end := t.Begin, t.End
+		if !t.Slice {
+			begin, end = nil, t.Begin
+		}
+		var isNull bool
+		var err error
+		if bounds[i][0], isNull, err = evalBound(begin, 1); err != nil || isNull {
+			return tree.DNull, err
+		}
+		if bounds[i][1], isNull, err = evalBound(end, math.MaxInt32); err != nil || isNull {
+			return tree.DNull, err
+		}
+	}
+	res, empty, err := sliceArray(arr, bounds)
+	if err != nil {
+		return nil, err
+	}
+	if empty {
+		return tree.NewDArray(arr.ParamTyp), nil
+	}
+	return res, nil
+}
+
+// sliceArray returns the slice of the array with the given inclusive,
+// 1-indexed bounds for each of its leading dimensions. empty is true if any
+// dimension of the result has no elements.
+func sliceArray(arr *tree.DArray, bounds [][2]int) (_ *tree.DArray, empty bool, _ error) {
+	lower, upper := bounds[0][0], bounds[0][1]
+	if lower < 1 {
+		lower = 1
+	}
+	if upper > arr.Len() {
+		upper = arr.Len()
+	}
+	if lower > upper {
+		return nil, true, nil
+	}
+	res := tree.NewDArray(arr.ParamTyp)
+	for _, elem := range arr.Array[lower-1 : upper] {
+		if len(bounds) > 1 && elem != tree.DNull {
+			inner, innerEmpty, err := sliceArray(tree.MustBeDArray(elem), bounds[1:])
+			if err != nil || innerEmpty {
+				return nil, innerEmpty, err
+			}
+			elem = inner
+		}
+		if err := res.Append(elem); err != nil {
+			return nil, false, err
+		}
+	}
+	return res, false, nil
+}
+
 func (e *evaluator) EvalDefaultVal(ctx context.Context, expr *tree.DefaultVal) (tree.Datum, error) {
 	return nil, errors.AssertionFailedf("unhandled type %T", expr)
 }
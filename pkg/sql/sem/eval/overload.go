@@ -37,7 +37,7 @@ type AggregateFunc interface {
 	// Result returns the current value of the accumulation. This value
 	// will be a deep copy of any AggregateFunc internal state, so that
 	// it will not be mutated by additional calls to Add.
-	Result() (tree.Datum, error)
+	Result(context.Context) (tree.Datum, error)
 
 	// Reset resets the aggregate function which allows for reusing the same
 	// instance for computation without the need to create a new instance.
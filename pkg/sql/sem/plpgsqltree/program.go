@@ -0,0 +1,45 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package plpgsqltree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// Program is a PL/pgSQL routine that has been built by the optimizer. The
+// embedded SQL expressions and queries of its statements are built into the
+// statements of a routine, which are planned and executed by the interpreter
+// as the control flow of the program requires.
+//
+// The values of the variables of the program are passed to the statements of
+// the routine as arguments, in the order of Vars.
+type Program struct {
+	// Block is the top-level block of the routine body. The indexes of its
+	// statements are set.
+	Block *Block
+
+	// Vars are the variables of the program. The first variables are the
+	// parameters of the routine, followed by the FOUND variable and all other
+	// variables that are declared in the body.
+	Vars []Variable
+
+	// FoundIdx is the index of the FOUND variable, which indicates whether the
+	// last statement affected or returned at least one row.
+	FoundIdx int
+}
+
+// Variable is a variable of a PL/pgSQL program.
+type Variable struct {
+	Name    tree.Name
+	Typ     *types.T
+	NotNull bool
+}
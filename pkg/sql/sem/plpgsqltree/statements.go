@@ -0,0 +1,592 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package plpgsqltree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Statement is a statement in the body of a PL/pgSQL routine.
+//
+// The SQL expressions and queries embedded in a statement are built by the
+// optimizer into separate statements of the routine. The optimizer records the
+// indexes of these statements, and the indexes of the variables that are
+// referenced by the statement, in the fields of the statement suffixed with
+// "Idx", so that they can be executed by the interpreter.
+type Statement interface {
+	tree.NodeFormatter
+	plpgsqlStmt()
+}
+
+func (*Block) plpgsqlStmt()      {}
+func (*Assignment) plpgsqlStmt() {}
+func (*If) plpgsqlStmt()         {}
+func (*Loop) plpgsqlStmt()       {}
+func (*While) plpgsqlStmt()      {}
+func (*ForInt) plpgsqlStmt()     {}
+func (*ForQuery) plpgsqlStmt()   {}
+func (*Exit) plpgsqlStmt()       {}
+func (*Continue) plpgsqlStmt()   {}
+func (*Return) plpgsqlStmt()     {}
+func (*Raise) plpgsqlStmt()      {}
+func (*ExecSQL) plpgsqlStmt()    {}
+func (*Perform) plpgsqlStmt()    {}
+func (*Null) plpgsqlStmt()       {}
+
+// Block is a block of statements with its own variable declarations and
+// optional exception handlers:
+//
+//	[ <<label>> ]
+//	[ DECLARE declarations ]
+//	BEGIN
+//	    statements
+//	[ EXCEPTION
+//	    WHEN condition [ OR condition ... ] THEN
+//	        handler_statements
+//	    ... ]
+//	END [ label ];
+type Block struct {
+	Label      string
+	Decls      []*Declaration
+	Body       []Statement
+	Exceptions []*Exception
+
+	// SQLStateIdx and SQLErrMIdx are the indexes of the SQLSTATE and SQLERRM
+	// variables, which are visible in the exception handlers of the block. They
+	// are only set if the block has exception handlers.
+	SQLStateIdx int
+	SQLErrMIdx  int
+}
+
+// Declaration declares a variable in a block:
+//
+//	name [ CONSTANT ] type [ NOT NULL ] [ { DEFAULT | := | = } expression ];
+type Declaration struct {
+	Var      tree.Name
+	Constant bool
+	Typ      tree.ResolvableTypeReference
+	NotNull  bool
+	Default  tree.Expr
+
+	// VarIdx is the index of the declared variable.
+	VarIdx int
+	// DefaultIdx is the index of the statement that evaluates the default
+	// value. It is only set if Default is non-nil.
+	DefaultIdx int
+}
+
+// Exception is an exception handler of a block.
+type Exception struct {
+	Conditions []Condition
+	Body       []Statement
+}
+
+// Condition is a condition in the WHEN clause of an exception handler. It is
+// either a condition name, like division_by_zero or others, or a SQLSTATE
+// code.
+type Condition struct {
+	Name     string
+	SQLState string
+}
+
+// Assignment assigns the value of an expression to a variable:
+//
+//	variable := expression;
+type Assignment struct {
+	Var   tree.Name
+	Value tree.Expr
+
+	VarIdx   int
+	ValueIdx int
+}
+
+// If is a conditional statement:
+//
+//	IF condition THEN
+//	    statements
+//	[ ELSIF condition THEN
+//	    statements ... ]
+//	[ ELSE
+//	    statements ]
+//	END IF;
+type If struct {
+	Condition tree.Expr
+	ThenBody  []Statement
+	ElseIfs   []ElseIf
+	ElseBody  []Statement
+
+	CondIdx int
+}
+
+// ElseIf is an ELSIF branch of an If statement.
+type ElseIf struct {
+	Condition tree.Expr
+	Body      []Statement
+
+	CondIdx int
+}
+
+// Loop is an unconditional loop, which is terminated by an EXIT or RETURN
+// statement:
+//
+//	[ <<label>> ]
+//	LOOP
+//	    statements
+//	END LOOP [ label ];
+type Loop struct {
+	Label string
+	Body  []Statement
+}
+
+// While repeats its statements as long as the condition evaluates to true:
+//
+//	[ <<label>> ]
+//	WHILE condition LOOP
+//	    statements
+//	END LOOP [ label ];
+type While struct {
+	Label     string
+	Condition tree.Expr
+	Body      []Statement
+
+	CondIdx int
+}
+
+// ForInt iterates over a range of integer values:
+//
+//	[ <<label>> ]
+//	FOR name IN [ REVERSE ] expression .. expression [ BY expression ] LOOP
+//	    statements
+//	END LOOP [ label ];
+//
+// The loop variable is declared implicitly, and is only visible inside the
+// loop.
+type ForInt struct {
+	Label   string
+	Var     tree.Name
+	Reverse bool
+	Lower   tree.Expr
+	Upper   tree.Expr
+	Step    tree.Expr
+	Body    []Statement
+
+	VarIdx   int
+	LowerIdx int
+	UpperIdx int
+	// StepIdx is only set if Step is non-nil.
+	StepIdx int
+}
+
+// ForQuery iterates over the rows returned by a query:
+//
+//	[ <<label>> ]
+//	FOR target [, ...] IN query LOOP
+//	    statements
+//	END LOOP [ label ];
+//
+// The targets must be variables that are declared in an enclosing block.
+type ForQuery struct {
+	Label   string
+	Targets []tree.Name
+	Query   tree.Statement
+	Body    []Statement
+
+	TargetIdxs []int
+	QueryIdx   int
+}
+
+// Exit terminates the innermost loop, or the enclosing loop or block with the
+// given label, if the condition is true or omitted:
+//
+//	EXIT [ label ] [ WHEN condition ];
+type Exit struct {
+	Label     string
+	Condition tree.Expr
+
+	// CondIdx is only set if Condition is non-nil.
+	CondIdx int
+}
+
+// Continue starts the next iteration of the innermost loop, or the enclosing
+// loop with the given label, if the condition is true or omitted:
+//
+//	CONTINUE [ label ] [ WHEN condition ];
+type Continue struct {
+	Label     string
+	Condition tree.Expr
+
+	// CondIdx is only set if Condition is non-nil.
+	CondIdx int
+}
+
+// Return terminates the routine and returns the value of the expression:
+//
+//	RETURN [ expression ];
+type Return struct {
+	Expr tree.Expr
+
+	// ExprIdx is only set if Expr is non-nil.
+	ExprIdx int
+}
+
+// Raise reports a message or raises an error:
+//
+//	RAISE [ level ] 'format' [, expression [, ...]] [ USING option = expression [, ...] ];
+//	RAISE [ level ] condition_name [ USING option = expression [, ...] ];
+//	RAISE [ level ] SQLSTATE 'sqlstate' [ USING option = expression [, ...] ];
+//	RAISE [ level ] USING option = expression [, ...];
+//	RAISE;
+//
+// The last form re-raises the error that is being handled by an exception
+// handler.
+type Raise struct {
+	// Level is one of DEBUG, LOG, INFO, NOTICE, WARNING and EXCEPTION. It is
+	// empty if the level is omitted, in which case it defaults to EXCEPTION.
+	Level    string
+	Message  string
+	Params   []tree.Expr
+	CondName string
+	SQLState string
+	Options  []RaiseOption
+
+	ParamIdxs []int
+}
+
+// RaiseOption is an option in the USING clause of a Raise statement.
+type RaiseOption struct {
+	// Name is one of MESSAGE, DETAIL, HINT and ERRCODE.
+	Name  string
+	Value tree.Expr
+
+	ValueIdx int
+}
+
+// ExecSQL executes a SQL statement. If there is an INTO clause, the columns of
+// the first row returned by the statement are assigned to the targets:
+//
+//	statement [ INTO [ STRICT ] target [, ...] ];
+//
+// If STRICT is specified, the statement must return exactly one row.
+type ExecSQL struct {
+	SQL    tree.Statement
+	Into   []tree.Name
+	Strict bool
+
+	SQLIdx   int
+	IntoIdxs []int
+}
+
+// Perform executes a query and discards its result:
+//
+//	PERFORM query;
+//
+// The query is written like a SELECT statement, with PERFORM in place of
+// SELECT.
+type Perform struct {
+	Query tree.Statement
+
+	QueryIdx int
+}
+
+// Null does nothing:
+//
+//	NULL;
+type Null struct{}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Block) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	if len(s.Decls) > 0 {
+		ctx.WriteString("DECLARE")
+		for _, d := range s.Decls {
+			formatIndented(ctx, d)
+		}
+		ctx.WriteString("\n")
+	}
+	ctx.WriteString("BEGIN")
+	formatBody(ctx, s.Body)
+	if len(s.Exceptions) > 0 {
+		ctx.WriteString("\nEXCEPTION")
+		for _, e := range s.Exceptions {
+			formatIndented(ctx, e)
+		}
+	}
+	ctx.WriteString("\nEND")
+	formatEndLabel(ctx, s.Label)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Declaration) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	if s.Constant {
+		ctx.WriteString(" CONSTANT")
+	}
+	ctx.WriteByte(' ')
+	ctx.FormatTypeReference(s.Typ)
+	if s.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	if s.Default != nil {
+		ctx.WriteString(" := ")
+		ctx.FormatNode(s.Default)
+	}
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Exception) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("WHEN ")
+	for i := range s.Conditions {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(&s.Conditions[i])
+	}
+	ctx.WriteString(" THEN")
+	formatBody(ctx, s.Body)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (c *Condition) Format(ctx *tree.FmtCtx) {
+	if c.SQLState != "" {
+		ctx.WriteString("SQLSTATE ")
+		lexbase.EncodeSQLString(&ctx.Buffer, c.SQLState)
+		return
+	}
+	ctx.WriteString(c.Name)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Assignment) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" := ")
+	ctx.FormatNode(s.Value)
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *If) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("IF ")
+	ctx.FormatNode(s.Condition)
+	ctx.WriteString(" THEN")
+	formatBody(ctx, s.ThenBody)
+	for i := range s.ElseIfs {
+		ctx.WriteString("\nELSIF ")
+		ctx.FormatNode(s.ElseIfs[i].Condition)
+		ctx.WriteString(" THEN")
+		formatBody(ctx, s.ElseIfs[i].Body)
+	}
+	if s.ElseBody != nil {
+		ctx.WriteString("\nELSE")
+		formatBody(ctx, s.ElseBody)
+	}
+	ctx.WriteString("\nEND IF;")
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Loop) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("LOOP")
+	formatLoopBody(ctx, s.Body, s.Label)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *While) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("WHILE ")
+	ctx.FormatNode(s.Condition)
+	ctx.WriteString(" LOOP")
+	formatLoopBody(ctx, s.Body, s.Label)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString(" .. ")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteString(" LOOP")
+	formatLoopBody(ctx, s.Body, s.Label)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ForQuery) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	formatNames(ctx, s.Targets)
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(" LOOP")
+	formatLoopBody(ctx, s.Body, s.Label)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Exit) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXIT")
+	formatExitOrContinue(ctx, s.Label, s.Condition)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Continue) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("CONTINUE")
+	formatExitOrContinue(ctx, s.Label, s.Condition)
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Return) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Raise) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RAISE")
+	if s.Level != "" {
+		ctx.WriteByte(' ')
+		ctx.WriteString(s.Level)
+	}
+	switch {
+	case s.CondName != "":
+		ctx.WriteByte(' ')
+		ctx.WriteString(s.CondName)
+	case s.SQLState != "":
+		ctx.WriteString(" SQLSTATE ")
+		lexbase.EncodeSQLString(&ctx.Buffer, s.SQLState)
+	case s.Message != "" || len(s.Params) > 0:
+		ctx.WriteByte(' ')
+		lexbase.EncodeSQLString(&ctx.Buffer, s.Message)
+		for _, p := range s.Params {
+			ctx.WriteString(", ")
+			ctx.FormatNode(p)
+		}
+	}
+	for i := range s.Options {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString(s.Options[i].Name)
+		ctx.WriteString(" = ")
+		ctx.FormatNode(s.Options[i].Value)
+	}
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ExecSQL) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(s.SQL)
+	if len(s.Into) > 0 {
+		ctx.WriteString(" INTO ")
+		if s.Strict {
+			ctx.WriteString("STRICT ")
+		}
+		formatNames(ctx, s.Into)
+	}
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// The query is stored as a SELECT statement.
+	start := ctx.Len()
+	ctx.FormatNode(s.Query)
+	formatted := strings.TrimPrefix(ctx.String()[start:], "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(formatted)
+	ctx.WriteByte(';')
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Null) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("NULL;")
+}
+
+// formatBody formats the given statements, each on a new, indented line.
+func formatBody(ctx *tree.FmtCtx, stmts []Statement) {
+	for _, s := range stmts {
+		formatIndented(ctx, s)
+	}
+}
+
+// formatIndented formats the given node on a new line, and indents each of the
+// lines of the formatted node.
+func formatIndented(ctx *tree.FmtCtx, n tree.NodeFormatter) {
+	start := ctx.Len()
+	ctx.FormatNode(n)
+	formatted := ctx.String()[start:]
+	ctx.Truncate(start)
+	for _, line := range strings.Split(formatted, "\n") {
+		ctx.WriteString("\n  ")
+		ctx.WriteString(line)
+	}
+}
+
+func formatLoopBody(ctx *tree.FmtCtx, stmts []Statement, label string) {
+	formatBody(ctx, stmts)
+	ctx.WriteString("\nEND LOOP")
+	formatEndLabel(ctx, label)
+}
+
+func formatLabel(ctx *tree.FmtCtx, label string) {
+	if label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatName(label)
+		ctx.WriteString(">>\n")
+	}
+}
+
+func formatEndLabel(ctx *tree.FmtCtx, label string) {
+	if label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatName(label)
+	}
+	ctx.WriteByte(';')
+}
+
+func formatExitOrContinue(ctx *tree.FmtCtx, label string, cond tree.Expr) {
+	if label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatName(label)
+	}
+	if cond != nil {
+		ctx.WriteString(" WHEN ")
+		ctx.FormatNode(cond)
+	}
+	ctx.WriteByte(';')
+}
+
+func formatNames(ctx *tree.FmtCtx, names []tree.Name) {
+	for i := range names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&names[i])
+	}
+}
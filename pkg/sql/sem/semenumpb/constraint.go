@@ -16,3 +16,23 @@ var _ redact.SafeValue = ForeignKeyAction(0)
 
 // SafeValue implements redact.SafeValue.
 func (x ForeignKeyAction) SafeValue() {}
+
+var _ redact.SafeValue = TriggerActionTime(0)
+
+// SafeValue implements redact.SafeValue.
+func (x TriggerActionTime) SafeValue() {}
+
+var _ redact.SafeValue = TriggerEvent(0)
+
+// SafeValue implements redact.SafeValue.
+func (x TriggerEvent) SafeValue() {}
+
+var _ redact.SafeValue = PolicyType(0)
+
+// SafeValue implements redact.SafeValue.
+func (x PolicyType) SafeValue() {}
+
+var _ redact.SafeValue = PolicyCommand(0)
+
+// SafeValue implements redact.SafeValue.
+func (x PolicyCommand) SafeValue() {}
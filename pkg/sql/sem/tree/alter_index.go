@@ -50,9 +50,11 @@ type AlterIndexCmd interface {
 	alterIndexCmd()
 }
 
-func (*AlterIndexPartitionBy) alterIndexCmd() {}
+func (*AlterIndexPartitionBy) alterIndexCmd()     {}
+func (*AlterIndexAttachPartition) alterIndexCmd() {}
 
 var _ AlterIndexCmd = &AlterIndexPartitionBy{}
+var _ AlterIndexCmd = &AlterIndexAttachPartition{}
 
 // AlterIndexPartitionBy represents an ALTER INDEX PARTITION BY
 // command.
@@ -65,6 +67,18 @@ func (node *AlterIndexPartitionBy) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.PartitionByIndex)
 }
 
+// AlterIndexAttachPartition represents an ALTER INDEX ATTACH PARTITION
+// command.
+type AlterIndexAttachPartition struct {
+	Index TableIndexName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterIndexAttachPartition) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ATTACH PARTITION ")
+	ctx.FormatNode(&node.Index)
+}
+
 // AlterIndexVisible represents a ALTER INDEX ... [VISIBLE | NOT VISIBLE] statement.
 type AlterIndexVisible struct {
 	Index      TableIndexName
@@ -73,9 +73,11 @@ func (*AlterTableSetOnUpdate) alterTableCmd()        {}
 func (*AlterTableSetVisible) alterTableCmd()         {}
 func (*AlterTableValidateConstraint) alterTableCmd() {}
 func (*AlterTablePartitionByTable) alterTableCmd()   {}
+func (*AlterTableAttachPartition) alterTableCmd()    {}
 func (*AlterTableInjectStats) alterTableCmd()        {}
 func (*AlterTableSetStorageParams) alterTableCmd()   {}
 func (*AlterTableResetStorageParams) alterTableCmd() {}
+func (*AlterTableRowLevelSecurity) alterTableCmd()   {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -93,9 +95,11 @@ var _ AlterTableCmd = &AlterTableSetOnUpdate{}
 var _ AlterTableCmd = &AlterTableSetVisible{}
 var _ AlterTableCmd = &AlterTableValidateConstraint{}
 var _ AlterTableCmd = &AlterTablePartitionByTable{}
+var _ AlterTableCmd = &AlterTableAttachPartition{}
 var _ AlterTableCmd = &AlterTableInjectStats{}
 var _ AlterTableCmd = &AlterTableSetStorageParams{}
 var _ AlterTableCmd = &AlterTableResetStorageParams{}
+var _ AlterTableCmd = &AlterTableRowLevelSecurity{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -169,12 +173,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:      *d.References.Table,
+					FromCols:   NameList{d.Name},
+					ToCols:     targetCol,
+					Name:       d.References.ConstraintName,
+					Actions:    d.References.Actions,
+					Match:      d.References.Match,
+					Deferrable: d.References.Deferrable,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
@@ -553,6 +558,26 @@ func (node *AlterTablePartitionByTable) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.PartitionByTable)
 }
 
+// AlterTableAttachPartition represents an ALTER TABLE ATTACH PARTITION
+// command.
+type AlterTableAttachPartition struct {
+	Partition TableName
+	Bound     PartitionBoundSpec
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableAttachPartition) TelemetryName() string {
+	return "attach_partition"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableAttachPartition) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ATTACH PARTITION ")
+	ctx.FormatNode(&node.Partition)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Bound)
+}
+
 // AuditMode represents a table audit mode
 type AuditMode int
 
@@ -127,3 +127,41 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrable describes whether the checks of a constraint may be
+// postponed until the end of the transaction.
+type ConstraintDeferrable int
+
+// The values for ConstraintDeferrable.
+const (
+	ConstraintNotDeferrable ConstraintDeferrable = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// IsDeferrable returns true if the constraint checks may be deferred.
+func (x ConstraintDeferrable) IsDeferrable() bool {
+	return x != ConstraintNotDeferrable
+}
+
+// Format implements the NodeFormatter interface.
+func (x *ConstraintDeferrable) Format(ctx *FmtCtx) {
+	if x.IsDeferrable() {
+		ctx.WriteByte(' ')
+		ctx.WriteString(x.String())
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (x ConstraintDeferrable) String() string {
+	switch x {
+	case ConstraintNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
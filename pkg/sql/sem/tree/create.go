@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
@@ -397,6 +398,75 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	TypeName *UnresolvedObjectName
+	// Type is the base type of the domain.
+	Type        ResolvableTypeReference
+	Default     Expr
+	Constraints DomainConstraints
+}
+
+var _ Statement = &CreateDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	if node.Default != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+	for i := range node.Constraints {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Constraints[i])
+	}
+}
+
+// DomainConstraintKind is the kind of a domain constraint.
+type DomainConstraintKind int
+
+// DomainConstraintKind values.
+const (
+	DomainConstraintCheck DomainConstraintKind = iota
+	DomainConstraintNotNull
+	DomainConstraintNull
+)
+
+// DomainConstraint represents a constraint of a domain: NOT NULL, NULL or
+// CHECK (expr).
+type DomainConstraint struct {
+	Name Name
+	Kind DomainConstraintKind
+	// Expr is the expression of a CHECK constraint. It refers to the value
+	// being checked as VALUE.
+	Expr Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch node.Kind {
+	case DomainConstraintNotNull:
+		ctx.WriteString("NOT NULL")
+	case DomainConstraintNull:
+		ctx.WriteString("NULL")
+	default:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Expr)
+		ctx.WriteByte(')')
+	}
+}
+
+// DomainConstraints is a list of domain constraints.
+type DomainConstraints []DomainConstraint
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -411,6 +481,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -500,6 +571,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrable     ConstraintDeferrable
 	}
 	Computed struct {
 		Computed bool
@@ -683,6 +755,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrable = t.Deferrable
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -853,6 +926,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrable)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -982,10 +1056,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table      TableName
+	Col        Name // empty-string means use PK
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrable
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1072,6 +1147,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -1080,6 +1156,7 @@ type UniqueConstraintTableDef struct {
 	PrimaryKey   bool
 	WithoutIndex bool
 	IfNotExists  bool
+	Deferrable   ConstraintDeferrable
 }
 
 // SetName implements the TableDef interface.
@@ -1124,6 +1201,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrable)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1141,6 +1219,7 @@ type ForeignKeyConstraintTableDef struct {
 	ToCols      NameList
 	Actions     ReferenceActions
 	Match       CompositeKeyMatchMethod
+	Deferrable  ConstraintDeferrable
 	IfNotExists bool
 }
 
@@ -1172,6 +1251,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrable)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1218,6 +1298,82 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExcludeConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement. Two rows conflict if every one of the constraint's
+// operators returns true when comparing the rows' values for the
+// corresponding column.
+type ExcludeConstraintTableDef struct {
+	Name        Name
+	Using       string
+	Elems       ExcludeElemList
+	Predicate   Expr
+	Deferrable  ConstraintDeferrable
+	IfNotExists bool
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Using != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(node.Using)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+	ctx.FormatNode(&node.Deferrable)
+}
+
+// ExcludeElem is a column of an EXCLUDE constraint, along with the operator
+// used to compare its values.
+type ExcludeElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1322,9 +1478,20 @@ func (node *PartitionByTable) ContainsPartitioningClause() bool {
 // structs for table and index definitions respectively.
 type PartitionBy struct {
 	Fields NameList
-	// Exactly one of List or Range is required to be non-empty.
+	// Exactly one of List or Range is required to be non-empty, unless
+	// Declarative is set.
 	List  []ListPartition
 	Range []RangePartition
+	// Declarative is set for a PostgreSQL-style PARTITION BY LIST|RANGE (...)
+	// clause which does not list any partitions. The partitions are added later
+	// by CREATE TABLE ... PARTITION OF or ALTER TABLE ... ATTACH PARTITION.
+	Declarative PartitionByType
+}
+
+// IsDeclarative returns true if this is a PostgreSQL-style PARTITION BY clause
+// without any partitions.
+func (node *PartitionBy) IsDeclarative() bool {
+	return node != nil && node.Declarative != ""
 }
 
 // Format implements the NodeFormatter interface.
@@ -1338,6 +1505,13 @@ func (node *PartitionBy) formatListOrRange(ctx *FmtCtx) {
 		ctx.WriteString(`NOTHING`)
 		return
 	}
+	if node.IsDeclarative() {
+		ctx.WriteString(string(node.Declarative))
+		ctx.WriteString(` (`)
+		ctx.FormatNode(&node.Fields)
+		ctx.WriteByte(')')
+		return
+	}
 	if len(node.List) > 0 {
 		ctx.WriteString(`LIST (`)
 	} else if len(node.Range) > 0 {
@@ -1401,6 +1575,101 @@ func (node *RangePartition) Format(ctx *FmtCtx) {
 	}
 }
 
+// PartitionBoundSpec represents the FOR VALUES ... or DEFAULT clause of a
+// CREATE TABLE ... PARTITION OF or ALTER TABLE ... ATTACH PARTITION statement.
+type PartitionBoundSpec struct {
+	// In is set for FOR VALUES IN (...).
+	In Exprs
+	// From and To are set for FOR VALUES FROM (...) TO (...).
+	From Exprs
+	To   Exprs
+	// Default is set for DEFAULT.
+	Default bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *PartitionBoundSpec) Format(ctx *FmtCtx) {
+	switch {
+	case node.Default:
+		ctx.WriteString(`DEFAULT`)
+	case node.In != nil:
+		ctx.WriteString(`FOR VALUES IN (`)
+		ctx.FormatNode(&node.In)
+		ctx.WriteByte(')')
+	default:
+		ctx.WriteString(`FOR VALUES FROM (`)
+		ctx.FormatNode(&node.From)
+		ctx.WriteString(`) TO (`)
+		ctx.FormatNode(&node.To)
+		ctx.WriteByte(')')
+	}
+}
+
+// PartitionType returns the type of the partitioning: the declared type for a
+// PostgreSQL-style PARTITION BY clause without partitions, and otherwise the
+// type of the listed partitions.
+func (node *PartitionBy) PartitionType() PartitionByType {
+	if len(node.List) > 0 {
+		return PartitionByList
+	} else if len(node.Range) > 0 {
+		return PartitionByRange
+	}
+	return node.Declarative
+}
+
+// AddPartition appends a partition with the given name and bound, as
+// specified by CREATE TABLE ... PARTITION OF or ALTER TABLE ... ATTACH
+// PARTITION, to the partitioning of the given type. A DEFAULT bound is
+// translated to a list partition containing DEFAULT.
+func (node *PartitionBy) AddPartition(
+	typ PartitionByType, name Name, bound *PartitionBoundSpec,
+) error {
+	if existing := node.PartitionType(); existing != "" && existing != typ {
+		return errors.AssertionFailedf("cannot add %s partition to %s partitioning", typ, existing)
+	}
+	switch typ {
+	case PartitionByList:
+		exprs := bound.In
+		if bound.Default {
+			exprs = Exprs{DefaultVal{}}
+		} else if exprs == nil {
+			return pgerror.New(pgcode.InvalidTableDefinition,
+				"invalid bound specification for a list partition")
+		}
+		node.List = append(node.List, ListPartition{Name: UnrestrictedName(name), Exprs: exprs})
+	case PartitionByRange:
+		if bound.Default {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"DEFAULT partitions are not supported for RANGE partitioning")
+		}
+		if bound.From == nil {
+			return pgerror.New(pgcode.InvalidTableDefinition,
+				"invalid bound specification for a range partition")
+		}
+		node.Range = append(node.Range, RangePartition{
+			Name: UnrestrictedName(name), From: bound.From, To: bound.To,
+		})
+	default:
+		return errors.AssertionFailedf("unknown partitioning type %q", typ)
+	}
+	node.Declarative = ""
+	return nil
+}
+
+// PartitionOf represents the PARTITION OF clause of a CREATE TABLE statement.
+type PartitionOf struct {
+	Parent TableName
+	Bound  PartitionBoundSpec
+}
+
+// Format implements the NodeFormatter interface.
+func (node *PartitionOf) Format(ctx *FmtCtx) {
+	ctx.WriteString(`PARTITION OF `)
+	ctx.FormatNode(&node.Parent)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Bound)
+}
+
 // StorageParam is a key-value parameter for table storage.
 type StorageParam struct {
 	Key   Name
@@ -1463,7 +1732,13 @@ type CreateTable struct {
 	// these columns.
 	Defs     TableDefs
 	AsSource *Select
-	Locality *Locality
+	// AsWithNoData is set for CREATE TABLE ... AS ... WITH NO DATA, in which
+	// case the table is created without running the AS query.
+	AsWithNoData bool
+	Locality     *Locality
+	// PartitionOf is set for CREATE TABLE ... PARTITION OF, in which case Defs
+	// is empty.
+	PartitionOf *PartitionOf
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1507,6 +1782,11 @@ func (node *CreateTable) Format(ctx *FmtCtx) {
 // FormatBody formats the "body" of the create table definition - everything
 // but the CREATE TABLE tableName part.
 func (node *CreateTable) FormatBody(ctx *FmtCtx) {
+	if node.PartitionOf != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.PartitionOf)
+		return
+	}
 	if node.As() {
 		if len(node.Defs) > 0 {
 			ctx.WriteString(" (")
@@ -1515,6 +1795,9 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		}
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(node.AsSource)
+		if node.AsWithNoData {
+			ctx.WriteString(" WITH NO DATA")
+		}
 	} else {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
@@ -1579,12 +1862,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:      *col.References.Table,
+					FromCols:   NameList{col.Name},
+					ToCols:     targetCol,
+					Name:       col.References.ConstraintName,
+					Actions:    col.References.Actions,
+					Match:      col.References.Match,
+					Deferrable: col.References.Deferrable,
 				})
 				col.References.Table = nil
 			}
@@ -1908,6 +2192,9 @@ type CreateView struct {
 	Replace      bool
 	Materialized bool
 	WithData     bool
+	// Recursive is set for CREATE RECURSIVE VIEW, in which case AsSource can
+	// refer to the view itself.
+	Recursive bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -1926,6 +2213,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString("MATERIALIZED ")
 	}
 
+	if node.Recursive {
+		ctx.WriteString("RECURSIVE ")
+	}
+
 	ctx.WriteString("VIEW ")
 
 	if node.IfNotExists {
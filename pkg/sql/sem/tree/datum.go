@@ -3713,7 +3713,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery:
+		*DTSVector, *DTSQuery, *DRange:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc))), nil
 	case *DGeometry:
 		return json.FromSpatialObject(t.Geometry.SpatialObject(), geo.DefaultGeoJSONDecimalDigits)
@@ -4086,6 +4086,469 @@ func ParseDTSVector(s string) (Datum, error) {
 	return NewDTSVector(v), nil
 }
 
+// DRange is the Datum for the built-in range types. A DRange is always in
+// canonical form: infinite bounds are exclusive, ranges containing no values
+// are represented as the empty range, and ranges over discrete element types
+// (integers and dates) use an inclusive lower bound and an exclusive upper
+// bound. See NewDRange.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. A nil bound is infinite.
+	// Both bounds are nil for the empty range.
+	Lower, Upper Datum
+	// LowerInc and UpperInc indicate whether the corresponding bound is
+	// included in the range. They are always false for infinite bounds.
+	LowerInc, UpperInc bool
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+// Range flags describe the shape of a range. They match the flags used by the
+// binary format of Postgres range types.
+const (
+	RangeFlagEmpty    byte = 0x01
+	RangeFlagLowerInc byte = 0x02
+	RangeFlagUpperInc byte = 0x04
+	RangeFlagLowerInf byte = 0x08
+	RangeFlagUpperInf byte = 0x10
+)
+
+// NewDRange creates a new DRange of the given range type with the given
+// bounds, which must be of the range's element type. A nil (or NULL) bound is
+// infinite. The resulting range is canonicalized.
+func NewDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) (*DRange, error) {
+	if lower == DNull {
+		lower = nil
+	}
+	if upper == DNull {
+		upper = nil
+	}
+	if lower == nil {
+		lowerInc = false
+	}
+	if upper == nil {
+		upperInc = false
+	}
+	if lower != nil && upper != nil {
+		cmp := compareRangeBoundValues(lower, upper)
+		if cmp > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		}
+		if cmp == 0 && !(lowerInc && upperInc) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if isDiscreteRangeType(typ) {
+		var err error
+		if lower != nil && !lowerInc {
+			if lower, err = nextRangeBoundValue(typ, lower); err != nil {
+				return nil, err
+			}
+			lowerInc = true
+		}
+		if upper != nil && upperInc {
+			if upper, err = nextRangeBoundValue(typ, upper); err != nil {
+				return nil, err
+			}
+			upperInc = false
+		}
+		if lower != nil && upper != nil && compareRangeBoundValues(lower, upper) == 0 {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{
+		typ:      typ,
+		Lower:    lower,
+		Upper:    upper,
+		LowerInc: lowerInc,
+		UpperInc: upperInc,
+	}, nil
+}
+
+// NewDEmptyRange returns the empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRangeFromFlags creates a new DRange from its bounds and range flags.
+func NewDRangeFromFlags(typ *types.T, lower, upper Datum, flags byte) (*DRange, error) {
+	if flags&RangeFlagEmpty != 0 {
+		return NewDEmptyRange(typ), nil
+	}
+	if flags&RangeFlagLowerInf != 0 {
+		lower = nil
+	}
+	if flags&RangeFlagUpperInf != 0 {
+		upper = nil
+	}
+	return NewDRange(typ, lower, upper, flags&RangeFlagLowerInc != 0, flags&RangeFlagUpperInc != 0)
+}
+
+// Flags returns the range flags describing the shape of the range.
+func (d *DRange) Flags() byte {
+	if d.Empty {
+		return RangeFlagEmpty
+	}
+	var flags byte
+	if d.LowerInc {
+		flags |= RangeFlagLowerInc
+	}
+	if d.UpperInc {
+		flags |= RangeFlagUpperInc
+	}
+	if d.Lower == nil {
+		flags |= RangeFlagLowerInf
+	}
+	if d.Upper == nil {
+		flags |= RangeFlagUpperInf
+	}
+	return flags
+}
+
+// isDiscreteRangeType returns true if the element type of the given range type
+// is discrete, in which case ranges of that type are canonicalized to the
+// [lower, upper) form.
+func isDiscreteRangeType(typ *types.T) bool {
+	switch typ.Oid() {
+	case oid.T_int4range, oid.T_int8range, oid.T_daterange:
+		return true
+	}
+	return false
+}
+
+// nextRangeBoundValue returns the value following the given bound value of a
+// discrete range type.
+func nextRangeBoundValue(typ *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		if *t == math.MaxInt64 || (typ.Oid() == oid.T_int4range && *t >= math.MaxInt32) {
+			return nil, pgerror.New(pgcode.NumericValueOutOfRange, "integer out of range")
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		n, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(n), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected range bound %T for %s", d, typ)
+}
+
+// compareRangeBoundValues compares two finite range bound values, which must
+// both be of the same range element type.
+func compareRangeBoundValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		o := *b.(*DInt)
+		if *t < o {
+			return -1
+		} else if *t > o {
+			return 1
+		}
+		return 0
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return t.Time.Compare(b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return t.Time.Compare(b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range bound %T", a))
+}
+
+// rangeBound is a lower or upper bound of a non-empty range. A nil val is an
+// infinite bound.
+type rangeBound struct {
+	val   Datum
+	inc   bool
+	lower bool
+}
+
+func (d *DRange) lowerBound() rangeBound {
+	return rangeBound{val: d.Lower, inc: d.LowerInc, lower: true}
+}
+
+func (d *DRange) upperBound() rangeBound {
+	return rangeBound{val: d.Upper, inc: d.UpperInc, lower: false}
+}
+
+// compareRangeBounds compares two range bounds, taking into account whether
+// they are lower or upper bounds and whether they are inclusive.
+func compareRangeBounds(b1, b2 rangeBound) int {
+	if b1.val == nil || b2.val == nil {
+		switch {
+		case b1.val == nil && b2.val == nil && b1.lower == b2.lower:
+			return 0
+		case b1.val == nil:
+			if b1.lower {
+				return -1
+			}
+			return 1
+		default:
+			if b2.lower {
+				return 1
+			}
+			return -1
+		}
+	}
+	if cmp := compareRangeBoundValues(b1.val, b2.val); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case !b1.inc && !b2.inc:
+		if b1.lower == b2.lower {
+			return 0
+		}
+		if b1.lower {
+			return 1
+		}
+		return -1
+	case !b1.inc:
+		if b1.lower {
+			return 1
+		}
+		return -1
+	case !b2.inc:
+		if b2.lower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Contains returns true if the range contains the other range. The empty
+// range is contained by all ranges.
+func (d *DRange) Contains(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.lowerBound(), other.lowerBound()) <= 0 &&
+		compareRangeBounds(d.upperBound(), other.upperBound()) >= 0
+}
+
+// ContainsElem returns true if the range contains the given value of the
+// range's element type.
+func (d *DRange) ContainsElem(elem Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if d.Lower != nil {
+		cmp := compareRangeBoundValues(d.Lower, elem)
+		if cmp > 0 || (cmp == 0 && !d.LowerInc) {
+			return false
+		}
+	}
+	if d.Upper != nil {
+		cmp := compareRangeBoundValues(d.Upper, elem)
+		if cmp < 0 || (cmp == 0 && !d.UpperInc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns true if the ranges have any values in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.lowerBound(), other.upperBound()) <= 0 &&
+		compareRangeBounds(other.lowerBound(), d.upperBound()) <= 0
+}
+
+// Adjacent returns true if the ranges do not overlap, but have no values
+// between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	// Ranges of discrete types are canonical, so it is enough to compare the
+	// bound values.
+	adjacent := func(upper, lower rangeBound) bool {
+		if upper.val == nil || lower.val == nil {
+			return false
+		}
+		return compareRangeBoundValues(upper.val, lower.val) == 0 && upper.inc != lower.inc
+	}
+	return adjacent(d.upperBound(), other.lowerBound()) ||
+		adjacent(other.upperBound(), d.lowerBound())
+}
+
+// Merge returns the smallest range which includes both ranges.
+func (d *DRange) Merge(other *DRange) *DRange {
+	if d.Empty {
+		return other
+	}
+	if other.Empty {
+		return d
+	}
+	res := *d
+	if compareRangeBounds(other.lowerBound(), d.lowerBound()) < 0 {
+		res.Lower, res.LowerInc = other.Lower, other.LowerInc
+	}
+	if compareRangeBounds(other.upperBound(), d.upperBound()) > 0 {
+		res.Upper, res.UpperInc = other.Upper, other.UpperInc
+	}
+	return &res
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	if d.Empty {
+		ctx.WriteString("empty")
+	} else {
+		if d.LowerInc {
+			ctx.WriteByte('[')
+		} else {
+			ctx.WriteByte('(')
+		}
+		formatBound := func(b Datum) {
+			if b == nil {
+				return
+			}
+			s := AsStringWithFlags(b, FmtBareStrings, FmtDataConversionConfig(ctx.dataConversionConfig))
+			if !bareStrings {
+				s = strings.ReplaceAll(s, `'`, `''`)
+			}
+			formatStringInRange(&ctx.Buffer, s)
+		}
+		formatBound(d.Lower)
+		ctx.WriteByte(',')
+		formatBound(d.Upper)
+		if d.UpperInc {
+			ctx.WriteByte(']')
+		} else {
+			ctx.WriteByte(')')
+		}
+	}
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DRange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. The empty range sorts before
+// all other ranges, which are ordered by their lower and then upper bounds.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	if d.Empty || v.Empty {
+		switch {
+		case d.Empty && v.Empty:
+			return 0, nil
+		case d.Empty:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	if cmp := compareRangeBounds(d.lowerBound(), v.lowerBound()); cmp != 0 {
+		return cmp, nil
+	}
+	return compareRangeBounds(d.upperBound(), v.upperBound()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ CompareContext) bool {
+	return d.Empty
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != nil {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != nil {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
+// AsDRange attempts to retrieve a DRange from an Expr, returning a DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	v, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return v
+}
+
 // DTuple is the tuple Datum.
 type DTuple struct {
 	D Datums
@@ -4773,6 +5236,100 @@ func (d *DArray) Len() int {
 	return len(d.Array)
 }
 
+// Dimensions returns the length of each dimension of the array. The number of
+// dimensions is determined by the array's type: a multi-dimensional array is
+// an array whose elements are themselves arrays, all of which have the same
+// dimensions. If any dimension has length zero, the array contains no
+// elements and Dimensions returns nil, matching the Postgres notion of an
+// empty array having no dimensions.
+func (d *DArray) Dimensions() []int {
+	var dims []int
+	for a := d; ; {
+		if a.Len() == 0 {
+			return nil
+		}
+		dims = append(dims, a.Len())
+		if a.ParamTyp.Family() != types.ArrayFamily {
+			return dims
+		}
+		inner, ok := AsDArray(a.Array[0])
+		if !ok {
+			return nil
+		}
+		a = inner
+	}
+}
+
+// FlatElements returns the scalar elements of a (possibly multi-dimensional)
+// array in row-major order. For a one-dimensional array, it returns the
+// array's elements.
+func (d *DArray) FlatElements() Datums {
+	if d.ParamTyp.Family() != types.ArrayFamily {
+		return d.Array
+	}
+	var res Datums
+	for _, e := range d.Array {
+		if inner, ok := AsDArray(e); ok {
+			res = append(res, inner.FlatElements()...)
+		}
+	}
+	return res
+}
+
+// NewDArrayFromDimensions returns an array of the given array type, with the
+// given dimensions, containing the given scalar elements in row-major order.
+// The number of dimensions must match the array type, and the number of
+// elements must match the dimensions. If dims is empty, an empty array is
+// returned.
+func NewDArrayFromDimensions(typ *types.T, dims []int, elems Datums) (*DArray, error) {
+	if len(dims) == 0 {
+		if len(elems) != 0 {
+			return nil, errors.AssertionFailedf("elements provided for an empty array")
+		}
+		return NewDArray(typ.ArrayContents()), nil
+	}
+	if len(dims) != typ.NumArrayDimensions() {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"cannot construct %d-dimensional array of type %s", len(dims), typ.SQLString())
+	}
+	n := 1
+	for _, dim := range dims {
+		if dim < 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid array dimension %d", dim)
+		}
+		n *= dim
+	}
+	if n != len(elems) {
+		return nil, errors.AssertionFailedf(
+			"array dimensions do not match the number of elements (%d vs %d)", n, len(elems))
+	}
+	if n == 0 {
+		return NewDArray(typ.ArrayContents()), nil
+	}
+	var build func(typ *types.T, dims []int, elems Datums) (*DArray, error)
+	build = func(typ *types.T, dims []int, elems Datums) (*DArray, error) {
+		res := NewDArray(typ.ArrayContents())
+		stride := len(elems) / dims[0]
+		for i := 0; i < dims[0]; i++ {
+			var e Datum
+			if len(dims) == 1 {
+				e = elems[i]
+			} else {
+				inner, err := build(typ.ArrayContents(), dims[1:], elems[i*stride:(i+1)*stride])
+				if err != nil {
+					return nil, err
+				}
+				e = inner
+			}
+			if err := res.Append(e); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	return build(typ, dims, elems)
+}
+
 // Size implements the Datum interface.
 func (d *DArray) Size() uintptr {
 	sz := unsafe.Sizeof(*d)
@@ -4805,8 +5362,9 @@ func (d *DArray) Append(v Datum) error {
 			if prevItem == DNull {
 				return errNonHomogeneousArray
 			}
-			expectedLen := MustBeDArray(prevItem).Len()
-			if MustBeDArray(v).Len() != expectedLen {
+			// All sub-arrays of a multi-dimensional array must have the same
+			// dimensions.
+			if !sameArrayShape(MustBeDArray(prevItem), MustBeDArray(v)) {
 				return errNonHomogeneousArray
 			}
 		}
@@ -4820,6 +5378,25 @@ func (d *DArray) Append(v Datum) error {
 	return d.Validate()
 }
 
+// sameArrayShape returns true if the given arrays have the same length in
+// every dimension.
+func sameArrayShape(a, b *DArray) bool {
+	for {
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Len() == 0 || a.ParamTyp.Family() != types.ArrayFamily {
+			return true
+		}
+		innerA, okA := AsDArray(a.Array[0])
+		innerB, okB := AsDArray(b.Array[0])
+		if !okA || !okB {
+			return okA == okB
+		}
+		a, b = innerA, innerB
+	}
+}
+
 // DVoid represents a void type.
 type DVoid struct{}
 
@@ -5275,6 +5852,12 @@ func (d *DOid) AsRegProc(name string) *DOid {
 	return d
 }
 
+// Name returns the name of the object referenced by the DOid, or the empty
+// string if it isn't known.
+func (d *DOid) Name() string {
+	return d.name
+}
+
 // AmbiguousFormat implements the Datum interface.
 func (*DOid) AmbiguousFormat() bool { return true }
 
@@ -5762,6 +6345,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TimestampTZFamily:    {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
@@ -1422,6 +1422,20 @@ func cmpOpFixups(
 		})
 	}
 
+	// Range comparisons.
+	for _, t := range types.Ranges {
+		cmpOps[treecmp.EQ].overloads = append(cmpOps[treecmp.EQ].overloads,
+			makeEqFn(t, t, volatility.Immutable))
+		cmpOps[treecmp.LT].overloads = append(cmpOps[treecmp.LT].overloads,
+			makeLtFn(t, t, volatility.Immutable))
+		cmpOps[treecmp.LE].overloads = append(cmpOps[treecmp.LE].overloads,
+			makeLeFn(t, t, volatility.Immutable))
+		cmpOps[treecmp.IsNotDistinctFrom].overloads = append(cmpOps[treecmp.IsNotDistinctFrom].overloads,
+			makeIsFn(t, t, volatility.Immutable))
+		cmpOps[treecmp.In].overloads = append(cmpOps[treecmp.In].overloads,
+			makeEvalTupleIn(t, volatility.Immutable))
+	}
+
 	for _, overloads := range cmpOps {
 		_ = overloads.ForEachCmpOp(func(op *CmpOp) error {
 			op.types = ParamTypes{{"left", op.LeftType}, {"right", op.RightType}}
@@ -1857,7 +1871,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append(append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1870,9 +1884,11 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOperators(&ContainsRangeOp{})...),
+		makeRangeElemOperators(&ContainsRangeElemOp{}, true /* rangeOnLeft */)...,
+	)},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append(append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1885,7 +1901,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOperators(&ContainedByRangeOp{})...),
+		makeRangeElemOperators(&ContainedByRangeElemOp{}, false /* rangeOnLeft */)...,
+	)},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1899,11 +1917,11 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), makeRangeOperators(&OverlapsRangeOp{})...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1919,8 +1937,45 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			Volatility: volatility.Immutable,
 		},
 	}},
+	treecmp.Adjacent: {overloads: makeRangeOperators(&AdjacentRangeOp{})},
 })
 
+// makeRangeOperators returns an overload of a comparison operator for each
+// range type, where both operands are ranges of that type.
+func makeRangeOperators(evalOp BinaryEvalOp) []*CmpOp {
+	ops := make([]*CmpOp, len(types.Ranges))
+	for i, t := range types.Ranges {
+		ops[i] = &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     evalOp,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ops
+}
+
+// makeRangeElemOperators returns an overload of a comparison operator for each
+// range type, where one operand is a range of that type and the other is a
+// value of its element type. The range is the left operand if rangeOnLeft is
+// true.
+func makeRangeElemOperators(evalOp BinaryEvalOp, rangeOnLeft bool) []*CmpOp {
+	ops := make([]*CmpOp, len(types.Ranges))
+	for i, t := range types.Ranges {
+		left, right := t, t.RangeContents()
+		if !rangeOnLeft {
+			left, right = right, left
+		}
+		ops[i] = &CmpOp{
+			LeftType:   left,
+			RightType:  right,
+			EvalOp:     evalOp,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ops
+}
+
 func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bool) []*CmpOp {
 	return []*CmpOp{
 		{
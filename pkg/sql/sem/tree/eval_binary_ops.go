@@ -75,6 +75,12 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
+// AdjacentRangeOp is a BinaryEvalOp.
+type AdjacentRangeOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -379,3 +385,15 @@ type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainsRangeElemOp is a BinaryEvalOp.
+type ContainsRangeElemOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// ContainedByRangeElemOp is a BinaryEvalOp.
+type ContainedByRangeElemOp struct{}
@@ -55,6 +55,7 @@ type UnaryOpEvaluator interface {
 
 // UnaryOpEvaluator knows how to evaluate BinaryEvalOps.
 type BinaryOpEvaluator interface {
+	EvalAdjacentRangeOp(context.Context, *AdjacentRangeOp, Datum, Datum) (Datum, error)
 	EvalAppendToMaybeNullArrayOp(context.Context, *AppendToMaybeNullArrayOp, Datum, Datum) (Datum, error)
 	EvalBitAndINetOp(context.Context, *BitAndINetOp, Datum, Datum) (Datum, error)
 	EvalBitAndIntOp(context.Context, *BitAndIntOp, Datum, Datum) (Datum, error)
@@ -75,8 +76,12 @@ type BinaryOpEvaluator interface {
 	EvalConcatVarBitOp(context.Context, *ConcatVarBitOp, Datum, Datum) (Datum, error)
 	EvalContainedByArrayOp(context.Context, *ContainedByArrayOp, Datum, Datum) (Datum, error)
 	EvalContainedByJsonbOp(context.Context, *ContainedByJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainedByRangeElemOp(context.Context, *ContainedByRangeElemOp, Datum, Datum) (Datum, error)
+	EvalContainedByRangeOp(context.Context, *ContainedByRangeOp, Datum, Datum) (Datum, error)
 	EvalContainsArrayOp(context.Context, *ContainsArrayOp, Datum, Datum) (Datum, error)
 	EvalContainsJsonbOp(context.Context, *ContainsJsonbOp, Datum, Datum) (Datum, error)
+	EvalContainsRangeElemOp(context.Context, *ContainsRangeElemOp, Datum, Datum) (Datum, error)
+	EvalContainsRangeOp(context.Context, *ContainsRangeOp, Datum, Datum) (Datum, error)
 	EvalDivDecimalIntOp(context.Context, *DivDecimalIntOp, Datum, Datum) (Datum, error)
 	EvalDivDecimalOp(context.Context, *DivDecimalOp, Datum, Datum) (Datum, error)
 	EvalDivFloatOp(context.Context, *DivFloatOp, Datum, Datum) (Datum, error)
@@ -147,6 +152,7 @@ type BinaryOpEvaluator interface {
 	EvalMultIntervalIntOp(context.Context, *MultIntervalIntOp, Datum, Datum) (Datum, error)
 	EvalOverlapsArrayOp(context.Context, *OverlapsArrayOp, Datum, Datum) (Datum, error)
 	EvalOverlapsINetOp(context.Context, *OverlapsINetOp, Datum, Datum) (Datum, error)
+	EvalOverlapsRangeOp(context.Context, *OverlapsRangeOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntOp(context.Context, *PlusDateIntOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntervalOp(context.Context, *PlusDateIntervalOp, Datum, Datum) (Datum, error)
 	EvalPlusDateTimeOp(context.Context, *PlusDateTimeOp, Datum, Datum) (Datum, error)
@@ -241,6 +247,11 @@ func (op *UnaryMinusIntervalOp) Eval(ctx context.Context, e OpEvaluator, v Datum
 	return e.EvalUnaryMinusIntervalOp(ctx, op, v)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *AdjacentRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalAdjacentRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *AppendToMaybeNullArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalAppendToMaybeNullArrayOp(ctx, op, a, b)
@@ -341,6 +352,16 @@ func (op *ContainedByJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datu
 	return e.EvalContainedByJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainedByRangeElemOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainedByRangeElemOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainedByRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainedByRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *ContainsArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalContainsArrayOp(ctx, op, a, b)
@@ -351,6 +372,16 @@ func (op *ContainsJsonbOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalContainsJsonbOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainsRangeElemOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainsRangeElemOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *ContainsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalContainsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *DivDecimalIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalDivDecimalIntOp(ctx, op, a, b)
@@ -701,6 +732,11 @@ func (op *OverlapsINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalOverlapsINetOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *OverlapsRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalOverlapsRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *PlusDateIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalPlusDateIntOp(ctx, op, a, b)
@@ -421,6 +421,18 @@ func NewTypedIndirectionExpr(expr, index TypedExpr, typ *types.T) *IndirectionEx
 	return node
 }
 
+// NewTypedArraySliceExpr returns a new IndirectionExpr that slices the given
+// array and is verified to be well-typed. At least one of the subscripts must
+// be a slice.
+func NewTypedArraySliceExpr(expr TypedExpr, subscripts ArraySubscripts) *IndirectionExpr {
+	node := &IndirectionExpr{
+		Expr:        expr,
+		Indirection: subscripts,
+	}
+	node.typ = expr.ResolvedType()
+	return node
+}
+
 // NewTypedCollateExpr returns a new CollateExpr that is verified to be well-typed.
 func NewTypedCollateExpr(expr TypedExpr, locale string) *CollateExpr {
 	node := &CollateExpr{
@@ -1249,6 +1261,15 @@ type FuncExpr struct {
 	// is used for any type of aggregation.
 	OrderBy OrderBy
 
+	// InCall is true when the FuncExpr is the procedure invoked by a CALL
+	// statement. Procedures cannot be invoked from any other context.
+	InCall bool
+
+	// Variadic is true when the last argument is marked VARIADIC, as in
+	// f(a, VARIADIC arr). The argument is an array which is passed as-is to
+	// the VARIADIC parameter of the function.
+	Variadic bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1361,7 +1382,17 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if n := len(node.Exprs) - 1; node.Variadic && n >= 0 {
+		if n > 0 {
+			fixed := node.Exprs[:n]
+			ctx.FormatNode(&fixed)
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[n])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
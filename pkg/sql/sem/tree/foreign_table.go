@@ -0,0 +1,121 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreateServer represents a CREATE SERVER statement.
+type CreateServer struct {
+	Name        Name
+	IfNotExists bool
+	// Wrapper is the name of the foreign-data wrapper of the server.
+	Wrapper Name
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	formatGenericOptions(ctx, node.Options)
+}
+
+// DropServer represents a DROP SERVER statement.
+type DropServer struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SERVER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	Table       TableName
+	IfNotExists bool
+	Defs        TableDefs
+	Server      Name
+	Options     KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	formatGenericOptions(ctx, node.Options)
+}
+
+// DropForeignTable represents a DROP FOREIGN TABLE statement.
+type DropForeignTable struct {
+	Names        TableNames
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP FOREIGN TABLE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// formatGenericOptions formats the OPTIONS clause of the statements related
+// to foreign data, whose options are written as `name 'value'` rather than
+// `name = 'value'`. The value of the password option is hidden unless
+// FmtShowPasswords is set.
+func formatGenericOptions(ctx *FmtCtx, options KVOptions) {
+	if len(options) == 0 {
+		return
+	}
+	ctx.WriteString(" OPTIONS (")
+	for i := range options {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.WithFlags(ctx.flags&^FmtMarkRedactionNode, func() {
+			ctx.FormatNode(&options[i].Key)
+		})
+		ctx.WriteByte(' ')
+		if options[i].Key == "password" && !ctx.HasFlags(FmtShowPasswords) {
+			ctx.WriteString(PasswordSubstitution)
+		} else {
+			ctx.FormatNode(options[i].Value)
+		}
+	}
+	ctx.WriteByte(')')
+}
@@ -276,7 +276,9 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 	paramTypes []*types.T, explicitSchema string, searchPath SearchPath,
 ) (QualifiedOverload, error) {
 	matched := func(ol QualifiedOverload, schema string) bool {
-		return schema == ol.Schema && (paramTypes == nil || ol.params().Match(paramTypes))
+		// Compare against the declared parameter types, so that the array type
+		// of a VARIADIC parameter is matched as-is.
+		return schema == ol.Schema && (paramTypes == nil || ol.Types.Match(paramTypes))
 	}
 	typeNames := func() string {
 		ns := make([]string, len(paramTypes))
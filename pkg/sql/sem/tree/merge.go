@@ -0,0 +1,109 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// Merge represents a MERGE statement.
+type Merge struct {
+	With   *With
+	Target TableExpr
+	Source TableExpr
+	On     Expr
+	Whens  MergeWhens
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Merge) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.With)
+	ctx.WriteString("MERGE INTO ")
+	ctx.FormatNode(node.Target)
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(node.Source)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.On)
+	for _, w := range node.Whens {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(w)
+	}
+}
+
+// MergeActionType is the type of the action of a WHEN clause of a MERGE
+// statement.
+type MergeActionType int
+
+const (
+	// MergeActionDoNothing is the DO NOTHING action.
+	MergeActionDoNothing MergeActionType = iota
+	// MergeActionUpdate is the UPDATE SET action of WHEN MATCHED clauses.
+	MergeActionUpdate
+	// MergeActionDelete is the DELETE action of WHEN MATCHED clauses.
+	MergeActionDelete
+	// MergeActionInsert is the INSERT action of WHEN NOT MATCHED clauses.
+	MergeActionInsert
+)
+
+// MergeWhens represents the list of WHEN clauses of a MERGE statement.
+type MergeWhens []*MergeWhen
+
+// MergeWhen represents a WHEN [NOT] MATCHED clause of a MERGE statement.
+type MergeWhen struct {
+	// Matched is true for WHEN MATCHED clauses, which apply to the target rows
+	// that are joined to a source row, and false for WHEN NOT MATCHED clauses,
+	// which apply to the source rows that are not joined to any target row.
+	Matched bool
+	// Cond is the condition of the clause, or nil if there is none.
+	Cond   Expr
+	Action MergeActionType
+	// Exprs contains the SET expressions of an UPDATE action.
+	Exprs UpdateExprs
+	// Columns contains the target columns of an INSERT action, or nil if they
+	// were not specified.
+	Columns NameList
+	// Values contains the values of an INSERT action. It is nil for INSERT
+	// DEFAULT VALUES.
+	Values Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhen) Format(ctx *FmtCtx) {
+	if node.Matched {
+		ctx.WriteString("WHEN MATCHED")
+	} else {
+		ctx.WriteString("WHEN NOT MATCHED")
+	}
+	if node.Cond != nil {
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.Cond)
+	}
+	ctx.WriteString(" THEN ")
+	switch node.Action {
+	case MergeActionDoNothing:
+		ctx.WriteString("DO NOTHING")
+	case MergeActionUpdate:
+		ctx.WriteString("UPDATE SET ")
+		ctx.FormatNode(&node.Exprs)
+	case MergeActionDelete:
+		ctx.WriteString("DELETE")
+	case MergeActionInsert:
+		ctx.WriteString("INSERT")
+		if node.Columns != nil {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteByte(')')
+		}
+		if node.Values == nil {
+			ctx.WriteString(" DEFAULT VALUES")
+		} else {
+			ctx.WriteString(" VALUES (")
+			ctx.FormatNode(&node.Values)
+			ctx.WriteByte(')')
+		}
+	}
+}
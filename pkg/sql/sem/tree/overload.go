@@ -188,13 +188,88 @@ type Overload struct {
 	UDFContainsOnlySignature bool
 	// Body is the SQL string body of a user-defined function.
 	Body string
+	// Language is the language of Body. It is only set if
+	// UDFContainsOnlySignature is false.
+	Language FunctionLanguage
 	// ReturnSet is set to true when a user-defined function is defined to return
 	// a set of values.
 	ReturnSet bool
+	// Variadic is set to true when the last parameter of a user-defined
+	// function is VARIADIC. Types contains the declared array type of that
+	// parameter, and calls may pass any number of arguments of its element
+	// type in its place.
+	Variadic bool
+	// IsProcedure is set to true when this is a stored procedure overload.
+	// Procedures can only be invoked with CALL statements.
+	IsProcedure bool
+	// UDA is set when this is a user-defined aggregate overload. It is only set
+	// if UDFContainsOnlySignature is false.
+	UDA *UserDefinedAggregate
+	// SessionOverrides is set when the body of a user-defined function is
+	// executed as a different user (SECURITY DEFINER) or with different session
+	// variable values (SET clauses) than those of the caller. It is only set if
+	// UDFContainsOnlySignature is false.
+	SessionOverrides *RoutineSessionOverrides
+}
+
+// UserDefinedAggregate describes a user-defined aggregate function, which is
+// computed by repeatedly calling a state transition function, and then calling
+// an optional final function on the final state.
+type UserDefinedAggregate struct {
+	// StateFuncOID is the OID of the state transition function. It is called
+	// with the current state followed by the aggregated arguments, and returns
+	// the new state.
+	StateFuncOID oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// FinalFuncOID is the OID of the final function, which is called with the
+	// final state and returns the result of the aggregate. It is zero if the
+	// aggregate has no final function, in which case the final state is the
+	// result.
+	FinalFuncOID oid.Oid
+	// InitCond is the string representation of the initial state, or nil if
+	// the initial state is NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
-func (b Overload) params() TypeList { return b.Types }
+func (b Overload) params() TypeList {
+	if b.Variadic {
+		return b.variadicParams()
+	}
+	return b.Types
+}
+
+// variadicParams returns the parameters of a variadic user-defined function as
+// they are matched against the arguments of a call.
+func (b Overload) variadicParams() VariadicType {
+	paramTypes := b.Types.(ParamTypes)
+	fixedTypes := make([]*types.T, len(paramTypes)-1)
+	for i := range fixedTypes {
+		fixedTypes[i] = paramTypes[i].Typ
+	}
+	return VariadicType{
+		FixedTypes: fixedTypes,
+		VarType:    paramTypes[len(paramTypes)-1].Typ.ArrayContents(),
+	}
+}
+
+// variadicArrayOverloads returns the variadic overloads which can be called
+// with an array passed as the VARIADIC argument, as in f(VARIADIC arr). The
+// returned overloads are copies which are not variadic, so that their
+// parameters are matched against the declared parameter types.
+func variadicArrayOverloads(overloads []QualifiedOverload) []QualifiedOverload {
+	var res []QualifiedOverload
+	for _, qo := range overloads {
+		if !qo.Variadic {
+			continue
+		}
+		ol := *qo.Overload
+		ol.Variadic = false
+		res = append(res, QualifiedOverload{Schema: qo.Schema, Overload: &ol})
+	}
+	return res
+}
 
 // returnType implements the overloadImpl interface.
 func (b Overload) returnType() ReturnTyper { return b.ReturnType }
@@ -1313,6 +1388,9 @@ func getFuncSig(expr *FuncExpr, typedInputExprs []TypedExpr, desiredType *types.
 	for _, expr := range typedInputExprs {
 		typeNames = append(typeNames, expr.ResolvedType().String())
 	}
+	if n := len(typeNames) - 1; expr.Variadic && n >= 0 {
+		typeNames[n] = "VARIADIC " + typeNames[n]
+	}
 	var desStr string
 	if desiredType.Family() != types.AnyFamily {
 		desStr = fmt.Sprintf(" (desired <%s>)", desiredType)
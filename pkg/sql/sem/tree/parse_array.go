@@ -12,6 +12,7 @@ package tree
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -19,13 +20,19 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 var enclosingError = pgerror.Newf(pgcode.InvalidTextRepresentation, "array must be enclosed in { and }")
 var extraTextError = pgerror.Newf(pgcode.InvalidTextRepresentation, "extra text after closing right brace")
-var nestedArraysNotSupportedError = unimplemented.NewWithIssueDetail(32552, "strcast", "nested arrays not supported")
+var unexpectedNestedArrayError = pgerror.Newf(pgcode.InvalidTextRepresentation, "nested arrays require a multi-dimensional array type")
 var malformedError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed array")
+var dimensionsError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed array dimensions")
+var dimensionsMismatchError = pgerror.Newf(pgcode.InvalidTextRepresentation, "array dimensions incompatible with array literal")
+
+// ErrArrayLowerBound is returned when an array with a lower bound other than 1
+// is decoded. Arrays do not store their lower bounds: the subscripts of every
+// dimension always start at 1.
+var ErrArrayLowerBound = pgerror.Newf(pgcode.FeatureNotSupported, "array lower bounds other than 1 are not supported")
 
 func isQuoteChar(ch byte) bool {
 	return ch == '"'
@@ -121,8 +128,6 @@ type parseState struct {
 	s                string
 	ctx              ParseTimeContext
 	dependsOnContext bool
-	result           *DArray
-	t                *types.T
 }
 
 func (p *parseState) advance() {
@@ -157,13 +162,28 @@ func (p *parseState) parseUnquotedString() (string, error) {
 	return trimSpaceInParseArray(out), nil
 }
 
-func (p *parseState) parseElement() error {
+// parseElement parses a single array element of type t and appends it to
+// result. If t is itself an array type, the element must be a nested,
+// brace-enclosed array.
+func (p *parseState) parseElement(result *DArray, t *types.T) error {
 	var next string
 	var err error
 	r := p.peek()
+	if t.Family() == types.ArrayFamily {
+		// The elements of a multi-dimensional array are sub-arrays, which
+		// cannot be NULL.
+		if r != '{' {
+			return malformedError
+		}
+		sub, err := p.parseArray(t.ArrayContents())
+		if err != nil {
+			return err
+		}
+		return result.Append(sub)
+	}
 	switch r {
 	case '{':
-		return nestedArraysNotSupportedError
+		return unexpectedNestedArrayError
 	case '"':
 		p.advance()
 		next, err = p.parseQuotedString()
@@ -180,18 +200,96 @@ func (p *parseState) parseElement() error {
 			return err
 		}
 		if strings.EqualFold(next, "null") {
-			return p.result.Append(DNull)
+			return result.Append(DNull)
 		}
 	}
 
-	d, dependsOnContext, err := ParseAndRequireString(p.t, next, p.ctx)
+	d, dependsOnContext, err := ParseAndRequireString(t, next, p.ctx)
 	if err != nil {
 		return err
 	}
 	if dependsOnContext {
 		p.dependsOnContext = true
 	}
-	return p.result.Append(d)
+	return result.Append(d)
+}
+
+// parseDimensions parses the optional dimension decoration which precedes an
+// array, as in '[1:2][1:3]={{1,2,3},{4,5,6}}', and returns the length of each
+// dimension. The lower bound of a dimension defaults to 1 and cannot be
+// anything else, see ErrArrayLowerBound.
+func (p *parseState) parseDimensions() ([]int, error) {
+	p.eatWhitespace()
+	var dims []int
+	for p.peek() == '[' {
+		p.advance()
+		end := strings.IndexByte(p.s, ']')
+		if end < 0 {
+			return nil, dimensionsError
+		}
+		lower, upper := "1", p.s[:end]
+		if i := strings.IndexByte(upper, ':'); i >= 0 {
+			lower, upper = upper[:i], upper[i+1:]
+		}
+		p.s = p.s[end+1:]
+		lb, err := strconv.Atoi(strings.TrimSpace(lower))
+		if err != nil {
+			return nil, dimensionsError
+		}
+		ub, err := strconv.Atoi(strings.TrimSpace(upper))
+		if err != nil {
+			return nil, dimensionsError
+		}
+		if lb != 1 {
+			return nil, ErrArrayLowerBound
+		}
+		if ub < lb {
+			return nil, dimensionsError
+		}
+		dims = append(dims, ub-lb+1)
+		p.eatWhitespace()
+	}
+	if dims == nil {
+		return nil, nil
+	}
+	if p.peek() != '=' {
+		return nil, dimensionsError
+	}
+	p.advance()
+	return dims, nil
+}
+
+// parseArray parses a brace-enclosed array with elements of type t.
+func (p *parseState) parseArray(t *types.T) (*DArray, error) {
+	result := NewDArray(t)
+	p.eatWhitespace()
+	if p.peek() != '{' {
+		return nil, enclosingError
+	}
+	p.advance()
+	p.eatWhitespace()
+	if p.peek() != '}' {
+		if err := p.parseElement(result, t); err != nil {
+			return nil, err
+		}
+		p.eatWhitespace()
+		for string(p.peek()) == t.Delimiter() {
+			p.advance()
+			p.eatWhitespace()
+			if err := p.parseElement(result, t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	p.eatWhitespace()
+	if p.eof() {
+		return nil, enclosingError
+	}
+	if p.peek() != '}' {
+		return nil, malformedError
+	}
+	p.advance()
+	return result, nil
 }
 
 // ParseDArrayFromString parses the string-form of constructing arrays, handling
@@ -219,43 +317,39 @@ func doParseDArrayFromString(
 	ctx ParseTimeContext, s string, t *types.T,
 ) (_ *DArray, dependsOnContext bool, _ error) {
 	parser := parseState{
-		s:      s,
-		ctx:    ctx,
-		result: NewDArray(t),
-		t:      t,
+		s:   s,
+		ctx: ctx,
 	}
 
-	parser.eatWhitespace()
-	if parser.peek() != '{' {
-		return nil, false, enclosingError
+	dims, err := parser.parseDimensions()
+	if err != nil {
+		return nil, false, err
+	}
+	result, err := parser.parseArray(t)
+	if err != nil {
+		return nil, false, err
 	}
-	parser.advance()
 	parser.eatWhitespace()
-	if parser.peek() != '}' {
-		if err := parser.parseElement(); err != nil {
-			return nil, false, err
+	if !parser.eof() {
+		return nil, false, extraTextError
+	}
+	if dims != nil {
+		// The dimensions must match the array literal.
+		resultDims := result.Dimensions()
+		if len(dims) != len(resultDims) {
+			return nil, false, dimensionsMismatchError
 		}
-		parser.eatWhitespace()
-		for string(parser.peek()) == t.Delimiter() {
-			parser.advance()
-			parser.eatWhitespace()
-			if err := parser.parseElement(); err != nil {
-				return nil, false, err
+		for i := range dims {
+			if dims[i] != resultDims[i] {
+				return nil, false, dimensionsMismatchError
 			}
 		}
 	}
-	parser.eatWhitespace()
-	if parser.eof() {
-		return nil, false, enclosingError
-	}
-	if parser.peek() != '}' {
-		return nil, false, malformedError
-	}
-	parser.advance()
-	parser.eatWhitespace()
-	if !parser.eof() {
-		return nil, false, extraTextError
+	// A multi-dimensional array with an empty sub-array contains no elements,
+	// so it is equivalent to the empty array, as in Postgres.
+	if t.Family() == types.ArrayFamily && result.Len() > 0 && result.Dimensions() == nil {
+		result = NewDArray(t)
 	}
 
-	return parser.result, parser.dependsOnContext, nil
+	return result, parser.dependsOnContext, nil
 }
@@ -120,6 +120,22 @@ lo}`, types.String, Datums{NewDString(`hel`), NewDString(`lo`)}},
 				NewDTuple(tupleOfTwoInts, NewDInt(3), DNull),
 			},
 		},
+
+		// Multi-dimensional arrays.
+		{`{{1,2},{3,NULL}}`, intArray, Datums{
+			makeTestDArray(types.Int, NewDInt(1), NewDInt(2)),
+			makeTestDArray(types.Int, NewDInt(3), DNull),
+		}},
+		{` { { 1 } , {"2"} } `, intArray, Datums{
+			makeTestDArray(types.Int, NewDInt(1)),
+			makeTestDArray(types.Int, NewDInt(2)),
+		}},
+		{`{{{a,b}},{{c,d}}}`, types.MakeArray(types.StringArray), Datums{
+			makeTestDArray(types.StringArray, makeTestDArray(types.String, NewDString("a"), NewDString("b"))),
+			makeTestDArray(types.StringArray, makeTestDArray(types.String, NewDString("c"), NewDString("d"))),
+		}},
+		// A multi-dimensional array with an empty sub-array is empty.
+		{`{{},{}}`, intArray, Datums{}},
 	}
 	for _, td := range testData {
 		t.Run(td.str, func(t *testing.T) {
@@ -140,6 +156,20 @@ lo}`, types.String, Datums{NewDString(`hel`), NewDString(`lo`)}},
 	}
 }
 
+var intArray = types.MakeArray(types.Int)
+
+// makeTestDArray returns an array with elements of type typ containing the
+// given elements.
+func makeTestDArray(typ *types.T, elems ...Datum) *DArray {
+	a := NewDArray(typ)
+	for _, e := range elems {
+		if err := a.Append(e); err != nil {
+			panic(err)
+		}
+	}
+	return a
+}
+
 type noopUnwrapCompareContext struct {
 	CompareContext
 }
@@ -218,8 +248,12 @@ func TestParseArrayError(t *testing.T) {
 		{`{,}`, types.Int, `could not parse "{,}" as type int[]: malformed array`},
 		{`{}{}`, types.Int, `could not parse "{}{}" as type int[]: extra text after closing right brace`},
 		{`{} {}`, types.Int, `could not parse "{} {}" as type int[]: extra text after closing right brace`},
-		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: unimplemented: nested arrays not supported`},
-		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: unimplemented: nested arrays not supported`},
+		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: nested arrays require a multi-dimensional array type`},
+		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: nested arrays require a multi-dimensional array type`},
+		{`{{1},2}`, intArray, `could not parse "{{1},2}" as type int[][]: malformed array`},
+		{`{{1},NULL}`, intArray, `could not parse "{{1},NULL}" as type int[][]: malformed array`},
+		{`{{1},{2,3}}`, intArray, `could not parse "{{1},{2,3}}" as type int[][]: multidimensional arrays must have array expressions with matching dimensions`},
+		{`{{1},{2}`, intArray, `could not parse "{{1},{2}" as type int[][]: array must be enclosed in { and }`},
 		{`{hello}`, types.Int, `could not parse "{hello}" as type int[]: could not parse "hello" as type int: strconv.ParseInt: parsing "hello": invalid syntax`},
 		{`{"hello}`, types.String, `could not parse "{\"hello}" as type string[]: malformed array`},
 		// It might be unnecessary to disallow this, but Postgres does.
@@ -0,0 +1,154 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ParseDRangeFromString parses the string-form of a range into a DRange of
+// the given range type. The format matches the text format of Postgres ranges,
+// for example:
+//
+//	empty
+//	[1,10)
+//	("2020-01-01 00:00:00",]
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParser{s: s}
+	lowerStr, upperStr, lowerInc, upperInc, empty, detail := p.parse()
+	if detail != "" {
+		return nil, false, errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s), detail,
+		)
+	}
+	if empty {
+		return NewDEmptyRange(t), false, nil
+	}
+	parseBound := func(b *string) (Datum, error) {
+		if b == nil {
+			return nil, nil
+		}
+		d, dependsOn, err := ParseAndRequireString(t.RangeContents(), *b, ctx)
+		dependsOnContext = dependsOnContext || dependsOn
+		return d, err
+	}
+	lower, err := parseBound(lowerStr)
+	if err != nil {
+		return nil, false, err
+	}
+	upper, err := parseBound(upperStr)
+	if err != nil {
+		return nil, false, err
+	}
+	r, err := NewDRange(t, lower, upper, lowerInc, upperInc)
+	return r, dependsOnContext, err
+}
+
+// rangeParser parses the text format of a range.
+type rangeParser struct {
+	s   string
+	pos int
+}
+
+func (p *rangeParser) skipSpace() {
+	for p.pos < len(p.s) && asciiSpace[p.s[p.pos]] == 1 {
+		p.pos++
+	}
+}
+
+// parse returns the string forms of the bounds of the range, which are nil
+// for infinite bounds. If the range is malformed, a non-empty error detail is
+// returned.
+func (p *rangeParser) parse() (
+	lower, upper *string, lowerInc, upperInc, empty bool, detail string,
+) {
+	p.skipSpace()
+	if len(p.s)-p.pos >= len("empty") && strings.EqualFold(p.s[p.pos:p.pos+len("empty")], "empty") {
+		p.pos += len("empty")
+		p.skipSpace()
+		if p.pos != len(p.s) {
+			return nil, nil, false, false, false, "Junk after \"empty\" key word."
+		}
+		return nil, nil, false, false, true, ""
+	}
+	if p.pos == len(p.s) || (p.s[p.pos] != '[' && p.s[p.pos] != '(') {
+		return nil, nil, false, false, false, "Missing left parenthesis or bracket."
+	}
+	lowerInc = p.s[p.pos] == '['
+	p.pos++
+	if lower, detail = p.parseBound(); detail != "" {
+		return nil, nil, false, false, false, detail
+	}
+	if p.pos == len(p.s) || p.s[p.pos] != ',' {
+		return nil, nil, false, false, false, "Missing comma after lower bound."
+	}
+	p.pos++
+	if upper, detail = p.parseBound(); detail != "" {
+		return nil, nil, false, false, false, detail
+	}
+	if p.pos == len(p.s) || (p.s[p.pos] != ']' && p.s[p.pos] != ')') {
+		return nil, nil, false, false, false, "Too many commas."
+	}
+	upperInc = p.s[p.pos] == ']'
+	p.pos++
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, nil, false, false, false, "Junk after right parenthesis or bracket."
+	}
+	return lower, upper, lowerInc, upperInc, false, ""
+}
+
+// parseBound parses a single range bound, stopping at the next unquoted
+// delimiter. An empty, unquoted bound is infinite, and nil is returned. If the
+// bound is malformed, a non-empty error detail is returned.
+func (p *rangeParser) parseBound() (_ *string, detail string) {
+	var buf strings.Builder
+	quoted, inQuote := false, false
+	for ; p.pos < len(p.s); p.pos++ {
+		ch := p.s[p.pos]
+		switch {
+		case ch == '\\':
+			p.pos++
+			if p.pos == len(p.s) {
+				return nil, "Unexpected end of input."
+			}
+			buf.WriteByte(p.s[p.pos])
+		case ch == '"':
+			if inQuote && p.pos+1 < len(p.s) && p.s[p.pos+1] == '"' {
+				// A doubled quote inside a quoted bound is a literal quote.
+				buf.WriteByte('"')
+				p.pos++
+			} else {
+				inQuote = !inQuote
+				quoted = true
+			}
+		case !inQuote && (ch == ',' || ch == ')' || ch == ']'):
+			if !quoted && buf.Len() == 0 {
+				return nil, ""
+			}
+			res := buf.String()
+			return &res, ""
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return nil, "Unexpected end of input."
+}
@@ -115,8 +115,6 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	case oid.T_int2vector, oid.T_oidvector:
 		// vectors are serialized as a string of space-separated values.
 		sep := ""
-		// TODO(justin): add a test for nested arrays when #32552 is
-		// addressed.
 		for _, d := range d.Array {
 			ctx.WriteString(sep)
 			ctx.FormatNode(d)
@@ -128,6 +126,16 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	if ctx.HasFlags(FmtPGCatalog) {
 		ctx.WriteByte('\'')
 	}
+	d.pgwireFormatElements(ctx)
+	if ctx.HasFlags(FmtPGCatalog) {
+		ctx.WriteByte('\'')
+	}
+}
+
+// pgwireFormatElements writes the brace-enclosed elements of the array. The
+// sub-arrays of a multi-dimensional array are written in the same way, without
+// any quoting, e.g. {{1,2},{3,4}}.
+func (d *DArray) pgwireFormatElements(ctx *FmtCtx) {
 	ctx.WriteByte('{')
 	delimiter := ""
 	for _, v := range d.Array {
@@ -135,6 +143,8 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 		switch dv := UnwrapDOidWrapper(v).(type) {
 		case dNull:
 			ctx.WriteString("NULL")
+		case *DArray:
+			dv.pgwireFormatElements(ctx)
 		case *DString:
 			pgwireFormatStringInArray(ctx, string(*dv))
 		case *DCollatedString:
@@ -155,12 +165,29 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 		delimiter = d.ParamTyp.Delimiter()
 	}
 	ctx.WriteByte('}')
-	if ctx.HasFlags(FmtPGCatalog) {
-		ctx.WriteByte('\'')
+}
+
+// formatStringInRange writes a range bound to buf, quoting it if necessary. As
+// in tuples, the special double quote and backslash characters are doubled.
+func formatStringInRange(buf *bytes.Buffer, in string) {
+	quote := in == "" || rangeQuoteSet.in(in)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range in {
+		if r == '"' || r == '\\' {
+			buf.WriteByte(byte(r))
+			buf.WriteByte(byte(r))
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	if quote {
+		buf.WriteByte('"')
 	}
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -172,6 +199,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 // PgwireFormatFloat returns a []byte representing a float according to
@@ -0,0 +1,212 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+)
+
+// PolicyType indicates how a row-level security policy is combined with the
+// other policies of a table.
+type PolicyType int
+
+const (
+	// PolicyTypePermissive policies are combined using OR: a row is visible if
+	// any of the permissive policies allows it.
+	PolicyTypePermissive PolicyType = iota
+	// PolicyTypeRestrictive policies are combined using AND: a row is visible
+	// only if all the restrictive policies allow it.
+	PolicyTypeRestrictive
+)
+
+// Format implements the NodeFormatter interface.
+func (node PolicyType) Format(ctx *FmtCtx) {
+	switch node {
+	case PolicyTypePermissive:
+		ctx.WriteString("PERMISSIVE")
+	case PolicyTypeRestrictive:
+		ctx.WriteString("RESTRICTIVE")
+	default:
+		panic(pgerror.Newf(pgcode.InvalidParameterValue, "unknown policy type %d", node))
+	}
+}
+
+// PolicyTypeValue allows the conversion from a tree.PolicyType to a
+// semenumpb.PolicyType.
+var PolicyTypeValue = [...]semenumpb.PolicyType{
+	PolicyTypePermissive:  semenumpb.PolicyType_PERMISSIVE,
+	PolicyTypeRestrictive: semenumpb.PolicyType_RESTRICTIVE,
+}
+
+// PolicyCommand is the kind of statement to which a row-level security
+// policy applies.
+type PolicyCommand int
+
+const (
+	// PolicyCommandAll applies the policy to all statements.
+	PolicyCommandAll PolicyCommand = iota
+	// PolicyCommandSelect applies the policy to SELECT.
+	PolicyCommandSelect
+	// PolicyCommandInsert applies the policy to INSERT.
+	PolicyCommandInsert
+	// PolicyCommandUpdate applies the policy to UPDATE.
+	PolicyCommandUpdate
+	// PolicyCommandDelete applies the policy to DELETE.
+	PolicyCommandDelete
+)
+
+// Format implements the NodeFormatter interface.
+func (node PolicyCommand) Format(ctx *FmtCtx) {
+	switch node {
+	case PolicyCommandAll:
+		ctx.WriteString("ALL")
+	case PolicyCommandSelect:
+		ctx.WriteString("SELECT")
+	case PolicyCommandInsert:
+		ctx.WriteString("INSERT")
+	case PolicyCommandUpdate:
+		ctx.WriteString("UPDATE")
+	case PolicyCommandDelete:
+		ctx.WriteString("DELETE")
+	default:
+		panic(pgerror.Newf(pgcode.InvalidParameterValue, "unknown policy command %d", node))
+	}
+}
+
+// PolicyCommandValue allows the conversion from a tree.PolicyCommand to a
+// semenumpb.PolicyCommand.
+var PolicyCommandValue = [...]semenumpb.PolicyCommand{
+	PolicyCommandAll:    semenumpb.PolicyCommand_POLICY_ALL,
+	PolicyCommandSelect: semenumpb.PolicyCommand_POLICY_SELECT,
+	PolicyCommandInsert: semenumpb.PolicyCommand_POLICY_INSERT,
+	PolicyCommandUpdate: semenumpb.PolicyCommand_POLICY_UPDATE,
+	PolicyCommandDelete: semenumpb.PolicyCommand_POLICY_DELETE,
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	Name    Name
+	Table   *UnresolvedObjectName
+	Type    PolicyType
+	Command PolicyCommand
+	// Roles is the list of roles to which the policy applies. An empty list
+	// applies the policy to all roles.
+	Roles RoleSpecList
+	// Using is the expression which rows must satisfy to be visible, or nil
+	// if there is none.
+	Using Expr
+	// WithCheck is the expression which new rows must satisfy, or nil if there
+	// is none.
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.Type != PolicyTypePermissive {
+		ctx.WriteString(" AS ")
+		ctx.FormatNode(node.Type)
+	}
+	if node.Command != PolicyCommandAll {
+		ctx.WriteString(" FOR ")
+		ctx.FormatNode(node.Command)
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteByte(')')
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteByte(')')
+	}
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	IfExists     bool
+	Name         Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// RowLevelSecurityMode is the change to the row-level security settings of a
+// table made by an ALTER TABLE command.
+type RowLevelSecurityMode int
+
+const (
+	// RowLevelSecurityEnable enables row-level security on the table.
+	RowLevelSecurityEnable RowLevelSecurityMode = iota
+	// RowLevelSecurityDisable disables row-level security on the table.
+	RowLevelSecurityDisable
+	// RowLevelSecurityForce applies row-level security to the owner of the
+	// table as well.
+	RowLevelSecurityForce
+	// RowLevelSecurityNoForce exempts the owner of the table from row-level
+	// security.
+	RowLevelSecurityNoForce
+)
+
+var rowLevelSecurityModeName = [...]string{
+	RowLevelSecurityEnable:  "ENABLE",
+	RowLevelSecurityDisable: "DISABLE",
+	RowLevelSecurityForce:   "FORCE",
+	RowLevelSecurityNoForce: "NO FORCE",
+}
+
+func (m RowLevelSecurityMode) String() string {
+	return rowLevelSecurityModeName[m]
+}
+
+// AlterTableRowLevelSecurity represents an ALTER TABLE {ENABLE | DISABLE |
+// FORCE | NO FORCE} ROW LEVEL SECURITY command.
+type AlterTableRowLevelSecurity struct {
+	Mode RowLevelSecurityMode
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableRowLevelSecurity) TelemetryName() string {
+	return strings.ReplaceAll(strings.ToLower(node.Mode.String()), " ", "_") + "_row_level_security"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableRowLevelSecurity) Format(ctx *FmtCtx) {
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Mode.String())
+	ctx.WriteString(" ROW LEVEL SECURITY")
+}
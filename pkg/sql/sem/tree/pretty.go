@@ -1229,6 +1229,9 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 	if node.Tuple {
 		d = p.bracket("(", d, ")")
 	}
+	for i := range node.Fields {
+		d = pretty.Concat(d, pretty.Concat(pretty.Text("."), p.Doc(&node.Fields[i])))
+	}
 	e := node.Expr
 	if p.Simplify {
 		e = StripParens(e)
@@ -1257,6 +1260,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	}
 	title = pretty.ConcatSpace(title, p.Doc(&node.Table))
 
+	if node.PartitionOf != nil {
+		return pretty.ConcatSpace(title, p.Doc(node.PartitionOf))
+	}
 	if node.As() {
 		if len(node.Defs) > 0 {
 			title = pretty.ConcatSpace(title,
@@ -1272,6 +1278,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	clauses := make([]pretty.Doc, 0, 4)
 	if node.As() {
 		clauses = append(clauses, p.Doc(node.AsSource))
+		if node.AsWithNoData {
+			clauses = append(clauses, pretty.Keyword("WITH NO DATA"))
+		}
 	}
 	if node.PartitionByTable != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByTable))
@@ -1297,7 +1306,7 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE [TEMP] VIEW name ( ... ) AS
+	// CREATE [TEMP] [RECURSIVE] VIEW name ( ... ) AS
 	//     SELECT ...
 	//
 	title := pretty.Keyword("CREATE")
@@ -1310,6 +1319,9 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	if node.Materialized {
 		title = pretty.ConcatSpace(title, pretty.Keyword("MATERIALIZED"))
 	}
+	if node.Recursive {
+		title = pretty.ConcatSpace(title, pretty.Keyword("RECURSIVE"))
+	}
 	title = pretty.ConcatSpace(title, pretty.Keyword("VIEW"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
@@ -1489,6 +1501,10 @@ func (node *PartitionBy) docInner(p *PrettyCfg, kw string) pretty.Doc {
 	if node == nil {
 		return pretty.Keyword(kw + `NOTHING`)
 	}
+	if node.IsDeclarative() {
+		return pretty.ConcatSpace(pretty.Keyword(kw+string(node.Declarative)),
+			p.bracket("(", p.Doc(&node.Fields), ")"))
+	}
 	if len(node.List) > 0 {
 		kw += `LIST`
 	} else if len(node.Range) > 0 {
@@ -1757,6 +1773,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE]
 	//
@@ -1766,6 +1783,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE]
 	//
@@ -1797,6 +1815,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrable.IsDeferrable() {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.String()))
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1817,6 +1838,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1824,8 +1846,9 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
-	clauses := make([]pretty.Doc, 0, 4)
+	clauses := make([]pretty.Doc, 0, 5)
 	title := pretty.ConcatSpace(
 		pretty.Keyword("FOREIGN KEY"),
 		p.bracket("(", p.Doc(&node.FromCols), ")"))
@@ -1850,6 +1873,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrable.IsDeferrable() {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.String()))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2023,7 +2050,7 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if node.References.Col != "" {
 			fkHead = pretty.ConcatSpace(fkHead, p.bracket("(", p.Doc(&node.References.Col), ")"))
 		}
-		fkDetails := make([]pretty.Doc, 0, 2)
+		fkDetails := make([]pretty.Doc, 0, 3)
 		// We omit MATCH SIMPLE because it is the default.
 		if node.References.Match != MatchSimple {
 			fkDetails = append(fkDetails, pretty.Keyword(node.References.Match.String()))
@@ -2031,6 +2058,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if node.References.Deferrable.IsDeferrable() {
+			fkDetails = append(fkDetails, pretty.Keyword(node.References.Deferrable.String()))
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
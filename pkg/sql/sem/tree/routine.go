@@ -13,6 +13,7 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -27,6 +28,12 @@ type RoutinePlanFn func(
 // import cycles.
 type RoutinePlan interface{}
 
+// RoutineProgram represents a program in a procedural language that controls
+// the execution of the statements in a routine. It currently maps to
+// *plpgsqltree.Program. We use the empty interface here rather than
+// *plpgsqltree.Program to avoid import cycles.
+type RoutineProgram interface{}
+
 // RoutineExecFactory is a factory used to build optimizer expressions into
 // execution plans for statements within a RoutineExpr. It currently maps to
 // exec.Factory. We use the empty interface here rather than exec.Factory to
@@ -66,6 +73,39 @@ type RoutineExpr struct {
 	// its inputs are NULL. If false, the function will not be evaluated in the
 	// presence of null inputs, and will instead evaluate directly to NULL.
 	CalledOnNullInput bool
+
+	// SessionOverrides, if non-nil, describes changes to the session that are
+	// in effect while the statements in the routine are executed.
+	SessionOverrides *RoutineSessionOverrides
+
+	// Program, if non-nil, is the procedural program that determines which of
+	// the statements in the routine are executed, and in which order. The
+	// arguments of the statements are the values of the variables of the
+	// program, rather than the arguments of the routine. If Program is nil,
+	// the statements are executed sequentially.
+	Program RoutineProgram
+}
+
+// RoutineSessionOverrides describes changes to the session that are in effect
+// while the body of a routine is executed.
+type RoutineSessionOverrides struct {
+	// User, if defined, is the user whose privileges are used to execute the
+	// body of the routine instead of those of the invoking user. It is set for
+	// SECURITY DEFINER functions.
+	User username.SQLUsername
+
+	// Settings are session variable values that are set for the duration of
+	// the routine invocation.
+	Settings []RoutineSetting
+}
+
+// RoutineSetting is a session variable value that is set for the duration of
+// a routine invocation.
+type RoutineSetting struct {
+	Name string
+	// Value is the string representation of the value, as accepted by the
+	// setter of the session variable.
+	Value string
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -77,6 +117,8 @@ func NewTypedRoutineExpr(
 	typ *types.T,
 	enableStepping bool,
 	calledOnNullInput bool,
+	sessionOverrides *RoutineSessionOverrides,
+	program RoutineProgram,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -86,6 +128,8 @@ func NewTypedRoutineExpr(
 		EnableStepping:    enableStepping,
 		CalledOnNullInput: calledOnNullInput,
 		Name:              name,
+		SessionOverrides:  sessionOverrides,
+		Program:           program,
 	}
 }
 
@@ -758,6 +758,50 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetsType is the kind of a GroupingSets item.
+type GroupingSetsType int
+
+const (
+	// ExplicitGroupingSets represents GROUPING SETS (...), whose elements are
+	// GROUP BY items.
+	ExplicitGroupingSets GroupingSetsType = iota
+	// RollupGroupingSets represents ROLLUP (...), which groups by every prefix
+	// of its elements.
+	RollupGroupingSets
+	// CubeGroupingSets represents CUBE (...), which groups by every subset of
+	// its elements.
+	CubeGroupingSets
+)
+
+var groupingSetsTypeName = [...]string{
+	ExplicitGroupingSets: "GROUPING SETS",
+	RollupGroupingSets:   "ROLLUP",
+	CubeGroupingSets:     "CUBE",
+}
+
+func (t GroupingSetsType) String() string {
+	return groupingSetsTypeName[t]
+}
+
+// GroupingSets represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each element of ROLLUP and CUBE is an expression, or a tuple of
+// expressions which are grouped by together. Each element of GROUPING SETS is
+// a GROUP BY item, where an empty tuple stands for the empty grouping set.
+type GroupingSets struct {
+	Type  GroupingSetsType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSets) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+func (node *GroupingSets) String() string { return AsString(node) }
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -70,6 +70,7 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	Adjacent
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -122,6 +123,7 @@ var comparisonOpName = [...]string{
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
 	TSMatches:         "@@",
+	Adjacent:          "-|-",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -134,6 +136,17 @@ func (i ComparisonOperatorSymbol) String() string {
 	return comparisonOpName[i]
 }
 
+// ComparisonOperatorSymbolFromString returns the ComparisonOperatorSymbol
+// with the given name, as returned by String.
+func ComparisonOperatorSymbolFromString(name string) (ComparisonOperatorSymbol, bool) {
+	for i, n := range comparisonOpName {
+		if n == name {
+			return ComparisonOperatorSymbol(i), true
+		}
+	}
+	return 0, false
+}
+
 // HasSubOperator returns if the ComparisonOperator is used with a sub-operator.
 func (i ComparisonOperatorSymbol) HasSubOperator() bool {
 	switch i {
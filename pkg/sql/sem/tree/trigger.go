@@ -0,0 +1,148 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+)
+
+// TriggerActionTime indicates whether a trigger fires before or after the
+// row modification it is attached to.
+type TriggerActionTime int
+
+const (
+	// TriggerActionTimeBefore triggers fire before the row is written, and may
+	// modify or skip the row.
+	TriggerActionTimeBefore TriggerActionTime = iota
+	// TriggerActionTimeAfter triggers fire once all the rows of the statement
+	// have been written.
+	TriggerActionTimeAfter
+)
+
+// Format implements the NodeFormatter interface.
+func (node TriggerActionTime) Format(ctx *FmtCtx) {
+	switch node {
+	case TriggerActionTimeBefore:
+		ctx.WriteString("BEFORE")
+	case TriggerActionTimeAfter:
+		ctx.WriteString("AFTER")
+	default:
+		panic(pgerror.Newf(pgcode.InvalidParameterValue, "unknown trigger action time %d", node))
+	}
+}
+
+// TriggerActionTimeValue allows the conversion from a tree.TriggerActionTime
+// to a semenumpb.TriggerActionTime.
+var TriggerActionTimeValue = [...]semenumpb.TriggerActionTime{
+	TriggerActionTimeBefore: semenumpb.TriggerActionTime_BEFORE,
+	TriggerActionTimeAfter:  semenumpb.TriggerActionTime_AFTER,
+}
+
+// TriggerEvent is a type of row modification which can fire a trigger.
+type TriggerEvent int
+
+const (
+	// TriggerEventInsert fires the trigger on INSERT.
+	TriggerEventInsert TriggerEvent = iota
+	// TriggerEventUpdate fires the trigger on UPDATE.
+	TriggerEventUpdate
+	// TriggerEventDelete fires the trigger on DELETE.
+	TriggerEventDelete
+)
+
+// Format implements the NodeFormatter interface.
+func (node TriggerEvent) Format(ctx *FmtCtx) {
+	switch node {
+	case TriggerEventInsert:
+		ctx.WriteString("INSERT")
+	case TriggerEventUpdate:
+		ctx.WriteString("UPDATE")
+	case TriggerEventDelete:
+		ctx.WriteString("DELETE")
+	default:
+		panic(pgerror.Newf(pgcode.InvalidParameterValue, "unknown trigger event %d", node))
+	}
+}
+
+// TriggerEventValue allows the conversion from a tree.TriggerEvent to a
+// semenumpb.TriggerEvent.
+var TriggerEventValue = [...]semenumpb.TriggerEvent{
+	TriggerEventInsert: semenumpb.TriggerEvent_INSERT,
+	TriggerEventUpdate: semenumpb.TriggerEvent_UPDATE,
+	TriggerEventDelete: semenumpb.TriggerEvent_DELETE,
+}
+
+// TriggerEvents is a list of TriggerEvent.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node TriggerEvents) Format(ctx *FmtCtx) {
+	for i, ev := range node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(ev)
+	}
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace    bool
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      *UnresolvedObjectName
+	FuncName   FunctionName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ")
+	ctx.FormatNode(node.ActionTime)
+	ctx.WriteString(" ")
+	ctx.FormatNode(node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" FOR EACH ROW EXECUTE FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("()")
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Name         Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -25,17 +25,31 @@ type IsolationLevel int
 const (
 	UnspecifiedIsolation IsolationLevel = iota
 	SerializableIsolation
+	ReadCommittedIsolation
 )
 
 var isolationLevelNames = [...]string{
-	UnspecifiedIsolation:  "UNSPECIFIED",
-	SerializableIsolation: "SERIALIZABLE",
+	UnspecifiedIsolation:   "UNSPECIFIED",
+	SerializableIsolation:  "SERIALIZABLE",
+	ReadCommittedIsolation: "READ COMMITTED",
 }
 
 // IsolationLevelMap is a map from string isolation level name to isolation
-// level, in the lowercase format that set isolation_level supports.
+// level, in the lowercase format that set isolation_level supports. Isolation
+// levels that are not supported are mapped to the next strongest isolation
+// level that is, as permitted by the SQL standard.
 var IsolationLevelMap = map[string]IsolationLevel{
-	"serializable": SerializableIsolation,
+	"read uncommitted": ReadCommittedIsolation,
+	"read committed":   ReadCommittedIsolation,
+	"repeatable read":  SerializableIsolation,
+	"snapshot":         SerializableIsolation,
+	"serializable":     SerializableIsolation,
+}
+
+// IsolationLevelFromString converts a string into an IsolationLevel.
+func IsolationLevelFromString(val string) (_ IsolationLevel, ok bool) {
+	level, ok := IsolationLevelMap[strings.ToLower(val)]
+	return level, ok
 }
 
 func (i IsolationLevel) String() string {
@@ -619,20 +619,39 @@ func (expr *IndirectionExpr) TypeCheck(
 
 	switch typ.Family() {
 	case types.ArrayFamily:
-		expr.typ = typ.ArrayContents()
-		for i, t := range expr.Indirection {
+		// If any of the subscripts is a slice, the result has the type of the
+		// array being subscripted. Otherwise, each subscript selects an element
+		// of one dimension of the array.
+		isSlice := false
+		for _, t := range expr.Indirection {
 			if t.Slice {
-				return nil, unimplemented.NewWithIssuef(32551, "ARRAY slicing in %s", expr)
+				isSlice = true
+				break
 			}
-			if i > 0 {
-				return nil, unimplemented.NewWithIssueDetailf(32552, "ind", "multidimensional indexing: %s", expr)
+		}
+		if len(expr.Indirection) > typ.NumArrayDimensions() {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"cannot subscript type %s because it is not an array", typ.ArrayInnermostContents())
+		}
+		expr.typ = typ
+		for _, t := range expr.Indirection {
+			if !isSlice {
+				expr.typ = expr.typ.ArrayContents()
 			}
-
-			beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
-			if err != nil {
-				return nil, err
+			if t.Begin != nil {
+				beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
+				if err != nil {
+					return nil, err
+				}
+				t.Begin = beginExpr
+			}
+			if t.End != nil {
+				endExpr, err := typeCheckAndRequire(ctx, semaCtx, t.End, types.Int, "ARRAY subscript")
+				if err != nil {
+					return nil, err
+				}
+				t.End = endExpr
 			}
-			t.Begin = beginExpr
 		}
 
 		if OnTypeCheckArraySubscript != nil {
@@ -1077,8 +1096,15 @@ func (expr *FuncExpr) TypeCheck(
 		}
 	}
 
+	overloads := def.Overloads
+	if expr.Variadic {
+		// The array passed as the VARIADIC argument is matched against the
+		// declared array type of the VARIADIC parameter, so only variadic
+		// functions can be called this way.
+		overloads = variadicArrayOverloads(overloads)
+	}
 	s := getOverloadTypeChecker(
-		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
+		(*qualifiedOverloads)(&overloads), expr.Exprs...,
 	)
 	defer s.release()
 	if err := s.typeCheckOverloadedExprs(ctx, semaCtx, desired, false); err != nil {
@@ -1087,7 +1113,7 @@ func (expr *FuncExpr) TypeCheck(
 
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
 	for _, idx := range s.overloadIdxs {
-		if def.Overloads[idx].CalledOnNullInput {
+		if overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
 			notCalledOnNullInputFns.Add(int(idx))
@@ -1109,7 +1135,7 @@ func (expr *FuncExpr) TypeCheck(
 			if s.typedExprs[i].ResolvedType().Family() == types.UnknownFamily {
 				var filtered intsets.Fast
 				for j, ok := notCalledOnNullInputFns.Next(0); ok; j, ok = notCalledOnNullInputFns.Next(j + 1) {
-					if def.Overloads[j].params().GetAt(i).Equivalent(types.String) {
+					if overloads[j].params().GetAt(i).Equivalent(types.String) {
 						filtered.Add(j)
 					}
 				}
@@ -1152,7 +1178,7 @@ func (expr *FuncExpr) TypeCheck(
 
 	// Get overloads from the most significant schema in search path.
 	favoredOverload, err := getMostSignificantOverload(
-		def.Overloads, s.overloads, s.overloadIdxs, searchPath, expr,
+		overloads, s.overloads, s.overloadIdxs, searchPath, expr,
 		func() string { return getFuncSig(expr, s.typedExprs, desired) },
 	)
 	if err != nil {
@@ -1165,12 +1191,33 @@ func (expr *FuncExpr) TypeCheck(
 		return nil, pgerror.Wrapf(errPrivateFunction, pgcode.ReservedName,
 			"%s()", errors.Safe(def.Name))
 	}
+	// Procedures can only be invoked by CALL statements, which cannot invoke
+	// anything but procedures.
+	if overloadImpl.IsProcedure && !expr.InCall {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is a procedure", getFuncSig(expr, s.typedExprs, types.Any)),
+			"To call a procedure, use CALL.",
+		)
+	}
+	if !overloadImpl.IsProcedure && expr.InCall {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure", getFuncSig(expr, s.typedExprs, types.Any)),
+			"To call a function, use SELECT.",
+		)
+	}
 	if resolver != nil && overloadImpl.UDFContainsOnlySignature {
 		_, overloadImpl, err = resolver.ResolveFunctionByOID(ctx, overloadImpl.Oid)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if expr.Variadic && overloadImpl.Variadic {
+		// The array is passed as-is, rather than collected from the trailing
+		// arguments.
+		ol := *overloadImpl
+		ol.Variadic = false
+		overloadImpl = &ol
+	}
 
 	if expr.IsWindowFunctionApplication() {
 		// Make sure the window function application is of either a built-in window
@@ -1459,6 +1506,13 @@ func (expr *AllColumnsSelector) TypeCheck(
 	return nil, pgerror.Newf(pgcode.Syntax, "cannot use %q in this context", expr)
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSets) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s is only allowed in GROUP BY", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *RangeCond) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -1882,6 +1936,12 @@ func (d *DTSVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Ty
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTuple) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -12,6 +12,7 @@ package tree
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -97,17 +98,25 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
-	ctx.WriteString("FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("PROCEDURE ")
+	} else {
+		ctx.WriteString("FUNCTION ")
+	}
 	ctx.FormatNode(&node.FuncName)
 	ctx.WriteString("(")
 	ctx.FormatNode(node.Params)
 	ctx.WriteString(")\n\t")
-	ctx.WriteString("RETURNS ")
-	if node.ReturnType.IsSet {
-		ctx.WriteString("SETOF ")
+	// The return type may be omitted if the function has OUT parameters.
+	// Procedures never have a return type.
+	if node.ReturnType.Type != nil && !node.IsProcedure {
+		ctx.WriteString("RETURNS ")
+		if node.ReturnType.IsSet {
+			ctx.WriteString("SETOF ")
+		}
+		ctx.WriteString(node.ReturnType.Type.SQLString())
+		ctx.WriteString("\n\t")
 	}
-	ctx.WriteString(node.ReturnType.Type.SQLString())
-	ctx.WriteString("\n\t")
 	var funcBody FunctionBodyStr
 	for _, option := range node.Options {
 		switch t := option.(type) {
@@ -131,6 +140,73 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	}
 }
 
+// ResolveReturnType returns the return type of the function. If the function
+// has OUT parameters, the return type is derived from them: it is the type of
+// the single OUT parameter, or a record of all the OUT parameters. An explicit
+// return type must agree with the derived one.
+func (node *CreateFunction) ResolveReturnType(
+	ctx context.Context, res TypeReferenceResolver,
+) (*types.T, error) {
+	var outTypes []*types.T
+	var outLabels []string
+	hasOutLabels := false
+	for i := range node.Params {
+		param := &node.Params[i]
+		if !param.IsOutParam() {
+			continue
+		}
+		typ, err := ResolveType(ctx, param.Type, res)
+		if err != nil {
+			return nil, err
+		}
+		label := string(param.Name)
+		if label == "" {
+			label = fmt.Sprintf("column%d", i+1)
+		} else {
+			hasOutLabels = true
+		}
+		outTypes = append(outTypes, typ)
+		outLabels = append(outLabels, label)
+	}
+	var outType *types.T
+	switch len(outTypes) {
+	case 0:
+	case 1:
+		outType = outTypes[0]
+	default:
+		if hasOutLabels {
+			outType = types.MakeLabeledTuple(outTypes, outLabels)
+		} else {
+			outType = types.MakeTuple(outTypes)
+		}
+	}
+
+	if node.ReturnType.Type == nil {
+		if outType == nil {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"function result type must be specified")
+		}
+		return outType, nil
+	}
+	retType, err := ResolveType(ctx, node.ReturnType.Type, res)
+	if err != nil {
+		return nil, err
+	}
+	if outType == nil {
+		return retType, nil
+	}
+	if len(outTypes) > 1 {
+		if retType.Family() != types.TupleFamily {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"function result type must be record because of OUT parameters")
+		}
+	} else if !retType.Equivalent(outType) {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function result type must be %s because of OUT parameters", outType.SQLString())
+	}
+	return outType, nil
+}
+
 // RoutineBody represent a list of statements in a UDF body.
 type RoutineBody struct {
 	Stmts Statements
@@ -161,6 +237,8 @@ func (FunctionVolatility) functionOption()        {}
 func (FunctionLeakproof) functionOption()         {}
 func (FunctionBodyStr) functionOption()           {}
 func (FunctionLanguage) functionOption()          {}
+func (FunctionSecurity) functionOption()          {}
+func (*SetVar) functionOption()                   {}
 
 // FunctionNullInputBehavior represent the UDF property on null parameters.
 type FunctionNullInputBehavior int
@@ -233,6 +311,32 @@ func (node FunctionLeakproof) Format(ctx *FmtCtx) {
 	ctx.WriteString("LEAKPROOF")
 }
 
+// FunctionSecurity indicates whether a UDF is executed with the privileges of
+// the user calling it or of the user owning it. The default is SECURITY
+// INVOKER if no security option is provided.
+type FunctionSecurity int
+
+const (
+	// FunctionSecurityInvoker indicates that the function is executed with the
+	// privileges of the user calling it.
+	FunctionSecurityInvoker FunctionSecurity = iota
+	// FunctionSecurityDefiner indicates that the function is executed with the
+	// privileges of the user owning it.
+	FunctionSecurityDefiner
+)
+
+// Format implements the NodeFormatter interface.
+func (node FunctionSecurity) Format(ctx *FmtCtx) {
+	switch node {
+	case FunctionSecurityInvoker:
+		ctx.WriteString("SECURITY INVOKER")
+	case FunctionSecurityDefiner:
+		ctx.WriteString("SECURITY DEFINER")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "Unknown function option"))
+	}
+}
+
 // FunctionLanguage indicates the language of the statements in the UDF function
 // body.
 type FunctionLanguage int
@@ -241,6 +345,8 @@ const (
 	_ FunctionLanguage = iota
 	// FunctionLangSQL represent SQL language.
 	FunctionLangSQL
+	// FunctionLangPLpgSQL represent the PL/pgSQL procedural language.
+	FunctionLangPLpgSQL
 )
 
 // Format implements the NodeFormatter interface.
@@ -249,6 +355,8 @@ func (node FunctionLanguage) Format(ctx *FmtCtx) {
 	switch node {
 	case FunctionLangSQL:
 		ctx.WriteString("SQL")
+	case FunctionLangPLpgSQL:
+		ctx.WriteString("plpgsql")
 	default:
 		panic(pgerror.New(pgcode.InvalidParameterValue, "Unknown function option"))
 	}
@@ -260,6 +368,8 @@ func AsFunctionLanguage(lang string) (FunctionLanguage, error) {
 	switch strings.ToLower(lang) {
 	case "sql":
 		return FunctionLangSQL, nil
+	case "plpgsql":
+		return FunctionLangPLpgSQL, nil
 	}
 	return 0, errors.Newf("language %q does not exist", lang)
 }
@@ -322,6 +432,18 @@ func (node *FuncParam) Format(ctx *FmtCtx) {
 	}
 }
 
+// IsInParam returns true if the parameter is passed as an argument when the
+// function is called.
+func (node *FuncParam) IsInParam() bool {
+	return node.Class != FunctionParamOut
+}
+
+// IsOutParam returns true if the parameter is part of the result of the
+// function.
+func (node *FuncParam) IsOutParam() bool {
+	return node.Class == FunctionParamOut || node.Class == FunctionParamInOut
+}
+
 // FuncParamClass indicates what type of argument an arg is.
 type FuncParamClass int
 
@@ -362,6 +484,110 @@ func (node *DropFunction) Format(ctx *FmtCtx) {
 	}
 }
 
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Name    FunctionName
+	Params  FuncParams
+	Options AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString("(")
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteString(")")
+}
+
+// AggregateOption represents an option of a CREATE AGGREGATE statement, such
+// as SFUNC = <function name>. Exactly one of Type and Value is set. Function
+// names are parsed as type names, and are stored in Type.
+type AggregateOption struct {
+	Name  Name
+	Type  ResolvableTypeReference
+	Value *StrVal
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(string(node.Name)))
+	ctx.WriteString(" = ")
+	if node.Type != nil {
+		ctx.FormatTypeReference(node.Type)
+	} else {
+		ctx.FormatNode(node.Value)
+	}
+}
+
+// AggregateOptions is a list of CREATE AGGREGATE options.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node[i])
+	}
+}
+
+// DropAggregate represents a DROP AGGREGATE statement.
+type DropAggregate struct {
+	IfExists     bool
+	Aggregates   FuncObjs
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP AGGREGATE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(node.Aggregates)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropProcedure represents a DROP PROCEDURE statement.
+type DropProcedure struct {
+	IfExists     bool
+	Procedures   FuncObjs
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropProcedure) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PROCEDURE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(node.Procedures)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// Call represents a CALL statement, which invokes a stored procedure.
+type Call struct {
+	Proc *FuncExpr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	// Format the procedure directly, rather than with FormatNode, so that it is
+	// never wrapped in parentheses.
+	node.Proc.Format(ctx)
+}
+
 // FuncObjs is a slice of FuncObj.
 type FuncObjs []FuncObj
 
@@ -391,20 +617,21 @@ func (node FuncObj) Format(ctx *FmtCtx) {
 	}
 }
 
-// ParamTypes returns a slice of parameter types of the function.
+// ParamTypes returns a slice of parameter types of the function. OUT
+// parameters are not part of the function signature, so they are omitted.
 func (node FuncObj) ParamTypes(ctx context.Context, res TypeReferenceResolver) ([]*types.T, error) {
-	// TODO(chengxiong): handle INOUT, OUT and VARIADIC argument classes when we
-	// support them. This is because only IN and INOUT arg types need to be
-	// considered to match a overload.
 	var argTypes []*types.T
 	if node.Params != nil {
-		argTypes = make([]*types.T, len(node.Params))
-		for i, arg := range node.Params {
+		argTypes = make([]*types.T, 0, len(node.Params))
+		for _, arg := range node.Params {
+			if !arg.IsInParam() {
+				continue
+			}
 			typ, err := ResolveType(ctx, arg.Type, res)
 			if err != nil {
 				return nil, err
 			}
-			argTypes[i] = typ
+			argTypes = append(argTypes, typ)
 		}
 	}
 	return argTypes, nil
@@ -522,7 +749,7 @@ func MaybeFailOnUDFUsage(expr TypedExpr) error {
 // ValidateFuncOptions checks whether there are conflicting or redundant
 // function options in the given slice.
 func ValidateFuncOptions(options FunctionOptions) error {
-	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior bool
+	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior, hasSecurity bool
 	err := func(opt FunctionOption) error {
 		return errors.Wrapf(ErrConflictingFunctionOption, "%s", AsString(opt))
 	}
@@ -553,6 +780,14 @@ func ValidateFuncOptions(options FunctionOptions) error {
 				return err(option)
 			}
 			hasNullInputBehavior = true
+		case FunctionSecurity:
+			if hasSecurity {
+				return err(option)
+			}
+			hasSecurity = true
+		case *SetVar:
+			// A function may have any number of SET and RESET clauses. Later
+			// clauses override earlier ones for the same variable.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
@@ -77,7 +77,10 @@ func (node *UpdateExprs) Format(ctx *FmtCtx) {
 type UpdateExpr struct {
 	Tuple bool
 	Names NameList
-	Expr  Expr
+	// Fields, if non-empty, is the path of composite type fields within the
+	// single column in Names that is assigned, as in SET a.b.c = 1.
+	Fields NameList
+	Expr   Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -88,6 +91,10 @@ func (node *UpdateExpr) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString(open)
 	ctx.FormatNode(&node.Names)
+	for i := range node.Fields {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&node.Fields[i])
+	}
 	ctx.WriteString(close)
 	ctx.WriteString(" = ")
 	ctx.FormatNode(node.Expr)
@@ -663,6 +663,16 @@ func (expr *Tuple) Walk(v Visitor) Expr {
 	return expr
 }
 
+// Walk implements the Expr interface.
+func (expr *GroupingSets) Walk(v Visitor) Expr {
+	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *Array) Walk(v Visitor) Expr {
 	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
@@ -774,6 +784,9 @@ func (expr *DTSQuery) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DTSVector) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DRange) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DUuid) Walk(_ Visitor) Expr { return expr }
 
@@ -1246,6 +1259,62 @@ func (stmt *Insert) walkStmt(v Visitor) Statement {
 	return ret
 }
 
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (stmt *Merge) copyNode() *Merge {
+	stmtCopy := *stmt
+	whens := make([]MergeWhen, len(stmt.Whens))
+	stmtCopy.Whens = make(MergeWhens, len(stmt.Whens))
+	for i, w := range stmt.Whens {
+		whens[i] = *w
+		exprs := make([]UpdateExpr, len(w.Exprs))
+		whens[i].Exprs = make(UpdateExprs, len(w.Exprs))
+		for j, e := range w.Exprs {
+			exprs[j] = *e
+			whens[i].Exprs[j] = &exprs[j]
+		}
+		stmtCopy.Whens[i] = &whens[i]
+	}
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (stmt *Merge) walkStmt(v Visitor) Statement {
+	ret := stmt
+	e, changed := WalkExpr(v, stmt.On)
+	if changed {
+		ret = stmt.copyNode()
+		ret.On = e
+	}
+	for i, w := range stmt.Whens {
+		if w.Cond != nil {
+			e, changed := WalkExpr(v, w.Cond)
+			if changed {
+				if ret == stmt {
+					ret = stmt.copyNode()
+				}
+				ret.Whens[i].Cond = e
+			}
+		}
+		for j, expr := range w.Exprs {
+			e, changed := WalkExpr(v, expr.Expr)
+			if changed {
+				if ret == stmt {
+					ret = stmt.copyNode()
+				}
+				ret.Whens[i].Exprs[j].Expr = e
+			}
+		}
+		values, changed := walkExprSlice(v, w.Values)
+		if changed {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Whens[i].Values = values
+		}
+	}
+	return ret
+}
+
 // copyNode makes a copy of this Statement without recursing in any child Statements.
 func (stmt *CreateTable) copyNode() *CreateTable {
 	stmtCopy := *stmt
@@ -1828,6 +1897,7 @@ var _ walkableStmt = &DropTenant{}
 var _ walkableStmt = &Explain{}
 var _ walkableStmt = &Import{}
 var _ walkableStmt = &Insert{}
+var _ walkableStmt = &Merge{}
 var _ walkableStmt = &ParenSelect{}
 var _ walkableStmt = &Restore{}
 var _ walkableStmt = &SelectClause{}
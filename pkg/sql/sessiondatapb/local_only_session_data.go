@@ -271,6 +271,50 @@ func DescriptorValidationModeFromString(val string) (_ DescriptorValidationMode,
 	}
 }
 
+// PlanCacheMode controls whether the optimizer uses custom or generic query
+// plans for prepared statements.
+type PlanCacheMode int64
+
+const (
+	// PlanCacheModeForceCustom means that a custom plan is built for every
+	// execution of a prepared statement, using the values of its placeholders.
+	PlanCacheModeForceCustom PlanCacheMode = iota
+	// PlanCacheModeForceGeneric means that a single generic plan, which does
+	// not depend on the values of placeholders, is built for a prepared
+	// statement and reused by all of its executions.
+	PlanCacheModeForceGeneric
+	// PlanCacheModeAuto means that the optimizer chooses between custom and
+	// generic plans based on their estimated costs.
+	PlanCacheModeAuto
+)
+
+func (m PlanCacheMode) String() string {
+	switch m {
+	case PlanCacheModeForceCustom:
+		return "force_custom_plan"
+	case PlanCacheModeForceGeneric:
+		return "force_generic_plan"
+	case PlanCacheModeAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("invalid (%d)", m)
+	}
+}
+
+// PlanCacheModeFromString converts a string into a PlanCacheMode.
+func PlanCacheModeFromString(val string) (_ PlanCacheMode, ok bool) {
+	switch strings.ToUpper(val) {
+	case "FORCE_CUSTOM_PLAN":
+		return PlanCacheModeForceCustom, true
+	case "FORCE_GENERIC_PLAN":
+		return PlanCacheModeForceGeneric, true
+	case "AUTO":
+		return PlanCacheModeAuto, true
+	default:
+		return 0, false
+	}
+}
+
 // QoSLevel controls the level of admission control to use for new SQL requests.
 type QoSLevel admissionpb.WorkPriority
 
@@ -232,6 +232,9 @@ func (p *planner) ShowCreate(
 	if desc.IsSequence() {
 		return ShowCreateSequence(ctx, &tn, desc)
 	}
+	if desc.IsForeignTable() {
+		return ShowCreateForeignTable(ctx, &tn, desc)
+	}
 	lCtx := newInternalLookupCtx(allHydratedDescs, nil /* prefix */)
 	// Overwrite desc with hydrated descriptor.
 	var err error
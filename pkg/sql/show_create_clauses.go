@@ -485,6 +485,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if d := fk.DeferrableMode(); d.IsDeferrable() {
+		buf.WriteByte(' ')
+		buf.WriteString(d.String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -520,6 +524,38 @@ func ShowCreateSequence(
 	return f.CloseAndGetString(), nil
 }
 
+// ShowCreateForeignTable returns a valid SQL representation of the
+// CREATE FOREIGN TABLE statement used to create the given foreign table.
+func ShowCreateForeignTable(
+	ctx context.Context, tn *tree.TableName, desc catalog.TableDescriptor,
+) (string, error) {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.WriteString("CREATE FOREIGN TABLE ")
+	f.FormatNode(tn)
+	f.WriteString(" (")
+	for i, col := range desc.PublicColumns() {
+		if i > 0 {
+			f.WriteString(",")
+		}
+		f.WriteString("\n\t")
+		formatQuoteNames(&f.Buffer, col.GetName())
+		f.WriteByte(' ')
+		f.WriteString(col.GetType().SQLString())
+		if !col.IsNullable() {
+			f.WriteString(" NOT NULL")
+		}
+	}
+	opts := desc.GetForeignTable()
+	f.WriteString("\n) SERVER ")
+	formatQuoteNames(&f.Buffer, opts.ServerName)
+	f.WriteString(" OPTIONS (schema_name ")
+	f.FormatNode(tree.NewDString(opts.RemoteSchema))
+	f.WriteString(", table_name ")
+	f.FormatNode(tree.NewDString(opts.RemoteTable))
+	f.WriteString(")")
+	return f.CloseAndGetString(), nil
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
@@ -724,6 +760,17 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
+		if c.UniqueWithoutIndexDesc().IsExclusion() {
+			if err := formatExclusionConstraint(
+				ctx, desc, c, semaCtx, sessionData, f, tree.FmtParsable,
+			); err != nil {
+				return err
+			}
+			if !c.IsConstraintValidated() {
+				f.WriteString(" NOT VALID")
+			}
+			continue
+		}
 		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := desc.NamesForColumnIDs(c.CollectKeyColumnIDs().Ordered())
 		if err != nil {
@@ -731,6 +778,10 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		if d := c.UniqueWithoutIndexDesc().DeferrableMode(); d.IsDeferrable() {
+			f.WriteString(" ")
+			f.WriteString(d.String())
+		}
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.GetPredicate(), semaCtx, sessionData, tree.FmtParsable)
@@ -746,3 +797,48 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// formatExclusionConstraint formats an exclusion constraint as it would
+// appear in a CREATE TABLE statement, without the constraint name. The
+// predicate of a partial constraint is formatted using fmtFlags.
+func formatExclusionConstraint(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	c catalog.UniqueWithoutIndexConstraint,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	f *tree.FmtCtx,
+	fmtFlags tree.FmtFlags,
+) error {
+	uc := c.UniqueWithoutIndexDesc()
+	colNames, err := desc.NamesForColumnIDs(uc.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	f.WriteString("EXCLUDE USING ")
+	f.WriteString(uc.ExclusionMethod)
+	f.WriteString(" (")
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		formatQuoteNames(&f.Buffer, colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(uc.ExclusionOperators[i])
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.GetPredicate(), semaCtx, sessionData, fmtFlags)
+		if err != nil {
+			return err
+		}
+		f.WriteString(" WHERE (")
+		f.WriteString(pred)
+		f.WriteString(")")
+	}
+	if d := uc.DeferrableMode(); d.IsDeferrable() {
+		f.WriteString(" ")
+		f.WriteString(d.String())
+	}
+	return nil
+}
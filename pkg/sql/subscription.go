@@ -0,0 +1,70 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// AddSubscription records a subscription in the current database. It is
+// used by CREATE SUBSCRIPTION once the job of the subscription is created.
+func (p *planner) AddSubscription(
+	ctx context.Context, sub descpb.DatabaseDescriptor_Subscription,
+) error {
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	if dbDesc.FindSubscription(sub.Name) != nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"subscription %q already exists", sub.Name)
+	}
+	dbDesc.AddSubscription(sub)
+	return p.writeNonDropDatabaseChange(
+		ctx, dbDesc, fmt.Sprintf("creating subscription %s", sub.Name),
+	)
+}
+
+// RemoveSubscription removes the record of a subscription from the current
+// database and returns it. It is used by DROP SUBSCRIPTION, which is then
+// responsible for canceling the job of the subscription.
+// Privileges: ownership of the subscription.
+func (p *planner) RemoveSubscription(
+	ctx context.Context, name string,
+) (descpb.DatabaseDescriptor_Subscription, error) {
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return descpb.DatabaseDescriptor_Subscription{}, err
+	}
+	sub := dbDesc.FindSubscription(name)
+	if sub == nil {
+		return descpb.DatabaseDescriptor_Subscription{}, pgerror.Newf(pgcode.UndefinedObject,
+			"subscription %q does not exist", name)
+	}
+	if err := p.checkReplicationObjectOwnership(
+		ctx, "subscription", sub.Name, sub.OwnerProto.Decode(),
+	); err != nil {
+		return descpb.DatabaseDescriptor_Subscription{}, err
+	}
+	removed := *sub
+	dbDesc.RemoveSubscription(name)
+	if err := p.writeNonDropDatabaseChange(
+		ctx, dbDesc, fmt.Sprintf("dropping subscription %s", name),
+	); err != nil {
+		return descpb.DatabaseDescriptor_Subscription{}, err
+	}
+	return removed, nil
+}
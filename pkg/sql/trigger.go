@@ -0,0 +1,40 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// CreateTrigger is only implemented in the declarative schema changer; the
+// legacy schema changer reaches this when the declarative schema changer is
+// disabled or the cluster has not been fully upgraded.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	return nil, errRequiresDeclarativeSchemaChanger("CREATE TRIGGER")
+}
+
+// DropTrigger is only implemented in the declarative schema changer.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	return nil, errRequiresDeclarativeSchemaChanger("DROP TRIGGER")
+}
+
+func errRequiresDeclarativeSchemaChanger(stmt string) error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only supported by the declarative schema changer", stmt),
+		"SET use_declarative_schema_changer = 'on' and ensure the cluster is fully upgraded.",
+	)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
@@ -1659,3 +1660,85 @@ SELECT * from t.test WHERE k = 'test_key';
 
 	require.Equal(t, numRetries, retryCount)
 }
+
+// TestReadCommittedStmtSnapshotsAndRetries verifies that each statement in a
+// READ COMMITTED transaction observes the writes committed by other sessions
+// before it began, and that a statement that hits a retryable error is retried
+// on its own without restarting the transaction.
+func TestReadCommittedStmtSnapshotsAndRetries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	params, cmdFilters := tests.CreateTestServerParams()
+	s, sqlDB, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+
+	r := sqlutils.MakeSQLRunner(sqlDB)
+	r.Exec(t, `
+SET CLUSTER SETTING sql.txn.read_committed_isolation.enabled = true;
+CREATE DATABASE t;
+CREATE TABLE t.test (k TEXT PRIMARY KEY, v INT);
+INSERT INTO t.test VALUES ('retry_key', 0);
+`)
+
+	// Inject retryable errors into the reads of retry_key performed by READ
+	// COMMITTED transactions, and count the attempts.
+	retriedStmtKey := []byte("retry_key")
+	var injectErrs, attempts int32
+	cleanupFilter := cmdFilters.AppendFilter(
+		func(args kvserverbase.FilterArgs) *roachpb.Error {
+			txn := args.Hdr.Txn
+			if txn == nil || txn.IsoLevel != isolation.ReadCommitted {
+				return nil
+			}
+			if req, ok := args.Req.(*roachpb.GetRequest); ok && bytes.Contains(req.Key, retriedStmtKey) {
+				atomic.AddInt32(&attempts, 1)
+				if atomic.AddInt32(&injectErrs, -1) >= 0 {
+					return roachpb.NewErrorWithTxn(roachpb.NewTransactionRetryError(roachpb.RETRY_REASON_UNKNOWN,
+						"injected err"), txn)
+				}
+			}
+			return nil
+		}, false)
+	defer cleanupFilter()
+
+	// The READ COMMITTED transaction runs on a dedicated connection, while the
+	// other session uses the rest of the connection pool.
+	conn, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	txnR := sqlutils.MakeSQLRunner(conn)
+
+	txnR.Exec(t, `BEGIN TRANSACTION ISOLATION LEVEL READ COMMITTED`)
+	txnR.Exec(t, `INSERT INTO t.test VALUES ('a', 1)`)
+	txnR.CheckQueryResults(t, `SELECT k, v FROM t.test ORDER BY k`, [][]string{
+		{"a", "1"}, {"retry_key", "0"},
+	})
+
+	// Each statement establishes a new read snapshot, so it observes the writes
+	// committed by the other session in the meantime.
+	r.Exec(t, `INSERT INTO t.test VALUES ('b', 2)`)
+	txnR.CheckQueryResults(t, `SELECT k, v FROM t.test ORDER BY k`, [][]string{
+		{"a", "1"}, {"b", "2"}, {"retry_key", "0"},
+	})
+	r.Exec(t, `UPDATE t.test SET v = 3 WHERE k = 'b'`)
+	txnR.CheckQueryResults(t, `SELECT v FROM t.test WHERE k = 'b'`, [][]string{{"3"}})
+
+	// A statement that hits retryable errors is retried transparently. Only the
+	// statement is retried: the transaction keeps the writes of its earlier
+	// statements.
+	const numRetries = 2
+	atomic.StoreInt32(&injectErrs, numRetries)
+	atomic.StoreInt32(&attempts, 0)
+	txnR.CheckQueryResults(t, `SELECT v FROM t.test WHERE k = 'retry_key'`, [][]string{{"0"}})
+	require.Equal(t, int32(numRetries+1), atomic.LoadInt32(&attempts))
+	txnR.CheckQueryResults(t, `SELECT k, v FROM t.test WHERE k < 'c' ORDER BY k`, [][]string{
+		{"a", "1"}, {"b", "3"},
+	})
+	txnR.Exec(t, `COMMIT`)
+
+	r.CheckQueryResults(t, `SELECT k, v FROM t.test ORDER BY k`, [][]string{
+		{"a", "1"}, {"b", "3"}, {"retry_key", "0"},
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -177,6 +178,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	sqlTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
 	priority roachpb.UserPriority,
+	isoLevel isolation.Level,
 	readOnly tree.ReadWriteMode,
 	txn *kv.Txn,
 	tranCtx transitionCtx,
@@ -224,10 +226,16 @@ func (ts *txnState) resetForNewSQLTxn(
 			if err := ts.setPriorityLocked(priority); err != nil {
 				panic(err)
 			}
+			if err := ts.setIsolationLevelLocked(isoLevel); err != nil {
+				panic(err)
+			}
 		} else {
 			if priority != roachpb.UnspecifiedUserPriority {
 				panic(errors.AssertionFailedf("unexpected priority when using an existing txn: %s", priority))
 			}
+			if isoLevel != isolation.Serializable {
+				panic(errors.AssertionFailedf("unexpected isolation level when using an existing txn: %s", isoLevel))
+			}
 			ts.mu.txn = txn
 		}
 
@@ -338,6 +346,16 @@ func (ts *txnState) setPriorityLocked(userPriority roachpb.UserPriority) error {
 	return nil
 }
 
+func (ts *txnState) setIsolationLevel(isoLevel isolation.Level) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.setIsolationLevelLocked(isoLevel)
+}
+
+func (ts *txnState) setIsolationLevelLocked(isoLevel isolation.Level) error {
+	return ts.mu.txn.SetIsoLevel(isoLevel)
+}
+
 func (ts *txnState) setReadOnlyMode(mode tree.ReadWriteMode) error {
 	switch mode {
 	case tree.UnspecifiedReadWriteMode:
@@ -260,6 +260,18 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 		return err
 	}
 
+	// Validate the existing values against the constraints added by ALTER
+	// DOMAIN. Since the new version of the domain is now leased on all nodes,
+	// these constraints are already enforced on new values.
+	if typeDesc.GetKind() == descpb.TypeDescriptor_DOMAIN && domainIsValidating(typeDesc) {
+		if err := t.validateDomainConstraints(ctx); err != nil {
+			return err
+		}
+		if err := refreshTypeDescriptorLeases(ctx, leaseMgr, typeDesc); err != nil {
+			return err
+		}
+	}
+
 	// For all the read only members the current job is responsible for, either
 	// promote them to writeable or remove them from the descriptor entirely,
 	// as dictated by the direction.
@@ -505,6 +517,121 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 	return nil
 }
 
+// domainIsValidating returns whether the given domain has constraints that
+// are being validated.
+func domainIsValidating(typeDesc catalog.TypeDescriptor) bool {
+	domain := typeDesc.TypeDesc().Domain
+	if domain == nil {
+		return false
+	}
+	if domain.NotNullValidating {
+		return true
+	}
+	for i := range domain.Checks {
+		if domain.Checks[i].Validating {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDomainConstraints validates the existing values of the columns of a
+// domain type against the constraints being validated, and marks these
+// constraints as validated. If a value violates one of them, the error is
+// returned and the constraints are removed by OnFailOrCancel.
+func (t *typeSchemaChanger) validateDomainConstraints(ctx context.Context) error {
+	// The validation is done in a separate txn from the one that mutates the
+	// descriptor, as it can take arbitrarily long.
+	validate := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		typeDesc, err := descsCol.ByID(txn).Get().Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		domain := typeDesc.TypeDesc().Domain
+		ie := t.execCfg.InternalExecutor
+		if domain.NotNullValidating {
+			if err := validateDomainNotNull(ctx, txn, descsCol, ie, typeDesc); err != nil {
+				return err
+			}
+		}
+		for i := range domain.Checks {
+			if !domain.Checks[i].Validating {
+				continue
+			}
+			expr, err := parser.ParseExpr(domain.Checks[i].Expr)
+			if err != nil {
+				return err
+			}
+			if err := validateDomainCheck(ctx, txn, descsCol, ie, typeDesc, expr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := DescsTxn(ctx, t.execCfg, validate); err != nil {
+		return err
+	}
+
+	return DescsTxn(ctx, t.execCfg, func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		typeDesc, err := descsCol.MutableByID(txn).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		typeDesc.Domain.NotNullValidating = false
+		for i := range typeDesc.Domain.Checks {
+			typeDesc.Domain.Checks[i].Validating = false
+		}
+		return t.writeTypeAndArrayTypeDesc(ctx, txn, descsCol, typeDesc)
+	})
+}
+
+// cleanupDomainConstraints removes the constraints of a domain that were being
+// validated when the job failed.
+func (t *typeSchemaChanger) cleanupDomainConstraints(ctx context.Context) error {
+	return DescsTxn(ctx, t.execCfg, func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		typeDesc, err := descsCol.MutableByID(txn).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		if !domainIsValidating(typeDesc) {
+			return nil
+		}
+		domain := typeDesc.Domain
+		if domain.NotNullValidating {
+			domain.NotNull = false
+			domain.NotNullValidating = false
+		}
+		checks := domain.Checks[:0]
+		for _, chk := range domain.Checks {
+			if !chk.Validating {
+				checks = append(checks, chk)
+			}
+		}
+		domain.Checks = checks
+		return t.writeTypeAndArrayTypeDesc(ctx, txn, descsCol, typeDesc)
+	})
+}
+
+// writeTypeAndArrayTypeDesc writes the type descriptor, and bumps the version
+// of its array type so that the changes to the underlying type are picked up.
+func (t *typeSchemaChanger) writeTypeAndArrayTypeDesc(
+	ctx context.Context, txn *kv.Txn, descsCol *descs.Collection, typeDesc *typedesc.Mutable,
+) error {
+	const kvTrace = true
+	b := txn.NewBatch()
+	if err := descsCol.WriteDescToBatch(ctx, kvTrace, typeDesc, b); err != nil {
+		return err
+	}
+	arrayTypeDesc, err := descsCol.MutableByID(txn).Type(ctx, typeDesc.ArrayTypeID)
+	if err != nil {
+		return err
+	}
+	if err := descsCol.WriteDescToBatch(ctx, kvTrace, arrayTypeDesc, b); err != nil {
+		return err
+	}
+	return txn.Run(ctx, b)
+}
+
 // isTransitioningInCurrentJob returns true if the given member is either being
 // added or removed in the current job.
 func (t *typeSchemaChanger) isTransitioningInCurrentJob(
@@ -757,6 +884,15 @@ func (t *typeSchemaChanger) canRemoveEnumValue(
 	descsCol *descs.Collection,
 ) error {
 	for _, ID := range typeDesc.ReferencingDescriptorIDs {
+		// The enum may be referenced by a composite type, whose values could
+		// contain the enum value anywhere it is used, so the value is
+		// conservatively considered to be in use.
+		if refDesc, err := descsCol.ByID(txn).WithoutNonPublic().Get().Desc(ctx, ID); err == nil &&
+			refDesc.DescriptorType() == catalog.Type {
+			return pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"could not remove enum value %q as it is being used by type %q",
+				member.LogicalRepresentation, refDesc.GetName())
+		}
 		desc, err := descsCol.ByID(txn).WithoutNonPublic().Get().Table(ctx, ID)
 		if err != nil {
 			return errors.Wrapf(err,
@@ -1278,6 +1414,10 @@ func (t *typeChangeResumer) OnFailOrCancel(
 			return err
 		}
 
+		if err := tc.cleanupDomainConstraints(ctx); err != nil {
+			return err
+		}
+
 		if fn := tc.execCfg.TypeSchemaChangerTestingKnobs.RunAfterOnFailOrCancel; fn != nil {
 			return fn()
 		}
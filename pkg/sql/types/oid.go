@@ -26,6 +26,10 @@ var (
 	RegClass = &T{InternalType: InternalType{
 		Family: OidFamily, Oid: oid.T_regclass, Locale: &emptyLocale}}
 
+	// RegConfig is the type of a Postgres regconfig OID variant (T_regconfig).
+	RegConfig = &T{InternalType: InternalType{
+		Family: OidFamily, Oid: oid.T_regconfig, Locale: &emptyLocale}}
+
 	// RegNamespace is the type of a Postgres regnamespace OID variant
 	// (T_regnamespace).
 	RegNamespace = &T{InternalType: InternalType{
@@ -66,12 +70,15 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_bytea:      Bytes,
 	oid.T_char:       QChar,
 	oid.T_date:       Date,
+	oid.T_daterange:  DateRange,
 	oid.T_float4:     Float4,
 	oid.T_float8:     Float,
 	oid.T_int2:       Int2,
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
+	oid.T_int4range:  Int4Range,
 	oid.T_int8:       Int,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -85,6 +92,7 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_oidvector:    OidVector,
 	oid.T_record:       AnyTuple,
 	oid.T_regclass:     RegClass,
+	oid.T_regconfig:    RegConfig,
 	oid.T_regnamespace: RegNamespace,
 	oid.T_regproc:      RegProc,
 	oid.T_regprocedure: RegProcedure,
@@ -96,6 +104,8 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
 	oid.T_tsquery:      TSQuery,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
@@ -132,6 +142,7 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_oidvector:    oid.T__oidvector,
 	oid.T_record:       oid.T__record,
 	oid.T_regclass:     oid.T__regclass,
+	oid.T_regconfig:    oid.T__regconfig,
 	oid.T_regnamespace: oid.T__regnamespace,
 	oid.T_regproc:      oid.T__regproc,
 	oid.T_regprocedure: oid.T__regprocedure,
@@ -178,6 +189,7 @@ var familyToOid = map[Family]oid.Oid{
 	JsonFamily:           oid.T_jsonb,
 	TSQueryFamily:        oid.T_tsquery,
 	TSVectorFamily:       oid.T_tsvector,
+	RangeFamily:          oid.T_int8range,
 	TupleFamily:          oid.T_record,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
@@ -200,6 +212,9 @@ func init() {
 // CalcArrayOid returns the OID of the array type having elements of the given
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	o := elemTyp.Oid()
 	switch elemTyp.Family() {
 	case ArrayFamily:
@@ -198,6 +198,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// ImplicitRecordType is true if the metadata is for an implicit record type
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
@@ -220,6 +223,23 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed to enforce its constraints.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, if any.
+	DefaultExpr *string
+	// Checks contains the CHECK constraints of the domain. The expressions
+	// refer to the checked value as VALUE.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a DOMAIN.
+type DomainCheck struct {
+	Name string
+	Expr string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -514,6 +534,41 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// AnyRange is a special type used only during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
+	// Ranges contains all of the built-in range types.
+	Ranges = []*T{
+		Int4Range,
+		Int8Range,
+		DateRange,
+		TSRange,
+		TSTZRange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -1135,6 +1190,34 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type with the given stable
+// type ID over the given base type. The domain shares the family, width and
+// other attributes of its base type. Note that it does not hydrate cached
+// fields on the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	t := &T{InternalType: base.InternalType}
+	t.InternalType.Oid = typeOID
+	t.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+		BaseOID:      base.Oid(),
+	}
+	return t
+}
+
+// IsDomain returns true if t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.BaseOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type. Values of a domain
+// type are represented as values of its base type.
+func (t *T) DomainBaseType() *T {
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.BaseOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1297,6 +1380,11 @@ func (t *T) TypeModifier() int32 {
 	if t.Oid() == oid.T_char {
 		return int32(-1)
 	}
+	// The type modifier of a domain is a property of the domain itself, so
+	// values of the domain type have no type modifier.
+	if t.IsDomain() {
+		return int32(-1)
+	}
 
 	switch t.Family() {
 	case StringFamily, CollatedStringFamily:
@@ -1355,6 +1443,11 @@ func (t *T) WithoutTypeModifiers() *T {
 		return t
 	}
 
+	// The type modifiers of domains are part of their definition.
+	if t.IsDomain() {
+		return t
+	}
+
 	// For types that can be a collated string, we copy the type and set the width
 	// to 0 rather than returning the default OidToType type so that we retain the
 	// locale value if the type is collated.
@@ -1389,6 +1482,30 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// NumArrayDimensions returns the number of dimensions of an ArrayFamily type.
+// A multi-dimensional array type is an array whose elements are themselves
+// arrays, so INT[][] has two dimensions. It returns 0 for non-array types.
+func (t *T) NumArrayDimensions() int {
+	n := 0
+	for ; t.Family() == ArrayFamily; t = t.ArrayContents() {
+		n++
+	}
+	return n
+}
+
+// ArrayInnermostContents returns the type of the scalar elements of a
+// (possibly multi-dimensional) ArrayFamily type. For example, it returns INT
+// for both INT[] and INT[][]. It returns nil for non-array types.
+func (t *T) ArrayInnermostContents() *T {
+	if t.Family() != ArrayFamily {
+		return nil
+	}
+	for t.Family() == ArrayFamily {
+		t = t.ArrayContents()
+	}
+	return t
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1402,6 +1519,27 @@ func (t *T) TupleLabels() []string {
 	return t.InternalType.TupleLabels
 }
 
+// RangeContents returns the type of the elements of a range type. This is nil
+// for non-RangeFamily types and for AnyRange.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	switch t.Oid() {
+	case oid.T_int4range:
+		return Int4
+	case oid.T_int8range:
+		return Int
+	case oid.T_daterange:
+		return Date
+	case oid.T_tsrange:
+		return Timestamp
+	case oid.T_tstzrange:
+		return TimestampTZ
+	}
+	return nil
+}
+
 // UserDefinedArrayOID returns the OID of the array type that corresponds to
 // this user defined type. This function only can only be called on user
 // defined types and returns non-zero data only for user defined types that
@@ -1464,6 +1602,7 @@ var familyNames = map[Family]string{
 	TimeTZFamily:         "timetz",
 	TSQueryFamily:        "tsquery",
 	TSVectorFamily:       "tsvector",
+	RangeFamily:          "range",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1490,6 +1629,13 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return t.DomainBaseType().Name()
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1553,6 +1699,9 @@ func (t *T) Name() string {
 	case TupleFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily:
+		return t.SQLStandardName()
+
 	case EnumFamily:
 		if t.Oid() == oid.T_anyenum {
 			return "anyenum"
@@ -1627,6 +1776,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1711,6 +1863,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "oid"
 		case oid.T_regclass:
 			return "regclass"
+		case oid.T_regconfig:
+			return "regconfig"
 		case oid.T_regnamespace:
 			return "regnamespace"
 		case oid.T_regproc:
@@ -1785,6 +1939,22 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "tsquery"
 	case TSVectorFamily:
 		return "tsvector"
+	case RangeFamily:
+		switch t.Oid() {
+		case oid.T_int4range:
+			return "int4range"
+		case oid.T_int8range:
+			return "int8range"
+		case oid.T_daterange:
+			return "daterange"
+		case oid.T_tsrange:
+			return "tsrange"
+		case oid.T_tstzrange:
+			return "tstzrange"
+		case oid.T_anyrange:
+			return "anyrange"
+		}
+		panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1825,6 +1995,13 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		// See the comment for EnumFamily below.
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1989,6 +2166,16 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily:
+		// If one of the types is anyrange, then allow the comparison to go
+		// through -- anyrange is used when matching overloads.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2304,11 +2491,6 @@ func (t *T) upgradeType() error {
 			t.InternalType.Oid = CalcArrayOid(t.ArrayContents())
 		}
 
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be unmarshaled")
-		}
-
 		// Zero out fields that may have been used to store information about
 		// the array element type, or which are no longer in use.
 		t.InternalType.Width = 0
@@ -2421,6 +2603,10 @@ func (t *T) downgradeType() error {
 		}
 
 	case StringFamily, CollatedStringFamily:
+		if t.IsDomain() {
+			// Domains did not exist in previous versions.
+			break
+		}
 		switch t.Oid() {
 		case oid.T_text:
 			// Nothing to do.
@@ -2437,11 +2623,6 @@ func (t *T) downgradeType() error {
 		}
 
 	case ArrayFamily:
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be marshaled")
-		}
-
 		// Downgrade to array representation used before 19.2, in which the array
 		// type fields specified the width, locale, etc. of the element type.
 		temp := *t.InternalType.ArrayContents
@@ -2654,6 +2835,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case TSVectorFamily:
 		return false, 90886
+	case RangeFamily:
+		return false, 27791
 	default:
 		return true, 0
 	}
@@ -2669,6 +2852,20 @@ func CheckArrayElementType(t *T) error {
 	return nil
 }
 
+// MaxArrayDimensions is the maximum number of dimensions of an array type. It
+// matches the limit in Postgres.
+const MaxArrayDimensions = 6
+
+// CheckArrayDimensions ensures that the given array type does not have more
+// than MaxArrayDimensions dimensions. If it does, it returns an error.
+func CheckArrayDimensions(t *T) error {
+	if n := t.NumArrayDimensions(); n > MaxArrayDimensions {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)", n, MaxArrayDimensions)
+	}
+	return nil
+}
+
 // IsDateTimeType returns true if the given type is a date or time-related type.
 func IsDateTimeType(t *T) bool {
 	switch t.Family() {
@@ -2842,6 +3039,7 @@ var unreservedTypeTokens = map[string]*T{
 	"oidvector": OidVector,
 	// Postgres OID pseudo-types. See https://www.postgresql.org/docs/9.4/static/datatype-oid.html.
 	"regclass":     RegClass,
+	"regconfig":    RegConfig,
 	"regnamespace": RegNamespace,
 	"regproc":      RegProc,
 	"regprocedure": RegProcedure,
@@ -2872,6 +3070,7 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"macaddr":       45813,
 	"macaddr8":      45813,
 	"money":         41578,
+	"numrange":      27791,
 	"path":          21286,
 	"pg_lsn":        -1,
 	"txid_snapshot": -1,
@@ -1126,7 +1126,7 @@ CREATE TABLE pg_catalog.pg_ts_template (
 	tmpllexize REGPROC
 )`
 
-// PgCatalogPublicationRel is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublicationRel describes the schema of pg_catalog.pg_publication_rel.
 const PgCatalogPublicationRel = `
 CREATE TABLE pg_catalog.pg_publication_rel (
 	oid OID,
@@ -1385,7 +1385,7 @@ CREATE TABLE pg_catalog.pg_statio_all_indexes (
 	idx_blks_hit INT
 )`
 
-// PgCatalogTsConfig is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfig describes the schema of the pg_catalog.pg_ts_config table.
 const PgCatalogTsConfig = `
 CREATE TABLE pg_catalog.pg_ts_config (
 	oid OID,
@@ -1546,7 +1546,7 @@ CREATE TABLE pg_catalog.pg_hba_file_rules (
 	error STRING
 )`
 
-// PgCatalogPublication is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublication describes the schema of pg_catalog.pg_publication.
 const PgCatalogPublication = `
 CREATE TABLE pg_catalog.pg_publication (
 	oid OID,
@@ -1673,7 +1673,7 @@ CREATE TABLE pg_catalog.pg_stat_xact_user_tables (
 	n_tup_hot_upd INT
 )`
 
-// PgCatalogPublicationTables is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublicationTables describes the schema of pg_catalog.pg_publication_tables.
 const PgCatalogPublicationTables = `
 CREATE TABLE pg_catalog.pg_publication_tables (
 	pubname NAME,
@@ -1898,7 +1898,7 @@ CREATE TABLE pg_catalog.pg_stat_user_tables (
 	autoanalyze_count INT
 )`
 
-// PgCatalogSubscription is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogSubscription describes the schema of pg_catalog.pg_subscription.
 const PgCatalogSubscription = `
 CREATE TABLE pg_catalog.pg_subscription (
 	oid OID,
@@ -1912,7 +1912,7 @@ CREATE TABLE pg_catalog.pg_subscription (
 	subpublications STRING[]
 )`
 
-// PgCatalogTsDict is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsDict describes the schema of the pg_catalog.pg_ts_dict table.
 const PgCatalogTsDict = `
 CREATE TABLE pg_catalog.pg_ts_dict (
 	oid OID,
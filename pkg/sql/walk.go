@@ -120,6 +120,7 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 	switch n := plan.(type) {
 	case *valuesNode:
 	case *scanNode:
+	case *foreignScanNode:
 
 	case *filterNode:
 		n.source.plan = v.visit(n.source.plan)
@@ -347,6 +348,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabaseDropSecondaryRegion{}):        "alter database secondary region",
 	reflect.TypeOf(&alterDatabaseSetZoneConfigExtensionNode{}): "alter database configure zone extension",
 	reflect.TypeOf(&alterDefaultPrivilegesNode{}):              "alter default privileges",
+	reflect.TypeOf(&alterDomainNode{}):                         "alter domain",
 	reflect.TypeOf(&alterFunctionOptionsNode{}):                "alter function",
 	reflect.TypeOf(&alterFunctionRenameNode{}):                 "alter function rename",
 	reflect.TypeOf(&alterFunctionSetOwnerNode{}):               "alter function owner",
@@ -381,12 +383,16 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&controlJobsNode{}):                         "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):                    "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):                      "create database",
+	reflect.TypeOf(&createDomainNode{}):                        "create domain",
 	reflect.TypeOf(&createExtensionNode{}):                     "create extension",
 	reflect.TypeOf(&createExternalConectionNode{}):             "create external connection",
+	reflect.TypeOf(&createForeignTableNode{}):                  "create foreign table",
 	reflect.TypeOf(&createFunctionNode{}):                      "create function",
 	reflect.TypeOf(&createIndexNode{}):                         "create index",
+	reflect.TypeOf(&createPublicationNode{}):                   "create publication",
 	reflect.TypeOf(&createSequenceNode{}):                      "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                        "create schema",
+	reflect.TypeOf(&createServerNode{}):                        "create server",
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
 	reflect.TypeOf(&createTableNode{}):                         "create table",
 	reflect.TypeOf(&createTenantNode{}):                        "create tenant",
@@ -400,10 +406,13 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&distinctNode{}):                            "distinct",
 	reflect.TypeOf(&dropDatabaseNode{}):                        "drop database",
 	reflect.TypeOf(&dropExternalConnectionNode{}):              "drop external connection",
+	reflect.TypeOf(&dropForeignTableNode{}):                    "drop foreign table",
 	reflect.TypeOf(&dropFunctionNode{}):                        "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                           "drop index",
+	reflect.TypeOf(&dropPublicationNode{}):                     "drop publication",
 	reflect.TypeOf(&dropSequenceNode{}):                        "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
+	reflect.TypeOf(&dropServerNode{}):                          "drop server",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
 	reflect.TypeOf(&dropTenantNode{}):                          "drop tenant",
 	reflect.TypeOf(&dropTypeNode{}):                            "drop type",
@@ -416,6 +425,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&exportNode{}):                              "export",
 	reflect.TypeOf(&fetchNode{}):                               "fetch",
 	reflect.TypeOf(&filterNode{}):                              "filter",
+	reflect.TypeOf(&foreignScanNode{}):                         "foreign scan",
 	reflect.TypeOf(&GrantRoleNode{}):                           "grant role",
 	reflect.TypeOf(&groupNode{}):                               "group",
 	reflect.TypeOf(&hookFnNode{}):                              "plugin",
@@ -426,8 +436,10 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&invertedJoinNode{}):                        "inverted join",
 	reflect.TypeOf(&joinNode{}):                                "join",
 	reflect.TypeOf(&limitNode{}):                               "limit",
+	reflect.TypeOf(&listenNode{}):                              "listen",
 	reflect.TypeOf(&lookupJoinNode{}):                          "lookup join",
 	reflect.TypeOf(&max1RowNode{}):                             "max1row",
+	reflect.TypeOf(&notifyNode{}):                              "notify",
 	reflect.TypeOf(&ordinalityNode{}):                          "ordinality",
 	reflect.TypeOf(&projectSetNode{}):                          "project set",
 	reflect.TypeOf(&reassignOwnedByNode{}):                     "reassign owned by",
@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -76,6 +77,9 @@ type windowFuncHolder struct {
 	partitionIdxs  []int
 	columnOrdering colinfo.ColumnOrdering
 	frame          *tree.WindowFrame
+
+	// userDefined is set if the function is a user-defined aggregate.
+	userDefined *exec.UserDefinedAggInfo
 }
 
 // samePartition returns whether w and other have the same PARTITION BY clause.
@@ -14,6 +14,7 @@ import (
 	"math"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/redact"
 )
 
@@ -356,6 +357,11 @@ func (t TxnMeta) SafeFormat(w redact.SafePrinter, _ rune) {
 		t.WriteTimestamp,
 		t.MinTimestamp,
 		t.Sequence)
+	// Only print the isolation level if it is not the default, to keep the
+	// common case compact.
+	if t.IsoLevel != isolation.Serializable {
+		w.Printf(" iso=%s", t.IsoLevel)
+	}
 }
 
 // FormatBytesAsKey is injected by module roachpb as dependency upon initialization.
@@ -3364,6 +3364,7 @@ var charts = []sectionDescription{
 					"jobs.auto_span_config_reconciliation.currently_running",
 					"jobs.auto_sql_stats_compaction.currently_running",
 					"jobs.stream_replication.currently_running",
+					"jobs.logical_replication.currently_running",
 				},
 			},
 			{
@@ -3376,6 +3377,7 @@ var charts = []sectionDescription{
 					"jobs.changefeed.currently_idle",
 					"jobs.create_stats.currently_idle",
 					"jobs.import.currently_idle",
+					"jobs.logical_replication.currently_idle",
 					"jobs.migration.currently_idle",
 					"jobs.new_schema_change.currently_idle",
 					"jobs.restore.currently_idle",
@@ -3528,6 +3530,17 @@ var charts = []sectionDescription{
 					"jobs.stream_replication.resume_retry_error",
 				},
 			},
+			{
+				Title: "Logical Replication",
+				Metrics: []string{
+					"jobs.logical_replication.fail_or_cancel_completed",
+					"jobs.logical_replication.fail_or_cancel_failed",
+					"jobs.logical_replication.fail_or_cancel_retry_error",
+					"jobs.logical_replication.resume_completed",
+					"jobs.logical_replication.resume_failed",
+					"jobs.logical_replication.resume_retry_error",
+				},
+			},
 			{
 				Title: "Long Running Migrations",
 				Metrics: []string{
@@ -212,6 +212,11 @@ export const typeOptions = [
     name: "Time-to-live Deletions",
     key: Object.keys(JobType)[JobType.ROW_LEVEL_TTL],
   },
+  {
+    value: JobType.LOGICAL_REPLICATION.toString(),
+    name: "Logical Replication",
+    key: Object.keys(JobType)[JobType.LOGICAL_REPLICATION],
+  },
 ];
 
 export const showOptions = [
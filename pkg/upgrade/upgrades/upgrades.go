@@ -253,6 +253,12 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.NoPrecondition,
 		alterSystemSQLInstancesAddSqlAddr,
 	),
+	upgrade.NewTenantUpgrade(
+		"create system.notifications table",
+		toCV(clusterversion.V23_1_CreateSystemNotificationsTable),
+		upgrade.NoPrecondition,
+		systemNotificationsTableMigration,
+	),
 }
 
 func init() {
@@ -833,6 +833,15 @@ func EncodeStringAscending(b []byte, s string) []byte {
 	return encodeStringAscendingWithTerminatorAndPrefix(b, s, ascendingBytesEscapes.escapedTerm, bytesMarker)
 }
 
+// EncodeStringPrefixAscending encodes the string value like
+// EncodeStringAscending, but without the terminator. The result is a prefix of
+// the encoding of every string that begins with s, so it can be used to
+// construct a span that contains all such strings.
+func EncodeStringPrefixAscending(b []byte, s string) []byte {
+	b = append(b, bytesMarker)
+	return encodeBytesAscendingWithoutTerminatorOrPrefix(b, UnsafeConvertStringToBytes(s))
+}
+
 // encodeStringAscendingWithTerminatorAndPrefix encodes the string value using an escape-based encoding. See
 // EncodeBytes for details. The encoded bytes are append to the supplied buffer
 // and the resulting buffer is returned. We can also pass a terminator byte to be used with
@@ -1679,6 +1688,7 @@ const (
 	Void         Type = 25
 	TSQuery      Type = 26
 	TSVector     Type = 27
+	Range        Type = 28
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -2654,6 +2664,14 @@ func EncodeTSVectorValue(appendTo []byte, colID uint32, data []byte) []byte {
 	return EncodeUntaggedBytesValue(appendTo, data)
 }
 
+// EncodeRangeValue encodes an already-byte-encoded range value with no value
+// tag but with a length prefix, appends it to the supplied buffer, and returns
+// the final buffer.
+func EncodeRangeValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, Range)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
 // DecodeValueTag decodes a value encoded by EncodeValueTag, used as a prefix in
 // each of the other EncodeFooValue methods.
 //
@@ -3042,7 +3060,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 		return dataOffset + n, err
 	case Float:
 		return dataOffset + floatValueEncodedLength, nil
-	case Bytes, Array, JSON, Geo, TSVector, TSQuery:
+	case Bytes, Array, JSON, Geo, TSVector, TSQuery, Range:
 		_, n, i, err := DecodeNonsortingUvarint(b)
 		return dataOffset + n + int(i), err
 	case Box2D:
@@ -0,0 +1,503 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// This file implements the text search parsing and normalization pipeline,
+// which turns documents and user queries into TSVectors and TSQueries.
+//
+// A text search configuration is made up of a parser, which splits a document
+// into tokens, and a dictionary, which normalizes each token into a lexeme.
+// Normalization lower-cases the token, discards it entirely if it's a stop
+// word, and otherwise optionally reduces it to its stem. Stop words still
+// occupy a position within the document, so that phrase queries continue to
+// take them into account.
+//
+// See https://www.postgresql.org/docs/current/textsearch-controls.html.
+
+// tsDictionary normalizes tokens into lexemes.
+type tsDictionary struct {
+	stopWords map[string]struct{}
+	stem      func(string) string
+}
+
+// lexize returns the lexeme for the given token, or false if the token is a
+// stop word.
+func (d *tsDictionary) lexize(token string) (string, bool) {
+	lexeme := strings.ToLower(token)
+	if _, ok := d.stopWords[lexeme]; ok {
+		return "", false
+	}
+	if d.stem != nil {
+		lexeme = d.stem(lexeme)
+	}
+	return lexeme, true
+}
+
+// registry holds the text search dictionaries and configurations that are
+// available, by name. It's initialized with the builtin ones, and may be
+// extended with RegisterDictionary and RegisterConfig.
+var registry = struct {
+	syncutil.RWMutex
+	dictionaries map[string]*tsDictionary
+	// configs maps the names of the text search configurations to the names of
+	// the dictionaries they use.
+	configs map[string]string
+}{
+	dictionaries: map[string]*tsDictionary{
+		"simple": {},
+		"english_stem": {
+			stopWords: englishStopWords,
+			stem:      stemEnglish,
+		},
+	},
+	configs: map[string]string{
+		"simple":  "simple",
+		"english": "english_stem",
+	},
+}
+
+// RegisterDictionary adds a text search dictionary which normalizes tokens by
+// discarding the given stop words and reducing the other tokens with the
+// given stemming function, which may be nil. Tokens are lower-cased before
+// they're compared to the stop words and stemmed.
+func RegisterDictionary(name string, stopWords []string, stem func(string) string) error {
+	name = trimCatalogPrefix(name)
+	d := &tsDictionary{stopWords: make(map[string]struct{}, len(stopWords)), stem: stem}
+	for _, w := range stopWords {
+		d.stopWords[strings.ToLower(w)] = struct{}{}
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.dictionaries[name]; ok {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"text search dictionary %q already exists", name)
+	}
+	registry.dictionaries[name] = d
+	return nil
+}
+
+// RegisterConfig adds a text search configuration which normalizes tokens with
+// the given dictionary.
+func RegisterConfig(name string, dictionary string) error {
+	name, dictionary = trimCatalogPrefix(name), trimCatalogPrefix(dictionary)
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.configs[name]; ok {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"text search configuration %q already exists", name)
+	}
+	if _, ok := registry.dictionaries[dictionary]; !ok {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", dictionary)
+	}
+	registry.configs[name] = dictionary
+	return nil
+}
+
+// Configs returns the names of the available text search configurations,
+// along with the names of the dictionaries they use, in sorted order.
+func Configs() (names []string, dictionaries []string) {
+	registry.RLock()
+	defer registry.RUnlock()
+	names = make([]string, 0, len(registry.configs))
+	for name := range registry.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	dictionaries = make([]string, len(names))
+	for i, name := range names {
+		dictionaries[i] = registry.configs[name]
+	}
+	return names, dictionaries
+}
+
+// Dictionaries returns the names of the available text search dictionaries, in
+// sorted order.
+func Dictionaries() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.dictionaries))
+	for name := range registry.dictionaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// trimCatalogPrefix removes the optional pg_catalog schema qualification from
+// the name of a text search configuration or dictionary.
+func trimCatalogPrefix(name string) string {
+	return strings.TrimPrefix(strings.ToLower(name), "pg_catalog.")
+}
+
+func getDictionary(name string) (*tsDictionary, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	dict, ok := registry.dictionaries[trimCatalogPrefix(name)]
+	return dict, ok
+}
+
+func getConfigDictionary(config string) (*tsDictionary, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	if dict, ok := registry.configs[trimCatalogPrefix(config)]; ok {
+		return registry.dictionaries[dict], nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", config)
+}
+
+// ValidConfig returns an error if the given text search configuration does
+// not exist.
+func ValidConfig(config string) error {
+	_, err := getConfigDictionary(config)
+	return err
+}
+
+// tsToken is a token produced by the text search parser. Word tokens are
+// interleaved with the non-word runs of text that separate them, so that the
+// original document can be reconstructed from the tokens.
+type tsToken struct {
+	text string
+	word bool
+}
+
+func isTSWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// tsTokenize splits the input into alternating runs of word and non-word
+// characters. Words are made up of letters and digits.
+func tsTokenize(input string) []tsToken {
+	var ret []tsToken
+	start := 0
+	for start < len(input) {
+		r, _ := utf8.DecodeRuneInString(input[start:])
+		word := isTSWordRune(r)
+		end := start
+		for end < len(input) {
+			r, n := utf8.DecodeRuneInString(input[end:])
+			if isTSWordRune(r) != word {
+				break
+			}
+			end += n
+		}
+		ret = append(ret, tsToken{text: input[start:end], word: word})
+		start = end
+	}
+	return ret
+}
+
+// TSParse splits the input text into a list of word tokens, discarding
+// whitespace and punctuation.
+func TSParse(input string) []string {
+	return strings.FieldsFunc(input, func(r rune) bool {
+		return !isTSWordRune(r)
+	})
+}
+
+// tsLexeme is a normalized token along with its 1-based position within the
+// text it was parsed from.
+type tsLexeme struct {
+	lexeme   string
+	position int
+}
+
+// lexemes parses the input and normalizes each of its tokens using the
+// receiver, discarding stop words and words that are too long to be indexed.
+func (d *tsDictionary) lexemes(input string) []tsLexeme {
+	return d.lexemesFromTokens(TSParse(input))
+}
+
+// lexemesFromTokens normalizes each of the given tokens using the receiver,
+// discarding stop words and words that are too long to be indexed.
+func (d *tsDictionary) lexemesFromTokens(tokens []string) []tsLexeme {
+	ret := make([]tsLexeme, 0, len(tokens))
+	for i, token := range tokens {
+		lexeme, ok := d.lexize(token)
+		if !ok || len(lexeme) > maxTSLexemeLen {
+			continue
+		}
+		ret = append(ret, tsLexeme{lexeme: lexeme, position: i + 1})
+	}
+	return ret
+}
+
+// DocumentToTSVector parses an input document into a TSVector using the given
+// text search configuration.
+func DocumentToTSVector(config string, input string) (TSVector, error) {
+	return DocumentsToTSVector(config, []string{input})
+}
+
+// DocumentsToTSVector parses a list of input documents into a single TSVector
+// using the given text search configuration. The positions of each document
+// follow the positions of the previous one, separated by a gap of one
+// position, so that phrase queries don't match across documents.
+func DocumentsToTSVector(config string, inputs []string) (TSVector, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return nil, err
+	}
+	var ret TSVector
+	offset := 0
+	for _, input := range inputs {
+		tokens := TSParse(input)
+		for _, l := range dict.lexemesFromTokens(tokens) {
+			pos := offset + l.position
+			if pos > maxTSVectorPosition {
+				// Postgres silently truncates positions larger than 16383 to 16383.
+				pos = maxTSVectorPosition
+			}
+			ret = append(ret, tsTerm{
+				lexeme:    l.lexeme,
+				positions: []tsPosition{{position: uint16(pos)}},
+			})
+		}
+		if len(tokens) > 0 {
+			offset += len(tokens) + 1
+		}
+	}
+	return sortAndUniqTSVector(ret), nil
+}
+
+// newLexemeNode returns a leaf node for the given lexeme. The weight and
+// prefix matching flags are copied from the given template term, if any.
+func newLexemeNode(lexeme string, template *tsTerm) *tsNode {
+	term := tsTerm{lexeme: lexeme}
+	if template != nil && len(template.positions) > 0 {
+		term.positions = append([]tsPosition(nil), template.positions...)
+	}
+	return &tsNode{term: term}
+}
+
+// makeFollowedByNode returns a node that requires r to follow l by exactly n
+// positions, clamping n to the largest allowable distance.
+func makeFollowedByNode(l, r *tsNode, n int) *tsNode {
+	if n > maxTSVectorFollowedBy {
+		n = maxTSVectorFollowedBy
+	}
+	return &tsNode{op: followedby, followedN: uint16(n), l: l, r: r}
+}
+
+// phraseNode returns a node that matches the given lexemes in the order that
+// they appear, taking into account the distance between them. It returns nil
+// if there are no lexemes.
+func phraseNode(lexemes []tsLexeme, template *tsTerm) *tsNode {
+	var ret *tsNode
+	for i, l := range lexemes {
+		n := newLexemeNode(l.lexeme, template)
+		if ret == nil {
+			ret = n
+		} else {
+			ret = makeFollowedByNode(ret, n, l.position-lexemes[i-1].position)
+		}
+	}
+	return ret
+}
+
+// joinNodes combines two possibly nil nodes with the given operator.
+func joinNodes(op tsOperator, l, r *tsNode) *tsNode {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	return &tsNode{op: op, l: l, r: r}
+}
+
+// ToTSQuery parses the input text into a TSQuery using the given text search
+// configuration. The input must be in the TSQuery input format, and each of
+// its terms is normalized according to the configuration. Terms that are
+// stop words are removed from the query.
+func ToTSQuery(config string, input string) (TSQuery, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	q, err := ParseTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	root, _, _ := dict.normalizeNode(q.root)
+	return TSQuery{root: root}, nil
+}
+
+// normalizeNode normalizes the lexemes of the tree rooted at the given node
+// according to the receiver, removing the ones that are stop words. It returns
+// the new tree, which is nil if every lexeme was removed.
+//
+// The removal of a stop word from a followed by operator must still be
+// accounted for in the distance of the remaining operators: for example,
+// 'cat <-> the <-> rat' becomes 'cat' <2> 'rat'. lAdd and rAdd are the
+// distances that were removed from the left and right edges of the returned
+// tree, which the caller must absorb. This follows the Postgres implementation
+// in src/backend/utils/adt/tsquery_cleanup.c.
+func (d *tsDictionary) normalizeNode(n *tsNode) (ret *tsNode, lAdd, rAdd int) {
+	switch n.op {
+	case invalid:
+		// A single query term might be parsed into more than one lexeme, in which
+		// case they are joined into a phrase.
+		return phraseNode(d.lexemes(n.term.lexeme), &n.term), 0, 0
+	case not:
+		l, lAdd, rAdd := d.normalizeNode(n.l)
+		if l == nil {
+			return nil, 0, 0
+		}
+		return &tsNode{op: not, l: l}, lAdd, rAdd
+	}
+	l, llAdd, lrAdd := d.normalizeNode(n.l)
+	r, rlAdd, rrAdd := d.normalizeNode(n.r)
+	var distance int
+	if n.op == followedby {
+		distance = int(n.followedN)
+	}
+	switch {
+	case l == nil && r == nil:
+		// Treat the removed followed by operator as a stop word of its own width.
+		if n.op == followedby {
+			width := llAdd + distance + rlAdd
+			return nil, width, width
+		}
+		return nil, 0, 0
+	case l == nil:
+		if n.op == followedby {
+			return r, llAdd + distance + rlAdd, rrAdd
+		}
+		return r, rlAdd, rrAdd
+	case r == nil:
+		if n.op == followedby {
+			return l, llAdd, lrAdd + distance + rrAdd
+		}
+		return l, llAdd, lrAdd
+	case n.op == followedby:
+		return makeFollowedByNode(l, r, distance+lrAdd+rlAdd), llAdd, rrAdd
+	}
+	return &tsNode{op: n.op, l: l, r: r}, 0, 0
+}
+
+// PlainToTSQuery converts the input text into a TSQuery that matches documents
+// that contain all of its non-stop words, using the given text search
+// configuration. Punctuation and TSQuery operators in the input are ignored.
+func PlainToTSQuery(config string, input string) (TSQuery, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var root *tsNode
+	for _, l := range dict.lexemes(input) {
+		root = joinNodes(and, root, newLexemeNode(l.lexeme, nil /* template */))
+	}
+	return TSQuery{root: root}, nil
+}
+
+// PhraseToTSQuery converts the input text into a TSQuery that matches
+// documents that contain all of its non-stop words in the same order and at
+// the same distance from each other, using the given text search
+// configuration.
+func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: phraseNode(dict.lexemes(input), nil /* template */)}, nil
+}
+
+// WebSearchToTSQuery converts the input text into a TSQuery using the given
+// text search configuration, using a syntax similar to the one used by web
+// search engines:
+//   - Unquoted words are combined with the & operator.
+//   - Text within double quotes is converted into a phrase.
+//   - The word "or" combines the terms to its left and right with the |
+//     operator.
+//   - A dash immediately preceding a word or a quoted phrase negates it.
+//
+// Unlike ToTSQuery, this function never returns a syntax error.
+func WebSearchToTSQuery(config string, input string) (TSQuery, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var root, group *tsNode
+	negate := false
+	for pos := 0; pos < len(input); {
+		r, size := utf8.DecodeRuneInString(input[pos:])
+		var n *tsNode
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+			negate = false
+			continue
+		case r == '-':
+			pos += size
+			negate = true
+			continue
+		case r == '"':
+			end := strings.IndexByte(input[pos+1:], '"')
+			if end < 0 {
+				end = len(input)
+			} else {
+				end += pos + 1
+			}
+			n = phraseNode(dict.lexemes(input[pos+1:end]), nil /* template */)
+			pos = end + 1
+		default:
+			end := strings.IndexFunc(input[pos:], func(r rune) bool {
+				return unicode.IsSpace(r) || r == '"'
+			})
+			if end < 0 {
+				end = len(input)
+			} else {
+				end += pos
+			}
+			word := input[pos:end]
+			pos = end
+			if !negate && strings.EqualFold(word, "or") {
+				root = joinNodes(or, root, group)
+				group = nil
+				continue
+			}
+			n = phraseNode(dict.lexemes(word), nil /* template */)
+		}
+		if n != nil && negate {
+			n = &tsNode{op: not, l: n}
+		}
+		group = joinNodes(and, group, n)
+		negate = false
+	}
+	return TSQuery{root: joinNodes(or, root, group)}, nil
+}
+
+// TSLexize returns the lexemes that the given text search dictionary produces
+// for the given token. The result is empty if the token is a stop word.
+func TSLexize(dictionary string, token string) ([]string, error) {
+	dict, ok := getDictionary(dictionary)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", dictionary)
+	}
+	lexeme, ok := dict.lexize(token)
+	if !ok {
+		return []string{}, nil
+	}
+	return []string{lexeme}, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStemEnglish(t *testing.T) {
+	tcs := []struct {
+		word     string
+		expected string
+	}{
+		{"a", "a"},
+		{"cats", "cat"},
+		{"rats", "rat"},
+		{"caresses", "caress"},
+		{"ponies", "poni"},
+		{"ties", "tie"},
+		{"gaps", "gap"},
+		{"gas", "gas"},
+		{"kiwis", "kiwi"},
+		{"feed", "feed"},
+		{"agreed", "agre"},
+		{"plastered", "plaster"},
+		{"motoring", "motor"},
+		{"sing", "sing"},
+		{"conflated", "conflat"},
+		{"troubled", "troubl"},
+		{"sized", "size"},
+		{"hopping", "hop"},
+		{"hoping", "hope"},
+		{"tanned", "tan"},
+		{"falling", "fall"},
+		{"hissing", "hiss"},
+		{"fizzed", "fizz"},
+		{"failing", "fail"},
+		{"filing", "file"},
+		{"happy", "happi"},
+		{"sky", "sky"},
+		{"skies", "sky"},
+		{"dying", "die"},
+		{"relational", "relat"},
+		{"generalizations", "general"},
+		{"communism", "communism"},
+		{"running", "run"},
+		{"supernovae", "supernova"},
+		{"consignment", "consign"},
+		{"consolation", "consol"},
+		{"generously", "generous"},
+		{"knack", "knack"},
+		{"searching", "search"},
+		{"query", "queri"},
+		{"similarity", "similar"},
+		{"yellow", "yellow"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.word, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stemEnglish(tc.word))
+		})
+	}
+}
+
+func TestDocumentToTSVector(t *testing.T) {
+	tcs := []struct {
+		config   string
+		input    string
+		expected string
+	}{
+		{"simple", "", ""},
+		{"simple", "The Fat Rats", "'fat':2 'rats':3 'the':1"},
+		{"english", "The Fat Rats", "'fat':2 'rat':3"},
+		{"pg_catalog.english", "The Fat Rats", "'fat':2 'rat':3"},
+		{"english", "a fat  cat sat on a mat - it ate a fat rats",
+			"'ate':9 'cat':3 'fat':2,11 'mat':7 'rat':12 'sat':4"},
+		{"english", "Supernovae, stars; and galaxies!", "'galaxi':4 'star':2 'supernova':1"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := DocumentToTSVector(tc.config, tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v.String())
+		})
+	}
+
+	v, err := DocumentsToTSVector("english", []string{"The Fat Rats", "fat dog"})
+	require.NoError(t, err)
+	assert.Equal(t, "'dog':6 'fat':2,5 'rat':3", v.String())
+
+	_, err = DocumentToTSVector("klingon", "foo")
+	require.EqualError(t, err, `text search configuration "klingon" does not exist`)
+}
+
+func TestToTSQuery(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"The & Fat & Rats", "'fat' & 'rat'"},
+		{"Fat | Rats:AB", "'fat' | 'rat':AB"},
+		{"supern:*A & star:A*B", "'supern':*A & 'star':*AB"},
+		{"!the & cat", "'cat'"},
+		{"cat <-> the <-> rat", "'cat' <2> 'rat'"},
+		{"the <-> cat", "'cat'"},
+		{"cat <-> the", "'cat'"},
+		{"fat & (the | rats)", "'fat' & 'rat'"},
+		{"supernovae-stars", "'supernova' <-> 'star'"},
+		{"the & a", ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.String())
+		})
+	}
+}
+
+func TestPlainAndPhraseToTSQuery(t *testing.T) {
+	tcs := []struct {
+		input  string
+		plain  string
+		phrase string
+	}{
+		{"The Fat Rats", "'fat' & 'rat'", "'fat' <-> 'rat'"},
+		{"The Cat and Rats", "'cat' & 'rat'", "'cat' <2> 'rat'"},
+		{"fat & rat:C", "'fat' & 'rat' & 'c'", "'fat' <-> 'rat' <-> 'c'"},
+		{"the", "", ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := PlainToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.plain, q.String())
+			q, err = PhraseToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.phrase, q.String())
+		})
+	}
+}
+
+func TestWebSearchToTSQuery(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected string
+	}{
+		{"The fat rats", "'fat' & 'rat'"},
+		{`"supernovae stars" -crab`, "'supernova' <-> 'star' & !'crab'"},
+		{`"sad cat" or "fat rat"`, "'sad' <-> 'cat' | 'fat' <-> 'rat'"},
+		{`signal -"segmentation fault"`, "'signal' & !( 'segment' <-> 'fault' )"},
+		{`fat or cat dog`, "'fat' | 'cat' & 'dog'"},
+		{`"unterminated phrase`, "'untermin' <-> 'phrase'"},
+		{`- fat`, "'fat'"},
+		{`the or a`, ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := WebSearchToTSQuery("english", tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.String())
+		})
+	}
+}
+
+func TestTSLexize(t *testing.T) {
+	lexemes, err := TSLexize("english_stem", "stars")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"star"}, lexemes)
+	lexemes, err = TSLexize("english_stem", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, lexemes)
+	lexemes, err = TSLexize("simple", "Stars")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stars"}, lexemes)
+	_, err = TSLexize("klingon_stem", "stars")
+	require.EqualError(t, err, `text search dictionary "klingon_stem" does not exist`)
+}
+
+func TestRegisterConfig(t *testing.T) {
+	require.NoError(t, RegisterDictionary("test_stem", []string{"The", "of"}, func(s string) string {
+		return strings.TrimSuffix(s, "s")
+	}))
+	require.EqualError(t, RegisterDictionary("test_stem", nil, nil),
+		`text search dictionary "test_stem" already exists`)
+	require.EqualError(t, RegisterConfig("test", "missing_stem"),
+		`text search dictionary "missing_stem" does not exist`)
+	require.NoError(t, RegisterConfig("pg_catalog.test", "test_stem"))
+	require.EqualError(t, RegisterConfig("test", "simple"),
+		`text search configuration "test" already exists`)
+
+	names, dictionaries := Configs()
+	assert.Equal(t, []string{"english", "simple", "test"}, names)
+	assert.Equal(t, []string{"english_stem", "simple", "test_stem"}, dictionaries)
+	assert.Equal(t, []string{"english_stem", "simple", "test_stem"}, Dictionaries())
+
+	v, err := DocumentToTSVector("test", "The Lord of the Rings")
+	require.NoError(t, err)
+	assert.Equal(t, "'lord':2 'ring':5", v.String())
+	lexemes, err := TSLexize("test_stem", "Cats")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cat"}, lexemes)
+}
@@ -13,6 +13,8 @@ package tsearch
 import (
 	"bytes"
 
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -176,6 +178,11 @@ func EncodeTSQuery(appendTo []byte, query TSQuery) ([]byte, error) {
 	// back and fill this in later.
 	lengthIdx := len(appendTo)
 	appendTo = encoding.EncodeUint32Ascending(appendTo, 0)
+	if query.root == nil {
+		// An empty query, which can result from a query made up of only stop
+		// words, has no nodes.
+		return appendTo, nil
+	}
 	var encoder tsNodeCodec
 	var err error
 	appendTo, err = encoder.encodeTSNode(query.root, appendTo)
@@ -192,6 +199,9 @@ func DecodeTSQuery(b []byte) (ret TSQuery, err error) {
 	if err != nil {
 		return ret, err
 	}
+	if nTokens == 0 {
+		return ret, nil
+	}
 	decoder := tsNodeCodec{nTokens: int(nTokens)}
 	_, ret.root, err = decoder.decodeTSNode(b)
 	if err != nil {
@@ -217,6 +227,9 @@ func EncodeTSQueryPGBinary(appendTo []byte, query TSQuery) []byte {
 	// back and fill this in later.
 	lengthIdx := len(appendTo)
 	appendTo = encoding.EncodeUint32Ascending(appendTo, 0)
+	if query.root == nil {
+		return appendTo
+	}
 	var encoder tsNodeCodec
 	appendTo = encoder.encodeTSNodePGBinary(query.root, appendTo)
 	return encoding.PutUint32Ascending(appendTo, uint32(encoder.nTokens), lengthIdx)
@@ -229,6 +242,9 @@ func DecodeTSQueryPGBinary(b []byte) (ret TSQuery, err error) {
 	if err != nil {
 		return ret, err
 	}
+	if nTokens == 0 {
+		return ret, nil
+	}
 	decoder := tsNodeCodec{nTokens: int(nTokens)}
 	_, ret.root, err = decoder.decodeTSNodePGBinary(b)
 	if err != nil {
@@ -455,3 +471,110 @@ func (c *tsNodeCodec) decodeTSNodePGBinary(b []byte) ([]byte, *tsNode, error) {
 	}
 	return b, ret, nil
 }
+
+// EncodeInvertedIndexKeys returns the inverted index keys for the input
+// tsvector: one key per lexeme, each prefixed by inKey. The positions and
+// weights of the lexemes are not encoded in the keys, so queries that depend
+// on them must be re-checked against the original tsvector.
+func EncodeInvertedIndexKeys(inKey []byte, vector TSVector) [][]byte {
+	outKeys := make([][]byte, len(vector))
+	for i := range vector {
+		outKeys[i] = EncodeInvertedIndexKey(inKey, vector[i].lexeme)
+	}
+	return outKeys
+}
+
+// EncodeInvertedIndexKey returns the inverted index key for the input lexeme,
+// prefixed by inKey.
+func EncodeInvertedIndexKey(inKey []byte, lexeme string) []byte {
+	// Make sure to copy inKey into a new byte slice to avoid aliasing. The key
+	// needs 3 extra bytes for the marker and the terminator.
+	outKey := make([]byte, len(inKey), len(inKey)+len(lexeme)+3)
+	copy(outKey, inKey)
+	return encoding.EncodeStringAscending(outKey, lexeme)
+}
+
+// GetInvertedExpr returns the inverted expression that can be used to search
+// an inverted index on a tsvector column for documents that match the query.
+//
+// Each lexeme in the query is converted into a span over its inverted index
+// key, or over all keys that begin with it if it is a prefix search term. The
+// & and <-> operators intersect the spans of their operands, and the |
+// operator unions them. The spans of a <-> operator are not tight, since the
+// index doesn't store the positions of the lexemes. Negated terms can't be
+// searched in an index, so they don't constrain the expression. An error is
+// returned if the query can't constrain the index at all, for example because
+// it consists of a single negated term.
+func (q TSQuery) GetInvertedExpr() (inverted.Expression, error) {
+	expr := getInvertedExprForNode(q.root)
+	if expr == nil {
+		return nil, errors.New("unable to create inverted expression for tsquery")
+	}
+	return expr, nil
+}
+
+// getInvertedExprForNode returns the inverted expression for the given query
+// node, or nil if the node can't constrain the index.
+func getInvertedExprForNode(node *tsNode) inverted.Expression {
+	if node == nil {
+		return nil
+	}
+	switch node.op {
+	case invalid:
+		return getInvertedExprForTerm(&node.term)
+	case not:
+		return nil
+	case and, followedby:
+		l, r := getInvertedExprForNode(node.l), getInvertedExprForNode(node.r)
+		var ret inverted.Expression
+		switch {
+		case l == nil && r == nil:
+			return nil
+		case l == nil:
+			// The right side is still required to match, but the expression
+			// is no longer tight since the left side must be re-checked.
+			ret = r
+			ret.SetNotTight()
+		case r == nil:
+			ret = l
+			ret.SetNotTight()
+		default:
+			ret = inverted.And(l, r)
+		}
+		if node.op == followedby {
+			ret.SetNotTight()
+		}
+		return ret
+	case or:
+		l, r := getInvertedExprForNode(node.l), getInvertedExprForNode(node.r)
+		if l == nil || r == nil {
+			// If either side can't constrain the index, then neither can the
+			// disjunction.
+			return nil
+		}
+		return inverted.Or(l, r)
+	}
+	return nil
+}
+
+// getInvertedExprForTerm returns the inverted expression for a single query
+// term.
+func getInvertedExprForTerm(term *tsTerm) inverted.Expression {
+	var weight tsWeight
+	if len(term.positions) > 0 {
+		weight = term.positions[0].weight
+	}
+	if weight&weightStar != 0 {
+		// A prefix search term matches any lexeme that begins with it.
+		start := encoding.EncodeStringPrefixAscending(nil, term.lexeme)
+		span := inverted.Span{Start: start, End: keysbase.PrefixEnd(start)}
+		return inverted.ExprForSpan(span, weight == weightStar /* tight */)
+	}
+	// A document contains each lexeme at most once, so the span is unique.
+	// Terms that are restricted to certain weights must be re-checked, since
+	// the weights aren't stored in the index.
+	key := EncodeInvertedIndexKey(nil /* inKey */, term.lexeme)
+	expr := inverted.ExprForSpan(inverted.MakeSingleValSpan(key), weight == 0 /* tight */)
+	expr.Unique = true
+	return expr
+}
@@ -13,8 +13,10 @@ package tsearch
 import (
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRoundtripRandomTSVector(t *testing.T) {
@@ -66,3 +68,74 @@ func TestRoundtripRandomTSQuery(t *testing.T) {
 		assert.Equal(t, encoded, reEncoded)
 	}
 }
+
+func TestTSQueryGetInvertedExpr(t *testing.T) {
+	docs := []string{
+		"",
+		"a",
+		"b",
+		"a:1 b:2",
+		"b:1 a:2",
+		"a:1 c:2 b:3",
+		"ab:1 c:2",
+		"abc:1A b:2",
+		"c:1 d:2",
+	}
+	vectors := make([]TSVector, len(docs))
+	for i, doc := range docs {
+		var err error
+		vectors[i], err = ParseTSVector(doc)
+		require.NoError(t, err)
+	}
+
+	tcs := []struct {
+		query string
+		ok    bool
+		tight bool
+	}{
+		{query: "a", ok: true, tight: true},
+		{query: "a & b", ok: true, tight: true},
+		{query: "a | b", ok: true, tight: true},
+		{query: "a | b & c", ok: true, tight: true},
+		{query: "ab:*", ok: true, tight: true},
+		{query: "ab:* & c", ok: true, tight: true},
+		{query: "a <-> b", ok: true, tight: false},
+		{query: "a <2> b", ok: true, tight: false},
+		{query: "a & !b", ok: true, tight: false},
+		{query: "!b & a", ok: true, tight: false},
+		{query: "a | !b", ok: false},
+		{query: "!a", ok: false},
+		{query: "!a & !b", ok: false},
+		{query: "a:A", ok: true, tight: false},
+		{query: "(a | c) & (b | d)", ok: true, tight: true},
+		{query: "(a <-> b) | (c <-> d)", ok: true, tight: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			expr, err := q.GetInvertedExpr()
+			if !tc.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.tight, expr.IsTight())
+			spanExpr, ok := expr.(*inverted.SpanExpression)
+			require.True(t, ok)
+			for i, v := range vectors {
+				matches, err := EvalTSQuery(q, v)
+				require.NoError(t, err)
+				contains, err := spanExpr.ContainsKeys(EncodeInvertedIndexKeys(nil /* inKey */, v))
+				require.NoError(t, err)
+				if matches {
+					// The index must return every matching document.
+					assert.True(t, contains, "expected %q to be found by the index", docs[i])
+				} else if tc.tight {
+					// A tight expression must not return any false positives.
+					assert.False(t, contains, "expected %q to not be found by the index", docs[i])
+				}
+			}
+		})
+	}
+}
@@ -34,6 +34,11 @@ type tsEvaluator struct {
 }
 
 func (e *tsEvaluator) eval() (bool, error) {
+	if e.q.root == nil {
+		// An empty query, which can result from a query made up of only stop
+		// words, doesn't match anything.
+		return false, nil
+	}
 	return e.evalNode(e.q.root)
 }
 
@@ -0,0 +1,410 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_headline, which produces an excerpt of a document
+// with the matches of a query highlighted. The fragment selection is modeled
+// after the Postgres implementation in src/backend/tsearch/wparser_def.c.
+
+// headlineOptions are the options that control the output of Headline. See
+// https://www.postgresql.org/docs/current/textsearch-controls.html#TEXTSEARCH-HEADLINE
+// for a description of each of them.
+type headlineOptions struct {
+	startSel          string
+	stopSel           string
+	maxWords          int
+	minWords          int
+	shortWord         int
+	highlightAll      bool
+	maxFragments      int
+	fragmentDelimiter string
+}
+
+var defaultHeadlineOptions = headlineOptions{
+	startSel:          "<b>",
+	stopSel:           "</b>",
+	maxWords:          35,
+	minWords:          15,
+	shortWord:         3,
+	maxFragments:      0,
+	fragmentDelimiter: " ... ",
+}
+
+// parseHeadlineOptions parses a comma-separated list of option=value pairs.
+// Values may be enclosed in double quotes.
+func parseHeadlineOptions(input string) (headlineOptions, error) {
+	opts := defaultHeadlineOptions
+	syntaxError := func() (headlineOptions, error) {
+		return opts, pgerror.Newf(pgcode.Syntax, "invalid parameter list format: %q", input)
+	}
+	isSpace := func(r rune) bool { return unicode.IsSpace(r) }
+	rest := strings.TrimLeftFunc(input, isSpace)
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return syntaxError()
+		}
+		key := strings.TrimRightFunc(rest[:eq], isSpace)
+		rest = strings.TrimLeftFunc(rest[eq+1:], isSpace)
+		var val string
+		if strings.HasPrefix(rest, `"`) {
+			// A quoted value may contain doubled double quotes.
+			var buf strings.Builder
+			i := 1
+			for ; i < len(rest); i++ {
+				if rest[i] == '"' {
+					if i+1 < len(rest) && rest[i+1] == '"' {
+						buf.WriteByte('"')
+						i++
+						continue
+					}
+					break
+				}
+				buf.WriteByte(rest[i])
+			}
+			if i >= len(rest) {
+				return syntaxError()
+			}
+			val = buf.String()
+			rest = rest[i+1:]
+		} else {
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})
+			if end < 0 {
+				end = len(rest)
+			}
+			val = rest[:end]
+			rest = rest[end:]
+		}
+		rest = strings.TrimLeftFunc(rest, isSpace)
+		if rest != "" {
+			if rest[0] != ',' {
+				return syntaxError()
+			}
+			rest = strings.TrimLeftFunc(rest[1:], isSpace)
+		}
+		if err := opts.set(key, val); err != nil {
+			return opts, err
+		}
+	}
+	if !opts.highlightAll {
+		if opts.minWords >= opts.maxWords {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be less than MaxWords")
+		}
+		if opts.minWords <= 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be positive")
+		}
+		if opts.shortWord < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "ShortWord should be >= 0")
+		}
+		if opts.maxFragments < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MaxFragments should be >= 0")
+		}
+	}
+	return opts, nil
+}
+
+func (o *headlineOptions) set(key, val string) error {
+	parseInt := func() (int, error) {
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid value for integer option %q: %q", key, val)
+		}
+		return i, nil
+	}
+	var err error
+	switch strings.ToLower(key) {
+	case "startsel":
+		o.startSel = val
+	case "stopsel":
+		o.stopSel = val
+	case "maxwords":
+		o.maxWords, err = parseInt()
+	case "minwords":
+		o.minWords, err = parseInt()
+	case "shortword":
+		o.shortWord, err = parseInt()
+	case "highlightall":
+		switch strings.ToLower(val) {
+		case "1", "on", "true", "t", "y", "yes":
+			o.highlightAll = true
+		default:
+			o.highlightAll = false
+		}
+	case "maxfragments":
+		o.maxFragments, err = parseInt()
+	case "fragmentdelimiter":
+		o.fragmentDelimiter = val
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized headline parameter: %q", key)
+	}
+	return err
+}
+
+// headlineWord is a word within the document passed to Headline.
+type headlineWord struct {
+	// token is the index of the word's token.
+	token int
+	// highlight is true if the word matches one of the query's lexemes.
+	highlight bool
+	// short is true if the word is no longer than the ShortWord option.
+	short bool
+}
+
+// headlineFragment is a range of words, identified by their 1-based positions,
+// that is included in the output of Headline.
+type headlineFragment struct {
+	start, end int
+	// nMatches is the number of distinct query lexemes within the fragment.
+	nMatches int
+}
+
+// Headline returns an excerpt of the given document in which the words that
+// match the query are highlighted, using the given text search configuration.
+// options is a comma-separated list of option=value pairs that control the
+// output.
+func Headline(config string, document string, q TSQuery, options string) (string, error) {
+	dict, err := getConfigDictionary(config)
+	if err != nil {
+		return "", err
+	}
+	opts, err := parseHeadlineOptions(options)
+	if err != nil {
+		return "", err
+	}
+
+	// Parse the document, keeping track of the lexeme of each word so that we
+	// can find the query's matches.
+	tokens := tsTokenize(document)
+	operands := q.operands(false /* includeNegated */)
+	var words []headlineWord
+	var lexemes []string
+	var vec TSVector
+	for i, token := range tokens {
+		if !token.word {
+			continue
+		}
+		w := headlineWord{
+			token: i,
+			short: utf8.RuneCountInString(token.text) <= opts.shortWord,
+		}
+		lexeme, ok := dict.lexize(token.text)
+		if ok && len(lexeme) <= maxTSLexemeLen {
+			pos := len(words) + 1
+			if pos > maxTSVectorPosition {
+				pos = maxTSVectorPosition
+			}
+			vec = append(vec, tsTerm{lexeme: lexeme, positions: []tsPosition{{position: uint16(pos)}}})
+			for _, operand := range operands {
+				if lexeme == operand.lexeme || (isPrefixTerm(operand) && strings.HasPrefix(lexeme, operand.lexeme)) {
+					w.highlight = true
+					break
+				}
+			}
+		} else {
+			lexeme = ""
+		}
+		words = append(words, w)
+		lexemes = append(lexemes, lexeme)
+	}
+	if len(words) == 0 {
+		return document, nil
+	}
+
+	var fragments []headlineFragment
+	if opts.highlightAll {
+		fragments = []headlineFragment{{start: 1, end: len(words)}}
+	} else {
+		covers, err := findCovers(makeDocEntries(sortAndUniqTSVector(vec), q), q)
+		if err != nil {
+			return "", err
+		}
+		// countMatches returns the number of distinct highlighted lexemes within
+		// the given range of words.
+		countMatches := func(start, end int) int {
+			seen := make(map[string]struct{})
+			for i := start; i <= end; i++ {
+				if words[i-1].highlight {
+					seen[lexemes[i-1]] = struct{}{}
+				}
+			}
+			return len(seen)
+		}
+		if opts.maxFragments == 0 {
+			fragments = selectHeadline(words, covers, &opts, countMatches)
+		} else {
+			fragments = selectHeadlineFragments(words, covers, &opts, countMatches)
+		}
+		if len(fragments) == 0 {
+			// If there are no matches, return the start of the document.
+			end := opts.minWords
+			if end > len(words) {
+				end = len(words)
+			}
+			fragments = []headlineFragment{{start: 1, end: end}}
+		}
+	}
+
+	var buf strings.Builder
+	for i, f := range fragments {
+		if i > 0 {
+			buf.WriteString(opts.fragmentDelimiter)
+		}
+		first, last := words[f.start-1].token, words[f.end-1].token
+		if opts.highlightAll {
+			first, last = 0, len(tokens)-1
+		}
+		for j := first; j <= last; j++ {
+			token := tokens[j]
+			if token.word && words[wordIndex(words, j)].highlight {
+				buf.WriteString(opts.startSel)
+				buf.WriteString(token.text)
+				buf.WriteString(opts.stopSel)
+			} else {
+				buf.WriteString(token.text)
+			}
+		}
+		// Include any punctuation that immediately follows the last word of the
+		// fragment, such as a period.
+		if !opts.highlightAll && last+1 < len(tokens) {
+			sep := tokens[last+1].text
+			if end := strings.IndexFunc(sep, unicode.IsSpace); end >= 0 {
+				sep = sep[:end]
+			}
+			buf.WriteString(sep)
+		}
+	}
+	return buf.String(), nil
+}
+
+// wordIndex returns the index within words of the word with the given token
+// index.
+func wordIndex(words []headlineWord, token int) int {
+	return sort.Search(len(words), func(i int) bool {
+		return words[i].token >= token
+	})
+}
+
+// selectHeadline chooses the single best fragment of the document to display.
+// Each cover of the query is trimmed or stretched to be between MinWords and
+// MaxWords long, avoiding ending the fragment on a short word, and the
+// fragment that contains the most distinct matches is chosen.
+func selectHeadline(
+	words []headlineWord,
+	covers []tsCover,
+	opts *headlineOptions,
+	countMatches func(start, end int) int,
+) []headlineFragment {
+	var best headlineFragment
+	for _, c := range covers {
+		f := headlineFragment{start: c.p, end: c.q}
+		if f.end-f.start+1 > opts.maxWords {
+			// The cover is too long, so shrink it, avoiding ending on a short word.
+			f.end = f.start + opts.maxWords - 1
+			for f.end > f.start+opts.minWords-1 && words[f.end-1].short {
+				f.end--
+			}
+		} else {
+			// Extend the cover to the right, ending on the last word that isn't
+			// short.
+			for i := f.end + 1; i <= len(words) && i-f.start+1 <= opts.maxWords; i++ {
+				if !words[i-1].short {
+					f.end = i
+				}
+			}
+			// Then extend the cover to the left if it's still too short.
+			for f.start > 1 && f.end-f.start+1 < opts.minWords {
+				f.start--
+			}
+		}
+		f.nMatches = countMatches(f.start, f.end)
+		if f.nMatches > best.nMatches {
+			best = f
+		}
+	}
+	if best.nMatches == 0 {
+		return nil
+	}
+	return []headlineFragment{best}
+}
+
+// selectHeadlineFragments chooses up to MaxFragments non-overlapping fragments
+// of the document to display, preferring the ones that contain the most
+// distinct matches. Each cover of the query is trimmed or stretched evenly on
+// both sides to be MaxWords long. The fragments are returned in document
+// order.
+func selectHeadlineFragments(
+	words []headlineWord,
+	covers []tsCover,
+	opts *headlineOptions,
+	countMatches func(start, end int) int,
+) []headlineFragment {
+	candidates := make([]headlineFragment, 0, len(covers))
+	for _, c := range covers {
+		f := headlineFragment{start: c.p, end: c.q}
+		if f.end-f.start+1 > opts.maxWords {
+			f.end = f.start + opts.maxWords - 1
+		} else {
+			extra := opts.maxWords - (f.end - f.start + 1)
+			left := extra / 2
+			f.start -= left
+			if f.start < 1 {
+				f.start = 1
+			}
+			f.end = f.start + opts.maxWords - 1
+			if f.end > len(words) {
+				f.end = len(words)
+				f.start = f.end - opts.maxWords + 1
+				if f.start < 1 {
+					f.start = 1
+				}
+			}
+		}
+		f.nMatches = countMatches(f.start, f.end)
+		candidates = append(candidates, f)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].nMatches > candidates[j].nMatches
+	})
+	var ret []headlineFragment
+	for _, f := range candidates {
+		if len(ret) == opts.maxFragments {
+			break
+		}
+		overlaps := false
+		for _, other := range ret {
+			if f.start <= other.end && other.start <= f.end {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			ret = append(ret, f)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].start < ret[j].start
+	})
+	return ret
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadline(t *testing.T) {
+	const doc = "The most common type of search is to find all documents containing " +
+		"given query terms and return them in order of their similarity to the query."
+	tcs := []struct {
+		query    string
+		options  string
+		expected string
+	}{
+		{
+			query: "query & similarity",
+			expected: "containing given <b>query</b> terms and return them in order of " +
+				"their <b>similarity</b> to the <b>query</b>.",
+		},
+		{
+			query:   "query & similarity",
+			options: `StartSel = <, StopSel = ">"`,
+			expected: "containing given <query> terms and return them in order of " +
+				"their <similarity> to the <query>.",
+		},
+		{
+			query:    "search",
+			options:  "MaxWords=5, MinWords=2",
+			expected: "<b>search</b> is to find",
+		},
+		{
+			query:    "search | similarity",
+			options:  "MaxFragments=2, MaxWords=3, MinWords=1, FragmentDelimiter=...",
+			expected: "of <b>search</b> is...their <b>similarity</b> to",
+		},
+		{
+			query:    "dog",
+			options:  "MaxWords=5, MinWords=3",
+			expected: "The most common",
+		},
+		{
+			query:    "!search & type",
+			options:  "MaxWords=4, MinWords=1, ShortWord=0",
+			expected: "<b>type</b> of search is",
+		},
+		{
+			query:   "query",
+			options: "HighlightAll=true",
+			expected: "The most common type of search is to find all documents containing " +
+				"given <b>query</b> terms and return them in order of their similarity to the <b>query</b>.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query+" "+tc.options, func(t *testing.T) {
+			q, err := ToTSQuery("english", tc.query)
+			require.NoError(t, err)
+			h, err := Headline("english", doc, q, tc.options)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, h)
+		})
+	}
+
+	q, err := ToTSQuery("english", "query")
+	require.NoError(t, err)
+	for options, expectedErr := range map[string]string{
+		"MinWords=10, MaxWords=5": "MinWords should be less than MaxWords",
+		"MinWords=0":              "MinWords should be positive",
+		"MaxFragments=-1":         "MaxFragments should be >= 0",
+		"Foo=1":                   `unrecognized headline parameter: "Foo"`,
+		"MaxWords":                `invalid parameter list format: "MaxWords"`,
+		"MaxWords=ten":            `invalid value for integer option "MaxWords": "ten"`,
+	} {
+		_, err := Headline("english", doc, q, options)
+		require.EqualError(t, err, expectedErr)
+	}
+}
@@ -70,6 +70,9 @@ const (
 	maxTSVectorFollowedBy = 1 << 14
 	// The maximum size of a TSVector lexeme.
 	maxTSVectorLexemeLen = (1 << 14) - 1
+	// The maximum size of a lexeme produced by the text search parser, or
+	// entered as part of a TSVector or TSQuery.
+	maxTSLexemeLen = 2046
 	// The maximum position within a TSVector position list.
 	maxTSVectorPosition = (1 << 14) - 1
 )
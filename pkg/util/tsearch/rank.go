@@ -0,0 +1,426 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements the ts_rank and ts_rank_cd ranking functions. It
+// follows the Postgres implementation in src/backend/utils/adt/tsrank.c.
+
+// defaultRankWeights are the weights assigned to the D, C, B and A weights, in
+// that order, unless otherwise specified.
+var defaultRankWeights = [4]float64{0.1, 0.2, 0.4, 1.0}
+
+// The normalization options of the ranking functions, which control how the
+// rank is adjusted according to the length of the document. They form a bit
+// mask.
+const (
+	// rankNormLogLength divides the rank by 1 + the logarithm of the document
+	// length.
+	rankNormLogLength = 1 << iota
+	// rankNormLength divides the rank by the document length.
+	rankNormLength
+	// rankNormExtDist divides the rank by the mean harmonic distance between
+	// extents. It's only implemented by RankCD.
+	rankNormExtDist
+	// rankNormUniq divides the rank by the number of unique words in the
+	// document.
+	rankNormUniq
+	// rankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	rankNormLogUniq
+	// rankNormRDivRPlus1 divides the rank by itself + 1.
+	rankNormRDivRPlus1
+)
+
+// The maximum distance between two matches that is taken into account by
+// the ranking functions.
+const maxRankEntryPos = 1 << 14
+
+// getRankWeights returns the weights to use for ranking given the user
+// supplied weights, which may be nil. Negative weights are replaced with the
+// defaults.
+func getRankWeights(weights []float64) ([4]float64, error) {
+	ret := defaultRankWeights
+	if weights == nil {
+		return ret, nil
+	}
+	if len(weights) < len(ret) {
+		return ret, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	for i := range ret {
+		if weights[i] >= 0 {
+			ret[i] = weights[i]
+		}
+		if ret[i] > 1.0 {
+			return ret, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+	}
+	return ret, nil
+}
+
+// rankWeight returns the rank weight of the given position.
+func rankWeight(weights *[4]float64, pos tsPosition) float64 {
+	idx, err := pos.weight.TSVectorPGEncoding()
+	if err != nil {
+		// Positions in a TSVector never have more than one weight.
+		return weights[0]
+	}
+	return weights[idx]
+}
+
+// isPrefixTerm returns whether the given query term is a prefix match.
+func isPrefixTerm(q *tsTerm) bool {
+	return len(q.positions) > 0 && q.positions[0].weight&weightStar != 0
+}
+
+// matchingTerms returns the terms of the receiver that match the given query
+// term.
+func (t TSVector) matchingTerms(q *tsTerm) TSVector {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].lexeme >= q.lexeme
+	})
+	j := i
+	if isPrefixTerm(q) {
+		for j < len(t) && strings.HasPrefix(t[j].lexeme, q.lexeme) {
+			j++
+		}
+	} else if j < len(t) && t[j].lexeme == q.lexeme {
+		j++
+	}
+	return t[i:j]
+}
+
+// nullPositions stands in for the position list of a term without positions.
+var nullPositions = []tsPosition{{}}
+
+func termPositions(t tsTerm) []tsPosition {
+	if len(t.positions) == 0 {
+		return nullPositions
+	}
+	return t.positions
+}
+
+// documentLength returns the number of words in the given document.
+func documentLength(v TSVector) int {
+	var ret int
+	for _, t := range v {
+		ret += len(termPositions(t))
+	}
+	return ret
+}
+
+// Rank returns the ts_rank of the given query against the given document,
+// which is based on the frequency of the query's lexemes within the document.
+// weights may be nil, in which case the default weights are used. method is
+// a bit mask of normalization options.
+func Rank(weights []float64, v TSVector, q TSQuery, method int) (float32, error) {
+	w, err := getRankWeights(weights)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || q.root == nil {
+		return 0, nil
+	}
+	var res float64
+	if q.root.op == and || q.root.op == followedby {
+		res = rankAnd(&w, v, q)
+	} else {
+		res = rankOr(&w, v, q)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	if method&rankNormLogLength != 0 {
+		res /= math.Log(float64(documentLength(v)+1)) / math.Log(2.0)
+	}
+	if method&rankNormLength != 0 {
+		if l := documentLength(v); l > 0 {
+			res /= float64(l)
+		}
+	}
+	if method&rankNormUniq != 0 {
+		res /= float64(len(v))
+	}
+	if method&rankNormLogUniq != 0 {
+		res /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&rankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return float32(res), nil
+}
+
+// rankOr ranks queries that require any of their lexemes to be present. Each
+// matching lexeme contributes to the rank according to the weights of its
+// occurrences, with diminishing returns for repeated occurrences.
+func rankOr(w *[4]float64, v TSVector, q TSQuery) float64 {
+	operands := q.operands(true /* includeNegated */)
+	var res float64
+	for _, operand := range operands {
+		for _, t := range v.matchingTerms(operand) {
+			positions := termPositions(t)
+			var resj float64
+			wjm := -1.0
+			jm := 0
+			for j, pos := range positions {
+				wp := rankWeight(w, pos)
+				resj += wp / float64((j+1)*(j+1))
+				if wp > wjm {
+					wjm = wp
+					jm = j
+				}
+			}
+			// The sum of 1/i^2 for i from 1 to infinity is pi^2/6.
+			res += (wjm + resj - wjm/float64((jm+1)*(jm+1))) / 1.64493406685
+		}
+	}
+	if len(operands) > 0 {
+		res /= float64(len(operands))
+	}
+	return res
+}
+
+// rankWordDistance returns the contribution of two matches separated by the
+// given distance to the rank.
+func rankWordDistance(dist int) float64 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return 1.0 / (1.005 + 0.05*math.Exp(float64(dist)/1.5-2))
+}
+
+// rankAnd ranks queries that require all of their lexemes to be present. The
+// rank is based on the distance between the occurrences of each pair of
+// lexemes. It returns a negative number if there were no pairs to rank.
+func rankAnd(w *[4]float64, v TSVector, q TSQuery) float64 {
+	operands := q.operands(true /* includeNegated */)
+	if len(operands) < 2 {
+		return rankOr(w, v, q)
+	}
+	res := -1.0
+	positions := make([][]tsPosition, len(operands))
+	// stripped tracks which of the position lists belong to terms without
+	// positions.
+	stripped := make([]bool, len(operands))
+	for i, operand := range operands {
+		for _, t := range v.matchingTerms(operand) {
+			positions[i] = termPositions(t)
+			stripped[i] = len(t.positions) == 0
+			for k := 0; k < i; k++ {
+				if positions[k] == nil {
+					continue
+				}
+				for _, lPos := range positions[i] {
+					for _, rPos := range positions[k] {
+						dist := int(lPos.position) - int(rPos.position)
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 {
+							if !stripped[i] && !stripped[k] {
+								continue
+							}
+							dist = maxRankEntryPos
+						}
+						curw := math.Sqrt(rankWeight(w, lPos) * rankWeight(w, rPos) * rankWordDistance(dist))
+						if res < 0 {
+							res = curw
+						} else {
+							res = 1.0 - (1.0-res)*(1.0-curw)
+						}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// tsDocEntry is an occurrence of a query operand within a document.
+type tsDocEntry struct {
+	lexeme string
+	pos    tsPosition
+}
+
+// makeDocEntries returns the occurrences within the given document of the
+// lexemes in the given query, ordered by position.
+func makeDocEntries(v TSVector, q TSQuery) []tsDocEntry {
+	type key struct {
+		lexeme string
+		pos    uint16
+	}
+	var ret []tsDocEntry
+	seen := make(map[key]struct{})
+	for _, operand := range q.operands(true /* includeNegated */) {
+		weights := tsWeight(0)
+		if len(operand.positions) > 0 {
+			weights = operand.positions[0].weight &^ weightStar
+		}
+		for _, t := range v.matchingTerms(operand) {
+			for _, pos := range termPositions(t) {
+				if weights != 0 && vectorWeight(pos)&weights == 0 {
+					continue
+				}
+				k := key{lexeme: t.lexeme, pos: pos.position}
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+				ret = append(ret, tsDocEntry{lexeme: t.lexeme, pos: pos})
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].pos.position < ret[j].pos.position
+	})
+	return ret
+}
+
+// tsCover is an extent of a document that satisfies a query.
+type tsCover struct {
+	// begin and end are the indexes of the first and last entries of the cover
+	// within the list of document entries.
+	begin, end int
+	// p and q are the first and last positions of the cover.
+	p, q int
+}
+
+// coverMatches returns whether the given document entries satisfy the query.
+func coverMatches(entries []tsDocEntry, q TSQuery) (bool, error) {
+	v := make(TSVector, len(entries))
+	for i, e := range entries {
+		v[i] = tsTerm{lexeme: e.lexeme, positions: []tsPosition{e.pos}}
+	}
+	return EvalTSQuery(q, sortAndUniqTSVector(v))
+}
+
+// findCovers returns the minimal extents of the document that satisfy the
+// query, in order of position.
+func findCovers(doc []tsDocEntry, q TSQuery) ([]tsCover, error) {
+	var ret []tsCover
+	for start := 0; start < len(doc); {
+		// Find the upper bound of the next cover, moving up from the current
+		// position.
+		c := tsCover{p: math.MaxInt32}
+		found := false
+		for i := start; i < len(doc); i++ {
+			ok, err := coverMatches(doc[start:i+1], q)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				c.q, c.end = int(doc[i].pos.position), i
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		// Find the lower bound of the cover, moving down from the upper bound.
+		i := c.end
+		for ; i >= start; i-- {
+			ok, err := coverMatches(doc[i:c.end+1], q)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				c.p, c.begin = int(doc[i].pos.position), i
+				break
+			}
+		}
+		if c.p <= c.q {
+			ret = append(ret, c)
+			start = i + 1
+		} else {
+			start++
+		}
+	}
+	return ret, nil
+}
+
+// RankCD returns the ts_rank_cd of the given query against the given
+// document, which is based on the cover density of the query's lexemes within
+// the document: the length of the extents of the document that satisfy the
+// query, and the number of other words within them. weights may be nil, in
+// which case the default weights are used. method is a bit mask of
+// normalization options.
+func RankCD(weights []float64, v TSVector, q TSQuery, method int) (float32, error) {
+	w, err := getRankWeights(weights)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || q.root == nil {
+		return 0, nil
+	}
+	var invWeights [4]float64
+	for i := range w {
+		invWeights[i] = 1.0 / w[i]
+	}
+	doc := makeDocEntries(v, q)
+	if len(doc) == 0 {
+		return 0, nil
+	}
+	covers, err := findCovers(doc, q)
+	if err != nil {
+		return 0, err
+	}
+	var res, sumDist, prevExtPos float64
+	for i, c := range covers {
+		var invSum float64
+		for _, e := range doc[c.begin : c.end+1] {
+			invSum += rankWeight(&invWeights, e.pos)
+		}
+		cPos := float64(c.end-c.begin+1) / invSum
+		// If the document is big enough, q may be equal to p due to the limit on
+		// positional information. In that case, approximate the number of noise
+		// words as half of the cover's length.
+		nNoise := (c.q - c.p) - (c.end - c.begin)
+		if nNoise < 0 {
+			nNoise = (c.end - c.begin) / 2
+		}
+		res += cPos / float64(1+nNoise)
+		curExtPos := float64(c.q+c.p) / 2
+		if i > 0 && curExtPos > prevExtPos {
+			sumDist += 1.0 / (curExtPos - prevExtPos)
+		}
+		prevExtPos = curExtPos
+	}
+	if method&rankNormLogLength != 0 {
+		res /= math.Log(float64(documentLength(v) + 1))
+	}
+	if method&rankNormLength != 0 {
+		if l := documentLength(v); l > 0 {
+			res /= float64(l)
+		}
+	}
+	if method&rankNormExtDist != 0 && len(covers) > 0 && sumDist > 0 {
+		res /= float64(len(covers)) / sumDist
+	}
+	if method&rankNormUniq != 0 {
+		res /= float64(len(v))
+	}
+	if method&rankNormLogUniq != 0 {
+		res /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&rankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return float32(res), nil
+}
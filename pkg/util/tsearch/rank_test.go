@@ -0,0 +1,88 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	const doc = "a fat cat sat on a mat and ate a fat rat"
+	tcs := []struct {
+		weights  []float64
+		query    string
+		method   int
+		rank     float32
+		rankCD   float32
+		rankLess string
+	}{
+		{query: "cat", rank: 0.0607927, rankCD: 0.1},
+		{query: "fat", rank: 0.0759909, rankCD: 0.2},
+		{query: "dog", rank: 0, rankCD: 0},
+		{query: "cat | dog", rank: 0.0303964, rankCD: 0.1},
+		{query: "fat & rat", rank: 0.134933, rankCD: 0.1},
+		{query: "fat <-> rat", rank: 0.134933, rankCD: 0.1},
+		{query: "cat & rat", rank: 0.0517440, rankCD: 0.0111111},
+		{query: "cat", method: rankNormLength, rank: 0.00868467, rankCD: 0.0142857},
+		{query: "cat", method: rankNormRDivRPlus1, rank: 0.0573088, rankCD: 0.0909091},
+		{weights: []float64{1, 1, 1, 1}, query: "cat", rank: 0.607927, rankCD: 1},
+		{weights: []float64{-1, 0.5, 0.5, 0.5}, query: "cat", rank: 0.0607927, rankCD: 0.1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			v, err := DocumentToTSVector("english", doc)
+			require.NoError(t, err)
+			q, err := ToTSQuery("english", tc.query)
+			require.NoError(t, err)
+			rank, err := Rank(tc.weights, v, q, tc.method)
+			require.NoError(t, err)
+			assert.InDelta(t, tc.rank, rank, 1e-6)
+			rankCD, err := RankCD(tc.weights, v, q, tc.method)
+			require.NoError(t, err)
+			assert.InDelta(t, tc.rankCD, rankCD, 1e-6)
+		})
+	}
+
+	// Closer matches rank higher.
+	near, err := ParseTSVector("fat:1 rat:2")
+	require.NoError(t, err)
+	far, err := ParseTSVector("fat:1 rat:20")
+	require.NoError(t, err)
+	q, err := ParseTSQuery("fat & rat")
+	require.NoError(t, err)
+	nearRank, err := Rank(nil, near, q, 0)
+	require.NoError(t, err)
+	farRank, err := Rank(nil, far, q, 0)
+	require.NoError(t, err)
+	assert.Greater(t, nearRank, farRank)
+	nearRank, err = RankCD(nil, near, q, 0)
+	require.NoError(t, err)
+	farRank, err = RankCD(nil, far, q, 0)
+	require.NoError(t, err)
+	assert.Greater(t, nearRank, farRank)
+
+	// Higher weights rank higher.
+	weighted, err := ParseTSVector("fat:1A rat:2")
+	require.NoError(t, err)
+	weightedRank, err := Rank(nil, weighted, q, 0)
+	require.NoError(t, err)
+	nearRank, err = Rank(nil, near, q, 0)
+	require.NoError(t, err)
+	assert.Greater(t, weightedRank, nearRank)
+
+	_, err = Rank([]float64{0.1, 0.2}, near, q, 0)
+	require.EqualError(t, err, "array of weight is too short")
+	_, err = Rank([]float64{0.1, 0.2, 0.3, 1.5}, near, q, 0)
+	require.EqualError(t, err, "weight out of range")
+}
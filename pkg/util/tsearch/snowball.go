@@ -0,0 +1,416 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+// This file implements the English Snowball stemmer, also known as the Porter2
+// stemmer, which is what Postgres uses for its english_stem dictionary.
+// See https://snowballstem.org/algorithms/english/stemmer.html for the
+// definition of the algorithm, which this implementation follows step by step.
+
+// englishStemExceptions are words that are either left alone or stemmed to a
+// fixed form before any of the stemming steps run.
+var englishStemExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// englishStemPostStep1aExceptions are words that are left alone if they are
+// the result of step 1a.
+var englishStemPostStep1aExceptions = map[string]struct{}{
+	"inning":  {},
+	"outing":  {},
+	"canning": {},
+	"herring": {},
+	"earring": {},
+	"proceed": {},
+	"exceed":  {},
+	"succeed": {},
+}
+
+// englishStemmer holds the state of a single word being stemmed.
+type englishStemmer struct {
+	w []rune
+	// r1 and r2 are the start indexes of the R1 and R2 regions of w. They are
+	// computed once, before any suffixes are removed, so a region is empty if
+	// its start index is at or past the end of w.
+	r1, r2 int
+}
+
+// stemEnglish returns the stem of the given lowercase English word.
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	if stem, ok := englishStemExceptions[word]; ok {
+		return stem
+	}
+	s := englishStemmer{w: []rune(word)}
+	if s.w[0] == '\'' {
+		s.w = s.w[1:]
+	}
+	s.markConsonantYs()
+	s.computeRegions()
+	s.step0()
+	s.step1a()
+	if _, ok := englishStemPostStep1aExceptions[string(s.w)]; ok {
+		return string(s.w)
+	}
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	for i, r := range s.w {
+		if r == 'Y' {
+			s.w[i] = 'y'
+		}
+	}
+	return string(s.w)
+}
+
+func isEnglishVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// markConsonantYs replaces each y that is at the start of the word or that
+// follows a vowel with Y, so that it is treated as a consonant.
+func (s *englishStemmer) markConsonantYs() {
+	for i, r := range s.w {
+		if r == 'y' && (i == 0 || isEnglishVowel(s.w[i-1])) {
+			s.w[i] = 'Y'
+		}
+	}
+}
+
+// regionAfter returns the index of the region after the first non-vowel that
+// follows a vowel at or after the given start index.
+func (s *englishStemmer) regionAfter(start int) int {
+	for i := start + 1; i < len(s.w); i++ {
+		if !isEnglishVowel(s.w[i]) && isEnglishVowel(s.w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(s.w)
+}
+
+func (s *englishStemmer) computeRegions() {
+	s.r1 = -1
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if s.hasPrefix(prefix) {
+			s.r1 = len(prefix)
+			break
+		}
+	}
+	if s.r1 < 0 {
+		s.r1 = s.regionAfter(0)
+	}
+	s.r2 = s.regionAfter(s.r1)
+}
+
+func (s *englishStemmer) hasPrefix(prefix string) bool {
+	p := []rune(prefix)
+	if len(p) > len(s.w) {
+		return false
+	}
+	for i := range p {
+		if s.w[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *englishStemmer) hasSuffix(suffix string) bool {
+	p := []rune(suffix)
+	if len(p) > len(s.w) {
+		return false
+	}
+	off := len(s.w) - len(p)
+	for i := range p {
+		if s.w[off+i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// longestSuffix returns the longest of the given suffixes that the word ends
+// with, or the empty string if there is none.
+func (s *englishStemmer) longestSuffix(suffixes ...string) string {
+	var ret string
+	for _, suffix := range suffixes {
+		if len(suffix) > len(ret) && s.hasSuffix(suffix) {
+			ret = suffix
+		}
+	}
+	return ret
+}
+
+// suffixStart returns the index at which the given suffix of the word starts.
+func (s *englishStemmer) suffixStart(suffix string) int {
+	return len(s.w) - len([]rune(suffix))
+}
+
+func (s *englishStemmer) replaceSuffix(suffix, replacement string) {
+	s.w = append(s.w[:s.suffixStart(suffix)], []rune(replacement)...)
+}
+
+// containsVowel returns whether the word contains a vowel before the given
+// index.
+func (s *englishStemmer) containsVowel(end int) bool {
+	if end <= 0 {
+		return false
+	}
+	for _, r := range s.w[:end] {
+		if isEnglishVowel(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsInShortSyllable returns whether the word ends in a short syllable: a
+// vowel followed by a non-vowel other than w, x or Y and preceded by a
+// non-vowel, or a vowel at the beginning of the word followed by a non-vowel.
+func (s *englishStemmer) endsInShortSyllable() bool {
+	n := len(s.w)
+	if n == 2 {
+		return isEnglishVowel(s.w[0]) && !isEnglishVowel(s.w[1])
+	}
+	if n < 3 {
+		return false
+	}
+	last := s.w[n-1]
+	return !isEnglishVowel(s.w[n-3]) && isEnglishVowel(s.w[n-2]) &&
+		!isEnglishVowel(last) && last != 'w' && last != 'x' && last != 'Y'
+}
+
+// isShort returns whether the word is short: it ends in a short syllable and
+// R1 is empty.
+func (s *englishStemmer) isShort() bool {
+	return s.r1 >= len(s.w) && s.endsInShortSyllable()
+}
+
+func (s *englishStemmer) step0() {
+	if suffix := s.longestSuffix("'s'", "'s", "'"); suffix != "" {
+		s.replaceSuffix(suffix, "")
+	}
+}
+
+func (s *englishStemmer) step1a() {
+	switch suffix := s.longestSuffix("sses", "ied", "ies", "s", "us", "ss"); suffix {
+	case "sses":
+		s.replaceSuffix(suffix, "ss")
+	case "ied", "ies":
+		if s.suffixStart(suffix) > 1 {
+			s.replaceSuffix(suffix, "i")
+		} else {
+			s.replaceSuffix(suffix, "ie")
+		}
+	case "s":
+		// Delete the s if the preceding word part contains a vowel that is not
+		// immediately before the s.
+		if s.containsVowel(len(s.w) - 2) {
+			s.replaceSuffix(suffix, "")
+		}
+	}
+}
+
+func (s *englishStemmer) step1b() {
+	switch suffix := s.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); suffix {
+	case "":
+	case "eed", "eedly":
+		if s.suffixStart(suffix) >= s.r1 {
+			s.replaceSuffix(suffix, "ee")
+		}
+	default:
+		if !s.containsVowel(s.suffixStart(suffix)) {
+			return
+		}
+		s.replaceSuffix(suffix, "")
+		switch {
+		case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+			s.w = append(s.w, 'e')
+		case s.endsInDouble():
+			s.w = s.w[:len(s.w)-1]
+		case s.isShort():
+			s.w = append(s.w, 'e')
+		}
+	}
+}
+
+func (s *englishStemmer) endsInDouble() bool {
+	for _, double := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if s.hasSuffix(double) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *englishStemmer) step1c() {
+	n := len(s.w)
+	if n > 2 && (s.w[n-1] == 'y' || s.w[n-1] == 'Y') && !isEnglishVowel(s.w[n-2]) {
+		s.w[n-1] = 'i'
+	}
+}
+
+var englishStep2Suffixes = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+var englishStep2SuffixList = mapKeys(englishStep2Suffixes)
+
+func (s *englishStemmer) step2() {
+	suffix := s.longestSuffix(englishStep2SuffixList...)
+	if suffix == "" || s.suffixStart(suffix) < s.r1 {
+		return
+	}
+	start := s.suffixStart(suffix)
+	switch suffix {
+	case "ogi":
+		if start == 0 || s.w[start-1] != 'l' {
+			return
+		}
+	case "li":
+		if start == 0 || !isValidLiEnding(s.w[start-1]) {
+			return
+		}
+	}
+	s.replaceSuffix(suffix, englishStep2Suffixes[suffix])
+}
+
+func isValidLiEnding(r rune) bool {
+	switch r {
+	case 'c', 'd', 'e', 'g', 'h', 'k', 'm', 'n', 'r', 't':
+		return true
+	}
+	return false
+}
+
+var englishStep3Suffixes = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+var englishStep3SuffixList = mapKeys(englishStep3Suffixes)
+
+func (s *englishStemmer) step3() {
+	suffix := s.longestSuffix(englishStep3SuffixList...)
+	if suffix == "" || s.suffixStart(suffix) < s.r1 {
+		return
+	}
+	if suffix == "ative" && s.suffixStart(suffix) < s.r2 {
+		return
+	}
+	s.replaceSuffix(suffix, englishStep3Suffixes[suffix])
+}
+
+var englishStep4Suffixes = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+}
+
+func (s *englishStemmer) step4() {
+	suffix := s.longestSuffix(englishStep4Suffixes...)
+	if suffix == "" || s.suffixStart(suffix) < s.r2 {
+		return
+	}
+	if suffix == "ion" {
+		start := s.suffixStart(suffix)
+		if start == 0 || (s.w[start-1] != 's' && s.w[start-1] != 't') {
+			return
+		}
+	}
+	s.replaceSuffix(suffix, "")
+}
+
+func (s *englishStemmer) step5() {
+	n := len(s.w)
+	if n == 0 {
+		return
+	}
+	switch s.w[n-1] {
+	case 'e':
+		if n-1 >= s.r2 {
+			s.w = s.w[:n-1]
+		} else if n-1 >= s.r1 {
+			// Only delete the e if it isn't preceded by a short syllable.
+			s.w = s.w[:n-1]
+			if s.endsInShortSyllable() {
+				s.w = append(s.w, 'e')
+			}
+		}
+	case 'l':
+		if n-1 >= s.r2 && n > 1 && s.w[n-2] == 'l' {
+			s.w = s.w[:n-1]
+		}
+	}
+}
+
+func mapKeys(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "strings"
+
+// englishStopWords is the list of stop words used by the english text search
+// configuration. It matches Postgres's english.stop file.
+var englishStopWords = makeStopWords(`
+i me my myself we our ours ourselves you your yours yourself yourselves he him
+his himself she her hers herself it its itself they them their theirs
+themselves what which who whom this that these those am is are was were be
+been being have has had having do does did doing a an the and but if or
+because as until while of at by for with about against between into through
+during before after above below to from up down in out on off over under again
+further then once here there when where why how all any both each few more most
+other some such no nor not only own same so than too very s t can will just
+don should now
+`)
+
+func makeStopWords(words string) map[string]struct{} {
+	fields := strings.Fields(words)
+	ret := make(map[string]struct{}, len(fields))
+	for _, w := range fields {
+		ret[w] = struct{}{}
+	}
+	return ret
+}
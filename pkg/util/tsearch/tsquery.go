@@ -304,3 +304,98 @@ func (p *tsQueryParser) parseTSExpr(minBindingPower int) (*tsNode, error) {
 func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 	return nil, pgerror.Newf(pgcode.Syntax, "syntax error in TSQuery: %s", p.input)
 }
+
+// NumNodes returns the number of lexemes and operators in the query.
+func (q TSQuery) NumNodes() int {
+	var count func(n *tsNode) int
+	count = func(n *tsNode) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + count(n.l) + count(n.r)
+	}
+	return count(q.root)
+}
+
+// QueryTree returns the portion of the query that can be used for searching
+// an index, which excludes negated terms. It returns "T" if no portion of the
+// query can be used.
+func (q TSQuery) QueryTree() string {
+	var clean func(n *tsNode) *tsNode
+	clean = func(n *tsNode) *tsNode {
+		if n == nil {
+			return nil
+		}
+		switch n.op {
+		case invalid:
+			return n
+		case not:
+			return nil
+		case or:
+			// Both sides of a | operator must be searchable, since either of them
+			// could produce a match.
+			l, r := clean(n.l), clean(n.r)
+			if l == nil || r == nil {
+				return nil
+			}
+			return &tsNode{op: or, l: l, r: r}
+		}
+		l, r := clean(n.l), clean(n.r)
+		if l == nil || r == nil {
+			return joinNodes(n.op, l, r)
+		}
+		return &tsNode{op: n.op, followedN: n.followedN, l: l, r: r}
+	}
+	root := clean(q.root)
+	if root == nil {
+		return "T"
+	}
+	return root.String()
+}
+
+// TSQueryPhrase returns a query that requires the right query to follow the
+// left query by the given distance.
+func TSQueryPhrase(l, r TSQuery, distance int) (TSQuery, error) {
+	if distance < 0 || distance > maxTSVectorFollowedBy {
+		return TSQuery{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"distance in phrase operator must be an integer value between zero and %d inclusive",
+			maxTSVectorFollowedBy)
+	}
+	if l.root == nil {
+		return r, nil
+	}
+	if r.root == nil {
+		return l, nil
+	}
+	return TSQuery{root: makeFollowedByNode(l.root, r.root, distance)}, nil
+}
+
+// operands returns the distinct leaf terms of the query. Terms that appear
+// under a ! operator are only included if includeNegated is true.
+func (q TSQuery) operands(includeNegated bool) []*tsTerm {
+	var ret []*tsTerm
+	seen := make(map[string]struct{})
+	var walk func(n *tsNode)
+	walk = func(n *tsNode) {
+		if n == nil {
+			return
+		}
+		switch n.op {
+		case invalid:
+			key := n.term.String()
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				ret = append(ret, &n.term)
+			}
+			return
+		case not:
+			if !includeNegated {
+				return
+			}
+		}
+		walk(n.l)
+		walk(n.r)
+	}
+	walk(q.root)
+	return ret
+}
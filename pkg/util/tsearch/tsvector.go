@@ -145,8 +145,8 @@ type tsTerm struct {
 }
 
 func newLexemeTerm(lexeme string) (tsTerm, error) {
-	if len(lexeme) > 2046 {
-		return tsTerm{}, pgerror.Newf(pgcode.ProgramLimitExceeded, "word is too long (%d bytes, max 2046 bytes)", len(lexeme))
+	if len(lexeme) > maxTSLexemeLen {
+		return tsTerm{}, pgerror.Newf(pgcode.ProgramLimitExceeded, "word is too long (%d bytes, max %d bytes)", len(lexeme), maxTSLexemeLen)
 	}
 	return tsTerm{lexeme: lexeme}, nil
 }
@@ -223,7 +223,12 @@ func ParseTSVector(input string) (TSVector, error) {
 	if err != nil {
 		return ret, err
 	}
+	return sortAndUniqTSVector(ret), nil
+}
 
+// sortAndUniqTSVector sorts the input TSVector by lexeme, merging the position
+// lists of duplicate lexemes.
+func sortAndUniqTSVector(ret TSVector) TSVector {
 	if len(ret) > 1 {
 		// Sort and de-duplicate the resultant TSVector.
 		sort.Slice(ret, func(i, j int) bool {
@@ -255,5 +260,177 @@ func ParseTSVector(input string) (TSVector, error) {
 		lastIdx := len(ret) - 1
 		ret[lastIdx].positions = sortAndUniqTSPositions(ret[lastIdx].positions)
 	}
+	return ret
+}
+
+// parseVectorWeight parses a weight specified as one of the letters A-D.
+func parseVectorWeight(weight string) (tsWeight, error) {
+	switch weight {
+	case "A", "a":
+		return weightA, nil
+	case "B", "b":
+		return weightB, nil
+	case "C", "c":
+		return weightC, nil
+	case "D", "d":
+		// Weight D is the default, so it's not explicitly stored.
+		return 0, nil
+	}
+	return invalidWeight, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", weight)
+}
+
+// vectorWeight returns the weight of the given position, mapping the implicit
+// default weight to weightD.
+func vectorWeight(pos tsPosition) tsWeight {
+	if pos.weight == 0 {
+		return weightD
+	}
+	return pos.weight
+}
+
+// SetWeight returns a copy of the receiver in which every position is
+// assigned the given weight. If lexemes is non-nil, only the positions of the
+// given lexemes are modified.
+func (t TSVector) SetWeight(weight string, lexemes []string) (TSVector, error) {
+	w, err := parseVectorWeight(weight)
+	if err != nil {
+		return nil, err
+	}
+	var filter map[string]struct{}
+	if lexemes != nil {
+		filter = make(map[string]struct{}, len(lexemes))
+		for _, l := range lexemes {
+			filter[l] = struct{}{}
+		}
+	}
+	ret := make(TSVector, len(t))
+	for i, term := range t {
+		ret[i] = term
+		if _, ok := filter[term.lexeme]; filter != nil && !ok {
+			continue
+		}
+		ret[i].positions = make([]tsPosition, len(term.positions))
+		for j, pos := range term.positions {
+			ret[i].positions[j] = tsPosition{position: pos.position, weight: w}
+		}
+	}
+	return ret, nil
+}
+
+// StripPositions returns a copy of the receiver without any positions or
+// weights.
+func (t TSVector) StripPositions() TSVector {
+	ret := make(TSVector, len(t))
+	for i, term := range t {
+		ret[i] = tsTerm{lexeme: term.lexeme}
+	}
+	return ret
+}
+
+// Delete returns a copy of the receiver without the given lexemes.
+func (t TSVector) Delete(lexemes []string) TSVector {
+	ret := make(TSVector, 0, len(t))
+	for _, term := range t {
+		found := false
+		for _, l := range lexemes {
+			if term.lexeme == l {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, term)
+		}
+	}
+	return ret
+}
+
+// Filter returns a copy of the receiver that only contains the positions that
+// have one of the given weights. Lexemes that are left without any positions
+// are removed.
+func (t TSVector) Filter(weights []string) (TSVector, error) {
+	var mask tsWeight
+	for _, weight := range weights {
+		w, err := parseVectorWeight(weight)
+		if err != nil {
+			return nil, err
+		}
+		if w == 0 {
+			w = weightD
+		}
+		mask |= w
+	}
+	ret := make(TSVector, 0, len(t))
+	for _, term := range t {
+		var positions []tsPosition
+		for _, pos := range term.positions {
+			if vectorWeight(pos)&mask != 0 {
+				positions = append(positions, pos)
+			}
+		}
+		if len(positions) > 0 {
+			ret = append(ret, tsTerm{lexeme: term.lexeme, positions: positions})
+		}
+	}
 	return ret, nil
 }
+
+// Concat returns the concatenation of the receiver and the given TSVector.
+// The positions of the second vector are shifted so that they follow the
+// largest position in the receiver.
+func (t TSVector) Concat(other TSVector) TSVector {
+	var maxPos int
+	for _, term := range t {
+		for _, pos := range term.positions {
+			if int(pos.position) > maxPos {
+				maxPos = int(pos.position)
+			}
+		}
+	}
+	ret := make(TSVector, 0, len(t)+len(other))
+	for _, term := range t {
+		ret = append(ret, tsTerm{
+			lexeme:    term.lexeme,
+			positions: append([]tsPosition(nil), term.positions...),
+		})
+	}
+	for _, term := range other {
+		positions := make([]tsPosition, len(term.positions))
+		for i, pos := range term.positions {
+			p := int(pos.position) + maxPos
+			if p > maxTSVectorPosition {
+				p = maxTSVectorPosition
+			}
+			positions[i] = tsPosition{position: uint16(p), weight: pos.weight}
+		}
+		ret = append(ret, tsTerm{lexeme: term.lexeme, positions: positions})
+	}
+	return sortAndUniqTSVector(ret)
+}
+
+// Lexemes returns the lexemes of the receiver, in sorted order.
+func (t TSVector) Lexemes() []string {
+	ret := make([]string, len(t))
+	for i, term := range t {
+		ret[i] = term.lexeme
+	}
+	return ret
+}
+
+// TSVectorFromLexemes returns a TSVector without positions that contains the
+// given lexemes.
+func TSVectorFromLexemes(lexemes []string) (TSVector, error) {
+	ret := make(TSVector, 0, len(lexemes))
+	for _, l := range lexemes {
+		if l == "" {
+			return nil, pgerror.New(pgcode.ZeroLengthCharacterString,
+				"lexeme array may not contain empty strings")
+		}
+		term, err := newLexemeTerm(l)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, term)
+	}
+	return sortAndUniqTSVector(ret), nil
+}